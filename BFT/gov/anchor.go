@@ -16,6 +16,8 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+
+	"merkle"
 )
 
 // 개인키, 공개키 자동 생성 (최초 실행 시)
@@ -48,7 +50,7 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 		Sig     string `json:"sig"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON", 400)
+		writeError(w, 400, "invalid JSON")
 		return
 	}
 	defer r.Body.Close()
@@ -56,7 +58,7 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 	// Hos의 공개키 가져오기
 	resp, err := http.Get("http://" + req.HosBoot + "/getPublicKey")
 	if err != nil {
-		http.Error(w, "failed to fetch public key", 500)
+		writeError(w, 500, "failed to fetch public key")
 		return
 	}
 	defer resp.Body.Close()
@@ -87,14 +89,14 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 	var sigStruct ecdsaSignature
 	_, err = asn1.Unmarshal(sigBytes, &sigStruct)
 	if err != nil {
-		http.Error(w, "invalid signature format", 403)
+		writeError(w, 403, "invalid signature format")
 		return
 	}
 
 	valid := ecdsa.Verify(pubKey, hash[:], sigStruct.R, sigStruct.S)
 
 	if !valid {
-		http.Error(w, "invalid signature", 403)
+		writeError(w, 403, "invalid signature")
 		log.Printf("[ANCHOR][INVALID] rejected from %s", req.HosID)
 		return
 	}
@@ -108,8 +110,9 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 		AnchorTimestamp:  req.Ts,
 	}
 
-	// pending 에 anchor 객체 전체 추가
+	// pending 에 anchor 객체 전체 추가 후 다른 노드 메모리풀로 중계 (proposer.go)
 	appendPending([]AnchorRecord{ar})
+	relayPending([]AnchorRecord{ar})
 	log.Printf("[ANCHOR] Pending anchor added: %+v", ar)
 
 	// AnchorRoot LevelDB 저장
@@ -223,7 +226,7 @@ func verifyHosResults(hosID string, items []SearchResponse) ([]SearchResponse, e
 		}
 
 		// 키워드가 포함된 블록의 Merkle 증명을 통한 유효성 검증
-		if verifyMerkleProof(it.Leaf, it.Proof, it.BlockRoot) {
+		if merkle.Verify(it.Leaf, it.Proof, it.BlockRoot) {
 			verified = append(verified, it)
 			logInfo("[QUERY][SUCCESS] Verified Record Appended")
 		}
@@ -24,22 +24,22 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 	// GET /block/index?id=<int>
 	mux.HandleFunc("/block/index", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		q := r.URL.Query().Get("id")
 		if q == "" {
-			http.Error(w, "id parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id parameter required")
 			return
 		}
 		idx, err := strconv.Atoi(q)
 		if err != nil {
-			http.Error(w, "id must be integer", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id must be integer")
 			return
 		}
 		blk, err := getBlockByIndex(idx)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
 		writeJSON(w, http.StatusOK, blk)
@@ -49,17 +49,17 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 	// GET /block/hash?value=<hash>
 	mux.HandleFunc("/block/hash", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		hash := r.URL.Query().Get("value")
 		if hash == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
 			return
 		}
 		blk, err := getBlockByHash(hash)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
 		writeJSON(w, http.StatusOK, blk)
@@ -69,7 +69,7 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 	// GET /blocks?offset=<int>&limit=<int>
 	mux.HandleFunc("/blocks", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
@@ -79,7 +79,7 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 		}
 		blocks, total, err := listBlocksPaginated(offset, limit)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("list blocks error: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("list blocks error: %v", err))
 			return
 		}
 		writeJSON(w, http.StatusOK, map[string]any{
@@ -108,6 +108,7 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 		writeJSON(w, http.StatusOK, map[string]any{
 			"addr":       self,
 			"height":     h,
+			"proposer":   nextProposer(),
 			"is_boot":    isBoot.Load(),
 			"bootAddr":   boot,
 			"started_at": startedAt.Format(time.RFC3339),
@@ -129,7 +130,7 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 	// GET /query?hos_id=<id>&keyword=<keyword>
 	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 
@@ -137,7 +138,7 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 		kw := r.URL.Query().Get("keyword")
 
 		if hosID == "" || kw == "" {
-			http.Error(w, "hos_id and keyword required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "hos_id and keyword required")
 			return
 		}
 		logInfo("[QUERY] Target Hos Chain: %s, Keyword: %s", hosID, kw)
@@ -145,7 +146,7 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 		// 쿼리 검색 수행 후 반환
 		resultBytes, status, err := handleHosSearch(hosID, kw)
 		if err != nil {
-			http.Error(w, err.Error(), status)
+			writeError(w, status, err.Error())
 			return
 		}
 
@@ -2,27 +2,69 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"sync"
 	"time"
+
+	"merkle"
 )
 
 // Gov BFT 합의 수집기 (AnchorRecord 기반)
 type consensusCollector struct {
 	mu         sync.Mutex
-	signatures []string
+	signatures []ConsensusSig
 	votedPeers map[string]bool
 }
 
+// view : 합의 대상 블록 높이(height+1)
+// - 겹치는 제안(다른 view)이 서로의 블록/투표를 덮어쓰지 않도록 view 별로 상태 관리
+type viewState struct {
+	mu        sync.Mutex
+	Phase     int32
+	Block     UpperBlock
+	Prepare   *consensusCollector
+	Commit    *consensusCollector
+	Finalized bool
+	Records   []AnchorRecord // 이 노드가 메모리풀에서 꺼내 제안한 앵커 (확정 못 하고 정리되면 복구)
+	PhaseAt   time.Time      // 현재 단계 진입 시각 (단계 제한시간 기준)
+}
+
 var (
-	prepareCollector *consensusCollector
-	commitCollector  *consensusCollector
-	collectorMu      sync.Mutex
-	currentBlock     UpperBlock //
+	viewStates = make(map[int]*viewState)
+	viewMu     sync.Mutex
 )
 
+const (
+	ViewGCInterval = 5  // 오래된 view 정리 주기(초)
+	ViewStaleAfter = 30 // 한 단계에서 정족수가 모이지 않은 view 를 버리는 기준(초)
+)
+
+func getOrCreateView(view int) *viewState {
+	viewMu.Lock()
+	defer viewMu.Unlock()
+	vs, ok := viewStates[view]
+	if !ok {
+		vs = &viewState{Phase: ConsIdle, Prepare: newCollector(), Commit: newCollector(), PhaseAt: time.Now()}
+		viewStates[view] = vs
+	}
+	return vs
+}
+
+// view 정리 (진행 중인 view 가 없으면 합의 단계를 대기 상태로)
+func deleteView(view int) {
+	viewMu.Lock()
+	delete(viewStates, view)
+	idle := len(viewStates) == 0
+	viewMu.Unlock()
+	if idle {
+		ConsPhase.Store(ConsIdle)
+	}
+}
+
 // 1. WATCHER: 수집된 앵커(Pending)가 있으면 리더가 제안 시작 (Pre-Prepare)
 func startMiningWatcher() {
 	t := time.NewTicker(time.Duration(ConsWatcherTime) * time.Second) //
@@ -34,8 +76,8 @@ func startMiningWatcher() {
 			continue
 		}
 
-		// Gov 체인의 부트노드가 리더 역할을 수행
-		if self != boot {
+		// 이번 view(높이+1)의 제안자가 리더 역할을 수행 (proposer.go)
+		if nextProposer() != self {
 			continue
 		}
 
@@ -47,9 +89,12 @@ func startMiningWatcher() {
 
 		// UpperBlock 생성 및 리더 서명
 		newBlock := createProposedBlock(records)
-		currentBlock = newBlock
-
-		initCollectors()
+		vs := getOrCreateView(newBlock.Index)
+		vs.mu.Lock()
+		vs.Block = newBlock
+		vs.setPhase(ConsPrePrepare)
+		vs.Records = records
+		vs.mu.Unlock()
 
 		// 모든 Gov 노드에 Pre-Prepare 알림 전파
 		broadcastToAll("/bft/start", newBlock)
@@ -60,11 +105,14 @@ func startMiningWatcher() {
 func handleBftStart(w http.ResponseWriter, r *http.Request) {
 	var ub UpperBlock
 	if err := json.NewDecoder(r.Body).Decode(&ub); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
 		return
 	}
 
-	// 단계 보호 및 Gov 체인 연결성 검증
-	if !ConsPhase.CompareAndSwap(ConsIdle, ConsPrepare) {
+	// 해당 view 의 제안자가 만든 블록만 수용 (proposer.go)
+	if expected := leaderFor(ub.Index); ub.Proposer != expected {
+		log.Printf("[BFT-VALIDATE] Reject Gov block #%d from non-proposer %s (expected=%s)", ub.Index, ub.Proposer, expected)
+		writeErrorDetail(w, http.StatusForbidden, "not_leader", "block is not from the view proposer", map[string]string{"expected": expected})
 		return
 	}
 
@@ -74,56 +122,187 @@ func handleBftStart(w http.ResponseWriter, r *http.Request) {
 	// Gov 체인용 검증 로직 (Index, PrevHash 등 확인)
 	if ub.Index != prev.Index+1 || ub.PrevHash != prev.BlockHash {
 		log.Printf("[BFT-VALIDATE] Gov Block Sequence Error")
-		ConsPhase.Store(ConsIdle)
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "invalid_block", "block does not extend local tip", nil)
 		return
 	}
 
-	currentBlock = ub
-	myPriv, _ := getMeta("meta_hos_privkey")               // Gov 노드 개인키 로드
-	mySig := makeAnchorSignature(myPriv, ub.BlockHash, "") //
+	// 단계 보호 : 같은 view 에서는 한 블록만 (리더 자신은 제안한 블록으로 Pre-Prepare 상태)
+	vs := getOrCreateView(ub.Index)
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	if vs.Phase > ConsPrePrepare || (vs.Block.BlockHash != "" && vs.Block.BlockHash != ub.BlockHash) {
+		writeErrorDetail(w, http.StatusConflict, "view_in_progress", "consensus already in progress for this view", nil)
+		return
+	}
+	vs.Block = ub
+	vs.setPhase(ConsPrepare)
 
 	log.Printf("[BFT-NODE] Phase: Prepare | Gov Index: %d", ub.Index)
-	broadcastToAll("/bft/prepare", map[string]string{"addr": self, "sig": mySig})
+	broadcastToAll("/bft/prepare", signedVote(PhaseNamePrepare, ub))
 	w.WriteHeader(http.StatusOK)
 }
 
+// 합의 메시지 단계 (투표 인증 다이제스트)
+const (
+	PhaseNamePrepare = "prepare"
+	PhaseNameCommit  = "commit"
+)
+
+// prepare/commit 투표 (view 와 블록 해시로 대상 구분)
+// - Sig : 블록 해시 서명 => 블록 합의 증거(Signatures)에 그대로 사용
+// - Auth : 단계/view/블록 해시 다이제스트 서명 => 이전 view/다른 단계의 서명 재전송 차단
+type voteMsg struct {
+	View int    `json:"view"`
+	Hash string `json:"hash"`
+	Addr string `json:"addr"`
+	Sig  string `json:"sig"`
+	Auth string `json:"auth"`
+}
+
+// 투표 인증 다이제스트 (hex)
+// - 이 트리에는 view-change(라운드)가 없으므로 단계/view/블록 해시를 함께 서명
+func voteDigest(phase string, view int, hash string) string {
+	return sha256Hex([]byte(fmt.Sprintf("bft|%s|%d|%s", phase, view, hash)))
+}
+
+// 내 키로 서명한 prepare/commit 투표
+func signedVote(phase string, ub UpperBlock) voteMsg {
+	myPriv, _ := getMeta("meta_hos_privkey") // Gov 노드 개인키 로드
+	return voteMsg{
+		View: ub.Index,
+		Hash: ub.BlockHash,
+		Addr: self,
+		Sig:  makeAnchorSignature(myPriv, ub.BlockHash, ""),
+		Auth: makeAnchorSignature(myPriv, voteDigest(phase, ub.Index, ub.BlockHash), ""),
+	}
+}
+
+// 투표 대상 view 상태 (잠금 상태로 반환, 인증 실패/제안 전/다른 블록이면 오류 응답 후 nil)
+// - Auth 가 이 단계/view/블록 해시를 서명자 키로 서명한 것이 아니면 403 unbound_message
+func lockVoteView(w http.ResponseWriter, r *http.Request, phase string) (*viewState, voteMsg) {
+	var msg voteMsg
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return nil, msg
+	}
+	// makeAnchorSignature 는 "메시지|타임스탬프" 의 해시에 서명
+	digest := sha256.Sum256([]byte(voteDigest(phase, msg.View, msg.Hash) + "|"))
+	if !verifyECDSA(consensusKeys()[msg.Addr], digest[:], msg.Auth) {
+		log.Printf("[BFT-VALIDATE] Reject %s vote from %s not bound to view %d", phase, msg.Addr, msg.View)
+		writeErrorDetail(w, http.StatusForbidden, "unbound_message", "vote signature does not bind this view/phase", nil)
+		return nil, msg
+	}
+	vs := getOrCreateView(msg.View)
+	vs.mu.Lock()
+	// 제안이 아직 도착 안 했으면 최대 3번(30ms)까지 재시도
+	for i := 0; i < 3 && vs.Block.BlockHash == ""; i++ {
+		vs.mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		vs.mu.Lock()
+	}
+	switch {
+	case vs.Block.BlockHash == "":
+		vs.mu.Unlock()
+		writeErrorDetail(w, http.StatusConflict, "no_proposal", "no proposal received for this view", nil)
+		return nil, msg
+	case vs.Block.BlockHash != msg.Hash:
+		vs.mu.Unlock()
+		writeErrorDetail(w, http.StatusConflict, "hash_mismatch", "vote is for a different block", nil)
+		return nil, msg
+	}
+	return vs, msg
+}
+
 // 3. NODE/LEADER: Prepare 서명 수집 및 Commit 전파
 func handleReceivePrepare(w http.ResponseWriter, r *http.Request) {
-	var msg struct{ Addr, Sig string }
-	json.NewDecoder(r.Body).Decode(&msg)
+	vs, msg := lockVoteView(w, r, PhaseNamePrepare)
+	if vs == nil {
+		return
+	}
+	defer vs.mu.Unlock()
 
-	if addVote(prepareCollector, msg.Addr, msg.Sig) {
+	if addVote(vs.Prepare, msg.Addr, msg.Sig) {
 		// Gov 노드들 사이의 정족수(2f+1) 확인
-		if checkQuorum(prepareCollector) && ConsPhase.Load() == ConsPrepare {
-			ConsPhase.Store(ConsCommit)
+		if checkQuorum(vs.Prepare) && vs.Phase == ConsPrepare {
+			vs.setPhase(ConsCommit)
 
-			myPriv, _ := getMeta("meta_hos_privkey")
-			mySig := makeAnchorSignature(myPriv, currentBlock.BlockHash, "")
-
-			log.Printf("[BFT-NODE] Phase: Commit | Gov Quorum reached")
-			broadcastToAll("/bft/commit", map[string]string{"addr": self, "sig": mySig})
+			log.Printf("[BFT-NODE] Phase: Commit | Gov Quorum reached (view=%d)", msg.View)
+			broadcastToAll("/bft/commit", signedVote(PhaseNameCommit, vs.Block))
 		}
 	}
 }
 
 // 4. NODE/LEADER: Commit 서명 수집 및 최종 상위 장부 기록
 func handleReceiveCommit(w http.ResponseWriter, r *http.Request) {
-	var msg struct{ Addr, Sig string }
-	json.NewDecoder(r.Body).Decode(&msg)
+	vs, msg := lockVoteView(w, r, PhaseNameCommit)
+	if vs == nil {
+		return
+	}
+	defer vs.mu.Unlock()
 
-	if addVote(commitCollector, msg.Addr, msg.Sig) {
-		if checkQuorum(commitCollector) && ConsPhase.Load() == ConsCommit {
-			log.Printf("[BFT-SUCCESS] Gov Consensus Finalized for Block #%d", currentBlock.Index)
+	if addVote(vs.Commit, msg.Addr, msg.Sig) {
+		if checkQuorum(vs.Commit) && vs.Phase == ConsCommit && !vs.Finalized {
+			log.Printf("[BFT-SUCCESS] Gov Consensus Finalized for Block #%d", vs.Block.Index)
 
 			// 최종 서명 목록 업데이트 및 저장
-			currentBlock.Signatures = commitCollector.signatures
-			onBlockReceived(currentBlock) //
+			vs.Finalized = true
+			vs.Block.Signatures = vs.Commit.signatures
+			if err := onBlockReceived(vs.Block); err != nil {
+				log.Printf("[BFT-ERROR] Save finalized block #%d: %v", vs.Block.Index, err)
+			}
 
-			ConsPhase.Store(ConsIdle)
+			deleteView(msg.View)
 		}
 	}
 }
 
+// 합의 단계 전환 (vs.mu 잠금 상태에서 호출)
+func (vs *viewState) setPhase(p int32) {
+	vs.Phase = p
+	vs.PhaseAt = time.Now()
+	ConsPhase.Store(p)
+}
+
+// 오래된 view 정리 루틴
+// - 이미 확정된 높이 이하의 view, 한 단계에 ViewStaleAfter 넘게 머무른 view 삭제 (네트워크 분할 등으로 정족수 미달)
+// - 이 노드가 제안했다가 확정되지 못한 앵커는 메모리풀로 복구
+func startViewGC() {
+	t := time.NewTicker(ViewGCInterval * time.Second)
+	defer t.Stop()
+	for range t.C {
+		height, _ := getLatestHeight()
+		viewMu.Lock()
+		stale := map[int]*viewState{}
+		for v, vs := range viewStates {
+			vs.mu.Lock()
+			expired := time.Since(vs.PhaseAt) > ViewStaleAfter*time.Second
+			vs.mu.Unlock()
+			if v <= height || expired {
+				stale[v] = vs
+			}
+		}
+		viewMu.Unlock()
+
+		for v, vs := range stale {
+			vs.mu.Lock()
+			records, hash, phase := vs.Records, vs.Block.BlockHash, vs.Phase
+			vs.mu.Unlock()
+			// 이 높이에 다른 블록이 확정됐거나 확정되지 않은 경우만 복구
+			restored := 0
+			if b, err := getBlockByIndex(v); len(records) > 0 && (err != nil || b.BlockHash != hash) {
+				appendPending(records)
+				restored = len(records)
+			}
+			log.Printf("[BFT-GC] Drop view %d at phase %d (restored=%d)", v, phase, restored)
+			deleteView(v)
+		}
+	}
+}
+
+func newCollector() *consensusCollector {
+	return &consensusCollector{votedPeers: make(map[string]bool)}
+}
+
 // --- Gov 전용 헬퍼 함수 ---
 
 func createProposedBlock(records []AnchorRecord) UpperBlock {
@@ -137,7 +316,7 @@ func createProposedBlock(records []AnchorRecord) UpperBlock {
 		Timestamp:  time.Now().Format(time.RFC3339),
 		Records:    records, // 하위체인에서 온 앵커들을 담음
 		Proposer:   self,
-		Signatures: []string{},
+		Signatures: []ConsensusSig{},
 	}
 
 	// 앵커들의 루트를 다시 Merkle Tree로 구성하여 상위 루트 계산
@@ -145,31 +324,24 @@ func createProposedBlock(records []AnchorRecord) UpperBlock {
 	for i, r := range records {
 		leafHashes[i] = sha256Hex([]byte(r.LowerRoot)) // 앵커 루트들을 리프로 사용
 	}
-	ub.MerkleRoot = merkleRootHex(leafHashes)
+	ub.MerkleRoot = merkle.Root(leafHashes)
 	ub.BlockHash = ub.computeHash() //
 
 	// 리더 서명 추가
 	myPriv, _ := getMeta("meta_hos_privkey")
 	mySig := makeAnchorSignature(myPriv, ub.BlockHash, "")
-	ub.Signatures = append(ub.Signatures, mySig)
+	ub.Signatures = append(ub.Signatures, ConsensusSig{Addr: self, KeyFP: pubKeyFingerprint(consensusKeys()[self]), Sig: mySig})
 
 	return ub
 }
 
-func initCollectors() {
-	collectorMu.Lock()
-	defer collectorMu.Unlock()
-	prepareCollector = &consensusCollector{votedPeers: make(map[string]bool)}
-	commitCollector = &consensusCollector{votedPeers: make(map[string]bool)}
-}
-
 func addVote(c *consensusCollector, addr string, sig string) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.votedPeers[addr] {
 		return false
 	}
-	c.signatures = append(c.signatures, sig)
+	c.signatures = append(c.signatures, ConsensusSig{Addr: addr, KeyFP: pubKeyFingerprint(consensusKeys()[addr]), Sig: sig})
 	c.votedPeers[addr] = true
 	return true
 }
@@ -183,6 +355,6 @@ func broadcastToAll(path string, data any) {
 	body, _ := json.Marshal(data)
 	nodes := append(peersSnapshot(), self)
 	for _, node := range nodes {
-		go http.Post("http://"+node+path, "application/json", bytes.NewReader(body))
+		go peerClient.Post("http://"+node+path, "application/json", bytes.NewReader(body))
 	}
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"encoding/json"
+	"encoding/pem"
 	"log"
 	"strings"
 )
@@ -23,11 +25,39 @@ type UpperBlock struct {
 	Records    []AnchorRecord `json:"records"`     // Hos 체인에서 제출한 AnchorRecord 목록
 	MerkleRoot string         `json:"merkle_root"` // AnchorRecords 속 MerkleRoot들을 병합하여 계산한 상위 MerkleRoot
 	Proposer   string         `json:"proposer"`    // 해당 블록의 합의 집행자
-	Signatures []string       `json:"signatures"`  // 2f+1개 이상의 노드 서명 목록 (합의 증거)
+	Signatures []ConsensusSig `json:"signatures"`  // 2f+1개 이상의 노드 서명 목록 (합의 증거)
 	BlockHash  string         `json:"block_hash"`  // 블록 전체 해시
 	Elapsed    float32        `json:"elapsed"`     // 채굴 소요 시간
 }
 
+// 합의 서명 (서명 노드 주소 + 공개키 지문 + 블록 해시에 대한 ECDSA 서명)
+// - 검증 시 Addr 의 공개키 하나로만 확인 (countValidSignatures)
+type ConsensusSig struct {
+	Addr  string `json:"addr"`
+	KeyFP string `json:"pubkey_fingerprint"` // 서명 시점 공개키의 SHA-256 지문 (pubKeyFingerprint)
+	Sig   string `json:"sig"`                // hex, DER
+}
+
+// 기존 블록의 서명 문자열("<sig hex>")도 서명자 없는 ConsensusSig 로 읽음
+func (s *ConsensusSig) UnmarshalJSON(data []byte) error {
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		*s = ConsensusSig{Sig: legacy}
+		return nil
+	}
+	type plain ConsensusSig
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// 공개키 PEM 의 지문 (DER 의 SHA-256 hex)
+func pubKeyFingerprint(pubPem string) string {
+	block, _ := pem.Decode([]byte(pubPem))
+	if block == nil {
+		return ""
+	}
+	return sha256Hex(block.Bytes)
+}
+
 // 제네시스 블록 생성
 func createGenesisBlock(govID string) UpperBlock {
 	log.Printf("[Blk] Start genesis block...") //
@@ -40,7 +70,7 @@ func createGenesisBlock(govID string) UpperBlock {
 		Records:    []AnchorRecord{},        //
 		MerkleRoot: "",                      //
 		Proposer:   "SYSTEM",                //
-		Signatures: []string{},              //
+		Signatures: []ConsensusSig{},        //
 		Elapsed:    0,                       //
 	}
 
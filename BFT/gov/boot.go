@@ -27,20 +27,20 @@ type registerResp struct {
 // 신규노드가 네트워크 진입 시 부트노드가 다른 노드들의 주소를 제공하는 함수
 func registerPeer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req registerReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Addr == "" {
-		http.Error(w, "invalid body", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid body")
 		return
 	}
 
 	// 체인 정체성 확인: 제네시스 gov_id와 일치해야 가입 허용
 	blk0, err := getBlockByIndex(0)
 	if err != nil || blk0.GovID != req.GovID {
-		http.Error(w, "Gov_id mismatch", http.StatusForbidden)
+		writeError(w, http.StatusForbidden, "Gov_id mismatch")
 		return
 	}
 
@@ -100,7 +100,7 @@ func notifyNewPeerWithKey(newAddr, newPubKey string) {
 				"addr":    newAddr,
 				"pub_key": newPubKey,
 			})
-			_, err := http.Post("http://"+dst+"/addPeer", "application/json", bytes.NewReader(body))
+			_, err := peerClient.Post("http://"+dst+"/addPeer", "application/json", bytes.NewReader(body))
 			if err != nil {
 				log.Printf("[BOOT] Failed to notify %s about new peer", dst)
 			}
@@ -197,7 +197,7 @@ func broadcastNewBoot(newBoot string) {
 	for _, p := range peersSnapshot() {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"addr": newBoot})
-			_, err := http.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
+			_, err := peerClient.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -209,7 +209,7 @@ func broadcastNewBoot(newBoot string) {
 // POST : /bootNotify
 func bootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -218,12 +218,12 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.Addr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.Addr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.Addr)
 		return
 	}
@@ -267,7 +267,7 @@ func broadcastNewHosBoot(hosID, hosBoot string) {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"hos_id": hosID, "hos_boot": hosBoot})
 			logInfo("[BOOT] notify new hosBoot to %s", dst)
-			_, err := http.Post("http://"+dst+"/hosBootNotify", "application/json", strings.NewReader(string(body)))
+			_, err := peerClient.Post("http://"+dst+"/hosBootNotify", "application/json", strings.NewReader(string(body)))
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -280,7 +280,7 @@ func broadcastNewHosBoot(hosID, hosBoot string) {
 // POST : /hosBootNotify
 func hosBootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -290,12 +290,12 @@ func hosBootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 주소 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.HosBoot == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.HosBoot); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.HosBoot)
 		return
 	}
@@ -72,14 +72,8 @@ func newUpperChain(govID string) (*UpperChain, error) {
 		genesis = createGenesisBlock(govID)
 
 		// 체인에 추가
-		if err := saveBlockToDB(genesis); err != nil {
-			return nil, fmt.Errorf("save genesis block: %w", err)
-		}
-		if err := updateIndicesForBlock(genesis); err != nil {
-			return nil, fmt.Errorf("update genesis indices: %w", err)
-		}
-		if err := setLatestHeight(genesis.Index); err != nil {
-			return nil, fmt.Errorf("set genesis height: %w", err)
+		if err := commitBlock(genesis); err != nil {
+			return nil, fmt.Errorf("commit genesis block: %w", err)
 		}
 
 		ch.lastBlockTime = time.Now()
@@ -97,6 +91,15 @@ func newUpperChain(govID string) (*UpperChain, error) {
 
 // 수신된 블록 검증 및 반영
 func onBlockReceived(ub UpperBlock) error {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	// 0. 이미 반영된 블록이면 무시 (같은 블록으로 두 번 호출되어도 안전)
+	if isBlockApplied(ub.BlockHash) {
+		log.Printf("[CHAIN] Block #%d already applied. Skipping.", ub.Index)
+		return nil
+	}
+
 	// 1. PBFT 정족수(2f+1) 및 서명 검증
 	if err := verifyConsensusEvidence(ub); err != nil {
 		return fmt.Errorf("consensus verification failed: %w", err)
@@ -110,20 +113,20 @@ func onBlockReceived(ub UpperBlock) error {
 	if ub.PrevHash != prev.BlockHash {
 		return fmt.Errorf("invalid hash link")
 	}
-
-	// 3. 로컬 장부 반영
-	if err := saveBlockToDB(ub); err != nil {
-		return fmt.Errorf("save block: %w", err)
-	}
-	if err := updateIndicesForBlock(ub); err != nil {
-		return fmt.Errorf("update indices: %w", err)
+	if h, ok := getLatestHeight(); ok && ub.Index <= h {
+		return fmt.Errorf("height %d already has another block", ub.Index)
 	}
-	if err := setLatestHeight(ub.Index); err != nil {
-		return fmt.Errorf("set height: %w", err)
+
+	// 3. 로컬 장부 반영 (본문/인덱스/높이/반영 표시를 한 번에 기록)
+	if err := commitBlock(ub); err != nil {
+		return fmt.Errorf("commit block: %w", err)
 	}
 
 	ch.lastBlockTime = time.Now()
 
+	// 확정된 앵커는 메모리풀에서 제거 (중계받은 사본 포함)
+	removePendingFinalized(ub.Records)
+
 	// 4. 합의 상태 초기화
 	ConsPhase.Store(ConsIdle)
 
@@ -147,51 +150,56 @@ func verifyConsensusEvidence(ub UpperBlock) error {
 	// 2. 검증할 메시지 해시 생성 (블록 해시 기준)
 	msgHash := sha256.Sum256([]byte(ub.BlockHash))
 
-	validCount := 0
-	checkedPeers := make(map[string]bool) // 동일 노드의 중복 서명 방지용
-
-	// 3. 서명 슬라이스 순회 (여기서 addr은 인덱스 int입니다)
-	for _, sigHex := range ub.Signatures {
-		found := false
+	// 3. 서명마다 명시된 서명자의 공개키로 검증
+	validCount := countValidSignatures(ub, msgHash[:], consensusKeys())
 
-		// 내 서명인지 먼저 확인 (가장 빠름)
-		myPubKey, _ := getMeta("meta_hos_pubkey")
-		if !checkedPeers[self] && verifyECDSA(myPubKey, msgHash[:], sigHex) {
-			validCount++
-			checkedPeers[self] = true
-			found = true
-		}
+	// 4. 유효 정족수 최종 확인
+	if validCount < required {
+		return fmt.Errorf("valid signatures insufficient: %d/%d (required %d)", validCount, required, required)
+	}
 
-		// 내 서명이 아니라면 피어들 명단에서 대조
-		if !found {
-			for _, pAddr := range peers {
-				if checkedPeers[pAddr] {
-					continue // 이미 검증 완료된 피어는 스킵
-				}
+	log.Printf("[BFT] Block #%d verified with %d valid signatures", ub.Index, validCount)
+	return nil
+}
 
-				pubPem := peerPubKeys[pAddr]
-				if pubPem == "" {
-					continue
-				}
+// 합의 참여 노드의 공개키 (주소 => 공개키 PEM, 자신 포함)
+func consensusKeys() map[string]string {
+	keys := make(map[string]string)
+	pkMu.RLock()
+	for addr, pub := range peerPubKeys {
+		keys[addr] = pub
+	}
+	pkMu.RUnlock()
+	if myPubKey, ok := getMeta("meta_hos_pubkey"); ok {
+		keys[self] = myPubKey
+	}
+	return keys
+}
 
-				// ECDSA 대조 연산 (CPU 집약적)
-				if verifyECDSA(pubPem, msgHash[:], sigHex) {
-					validCount++
-					checkedPeers[pAddr] = true
-					found = true
+// 블록 서명 중 keys(주소 => 공개키) 노드의 유효 서명 수 (노드당 1개)
+// - 서명마다 명시된 서명자(Addr)의 공개키로만 검증, 지문이 현재 공개키와 다르면 무효
+// - 서명자 정보가 없는 기존 블록 서명만 모든 공개키와 대조
+func countValidSignatures(b UpperBlock, hash []byte, keys map[string]string) int {
+	signed := make(map[string]bool) // 동일 노드의 중복 서명 방지용
+	for _, s := range b.Signatures {
+		if s.Addr == "" {
+			for addr, pub := range keys {
+				if !signed[addr] && verifyECDSA(pub, hash, s.Sig) {
+					signed[addr] = true
 					break // 이 서명의 주인을 찾았으므로 다음 서명으로
 				}
 			}
+			continue
+		}
+		pub := keys[s.Addr]
+		if signed[s.Addr] || pub == "" || (s.KeyFP != "" && s.KeyFP != pubKeyFingerprint(pub)) {
+			continue
+		}
+		if verifyECDSA(pub, hash, s.Sig) {
+			signed[s.Addr] = true
 		}
 	}
-
-	// 4. 유효 정족수 최종 확인
-	if validCount < required {
-		return fmt.Errorf("valid signatures insufficient: %d/%d (required %d)", validCount, required, required)
-	}
-
-	log.Printf("[BFT] Block #%d verified with %d valid signatures", ub.Index, validCount)
-	return nil
+	return len(signed)
 }
 
 // 체인의 메모리풀인 pending에 앵커 내용 추가
@@ -215,6 +223,23 @@ func getPending() []AnchorRecord {
 	return entries
 }
 
+// 블록에 포함되어 확정된 앵커를 메모리풀에서 제거 (Hos ID + 하위 루트 기준)
+func removePendingFinalized(records []AnchorRecord) {
+	finalized := make(map[string]bool, len(records))
+	for _, r := range records {
+		finalized[r.HosID+"|"+r.LowerRoot] = true
+	}
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	kept := ch.pending[:0]
+	for _, r := range ch.pending {
+		if !finalized[r.HosID+"|"+r.LowerRoot] {
+			kept = append(kept, r)
+		}
+	}
+	ch.pending = kept
+}
+
 // 메모리풀이 비어있는 지 확인
 func pendingIsEmpty() bool {
 	ch.pendingMu.Lock()
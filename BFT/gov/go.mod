@@ -5,3 +5,7 @@ go 1.25
 require github.com/syndtr/goleveldb v1.0.0
 
 require github.com/golang/snappy v1.0.0 // indirect
+
+require merkle v0.0.0
+
+replace merkle => ../../merkle
@@ -20,6 +20,9 @@ func main() {
 	boot = getEnvDefault("BOOTSTRAP_ADDR", "gov-boot:5000") // 부트노드 고정주소
 	self = getEnvDefault("NODE_ADDR", "gov-node-00:5000")   // 이 노드의 외부접속 주소
 
+	// API 인증 설정 (API_KEYS / JWT_SECRET / PEER_TOKEN)
+	initAuth()
+
 	// 2) DB 초기화
 	initDB(dbPath)
 	defer closeDB()
@@ -46,14 +49,17 @@ func main() {
 	//	   - /bootNotify : 부트노드 변경 수신
 	//	   - /addAnchor : Hos 체인으로부터 Anchor 수신, 해당 Hos의 부트노드 주소를 다른 Gov 노드에 전파
 	//	   - /hosBootNotify : Gov 부트노드로부터 전파된 Hos 부트노드 주소를 수신
-	mux.HandleFunc("/addPeer", addPeer)
-	mux.HandleFunc("/bft/start", handleBftStart)
-	mux.HandleFunc("/bft/prepare", handleReceivePrepare)
-	mux.HandleFunc("/bft/commit", handleReceiveCommit)
-	mux.HandleFunc("/register", registerPeer)
-	mux.HandleFunc("/bootNotify", bootNotify)
+	//	   (같은 체인 노드 간 엔드포인트는 X-Chain-ID 가 없거나 다른 요청 거절 : chainid.go)
+	//	   (인증 사용 시 노드 간 엔드포인트는 peer 역할 필요 : auth.go)
+	mux.HandleFunc("/addPeer", requireRole(RolePeer, addPeer))
+	mux.HandleFunc("/bft/start", requireSameChain(requireRole(RolePeer, handleBftStart)))
+	mux.HandleFunc("/bft/prepare", requireSameChain(requireRole(RolePeer, handleReceivePrepare)))
+	mux.HandleFunc("/bft/commit", requireSameChain(requireRole(RolePeer, handleReceiveCommit)))
+	mux.HandleFunc("/pending/relay", requireSameChain(requireRole(RolePeer, handlePendingRelay)))
+	mux.HandleFunc("/register", requireRole(RolePeer, registerPeer))
+	mux.HandleFunc("/bootNotify", requireRole(RolePeer, bootNotify))
 	mux.HandleFunc("/addAnchor", addAnchor)
-	mux.HandleFunc("/hosBootNotify", hosBootNotify)
+	mux.HandleFunc("/hosBootNotify", requireRole(RolePeer, hosBootNotify))
 
 	mux.Handle("/", http.FileServer(http.Dir("./static")))
 
@@ -84,7 +90,7 @@ func main() {
 		}
 		b, _ := json.Marshal(payload)
 
-		resp, err := http.Post("http://"+boot+"/register", "application/json", strings.NewReader(string(b)))
+		resp, err := peerClient.Post("http://"+boot+"/register", "application/json", strings.NewReader(string(b)))
 		if err != nil {
 			log.Printf("[BOOT] register failed: %v", err)
 			return
@@ -132,6 +138,11 @@ func main() {
 		log.Printf("[WATCHER] starting unified mining watcher (%ds interval)", ConsWatcherTime)
 		startMiningWatcher()
 	}()
+
+	go func() {
+		log.Printf("[WATCHER] starting view GC (%ds interval, stale after %ds)", ViewGCInterval, ViewStaleAfter)
+		startViewGC()
+	}()
 	//
 	//go func() {
 	//	log.Printf("[WATCHER] starting unified chain watcher (%ds interval)", ChainWatcherTime)
@@ -22,7 +22,7 @@ type nodeStatus struct {
 // 해당 노드의 현재 상태(nodeStatus)를 가져옴
 func probeStatus(addr string) (nodeStatus, bool) {
 	var s nodeStatus
-	resp, err := http.Get("http://" + addr + "/status")
+	resp, err := peerClient.Get("http://" + addr + "/status")
 	if err != nil {
 		return s, false
 	}
@@ -86,7 +86,7 @@ func syncChain(peer string) {
 	url := "http://" + peer + "/blocks"
 
 	// 원격에서 전체 블록 수신
-	resp, err := http.Get(url)
+	resp, err := peerClient.Get(url)
 	if err != nil {
 		log.Printf("[P2P] Failed to sync from %s: %v\n", peer, err)
 		return
@@ -122,6 +122,12 @@ func syncChain(peer string) {
 	for _, nb := range page.Items {
 		chainMu.Lock()
 
+		// 이미 반영된 블록은 건너뜀
+		if isBlockApplied(nb.BlockHash) {
+			chainMu.Unlock()
+			continue
+		}
+
 		if nb.Index != 0 {
 			prev, err := getBlockByIndex(nb.Index - 1)
 			if err != nil {
@@ -140,20 +146,10 @@ func syncChain(peer string) {
 			log.Printf("[P2P] Fetching genesis from %s", peer)
 		}
 
-		// append
-		if err := saveBlockToDB(nb); err != nil {
-			chainMu.Unlock()
-			log.Printf("[P2P] saveBlockToDB error: %v\n", err)
-			return
-		}
-		if err := updateIndicesForBlock(nb); err != nil {
-			chainMu.Unlock()
-			log.Printf("[P2P] updateIndicesForBlock error: %v\n", err)
-			return
-		}
-		if err := setLatestHeight(nb.Index); err != nil {
+		// append (본문/인덱스/높이를 한 번에 기록)
+		if err := commitBlock(nb); err != nil {
 			chainMu.Unlock()
-			log.Printf("[P2P] setLatestHeight error: %v\n", err)
+			log.Printf("[P2P] commitBlock error: %v\n", err)
 			return
 		}
 
@@ -174,7 +170,7 @@ func addPeer(w http.ResponseWriter, r *http.Request) {
 	}
 	// 부트노드가 보낸 JSON 객체 파싱해
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid peer format", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid peer format")
 		return
 	}
 	if addPeerInternal(req.Addr, req.PubKey) { // 공개키 함께 전달
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Proposer Rotation (view 별 제안자 순환)
+// ------------------------------------------------------------
+// - 부트노드가 모든 블록을 제안하던 방식 => view(블록 높이) 마다 제안자 순환
+//   · 검증자 집합 = 공개키가 등록된 노드(consensusKeys) 주소 정렬 목록
+//   · leaderFor(view) = 집합[view % n] => view 번호만으로 누구나 계산 가능
+//   · /bft/start 는 해당 view 의 제안자가 만든 블록만 수용 (handleBftStart)
+// - 메모리풀 중계 : 어느 노드가 접수한 앵커든 다음 제안자가 블록에 담을 수 있도록
+//   접수 노드가 다른 노드들에 POST /pending/relay 로 전달 (수신 노드는 재중계하지 않음)
+//   · 확정된 앵커는 블록 반영 시 모든 노드의 메모리풀에서 제거 (removePendingFinalized)
+// - 이 트리에는 view-change 가 없으므로 제안자가 응답하지 않으면 해당 view 는 멈춤
+//   (부트노드가 유일한 제안자였던 기존 동작과 같은 한계)
+////////////////////////////////////////////////////////////////////////////////
+
+// 서명 검증자 집합 (주소 정렬)
+func validatorSet() []string {
+	keys := consensusKeys()
+	nodes := make([]string, 0, len(keys)+1)
+	for addr := range keys {
+		nodes = append(nodes, addr)
+	}
+	if _, ok := keys[self]; !ok {
+		nodes = append(nodes, self)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// view 의 제안자 : 검증자 집합에서 view 위치
+func leaderFor(view int) string {
+	nodes := validatorSet()
+	return nodes[view%len(nodes)]
+}
+
+// 다음 블록(view = 높이+1)의 제안자
+func nextProposer() string {
+	height, _ := getLatestHeight()
+	return leaderFor(height + 1)
+}
+
+// 접수한 앵커를 다른 노드 메모리풀로 중계
+func relayPending(records []AnchorRecord) {
+	if len(records) == 0 {
+		return
+	}
+	body, _ := json.Marshal(records)
+	for _, p := range peersSnapshot() {
+		go func(dst string) {
+			resp, err := peerClient.Post("http://"+dst+"/pending/relay", "application/json", bytes.NewReader(body))
+			if err != nil {
+				log.Printf("[PENDING][RELAY] failed to relay %d anchors to %s: %v", len(records), dst, err)
+				return
+			}
+			resp.Body.Close()
+		}(p)
+	}
+}
+
+// POST /pending/relay : 다른 노드가 접수한 앵커 수신 (노드 간)
+func handlePendingRelay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var records []AnchorRecord
+	if err := json.NewDecoder(r.Body).Decode(&records); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid anchor record")
+		return
+	}
+	appendPending(records)
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(records)})
+}
@@ -71,35 +71,53 @@ func closeDB() {
 // 블록 저장/조회
 ////////////////////////////////////////////////////////////////////////////////
 
-// UpperBlock 전체를 JSON으로 저장
+// 블록 본문 + 검색 인덱스 + 최신 높이 + 반영 표시를 하나의 Batch로 원자적 반영
+// - 중간에 프로세스가 죽어도 "본문만 있고 인덱스/높이가 없는" 블록이 남지 않음
+// - 반영 표시 "applied_<BlockHash>" => Index : 같은 블록 재반영 차단 (isBlockApplied)
+func commitBlock(block UpperBlock) error {
+	batch := new(leveldb.Batch)
+	if err := saveBlockToBatch(batch, block); err != nil {
+		return err
+	}
+	updateIndicesForBlock(batch, block)
+	batch.Put([]byte("height_latest"), []byte(strconv.Itoa(block.Index)))
+	batch.Put(appliedKey(block.BlockHash), []byte(strconv.Itoa(block.Index)))
+	if err := db.Write(batch, nil); err != nil {
+		return err
+	}
+	log.Printf("[DB] Block #%d committed (Hash=%s, %d keys)\n", block.Index, block.BlockHash, batch.Len())
+	appendBlockLog(block)
+	return nil
+}
+
+func appliedKey(hash string) []byte {
+	return []byte("applied_" + hash)
+}
+
+// 이미 장부에 반영된 블록인지 (반영 표시 확인)
+func isBlockApplied(hash string) bool {
+	ok, _ := db.Has(appliedKey(hash), nil)
+	return ok
+}
+
+// UpperBlock 전체를 JSON으로 Batch 에 기록
 // - Key1: "block_<Index>"     => UpperBlock JSON (번호 기반 접근)
 // - Key2: "hash_<BlockHash>"  => UpperBlock JSON (해시 기반 접근)
 // 주: 키 형식은 기존 코드와의 호환을 위해 유지
-func saveBlockToDB(block UpperBlock) error {
+func saveBlockToBatch(batch *leveldb.Batch, block UpperBlock) error {
 	data, err := json.Marshal(block)
 	if err != nil {
 		return err
 	}
 
 	// 블록 번호 기반 저장
-	keyByIndex := fmt.Sprintf("block_%d", block.Index)
-	if err := db.Put([]byte(keyByIndex), data, nil); err != nil {
-		return err
-	}
+	batch.Put([]byte(fmt.Sprintf("block_%d", block.Index)), data)
 
 	// 블록 해시 기반 저장
-	keyByHash := fmt.Sprintf("hash_%s", block.BlockHash)
-	if err := db.Put([]byte(keyByHash), data, nil); err != nil {
-		return err
-	}
+	batch.Put([]byte(fmt.Sprintf("hash_%s", block.BlockHash)), data)
 
 	// 최신 루트 캐시(선택)
-	if err := db.Put([]byte("root_latest"), []byte(block.MerkleRoot), nil); err != nil {
-		return err
-	}
-
-	log.Printf("[DB] Block #%d saved (Hash=%s)\n", block.Index, block.BlockHash)
-	appendBlockLog(block)
+	batch.Put([]byte("root_latest"), []byte(block.MerkleRoot))
 	return nil
 }
 
@@ -141,22 +159,19 @@ func getLatestRoot() string {
 
 // UpperBlock 내의 AnchorRecord(각 Hos별 앵커 데이터)를 기반으로
 // LevelDB에 색인 정보를 갱신하는 함수
-func updateIndicesForBlock(block UpperBlock) error {
+func updateIndicesForBlock(batch *leveldb.Batch, block UpperBlock) {
 	ptr := func(bi, ei int) []byte { return []byte(fmt.Sprintf("%d:%d", bi, ei)) }
 
 	for ei, rec := range block.Records {
 		// Hos별 앵커 색인 등록
 		if rec.HosID != "" {
 			keyByHos := fmt.Sprintf("anchor_%s", rec.HosID)
-			if err := db.Put([]byte(keyByHos), ptr(block.Index, ei), nil); err != nil {
-				return err
-			}
+			batch.Put([]byte(keyByHos), ptr(block.Index, ei))
 		}
 	}
 
 	log.Printf("[DB] Indices updated for UpperBlock #%d (%d anchors)\n",
 		block.Index, len(block.Records))
-	return nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
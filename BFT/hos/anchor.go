@@ -17,6 +17,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"merkle"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -52,7 +54,7 @@ func ensureKeyPair() {
 func getPublicKey(w http.ResponseWriter, r *http.Request) {
 	pubPem, ok := getMeta("meta_hos_pubkey")
 	if !ok {
-		http.Error(w, "public key not found", http.StatusNotFound)
+		writeError(w, http.StatusNotFound, "public key not found")
 		return
 	}
 	w.Header().Set("Content-Type", "application/x-pem-file")
@@ -174,7 +176,7 @@ func buildSearchResponse(rec ClinicRecord, blk *LowerBlock, entryIndex int) Sear
 	leaf := blk.LeafHashes[entryIndex]
 
 	// 2) 검색된 레코드가 속한 블록을 기준으로 Merkle Proof 생성
-	proof := merkleProof(blk.LeafHashes, entryIndex)
+	proof := merkle.Proof(blk.LeafHashes, entryIndex)
 
 	// 3) 최종 결과 패키징
 	return SearchResponse{
@@ -24,7 +24,7 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /block/root
 	mux.HandleFunc("/block/root", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		root := getLatestRoot() // storage의 getLatestRoot 사용
@@ -35,22 +35,22 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /block/index?id=<int>
 	mux.HandleFunc("/block/index", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		q := r.URL.Query().Get("id")
 		if q == "" {
-			http.Error(w, "id parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id parameter required")
 			return
 		}
 		idx, err := strconv.Atoi(q)
 		if err != nil {
-			http.Error(w, "id must be integer", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id must be integer")
 			return
 		}
 		blk, err := getBlockByIndex(idx)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
 		writeJSON(w, http.StatusOK, blk)
@@ -60,17 +60,17 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /block/hash?value=<hash>
 	mux.HandleFunc("/block/hash", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		hash := r.URL.Query().Get("value")
 		if hash == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
 			return
 		}
 		blk, err := getBlockByHash(hash)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
 		writeJSON(w, http.StatusOK, blk)
@@ -80,20 +80,20 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /search?value=<keyword>
 	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 
 		kw := r.URL.Query().Get("value")
 		if kw == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
 			return
 		}
 		logInfo("search query keyword: %s", kw)
 		// 검색 수행
 		results, err := searchClinic(kw)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			writeError(w, http.StatusNotFound, err.Error())
 			return
 		}
 		logInfo("query response's length: %s", len(results))
@@ -105,7 +105,7 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /blocks?offset=<int>&limit=<int>
 	mux.HandleFunc("/blocks", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
@@ -115,7 +115,7 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 		}
 		blocks, total, err := listBlocksPaginated(offset, limit)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("list blocks error: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("list blocks error: %v", err))
 			return
 		}
 		writeJSON(w, http.StatusOK, map[string]any{
@@ -143,7 +143,7 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 		writeJSON(w, http.StatusOK, map[string]any{
 			"addr":       self,
 			"height":     height,
-			"proposer":   proposer,
+			"proposer":   nextProposer(),
 			"is_boot":    isBoot.Load(),
 			"bootAddr":   boot,
 			"started_at": startedAt.Format(time.RFC3339),
@@ -162,20 +162,21 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 
 	// 데이터 업로드 요청을 받아 메모리풀에 저장시킴
 	// POST /upload
-	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/upload", requireRole(RoleSubmitter, func(w http.ResponseWriter, r *http.Request) {
 		var rec []ClinicRecord
 		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
-			http.Error(w, "invalid Clinic record", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "invalid Clinic record")
 			return
 		}
 		defer r.Body.Close()
 
 		appendPending(rec) // 데이터 저장
+		relayPending(rec)  // 다음 제안자가 담을 수 있도록 다른 노드로 중계 (proposer.go)
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{
 			"status": "Uploading Request Submitted",
 			"count":  len(rec),
 		})
-	})
+	}))
 }
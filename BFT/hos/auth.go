@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 인증/인가 (쓰기 및 관리 API 보호)
+// ------------------------------------------------------------
+// - 역할(Role)
+//   · submitter : 진료 기록 제출 (/upload)
+//   · peer      : 노드 간 통신 (/addPeer, /bootNotify, /register, /bft/*, /pending/relay ...)
+//   · operator  : 운영 관리 - 모든 역할의 권한 포함
+// - 자격 증명 (Authorization: Bearer <token> 또는 X-API-Key: <key>)
+//   · API 키 : API_KEYS="<id>:<key>:<role>,..."
+//   · JWT   : JWT_SECRET 으로 서명된 HS256 토큰 (claims: sub, role, exp, exp 없는 토큰은 거부)
+// - 노드 간 요청은 전용 클라이언트(peerClient)로 보내고 PEER_TOKEN 을 자동으로 첨부 (피어/부트노드 주소로 가는 요청에 한함)
+//   · 인증 사용 여부와 관계없이 모든 요청에 X-Chain-ID 기록 (chainid.go)
+//   · http.DefaultClient 는 건드리지 않으므로 Gov 등 다른 체인으로 가는 요청에는 토큰이 붙지 않음
+//   · peerClient 는 응답 없는 노드에 묶이지 않도록 요청 타임아웃(peerRequestTimeout) 적용
+// - API_KEYS, JWT_SECRET 모두 미설정 시 인증 없이 동작 (기존 동작 유지)
+//   · 단 operator 엔드포인트는 loopback 호출만 허용 (원격에서 관리 API 가 열리지 않도록)
+////////////////////////////////////////////////////////////////////////////////
+
+type Role string
+
+// 노드 간 요청 타임아웃 (/blocks 전체 동기화까지 고려)
+const peerRequestTimeout = 30 * time.Second
+
+const (
+	RoleSubmitter Role = "submitter"
+	RolePeer      Role = "peer"
+	RoleOperator  Role = "operator"
+)
+
+// 인증된 호출자
+type Principal struct {
+	ID   string `json:"id"`   // API 키 ID 또는 JWT sub
+	Role Role   `json:"role"` // 부여된 역할
+}
+
+type principalCtxKey struct{}
+
+type apiKeyEntry struct {
+	ID   string
+	Role Role
+}
+
+var (
+	authEnabled bool
+	apiKeys     = make(map[string]apiKeyEntry) // key => (id, role)
+	jwtSecret   []byte
+	peerToken   string // 노드 간 요청에 첨부할 토큰
+	// 노드 간 요청 전용 클라이언트 (X-Chain-ID 기록, 인증 사용 시 PEER_TOKEN 첨부)
+	peerClient = &http.Client{Timeout: peerRequestTimeout, Transport: &peerAuthTransport{base: http.DefaultTransport}}
+)
+
+// 환경변수로 인증 설정 초기화
+func initAuth() {
+	for _, item := range strings.Split(os.Getenv("API_KEYS"), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 || !validRole(Role(parts[2])) {
+			log.Fatalf("[AUTH] invalid API_KEYS entry (want <id>:<key>:<role>): %q", item)
+		}
+		apiKeys[parts[1]] = apiKeyEntry{ID: parts[0], Role: Role(parts[2])}
+	}
+	if s := os.Getenv("JWT_SECRET"); s != "" {
+		jwtSecret = []byte(s)
+	}
+	peerToken = os.Getenv("PEER_TOKEN")
+
+	authEnabled = len(apiKeys) > 0 || len(jwtSecret) > 0
+	if !authEnabled {
+		log.Println("[AUTH] API_KEYS/JWT_SECRET not set; write APIs are open, operator APIs are loopback-only")
+		return
+	}
+	if peerToken == "" {
+		log.Println("[AUTH][WARN] PEER_TOKEN not set; node-to-node calls will be rejected by peers")
+	}
+	log.Printf("[AUTH] enabled (api_keys=%d, jwt=%v)", len(apiKeys), len(jwtSecret) > 0)
+}
+
+func validRole(r Role) bool {
+	return r == RoleSubmitter || r == RolePeer || r == RoleOperator
+}
+
+// 요청의 자격 증명 추출
+func requestCredential(r *http.Request) string {
+	if k := r.Header.Get("X-API-Key"); k != "" {
+		return k
+	}
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(h, "Bearer "))
+	}
+	return ""
+}
+
+// 자격 증명 검증 => 호출자 정보
+func authenticate(cred string) (Principal, error) {
+	if cred == "" {
+		return Principal{}, fmt.Errorf("missing credentials")
+	}
+	for key, e := range apiKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(cred)) == 1 {
+			return Principal{ID: e.ID, Role: e.Role}, nil
+		}
+	}
+	if peerToken != "" && subtle.ConstantTimeCompare([]byte(peerToken), []byte(cred)) == 1 {
+		return Principal{ID: "peer", Role: RolePeer}, nil
+	}
+	if len(jwtSecret) > 0 && strings.Count(cred, ".") == 2 {
+		return verifyJWT(cred)
+	}
+	return Principal{}, fmt.Errorf("invalid credentials")
+}
+
+// HS256 JWT 검증 (sub, role, exp 필수)
+func verifyJWT(token string) (Principal, error) {
+	parts := strings.Split(token, ".")
+	var hdr struct {
+		Alg string `json:"alg"`
+	}
+	hb, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(hb, &hdr) != nil || hdr.Alg != "HS256" {
+		return Principal{}, fmt.Errorf("unsupported token header")
+	}
+	mac := hmac.New(sha256.New, jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, mac.Sum(nil)) {
+		return Principal{}, fmt.Errorf("invalid token signature")
+	}
+	var claims struct {
+		Sub  string `json:"sub"`
+		Role Role   `json:"role"`
+		Exp  int64  `json:"exp"`
+	}
+	cb, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(cb, &claims) != nil {
+		return Principal{}, fmt.Errorf("invalid token claims")
+	}
+	if claims.Exp == 0 {
+		return Principal{}, fmt.Errorf("token has no exp")
+	}
+	if time.Now().Unix() > claims.Exp {
+		return Principal{}, fmt.Errorf("token expired")
+	}
+	if !validRole(claims.Role) {
+		return Principal{}, fmt.Errorf("invalid role: %q", claims.Role)
+	}
+	return Principal{ID: claims.Sub, Role: claims.Role}, nil
+}
+
+// 역할 기반 접근 제어 미들웨어 (operator 는 모든 역할 허용)
+func requireRole(role Role, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !authEnabled {
+			if role == RoleOperator && !isLoopbackRequest(r) {
+				writeError(w, http.StatusForbidden, "operator endpoints require API_KEYS/JWT_SECRET or a loopback caller")
+				return
+			}
+			h(w, r)
+			return
+		}
+		p, err := authenticate(requestCredential(r))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="hos"`)
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if p.Role != role && p.Role != RoleOperator {
+			log.Printf("[AUTH][DENY] %s (%s) -> %s requires %s", p.ID, p.Role, r.URL.Path, role)
+			writeError(w, http.StatusForbidden, "forbidden")
+			return
+		}
+		h(w, r.WithContext(context.WithValue(r.Context(), principalCtxKey{}, p)))
+	}
+}
+
+// 같은 호스트(loopback)에서 온 요청인지 (인증 미설정 시 operator 엔드포인트 허용 기준)
+func isLoopbackRequest(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// 요청에 첨부된 호출자 정보 (인증 미사용/미인증 시 false)
+func principalFrom(r *http.Request) (Principal, bool) {
+	p, ok := r.Context().Value(principalCtxKey{}).(Principal)
+	return p, ok
+}
+
+// 노드 간 요청에 체인 식별값 기록, 피어/부트노드로 가는 요청에만 PEER_TOKEN 첨부 (다른 체인으로 토큰이 새지 않도록)
+type peerAuthTransport struct {
+	base http.RoundTripper
+}
+
+func (t *peerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = stampChainID(req)
+	if authEnabled && peerToken != "" && req.Header.Get("Authorization") == "" && isNodeHost(req.URL.Host) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+peerToken)
+	}
+	return t.base.RoundTrip(req)
+}
+
+func isNodeHost(host string) bool {
+	if host == self || host == getBootAddr() {
+		return true
+	}
+	for _, p := range peersSnapshot() {
+		if p == host {
+			return true
+		}
+	}
+	return false
+}
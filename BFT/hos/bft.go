@@ -2,17 +2,22 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"merkle"
 )
 
 // BFT 합의 수집기 (Prepare/Commit 단계별로 별도 관리)
 type consensusCollector struct {
 	mu         sync.Mutex
-	signatures []string
+	signatures []ConsensusSig
 	votedPeers map[string]bool
 }
 
@@ -23,19 +28,80 @@ var (
 	currentBlock     LowerBlock // 현재 합의 중인 블록 임시 저장
 )
 
+// 단계 제한시간 : 정족수가 모이지 않아(네트워크 분할 등) 한 단계에 머무르면 라운드를 포기하고 대기 상태로 복귀
+// - 이 노드가 제안하며 메모리풀에서 꺼낸 레코드는 메모리풀로 복구 (다른 블록이 확정된 경우도 빠진 레코드는 복구)
+const PhaseTimeout = 30 // 초
+
+var (
+	phaseSince    atomic.Int64   // 현재 단계 진입 시각 (unix nano)
+	ownProposal   []ClinicRecord // 합의 중인 내 제안 레코드
+	ownProposalMu sync.Mutex
+)
+
+var phaseNames = map[int32]string{ConsIdle: "idle", ConsPrePrepare: "pre_prepare", ConsPrepare: "prepare", ConsCommit: "commit"}
+
+// 합의 단계 전환 (단계 제한시간 기준 시각 기록)
+func setPhase(p int32) {
+	ConsPhase.Store(p)
+	phaseSince.Store(time.Now().UnixNano())
+}
+
+// 제한시간이 지난 단계 포기 (포기했으면 true)
+func abandonStuckPhase() bool {
+	p := ConsPhase.Load()
+	if p == ConsIdle || time.Since(time.Unix(0, phaseSince.Load())) < PhaseTimeout*time.Second {
+		return false
+	}
+	if !ConsPhase.CompareAndSwap(p, ConsIdle) {
+		return false
+	}
+	phaseSince.Store(time.Now().UnixNano())
+	restored := settleOwnProposal(nil)
+	log.Printf("[BFT-TIMEOUT] Phase %s stuck over %ds => abandon block #%d (restored %d records)", phaseNames[p], PhaseTimeout, currentBlock.Index, restored)
+	return true
+}
+
+// 내 제안 정리 : 확정 블록(없으면 nil)에 포함되지 않은 레코드를 메모리풀로 복구
+func settleOwnProposal(finalized []string) int {
+	ownProposalMu.Lock()
+	records := ownProposal
+	ownProposal = nil
+	ownProposalMu.Unlock()
+
+	included := make(map[string]bool, len(finalized))
+	for _, h := range finalized {
+		included[h] = true
+	}
+	var restore []ClinicRecord
+	for _, r := range records {
+		if !included[hashClinicRecord(r)] {
+			restore = append(restore, r)
+		}
+	}
+	if len(restore) > 0 {
+		appendPending(restore)
+	}
+	return len(restore)
+}
+
 // 1. WATCHER: 리더가 블록을 제안 (Pre-Prepare)
 func startMiningWatcher() {
 	t := time.NewTicker(time.Duration(ConsWatcherTime) * time.Second)
 	for range t.C {
+		// 정족수가 모이지 않아 멈춘 단계는 제한시간 후 포기
+		abandonStuckPhase()
 		if ConsPhase.Load() != ConsIdle || pendingIsEmpty() {
 			continue
 		}
-		if self != boot { // 리더(부트노드)만 제안
+		if nextProposer() != self { // 이번 view 의 제안자만 제안 (proposer.go)
 			continue
 		}
 
 		records := getPending()
-		ConsPhase.Store(ConsPrePrepare)
+		ownProposalMu.Lock()
+		ownProposal = records
+		ownProposalMu.Unlock()
+		setPhase(ConsPrePrepare)
 
 		// 블록 생성 및 리더 서명
 		newBlock := createProposedBlock(records)
@@ -52,53 +118,65 @@ func startMiningWatcher() {
 // 2. NODE: 리더의 제안을 받고 검증 신호 전파 (Prepare)
 func handleBftStart(w http.ResponseWriter, r *http.Request) {
 	var lb LowerBlock
-	json.NewDecoder(r.Body).Decode(&lb)
+	if err := json.NewDecoder(r.Body).Decode(&lb); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	// 해당 view 의 제안자가 만든 블록만 수용 (proposer.go)
+	if expected := leaderFor(lb.Index); lb.Proposer != expected {
+		log.Printf("[BFT-VALIDATE] Reject block #%d from non-proposer %s (expected=%s)", lb.Index, lb.Proposer, expected)
+		writeErrorDetail(w, http.StatusForbidden, "not_leader", "block is not from the view proposer", map[string]string{"expected": expected})
+		return
+	}
 
 	// 단계 보호 및 검증
 	if !ConsPhase.CompareAndSwap(ConsIdle, ConsPrepare) {
+		writeError(w, http.StatusConflict, "consensus already in progress")
 		return
 	}
+	phaseSince.Store(time.Now().UnixNano())
 
 	height, _ := getLatestHeight()
 	prev, _ := getBlockByIndex(height)
 	if err := validateLowerBlock(lb, prev); err != nil {
-		ConsPhase.Store(ConsIdle)
+		setPhase(ConsIdle)
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "invalid_block", err.Error(), nil)
 		return
 	}
 
 	currentBlock = lb // 검증된 블록 저장
-	myPriv, _ := getMeta("meta_hos_privkey")
-	mySig := makeAnchorSignature(myPriv, lb.BlockHash, "")
 
 	log.Printf("[BFT-NODE] Phase: Prepare | Index: %d", lb.Index)
 	// 모든 노드에게 "나 이 블록 준비됐어"라고 Prepare 신호 전파
-	broadcastToAll("/bft/prepare", map[string]string{"addr": self, "sig": mySig})
+	broadcastToAll("/bft/prepare", signedVote(PhaseNamePrepare, lb))
 	w.WriteHeader(http.StatusOK)
 }
 
 // 3. NODE/LEADER: Prepare 서명 수집 및 Commit 전파
 func handleReceivePrepare(w http.ResponseWriter, r *http.Request) {
-	var msg struct{ Addr, Sig string }
-	json.NewDecoder(r.Body).Decode(&msg)
+	msg, ok := decodeBoundVote(w, r, PhaseNamePrepare)
+	if !ok {
+		return
+	}
 
 	if addVote(prepareCollector, msg.Addr, msg.Sig) {
 		if checkQuorum(prepareCollector) && ConsPhase.Load() == ConsPrepare {
-			ConsPhase.Store(ConsCommit)
-
-			myPriv, _ := getMeta("meta_hos_privkey")
-			mySig := makeAnchorSignature(myPriv, currentBlock.BlockHash, "")
+			setPhase(ConsCommit)
 
 			log.Printf("[BFT-NODE] Phase: Commit | Quorum reached")
 			// 정족수 채워지면 "진짜 합의하자"고 Commit 신호 전파
-			broadcastToAll("/bft/commit", map[string]string{"addr": self, "sig": mySig})
+			broadcastToAll("/bft/commit", signedVote(PhaseNameCommit, currentBlock))
 		}
 	}
 }
 
 // 4. NODE/LEADER: Commit 서명 수집 및 최종 장부 기록
 func handleReceiveCommit(w http.ResponseWriter, r *http.Request) {
-	var msg struct{ Addr, Sig string }
-	json.NewDecoder(r.Body).Decode(&msg)
+	msg, ok := decodeBoundVote(w, r, PhaseNameCommit)
+	if !ok {
+		return
+	}
 
 	if addVote(commitCollector, msg.Addr, msg.Sig) {
 		if checkQuorum(commitCollector) && ConsPhase.Load() == ConsCommit {
@@ -108,13 +186,71 @@ func handleReceiveCommit(w http.ResponseWriter, r *http.Request) {
 			currentBlock.Signatures = commitCollector.signatures
 			onBlockReceived(currentBlock)
 
-			ConsPhase.Store(ConsIdle) // 합의 종료 및 대기상태 복귀
+			setPhase(ConsIdle) // 합의 종료 및 대기상태 복귀
 		}
 	}
 }
 
 // --- 헬퍼 함수들 ---
 
+// 합의 메시지 단계 (투표 인증 다이제스트)
+const (
+	PhaseNamePrepare = "prepare"
+	PhaseNameCommit  = "commit"
+)
+
+// prepare/commit 투표 (view = 블록 높이)
+// - Sig : 블록 해시 서명 => 블록 합의 증거(Signatures)에 그대로 사용
+// - Auth : 단계/view/블록 해시 다이제스트 서명 => 이전 view/다른 단계의 서명 재전송 차단
+type voteMsg struct {
+	View int    `json:"view"`
+	Hash string `json:"hash"`
+	Addr string `json:"addr"`
+	Sig  string `json:"sig"`
+	Auth string `json:"auth"`
+}
+
+// 투표 인증 다이제스트 (hex)
+// - 이 트리에는 view-change(라운드)가 없으므로 단계/view/블록 해시를 함께 서명
+func voteDigest(phase string, view int, hash string) string {
+	return sha256Hex([]byte(fmt.Sprintf("bft|%s|%d|%s", phase, view, hash)))
+}
+
+// 내 키로 서명한 prepare/commit 투표
+func signedVote(phase string, lb LowerBlock) voteMsg {
+	myPriv, _ := getMeta("meta_hos_privkey")
+	return voteMsg{
+		View: lb.Index,
+		Hash: lb.BlockHash,
+		Addr: self,
+		Sig:  makeAnchorSignature(myPriv, lb.BlockHash, ""),
+		Auth: makeAnchorSignature(myPriv, voteDigest(phase, lb.Index, lb.BlockHash), ""),
+	}
+}
+
+// 투표 디코딩 및 인증 (실패 시 오류 응답 후 false)
+// - Auth 가 이 단계/view/블록 해시를 서명자 키로 서명한 것이 아니면 403 unbound_message
+// - 서명이 맞아도 현재 합의 중인 블록(view, 해시)이 아니면 409 hash_mismatch (이전 view 투표 재전송)
+func decodeBoundVote(w http.ResponseWriter, r *http.Request, phase string) (voteMsg, bool) {
+	var msg voteMsg
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return msg, false
+	}
+	// makeAnchorSignature 는 "메시지|타임스탬프" 의 해시에 서명
+	digest := sha256.Sum256([]byte(voteDigest(phase, msg.View, msg.Hash) + "|"))
+	if !verifyECDSA(consensusKeys()[msg.Addr], digest[:], msg.Auth) {
+		log.Printf("[BFT-VALIDATE] Reject %s vote from %s not bound to view %d", phase, msg.Addr, msg.View)
+		writeErrorDetail(w, http.StatusForbidden, "unbound_message", "vote signature does not bind this view/phase", nil)
+		return msg, false
+	}
+	if msg.View != currentBlock.Index || msg.Hash != currentBlock.BlockHash {
+		writeErrorDetail(w, http.StatusConflict, "hash_mismatch", "vote is for a different block", nil)
+		return msg, false
+	}
+	return msg, true
+}
+
 func initCollectors() {
 	collectorMu.Lock()
 	defer collectorMu.Unlock()
@@ -128,7 +264,7 @@ func addVote(c *consensusCollector, addr string, sig string) bool {
 	if c.votedPeers[addr] {
 		return false
 	}
-	c.signatures = append(c.signatures, sig)
+	c.signatures = append(c.signatures, ConsensusSig{Addr: addr, KeyFP: pubKeyFingerprint(consensusKeys()[addr]), Sig: sig})
 	c.votedPeers[addr] = true
 	return true
 }
@@ -142,7 +278,7 @@ func broadcastToAll(path string, data any) {
 	body, _ := json.Marshal(data)
 	nodes := append(peersSnapshot(), self) // 나 포함 전체 전파
 	for _, node := range nodes {
-		go http.Post("http://"+node+path, "application/json", bytes.NewReader(body))
+		go peerClient.Post("http://"+node+path, "application/json", bytes.NewReader(body))
 	}
 }
 
@@ -158,7 +294,7 @@ func createProposedBlock(entries []ClinicRecord) LowerBlock {
 		Timestamp:  time.Now().Format(time.RFC3339),
 		Entries:    entries,
 		Proposer:   self,
-		Signatures: []string{}, // 아직 다른 노드 서명은 없음
+		Signatures: []ConsensusSig{}, // 아직 다른 노드 서명은 없음
 	}
 
 	// 머클루트 계산 및 블록 해시 생성
@@ -166,14 +302,14 @@ func createProposedBlock(entries []ClinicRecord) LowerBlock {
 	for i, r := range entries {
 		leafHashes[i] = hashClinicRecord(r)
 	}
-	newBlock.MerkleRoot = merkleRootHex(leafHashes)
+	newBlock.MerkleRoot = merkle.Root(leafHashes)
 	newBlock.LeafHashes = leafHashes
 	newBlock.BlockHash = newBlock.computeHash() //
 
 	// 리더(자신)의 서명 생성하여 추가
 	myPriv, _ := getMeta("meta_hos_privkey")                     //
 	mySig := makeAnchorSignature(myPriv, newBlock.BlockHash, "") //
-	newBlock.Signatures = append(newBlock.Signatures, mySig)
+	newBlock.Signatures = append(newBlock.Signatures, ConsensusSig{Addr: self, KeyFP: pubKeyFingerprint(consensusKeys()[self]), Sig: mySig})
 
 	return newBlock
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"encoding/json"
+	"encoding/pem"
 	"log"
 	"strings"
 )
@@ -20,12 +22,40 @@ type LowerBlock struct {
 	Entries    []ClinicRecord `json:"entries"`     // 블록 내 진료 정보 목록
 	MerkleRoot string         `json:"merkle_root"` // Entries의 해시 기반 머클루트
 	Proposer   string         `json:"proposer"`    // 해당 블록의 합의 집행자
-	Signatures []string       `json:"signatures"`  // 2f+1개 이상의 노드 서명 목록 (합의 증거)
+	Signatures []ConsensusSig `json:"signatures"`  // 2f+1개 이상의 노드 서명 목록 (합의 증거)
 	BlockHash  string         `json:"block_hash"`  // 블록 전체 해시 (헤더 기준)
 	Elapsed    float32        `json:"elapsed"`     // 소요 시간
 	LeafHashes []string       `json:"leaf_hashes"` // Merkle Proof 재현을 위한 해시값 모음
 }
 
+// 합의 서명 (서명 노드 주소 + 공개키 지문 + 블록 해시에 대한 ECDSA 서명)
+// - 검증 시 Addr 의 공개키 하나로만 확인 (countValidSignatures)
+type ConsensusSig struct {
+	Addr  string `json:"addr"`
+	KeyFP string `json:"pubkey_fingerprint"` // 서명 시점 공개키의 SHA-256 지문 (pubKeyFingerprint)
+	Sig   string `json:"sig"`                // hex, DER
+}
+
+// 기존 블록의 서명 문자열("<sig hex>")도 서명자 없는 ConsensusSig 로 읽음
+func (s *ConsensusSig) UnmarshalJSON(data []byte) error {
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		*s = ConsensusSig{Sig: legacy}
+		return nil
+	}
+	type plain ConsensusSig
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// 공개키 PEM 의 지문 (DER 의 SHA-256 hex)
+func pubKeyFingerprint(pubPem string) string {
+	block, _ := pem.Decode([]byte(pubPem))
+	if block == nil {
+		return ""
+	}
+	return sha256Hex(block.Bytes)
+}
+
 // 제네시스 블록 생성
 func createGenesisBlock(hosID string) LowerBlock {
 	log.Printf("[Blk] Start genesis block...")
@@ -36,8 +66,8 @@ func createGenesisBlock(hosID string) LowerBlock {
 		Timestamp:  "2026-01-21 T01:07:18Z",
 		Entries:    []ClinicRecord{},
 		MerkleRoot: "",
-		Proposer:   "SYSTEM",   // 제네시스는 시스템에 의해 생성됨
-		Signatures: []string{}, // 제네시스는 투표 절차 생략
+		Proposer:   "SYSTEM",         // 제네시스는 시스템에 의해 생성됨
+		Signatures: []ConsensusSig{}, // 제네시스는 투표 절차 생략
 		Elapsed:    0,
 		LeafHashes: []string{},
 	}
@@ -27,20 +27,20 @@ type registerResp struct {
 // 신규노드가 네트워크 진입 시 부트노드에게 다른 노드들의 주소를 제공받기 위한 함수
 func registerPeer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req registerReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Addr == "" || req.PubKey == "" {
-		http.Error(w, "invalid body", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid body")
 		return
 	}
 
 	// 체인 ID 확인
 	blk0, err := getBlockByIndex(0)
 	if err != nil || blk0.HosID != req.HosID {
-		http.Error(w, "hos_id mismatch", http.StatusForbidden)
+		writeError(w, http.StatusForbidden, "hos_id mismatch")
 		log.Printf("[BOOT] Join denied: hos_id mismatch (%s)", req.HosID)
 		return
 	}
@@ -100,7 +100,7 @@ func notifyNewPeerWithKey(newAddr, newPubKey string) {
 				"addr":    newAddr,
 				"pub_key": newPubKey,
 			})
-			_, err := http.Post("http://"+dst+"/addPeer", "application/json", bytes.NewReader(body))
+			_, err := peerClient.Post("http://"+dst+"/addPeer", "application/json", bytes.NewReader(body))
 			if err != nil {
 				log.Printf("[BOOT] Failed to notify %s about new peer", dst)
 			}
@@ -194,7 +194,7 @@ func broadcastNewBoot(newBoot string) {
 	for _, p := range peersSnapshot() {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"addr": newBoot})
-			_, err := http.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
+			_, err := peerClient.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -205,7 +205,7 @@ func broadcastNewBoot(newBoot string) {
 // 부트노드 변경 수신(모든 노드 수행)
 func bootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -214,12 +214,12 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.Addr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.Addr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.Addr)
 		return
 	}
@@ -241,7 +241,7 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 // POST /chgGovBoot
 func chgGovBoot(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -250,12 +250,12 @@ func chgGovBoot(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.GovAddr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.GovAddr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new Gov Boot addr (%s) but not reachable", in.GovAddr)
 		return
 	}
@@ -273,7 +273,7 @@ func broadcastNewGovBoot(govBoot string) {
 		go func(dst string) {
 			log.Printf("[BOOT][Gov] HosBOOT is now sending New GovBootNode's Addr to : %s", dst)
 			body, _ := json.Marshal(map[string]string{"addr": govBoot})
-			_, err := http.Post("http://"+dst+"/govBootNotify", "application/json", strings.NewReader(string(body)))
+			_, err := peerClient.Post("http://"+dst+"/govBootNotify", "application/json", strings.NewReader(string(body)))
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -285,7 +285,7 @@ func broadcastNewGovBoot(govBoot string) {
 // POST : /govBootNotify
 func govBootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -294,12 +294,12 @@ func govBootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.GovAddr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 gov 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.GovAddr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.GovAddr)
 		return
 	}
@@ -40,7 +40,6 @@ var (
 	chainMu            sync.Mutex   // 내부 체인 상태 보호용 뮤텍스
 	self               string       // 현재 노드 주소 NODE_ADDR (예: "hos-node-01:5000")
 	boot               string       // 현재 네트워크 상의 부트노드 주소
-	proposer           string       // BFT 합의를 위한 리더노드 주소
 	startedAt          = time.Now() // 현재 노드 시작 시간
 	isBoot             atomic.Bool  // 현재 노드가 부트노드인지 여부
 	bootAddrMu         sync.RWMutex // 부트노드 주소 접근 시 동시성 보호용 RW 잠금 객체
@@ -73,14 +72,8 @@ func newLowerChain(hosID string) (*LowerChain, error) {
 		genesis = createGenesisBlock(hosID)
 
 		// 체인에 추가
-		if err := saveBlockToDB(genesis); err != nil {
-			return nil, fmt.Errorf("save genesis block: %w", err)
-		}
-		if err := updateIndicesForBlock(genesis); err != nil {
-			return nil, fmt.Errorf("update genesis indices: %w", err)
-		}
-		if err := setLatestHeight(genesis.Index); err != nil {
-			return nil, fmt.Errorf("set genesis height: %w", err)
+		if err := commitBlock(genesis); err != nil {
+			return nil, fmt.Errorf("commit genesis block: %w", err)
 		}
 
 		ch.lastBlockTime = time.Now()
@@ -98,6 +91,15 @@ func newLowerChain(hosID string) (*LowerChain, error) {
 
 // 합의가 완료된 블록 처리
 func onBlockReceived(lb LowerBlock) error {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	// 0. 이미 반영된 블록이면 무시 (같은 블록으로 두 번 호출되어도 안전)
+	if isBlockApplied(lb.BlockHash) {
+		log.Printf("[CHAIN] Block #%d already applied. Skipping.", lb.Index)
+		return nil
+	}
+
 	// 1. PBFT 정족수(2f+1) 및 서명 검증
 	if err := verifyConsensusEvidence(lb); err != nil {
 		return fmt.Errorf("consensus verification failed: %w", err)
@@ -111,22 +113,23 @@ func onBlockReceived(lb LowerBlock) error {
 	if lb.PrevHash != prev.BlockHash {
 		return fmt.Errorf("invalid hash link")
 	}
-
-	// 3. 로컬 장부 반영
-	if err := saveBlockToDB(lb); err != nil {
-		return fmt.Errorf("save block: %w", err)
-	}
-	if err := updateIndicesForBlock(lb); err != nil {
-		return fmt.Errorf("update indices: %w", err)
+	if h, ok := getLatestHeight(); ok && lb.Index <= h {
+		return fmt.Errorf("height %d already has another block", lb.Index)
 	}
-	if err := setLatestHeight(lb.Index); err != nil {
-		return fmt.Errorf("set height: %w", err)
+
+	// 3. 로컬 장부 반영 (본문/인덱스/높이/반영 표시를 한 번에 기록)
+	if err := commitBlock(lb); err != nil {
+		return fmt.Errorf("commit block: %w", err)
 	}
 
 	ch.lastBlockTime = time.Now()
 
-	// 4. 합의 상태 초기화
-	ConsPhase.Store(ConsIdle)
+	// 확정된 레코드는 메모리풀에서 제거 (중계받은 사본 포함)
+	removePendingFinalized(lb.LeafHashes)
+
+	// 4. 합의 상태 초기화 (내 제안 중 이 블록에 빠진 레코드는 메모리풀로 복구)
+	settleOwnProposal(lb.LeafHashes)
+	setPhase(ConsIdle)
 
 	// 5. 부트노드라면 상위 체인(Gov)으로 앵커링 전송
 	if self == boot {
@@ -154,51 +157,56 @@ func verifyConsensusEvidence(lb LowerBlock) error {
 	// 2. 검증할 메시지 해시 생성 (블록 해시 기준)
 	msgHash := sha256.Sum256([]byte(lb.BlockHash))
 
-	validCount := 0
-	checkedPeers := make(map[string]bool) // 동일 노드의 중복 서명 방지용
-
-	// 3. 서명 슬라이스 순회 (여기서 addr은 인덱스 int입니다)
-	for _, sigHex := range lb.Signatures {
-		found := false
+	// 3. 서명마다 명시된 서명자의 공개키로 검증
+	validCount := countValidSignatures(lb, msgHash[:], consensusKeys())
 
-		// 내 서명인지 먼저 확인 (가장 빠름)
-		myPubKey, _ := getMeta("meta_hos_pubkey")
-		if !checkedPeers[self] && verifyECDSA(myPubKey, msgHash[:], sigHex) {
-			validCount++
-			checkedPeers[self] = true
-			found = true
-		}
+	// 4. 유효 정족수 최종 확인
+	if validCount < required {
+		return fmt.Errorf("valid signatures insufficient: %d/%d (required %d)", validCount, required, required)
+	}
 
-		// 내 서명이 아니라면 피어들 명단에서 대조
-		if !found {
-			for _, pAddr := range peers {
-				if checkedPeers[pAddr] {
-					continue // 이미 검증 완료된 피어는 스킵
-				}
+	log.Printf("[BFT] Block #%d verified with %d valid signatures", lb.Index, validCount)
+	return nil
+}
 
-				pubPem := peerPubKeys[pAddr]
-				if pubPem == "" {
-					continue
-				}
+// 합의 참여 노드의 공개키 (주소 => 공개키 PEM, 자신 포함)
+func consensusKeys() map[string]string {
+	keys := make(map[string]string)
+	pkMu.RLock()
+	for addr, pub := range peerPubKeys {
+		keys[addr] = pub
+	}
+	pkMu.RUnlock()
+	if myPubKey, ok := getMeta("meta_hos_pubkey"); ok {
+		keys[self] = myPubKey
+	}
+	return keys
+}
 
-				// ECDSA 대조 연산 (CPU 집약적)
-				if verifyECDSA(pubPem, msgHash[:], sigHex) {
-					validCount++
-					checkedPeers[pAddr] = true
-					found = true
+// 블록 서명 중 keys(주소 => 공개키) 노드의 유효 서명 수 (노드당 1개)
+// - 서명마다 명시된 서명자(Addr)의 공개키로만 검증, 지문이 현재 공개키와 다르면 무효
+// - 서명자 정보가 없는 기존 블록 서명만 모든 공개키와 대조
+func countValidSignatures(b LowerBlock, hash []byte, keys map[string]string) int {
+	signed := make(map[string]bool) // 동일 노드의 중복 서명 방지용
+	for _, s := range b.Signatures {
+		if s.Addr == "" {
+			for addr, pub := range keys {
+				if !signed[addr] && verifyECDSA(pub, hash, s.Sig) {
+					signed[addr] = true
 					break // 이 서명의 주인을 찾았으므로 다음 서명으로
 				}
 			}
+			continue
+		}
+		pub := keys[s.Addr]
+		if signed[s.Addr] || pub == "" || (s.KeyFP != "" && s.KeyFP != pubKeyFingerprint(pub)) {
+			continue
+		}
+		if verifyECDSA(pub, hash, s.Sig) {
+			signed[s.Addr] = true
 		}
 	}
-
-	// 4. 유효 정족수 최종 확인
-	if validCount < required {
-		return fmt.Errorf("valid signatures insufficient: %d/%d (required %d)", validCount, required, required)
-	}
-
-	log.Printf("[BFT] Block #%d verified with %d valid signatures", lb.Index, validCount)
-	return nil
+	return len(signed)
 }
 
 // 체인의 메모리풀인 pending에 컨텐츠 내용 추가
@@ -222,6 +230,23 @@ func getPending() []ClinicRecord {
 	return entries
 }
 
+// 블록에 포함되어 확정된 레코드를 메모리풀에서 제거
+func removePendingFinalized(leafHashes []string) {
+	finalized := make(map[string]bool, len(leafHashes))
+	for _, h := range leafHashes {
+		finalized[h] = true
+	}
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	kept := ch.pending[:0]
+	for _, rec := range ch.pending {
+		if !finalized[hashClinicRecord(rec)] {
+			kept = append(kept, rec)
+		}
+	}
+	ch.pending = kept
+}
+
 // 메모리풀이 비어있는 지 확인
 func pendingIsEmpty() bool {
 	ch.pendingMu.Lock()
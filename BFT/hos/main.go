@@ -21,6 +21,9 @@ func main() {
 	self = getEnvDefault("NODE_ADDR", "hos-node-00:5000")          // 이 노드의 외부접속 주소
 	govBoot = getEnvDefault("GOV_BOOTSTRAP_ADDR", "gov-boot:5000") // GOV체인 부트노드 주소
 
+	// API 인증 설정 (API_KEYS / JWT_SECRET / PEER_TOKEN)
+	initAuth()
+
 	// 2) DB 초기화
 	initDB(dbPath)
 	defer closeDB()
@@ -46,15 +49,18 @@ func main() {
 	//	   - /getPublicKey : 공개키 반환
 	//	   - /chgGovBoot : 신규 선출된 Gov 부트노드 주소를 Hos 부트노드가 수신
 	//	   - /govBootNotify : Hos 부트노드로부터 전파된 Gov 부트노드 주소 수신
-	mux.HandleFunc("/addPeer", addPeer)
-	mux.HandleFunc("/bft/start", handleBftStart)
-	mux.HandleFunc("/bft/prepare", handleReceivePrepare)
-	mux.HandleFunc("/bft/commit", handleReceiveCommit)
-	mux.HandleFunc("/register", registerPeer)
-	mux.HandleFunc("/bootNotify", bootNotify)
+	//	   (같은 체인 노드 간 엔드포인트는 X-Chain-ID 가 없거나 다른 요청 거절 : chainid.go)
+	//	   (인증 사용 시 노드 간 엔드포인트는 peer 역할 필요 : auth.go)
+	mux.HandleFunc("/addPeer", requireRole(RolePeer, addPeer))
+	mux.HandleFunc("/bft/start", requireSameChain(requireRole(RolePeer, handleBftStart)))
+	mux.HandleFunc("/bft/prepare", requireSameChain(requireRole(RolePeer, handleReceivePrepare)))
+	mux.HandleFunc("/bft/commit", requireSameChain(requireRole(RolePeer, handleReceiveCommit)))
+	mux.HandleFunc("/pending/relay", requireSameChain(requireRole(RolePeer, handlePendingRelay)))
+	mux.HandleFunc("/register", requireRole(RolePeer, registerPeer))
+	mux.HandleFunc("/bootNotify", requireRole(RolePeer, bootNotify))
 	mux.HandleFunc("/getPublicKey", getPublicKey)
 	mux.HandleFunc("/chgGovBoot", chgGovBoot)
-	mux.HandleFunc("/govBootNotify", govBootNotify)
+	mux.HandleFunc("/govBootNotify", requireRole(RolePeer, govBootNotify))
 
 	mux.Handle("/", http.FileServer(http.Dir("./static")))
 
@@ -86,7 +92,7 @@ func main() {
 		}
 		b, _ := json.Marshal(payload)
 
-		resp, err := http.Post("http://"+boot+"/register", "application/json", strings.NewReader(string(b)))
+		resp, err := peerClient.Post("http://"+boot+"/register", "application/json", strings.NewReader(string(b)))
 		if err != nil {
 			log.Printf("[BOOT] register failed: %v", err)
 			return
@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 	"time"
+
+	"merkle"
 )
 
 // 노드 상태 구조체, /status API 호출 시 응답받는 JSON 구조
@@ -22,7 +24,7 @@ type nodeStatus struct {
 // 해당 노드의 현재 상태(nodeStatus)를 가져옴
 func probeStatus(addr string) (nodeStatus, bool) {
 	var s nodeStatus
-	resp, err := http.Get("http://" + addr + "/status")
+	resp, err := peerClient.Get("http://" + addr + "/status")
 	if err != nil {
 		return s, false
 	}
@@ -60,7 +62,7 @@ func validateLowerBlock(newBlk, prevBlk LowerBlock) error {
 	for i, r := range newBlk.Entries {
 		leaf[i] = hashClinicRecord(r)
 	}
-	expectedRoot := merkleRootHex(leaf)
+	expectedRoot := merkle.Root(leaf)
 	if expectedRoot != newBlk.MerkleRoot {
 		return fmt.Errorf("merkle_root mismatch")
 	}
@@ -89,7 +91,7 @@ func syncChain(peer string) {
 	url := "http://" + peer + "/blocks"
 
 	// 원격에서 전체 블록 수신
-	resp, err := http.Get(url)
+	resp, err := peerClient.Get(url)
 	if err != nil {
 		log.Printf("[P2P] Failed to sync from %s: %v\n", peer, err)
 		return
@@ -125,6 +127,12 @@ func syncChain(peer string) {
 	for _, nb := range page.Items {
 		chainMu.Lock()
 
+		// 이미 반영된 블록은 건너뜀
+		if isBlockApplied(nb.BlockHash) {
+			chainMu.Unlock()
+			continue
+		}
+
 		if nb.Index != 0 {
 			prev, err := getBlockByIndex(nb.Index - 1)
 			if err != nil {
@@ -143,20 +151,10 @@ func syncChain(peer string) {
 			log.Printf("[P2P] Fetching genesis from %s", peer)
 		}
 
-		// append
-		if err := saveBlockToDB(nb); err != nil {
-			chainMu.Unlock()
-			log.Printf("[P2P] saveBlockToDB error: %v\n", err)
-			return
-		}
-		if err := updateIndicesForBlock(nb); err != nil {
-			chainMu.Unlock()
-			log.Printf("[P2P] updateIndicesForBlock error: %v\n", err)
-			return
-		}
-		if err := setLatestHeight(nb.Index); err != nil {
+		// append (본문/인덱스/높이를 한 번에 기록)
+		if err := commitBlock(nb); err != nil {
 			chainMu.Unlock()
-			log.Printf("[P2P] setLatestHeight error: %v\n", err)
+			log.Printf("[P2P] commitBlock error: %v\n", err)
 			return
 		}
 
@@ -177,7 +175,7 @@ func addPeer(w http.ResponseWriter, r *http.Request) {
 	}
 	// 부트노드가 보낸 JSON 객체 파싱해
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid peer format", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid peer format")
 		return
 	}
 
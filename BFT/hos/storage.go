@@ -71,34 +71,53 @@ func closeDB() {
 // 블록 저장/조회
 ////////////////////////////////////////////////////////////////////////////////
 
-// LowerBlock 전체를 JSON으로 저장
+// 블록 본문 + 검색 인덱스 + 최신 높이 + 반영 표시를 하나의 Batch로 원자적 반영
+// - 중간에 프로세스가 죽어도 "본문만 있고 인덱스/높이가 없는" 블록이 남지 않음
+// - 반영 표시 "applied_<BlockHash>" => Index : 같은 블록 재반영 차단 (isBlockApplied)
+func commitBlock(block LowerBlock) error {
+	batch := new(leveldb.Batch)
+	if err := saveBlockToBatch(batch, block); err != nil {
+		return err
+	}
+	updateIndicesForBlock(batch, block)
+	batch.Put([]byte("height_latest"), []byte(strconv.Itoa(block.Index)))
+	batch.Put(appliedKey(block.BlockHash), []byte(strconv.Itoa(block.Index)))
+	if err := db.Write(batch, nil); err != nil {
+		return err
+	}
+	log.Printf("[DB] Block #%d committed (Hash=%s, %d keys)\n", block.Index, block.BlockHash, batch.Len())
+	appendBlockLog(block)
+	return nil
+}
+
+func appliedKey(hash string) []byte {
+	return []byte("applied_" + hash)
+}
+
+// 이미 장부에 반영된 블록인지 (반영 표시 확인)
+func isBlockApplied(hash string) bool {
+	ok, _ := db.Has(appliedKey(hash), nil)
+	return ok
+}
+
+// LowerBlock 전체를 JSON으로 Batch 에 기록
 // - Key1: "block_<Index>"     => LowerBlock JSON (번호 기반 접근)
 // - Key2: "hash_<BlockHash>"  => LowerBlock JSON (해시 기반 접근)
 // 주: 키 형식은 기존 코드와의 호환을 위해 유지
-func saveBlockToDB(block LowerBlock) error {
+func saveBlockToBatch(batch *leveldb.Batch, block LowerBlock) error {
 	data, err := json.Marshal(block)
 	if err != nil {
 		return err
 	}
 
 	// 블록 번호 기반 저장
-	keyByIndex := fmt.Sprintf("block_%d", block.Index)
-	if err := db.Put([]byte(keyByIndex), data, nil); err != nil {
-		return err
-	}
+	batch.Put([]byte(fmt.Sprintf("block_%d", block.Index)), data)
 
 	// 블록 해시 기반 저장
-	keyByHash := fmt.Sprintf("hash_%s", block.BlockHash)
-	if err := db.Put([]byte(keyByHash), data, nil); err != nil {
-		return err
-	}
+	batch.Put([]byte(fmt.Sprintf("hash_%s", block.BlockHash)), data)
 
 	// 최신 루트 캐시(선택)
-	if err := db.Put([]byte("root_latest"), []byte(block.MerkleRoot), nil); err != nil {
-		return err
-	}
-	log.Printf("[DB] Block #%d saved (Hash=%s)\n", block.Index, block.BlockHash)
-	appendBlockLog(block)
+	batch.Put([]byte("root_latest"), []byte(block.MerkleRoot))
 	return nil
 }
 
@@ -143,7 +162,7 @@ func getLatestRoot() string {
 //  - 블록 단위로 cid/pc/info 색인을 "<blockIndex>:<entryIndex>" 포인터로 저장
 ////////////////////////////////////////////////////////////////////////////////
 
-func updateIndicesForBlock(block LowerBlock) error {
+func updateIndicesForBlock(batch *leveldb.Batch, block LowerBlock) {
 	// 포인터 문자열: "blockIndex:entryIndex"
 	ptr := func(bi, ei int) []byte { return []byte(fmt.Sprintf("%d:%d", bi, ei)) }
 
@@ -151,17 +170,13 @@ func updateIndicesForBlock(block LowerBlock) error {
 		// 1) ClinicID 색인: "cid_<ClinicID>" -> "bi:ei"
 		if entry.ClinicID != "" {
 			keyByCID := fmt.Sprintf("cid_%s", entry.ClinicID)
-			if err := db.Put([]byte(keyByCID), ptr(block.Index, ei), nil); err != nil {
-				return err
-			}
+			batch.Put([]byte(keyByCID), ptr(block.Index, ei))
 		}
 
 		// 2) PrescCode 색인: "pc_<PrescCode>" -> "bi:ei"
 		if entry.PrescCode != "" {
 			keyByPC := fmt.Sprintf("pc_%s", entry.PrescCode)
-			if err := db.Put([]byte(keyByPC), ptr(block.Index, ei), nil); err != nil {
-				return err
-			}
+			batch.Put([]byte(keyByPC), ptr(block.Index, ei))
 		}
 
 		// 3) Info 키워드 색인(간단 버전)
@@ -173,15 +188,12 @@ func updateIndicesForBlock(block LowerBlock) error {
 				continue
 			}
 			key := fmt.Sprintf("info_%s_%s", k, strings.ToLower(strVal))
-			if err := db.Put([]byte(key), ptr(block.Index, ei), nil); err != nil {
-				return err
-			}
+			batch.Put([]byte(key), ptr(block.Index, ei))
 		}
 	}
 
 	log.Printf("[DB] Indices updated for Block #%d (%d entries)\n",
 		block.Index, len(block.Entries))
-	return nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// 운영(관리) API : /v1/meta, /peers, /jobs, /admin/finalize, /admin/resync, /admin/export, /admin/import
+// Hos / Gov 노드 공통이므로 두 클라이언트가 같은 구현을 사용
+
+// GET /v1/meta
+type NodeMeta struct {
+	APIVersions []string `json:"api_versions"`
+	Current     string   `json:"current"`
+	NodeRole    string   `json:"node_role"` // "hos" | "gov"
+	ChainID     string   `json:"chain_id"`
+	Node        string   `json:"node"`
+	Features    []string `json:"features"`
+	TLS         bool     `json:"tls"`
+	Region      string   `json:"region,omitempty"`
+}
+
+// GET /chain/info : 체인 식별 정보
+type ChainInfo struct {
+	ChainID          string `json:"chain_id"`
+	GenesisHash      string `json:"genesis_hash"`
+	GenesisTimestamp string `json:"genesis_timestamp"`
+	Consensus        string `json:"consensus"` // "pbft" | "pow"
+	HashProfile      string `json:"hash_profile"`
+	PoWHash          string `json:"pow_hash,omitempty"` // Gov 블록 헤더 PoW 해시 규칙
+	ValidatorSetHash string `json:"validator_set_hash"`
+	Validators       int    `json:"validators"`
+	ProtocolVersion  int    `json:"protocol_version"`
+	Height           int    `json:"height"`
+	Difficulty       int    `json:"difficulty,omitempty"`
+	// Gov 체인 난이도 조정 파라미터
+	DifficultyParams *GovDifficultyParams `json:"difficulty_params,omitempty"`
+}
+
+type CircuitState struct {
+	State     string `json:"state"` // closed | open | half-open
+	Failures  int    `json:"failures"`
+	OpenedAt  string `json:"opened_at,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// GET /peers?detail=true
+type PeerDetail struct {
+	Addr    string       `json:"addr"`
+	Alive   bool         `json:"alive"`
+	Circuit CircuitState `json:"circuit"`
+}
+
+// 비동기 관리 작업 (/jobs/{id})
+type Job struct {
+	ID         string          `json:"id"`
+	Kind       string          `json:"kind"`
+	Status     string          `json:"status"` // queued | running | succeeded | failed | canceled
+	Done       int             `json:"done"`
+	Total      int             `json:"total"`
+	Error      string          `json:"error,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+	StartedAt  string          `json:"started_at,omitempty"`
+	FinishedAt string          `json:"finished_at,omitempty"`
+}
+
+func (j Job) Finished() bool {
+	return j.Status == "succeeded" || j.Status == "failed" || j.Status == "canceled"
+}
+
+// POST /admin/finalize 응답 (Hos : 합의 예약, Gov : 채굴 신호 전파)
+type FinalizeResult struct {
+	Status     string `json:"status"`
+	Pending    int    `json:"pending,omitempty"`  // Hos 메모리풀 레코드 수
+	Proposer   string `json:"proposer,omitempty"` // Hos 다음 블록 제안자 (status = "forwarded" 이면 전달 대상)
+	InProgress bool   `json:"in_progress,omitempty"`
+	Anchors    int    `json:"anchors,omitempty"` // Gov 채굴 대상 앵커 수
+}
+
+// POST /admin/import 응답
+type ImportResult struct {
+	Status    string `json:"status"`
+	Imported  int    `json:"imported"` // 새로 반영한 블록 수
+	Skipped   int    `json:"skipped"`  // 로컬에 이미 있던 블록 수
+	Height    int    `json:"height"`
+	BlockHash string `json:"block_hash"`
+}
+
+func (n *node) meta(ctx context.Context) (NodeMeta, error) {
+	var m NodeMeta
+	_, err := n.do(ctx, http.MethodGet, "/meta", nil, &m)
+	return m, err
+}
+
+func (n *node) chainInfo(ctx context.Context) (ChainInfo, error) {
+	var ci ChainInfo
+	_, err := n.do(ctx, http.MethodGet, "/chain/info", nil, &ci)
+	return ci, err
+}
+
+func (n *node) peers(ctx context.Context) ([]PeerDetail, error) {
+	var ps []PeerDetail
+	_, err := n.do(ctx, http.MethodGet, "/peers?detail=true", nil, &ps)
+	return ps, err
+}
+
+func (n *node) job(ctx context.Context, id string) (Job, error) {
+	var j Job
+	_, err := n.do(ctx, http.MethodGet, "/jobs/"+url.PathEscape(id), nil, &j)
+	return j, err
+}
+
+// 작업이 끝날 때까지 interval 간격으로 조회
+func (n *node) waitJob(ctx context.Context, id string, interval time.Duration) (Job, error) {
+	for {
+		j, err := n.job(ctx, id)
+		if err != nil || j.Finished() {
+			return j, err
+		}
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return j, ctx.Err()
+		}
+	}
+}
+
+func (n *node) finalize(ctx context.Context) (FinalizeResult, error) {
+	var res FinalizeResult
+	_, err := n.do(ctx, http.MethodPost, "/admin/finalize", nil, &res)
+	return res, err
+}
+
+func (n *node) resync(ctx context.Context) (Job, error) {
+	var j Job
+	_, err := n.do(ctx, http.MethodPost, "/admin/resync", nil, &j)
+	return j, err
+}
+
+// GET /v1/meta : 노드 역할(hos/gov), 체인 ID, 지원 기능
+func (c *HosClient) Meta(ctx context.Context) (NodeMeta, error) { return c.n.meta(ctx) }
+func (c *GovClient) Meta(ctx context.Context) (NodeMeta, error) { return c.n.meta(ctx) }
+
+// GET /chain/info : 체인 ID, 제네시스 해시, 합의 방식, 해시 규칙
+func (c *HosClient) ChainInfo(ctx context.Context) (ChainInfo, error) { return c.n.chainInfo(ctx) }
+func (c *GovClient) ChainInfo(ctx context.Context) (ChainInfo, error) { return c.n.chainInfo(ctx) }
+
+// GET /peers?detail=true : 피어별 생존 여부와 회로 차단기 상태
+func (c *HosClient) Peers(ctx context.Context) ([]PeerDetail, error) { return c.n.peers(ctx) }
+func (c *GovClient) Peers(ctx context.Context) ([]PeerDetail, error) { return c.n.peers(ctx) }
+
+// GET /jobs/{id}
+func (c *HosClient) Job(ctx context.Context, id string) (Job, error) { return c.n.job(ctx, id) }
+func (c *GovClient) Job(ctx context.Context, id string) (Job, error) { return c.n.job(ctx, id) }
+
+func (c *HosClient) WaitJob(ctx context.Context, id string, interval time.Duration) (Job, error) {
+	return c.n.waitJob(ctx, id, interval)
+}
+func (c *GovClient) WaitJob(ctx context.Context, id string, interval time.Duration) (Job, error) {
+	return c.n.waitJob(ctx, id, interval)
+}
+
+// POST /admin/finalize : 메모리풀 레코드로 즉시 합의 라운드 시작 (제안자가 아니면 제안자에게 전달)
+func (c *HosClient) Finalize(ctx context.Context) (FinalizeResult, error) { return c.n.finalize(ctx) }
+
+// POST /admin/finalize : 대기 중인 앵커로 즉시 채굴 시작 (채굴 중이거나 대기 앵커가 없으면 ErrRejected)
+func (c *GovClient) Finalize(ctx context.Context) (FinalizeResult, error) { return c.n.finalize(ctx) }
+
+// POST /admin/resync : 피어 체인과 즉시 동기화/분기 교체 작업 시작 (결과는 WaitJob 으로 확인)
+func (c *HosClient) Resync(ctx context.Context) (Job, error) { return c.n.resync(ctx) }
+func (c *GovClient) Resync(ctx context.Context) (Job, error) { return c.n.resync(ctx) }
+
+// 아카이브 스트림 요청 : 크기를 알 수 없으므로 재시도/클라이언트 제한 시간 없이 ctx 로만 제한
+func (n *node) stream(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, n.base+apiPrefix+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	}
+	n.setHeaders(req)
+	hc := *n.http
+	hc.Timeout = 0
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		return nil, newAPIError(method, path, resp.StatusCode, msg)
+	}
+	return resp, nil
+}
+
+func (n *node) exportChain(ctx context.Context, w io.Writer, from int) (int64, error) {
+	resp, err := n.stream(ctx, http.MethodGet, fmt.Sprintf("/admin/export?from=%d", from), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return io.Copy(w, resp.Body)
+}
+
+func (n *node) importChain(ctx context.Context, r io.Reader) (ImportResult, error) {
+	var res ImportResult
+	resp, err := n.stream(ctx, http.MethodPost, "/admin/import", r)
+	if err != nil {
+		return res, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return res, fmt.Errorf("decode /admin/import response: %w", err)
+	}
+	return res, nil
+}
+
+// GET /admin/export : from 번 블록부터 NDJSON 아카이브(1행 매니페스트 + 블록 한 줄씩)를 w 에 기록, 기록한 바이트 수 반환
+func (c *HosClient) ExportChain(ctx context.Context, w io.Writer, from int) (int64, error) {
+	return c.n.exportChain(ctx, w, from)
+}
+func (c *GovClient) ExportChain(ctx context.Context, w io.Writer, from int) (int64, error) {
+	return c.n.exportChain(ctx, w, from)
+}
+
+// POST /admin/import : 아카이브를 검증하며 노드 체인에 반영 (다른 체인이거나 로컬과 갈라지면 ErrRejected)
+func (c *HosClient) ImportChain(ctx context.Context, r io.Reader) (ImportResult, error) {
+	return c.n.importChain(ctx, r)
+}
+func (c *GovClient) ImportChain(ctx context.Context, r io.Reader) (ImportResult, error) {
+	return c.n.importChain(ctx, r)
+}
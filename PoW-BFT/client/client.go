@@ -0,0 +1,256 @@
+// Package client 는 Hos(하위 체인) / Gov(상위 체인) 노드 HTTP API 의 Go 클라이언트.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Client SDK (노드 HTTP API 래퍼)
+// ------------------------------------------------------------
+// - NewHosClient / NewGovClient : 노드 주소("host:port" 또는 "https://host:port") 하나에 대한 클라이언트
+//   · 모든 요청은 /v1 경로 사용 (기존 경로 폐기 예정 헤더 회피)
+// - 운영/노드 인증이 켜진 노드는 WithAPIKey 로 자격 증명 첨부 (X-API-Key 헤더)
+// - 재시도 (WithRetries, 기본 3회, 지수 백오프)
+//   · 전송 오류, 502/504 : GET 만 재시도
+//   · 503 (부하 제한, loadshed.go) : 요청이 처리되지 않았으므로 POST 포함 재시도, Retry-After 준수
+// - 오류는 *APIError (상태 코드, 경로, 노드 오류 봉투의 code/message/details) 로 반환
+//   · errors.Is(err, ErrNotFound | ErrRejected | ErrForbidden | ErrUnavailable | ErrBadRequest) 로 분기
+// - 오프라인 검증 : VerifyMerkleProof(Version), VerifyFullProof, VerifyDisclosure (verify.go)
+// - 운영 API : Meta, Peers, ChainInfo, Finalize, Resync, Job/WaitJob, ExportChain/ImportChain (admin.go)
+// - 라이트 클라이언트 : 헤더만 검증 동기화 + 요청한 증명만 보관, NewLightClient (light.go)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	DefaultTimeout = 30 * time.Second
+	DefaultRetries = 3
+	DefaultBackoff = 200 * time.Millisecond
+	MaxRetryAfter  = 30 * time.Second // 노드가 알려준 Retry-After 상한
+
+	apiPrefix = "/v1"
+)
+
+var (
+	ErrBadRequest  = errors.New("bad request")
+	ErrForbidden   = errors.New("forbidden")
+	ErrNotFound    = errors.New("not found")
+	ErrRejected    = errors.New("rejected") // 409 : 중복/재전송 등으로 모두 거부
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// 노드가 2xx 외 상태로 응답한 경우
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Code       string          // 노드 오류 코드 (예: "not_found", "all_rejected"), 봉투가 아닌 응답이면 비어 있음
+	Message    string          // 오류 설명 (봉투가 아닌 응답이면 본문 전체)
+	Details    json.RawMessage // 부가 정보 (거부 목록 등)
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s %s: status=%d %s: %s", e.Method, e.Path, e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s %s: status=%d %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// 노드 오류 봉투 ({"code", "message", "details"}) 해석, 봉투가 아니면 본문을 Message 로
+func newAPIError(method, path string, status int, body []byte) *APIError {
+	ae := &APIError{Method: method, Path: path, StatusCode: status}
+	var env struct {
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	}
+	if json.Unmarshal(body, &env) == nil && env.Code != "" {
+		ae.Code, ae.Message, ae.Details = env.Code, env.Message, env.Details
+		return ae
+	}
+	ae.Message = strings.TrimSpace(string(body))
+	return ae
+}
+
+// errors.Is 분기용
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrForbidden:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRejected:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable || e.StatusCode == http.StatusBadGateway || e.StatusCode == http.StatusGatewayTimeout
+	}
+	return false
+}
+
+type Option func(*node)
+
+// http.Client 교체 (mTLS 등)
+func WithHTTPClient(c *http.Client) Option {
+	return func(n *node) { n.http = c }
+}
+
+// 재시도 횟수 (0 이면 재시도 안 함)
+func WithRetries(n int) Option {
+	return func(nd *node) {
+		if n >= 0 {
+			nd.retries = n
+		}
+	}
+}
+
+// 첫 재시도 대기 시간 (이후 2배씩)
+func WithBackoff(d time.Duration) Option {
+	return func(n *node) {
+		if d > 0 {
+			n.backoff = d
+		}
+	}
+}
+
+// 요청 주체 (X-Requester 헤더, Gov /patient/records 필수)
+func WithRequester(id string) Option {
+	return func(n *node) { n.requester = id }
+}
+
+// 노드 API 키 (X-API-Key 헤더, 관리 API 는 operator 역할 키 필요)
+func WithAPIKey(key string) Option {
+	return func(n *node) { n.apiKey = key }
+}
+
+// 노드 하나에 대한 공통 요청 처리
+type node struct {
+	base      string
+	http      *http.Client
+	retries   int
+	backoff   time.Duration
+	requester string
+	apiKey    string
+}
+
+// 공통 요청 헤더 (요청 주체, API 키)
+func (n *node) setHeaders(req *http.Request) {
+	if n.requester != "" {
+		req.Header.Set("X-Requester", n.requester)
+	}
+	if n.apiKey != "" {
+		req.Header.Set("X-API-Key", n.apiKey)
+	}
+}
+
+func newNode(addr string, opts []Option) *node {
+	base := strings.TrimRight(addr, "/")
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		base = "http://" + base
+	}
+	n := &node{
+		base:    base,
+		http:    &http.Client{Timeout: DefaultTimeout},
+		retries: DefaultRetries,
+		backoff: DefaultBackoff,
+	}
+	for _, o := range opts {
+		o(n)
+	}
+	return n
+}
+
+// 요청 + 재시도 + JSON 디코딩
+//   - body 가 nil 이 아니면 JSON 으로 전송
+//   - out 이 nil 이 아니면 응답 본문을 디코딩, 헤더가 필요하면 응답 헤더 반환
+func (n *node) do(ctx context.Context, method, path string, body, out any) (http.Header, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		hdr, retryAfter, err := n.once(ctx, method, path, payload, out)
+		if err == nil || attempt >= n.retries || !n.retryable(method, err) {
+			return hdr, err
+		}
+		wait := n.backoff << attempt
+		if retryAfter > 0 {
+			wait = min(retryAfter, MaxRetryAfter)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (n *node) once(ctx context.Context, method, path string, payload []byte, out any) (http.Header, time.Duration, error) {
+	var rd io.Reader
+	if payload != nil {
+		rd = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, n.base+apiPrefix+path, rd)
+	if err != nil {
+		return nil, 0, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	n.setHeaders(req)
+	resp, err := n.http.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		retryAfter := time.Duration(0)
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s > 0 {
+			retryAfter = time.Duration(s) * time.Second
+		}
+		return resp.Header, retryAfter, newAPIError(method, path, resp.StatusCode, msg)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.Header, 0, fmt.Errorf("decode %s response: %w", path, err)
+		}
+	}
+	return resp.Header, 0, nil
+}
+
+// 재시도 여부 : 503 은 모든 메서드, 그 외 일시 오류는 GET 만
+func (n *node) retryable(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var ae *APIError
+	if errors.As(err, &ae) {
+		if ae.StatusCode == http.StatusServiceUnavailable {
+			return true
+		}
+		return method == http.MethodGet && (ae.StatusCode == http.StatusBadGateway || ae.StatusCode == http.StatusGatewayTimeout)
+	}
+	return method == http.MethodGet // 전송 오류
+}
+
+// 페이지 조회 공통 응답
+type BlocksPage[T any] struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Items  []T `json:"items"`
+}
@@ -0,0 +1,258 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Gov 체인 노드 클라이언트 (앵커 검증, 포함 증명, 중계 검색)
+type GovClient struct {
+	n *node
+}
+
+func NewGovClient(addr string, opts ...Option) *GovClient {
+	return &GovClient{n: newNode(addr, opts)}
+}
+
+type GovStatus struct {
+	Addr       string            `json:"addr"`
+	Height     int               `json:"height"`
+	IsBoot     bool              `json:"is_boot"`
+	BootAddr   string            `json:"bootAddr"`
+	StartedAt  string            `json:"started_at"`
+	Peers      []string          `json:"peers"`
+	Difficulty int               `json:"difficulty"`
+	Retarget   *GovRetarget      `json:"retarget,omitempty"`
+	HosBoot    map[string]string `json:"hos_boot"`
+	LastHash   string            `json:"last_hash"`
+	TotalWork  string            `json:"total_work"`
+}
+
+// Gov 체인 난이도 조정 파라미터 (제네시스 생성 시 기록)
+type GovDifficultyParams struct {
+	TargetBlockTime int     `json:"target_block_time"`
+	Window          int     `json:"window"`
+	MinDifficulty   int     `json:"min_difficulty"`
+	MaxDifficulty   int     `json:"max_difficulty"`
+	RaiseRatio      float64 `json:"raise_ratio"`
+	LowerRatio      float64 `json:"lower_ratio"`
+}
+
+// GET /status 의 retarget : 다음 블록 난이도 조정 상태
+type GovRetarget struct {
+	Params       GovDifficultyParams `json:"params"`
+	Height       int                 `json:"height"`
+	Difficulty   int                 `json:"difficulty"`
+	Next         int                 `json:"next_difficulty"`
+	AvgBlockTime float64             `json:"avg_block_time"`
+	Ratio        float64             `json:"ratio"`
+	Adjustment   string              `json:"adjustment"` // raise | lower | hold | warmup
+}
+
+type AnchorRecord struct {
+	HosID           string `json:"hos_id"`
+	LowerRoot       string `json:"lower_root"`
+	AnchorTimestamp string `json:"anchor_ts"`
+	Kind            string `json:"kind,omitempty"`
+}
+
+type GovBlock struct {
+	Index      int            `json:"index"`
+	GovID      string         `json:"gov_id"`
+	PrevHash   string         `json:"prev_hash"`
+	Timestamp  string         `json:"timestamp"`
+	Records    []AnchorRecord `json:"records"`
+	MerkleRoot string         `json:"merkle_root"`
+	Nonce      int            `json:"nonce"`
+	ExtraNonce int            `json:"extra_nonce,omitempty"`
+	Difficulty int            `json:"difficulty"`
+	BlockHash  string         `json:"block_hash"`
+	Elapsed    float32        `json:"elapsed"`
+}
+
+type GovBlockHeader struct {
+	Index       int     `json:"index"`
+	GovID       string  `json:"gov_id"`
+	PrevHash    string  `json:"prev_hash"`
+	Timestamp   string  `json:"timestamp"`
+	MerkleRoot  string  `json:"merkle_root"`
+	Nonce       int     `json:"nonce"`
+	ExtraNonce  int     `json:"extra_nonce,omitempty"`
+	Difficulty  int     `json:"difficulty"`
+	BlockHash   string  `json:"block_hash"`
+	Elapsed     float32 `json:"elapsed"`
+	RecordCount int     `json:"record_count"`
+}
+
+// Gov /verify 서명 영수증
+type VerificationReceipt struct {
+	HosID           string      `json:"hos_id"`
+	Leaf            string      `json:"leaf"`
+	BlockRoot       string      `json:"block_root"`
+	Proof           [][2]string `json:"proof"`
+	ProofVersion    int         `json:"proof_version"`
+	ProofValid      bool        `json:"proof_valid"`
+	AnchorStatus    string      `json:"anchor_status"`
+	UpperBlockIndex *int        `json:"upper_block_index,omitempty"`
+	UpperBlockHash  string      `json:"upper_block_hash,omitempty"`
+	Verified        bool        `json:"verified"`
+	GovID           string      `json:"gov_id"`
+	Signer          string      `json:"signer"`
+	IssuedAt        string      `json:"issued_at"`
+	Sig             string      `json:"sig"`
+}
+
+// Gov /anchor/proof : Hos 블록 루트의 상위 블록 포함 증명 (Gov 노드 키 서명)
+type AnchorProof struct {
+	HosID           string         `json:"hos_id"`
+	Root            string         `json:"root"`
+	UpperBlockIndex int            `json:"upper_block_index"`
+	RecordIndex     int            `json:"record_index"`
+	MerkleProof     [][2]string    `json:"merkle_proof"`
+	ProofVersion    int            `json:"proof_version"`
+	Block           GovBlockHeader `json:"block"`
+	Confirmations   int            `json:"confirmations"`
+	GovID           string         `json:"gov_id"`
+	Signer          string         `json:"signer"`
+	IssuedAt        string         `json:"issued_at"`
+	Sig             string         `json:"sig"`
+}
+
+type LowerProof struct {
+	BlockIndex   int         `json:"block_index"`
+	EntryIndex   int         `json:"entry_index"`
+	Leaf         string      `json:"leaf"`
+	Proof        [][2]string `json:"proof"`
+	ProofVersion int         `json:"proof_version"`
+	BlockRoot    string      `json:"block_root"`
+}
+
+// Gov /proof/full : 레코드 => Hos 블록 => 상위 블록 단일 증명 묶음
+type FullProof struct {
+	HosID    string          `json:"hos_id"`
+	ClinicID string          `json:"clinic_id"`
+	Record   json.RawMessage `json:"record"`
+	Lower    LowerProof      `json:"lower"`
+	Anchor   AnchorProof     `json:"anchor"`
+	Checks   map[string]bool `json:"checks"`
+}
+
+// GET /status
+func (c *GovClient) Status(ctx context.Context) (GovStatus, error) {
+	var st GovStatus
+	_, err := c.n.do(ctx, http.MethodGet, "/status", nil, &st)
+	return st, err
+}
+
+// GET /blocks
+func (c *GovClient) ListBlocks(ctx context.Context, offset, limit int) (BlocksPage[GovBlock], error) {
+	var page BlocksPage[GovBlock]
+	q := url.Values{"offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	_, err := c.n.do(ctx, http.MethodGet, "/blocks?"+q.Encode(), nil, &page)
+	return page, err
+}
+
+// GET /headers : 블록 헤더 페이지 (앵커 본문 제외)
+func (c *GovClient) Headers(ctx context.Context, offset, limit int) (BlocksPage[GovBlockHeader], error) {
+	var page BlocksPage[GovBlockHeader]
+	q := url.Values{"offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	_, err := c.n.do(ctx, http.MethodGet, "/headers?"+q.Encode(), nil, &page)
+	return page, err
+}
+
+// GET /block/index
+func (c *GovClient) GetBlock(ctx context.Context, index int) (GovBlock, error) {
+	var b GovBlock
+	_, err := c.n.do(ctx, http.MethodGet, "/block/index?id="+strconv.Itoa(index), nil, &b)
+	return b, err
+}
+
+// GET /query : Gov 가 Hos 검색 결과를 앵커 기준으로 검증해 중계
+func (c *GovClient) Query(ctx context.Context, hosID, keyword string, offset, limit int) ([]SearchResult, int, error) {
+	var items []SearchResult
+	q := url.Values{"hos_id": {hosID}, "keyword": {keyword}, "offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	hdr, err := c.n.do(ctx, http.MethodGet, "/query?"+q.Encode(), nil, &items)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, _ := strconv.Atoi(hdr.Get("X-Total-Count"))
+	return items, total, nil
+}
+
+// GET /patient/records : Gov 서명으로 중계한 Hos 환자별 레코드 (WithRequester 필요), total 은 전체 수
+//   - decrypt : 봉인된 필드 평문을 Decrypted 로 받음 (Gov 에 복호화 허용된 요청자만, 아니면 ErrForbidden)
+func (c *GovClient) PatientRecords(ctx context.Context, hosID, patientID string, decrypt bool, offset, limit int) ([]SearchResult, int, error) {
+	var items []SearchResult
+	q := url.Values{"hos_id": {hosID}, "patient_id": {patientID}, "offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	if decrypt {
+		q.Set("decrypt", "true")
+	}
+	hdr, err := c.n.do(ctx, http.MethodGet, "/patient/records?"+q.Encode(), nil, &items)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, _ := strconv.Atoi(hdr.Get("X-Total-Count"))
+	return items, total, nil
+}
+
+// Gov 장부에 기록된 중계 조회 감사 기록 (검색어는 sha256 만 기록됨)
+type QueryAudit struct {
+	Query       string `json:"query"` // search | patient_records
+	Requester   string `json:"requester,omitempty"`
+	HosID       string `json:"hos_id"`
+	KeywordHash string `json:"keyword_hash"`
+	Ts          string `json:"ts"`
+	ResultCount int    `json:"result_count"`
+	Status      int    `json:"status"`
+	Node        string `json:"node"` // 조회를 처리한 Gov 노드
+	NodeKey     string `json:"node_key"`
+	Sig         string `json:"sig"`
+	Block       int    `json:"block"`
+	Entry       int    `json:"entry"`
+}
+
+// GET /audit/queries : hos_id 에 대한 중계 조회 감사 이력 (블록 순), requester 가 비어 있으면 전체
+func (c *GovClient) QueryAudits(ctx context.Context, hosID, requester string, offset, limit int) ([]QueryAudit, int, error) {
+	var items []QueryAudit
+	q := url.Values{"hos_id": {hosID}, "offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	if requester != "" {
+		q.Set("requester", requester)
+	}
+	hdr, err := c.n.do(ctx, http.MethodGet, "/audit/queries?"+q.Encode(), nil, &items)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, _ := strconv.Atoi(hdr.Get("X-Total-Count"))
+	return items, total, nil
+}
+
+// GET /verify : leaf 의 Merkle 증명과 block_root 앵커 기록을 Gov 가 확인한 서명 영수증
+func (c *GovClient) VerifyProof(ctx context.Context, hosID, leaf, blockRoot string, proof [][2]string) (VerificationReceipt, error) {
+	var rc VerificationReceipt
+	pj, err := json.Marshal(proof)
+	if err != nil {
+		return rc, err
+	}
+	q := url.Values{"hos_id": {hosID}, "leaf": {leaf}, "block_root": {blockRoot}, "proof": {string(pj)}}
+	_, err = c.n.do(ctx, http.MethodGet, "/verify?"+q.Encode(), nil, &rc)
+	return rc, err
+}
+
+// GET /anchor/proof
+func (c *GovClient) AnchorProof(ctx context.Context, hosID, root string) (AnchorProof, error) {
+	var p AnchorProof
+	q := url.Values{"hos_id": {hosID}, "root": {root}}
+	_, err := c.n.do(ctx, http.MethodGet, "/anchor/proof?"+q.Encode(), nil, &p)
+	return p, err
+}
+
+// GET /proof/full
+func (c *GovClient) FullProof(ctx context.Context, hosID, clinicID string) (FullProof, error) {
+	var p FullProof
+	q := url.Values{"hos_id": {hosID}, "clinic_id": {clinicID}}
+	_, err := c.n.do(ctx, http.MethodGet, "/proof/full?"+q.Encode(), nil, &p)
+	return p, err
+}
@@ -0,0 +1,411 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Hos 체인 노드 클라이언트 (레코드 접수, 블록/검색/포함 증명 조회)
+type HosClient struct {
+	n *node
+}
+
+func NewHosClient(addr string, opts ...Option) *HosClient {
+	return &HosClient{n: newNode(addr, opts)}
+}
+
+// 진료 정보 레코드 (Hos ClinicRecord 와 동일 JSON)
+type Record struct {
+	ClinicID  string         `json:"clinic_id"`
+	Info      map[string]any `json:"info,omitempty"`
+	PatientID string         `json:"patient_id"`
+	PrescCode string         `json:"presc_code"`
+	ClinicHis map[string]any `json:"clinic_his,omitempty"`
+	Timestamp string         `json:"timestamp"`
+	Residency string         `json:"residency,omitempty"`
+
+	Revocation *Revocation      `json:"revocation,omitempty"` // 철회 레코드(툼스톤)일 때만 설정
+	Sealed     *SealedPHI       `json:"sealed,omitempty"`     // 노드가 암호화한 Info/ClinicHis (이때 Info/ClinicHis 는 비어 있음)
+	Salt       string           `json:"salt,omitempty"`       // 노드가 접수 시 부여, 있으면 leaf = 필드별 머클 루트
+	Validator  *ValidatorChange `json:"validator,omitempty"`  // 검증자 변경 레코드일 때만 설정
+	Evidence   *Evidence        `json:"evidence,omitempty"`   // 부정 행위 증거 레코드일 때만 설정
+}
+
+// 봉인된 진료 정보 필드 (AES-256-GCM 봉투 암호화, 기관 노드만 복호화 가능)
+type SealedPHI struct {
+	KeyID      string `json:"kid"`
+	Alg        string `json:"alg"`
+	WrappedKey string `json:"wrapped_key"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// 복호화 조회 결과 평문 필드
+type PlainPHI struct {
+	Info      map[string]any `json:"info,omitempty"`
+	ClinicHis map[string]any `json:"clinic_his,omitempty"`
+}
+
+// 툼스톤 본문 : 철회 대상 레코드 leaf 해시
+type Revocation struct {
+	Targets []string `json:"targets"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// 검증자 변경 레코드 본문 (op = join | leave, effective_height 높이의 블록부터 적용)
+type ValidatorChange struct {
+	Op              string `json:"op"`
+	Addr            string `json:"addr"`
+	PubKey          string `json:"pub_key,omitempty"`
+	EffectiveHeight int    `json:"effective_height"`
+}
+
+// 장부에 확정된 검증자 변경 (블록 위치 포함)
+type ValidatorChangeEntry struct {
+	ValidatorChange
+	Block int `json:"block"`
+	Entry int `json:"entry"`
+}
+
+type Validator struct {
+	Addr   string `json:"addr"`
+	PubKey string `json:"pub_key"`
+	KeyFP  string `json:"pubkey_fingerprint"`
+	Since  int    `json:"since"`
+}
+
+// GET /validators 응답
+type ValidatorSet struct {
+	Height     int                    `json:"height"`
+	Committed  bool                   `json:"committed"` // false 면 장부 기록 전 (공개키 등록 노드 기준)
+	Quorum     int                    `json:"quorum"`
+	Validators []Validator            `json:"validators"`
+	Penalized  []string               `json:"penalized"` // 부정 행위 증거로 정족수에서 제외 중인 노드
+	Changes    []ValidatorChangeEntry `json:"changes"`
+	Pending    map[string]string      `json:"pending"` // 주소 => 확정 대기 중인 op
+}
+
+// 부정 행위 증거 (type = double_sign | invalid_proposal)
+// Votes 는 서명된 블록 헤더 2개, Block 은 잘못 제안된 블록 (노드 규격 그대로 보관)
+type Evidence struct {
+	Type     string          `json:"type"`
+	Offender string          `json:"offender"`
+	KeyFP    string          `json:"pubkey_fingerprint"`
+	View     int             `json:"view"`
+	Round    int             `json:"round"`
+	Phase    string          `json:"phase,omitempty"`
+	Votes    json.RawMessage `json:"votes,omitempty"`
+	Block    json.RawMessage `json:"block,omitempty"`
+	BlockSig string          `json:"block_sig,omitempty"`
+	Reason   string          `json:"reason,omitempty"`
+	Reporter string          `json:"reporter"`
+}
+
+// 장부에 기록된 증거 (처벌 구간 = penalty_from ~ penalty_until 높이)
+type EvidenceEntry struct {
+	ID string `json:"id"`
+	Evidence
+	BlockIndex   int `json:"block_index"`
+	EntryIndex   int `json:"entry_index"`
+	PenaltyFrom  int `json:"penalty_from"`
+	PenaltyUntil int `json:"penalty_until"`
+}
+
+// GET /evidence 응답
+type EvidenceReport struct {
+	Height    int             `json:"height"`
+	Penalized []string        `json:"penalized"` // height 블록에서 검증자에서 제외된 노드
+	Evidence  []EvidenceEntry `json:"evidence"`
+}
+
+// 철회된 레코드의 툼스톤 위치 (/search, /proof 의 revoked)
+type RevocationStatus struct {
+	Reason     string `json:"reason,omitempty"`
+	RevokedAt  string `json:"revoked_at"`
+	BlockIndex int    `json:"block_index"`
+	EntryIndex int    `json:"entry_index"`
+}
+
+// POST /revoke 응답
+type RevokeResult struct {
+	Status   string   `json:"status"`
+	ClinicID string   `json:"clinic_id"`
+	Targets  []string `json:"targets"`
+}
+
+type ConsensusSig struct {
+	Addr  string `json:"addr"`
+	KeyFP string `json:"pubkey_fingerprint"`
+	Sig   string `json:"sig"`
+}
+
+type HosBlock struct {
+	Index      int            `json:"index"`
+	HosID      string         `json:"hos_id"`
+	PrevHash   string         `json:"prev_hash"`
+	Timestamp  string         `json:"timestamp"`
+	Entries    []Record       `json:"entries"`
+	MerkleRoot string         `json:"merkle_root"`
+	Proposer   string         `json:"proposer"`
+	Signatures []ConsensusSig `json:"signatures"`
+	BlockHash  string         `json:"block_hash"`
+	Elapsed    float32        `json:"elapsed"`
+	LeafHashes []string       `json:"leaf_hashes"`
+	Pruned     bool           `json:"pruned,omitempty"`
+}
+
+// 블록 헤더 (GET /headers, sigs=true 면 합의 서명 포함)
+type HosBlockHeader struct {
+	Index      int            `json:"index"`
+	HosID      string         `json:"hos_id"`
+	PrevHash   string         `json:"prev_hash"`
+	Timestamp  string         `json:"timestamp"`
+	MerkleRoot string         `json:"merkle_root"`
+	Proposer   string         `json:"proposer"`
+	BlockHash  string         `json:"block_hash"`
+	Elapsed    float32        `json:"elapsed"`
+	EntryCount int            `json:"entry_count"`
+	Signatures []ConsensusSig `json:"signatures,omitempty"`
+}
+
+type HosStatus struct {
+	HosID     string   `json:"hos_id"`
+	Addr      string   `json:"addr"`
+	Height    int      `json:"height"`
+	IsBoot    bool     `json:"is_boot"`
+	BootAddr  string   `json:"bootAddr"`
+	StartedAt string   `json:"started_at"`
+	Peers     []string `json:"peers"`
+	GovBoot   string   `json:"gov_boot"`
+	LastHash  string   `json:"last_hash"`
+	Region    string   `json:"region"`
+	PrunedTo  int      `json:"pruned_to"`
+}
+
+type Rejection struct {
+	Index    int    `json:"index"`
+	ClinicID string `json:"clinic_id"`
+	Hash     string `json:"hash"`
+	Reason   string `json:"reason"`
+}
+
+type SubmitResult struct {
+	Status   string      `json:"status"`
+	Count    int         `json:"count"` // 접수된 레코드 수
+	Rejected []Rejection `json:"rejected"`
+}
+
+type Inclusion struct {
+	BlockIndex      int    `json:"block_index"`
+	EntryIndex      int    `json:"entry_index"`
+	BlockTimestamp  string `json:"block_timestamp"`
+	Confirmations   int    `json:"confirmations"`
+	Final           bool   `json:"final"`
+	AnchorStatus    string `json:"anchor_status"`
+	UpperBlockIndex *int   `json:"upper_block_index,omitempty"`
+}
+
+// 레코드 포함 증명 (GET /proof)
+type Proof struct {
+	BlockRoot    string            `json:"block_root"`
+	LatestRoot   string            `json:"latest_root"`
+	Leaf         string            `json:"leaf"`
+	Proof        [][2]string       `json:"proof"`
+	ProofVersion int               `json:"proof_version"` // 증명 형식 버전 (0 = 이전 노드)
+	Inclusion    Inclusion         `json:"inclusion"`
+	Pruned       bool              `json:"pruned"`
+	Revoked      *RevocationStatus `json:"revoked,omitempty"`
+}
+
+// 검색 결과 (GET /search, Gov /query)
+type SearchResult struct {
+	Record       Record            `json:"record"`
+	BlockRoot    string            `json:"block_root"`
+	LatestRoot   string            `json:"latest_root"`
+	Leaf         string            `json:"leaf"`
+	Proof        [][2]string       `json:"proof"`
+	ProofVersion int               `json:"proof_version"` // 증명 형식 버전 (0 = 이전 노드)
+	Inclusion    Inclusion         `json:"inclusion"`
+	Retention    string            `json:"retention,omitempty"`
+	Revoked      *RevocationStatus `json:"revoked,omitempty"`
+	Decrypted    *PlainPHI         `json:"decrypted,omitempty"` // 복호화 조회(PatientRecords decrypt)일 때만
+}
+
+// GET /status
+func (c *HosClient) Status(ctx context.Context) (HosStatus, error) {
+	var st HosStatus
+	_, err := c.n.do(ctx, http.MethodGet, "/status", nil, &st)
+	return st, err
+}
+
+// clinic_id 레코드 버전 하나 (GET /content/{clinic_id}/history)
+type HistoryEntry struct {
+	Version int     `json:"version"`
+	Record  *Record `json:"record,omitempty"` // 정리된 블록이면 nil
+	Proof
+}
+
+// POST /upload : 레코드를 메모리풀에 접수
+//   - 일부만 거부되면 Rejected 에 사유 포함 (err 는 nil)
+//   - 모두 거부되면 ErrRejected 와 함께 거부 목록 반환
+func (c *HosClient) SubmitRecords(ctx context.Context, recs []Record) (SubmitResult, error) {
+	var res SubmitResult
+	_, err := c.n.do(ctx, http.MethodPost, "/upload", recs, &res)
+	var ae *APIError
+	if errors.As(err, &ae) && ae.StatusCode == http.StatusConflict {
+		_ = json.Unmarshal(ae.Details, &res) // 409 details 에 거부 목록 포함
+	}
+	return res, err
+}
+
+// POST /revoke : clinic_id 의 확정 레코드 철회 (툼스톤은 다음 블록에 기록)
+//   - block, entry 가 모두 0 이상이면 해당 위치 레코드만 철회
+//   - 대상이 없으면 ErrNotFound, 이미 철회됐으면 ErrRejected
+func (c *HosClient) Revoke(ctx context.Context, clinicID, reason string, block, entry int) (RevokeResult, error) {
+	var res RevokeResult
+	body := map[string]any{"clinic_id": clinicID, "reason": reason}
+	if block >= 0 && entry >= 0 {
+		body["block_index"], body["entry_index"] = block, entry
+	}
+	_, err := c.n.do(ctx, http.MethodPost, "/revoke", body, &res)
+	return res, err
+}
+
+// GET /validators : height 블록의 검증자 집합 (height < 0 이면 다음 블록)
+func (c *HosClient) Validators(ctx context.Context, height int) (ValidatorSet, error) {
+	var vs ValidatorSet
+	path := "/validators"
+	if height >= 0 {
+		path += "?height=" + strconv.Itoa(height)
+	}
+	_, err := c.n.do(ctx, http.MethodGet, path, nil, &vs)
+	return vs, err
+}
+
+// POST /validators : 검증자 제외 접수 (다음 블록 이후 적용)
+func (c *HosClient) RemoveValidator(ctx context.Context, addr string) error {
+	_, err := c.n.do(ctx, http.MethodPost, "/validators", ValidatorChange{Op: "leave", Addr: addr}, nil)
+	return err
+}
+
+// GET /evidence : 기록된 부정 행위 증거와 height 블록 기준 처벌 중인 노드 (height < 0 이면 다음 블록)
+func (c *HosClient) Evidence(ctx context.Context, height int) (EvidenceReport, error) {
+	var er EvidenceReport
+	path := "/evidence"
+	if height >= 0 {
+		path += "?height=" + strconv.Itoa(height)
+	}
+	_, err := c.n.do(ctx, http.MethodGet, path, nil, &er)
+	return er, err
+}
+
+// GET /blocks
+func (c *HosClient) ListBlocks(ctx context.Context, offset, limit int) (BlocksPage[HosBlock], error) {
+	var page BlocksPage[HosBlock]
+	q := url.Values{"offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	_, err := c.n.do(ctx, http.MethodGet, "/blocks?"+q.Encode(), nil, &page)
+	return page, err
+}
+
+// GET /headers : 블록 헤더 페이지 (sigs : 합의 서명 포함)
+func (c *HosClient) Headers(ctx context.Context, offset, limit int, sigs bool) (BlocksPage[HosBlockHeader], error) {
+	var page BlocksPage[HosBlockHeader]
+	q := url.Values{"offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	if sigs {
+		q.Set("sigs", "true")
+	}
+	_, err := c.n.do(ctx, http.MethodGet, "/headers?"+q.Encode(), nil, &page)
+	return page, err
+}
+
+// GET /block/index
+func (c *HosClient) GetBlock(ctx context.Context, index int) (HosBlock, error) {
+	var b HosBlock
+	_, err := c.n.do(ctx, http.MethodGet, "/block/index?id="+strconv.Itoa(index), nil, &b)
+	return b, err
+}
+
+// GET /search : 키워드(clinic_id, cCode) 검색, total 은 전체 매칭 수
+func (c *HosClient) Search(ctx context.Context, keyword string, offset, limit int) ([]SearchResult, int, error) {
+	var items []SearchResult
+	q := url.Values{"value": {keyword}, "offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	hdr, err := c.n.do(ctx, http.MethodGet, "/search?"+q.Encode(), nil, &items)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, _ := strconv.Atoi(hdr.Get("X-Total-Count"))
+	return items, total, nil
+}
+
+// 선택 공개 필드 하나 (필드 leaf = sha256(정렬 JSON {field, salt, value}))
+type FieldDisclosure struct {
+	Field string      `json:"field"`
+	Value any         `json:"value"`
+	Salt  string      `json:"salt"`
+	Proof [][2]string `json:"proof"` // 필드 leaf => 레코드 leaf
+}
+
+type Disclosure struct {
+	Fields     []FieldDisclosure `json:"fields"`
+	FieldCount int               `json:"field_count"`
+	Missing    []string          `json:"missing,omitempty"` // 레코드에 없는 요청 필드
+}
+
+// /search?fields= 응답 항목 (레코드 본문 대신 공개 필드만)
+type DisclosedResult struct {
+	Disclosure   *Disclosure       `json:"disclosure,omitempty"` // salt 없는 기존 레코드면 nil (Note 참고)
+	Note         string            `json:"note,omitempty"`
+	BlockRoot    string            `json:"block_root"`
+	LatestRoot   string            `json:"latest_root"`
+	Leaf         string            `json:"leaf"`
+	Proof        [][2]string       `json:"proof"`
+	ProofVersion int               `json:"proof_version"` // 증명 형식 버전 (0 = 이전 노드)
+	Inclusion    Inclusion         `json:"inclusion"`
+	Retention    string            `json:"retention,omitempty"`
+	Revoked      *RevocationStatus `json:"revoked,omitempty"`
+}
+
+// GET /search?fields= : 매칭 레코드의 지정 필드만 필드 증명과 함께 조회 (VerifyDisclosure 로 검증)
+func (c *HosClient) Disclose(ctx context.Context, keyword string, fields []string, offset, limit int) ([]DisclosedResult, int, error) {
+	var items []DisclosedResult
+	q := url.Values{"value": {keyword}, "fields": {strings.Join(fields, ",")}, "offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	hdr, err := c.n.do(ctx, http.MethodGet, "/search?"+q.Encode(), nil, &items)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, _ := strconv.Atoi(hdr.Get("X-Total-Count"))
+	return items, total, nil
+}
+
+// GET /content/{clinic_id}/history : 버전 이력 (오래된 버전부터), total 은 전체 버전 수
+func (c *HosClient) History(ctx context.Context, clinicID string, offset, limit int) ([]HistoryEntry, int, error) {
+	var items []HistoryEntry
+	q := url.Values{"offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	hdr, err := c.n.do(ctx, http.MethodGet, "/content/"+url.PathEscape(clinicID)+"/history?"+q.Encode(), nil, &items)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, _ := strconv.Atoi(hdr.Get("X-Total-Count"))
+	return items, total, nil
+}
+
+// GET /content/{clinic_id}/history?version=N : 버전 N 레코드와 포함 증명
+func (c *HosClient) Version(ctx context.Context, clinicID string, version int) (HistoryEntry, error) {
+	var e HistoryEntry
+	q := url.Values{"version": {strconv.Itoa(version)}}
+	_, err := c.n.do(ctx, http.MethodGet, "/content/"+url.PathEscape(clinicID)+"/history?"+q.Encode(), nil, &e)
+	return e, err
+}
+
+// GET /proof : 블록 block 의 entry 번째 레코드 포함 증명
+func (c *HosClient) GetProof(ctx context.Context, block, entry int) (Proof, error) {
+	var p Proof
+	q := url.Values{"block": {strconv.Itoa(block)}, "entry": {strconv.Itoa(entry)}}
+	_, err := c.n.do(ctx, http.MethodGet, "/proof?"+q.Encode(), nil, &p)
+	return p, err
+}
@@ -0,0 +1,639 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Light Client (헤더 + 요청한 증명만 보관하는 라이트 노드)
+// ------------------------------------------------------------
+// - 블록 본문 없이 Hos / Gov 헤더 체인만 받아 검증하고, 레코드 증명은 필요할 때만 요청
+//   (모바일/엣지에서 앵커링 검증용, 상태는 State() 로 꺼내 저장하고 WithLightState 로 복원)
+// - Hos 헤더 (GET /headers?sigs=true)
+//   · index 연속, prev_hash 연결, hos_id 일치, 헤더 필드로 재계산한 block_hash 일치
+//   · 검증자 집합 정족수(2f+1, 처벌 중인 노드 제외) 이상의 유효 ECDSA 서명 (블록 해시 서명)
+//   · 검증자 집합은 처음 한 번 노드에서 받거나(WithTrustedValidators 로 고정 가능) 이후 신뢰한 집합 유지
+//     서명이 부족하면 해당 높이 집합을 다시 받아, 새 집합 정족수 + 이전 신뢰 집합 f+1 이상 서명이 있을 때만 교체
+//   · 확정 블록이므로 이미 검증한 헤더와 다른 헤더가 오면 ErrLightConflict
+// - Gov 헤더 (GET /headers)
+//   · index 연속, prev_hash 연결, gov_id 일치, 체인 PoW 해시 규칙(/chain/info pow_hash)으로 재계산한 해시 일치
+//   · 해시가 헤더 난이도(선행 0 hex 자릿수)를 충족하고 난이도가 LightMinDifficulty 이상
+//     (난이도 조정 규칙 자체는 재현하지 않음 : 노드가 검증)
+//   · 보관한 헤더와 갈라지면 최근 LightReorgDepth 블록 안에서 분기점을 찾고, 누적 작업량이 더 큰 쪽만 채택
+// - 증명 (요청 시에만 조회, 검증한 증명만 보관)
+//   · ProveRecord : Gov /proof/full 을 VerifyFullProof 로 검증 + Hos 블록 루트/상위 블록 해시가 보관 헤더와 일치
+//   · ProveEntry  : Hos /proof 의 leaf 증명이 보관 헤더 merkle_root 에 도달
+// - 제네시스 해시는 WithTrustedHosGenesis / WithTrustedGovGenesis 로 고정 (없으면 처음 받은 제네시스 신뢰)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	LightHeaderPage    = 500 // 헤더 요청 1건당 블록 수
+	LightReorgDepth    = 64  // Gov 분기 탐색 깊이
+	LightMinDifficulty = 1
+
+	powHashSHA256JSON = "sha256-json"
+	powHashSHA256d    = "sha256d"
+	powHashSHA3       = "sha3-256"
+)
+
+var (
+	ErrLightConflict = errors.New("header conflicts with verified headers")
+	ErrNotSynced     = errors.New("header not synced")
+)
+
+// 라이트 클라이언트 보관 상태 (JSON 으로 저장/복원)
+type LightState struct {
+	HosID      string                `json:"hos_id,omitempty"`
+	HosHeaders []HosBlockHeader      `json:"hos_headers,omitempty"` // 검증 후 서명은 제외하고 보관
+	Validators []Validator           `json:"validators,omitempty"`  // 마지막으로 신뢰한 Hos 검증자 집합
+	Penalized  []string              `json:"penalized,omitempty"`
+	GovID      string                `json:"gov_id,omitempty"`
+	PoWHash    string                `json:"pow_hash,omitempty"`
+	GovHeaders []GovBlockHeader      `json:"gov_headers,omitempty"`
+	Proofs     map[string]FullProof  `json:"proofs,omitempty"`  // clinic_id => 검증한 전체 증명
+	Entries    map[string]LightEntry `json:"entries,omitempty"` // "<block>:<entry>" => 검증한 Hos 포함 증명
+}
+
+// 보관 헤더로 검증한 Hos 레코드 포함 증명
+type LightEntry struct {
+	BlockIndex   int         `json:"block_index"`
+	EntryIndex   int         `json:"entry_index"`
+	Leaf         string      `json:"leaf"`
+	Proof        [][2]string `json:"proof"`
+	ProofVersion int         `json:"proof_version"`
+	BlockRoot    string      `json:"block_root"`
+}
+
+type LightOption func(*LightClient)
+
+// Hos 제네시스 블록 해시 고정
+func WithTrustedHosGenesis(hash string) LightOption {
+	return func(l *LightClient) { l.hosGenesis = hash }
+}
+
+// Gov 제네시스 블록 해시 고정
+func WithTrustedGovGenesis(hash string) LightOption {
+	return func(l *LightClient) { l.govGenesis = hash }
+}
+
+// 따라갈 Hos 체인 ID (Hos 노드 없이 Gov 로만 증명을 받을 때 필요)
+func WithHosID(hosID string) LightOption {
+	return func(l *LightClient) { l.st.HosID = hosID }
+}
+
+// 처음 신뢰할 Hos 검증자 집합 고정 (없으면 첫 동기화 시 노드에서 받음)
+func WithTrustedValidators(vs []Validator) LightOption {
+	return func(l *LightClient) { l.st.Validators = vs }
+}
+
+// 저장해 둔 상태에서 이어서 동기화
+func WithLightState(st LightState) LightOption {
+	return func(l *LightClient) { l.st = st }
+}
+
+type LightClient struct {
+	hos *HosClient // nil 이면 Hos 헤더 동기화/ProveEntry 사용 불가
+	gov *GovClient // nil 이면 Gov 헤더 동기화/ProveRecord 사용 불가
+
+	hosGenesis string
+	govGenesis string
+
+	mu sync.Mutex
+	st LightState
+}
+
+func NewLightClient(hos *HosClient, gov *GovClient, opts ...LightOption) *LightClient {
+	l := &LightClient{hos: hos, gov: gov}
+	for _, o := range opts {
+		o(l)
+	}
+	return l
+}
+
+// 현재 보관 상태 복사본 (저장용)
+func (l *LightClient) State() LightState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	st := l.st
+	st.HosHeaders = append([]HosBlockHeader(nil), l.st.HosHeaders...)
+	st.Validators = append([]Validator(nil), l.st.Validators...)
+	st.Penalized = append([]string(nil), l.st.Penalized...)
+	st.GovHeaders = append([]GovBlockHeader(nil), l.st.GovHeaders...)
+	st.Proofs = maps.Clone(l.st.Proofs)
+	st.Entries = maps.Clone(l.st.Entries)
+	return st
+}
+
+// 보관한 헤더 높이 (없으면 -1)
+func (l *LightClient) Heights() (hos, gov int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.st.HosHeaders) - 1, len(l.st.GovHeaders) - 1
+}
+
+// Hos, Gov 헤더 동기화 (설정된 쪽만)
+func (l *LightClient) Sync(ctx context.Context) error {
+	if l.hos != nil {
+		if _, err := l.SyncHos(ctx); err != nil {
+			return fmt.Errorf("hos: %w", err)
+		}
+	}
+	if l.gov != nil {
+		if _, err := l.SyncGov(ctx); err != nil {
+			return fmt.Errorf("gov: %w", err)
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Hos 헤더 (PBFT 서명)
+////////////////////////////////////////////////////////////////////////////////
+
+// Hos 헤더를 노드 최신 높이까지 받아 검증, 보관한 최신 높이 반환
+func (l *LightClient) SyncHos(ctx context.Context) (int, error) {
+	if l.hos == nil {
+		return -1, errors.New("no hos node configured")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for {
+		n := len(l.st.HosHeaders)
+		// 보관한 최신 헤더부터 다시 받아 같은 체인인지 확인
+		offset := max(n-1, 0)
+		page, err := l.hos.Headers(ctx, offset, LightHeaderPage, true)
+		if err != nil {
+			return n - 1, err
+		}
+		items := page.Items
+		if n > 0 {
+			if len(items) == 0 && page.Total < n {
+				return n - 1, nil // 노드가 뒤처져 있음
+			}
+			if len(items) == 0 || items[0].Index != n-1 || items[0].BlockHash != l.st.HosHeaders[n-1].BlockHash {
+				return n - 1, fmt.Errorf("hos block #%d: %w", n-1, ErrLightConflict)
+			}
+			items = items[1:]
+		}
+		if len(items) == 0 {
+			return n - 1, nil
+		}
+		for _, h := range items {
+			if err := l.verifyHosHeader(ctx, h); err != nil {
+				return len(l.st.HosHeaders) - 1, err
+			}
+			h.Signatures = nil
+			l.st.HosHeaders = append(l.st.HosHeaders, h)
+		}
+	}
+}
+
+func (l *LightClient) verifyHosHeader(ctx context.Context, h HosBlockHeader) error {
+	n := len(l.st.HosHeaders)
+	if got := hosHeaderHash(h); got != h.BlockHash {
+		return fmt.Errorf("hos block #%d block_hash mismatch", h.Index)
+	}
+	if n == 0 {
+		if h.Index != 0 {
+			return fmt.Errorf("hos headers start at #%d, want genesis", h.Index)
+		}
+		if l.hosGenesis != "" && h.BlockHash != l.hosGenesis {
+			return fmt.Errorf("hos genesis %s differs from trusted %s", h.BlockHash, l.hosGenesis)
+		}
+		if l.st.HosID != "" && h.HosID != l.st.HosID {
+			return fmt.Errorf("hos genesis hos_id %s differs from %s", h.HosID, l.st.HosID)
+		}
+		l.st.HosID = h.HosID
+		return nil // 제네시스는 합의 서명 없음
+	}
+	prev := l.st.HosHeaders[n-1]
+	switch {
+	case h.Index != prev.Index+1:
+		return fmt.Errorf("hos header index not consecutive: prev=%d got=%d", prev.Index, h.Index)
+	case h.PrevHash != prev.BlockHash:
+		return fmt.Errorf("hos block #%d prev_hash mismatch", h.Index)
+	case h.HosID != l.st.HosID:
+		return fmt.Errorf("hos block #%d hos_id mismatch: %s", h.Index, h.HosID)
+	}
+	return l.verifyHosSignatures(ctx, h)
+}
+
+// 신뢰 집합 정족수 서명 확인, 부족하면 해당 높이 집합으로 교체 시도
+func (l *LightClient) verifyHosSignatures(ctx context.Context, h HosBlockHeader) error {
+	if len(l.st.Validators) == 0 {
+		vs, err := l.hos.Validators(ctx, h.Index)
+		if err != nil {
+			return fmt.Errorf("load validators at #%d: %w", h.Index, err)
+		}
+		l.st.Validators, l.st.Penalized = vs.Validators, vs.Penalized
+	}
+	trusted := activeValidatorKeys(l.st.Validators, l.st.Penalized)
+	signed := countHosSignatures(h, trusted)
+	if signed >= bftQuorum(len(trusted)) {
+		return nil
+	}
+	vs, err := l.hos.Validators(ctx, h.Index)
+	if err != nil {
+		return fmt.Errorf("load validators at #%d: %w", h.Index, err)
+	}
+	next := activeValidatorKeys(vs.Validators, vs.Penalized)
+	if maps.Equal(next, trusted) {
+		return fmt.Errorf("hos block #%d: valid signatures insufficient: %d/%d", h.Index, signed, bftQuorum(len(trusted)))
+	}
+	if got, want := countHosSignatures(h, next), bftQuorum(len(next)); got < want {
+		return fmt.Errorf("hos block #%d: valid signatures insufficient for validator set: %d/%d", h.Index, got, want)
+	}
+	// 새 집합은 이전에 신뢰한 검증자 f+1 이상이 함께 서명한 경우에만 신뢰
+	if want := (len(trusted)-1)/3 + 1; signed < want {
+		return fmt.Errorf("hos block #%d: validator set change not vouched by trusted set: %d/%d", h.Index, signed, want)
+	}
+	l.st.Validators, l.st.Penalized = vs.Validators, vs.Penalized
+	return nil
+}
+
+// 처벌 중인 노드를 뺀 검증자 공개키 (모두 처벌 중이면 빼지 않음, 노드 validatorsAt 과 같은 규칙)
+func activeValidatorKeys(vs []Validator, penalized []string) map[string]string {
+	all := make(map[string]string, len(vs))
+	kept := make(map[string]string, len(vs))
+	for _, v := range vs {
+		all[v.Addr] = v.PubKey
+		if !slices.Contains(penalized, v.Addr) {
+			kept[v.Addr] = v.PubKey
+		}
+	}
+	if len(kept) == 0 {
+		return all
+	}
+	return kept
+}
+
+// 2f+1
+func bftQuorum(n int) int {
+	return 2*((n-1)/3) + 1
+}
+
+// keys 검증자의 유효 서명 수 (노드당 1개, 서명 시점 공개키 지문이 다르면 제외)
+func countHosSignatures(h HosBlockHeader, keys map[string]string) int {
+	digest, err := hex.DecodeString(h.BlockHash)
+	if err != nil {
+		return 0
+	}
+	signed := make(map[string]bool)
+	for _, s := range h.Signatures {
+		if s.Addr == "" { // 서명자 정보가 없는 기존 블록 서명
+			for addr, pub := range keys {
+				if !signed[addr] && verifyECDSA(pub, digest, s.Sig) {
+					signed[addr] = true
+					break
+				}
+			}
+			continue
+		}
+		pub, ok := keys[s.Addr]
+		if !ok || signed[s.Addr] || (s.KeyFP != "" && s.KeyFP != pubKeyFingerprint(pub)) {
+			continue
+		}
+		if verifyECDSA(pub, digest, s.Sig) {
+			signed[s.Addr] = true
+		}
+	}
+	return len(signed)
+}
+
+// 블록 해시 = sha256(정렬 JSON {index, hos_id, prev_hash, timestamp, merkle_root, proposer})
+func hosHeaderHash(h HosBlockHeader) string {
+	sum, _ := canonicalHash(map[string]any{
+		"index":       h.Index,
+		"hos_id":      h.HosID,
+		"prev_hash":   h.PrevHash,
+		"timestamp":   h.Timestamp,
+		"merkle_root": h.MerkleRoot,
+		"proposer":    h.Proposer,
+	})
+	return sum
+}
+
+// PEM 공개키로 DER ECDSA 서명(hex) 검증
+func verifyECDSA(pubPem string, digest []byte, sigHex string) bool {
+	block, _ := pem.Decode([]byte(pubPem))
+	if block == nil {
+		return false
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return false
+	}
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return false
+	}
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return false
+	}
+	return ecdsa.VerifyASN1(pub, digest, sig)
+}
+
+// 공개키 PEM 지문 (DER 의 sha256 hex)
+func pubKeyFingerprint(pubPem string) string {
+	block, _ := pem.Decode([]byte(pubPem))
+	if block == nil {
+		return ""
+	}
+	sum := sha256.Sum256(block.Bytes)
+	return hex.EncodeToString(sum[:])
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Gov 헤더 (PoW)
+////////////////////////////////////////////////////////////////////////////////
+
+// Gov 헤더를 노드 최신 높이까지 받아 검증, 보관한 최신 높이 반환
+func (l *LightClient) SyncGov(ctx context.Context) (int, error) {
+	if l.gov == nil {
+		return -1, errors.New("no gov node configured")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.st.PoWHash == "" {
+		ci, err := l.gov.ChainInfo(ctx)
+		if err != nil {
+			return -1, fmt.Errorf("chain info: %w", err)
+		}
+		l.st.PoWHash = ci.PoWHash
+		if l.st.PoWHash == "" {
+			l.st.PoWHash = powHashSHA256JSON // pow_hash 를 알리지 않는 이전 노드
+		}
+	}
+	for {
+		n := len(l.st.GovHeaders)
+		offset := max(n-1, 0)
+		page, err := l.gov.Headers(ctx, offset, LightHeaderPage)
+		if err != nil {
+			return n - 1, err
+		}
+		items := page.Items
+		if n > 0 {
+			if len(items) == 0 || items[0].Index != n-1 || items[0].BlockHash != l.st.GovHeaders[n-1].BlockHash {
+				switched, err := l.reorgGov(ctx)
+				if err != nil || !switched {
+					return len(l.st.GovHeaders) - 1, err // 노드 체인의 작업량이 더 크지 않으면 보관 체인 유지
+				}
+				continue
+			}
+			items = items[1:]
+		}
+		if len(items) == 0 {
+			return n - 1, nil
+		}
+		headers := append([]GovBlockHeader(nil), l.st.GovHeaders...)
+		for _, h := range items {
+			if err := l.verifyGovHeader(headers, h); err != nil {
+				return len(l.st.GovHeaders) - 1, err
+			}
+			headers = append(headers, h)
+		}
+		l.st.GovHeaders = headers
+	}
+}
+
+// prev 체인(headers) 다음 Gov 헤더 검증
+func (l *LightClient) verifyGovHeader(headers []GovBlockHeader, h GovBlockHeader) error {
+	got, err := powHeaderHash(l.st.PoWHash, h)
+	if err != nil {
+		return err
+	}
+	switch {
+	case got != h.BlockHash:
+		return fmt.Errorf("gov block #%d block_hash mismatch", h.Index)
+	case h.Difficulty < LightMinDifficulty:
+		return fmt.Errorf("gov block #%d difficulty %d below %d", h.Index, h.Difficulty, LightMinDifficulty)
+	case !strings.HasPrefix(h.BlockHash, strings.Repeat("0", h.Difficulty)):
+		return fmt.Errorf("gov block #%d hash does not meet difficulty %d", h.Index, h.Difficulty)
+	}
+	n := len(headers)
+	if n == 0 {
+		if h.Index != 0 {
+			return fmt.Errorf("gov headers start at #%d, want genesis", h.Index)
+		}
+		if l.govGenesis != "" && h.BlockHash != l.govGenesis {
+			return fmt.Errorf("gov genesis %s differs from trusted %s", h.BlockHash, l.govGenesis)
+		}
+		l.st.GovID = h.GovID
+		return nil
+	}
+	prev := headers[n-1]
+	switch {
+	case h.Index != prev.Index+1:
+		return fmt.Errorf("gov header index not consecutive: prev=%d got=%d", prev.Index, h.Index)
+	case h.PrevHash != prev.BlockHash:
+		return fmt.Errorf("gov block #%d prev_hash mismatch", h.Index)
+	case h.GovID != l.st.GovID:
+		return fmt.Errorf("gov block #%d gov_id mismatch: %s", h.Index, h.GovID)
+	}
+	return nil
+}
+
+// 보관 체인과 노드 체인이 갈라진 경우 : 최근 LightReorgDepth 안의 분기점 이후 누적 작업량이 더 크면 노드 체인으로 교체
+func (l *LightClient) reorgGov(ctx context.Context) (bool, error) {
+	n := len(l.st.GovHeaders)
+	from := max(n-LightReorgDepth, 0)
+	page, err := l.gov.Headers(ctx, from, LightReorgDepth)
+	if err != nil {
+		return false, err
+	}
+	fork := -1
+	for _, h := range page.Items {
+		if h.Index < n && h.BlockHash == l.st.GovHeaders[h.Index].BlockHash {
+			fork = h.Index
+		}
+	}
+	if fork < 0 {
+		return false, fmt.Errorf("gov fork deeper than %d blocks: %w", LightReorgDepth, ErrLightConflict)
+	}
+	branch := append([]GovBlockHeader(nil), l.st.GovHeaders[:fork+1]...)
+	for {
+		page, err := l.gov.Headers(ctx, len(branch), LightHeaderPage)
+		if err != nil {
+			return false, err
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+		for _, h := range page.Items {
+			if err := l.verifyGovHeader(branch, h); err != nil {
+				return false, err
+			}
+			branch = append(branch, h)
+		}
+	}
+	if govWork(branch[fork+1:]).Cmp(govWork(l.st.GovHeaders[fork+1:])) <= 0 {
+		return false, nil
+	}
+	l.st.GovHeaders = branch
+	return true, nil
+}
+
+// 누적 작업량 (블록당 16^difficulty, 노드 forkchoice 와 같은 규칙)
+func govWork(headers []GovBlockHeader) *big.Int {
+	w := new(big.Int)
+	for _, h := range headers {
+		w.Add(w, new(big.Int).Lsh(big.NewInt(1), uint(4*max(h.Difficulty, 0))))
+	}
+	return w
+}
+
+// 체인 PoW 해시 규칙으로 헤더 해시 계산 (노드 powhash.go 와 같은 인코딩)
+func powHeaderHash(rule string, h GovBlockHeader) (string, error) {
+	var sum [32]byte
+	switch rule {
+	case powHashSHA256JSON:
+		data, err := json.Marshal(struct {
+			Index      int    `json:"index"`
+			PrevHash   string `json:"prev_hash"`
+			MerkleRoot string `json:"merkle_root"`
+			Timestamp  string `json:"timestamp"`
+			Difficulty int    `json:"difficulty"`
+			ExtraNonce int    `json:"extra_nonce,omitempty"`
+			Nonce      int    `json:"nonce"`
+		}{h.Index, h.PrevHash, h.MerkleRoot, h.Timestamp, h.Difficulty, h.ExtraNonce, h.Nonce})
+		if err != nil {
+			return "", err
+		}
+		sum = sha256.Sum256(data)
+	case powHashSHA256d:
+		first := sha256.Sum256(encodePoWHeader(h))
+		sum = sha256.Sum256(first[:])
+	case powHashSHA3:
+		sum = sha3.Sum256(encodePoWHeader(h))
+	default:
+		return "", fmt.Errorf("unknown pow hash rule %q", rule)
+	}
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// 이진 헤더 : index(8) | difficulty(8) | prev_hash | merkle_root | timestamp (각 길이(2) + 바이트) | [extra_nonce(8), 0 이면 생략] | nonce(8)
+func encodePoWHeader(h GovBlockHeader) []byte {
+	var buf bytes.Buffer
+	buf.Write(binary.BigEndian.AppendUint64(nil, uint64(h.Index)))
+	buf.Write(binary.BigEndian.AppendUint64(nil, uint64(h.Difficulty)))
+	for _, s := range []string{h.PrevHash, h.MerkleRoot, h.Timestamp} {
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(len(s))))
+		buf.WriteString(s)
+	}
+	if h.ExtraNonce != 0 {
+		buf.Write(binary.BigEndian.AppendUint64(nil, uint64(h.ExtraNonce)))
+	}
+	buf.Write(binary.BigEndian.AppendUint64(nil, uint64(h.Nonce)))
+	return buf.Bytes()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 증명 (요청 시 조회)
+////////////////////////////////////////////////////////////////////////////////
+
+// clinic_id 레코드 => Hos 블록 => 상위 블록 증명을 Gov 에서 받아 보관 헤더로 검증 후 보관
+//   - 증명이 가리키는 블록이 아직 동기화되지 않았으면 한 번 동기화 후 다시 확인 (그래도 없으면 ErrNotSynced)
+func (l *LightClient) ProveRecord(ctx context.Context, clinicID string) (FullProof, error) {
+	if l.gov == nil {
+		return FullProof{}, errors.New("no gov node configured")
+	}
+	l.mu.Lock()
+	hosID := l.st.HosID
+	l.mu.Unlock()
+	if hosID == "" {
+		return FullProof{}, fmt.Errorf("hos_id unknown: %w", ErrNotSynced)
+	}
+	fp, err := l.gov.FullProof(ctx, hosID, clinicID)
+	if err != nil {
+		return fp, err
+	}
+	if err := VerifyFullProof(fp); err != nil {
+		return fp, err
+	}
+	if err := l.checkFullProof(fp); errors.Is(err, ErrNotSynced) {
+		if err := l.Sync(ctx); err != nil {
+			return fp, err
+		}
+		if err := l.checkFullProof(fp); err != nil {
+			return fp, err
+		}
+	} else if err != nil {
+		return fp, err
+	}
+	l.mu.Lock()
+	if l.st.Proofs == nil {
+		l.st.Proofs = make(map[string]FullProof)
+	}
+	l.st.Proofs[clinicID] = fp
+	l.mu.Unlock()
+	return fp, nil
+}
+
+// 증명의 Hos 블록 루트와 상위 블록 헤더가 보관 헤더와 같은지
+func (l *LightClient) checkFullProof(fp FullProof) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if fp.HosID != l.st.HosID {
+		return fmt.Errorf("proof hos_id %s differs from followed chain %s", fp.HosID, l.st.HosID)
+	}
+	bi, ui := fp.Lower.BlockIndex, fp.Anchor.UpperBlockIndex
+	if l.hos != nil {
+		if bi >= len(l.st.HosHeaders) {
+			return fmt.Errorf("hos block #%d: %w", bi, ErrNotSynced)
+		}
+		if root := l.st.HosHeaders[bi].MerkleRoot; root != fp.Lower.BlockRoot {
+			return fmt.Errorf("hos block #%d root %s differs from verified header %s", bi, fp.Lower.BlockRoot, root)
+		}
+	}
+	if ui >= len(l.st.GovHeaders) {
+		return fmt.Errorf("gov block #%d: %w", ui, ErrNotSynced)
+	}
+	gh := l.st.GovHeaders[ui]
+	if gh.BlockHash != fp.Anchor.Block.BlockHash || gh.MerkleRoot != fp.Anchor.Block.MerkleRoot {
+		return fmt.Errorf("gov block #%d differs from verified header", ui)
+	}
+	return nil
+}
+
+// Hos 블록 block 의 entry 번째 레코드 포함 증명을 받아 보관 헤더 merkle_root 로 검증 후 보관
+func (l *LightClient) ProveEntry(ctx context.Context, block, entry int) (LightEntry, error) {
+	if l.hos == nil {
+		return LightEntry{}, errors.New("no hos node configured")
+	}
+	p, err := l.hos.GetProof(ctx, block, entry)
+	if err != nil {
+		return LightEntry{}, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if block >= len(l.st.HosHeaders) {
+		return LightEntry{}, fmt.Errorf("hos block #%d: %w", block, ErrNotSynced)
+	}
+	root := l.st.HosHeaders[block].MerkleRoot
+	if err := checkProof(p.ProofVersion, p.Leaf, p.Proof, root, fmt.Errorf("leaf proof does not reach verified block #%d root %s", block, root)); err != nil {
+		return LightEntry{}, err
+	}
+	e := LightEntry{BlockIndex: block, EntryIndex: entry, Leaf: p.Leaf, Proof: p.Proof, ProofVersion: p.ProofVersion, BlockRoot: root}
+	if l.st.Entries == nil {
+		l.st.Entries = make(map[string]LightEntry)
+	}
+	l.st.Entries[fmt.Sprintf("%d:%d", block, entry)] = e
+	return e, nil
+}
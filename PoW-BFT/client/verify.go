@@ -0,0 +1,200 @@
+package client
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"merkle"
+)
+
+// Merkle 증명 검증 (노드와 같은 공용 merkle 패키지 규칙)
+//   - proof 항목 = [방향, 형제 해시], "L" 이면 형제가 왼쪽
+//   - 부모 = sha256(왼쪽 바이트 || 오른쪽 바이트)
+func VerifyMerkleProof(leaf string, proof [][2]string, root string) bool {
+	return merkle.Verify(leaf, proof, root)
+}
+
+// 응답의 proof_version 규칙으로 Merkle 증명 검증 (0 = proof_version 없는 이전 응답)
+//   - 이 SDK 가 지원하지 않는 버전이면 오류 (지원 범위 : merkle.SupportedProofVersions)
+func VerifyMerkleProofVersion(version int, leaf string, proof [][2]string, root string) (bool, error) {
+	return merkle.VerifyVersion(version, leaf, proof, root)
+}
+
+// 버전별 검증 결과를 오류로 (불일치면 mismatch)
+func checkProof(version int, leaf string, proof [][2]string, root string, mismatch error) error {
+	ok, err := VerifyMerkleProofVersion(version, leaf, proof, root)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return mismatch
+	}
+	return nil
+}
+
+// 정규 JSON 의 sha256 (노드 jsonCanonical 과 같은 규칙)
+//   - 객체 키는 모든 깊이에서 정렬, 공백/HTML 이스케이프 없음
+//   - 숫자는 자릿수 유지 : 정수 리터럴은 그대로, 소수/지수 표기는 float64 의 Go 표준 표기
+func canonicalHash(v any) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	var tree any
+	if err := unmarshalNumbers(raw, &tree); err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	writeCanonical(buf, enc, tree)
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func unmarshalNumbers(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func writeCanonical(buf *bytes.Buffer, enc *json.Encoder, v any) {
+	switch x := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonical(buf, enc, k)
+			buf.WriteByte(':')
+			writeCanonical(buf, enc, x[k])
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, e := range x {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonical(buf, enc, e)
+		}
+		buf.WriteByte(']')
+	case string:
+		enc.Encode(x)
+		buf.Truncate(buf.Len() - 1)
+	case json.Number:
+		s := string(x)
+		if f, err := x.Float64(); err == nil && strings.ContainsAny(s, ".eE") {
+			b, _ := json.Marshal(f)
+			s = string(b)
+		}
+		buf.WriteString(s)
+	case bool:
+		buf.WriteString(strconv.FormatBool(x))
+	default:
+		buf.WriteString("null")
+	}
+}
+
+// 레코드 leaf 해시 (노드 hashClinicRecord 와 같은 규칙)
+//   - salt 없는 레코드 : 정렬 JSON 의 sha256
+//   - salt 있는 레코드 : 필드 leaf(FieldLeaf) 를 키 정렬 순서로 쌓은 머클 루트
+func RecordLeaf(record json.RawMessage) (string, error) {
+	var m map[string]any
+	if err := unmarshalNumbers(record, &m); err != nil {
+		return "", err
+	}
+	salt, _ := m["salt"].(string)
+	if salt == "" {
+		return canonicalHash(m)
+	}
+	delete(m, "salt")
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	leaves := make([]string, len(keys))
+	for i, k := range keys {
+		leaf, err := FieldLeaf(k, FieldSalt(salt, k), m[k])
+		if err != nil {
+			return "", err
+		}
+		leaves[i] = leaf
+	}
+	return merkle.Root(leaves), nil
+}
+
+// 필드 salt = HMAC-SHA256(레코드 salt, 필드 이름) hex
+func FieldSalt(recordSalt, field string) string {
+	m := hmac.New(sha256.New, []byte(recordSalt))
+	m.Write([]byte(field))
+	return hex.EncodeToString(m.Sum(nil))
+}
+
+// 필드 leaf = sha256(정렬 JSON {"field", "salt", "value"})
+func FieldLeaf(field, salt string, value any) (string, error) {
+	return canonicalHash(map[string]any{"field": field, "salt": salt, "value": value})
+}
+
+// 선택 공개 응답 검증
+//  1. 공개 필드마다 필드 leaf => leaf
+//  2. leaf => block_root
+//
+// (block_root 의 앵커 여부는 Gov /proof/full 또는 /verify 로 별도 확인)
+func VerifyDisclosure(d DisclosedResult) error {
+	if d.Disclosure == nil {
+		return fmt.Errorf("no disclosure: %s", d.Note)
+	}
+	for _, f := range d.Disclosure.Fields {
+		leaf, err := FieldLeaf(f.Field, f.Salt, f.Value)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.Field, err)
+		}
+		if err := checkProof(d.ProofVersion, leaf, f.Proof, d.Leaf, fmt.Errorf("field %s proof does not reach leaf %s", f.Field, d.Leaf)); err != nil {
+			return err
+		}
+	}
+	return checkProof(d.ProofVersion, d.Leaf, d.Proof, d.BlockRoot, fmt.Errorf("leaf proof does not reach block root %s", d.BlockRoot))
+}
+
+// /proof/full 묶음 오프라인 검증 (서명 검증은 제외, Gov 공개키로 별도 확인)
+//  1. 레코드 해시 == lower.leaf
+//  2. lower.leaf => lower.block_root
+//  3. anchor.root == lower.block_root, block_root => 상위 블록 merkle_root
+//  4. 상위 블록 해시가 난이도 조건(선행 0 개수) 충족
+func VerifyFullProof(fp FullProof) error {
+	leaf, err := RecordLeaf(fp.Record)
+	if err != nil {
+		return fmt.Errorf("record: %w", err)
+	}
+	if leaf != fp.Lower.Leaf {
+		return fmt.Errorf("record hash %s does not match leaf %s", leaf, fp.Lower.Leaf)
+	}
+	if err := checkProof(fp.Lower.ProofVersion, fp.Lower.Leaf, fp.Lower.Proof, fp.Lower.BlockRoot, fmt.Errorf("lower proof does not reach block root %s", fp.Lower.BlockRoot)); err != nil {
+		return err
+	}
+	if fp.Anchor.Root != fp.Lower.BlockRoot {
+		return fmt.Errorf("anchor root %s differs from block root %s", fp.Anchor.Root, fp.Lower.BlockRoot)
+	}
+	if err := checkProof(fp.Anchor.ProofVersion, fp.Lower.BlockRoot, fp.Anchor.MerkleProof, fp.Anchor.Block.MerkleRoot, fmt.Errorf("anchor proof does not reach upper block #%d root", fp.Anchor.UpperBlockIndex)); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(fp.Anchor.Block.BlockHash, strings.Repeat("0", fp.Anchor.Block.Difficulty)) {
+		return fmt.Errorf("upper block #%d hash does not meet difficulty %d", fp.Anchor.UpperBlockIndex, fp.Anchor.Block.Difficulty)
+	}
+	return nil
+}
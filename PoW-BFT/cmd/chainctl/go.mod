@@ -0,0 +1,18 @@
+module chainctl
+
+go 1.25
+
+require (
+	client v0.0.0
+	github.com/spf13/cobra v1.10.2
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.9 // indirect
+	merkle v0.0.0 // indirect
+)
+
+replace client => ../../client
+
+replace merkle => ../../../merkle
@@ -0,0 +1,166 @@
+// main.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"client"
+
+	"github.com/spf13/cobra"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// chainctl : 노드 운영 / 체인 조회 CLI
+// ------------------------------------------------------------
+// - 임의의 노드 주소(--node)에 대해 Hos(하위 체인) / Gov(상위 체인) HTTP API 호출 (client SDK 사용)
+//   · 노드 종류는 --type 으로 지정, 기본(auto)은 GET /v1/meta 의 node_role 로 판별
+//   · cp 는 hos, ott 는 gov 의 별칭 (같은 2계층 구조의 이전 명칭)
+// - 명령
+//   · status / peers / blocks / block       : 노드 상태, 피어, 블록 조회
+//   · submit                                : 레코드 접수 (Hos)
+//   · revoke <clinic-id>                    : 확정 레코드 철회 (Hos)
+//   · finalize                              : 즉시 합의(Hos) / 채굴(Gov) 시작
+//   · proof record | anchor | full          : 포함 증명 조회 (JSON 출력, -o 로 파일 저장)
+//   · proof disclose                        : 지정 필드만 공개하는 선택 공개 증명 조회 (Hos)
+//   · verify <file>                         : 저장된 증명 오프라인 검증 (노드 접속 없음)
+//   · resync                                : 피어 체인과 즉시 동기화/분기 교체
+//   · light                                 : 헤더만 검증 동기화 + 요청한 증명 확인 (-s 상태 파일)
+//   · export / import <file>                : 블록 NDJSON 아카이브 내보내기 / 검증 후 가져오기 (이관, 오프라인 감사)
+// - 사용 예 (PoW-BFT/cmd/chainctl 에서)
+//     go run . --node 127.0.0.1:6100 status
+//     go run . --node 127.0.0.1:6000 proof full --hos-id hos-a --clinic-id C-001 -o full.json
+//     go run . verify full.json
+// - 노드에 인증이 켜져 있으면 --api-key (또는 CHAINCTL_API_KEY) 로 operator 키 전달 (관리 명령)
+// - 결과는 JSON 으로 표준 출력, 실패 시 종료 코드 1
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	NodeEnv        = "CHAINCTL_NODE"    // --node 기본값
+	APIKeyEnv      = "CHAINCTL_API_KEY" // --api-key 기본값
+	DefaultNode    = "127.0.0.1:6100"
+	DefaultTimeout = 30 * time.Second
+)
+
+type Globals struct {
+	Node    string
+	Type    string
+	Timeout time.Duration
+	Retries int
+	APIKey  string
+}
+
+var g Globals
+
+func main() {
+	root := &cobra.Command{
+		Use:           "chainctl",
+		Short:         "Hos/Gov 노드 운영 및 체인 조회",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	pf := root.PersistentFlags()
+	pf.StringVar(&g.Node, "node", getEnvDefault(NodeEnv, DefaultNode), "노드 주소 (host:port 또는 http(s)://host:port)")
+	pf.StringVar(&g.Type, "type", "auto", "노드 종류 : auto | hos | gov (cp=hos, ott=gov)")
+	pf.DurationVar(&g.Timeout, "timeout", DefaultTimeout, "명령 전체 제한 시간")
+	pf.IntVar(&g.Retries, "retries", client.DefaultRetries, "일시 오류 재시도 횟수")
+	pf.StringVar(&g.APIKey, "api-key", os.Getenv(APIKeyEnv), "노드 API 키 (X-API-Key, 관리 명령은 operator 키)")
+
+	root.AddCommand(
+		statusCmd(), peersCmd(), blocksCmd(), blockCmd(),
+		submitCmd(), revokeCmd(), finalizeCmd(), proofCmd(), verifyCmd(), resyncCmd(), lightCmd(),
+		exportCmd(), importCmd(),
+	)
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "chainctl:", err)
+		os.Exit(1)
+	}
+}
+
+// 명령 실행 컨텍스트 (--timeout)
+func cmdContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(cmd.Context(), g.Timeout)
+}
+
+// 대상 노드 : hos / gov 중 하나만 설정
+type target struct {
+	hos *client.HosClient
+	gov *client.GovClient
+}
+
+func (t target) role() string {
+	if t.hos != nil {
+		return "hos"
+	}
+	return "gov"
+}
+
+// --type 해석, auto 면 /v1/meta 조회
+func resolveTarget(ctx context.Context) (target, error) {
+	opts := []client.Option{client.WithRetries(g.Retries), client.WithAPIKey(g.APIKey)}
+	role := strings.ToLower(g.Type)
+	if role == "auto" {
+		m, err := client.NewHosClient(g.Node, opts...).Meta(ctx)
+		if err != nil {
+			return target{}, fmt.Errorf("detect node type (use --type): %w", err)
+		}
+		role = m.NodeRole
+	}
+	switch role {
+	case "hos", "cp":
+		return target{hos: client.NewHosClient(g.Node, opts...)}, nil
+	case "gov", "ott":
+		return target{gov: client.NewGovClient(g.Node, opts...)}, nil
+	}
+	return target{}, fmt.Errorf("unknown node type %q", role)
+}
+
+// 특정 종류 노드 전용 명령
+func requireRole(t target, role, what string) error {
+	if t.role() != role {
+		return fmt.Errorf("%s requires a %s node (%s is %s)", what, role, g.Node, t.role())
+	}
+	return nil
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// 파일 경로가 비어 있으면 표준 출력
+func writeJSONFile(path string, v any) error {
+	if path == "" {
+		return printJSON(v)
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s\n", path)
+	return nil
+}
+
+// "-" 이면 표준 입력
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+func getEnvDefault(k, def string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Fault Proxy (노드 앞단의 장애 주입 전송 계층)
+// ------------------------------------------------------------
+// - 노드마다 devnet 프로세스 안에서 HTTP 프록시 1개를 띄움
+//   · 노드가 외부에 알리는 주소(NODE_ADDR) = 프록시, 실제 노드는 프록시 포트 + directPortOffset 에서 대기
+//   · 따라서 다른 노드가 이 노드로 보내는 모든 요청(합의, 블록 전파, 동기화)이 프록시를 거침
+//   · devnet 의 상태 조회/검증은 실제 포트로 직접 호출 (장애 규칙의 영향 없음)
+// - 장애 규칙 (경로 접두어 단위, 수신 측 기준)
+//   · Drop  : 확률적으로 연결을 끊음 (네트워크 유실)
+//   · Delay : 전달 전 대기 (지연)
+//   · Dup   : 확률적으로 같은 요청을 한 번 더 전달 (중복 수신)
+//   · Limit : 규칙을 적용할 최대 요청 수 (0 이면 무제한)
+// - 노드가 죽어 있으면 연결을 끊어 connection refused 와 같은 효과를 냄
+////////////////////////////////////////////////////////////////////////////////
+
+const directPortOffset = 50 // 프록시 포트 + 50 = 실제 노드 포트 (체인당 노드 50개 미만)
+
+type Fault struct {
+	Path  string        // 경로 접두어 (예: "/bft/", "/receiveBlock")
+	Drop  float64       // 0..1
+	Delay time.Duration // 전달 전 대기
+	Dup   float64       // 0..1
+	Limit int           // 적용 횟수 상한
+}
+
+type faultRule struct {
+	Fault
+	hits atomic.Int64
+}
+
+type FaultProxy struct {
+	Listen string // 프록시 주소 (노드 NODE_ADDR)
+	Target string // 실제 노드 주소
+	mu     sync.Mutex
+	rules  []*faultRule
+	srv    *http.Server
+	rp     *httputil.ReverseProxy
+
+	dropped, delayed, duplicated atomic.Int64
+}
+
+func newFaultProxy(listen, target string) *FaultProxy {
+	p := &FaultProxy{Listen: listen, Target: target}
+	p.rp = httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: target})
+	p.rp.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		closeConn(w) // 노드 다운 => 연결 거부처럼 보이게
+	}
+	return p
+}
+
+func (p *FaultProxy) Start() error {
+	ln, err := net.Listen("tcp", p.Listen)
+	if err != nil {
+		return err
+	}
+	p.srv = &http.Server{Handler: p}
+	go func() { _ = p.srv.Serve(ln) }()
+	return nil
+}
+
+func (p *FaultProxy) Close() {
+	if p.srv != nil {
+		_ = p.srv.Close()
+	}
+}
+
+// 장애 규칙 추가 (기존 규칙 유지)
+func (p *FaultProxy) Add(f Fault) {
+	p.mu.Lock()
+	p.rules = append(p.rules, &faultRule{Fault: f})
+	p.mu.Unlock()
+}
+
+// 모든 장애 규칙 제거 (통계도 초기화)
+func (p *FaultProxy) Clear() {
+	p.mu.Lock()
+	p.rules = nil
+	p.mu.Unlock()
+	p.dropped.Store(0)
+	p.delayed.Store(0)
+	p.duplicated.Store(0)
+}
+
+// 요청 경로에 맞는 첫 규칙 (적용 횟수 상한을 넘으면 제외)
+func (p *FaultProxy) match(path string) *Fault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, rule := range p.rules {
+		if !strings.HasPrefix(path, rule.Path) {
+			continue
+		}
+		if rule.Limit > 0 && rule.hits.Add(1) > int64(rule.Limit) {
+			continue
+		}
+		return &rule.Fault
+	}
+	return nil
+}
+
+func (p *FaultProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f := p.match(r.URL.Path)
+	if f == nil {
+		p.rp.ServeHTTP(w, r)
+		return
+	}
+	if f.Drop > 0 && rand.Float64() < f.Drop {
+		p.dropped.Add(1)
+		closeConn(w)
+		return
+	}
+	if f.Delay > 0 {
+		p.delayed.Add(1)
+		time.Sleep(f.Delay)
+	}
+	if f.Dup > 0 && rand.Float64() < f.Dup {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		p.duplicated.Add(1)
+		go p.resend(r.Method, r.URL.RequestURI(), r.Header.Clone(), body)
+	}
+	p.rp.ServeHTTP(w, r)
+}
+
+// 중복 전달 (응답은 버림, X-Chain-ID 등 원래 요청 헤더 유지)
+func (p *FaultProxy) resend(method, uri string, header http.Header, body []byte) {
+	req, err := http.NewRequest(method, "http://"+p.Target+uri, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header = header
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+func (p *FaultProxy) Stats() string {
+	return fmt.Sprintf("dropped=%d delayed=%d duplicated=%d", p.dropped.Load(), p.delayed.Load(), p.duplicated.Load())
+}
+
+func closeConn(w http.ResponseWriter) {
+	if hj, ok := w.(http.Hijacker); ok {
+		if conn, _, err := hj.Hijack(); err == nil {
+			conn.Close()
+			return
+		}
+	}
+	w.WriteHeader(http.StatusServiceUnavailable)
+}
+
+// 여러 노드에 같은 장애 규칙 적용 (Limit 은 노드별로 계산)
+func injectFaults(nodes []*Node, f Fault) {
+	names := []string{}
+	for _, n := range nodes {
+		n.proxy.Add(f)
+		names = append(names, n.Name)
+	}
+	log.Printf("[FAULT] %s: path=%s drop=%.2f delay=%s dup=%.2f limit=%d",
+		strings.Join(names, ","), f.Path, f.Drop, f.Delay, f.Dup, f.Limit)
+}
+
+// 모든 노드의 장애 규칙 제거 + 통계 출력
+func (nw *Network) ClearFaults() {
+	for _, ch := range append([]*Chain{nw.Gov}, nw.Hos...) {
+		for _, n := range ch.Nodes {
+			if n.proxy == nil {
+				continue
+			}
+			if s := n.proxy.Stats(); s != "dropped=0 delayed=0 duplicated=0" {
+				log.Printf("[FAULT] %s %s", n.Name, s)
+			}
+			n.proxy.Clear()
+		}
+	}
+}
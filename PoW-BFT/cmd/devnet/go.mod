@@ -0,0 +1,3 @@
+module devnet
+
+go 1.25
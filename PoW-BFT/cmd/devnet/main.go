@@ -0,0 +1,152 @@
+// main.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// devnet : 2계층 통합 테스트 네트워크 실행기
+// ------------------------------------------------------------
+// - 명령 하나로 Gov 노드 M개 + Hos 체인 K개(체인별 노드 N개)를 로컬 자식 프로세스로 실행
+//   (노드 소스는 package main 전역 상태를 사용하므로 프로세스 단위로만 격리)
+// - 샘플 레코드 접수, 시나리오(anchor / kill / viewstall / lossy / powrace / failover) 실행, 불변식 검사 후 결과 출력
+// - 노드 간 통신은 devnet 안의 장애 주입 프록시를 거침 (유실/지연/중복 : faults.go)
+// - 사용 예 (PoW-BFT/cmd/devnet 에서)
+//     go run . -gov 2 -chains 2 -nodes 4 -scenarios all
+//     go run . -chains 1 -scenarios anchor -keep      // 시나리오 후 Ctrl-C 까지 유지
+// - 실패 시 종료 코드 1, 노드 로그는 <workdir>/<노드 이름>/node.log
+////////////////////////////////////////////////////////////////////////////////
+
+type Config struct {
+	GovNodes  int
+	Chains    int
+	HosNodes  int
+	BasePort  int
+	Records   int
+	Workdir   string
+	GovSrc    string
+	HosSrc    string
+	Scenarios []string
+	Timeout   time.Duration
+	Policy    bool
+	Keep      bool
+}
+
+func parseConfig() Config {
+	var cfg Config
+	var scen string
+	flag.IntVar(&cfg.GovNodes, "gov", 2, "number of Gov (upper chain) nodes")
+	flag.IntVar(&cfg.Chains, "chains", 2, "number of Hos (lower) chains")
+	flag.IntVar(&cfg.HosNodes, "nodes", 4, "nodes per Hos chain")
+	flag.IntVar(&cfg.BasePort, "base-port", 6000, "first port (Gov), Hos chain k uses base+100*(k+1)")
+	flag.IntVar(&cfg.Records, "records", 5, "sample records seeded per scenario step")
+	flag.StringVar(&cfg.Workdir, "workdir", "", "node data directory (default: new temp dir)")
+	flag.StringVar(&cfg.GovSrc, "gov-src", "../../gov", "Gov node source directory")
+	flag.StringVar(&cfg.HosSrc, "hos-src", "../../hos", "Hos node source directory")
+	flag.StringVar(&scen, "scenarios", "all", "comma separated: "+strings.Join(scenarioOrder, ",")+" | all | none")
+	flag.DurationVar(&cfg.Timeout, "timeout", 90*time.Second, "per-step wait limit")
+	flag.BoolVar(&cfg.Policy, "policy", false, "keep Gov onboarding/contract policy enabled")
+	flag.BoolVar(&cfg.Keep, "keep", false, "keep the network running after scenarios until interrupted")
+	flag.Parse()
+
+	switch scen {
+	case "all":
+		cfg.Scenarios = scenarioOrder
+	case "none", "":
+	default:
+		cfg.Scenarios = strings.Split(scen, ",")
+	}
+	return cfg
+}
+
+func main() {
+	cfg := parseConfig()
+	if cfg.GovNodes < 1 || cfg.Chains < 1 || cfg.HosNodes < 1 {
+		log.Fatal("[DEVNET] -gov, -chains and -nodes must be >= 1")
+	}
+	if cfg.GovNodes >= directPortOffset || cfg.HosNodes >= directPortOffset {
+		log.Fatalf("[DEVNET] -gov and -nodes must be < %d", directPortOffset)
+	}
+	for _, s := range cfg.Scenarios {
+		if _, ok := scenarios[s]; !ok {
+			log.Fatalf("[DEVNET] unknown scenario %q (known: %s)", s, strings.Join(scenarioOrder, ","))
+		}
+	}
+	if cfg.Workdir == "" {
+		dir, err := os.MkdirTemp("", "devnet-")
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Workdir = dir
+	}
+	log.Printf("[DEVNET] gov=%d chains=%d nodes/chain=%d workdir=%s", cfg.GovNodes, cfg.Chains, cfg.HosNodes, cfg.Workdir)
+
+	bins, err := buildBinaries(cfg.Workdir, cfg.GovSrc, cfg.HosSrc)
+	if err != nil {
+		log.Fatal("[DEVNET] ", err)
+	}
+	nw := newNetwork(cfg, bins)
+
+	// Ctrl-C / SIGTERM 시 자식 프로세스 정리
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		log.Println("[DEVNET] interrupted, stopping nodes")
+		nw.Stop()
+		os.Exit(130)
+	}()
+
+	if err := nw.Start(cfg.GovSrc, cfg.HosSrc); err != nil {
+		nw.Stop()
+		log.Fatal("[DEVNET] ", err)
+	}
+
+	failed := runScenarios(nw, cfg)
+
+	if cfg.Keep {
+		log.Println("[DEVNET] network kept running; press Ctrl-C to stop")
+		select {}
+	}
+	nw.Stop()
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// 시나리오 순차 실행 + 각 시나리오 후 불변식 검사, 실패 수 반환
+func runScenarios(nw *Network, cfg Config) int {
+	failed := 0
+	results := []string{}
+	for _, name := range cfg.Scenarios {
+		log.Printf("[SCENARIO] ===== %s =====", name)
+		before := heights(nw)
+		start := time.Now()
+		err := scenarios[name](nw, cfg)
+		if err == nil {
+			err = checkInvariants(nw, before, cfg.Timeout)
+		}
+		status := "PASS"
+		if err != nil {
+			status = "FAIL: " + err.Error()
+			failed++
+		}
+		results = append(results, fmt.Sprintf("%-9s %s (%s)", name, status, time.Since(start).Round(time.Second)))
+		log.Printf("[SCENARIO] %s %s", name, status)
+	}
+	if len(results) > 0 {
+		fmt.Println("\n===== devnet results =====")
+		for _, r := range results {
+			fmt.Println(r)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Devnet 네트워크 (노드 자식 프로세스 관리)
+// ------------------------------------------------------------
+// - gov/hos 소스를 workdir/bin 에 한 번 빌드한 뒤 노드마다 자식 프로세스로 실행
+//   · 노드별 작업 디렉터리 workdir/<노드 이름> (LevelDB, block_history.txt, node.log)
+//   · 대시보드 정적 파일은 소스의 static 디렉터리를 링크
+// - 모든 노드는 127.0.0.1:<포트> 로 통신 (Gov : basePort.., Hos 체인 k : basePort+100*(k+1)..)
+//   · 알리는 주소는 장애 주입 프록시(faults.go), 실제 노드는 +directPortOffset 포트에서 대기
+// - 각 체인의 첫 노드가 부트노드, 나머지는 부트노드에 등록하며 합류
+// - Gov 는 가입 심사/계약 정책을 끈 상태로 실행 (-policy 지정 시 노드 기본값 유지)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	readyTimeout = 30 * time.Second
+	joinDelay    = 1500 * time.Millisecond // 부트노드 기동 후 다음 노드 실행까지 대기
+)
+
+var httpClient = &http.Client{Timeout: 5 * time.Second}
+
+type Node struct {
+	Name    string
+	Role    string // "gov" | "hos"
+	ChainID string
+	Addr    string // 다른 노드가 쓰는 주소 (장애 주입 프록시)
+	Direct  string // 실제 노드 주소 (devnet 조회용)
+	Dir     string
+	Env     []string
+	proxy   *FaultProxy
+	cmd     *exec.Cmd
+	done    chan struct{}
+	logFile *os.File
+}
+
+type Chain struct {
+	ID    string
+	Nodes []*Node
+}
+
+type Network struct {
+	Workdir string
+	Gov     *Chain
+	Hos     []*Chain
+	bins    map[string]string // role => 빌드된 바이너리 경로
+}
+
+// 노드 소스 빌드 (workdir/bin/<role>)
+func buildBinaries(workdir, govSrc, hosSrc string) (map[string]string, error) {
+	bins := map[string]string{}
+	for role, src := range map[string]string{"gov": govSrc, "hos": hosSrc} {
+		out, err := filepath.Abs(filepath.Join(workdir, "bin", role))
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[BUILD] %s <= %s", out, src)
+		cmd := exec.Command("go", "build", "-o", out, ".")
+		cmd.Dir = src
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("build %s: %w", role, err)
+		}
+		bins[role] = out
+	}
+	return bins, nil
+}
+
+// 네트워크 구성 (노드 정의만 생성, 실행은 Start)
+func newNetwork(cfg Config, bins map[string]string) *Network {
+	nw := &Network{Workdir: cfg.Workdir, bins: bins}
+
+	gov := &Chain{ID: "Gov-A"}
+	govBoot := fmt.Sprintf("127.0.0.1:%d", cfg.BasePort)
+	for i := 0; i < cfg.GovNodes; i++ {
+		n := newNode(fmt.Sprintf("gov-%02d", i), "gov", gov.ID, cfg.BasePort+i)
+		n.Env = []string{
+			"PORT=" + portOf(n.Direct), "NODE_ADDR=" + n.Addr, "BOOTSTRAP_ADDR=" + govBoot,
+			"Gov_ID=" + gov.ID, "Gov_DB_PATH=blockchain_db",
+		}
+		if !cfg.Policy {
+			n.Env = append(n.Env, "ONBOARDING_REQUIRED=false", "CONTRACT_POLICY=false")
+		}
+		gov.Nodes = append(gov.Nodes, n)
+	}
+	nw.Gov = gov
+
+	for k := 0; k < cfg.Chains; k++ {
+		ch := &Chain{ID: fmt.Sprintf("Hos-%c", 'A'+k)}
+		base := cfg.BasePort + 100*(k+1)
+		boot := fmt.Sprintf("127.0.0.1:%d", base)
+		for i := 0; i < cfg.HosNodes; i++ {
+			n := newNode(fmt.Sprintf("%s-%02d", strings.ToLower(ch.ID), i), "hos", ch.ID, base+i)
+			n.Env = []string{
+				"PORT=" + portOf(n.Direct), "NODE_ADDR=" + n.Addr, "BOOTSTRAP_ADDR=" + boot,
+				"Hos_ID=" + ch.ID, "Hos_DB_PATH=blockchain_db", "GOV_BOOTSTRAP_ADDR=" + govBoot,
+			}
+			ch.Nodes = append(ch.Nodes, n)
+		}
+		nw.Hos = append(nw.Hos, ch)
+	}
+	return nw
+}
+
+// 노드 정의 (port : 알리는 주소의 포트)
+func newNode(name, role, chainID string, port int) *Node {
+	n := &Node{
+		Name:    name,
+		Role:    role,
+		ChainID: chainID,
+		Addr:    fmt.Sprintf("127.0.0.1:%d", port),
+		Direct:  fmt.Sprintf("127.0.0.1:%d", port+directPortOffset),
+	}
+	n.proxy = newFaultProxy(n.Addr, n.Direct)
+	return n
+}
+
+func portOf(addr string) string {
+	return addr[strings.LastIndex(addr, ":")+1:]
+}
+
+// 모든 노드 실행 (Gov 먼저, 각 체인은 부트노드부터)
+func (nw *Network) Start(govSrc, hosSrc string) error {
+	for _, ch := range append([]*Chain{nw.Gov}, nw.Hos...) {
+		src := hosSrc
+		if ch == nw.Gov {
+			src = govSrc
+		}
+		for i, n := range ch.Nodes {
+			if err := n.proxy.Start(); err != nil {
+				return fmt.Errorf("proxy %s: %w", n.Name, err)
+			}
+			if err := nw.startNode(n, src); err != nil {
+				return err
+			}
+			if err := waitReady(n); err != nil {
+				return err
+			}
+			if i == 0 {
+				time.Sleep(joinDelay)
+			}
+		}
+		log.Printf("[NET] %s up (%d nodes)", ch.ID, len(ch.Nodes))
+	}
+	return nil
+}
+
+func (nw *Network) startNode(n *Node, src string) error {
+	n.Dir = filepath.Join(nw.Workdir, n.Name)
+	if err := os.MkdirAll(n.Dir, 0o755); err != nil {
+		return err
+	}
+	if abs, err := filepath.Abs(filepath.Join(src, "static")); err == nil {
+		_ = os.Symlink(abs, filepath.Join(n.Dir, "static"))
+	}
+	f, err := os.OpenFile(filepath.Join(n.Dir, "node.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(nw.bins[n.Role])
+	cmd.Dir = n.Dir
+	cmd.Env = append(os.Environ(), n.Env...)
+	cmd.Stdout, cmd.Stderr = f, f
+	if err := cmd.Start(); err != nil {
+		f.Close()
+		return fmt.Errorf("start %s: %w", n.Name, err)
+	}
+	n.cmd, n.logFile, n.done = cmd, f, make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		f.Close()
+		close(n.done)
+	}()
+	log.Printf("[NET] started %s (%s) pid=%d", n.Name, n.Addr, cmd.Process.Pid)
+	return nil
+}
+
+// 노드 강제 종료 (장애 시나리오용)
+func (n *Node) Kill() {
+	if !n.Alive() {
+		return
+	}
+	_ = n.cmd.Process.Kill()
+	<-n.done
+	log.Printf("[NET] killed %s (%s)", n.Name, n.Addr)
+}
+
+func (n *Node) Alive() bool {
+	if n.cmd == nil {
+		return false
+	}
+	select {
+	case <-n.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// 전체 노드 종료
+func (nw *Network) Stop() {
+	for _, ch := range append([]*Chain{nw.Gov}, nw.Hos...) {
+		for _, n := range ch.Nodes {
+			if n.Alive() {
+				_ = n.cmd.Process.Signal(os.Interrupt)
+				select {
+				case <-n.done:
+				case <-time.After(3 * time.Second):
+					n.Kill()
+				}
+			}
+			n.proxy.Close()
+		}
+	}
+	log.Printf("[NET] all nodes stopped (logs: %s/<node>/node.log)", nw.Workdir)
+}
+
+func (ch *Chain) Live() []*Node {
+	out := []*Node{}
+	for _, n := range ch.Nodes {
+		if n.Alive() {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 노드 HTTP 조회
+////////////////////////////////////////////////////////////////////////////////
+
+type nodeStatus struct {
+	Addr     string   `json:"addr"`
+	Height   int      `json:"height"`
+	IsBoot   bool     `json:"is_boot"`
+	BootAddr string   `json:"bootAddr"`
+	Peers    []string `json:"peers"`
+	LastHash string   `json:"last_hash"`
+}
+
+func getJSON(addr, path string, v any) error {
+	resp, err := httpClient.Get("http://" + addr + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GET %s: status=%d %s", path, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func postJSON(addr, path string, body any) error {
+	data, _ := json.Marshal(body)
+	resp, err := httpClient.Post("http://"+addr+path, "application/json", strings.NewReader(string(data)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("POST %s: status=%d %s", path, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+func (n *Node) Status() (nodeStatus, error) {
+	var st nodeStatus
+	err := getJSON(n.Direct, "/status", &st)
+	return st, err
+}
+
+// 높이 h 블록의 해시
+func (n *Node) BlockHash(h int) (string, error) {
+	var blk struct {
+		BlockHash string `json:"block_hash"`
+	}
+	err := getJSON(n.Direct, "/block/index?id="+strconv.Itoa(h), &blk)
+	return blk.BlockHash, err
+}
+
+// /status 가 응답할 때까지 대기
+func waitReady(n *Node) error {
+	deadline := time.Now().Add(readyTimeout)
+	for time.Now().Before(deadline) {
+		if !n.Alive() {
+			return fmt.Errorf("%s exited during startup (see %s/node.log)", n.Name, n.Dir)
+		}
+		if _, err := n.Status(); err == nil {
+			return nil
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+	return fmt.Errorf("%s not ready after %s", n.Name, readyTimeout)
+}
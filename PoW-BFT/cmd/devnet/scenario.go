@@ -0,0 +1,450 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Devnet 시나리오 + 불변식 검사
+// ------------------------------------------------------------
+// - anchor   : 체인별 샘플 레코드 접수 => 모든 Hos 노드 블록 확정 => Gov 앵커 채굴
+//              => Gov /query 검증 결과가 anchored 로 조회되는지 확인
+// - kill     : 첫 Hos 체인의 일반 노드 1개 강제 종료 후에도 남은 노드가 블록을 확정하는지 확인
+// - viewstall: 마지막 Hos 체인의 모든 노드가 첫 라운드 Commit(/bft/commit)을 잃어버려 라운드가 멈춤
+//              => 뷰 체인지로 다음 리더가 같은 높이를 확정하는지 확인
+//              (Pre-Prepare 자체를 잃은 노드는 뷰 체인지 투표를 하지 않으므로 Commit 을 유실시킴)
+// - lossy    : 마지막 Hos 체인의 합의 메시지(/bft/)를 지연 + 중복 + 일부 유실시킨 상태에서 확정 확인
+// - powrace  : Gov 노드들의 블록 전파(/receiveBlock)를 지연시켜 채굴 경합을 유도
+//              => 경합 중 분기 여부를 기록하고, 장애 해제 후 하나의 체인으로 수렴하는지 불변식으로 확인
+// - failover : 첫 Hos 체인의 부트노드 강제 종료 => 남은 노드가 같은 새 부트노드를 선출하고
+//              새 부트노드로 접수한 레코드가 확정되는지 확인 (네트워크 감시 주기 60초 이상 소요)
+// - 장애 주입 시나리오는 종료 시 모든 장애 규칙을 제거 (faults.go)
+//   (노드 코드는 전역 상태를 쓰는 별도 프로세스이므로 리더 이중 제안 같은 비잔틴 행위는 주입하지 않음)
+// - 불변식 (각 시나리오 후 검사)
+//   · 같은 체인의 살아있는 노드는 공통 높이까지 모든 블록 해시가 일치
+//   · Hos 체인 높이는 시나리오 전보다 감소하지 않음
+////////////////////////////////////////////////////////////////////////////////
+
+var scenarioOrder = []string{"anchor", "kill", "viewstall", "lossy", "powrace", "failover"}
+
+const viewChangeWait = 45 * time.Second // 뷰 체인지 2라운드(15초 + 30초) 여유
+
+type Scenario func(nw *Network, cfg Config) error
+
+var scenarios = map[string]Scenario{
+	"anchor":    scenarioAnchor,
+	"kill":      scenarioKill,
+	"viewstall": scenarioViewStall,
+	"lossy":     scenarioLossy,
+	"powrace":   scenarioPowRace,
+	"failover":  scenarioFailover,
+}
+
+// 조건이 참이 될 때까지 폴링 (마지막 상태 설명을 오류에 포함)
+func waitUntil(timeout time.Duration, what string, cond func() (bool, string)) error {
+	deadline := time.Now().Add(timeout)
+	last := ""
+	for time.Now().Before(deadline) {
+		ok, state := cond()
+		if ok {
+			return nil
+		}
+		last = state
+		time.Sleep(time.Second)
+	}
+	return fmt.Errorf("timeout waiting for %s (%s): %s", what, timeout, last)
+}
+
+// 샘플 레코드 접수 (cCode = tag, clinic_id = <체인>-<tag>-<i>)
+func seedRecords(n *Node, chainID, tag string, count int) error {
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+	recs := make([]map[string]any, 0, count)
+	for i := 0; i < count; i++ {
+		recs = append(recs, map[string]any{
+			"clinic_id": fmt.Sprintf("%s-%s-%d", chainID, tag, i),
+			"timestamp": ts,
+			"info": map[string]any{
+				"cCode": tag,
+				"title": fmt.Sprintf("devnet sample %d", i),
+			},
+		})
+	}
+	if err := postJSON(n.Direct, "/upload", recs); err != nil {
+		return fmt.Errorf("seed %s via %s: %w", chainID, n.Name, err)
+	}
+	log.Printf("[SEED] %d records (cCode=%s) => %s", count, tag, n.Name)
+	return nil
+}
+
+// 살아있는 노드들의 최소 높이
+func minHeight(ch *Chain) (int, error) {
+	low := -1
+	for _, n := range ch.Live() {
+		st, err := n.Status()
+		if err != nil {
+			return -1, fmt.Errorf("%s: %w", n.Name, err)
+		}
+		if low < 0 || st.Height < low {
+			low = st.Height
+		}
+	}
+	return low, nil
+}
+
+// 모든 살아있는 노드가 height 이상에 도달할 때까지 대기
+func waitHeight(ch *Chain, height int, timeout time.Duration) error {
+	return waitUntil(timeout, fmt.Sprintf("%s height >= %d", ch.ID, height), func() (bool, string) {
+		h, err := minHeight(ch)
+		if err != nil {
+			return false, err.Error()
+		}
+		return h >= height, fmt.Sprintf("min height=%d", h)
+	})
+}
+
+// 불변식 : 공통 높이까지 블록 해시 일치
+func checkConsistent(ch *Chain) error {
+	live := ch.Live()
+	if len(live) == 0 {
+		return fmt.Errorf("%s: no live nodes", ch.ID)
+	}
+	h, err := minHeight(ch)
+	if err != nil {
+		return err
+	}
+	for i := 0; i <= h; i++ {
+		want, err := live[0].BlockHash(i)
+		if err != nil {
+			return fmt.Errorf("%s block #%d on %s: %w", ch.ID, i, live[0].Name, err)
+		}
+		for _, n := range live[1:] {
+			got, err := n.BlockHash(i)
+			if err != nil {
+				return fmt.Errorf("%s block #%d on %s: %w", ch.ID, i, n.Name, err)
+			}
+			if got != want {
+				return fmt.Errorf("%s fork at #%d: %s=%.12s %s=%.12s", ch.ID, i, live[0].Name, want, n.Name, got)
+			}
+		}
+	}
+	return nil
+}
+
+// 모든 체인의 불변식 검사 (PoW 채굴 경합으로 인한 일시적 분기는 timeout 동안 재확인)
+func checkInvariants(nw *Network, before map[string]int, timeout time.Duration) error {
+	for _, ch := range append([]*Chain{nw.Gov}, nw.Hos...) {
+		err := waitUntil(timeout, ch.ID+" consistency", func() (bool, string) {
+			if err := checkConsistent(ch); err != nil {
+				return false, err.Error()
+			}
+			return true, ""
+		})
+		if err != nil {
+			return err
+		}
+		if h, err := minHeight(ch); err == nil && h < before[ch.ID] {
+			return fmt.Errorf("%s height went backwards: %d -> %d", ch.ID, before[ch.ID], h)
+		}
+	}
+	return nil
+}
+
+func heights(nw *Network) map[string]int {
+	out := map[string]int{}
+	for _, ch := range append([]*Chain{nw.Gov}, nw.Hos...) {
+		out[ch.ID], _ = minHeight(ch)
+	}
+	return out
+}
+
+// 현재 부트노드 (살아있는 노드 중 is_boot)
+func bootOf(ch *Chain) *Node {
+	for _, n := range ch.Live() {
+		if st, err := n.Status(); err == nil && st.IsBoot {
+			return n
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 시나리오
+////////////////////////////////////////////////////////////////////////////////
+
+func scenarioAnchor(nw *Network, cfg Config) error {
+	for k, ch := range nw.Hos {
+		boot := bootOf(ch)
+		if boot == nil {
+			return fmt.Errorf("%s: no boot node", ch.ID)
+		}
+		start, err := minHeight(ch)
+		if err != nil {
+			return err
+		}
+		tag := fmt.Sprintf("DEVA%d", k)
+		if err := seedRecords(boot, ch.ID, tag, cfg.Records); err != nil {
+			return err
+		}
+		if err := waitHeight(ch, start+1, cfg.Timeout); err != nil {
+			return err
+		}
+
+		// Gov 가 앵커를 채굴하고 /query 검증 결과가 anchored 가 될 때까지 대기
+		q := url.Values{"hos_id": {ch.ID}, "keyword": {tag}}
+		err = waitUntil(cfg.Timeout, ch.ID+" anchored query via Gov", func() (bool, string) {
+			var items []struct {
+				Inclusion struct {
+					AnchorStatus string `json:"anchor_status"`
+				} `json:"inclusion"`
+			}
+			if err := getJSON(nw.Gov.Nodes[0].Direct, "/query?"+q.Encode(), &items); err != nil {
+				return false, err.Error()
+			}
+			if len(items) == 0 {
+				return false, "no verified results yet"
+			}
+			for _, it := range items {
+				if it.Inclusion.AnchorStatus != "anchored" {
+					return false, "anchor_status=" + it.Inclusion.AnchorStatus
+				}
+			}
+			return true, ""
+		})
+		if err != nil {
+			return err
+		}
+		if err := checkAnchorProof(nw, ch, boot, start+1, tag); err != nil {
+			return err
+		}
+		log.Printf("[SCENARIO][anchor] %s records anchored and verified through Gov", ch.ID)
+	}
+	return nil
+}
+
+// Hos 블록 h 의 루트에 대한 Gov 앵커 포함 증명(/anchor/proof)을 받아 머클 경로를 직접 재계산
+// + tag 로 접수한 첫 레코드의 단일 증명 묶음(/proof/full) 검사 결과 확인
+func checkAnchorProof(nw *Network, ch *Chain, hos *Node, h int, tag string) error {
+	var blk struct {
+		MerkleRoot string `json:"merkle_root"`
+	}
+	if err := getJSON(hos.Direct, "/block/index?id="+strconv.Itoa(h), &blk); err != nil {
+		return err
+	}
+	var p struct {
+		UpperBlockIndex int         `json:"upper_block_index"`
+		MerkleProof     [][2]string `json:"merkle_proof"`
+		Block           struct {
+			MerkleRoot string `json:"merkle_root"`
+		} `json:"block"`
+		Sig string `json:"sig"`
+	}
+	q := url.Values{"hos_id": {ch.ID}, "root": {blk.MerkleRoot}}
+	if err := getJSON(nw.Gov.Nodes[0].Direct, "/anchor/proof?"+q.Encode(), &p); err != nil {
+		return fmt.Errorf("%s anchor proof for block #%d: %w", ch.ID, h, err)
+	}
+	cur := blk.MerkleRoot
+	for _, step := range p.MerkleProof {
+		l, _ := hex.DecodeString(cur)
+		s, _ := hex.DecodeString(step[1])
+		if step[0] == "L" {
+			l, s = s, l
+		}
+		sum := sha256.Sum256(append(l, s...))
+		cur = hex.EncodeToString(sum[:])
+	}
+	if cur != p.Block.MerkleRoot || p.Sig == "" {
+		return fmt.Errorf("%s anchor proof for block #%d does not reach upper block #%d root", ch.ID, h, p.UpperBlockIndex)
+	}
+
+	// 레코드 => Hos 블록 => 상위 블록 단일 증명 묶음 (/proof/full)
+	var full struct {
+		Checks map[string]bool `json:"checks"`
+	}
+	q = url.Values{"hos_id": {ch.ID}, "clinic_id": {ch.ID + "-" + tag + "-0"}}
+	if err := getJSON(nw.Gov.Nodes[0].Direct, "/proof/full?"+q.Encode(), &full); err != nil {
+		return fmt.Errorf("%s full proof: %w", ch.ID, err)
+	}
+	if len(full.Checks) == 0 {
+		return fmt.Errorf("%s full proof has no checks", ch.ID)
+	}
+	for name, ok := range full.Checks {
+		if !ok {
+			return fmt.Errorf("%s full proof check %s failed", ch.ID, name)
+		}
+	}
+	return nil
+}
+
+func scenarioKill(nw *Network, cfg Config) error {
+	ch := nw.Hos[0]
+	boot := bootOf(ch)
+	var victim *Node
+	for _, n := range ch.Live() {
+		if n != boot {
+			victim = n
+		}
+	}
+	if victim == nil {
+		return fmt.Errorf("%s: no non-boot node to kill", ch.ID)
+	}
+	victim.Kill()
+
+	start, err := minHeight(ch)
+	if err != nil {
+		return err
+	}
+	if err := seedRecords(boot, ch.ID, "DEVK", cfg.Records); err != nil {
+		return err
+	}
+	if err := waitHeight(ch, start+1, cfg.Timeout); err != nil {
+		return err
+	}
+	log.Printf("[SCENARIO][kill] %s finalized block #%d without %s", ch.ID, start+1, victim.Name)
+	return nil
+}
+
+func scenarioFailover(nw *Network, cfg Config) error {
+	ch := nw.Hos[0]
+	old := bootOf(ch)
+	if old == nil {
+		return fmt.Errorf("%s: no boot node", ch.ID)
+	}
+	if len(ch.Live()) < 2 {
+		return fmt.Errorf("%s: need at least 2 live nodes for failover", ch.ID)
+	}
+	old.Kill()
+
+	// 남은 노드가 모두 같은 새 부트노드를 인식할 때까지 대기
+	var newBoot *Node
+	err := waitUntil(cfg.Timeout+90*time.Second, ch.ID+" boot re-election", func() (bool, string) {
+		agreed := ""
+		for _, n := range ch.Live() {
+			st, err := n.Status()
+			if err != nil {
+				return false, err.Error()
+			}
+			if st.BootAddr == old.Addr || (agreed != "" && st.BootAddr != agreed) {
+				return false, fmt.Sprintf("%s sees boot=%s", n.Name, st.BootAddr)
+			}
+			agreed = st.BootAddr
+		}
+		for _, n := range ch.Live() {
+			if n.Addr == agreed {
+				newBoot = n
+			}
+		}
+		return newBoot != nil, "boot " + agreed + " is not a live node"
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[SCENARIO][failover] %s re-elected boot %s", ch.ID, newBoot.Name)
+
+	start, err := minHeight(ch)
+	if err != nil {
+		return err
+	}
+	if err := seedRecords(newBoot, ch.ID, "DEVF", cfg.Records); err != nil {
+		return err
+	}
+	if err := waitHeight(ch, start+1, cfg.Timeout); err != nil {
+		return err
+	}
+	log.Printf("[SCENARIO][failover] %s finalized block #%d under new boot", ch.ID, start+1)
+	return nil
+}
+
+func scenarioViewStall(nw *Network, cfg Config) error {
+	ch := nw.Hos[len(nw.Hos)-1]
+	leader := bootOf(ch)
+	if leader == nil {
+		return fmt.Errorf("%s: no boot node", ch.ID)
+	}
+	live := ch.Live()
+	if len(live) < 2 {
+		return fmt.Errorf("%s: need at least 2 live nodes for a view stall", ch.ID)
+	}
+	start, err := minHeight(ch)
+	if err != nil {
+		return err
+	}
+	// 노드마다 다른 노드가 보낸 첫 라운드 Commit 을 모두 유실 => 어떤 노드도 정족수에 도달하지 못함
+	injectFaults(live, Fault{Path: "/bft/commit", Drop: 1, Limit: len(live) - 1})
+	defer nw.ClearFaults()
+
+	if err := seedRecords(leader, ch.ID, "DEVV", cfg.Records); err != nil {
+		return err
+	}
+	if err := waitHeight(ch, start+1, cfg.Timeout+viewChangeWait); err != nil {
+		return err
+	}
+	log.Printf("[SCENARIO][viewstall] %s finalized block #%d after losing the first round's commits", ch.ID, start+1)
+	return nil
+}
+
+func scenarioLossy(nw *Network, cfg Config) error {
+	ch := nw.Hos[len(nw.Hos)-1]
+	boot := bootOf(ch)
+	if boot == nil {
+		return fmt.Errorf("%s: no boot node", ch.ID)
+	}
+	start, err := minHeight(ch)
+	if err != nil {
+		return err
+	}
+	injectFaults(ch.Live(), Fault{Path: "/bft/", Delay: 150 * time.Millisecond, Dup: 0.3, Drop: 0.05})
+	defer nw.ClearFaults()
+
+	if err := seedRecords(boot, ch.ID, "DEVL", cfg.Records); err != nil {
+		return err
+	}
+	if err := waitHeight(ch, start+1, cfg.Timeout+viewChangeWait); err != nil {
+		return err
+	}
+	log.Printf("[SCENARIO][lossy] %s finalized block #%d over a delayed/duplicating/lossy transport", ch.ID, start+1)
+	return nil
+}
+
+func scenarioPowRace(nw *Network, cfg Config) error {
+	gov := nw.Gov
+	if len(gov.Live()) < 2 {
+		return fmt.Errorf("%s: need at least 2 live nodes for a mining race", gov.ID)
+	}
+	ch := nw.Hos[0]
+	boot := bootOf(ch)
+	if boot == nil {
+		return fmt.Errorf("%s: no boot node", ch.ID)
+	}
+	start, err := minHeight(gov)
+	if err != nil {
+		return err
+	}
+	injectFaults(gov.Live(), Fault{Path: "/receiveBlock", Delay: 2 * time.Second})
+	defer nw.ClearFaults()
+
+	// Hos 블록 확정 => 앵커 => Gov 전 노드 채굴 시작
+	if err := seedRecords(boot, ch.ID, "DEVP", cfg.Records); err != nil {
+		return err
+	}
+	if err := waitHeight(gov, start+1, cfg.Timeout); err != nil {
+		return err
+	}
+	hashes := map[string]bool{}
+	for _, n := range gov.Live() {
+		if h, err := n.BlockHash(start + 1); err == nil {
+			hashes[h] = true
+		}
+	}
+	if len(hashes) > 1 {
+		log.Printf("[SCENARIO][powrace] %s forked at #%d (%d competing blocks), checking convergence", gov.ID, start+1, len(hashes))
+	} else {
+		log.Printf("[SCENARIO][powrace] %s agreed on #%d without a visible fork", gov.ID, start+1)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 외부 접속 주소 탐지 / 다중 주소 광고 (NAT Traversal / Advertised Address)
+// ------------------------------------------------------------
+// - NODE_ADDR=auto 이면 시작 시 외부에서 보이는 IP 로 self 결정 (포트는 PORT)
+//   1) STUN_SERVER 가 있으면 STUN Binding 요청의 XOR-MAPPED-ADDRESS
+//   2) 부트노드 GET /whoami 의 observed_ip (부트노드가 본 요청 출발 주소)
+//   3) 둘 다 실패하면 첫 번째 비루프백 인터페이스 주소
+//   · 부트노드 자신은 NODE_ADDR 를 직접 지정 (다른 노드가 BOOTSTRAP_ADDR 로 찾아오므로)
+// - NODE_ADVERTISE_ADDRS (쉼표 구분) : self 외에 접속 가능한 주소 (사설망/공인 주소 등)
+//   · /status, /whoami 의 addrs 로 광고 => 피어는 상태 점검 시 후보 주소를 기록
+//   · 노드 식별 주소(피어 목록, 서명, 검증자 집합)는 계속 self 하나
+// - 도달성 점수 : 상태 점검 주기마다 후보 주소별 /whoami 확인 (응답 addr 가 피어 식별 주소와 같아야 성공)
+//   · score = 0.7*score + 0.3*(성공 1, 실패 0), 응답 시간(rtt) 기록
+//   · nodeURL 은 AddrMinScore 이상인 후보 중 점수(같으면 rtt)가 가장 좋은 주소로 접속 (없으면 식별 주소)
+//   · mTLS 사용 시 후보 주소에는 식별 주소의 인증서 지문을 고정
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	AddrDiscoveryTimeout = 3 * time.Second
+	AddrProbeTimeout     = 2 * time.Second
+	AddrMinScore         = 0.5 // 이 점수 이상인 후보만 접속 주소로 사용
+	AddrScoreWeight      = 0.3 // 점검 결과 반영 비율 (EWMA)
+	stunMagicCookie      = 0x2112A442
+)
+
+var (
+	stunServer = "" // NODE_ADDR=auto 일 때 사용할 STUN 서버 (STUN_SERVER)
+	extraAddrs []string
+
+	peerAddrBook = make(map[string][]*addrScore) // 피어 식별 주소 => 후보 주소 (첫 번째는 식별 주소)
+	addrBookMu   sync.RWMutex
+)
+
+// 피어 후보 주소별 도달성
+type addrScore struct {
+	Addr  string  `json:"addr"`
+	Score float64 `json:"score"`  // 도달 성공률 (0~1)
+	RTTMs int64   `json:"rtt_ms"` // 마지막 성공 응답 시간
+}
+
+// GET /whoami 응답
+type whoamiResp struct {
+	ObservedIP   string   `json:"observed_ip"`   // 요청 출발 IP (NAT 외부 주소)
+	ObservedAddr string   `json:"observed_addr"` // 요청 출발 IP:포트
+	Addr         string   `json:"addr"`          // 이 노드의 식별 주소
+	Addrs        []string `json:"addrs"`         // 이 노드가 광고하는 접속 주소
+}
+
+// GET /whoami
+func handleWhoami(w http.ResponseWriter, r *http.Request) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	writeJSON(w, http.StatusOK, whoamiResp{ObservedIP: host, ObservedAddr: r.RemoteAddr, Addr: self, Addrs: advertisedAddrs()})
+}
+
+// self + NODE_ADVERTISE_ADDRS
+func advertisedAddrs() []string {
+	out := []string{self}
+	for _, a := range extraAddrs {
+		if a != self && !slices.Contains(out, a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// 시작 시 광고 주소 설정 (port : 수신 포트)
+func initAdvertisedAddrs(port, extra string) {
+	for _, a := range strings.Split(extra, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			extraAddrs = append(extraAddrs, a)
+		}
+	}
+	if self != "auto" {
+		return
+	}
+	ip, via := discoverExternalIP()
+	if ip == "" {
+		log.Fatal("[START] NODE_ADDR=auto but no external address could be discovered")
+	}
+	self = net.JoinHostPort(ip, port)
+	pinPeerCert(self, selfCertPin) // mTLS 미사용이면 지문이 비어 무시됨
+	log.Printf("[START] Advertised address discovered via %s: %s", via, self)
+}
+
+// 외부 IP 탐지 (STUN => 부트노드 에코 => 로컬 인터페이스)
+func discoverExternalIP() (ip, via string) {
+	if stunServer != "" {
+		got, err := stunMappedIP(stunServer)
+		if err == nil {
+			return got.String(), "stun"
+		}
+		log.Printf("[START] STUN %s failed: %v", stunServer, err)
+	}
+	if boot != "" {
+		got, err := bootEchoIP(boot)
+		if err == nil {
+			return got, "boot-echo"
+		}
+		log.Printf("[START] boot echo from %s failed: %v", boot, err)
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, a := range addrs {
+			if n, ok := a.(*net.IPNet); ok && !n.IP.IsLoopback() && n.IP.To4() != nil {
+				return n.IP.String(), "interface"
+			}
+		}
+	}
+	return "", ""
+}
+
+// 부트노드가 관측한 요청 출발 IP
+func bootEchoIP(addr string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), AddrDiscoveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nodeURL(addr, "/whoami"), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := nodeClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("/whoami status=%d", resp.StatusCode)
+	}
+	var out whoamiResp
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	if net.ParseIP(out.ObservedIP) == nil {
+		return "", fmt.Errorf("invalid observed ip %q", out.ObservedIP)
+	}
+	return out.ObservedIP, nil
+}
+
+// STUN Binding 요청으로 NAT 외부 IP 확인 (RFC 5389)
+func stunMappedIP(server string) (net.IP, error) {
+	conn, err := net.DialTimeout("udp", server, AddrDiscoveryTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(AddrDiscoveryTimeout))
+
+	req := make([]byte, 20)
+	binary.BigEndian.PutUint16(req[0:], 0x0001) // Binding Request
+	binary.BigEndian.PutUint32(req[4:], stunMagicCookie)
+	if _, err := rand.Read(req[8:20]); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(req); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 1500)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	if n < 20 || binary.BigEndian.Uint16(buf[0:]) != 0x0101 || !bytes.Equal(buf[8:20], req[8:20]) {
+		return nil, fmt.Errorf("unexpected STUN response")
+	}
+	attrs := buf[20:min(n, 20+int(binary.BigEndian.Uint16(buf[2:])))]
+	var mapped net.IP
+	for len(attrs) >= 4 {
+		typ, size := binary.BigEndian.Uint16(attrs[0:]), int(binary.BigEndian.Uint16(attrs[2:]))
+		if 4+size > len(attrs) {
+			break
+		}
+		v := attrs[4 : 4+size]
+		switch {
+		case typ == 0x0020 && len(v) >= 8: // XOR-MAPPED-ADDRESS
+			key := slices.Concat(req[4:8], req[8:20])
+			ip := slices.Clone(v[4:])
+			for i := range ip {
+				ip[i] ^= key[i%len(key)]
+			}
+			return net.IP(ip), nil
+		case typ == 0x0001 && len(v) >= 8: // MAPPED-ADDRESS (구형 서버)
+			mapped = net.IP(slices.Clone(v[4:]))
+		}
+		attrs = attrs[min(len(attrs), 4+(size+3)&^3):]
+	}
+	if mapped != nil {
+		return mapped, nil
+	}
+	return nil, fmt.Errorf("no mapped address in STUN response")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 피어 후보 주소 / 도달성 점수
+////////////////////////////////////////////////////////////////////////////////
+
+// 피어가 광고한 후보 주소 기록 (기존 점수 유지)
+func notePeerAddrs(peer string, addrs []string) {
+	addrBookMu.Lock()
+	defer addrBookMu.Unlock()
+	book := peerAddrBook[peer]
+	if len(book) == 0 {
+		book = []*addrScore{{Addr: peer, Score: 1}}
+	}
+	for _, a := range addrs {
+		if a == "" || slices.ContainsFunc(book, func(s *addrScore) bool { return s.Addr == a }) {
+			continue
+		}
+		book = append(book, &addrScore{Addr: a})
+		if pin := peerCertPin(peer); pin != "" && peerCertPin(a) == "" {
+			pinPeerCert(a, pin)
+		}
+	}
+	if len(book) > 1 {
+		peerAddrBook[peer] = book
+	}
+}
+
+// 후보 주소별 /whoami 확인 후 점수 갱신
+func probePeerAddrs(peer string) {
+	addrBookMu.RLock()
+	book := slices.Clone(peerAddrBook[peer])
+	addrBookMu.RUnlock()
+	for _, c := range book {
+		ok, rtt := probeAddr(peer, c.Addr)
+		hit := 0.0
+		if ok {
+			hit = 1
+		}
+		addrBookMu.Lock()
+		c.Score = (1-AddrScoreWeight)*c.Score + AddrScoreWeight*hit
+		if ok {
+			c.RTTMs = rtt.Milliseconds()
+		}
+		addrBookMu.Unlock()
+	}
+}
+
+// 후보 주소가 해당 피어로 연결되는지 (응답 식별 주소 일치)
+func probeAddr(peer, addr string) (bool, time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), AddrProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nodeScheme+"://"+addr+"/whoami", nil)
+	if err != nil {
+		return false, 0
+	}
+	start := time.Now()
+	resp, err := nodeClient.Do(req)
+	if err != nil {
+		return false, 0
+	}
+	defer resp.Body.Close()
+	var out whoamiResp
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&out) != nil || out.Addr != peer {
+		return false, 0
+	}
+	return true, time.Since(start)
+}
+
+// 피어 접속에 사용할 주소 (점수가 가장 좋은 후보, 없으면 식별 주소)
+func dialAddr(peer string) string {
+	addrBookMu.RLock()
+	defer addrBookMu.RUnlock()
+	var best *addrScore
+	for _, c := range peerAddrBook[peer] {
+		if c.Score < AddrMinScore {
+			continue
+		}
+		if best == nil || c.Score > best.Score || (c.Score == best.Score && c.RTTMs < best.RTTMs) {
+			best = c
+		}
+	}
+	if best == nil {
+		return peer
+	}
+	return best.Addr
+}
+
+// /peers?detail=true 용 후보 주소 점수
+func peerAddrScores(peer string) []addrScore {
+	addrBookMu.RLock()
+	defer addrBookMu.RUnlock()
+	var out []addrScore
+	for _, c := range peerAddrBook[peer] {
+		out = append(out, *c)
+	}
+	return out
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"encoding/asn1"
 	"encoding/hex"
@@ -13,55 +15,120 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
+
+	"merkle"
 )
 
+// 개인키, 공개키 자동 생성 (최초 실행 시)
+// - 체인 상태 커밋먼트(/commitment) 서명에 사용
+func ensureKeyPair() {
+	if _, ok := getMeta("meta_gov_privkey"); ok {
+		return
+	}
+
+	priv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privBytes, _ := x509.MarshalECPrivateKey(priv)
+	pubBytes, _ := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+
+	privPem := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privBytes}))
+	pubPem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}))
+
+	putMeta("meta_gov_privkey", privPem)
+	putMeta("meta_gov_pubkey", pubPem)
+	log.Println("[ANCHOR][INIT] Generated ECDSA key pair for Gov node")
+}
+
+// 공개키 조회 API (커밋먼트 서명 검증용)
+// GET /getPublicKey
+func getPublicKey(w http.ResponseWriter, r *http.Request) {
+	pubPem, ok := getMeta("meta_gov_pubkey")
+	if !ok {
+		writeError(w, http.StatusNotFound, "public key not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Write([]byte(pubPem))
+}
+
 // Gov에서 Hos가 제출한 앵커를 수신하고 검증한 후 pending 추가함수 호출(부트노드만 수행)
 // Gov에서 Hos가 제출한 앵커를 수신하고 검증한 후 pending 추가 (상위 체인용 최종 수정본)
 func addAnchor(w http.ResponseWriter, r *http.Request) {
+	if isObserver() {
+		writeError(w, http.StatusForbidden, errObserverReadOnly.Error())
+		return
+	}
 	var req struct {
 		HosID   string `json:"hos_id"`
 		HosBoot string `json:"hos_boot"`
 		Root    string `json:"root"`
 		Ts      string `json:"ts"`
 		Sig     string `json:"sig"`
+
+		ClinicIDs    []string `json:"clinic_ids,omitempty"`    // 앵커 블록에 포함된 clinic_id 목록 (계약 정책 검사용)
+		ProofVersion int      `json:"proof_version,omitempty"` // 루트 아래 레코드 증명 형식 (없으면 0 : 이전 Hos)
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON", 400)
+		writeError(w, 400, "invalid JSON")
 		return
 	}
 	defer r.Body.Close()
 
+	// 거부 응답 + anchor_rejected 이벤트 (웹훅 통지)
+	reject := func(status int, msg string) {
+		publishAnchorRejected(req.HosID, req.Root, status, msg)
+		writeError(w, status, msg)
+	}
+
+	// 이 노드가 검증할 수 없는 증명 형식의 루트는 앵커하지 않음
+	if !merkle.ProofVersionSupported(req.ProofVersion) {
+		log.Printf("[ANCHOR][DENY] unsupported proof_version %d from %s", req.ProofVersion, req.HosID)
+		publishAnchorRejected(req.HosID, req.Root, http.StatusUnprocessableEntity, "unsupported proof_version")
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "unsupported_proof_version", "unsupported proof_version", map[string]any{"supported": merkle.SupportedProofVersions()})
+		return
+	}
+
 	// 1. Hos의 공개키 가져오기
-	resp, err := http.Get("http://" + req.HosBoot + "/getPublicKey")
+	resp, err := nodeClient.Get(nodeURL(req.HosBoot, "/getPublicKey"))
 	if err != nil {
 		log.Printf("[ANCHOR][ERROR] failed to fetch public key from %s: %v", req.HosBoot, err)
-		http.Error(w, "failed to fetch public key", 500)
+		writeError(w, 500, "failed to fetch public key")
 		return
 	}
 	defer resp.Body.Close()
 
 	pubPem, err := io.ReadAll(resp.Body)
 	if err != nil {
-		http.Error(w, "failed to read public key", 500)
+		writeError(w, 500, "failed to read public key")
 		return
 	}
 
+	// mTLS 활성 시 : 요청자의 인증서가 Hos 부트노드 주소에 고정된 지문과 같아야 함
+	// (위 공개키 조회 시 해당 주소의 서버 인증서가 고정됨)
+	if tlsEnabled {
+		if pin := clientCertPin(r); pin == "" || pin != peerCertPin(req.HosBoot) {
+			log.Printf("[ANCHOR][DENY] client certificate does not match %s", req.HosBoot)
+			reject(http.StatusForbidden, "client certificate does not match hos_boot")
+			return
+		}
+	}
+
 	// 2. ECDSA 공개키 파싱 (하위 체인과 규격 일치)
 	block, _ := pem.Decode(pubPem)
 	if block == nil {
 		log.Printf("[ANCHOR][ERROR] failed to decode PEM for %s", req.HosID)
-		http.Error(w, "invalid public key pem", 400)
+		reject(400, "invalid public key pem")
 		return
 	}
 	pubIfc, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
 		log.Printf("[ANCHOR][ERROR] failed to parse PKIX for %s: %v", req.HosID, err)
-		http.Error(w, "invalid public key format", 400)
+		reject(400, "invalid public key format")
 		return
 	}
 	pubKey, ok := pubIfc.(*ecdsa.PublicKey)
 	if !ok {
-		http.Error(w, "not an ecdsa public key", 400)
+		reject(400, "not an ecdsa public key")
 		return
 	}
 
@@ -70,14 +137,14 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 	hash, err := hex.DecodeString(req.Root)
 	if err != nil {
 		log.Printf("[ANCHOR][ERROR] Invalid Root hex from %s: %v", req.HosID, err)
-		http.Error(w, "invalid root format", 400)
+		reject(400, "invalid root format")
 		return
 	}
 
 	// 4. DER 디코딩 및 검증
 	sigBytes, err := hex.DecodeString(req.Sig)
 	if err != nil {
-		http.Error(w, "invalid hex signature", 400)
+		reject(400, "invalid hex signature")
 		return
 	}
 
@@ -86,7 +153,7 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 	}
 	if _, err := asn1.Unmarshal(sigBytes, &sigStruct); err != nil {
 		log.Printf("[ANCHOR][ERROR] ASN1 Unmarshal fail for %s: %v", req.HosID, err)
-		http.Error(w, "invalid signature format", 403)
+		reject(403, "invalid signature format")
 		return
 	}
 
@@ -94,20 +161,47 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 	if !ecdsa.Verify(pubKey, hash, sigStruct.R, sigStruct.S) {
 		log.Printf("[ANCHOR][INVALID] Signature verification failed from %s", req.HosID)
 		log.Printf("[DEBUG] Verify Fail - Root: %s, Sig: %s...", req.Root, req.Sig[:10])
-		http.Error(w, "invalid signature", 403)
+		reject(403, "invalid signature")
+		return
+	}
+
+	// 이미 기록/대기 중인 루트 재제출 (누락 앵커 재전송과 Hos 재시도가 겹친 경우) : 중복 기록 없이 성공 처리
+	if anchorRecorded(req.HosID, req.Root) || isPendingAnchor(req.HosID, req.Root) {
+		log.Printf("[ANCHOR] duplicate anchor ignored (hos=%s root=%s)", req.HosID, req.Root)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// 5. 가입 승인된 기관의 앵커만 수락
+	if onboardingRequired && !isOnboarded(orgOf(req.HosID)) {
+		log.Printf("[ANCHOR][DENY] %s is not an approved organization", req.HosID)
+		reject(http.StatusForbidden, "organization not approved (see /onboarding/status)")
 		return
 	}
 
-	// 5. AnchorRecord 구성 및 저장
+	// 6. 계약 정책 검사 (계약 없음/만료/허용 리전/허용 진료 정보)
+	if contractPolicy {
+		if err := checkContractPolicy(req.HosID, req.ClinicIDs); err != nil {
+			log.Printf("[ANCHOR][DENY] %s: %v", req.HosID, err)
+			reject(http.StatusForbidden, "contract policy: "+err.Error())
+			return
+		}
+	}
+
+	// 7. AnchorRecord 구성 및 저장
 	ar := AnchorRecord{
 		HosID:            req.HosID,
-		ContractSnapshot: ContractData{},
+		ContractSnapshot: getRegisteredContract(orgOf(req.HosID)),
 		LowerRoot:        req.Root,
 		AccessCatalog:    []string{},
 		AnchorTimestamp:  req.Ts,
 	}
 
-	appendPending([]AnchorRecord{ar})
+	if err := admitPending([]AnchorRecord{ar}); err != nil {
+		log.Printf("[ANCHOR][DENY] %s: %v", req.HosID, err)
+		writePendingFull(w, err.(*PendingFullError))
+		return
+	}
 	log.Printf("[ANCHOR] Verified & Pending anchor added")
 
 	if err := saveAnchorToDB(req.HosID, req.Root, req.Ts); err != nil {
@@ -117,6 +211,7 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 	anchorMu.Lock()
 	anchorMap[req.HosID] = AnchorInfo{Root: req.Root, Ts: req.Ts}
 	anchorMu.Unlock()
+	publishEvent(EventAnchorAccepted, map[string]any{"hos_id": req.HosID, "hos_boot": req.HosBoot, "root": req.Root, "ts": req.Ts})
 
 	// 부트노드 정보 업데이트 체크
 	if req.HosBoot != getHosBootAddr(req.HosID) {
@@ -128,63 +223,75 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 
 // Hos가 반환하는 검색 응답 구조체
 type SearchResponse struct {
-	Record     ClinicRecord `json:"record"`
-	BlockRoot  string       `json:"block_root"`
-	LatestRoot string       `json:"latest_root"`
-	Leaf       string       `json:"leaf"`
-	Proof      [][2]string  `json:"proof"`
+	Record       ClinicRecord    `json:"record"`
+	BlockRoot    string          `json:"block_root"`
+	LatestRoot   string          `json:"latest_root"`
+	Leaf         string          `json:"leaf"`
+	Proof        [][2]string     `json:"proof"`
+	ProofVersion int             `json:"proof_version"` // 증명 형식 버전 (없으면 0 : 이전 Hos)
+	Inclusion    Inclusion       `json:"inclusion"`
+	Revoked      json.RawMessage `json:"revoked,omitempty"`   // Hos 가 철회 표시한 레코드 (툼스톤 정보 그대로 전달)
+	Decrypted    json.RawMessage `json:"decrypted,omitempty"` // 복호화 조회 시 Hos 가 제공한 평문 필드 (/patient/records)
 }
 
 // Hos 검색 프로세스 (핸들러에서 호출)
-func handleHosSearch(hosID, keyword string) ([]byte, int, error) {
+//   - page : Hos /search 에 그대로 넘길 offset/limit 파라미터
+//   - total : Hos 가 알려준 전체 매칭 수 (X-Total-Count, 없으면 "")
+func handleHosSearch(hosID, keyword string, page url.Values) ([]byte, string, int, error) {
 
 	// 1) Hos 부트 주소 조회
 	hosAddr := getHosBootAddr(hosID)
 	if hosAddr == "" {
 		fmt.Println("[Search] Invalid Hos Boot Address")
-		return nil, http.StatusBadGateway, nil
+		return nil, "", http.StatusBadGateway, nil
 	}
 
 	// 2) CP 체인에 검색 요청 (/search)
-	items, err := requestHosSearch(hosAddr, keyword)
+	items, total, err := requestHosSearch(hosAddr, keyword, page)
 	if err != nil {
-		return nil, http.StatusBadGateway, err
+		return nil, "", http.StatusBadGateway, err
 	}
 
 	// 3) Gov AnchorRoot + MerkleProof 검증
 	verified, err := verifyHosResults(hosID, items)
 	if err != nil {
-		return nil, http.StatusInternalServerError, err
+		return nil, "", http.StatusInternalServerError, err
 	}
 
 	// 4) JSON 반환
 	out, _ := json.Marshal(verified)
-	return out, http.StatusOK, nil
+	return out, total, http.StatusOK, nil
 }
 
-// CP /search 호출 (CP가 주는 JSON = []SearchResponse)
-func requestHosSearch(hosAddr, keyword string) ([]SearchResponse, error) {
+// CP /search 호출 (CP가 주는 JSON = []SearchResponse, 전체 매칭 수는 X-Total-Count)
+func requestHosSearch(hosAddr, keyword string, page url.Values) ([]SearchResponse, string, error) {
 
-	url := fmt.Sprintf("http://%s/search?value=%s", hosAddr, url.QueryEscape(keyword))
+	q := url.Values{"value": {keyword}, "proof_version": {strconv.Itoa(merkle.ProofVersion)}}
+	for _, k := range []string{"offset", "limit"} {
+		if v := page.Get(k); v != "" {
+			q.Set(k, v)
+		}
+	}
+	url := nodeURL(hosAddr, "/search?"+q.Encode())
 
-	resp, err := http.Get(url)
+	resp, err := nodeClient.Get(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reach CP node: %v", err)
+		return nil, "", fmt.Errorf("failed to reach CP node: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("hos error: %s", string(b))
+		return nil, "", fmt.Errorf("hos error: %s", string(b))
 	}
 
 	// SearchResponse 배열로 받는다
 	var items []SearchResponse
 	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
-		return nil, fmt.Errorf("invalid JSON from CP")
+		return nil, "", fmt.Errorf("invalid JSON from CP")
 	}
 	logInfo("[QUERY] Response From CP Chain : %d", len(items))
-	return items, nil
+	return items, resp.Header.Get("X-Total-Count"), nil
 }
 
 // Gov -> CP 검색 결과 검증
@@ -213,10 +320,17 @@ func verifyHosResults(hosID string, items []SearchResponse) ([]SearchResponse, e
 		}
 
 		// 키워드가 포함된 블록의 Merkle 증명을 통한 유효성 검증
-		if verifyMerkleProof(it.Leaf, it.Proof, it.BlockRoot) {
+		if proofValid(it.ProofVersion, it.Leaf, it.Proof, it.BlockRoot) {
+			// 앵커 상태는 Hos 응답 대신 Gov 장부 기준으로 판정
+			it.Inclusion.AnchorStatus, it.Inclusion.UpperBlockIndex = anchorStatusOf(hosID, it.BlockRoot)
 			verified = append(verified, it)
 			logInfo("[QUERY][SUCCESS] Verified Record Appended")
 		}
 	}
 	return verified, nil
 }
+
+// 앵커 거부 이벤트 (Hos 가 재전송하지 않는 4xx 거부만, 메모리풀 한도 429 는 재시도되므로 제외)
+func publishAnchorRejected(hosID, root string, status int, reason string) {
+	publishEvent(EventAnchorRejected, map[string]any{"hos_id": hosID, "root": root, "status": status, "reason": reason})
+}
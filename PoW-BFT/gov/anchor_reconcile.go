@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Anchor Reconcile (Hos 블록 루트 누락 앵커 회수)
+// ------------------------------------------------------------
+// - Hos 부트노드가 블록 확정 직후 종료되면 그 블록의 앵커가 제출되지 않아 Gov 가 루트를 모름
+// - Gov 부트노드가 AnchorReconcileInterval 마다 등록된 Hos 부트노드(hosBootMap)를 순회
+//   1) Hos /headers 로 커서 이후 블록의 merkle_root 조회 (제네시스/빈 루트 제외)
+//   2) 앵커 루트 색인(aroot_) 또는 대기 중 앵커에 없는 루트를 누락으로 판단
+//   3) 누락 블록 번호를 Hos POST /anchor/resend 로 요청 => Hos 가 앵커 큐로 다시 제출
+// - 커서(meta_anchor_reconcile_<hos_id>)는 처음부터 연속으로 장부에 기록된 블록까지만 전진
+//   (대기 중/누락 블록이 있으면 다음 주기에 그 블록부터 다시 확인)
+// - 이미 기록된 루트가 다시 제출되면 addAnchor 가 중복 기록 없이 200 응답 (재전송과 재시도가 겹쳐도 안전)
+////////////////////////////////////////////////////////////////////////////////
+
+const AnchorReconcilePage = 100 // 주기당 Hos 별 최대 확인 블록 수
+
+var AnchorReconcileInterval = 300 // 초, 0 이면 비활성 (ANCHOR_RECONCILE_INTERVAL)
+
+// Hos /headers 응답 중 필요한 부분
+type hosHeadersPage struct {
+	Total int `json:"total"`
+	Items []struct {
+		Index      int    `json:"index"`
+		MerkleRoot string `json:"merkle_root"`
+	} `json:"items"`
+}
+
+// 1회 점검 결과 (Hos 별)
+type anchorReconcileResult struct {
+	HosID     string `json:"hos_id"`
+	Checked   int    `json:"checked"`
+	Cursor    int    `json:"cursor"`
+	Missing   []int  `json:"missing"`
+	Requested bool   `json:"requested"`
+}
+
+func anchorReconcileKey(hosID string) string {
+	return "meta_anchor_reconcile_" + hosID
+}
+
+// hosID 의 root 가 장부에 기록되었는지 (앵커 루트 색인만 확인, 전체 스캔 없음)
+func anchorRecorded(hosID, root string) bool {
+	v, err := db.Get(anchorRootKey(root), nil)
+	if err != nil {
+		return false
+	}
+	bi, ei, ok := parsePtr(string(v))
+	if !ok {
+		return false
+	}
+	b, err := getBlockByIndex(bi)
+	return err == nil && ei < len(b.Records) && b.Records[ei].HosID == hosID && b.Records[ei].LowerRoot == root
+}
+
+// 누락 앵커 점검 루틴 (main 에서 실행, 부트노드일 때만 수행)
+func startAnchorReconciler() {
+	if AnchorReconcileInterval <= 0 {
+		log.Printf("[ANCHOR][RECONCILE] disabled")
+		return
+	}
+	t := time.NewTicker(time.Duration(AnchorReconcileInterval) * time.Second)
+	defer t.Stop()
+	for range t.C {
+		if !isBoot.Load() {
+			continue
+		}
+		reconcileAnchors()
+	}
+}
+
+// 등록된 모든 Hos 체인 점검
+func reconcileAnchors() []anchorReconcileResult {
+	hosBootMapMu.RLock()
+	targets := maps.Clone(hosBootMap)
+	hosBootMapMu.RUnlock()
+
+	out := []anchorReconcileResult{}
+	for hosID, addr := range targets {
+		res, err := reconcileHosAnchors(hosID, addr)
+		if err != nil {
+			log.Printf("[ANCHOR][RECONCILE][WARN] %s (%s): %v", hosID, addr, err)
+			continue
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+// Hos 체인 하나의 커서 이후 블록 루트를 앵커 기록과 대조, 누락분 재전송 요청
+func reconcileHosAnchors(hosID, addr string) (anchorReconcileResult, error) {
+	res := anchorReconcileResult{HosID: hosID, Missing: []int{}}
+	if s, ok := getMeta(anchorReconcileKey(hosID)); ok {
+		res.Cursor, _ = strconv.Atoi(s)
+	}
+
+	resp, err := nodeClient.Get(nodeURL(addr, fmt.Sprintf("/headers?offset=%d&limit=%d", res.Cursor+1, AnchorReconcilePage)))
+	if err != nil {
+		return res, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return res, fmt.Errorf("headers status %d", resp.StatusCode)
+	}
+	var page hosHeadersPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return res, fmt.Errorf("decode headers: %w", err)
+	}
+
+	contiguous := true
+	for _, h := range page.Items {
+		res.Checked++
+		switch {
+		case h.Index == 0 || h.MerkleRoot == "" || anchorRecorded(hosID, h.MerkleRoot):
+			if contiguous {
+				res.Cursor = h.Index
+			}
+		case isPendingAnchor(hosID, h.MerkleRoot):
+			contiguous = false
+		default:
+			contiguous = false
+			res.Missing = append(res.Missing, h.Index)
+		}
+	}
+	if err := putMeta(anchorReconcileKey(hosID), strconv.Itoa(res.Cursor)); err != nil {
+		return res, err
+	}
+	if len(res.Missing) == 0 {
+		return res, nil
+	}
+
+	body, _ := json.Marshal(map[string]any{"block_indices": res.Missing})
+	rresp, err := nodeClient.Post(nodeURL(addr, "/anchor/resend"), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return res, fmt.Errorf("request resend: %w", err)
+	}
+	defer rresp.Body.Close()
+	if rresp.StatusCode != http.StatusOK {
+		return res, fmt.Errorf("resend status %d", rresp.StatusCode)
+	}
+	res.Requested = true
+	log.Printf("[ANCHOR][RECONCILE] %s: requested %d missing anchors %v", hosID, len(res.Missing), res.Missing)
+	return res, nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Anchor History (Hos 별 앵커 이력)
+// ------------------------------------------------------------
+// - anchorMap / anchor_<hos_id> 는 Hos 별 최신 루트만 유지 => 감사용으로 모든 앵커를 별도 보관
+// - 앵커가 블록에 포함될 때 색인과 함께 기록 (indexRecord)
+//   · 키 : ahist_<hos_id>|<상위 블록 번호(10자리)>:<레코드 위치(4자리)>
+//   · 블록 되돌리기 시 되돌리기 기록(undo_)으로 함께 복원, 되돌리기 기록이 없는 블록은 rebuildTo 에서 삭제 후 재반영
+//   · 기능 도입 이전 장부는 /admin/reindex 로 채움
+// - GET /anchors?hos_id=<id>[&from=<int>&to=<int>][&offset=<int>&limit=<int>]
+//   · from/to : 상위 블록 번호 범위 (포함), 응답은 블록 순서
+//   · 항목마다 포함 블록의 해시/시각을 채워 반환, 전체 건수는 X-Total-Count
+// - GET /anchors/latest : Hos 별 최신 앵커 (anchorMap) + 등록된 Hos 부트노드 주소 (대시보드용)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	anchorHistoryPrefix       = "ahist_"
+	AnchorHistoryDefaultLimit = 50
+)
+
+type AnchorHistoryEntry struct {
+	HosID           string `json:"hos_id"`
+	Root            string `json:"root"`
+	AnchorTimestamp string `json:"anchor_ts"`         // Hos 가 앵커를 제출한 시각
+	UpperBlockIndex int    `json:"upper_block_index"` // 앵커가 포함된 상위 블록
+	RecordIndex     int    `json:"record_index"`      // 블록 내 레코드 위치
+	BlockHash       string `json:"block_hash,omitempty"`
+	BlockTimestamp  string `json:"block_timestamp,omitempty"`
+}
+
+func anchorHistoryKey(hosID string, bi, ei int) []byte {
+	return []byte(fmt.Sprintf("%s%s|%010d:%04d", anchorHistoryPrefix, hosID, bi, ei))
+}
+
+// 블록 bi 의 ei 번째 앵커를 이력에 기록 (indexRecord 에서 호출)
+func putAnchorHistory(tx *ledgerTxn, bi, ei int, rec AnchorRecord) error {
+	data, err := json.Marshal(AnchorHistoryEntry{
+		HosID:           rec.HosID,
+		Root:            rec.LowerRoot,
+		AnchorTimestamp: rec.AnchorTimestamp,
+		UpperBlockIndex: bi,
+		RecordIndex:     ei,
+	})
+	if err != nil {
+		return err
+	}
+	tx.Put(anchorHistoryKey(rec.HosID, bi, ei), data)
+	return nil
+}
+
+// hosID 의 상위 블록 from..to (포함) 범위 앵커 이력
+func listAnchorHistory(hosID string, from, to int) ([]AnchorHistoryEntry, error) {
+	out := []AnchorHistoryEntry{}
+	err := withReadSnapshot(func(rd dbReader) error {
+		iter := rd.NewIterator(&util.Range{
+			Start: anchorHistoryKey(hosID, from, 0),
+			Limit: anchorHistoryKey(hosID, to+1, 0),
+		}, nil)
+		defer iter.Release()
+		blocks := map[int]UpperBlock{}
+		for iter.Next() {
+			var e AnchorHistoryEntry
+			if err := json.Unmarshal(iter.Value(), &e); err != nil {
+				continue
+			}
+			b, cached := blocks[e.UpperBlockIndex]
+			if !cached {
+				if blk, err := getBlockByIndexFrom(rd, e.UpperBlockIndex); err == nil {
+					b, blocks[e.UpperBlockIndex] = blk, blk
+				}
+			}
+			e.BlockHash, e.BlockTimestamp = b.BlockHash, b.Timestamp
+			out = append(out, e)
+		}
+		return iter.Error()
+	})
+	return out, err
+}
+
+// GET /anchors
+func handleAnchorHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	q := r.URL.Query()
+	hosID := q.Get("hos_id")
+	if hosID == "" {
+		writeError(w, http.StatusBadRequest, "hos_id required")
+		return
+	}
+	from, to := 0, 0
+	if h, ok := getLatestHeight(); ok {
+		to = h
+	}
+	var err error
+	if s := q.Get("from"); s != "" {
+		if from, err = strconv.Atoi(s); err != nil || from < 0 {
+			writeError(w, http.StatusBadRequest, "invalid from")
+			return
+		}
+	}
+	if s := q.Get("to"); s != "" {
+		if to, err = strconv.Atoi(s); err != nil || to < from {
+			writeError(w, http.StatusBadRequest, "invalid to")
+			return
+		}
+	}
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if offset < 0 {
+		writeError(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+	if limit <= 0 {
+		limit = AnchorHistoryDefaultLimit
+	}
+
+	all, err := listAnchorHistory(hosID, from, to)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("list anchors error: %v", err))
+		return
+	}
+	out := []AnchorHistoryEntry{}
+	if offset < len(all) {
+		out = all[offset:min(offset+limit, len(all))]
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(all)))
+	writeJSON(w, http.StatusOK, out)
+}
+
+// Hos 별 최신 앵커
+type LatestAnchor struct {
+	HosID   string `json:"hos_id"`
+	Root    string `json:"root"`
+	Ts      string `json:"ts"`
+	HosBoot string `json:"hos_boot,omitempty"`
+}
+
+// GET /anchors/latest
+func handleLatestAnchors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	anchorMu.RLock()
+	out := make([]LatestAnchor, 0, len(anchorMap))
+	for hosID, ai := range anchorMap {
+		out = append(out, LatestAnchor{HosID: hosID, Root: ai.Root, Ts: ai.Ts})
+	}
+	anchorMu.RUnlock()
+	for i := range out {
+		out[i].HosBoot = getHosBootAddr(out[i].HosID)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].HosID < out[j].HosID })
+	writeJSON(w, http.StatusOK, out)
+}
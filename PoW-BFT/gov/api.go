@@ -18,118 +18,178 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 }
 
 // main.go에서 mux와 UpperChain을 넘겨받아 API 핸들 등록
-func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
+func RegisterAPI(mux *routeMux, chain *UpperChain) {
 
 	// 블록 조회: 인덱스
 	// GET /block/index?id=<int>
 	mux.HandleFunc("/block/index", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		q := r.URL.Query().Get("id")
 		if q == "" {
-			http.Error(w, "id parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id parameter required")
 			return
 		}
 		idx, err := strconv.Atoi(q)
 		if err != nil {
-			http.Error(w, "id must be integer", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id must be integer")
 			return
 		}
 		blk, err := getBlockByIndex(idx)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
 		writeJSON(w, http.StatusOK, blk)
 	})
 
+	// 최신 블록 조회
+	// GET /block/latest
+	mux.HandleFunc("/block/latest", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		blocks, err := listRecentBlocks(1)
+		if err != nil || len(blocks) == 0 {
+			writeError(w, http.StatusNotFound, "block not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, blocks[0])
+	})
+
 	// 블록 조회: 해시
 	// GET /block/hash?value=<hash>
 	mux.HandleFunc("/block/hash", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		hash := r.URL.Query().Get("value")
 		if hash == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
 			return
 		}
 		blk, err := getBlockByHash(hash)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
 		writeJSON(w, http.StatusOK, blk)
 	})
 
-	// 전체 장부 조회 (페이지네이션)
+	// 전체 장부 조회 (페이지네이션, gzip/ETag 지원 : blocktransfer.go)
 	// GET /blocks?offset=<int>&limit=<int>
-	mux.HandleFunc("/blocks", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/blocks", handleBlocks)
+
+	// 블록 헤더 페이지 (라이트 클라이언트 헤더 동기화용 : blocktransfer.go)
+	// GET /headers?offset=<int>&limit=<int>
+	mux.HandleFunc("/headers", handleHeaders)
+
+	// 최신 블록 N개 조회 (대시보드용, 최신순)
+	// GET /blocks/recent?count=<int>&full=<bool>
+	//  - 기본은 헤더만 반환, full=true 이면 전체 블록 반환
+	mux.HandleFunc("/blocks/recent", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
-		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-		if limit <= 0 {
-			limit = 50
+		count := 10
+		if q := r.URL.Query().Get("count"); q != "" {
+			n, err := strconv.Atoi(q)
+			if err != nil || n <= 0 {
+				writeError(w, http.StatusBadRequest, "count must be positive integer")
+				return
+			}
+			count = min(n, MaxRecentBlocks)
 		}
-		blocks, total, err := listBlocksPaginated(offset, limit)
+		blocks, err := listRecentBlocks(count)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("list blocks error: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("list blocks error: %v", err))
 			return
 		}
+		var items any = blocks
+		if r.URL.Query().Get("full") != "true" {
+			headers := make([]UpperBlockHeader, 0, len(blocks))
+			for _, b := range blocks {
+				headers = append(headers, b.header())
+			}
+			items = headers
+		}
+		height := -1
+		if len(blocks) > 0 {
+			height = blocks[0].Index
+		}
 		writeJSON(w, http.StatusOK, map[string]any{
-			"total":      total,
-			"offset":     offset,
-			"limit":      limit,
-			"items":      blocks,
-			"difficulty": GlobalDifficulty,
+			"height": height,
+			"count":  len(blocks),
+			"items":  items,
 		})
 	})
 
 	// 노드 상태 확인
 	// GET /status : 헬스/높이/주소 리턴 (부트노드 선정에 사용)
 	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
-		chainMu.Lock()
-		h, _ := getLatestHeight()
-		lastHash := ""
-		ub, err := getBlockByIndex(h)
-		if err != nil {
+		h, lastHash, work := localTip()
+		if lastHash == "" {
 			log.Printf("[P2P] Block Hash Not Found")
-		} else {
-			lastHash = ub.BlockHash
 		}
-		chainMu.Unlock()
 
 		writeJSON(w, http.StatusOK, map[string]any{
 			"addr":       self,
+			"addrs":      advertisedAddrs(),
 			"height":     h,
 			"is_boot":    isBoot.Load(),
 			"bootAddr":   boot,
 			"started_at": startedAt.Format(time.RFC3339),
 			"peers":      peersSnapshot(),
-			"difficulty": GlobalDifficulty,
+			"difficulty": currentDifficulty(),
+			"retarget":   currentRetarget(),
 			"hos_boot":   hosBootMap,
 			"last_hash":  lastHash,
+			"total_work": work.String(),
+			"mining":     isMining.Load(),
+			"cert_pin":   selfCertPin,
+			"time":       time.Now().UTC().Format(time.RFC3339Nano),
 		})
 	})
 
 	// 현재 노드가 알고 있는 피어 리스트 반환
-	// GET /peers
+	// GET /peers[?detail=true] : detail 이면 피어별 생존 여부와 회로 차단 상태 (peerclient.go)
 	mux.HandleFunc("/peers", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("detail") == "true" {
+			writeJSON(w, http.StatusOK, peerDetails())
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(peersSnapshot()) // 비어있어도 "[]" 반환
 	})
 
+	// 아직 블록에 포함되지 않은 메모리풀 앵커 조회
+	// GET /pending
+	mux.HandleFunc("/pending", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		items, size := pendingSnapshot()
+		writeJSON(w, http.StatusOK, map[string]any{
+			"count":  len(items),
+			"bytes":  size,
+			"mining": isMining.Load(),
+			"items":  items,
+		})
+	})
+
 	// Hos 체인에게 검색 요청을 중계하는 API
-	// GET /query?hos_id=<id>&keyword=<keyword>
+	// GET /query?hos_id=<id>&keyword=<keyword>[&offset=<int>&limit=<int>]
+	//  - offset/limit 은 Hos /search 로 전달, 전체 매칭 수는 X-Total-Count 헤더로 전달
+	//  - 요청자(X-Requester, 선택)/검색어 해시/반환 건수를 장부에 감사 기록 (queryaudit.go)
 	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 
@@ -137,18 +197,24 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 		kw := r.URL.Query().Get("keyword")
 
 		if hosID == "" || kw == "" {
-			http.Error(w, "hos_id and keyword required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "hos_id and keyword required")
 			return
 		}
 		logInfo("[QUERY] Target Hos Chain: %s, Keyword: %s", hosID, kw)
 
 		// 쿼리 검색 수행 후 반환
-		resultBytes, status, err := handleHosSearch(hosID, kw)
+		resultBytes, total, status, err := handleHosSearch(hosID, kw, r.URL.Query())
 		if err != nil {
-			http.Error(w, err.Error(), status)
+			recordQueryAudit(r, QueryAuditSearch, hosID, kw, 0, status)
+			writeError(w, status, err.Error())
 			return
 		}
+		// 중계 조회 감사 기록 (queryaudit.go)
+		recordQueryAudit(r, QueryAuditSearch, hosID, kw, resultCount(resultBytes), status)
 
+		if total != "" {
+			w.Header().Set("X-Total-Count", total)
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(status)
 		w.Write(resultBytes)
@@ -26,7 +26,42 @@ type UpperBlock struct {
 	Nonce      int            `json:"nonce"`       // PoW용 Nonce
 	Difficulty int            `json:"difficulty"`  // 난이도
 	BlockHash  string         `json:"block_hash"`  // 블록 전체 해시
-	Elapsed    float32        `json:"elapsed"`     // 채굴 소요 시간
+	Elapsed    float32        `json:"elapsed"`     // 직전 블록과의 타임스탬프 간격(초), 제네시스는 채굴 소요 시간
+	// nonce 공간 소진 시 증가하는 추가 nonce (0 이면 생략, pow.go)
+	ExtraNonce int `json:"extra_nonce,omitempty"`
+	// 체인의 난이도 조정 규칙 (제네시스에만, 기본 규칙이면 생략 : difficulty.go)
+	DifficultyParams *DifficultyParams `json:"difficulty_params,omitempty"`
+}
+
+// 블록 헤더 (대시보드 목록 조회용, Records 본문 제외)
+type UpperBlockHeader struct {
+	Index       int     `json:"index"`
+	GovID       string  `json:"gov_id"`
+	PrevHash    string  `json:"prev_hash"`
+	Timestamp   string  `json:"timestamp"`
+	MerkleRoot  string  `json:"merkle_root"`
+	Nonce       int     `json:"nonce"`
+	ExtraNonce  int     `json:"extra_nonce,omitempty"`
+	Difficulty  int     `json:"difficulty"`
+	BlockHash   string  `json:"block_hash"`
+	Elapsed     float32 `json:"elapsed"`
+	RecordCount int     `json:"record_count"` // 블록 내 앵커 수
+}
+
+func (b UpperBlock) header() UpperBlockHeader {
+	return UpperBlockHeader{
+		Index:       b.Index,
+		GovID:       b.GovID,
+		PrevHash:    b.PrevHash,
+		Timestamp:   b.Timestamp,
+		MerkleRoot:  b.MerkleRoot,
+		Nonce:       b.Nonce,
+		ExtraNonce:  b.ExtraNonce,
+		Difficulty:  b.Difficulty,
+		BlockHash:   b.BlockHash,
+		Elapsed:     b.Elapsed,
+		RecordCount: len(b.Records),
+	}
 }
 
 // 제네시스 블록 생성
@@ -44,8 +79,11 @@ func mineGenesisBlock(govID string) UpperBlock {
 		PrevHash:   prevHash,
 		MerkleRoot: merkleRoot,
 		Timestamp:  timestamp,
-		Difficulty: GlobalDifficulty,
+		Difficulty: GenesisDifficulty,
 	}
+	// 기본값이 아닌 난이도 규칙은 제네시스 해시에 묶어서 기록 (difficulty.go)
+	params := genesisDifficultyParams()
+	header.ParamsHash = params.hash()
 
 	// === 제네시스 Nonce 탐색 ===
 	nonce := 0
@@ -54,7 +92,7 @@ func mineGenesisBlock(govID string) UpperBlock {
 	for {
 		header.Nonce = nonce
 		hash = computeHashForPoW(header)
-		if validHash(hash, GlobalDifficulty) {
+		if validHash(hash, GenesisDifficulty) {
 			log.Printf("[PoW] GENESIS mined: nonce=%d hash=%s", nonce, hash)
 			break
 		}
@@ -71,12 +109,12 @@ func mineGenesisBlock(govID string) UpperBlock {
 		Records:    []AnchorRecord{}, // Genesis는 Records 없음
 		MerkleRoot: merkleRoot,
 		Nonce:      header.Nonce,
-		Difficulty: GlobalDifficulty,
+		Difficulty: GenesisDifficulty,
 		BlockHash:  hash,
 		Elapsed:    elapsed,
+		// 난이도 규칙 (기본 규칙이면 nil)
+		DifficultyParams: params,
 	}
-	// 난이도 조정 수행
-	adjustDifficulty(0, elapsed)
 	return genesis
 }
 
@@ -0,0 +1,45 @@
+package main
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+// 블록 크기 한도 (Block Size / Record-count Limits)
+// ------------------------------------------------------------
+// - 합의 상수 : 모든 노드가 같은 값을 써야 하므로 환경변수로 바꾸지 않음
+//   · MaxBlockEntries : 블록당 최대 레코드 수
+//   · MaxBlockBytes   : 블록 레코드 직렬화 크기 합 최대값 (pendingSize 기준)
+// - 부트노드는 메모리풀 앞쪽부터 한도까지만 꺼내 채굴 신호로 보내고 나머지는 다음 블록으로 (popPending)
+// - 채굴 신호(/mine/start)와 수신 블록(validateUpperBlock)에서 한도 초과 거부
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	MaxBlockEntries = 1000
+	MaxBlockBytes   = 4 << 20
+)
+
+// 블록 레코드 한도 검증
+func checkBlockLimits(records []AnchorRecord) error {
+	if len(records) > MaxBlockEntries {
+		return fmt.Errorf("too many records: %d > %d", len(records), MaxBlockEntries)
+	}
+	if n := pendingSize(records); n > MaxBlockBytes {
+		return fmt.Errorf("block too large: %d > %d bytes", n, MaxBlockBytes)
+	}
+	return nil
+}
+
+// 한도 안에서 다음 블록에 넣을 레코드 선택 (접수 순서 유지, 들어가지 않는 레코드는 deferred)
+func splitForBlock(records []AnchorRecord) (selected, deferred []AnchorRecord) {
+	selected, deferred = []AnchorRecord{}, []AnchorRecord{}
+	bytes := 0
+	for _, rec := range records {
+		size := pendingSize([]AnchorRecord{rec})
+		if len(selected) >= MaxBlockEntries || bytes+size > MaxBlockBytes {
+			deferred = append(deferred, rec)
+			continue
+		}
+		bytes += size
+		selected = append(selected, rec)
+	}
+	return selected, deferred
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 )
 
 // ============================================
@@ -17,38 +18,54 @@ import (
 type registerReq struct {
 	Addr  string `json:"addr"` // "host:port" 또는 "컨테이너명:포트"
 	GovID string `json:"gov_id"`
+	Role  string `json:"role,omitempty"` // observer 면 피어 목록에 넣지 않음 (observer.go)
 }
 type registerResp struct {
-	Peers []string `json:"peers"`
+	Peers    []string          `json:"peers"`
+	CertPins map[string]string `json:"cert_pins,omitempty"` // mTLS 인증서 지문 (노드 주소 => 지문)
 }
 
 // 신규노드가 네트워크 진입 시 부트노드가 다른 노드들의 주소를 제공하는 함수
 func registerPeer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req registerReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Addr == "" {
-		http.Error(w, "invalid body", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid body")
 		return
 	}
 
 	// 체인 정체성 확인: 제네시스 gov_id와 일치해야 가입 허용
 	blk0, err := getBlockByIndex(0)
 	if err != nil || blk0.GovID != req.GovID {
-		http.Error(w, "Gov_id mismatch", http.StatusForbidden)
+		writeError(w, http.StatusForbidden, "Gov_id mismatch")
 		return
 	}
 
+	// mTLS 활성 시 신규 노드가 제시한 인증서 지문을 주소에 고정
+	certPin, err := registrationPin(r)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		log.Printf("[P2P][REGISTER] Join denied for %s: %v", req.Addr, err)
+		return
+	}
+	pinPeerCert(req.Addr, certPin)
+
+	// 관찰 노드는 피어 명단만 받아가고 피어 목록/전파 대상에는 들어가지 않음 (observer.go)
+	observer := req.Role == RoleObserver
+
 	// 부트노드 로컬 peers에 추가
 	peerMu.Lock() // 동시 접근 막음
 	// 이미 등록된 주소인지 검증
 	already := checkAddress(req.Addr)
 
 	// 등록된 주소가 아니라면 추가
-	if !already {
+	if observer {
+		log.Printf("[P2P][REGISTER] observer synced peer list: %s (Gov_id=%s)", req.Addr, req.GovID)
+	} else if !already {
 		peers = append(peers, req.Addr)
 		log.Printf("[P2P][REGISTER] new peer joined: %s (Gov_id=%s) | total=%d", req.Addr, req.GovID, len(peers))
 	} else {
@@ -56,7 +73,9 @@ func registerPeer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 신규 노드는 peerAliveMap에 초기 상태 초기화
-	markAlive(req.Addr, true)
+	if !observer {
+		markAlive(req.Addr, true)
+	}
 
 	// 응답으로 넘겨줄 피어목록을 만듦 (자기 자신은 제외)
 	out := make([]string, 0, len(peers))
@@ -67,25 +86,40 @@ func registerPeer(w http.ResponseWriter, r *http.Request) {
 	}
 	peerMu.Unlock()
 
-	// 기존 피어들에게도 새 피어 알려주기(비동기)
-	go func(newPeer string, others []string) {
-		log.Printf("[P2P][REGISTER] notifying %d peers about %s", len(others), newPeer)
-		b, _ := json.Marshal(newPeer)
-		for _, op := range others {
-			resp, err := http.Post("http://"+op+"/addPeer", "application/json", strings.NewReader(string(b)))
-			if err != nil {
-				log.Printf("[P2P][REGISTER] notify failed to %s: %v", op, err)
-				continue
-			}
-			io.Copy(io.Discard, resp.Body)
-			resp.Body.Close()
-			log.Printf("[P2P][REGISTER] notified %s (status=%d)", op, resp.StatusCode)
-		}
-	}(req.Addr, out)
+	// 기존 피어들에게도 새 피어 알려주기(비동기, 관찰 노드는 제외)
+	if !observer {
+		go notifyNewPeer(req.Addr, certPin, out)
+	}
 
 	// 신규 노드에게 현재 피어 목록을 응답
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(registerResp{Peers: out})
+	resp := registerResp{Peers: out}
+	if tlsEnabled {
+		resp.CertPins = certPinsSnapshot()
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// 기존 피어들에게 신규 피어 주소(와 인증서 지문) 전달
+func notifyNewPeer(newPeer, certPin string, others []string) {
+	log.Printf("[P2P][REGISTER] notifying %d peers about %s", len(others), newPeer)
+	// mTLS 사용 시 지문을 함께 전달 (미사용 시 기존 형식인 주소 문자열 유지)
+	var b []byte
+	if certPin != "" {
+		b, _ = json.Marshal(map[string]string{"addr": newPeer, "cert_pin": certPin})
+	} else {
+		b, _ = json.Marshal(newPeer)
+	}
+	for _, op := range others {
+		resp, err := nodeClient.Post(nodeURL(op, "/addPeer"), "application/json", strings.NewReader(string(b)))
+		if err != nil {
+			log.Printf("[P2P][REGISTER] notify failed to %s: %v", op, err)
+			continue
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		log.Printf("[P2P][REGISTER] notified %s (status=%d)", op, resp.StatusCode)
+	}
 }
 
 // ============================================
@@ -94,11 +128,14 @@ func registerPeer(w http.ResponseWriter, r *http.Request) {
 
 // 노드 상태 구조체, /status API 호출 시 응답받는 JSON 구조
 type nodeStatus struct {
-	Addr     string   `json:"addr"`      // 노드 주소
-	Height   int      `json:"height"`    // 블록 높이 (체인 진행 정도)
-	IsBoot   bool     `json:"is_boot"`   // 부트노드 여부
-	Peers    []string `json:"peers"`     // 연결된 피어 목록
-	LastHash string   `json:"last_hash"` // 최신 블록의 해시
+	Addr      string   `json:"addr"`       // 노드 주소
+	Height    int      `json:"height"`     // 블록 높이 (체인 진행 정도)
+	IsBoot    bool     `json:"is_boot"`    // 부트노드 여부
+	Peers     []string `json:"peers"`      // 연결된 피어 목록
+	LastHash  string   `json:"last_hash"`  // 최신 블록의 해시
+	TotalWork string   `json:"total_work"` // 누적 작업량 (10진수, forkchoice.go)
+	Addrs     []string `json:"addrs"`      // 광고 접속 주소 (advertise.go)
+	Time      string   `json:"time"`       // 응답 시점의 노드 시계 (timesync.go)
 }
 
 // 다른 노드 상태 조회
@@ -106,10 +143,12 @@ type nodeStatus struct {
 // 해당 노드의 현재 상태(nodeStatus)를 가져옴
 func probeStatus(addr string) (nodeStatus, bool) {
 	var s nodeStatus
-	resp, err := http.Get("http://" + addr + "/status")
+	sent := time.Now()
+	resp, err := nodeClient.Get(nodeURL(addr, "/status"))
 	if err != nil {
 		return s, false
 	}
+	recv := time.Now()
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
 		return s, false
@@ -117,21 +156,24 @@ func probeStatus(addr string) (nodeStatus, bool) {
 	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
 		return s, false
 	}
+	observePeerClock(addr, s.Time, sent, recv)
 	return s, true
 }
 
 // 부트노드 선출 및 전환
 // 네트워크 상의 모든 노드(peers + self)를 조사
 // 1) 가장 높은 블록 높이를 가진 노드를 찾음
-// 2) 동률이면 주소 사전순으로 가장 앞선 노드를 부트노드로 지정
+// 2) 동률이면 최신 블록 해시 기반 무작위 값으로 선택 (pickBoot)
 // 3) 선출된 부트노드는 다른 Gov노드들에게 자신의 주소를 전파
 // 4) 선출된 부트노드는 Hos 부트노드들에게 자신의 주소를 전파
 // 현재 노드가 그 승자라면 => self를 부트노드로 승격
 // 그렇지 않으면 => 해당 승자를 부트노드로 인식
 func electAndSwitch() {
-	// 후보: peers + self
+	// 후보: peers + self (관찰 노드는 후보가 아님)
 	cand := peersSnapshot()
-	cand = append(cand, self)
+	if !isObserver() {
+		cand = append(cand, self)
+	}
 
 	// 상태 수집
 	type info struct {
@@ -170,6 +212,11 @@ func electAndSwitch() {
 			markAlive(r.ns.Addr, false) // 노드 상태 false로 기록
 		}
 	}
+	// 관찰 노드는 스스로 부트노드가 되지 않고 다음 감시 주기에 다시 선출
+	if len(live) == 0 && isObserver() {
+		log.Printf("[OBSERVER] no live peers; waiting for a bootnode")
+		return
+	}
 	// 살아있는 노드가 없다면 자기 자신을 부트로 승격
 	if len(live) == 0 {
 		isBoot.Store(true)
@@ -178,14 +225,8 @@ func electAndSwitch() {
 		return
 	}
 
-	// 부트노드 선정 기준: 높이 최댓값, 동률이면 주소 사전순 최소
-	winner := live[0]
-	for _, x := range live[1:] {
-		if x.Height > winner.Height ||
-			(x.Height == winner.Height && x.Addr < winner.Addr) {
-			winner = x
-		}
-	}
+	// 부트노드 선정 기준: 높이 최댓값, 동률이면 최신 블록 해시 기반 추첨
+	winner := pickBoot(live)
 	// 자신이 승자노드가 된 경우, 다른 Gov 노드들과 hos 부트노드들에게 자신의 주소 전파
 	if winner.Addr == self {
 		isBoot.Store(true)
@@ -205,8 +246,7 @@ func broadcastNewBoot(newBoot string) {
 	for _, p := range peersSnapshot() {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"addr": newBoot})
-			_, err := http.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
-			if err != nil {
+			if err := postPeer(dst, "/bootNotify", body); err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
 		}(p)
@@ -217,7 +257,7 @@ func broadcastNewBoot(newBoot string) {
 // POST : /bootNotify
 func bootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -226,12 +266,12 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.Addr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.Addr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.Addr)
 		return
 	}
@@ -256,8 +296,7 @@ func broadcastNewBootToHos(newBoot string) {
 		go func(id, dst string) {
 			log.Printf("[BOOT][ToHos] New Gov Boot Node's Addr is now sending to : %s", dst)
 			body, _ := json.Marshal(map[string]string{"gov_boot": newBoot})
-			_, err := http.Post("http://"+dst+"/chgGovBoot", "application/json", strings.NewReader(string(body)))
-			if err != nil {
+			if err := postPeer(dst, "/chgGovBoot", body); err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
 		}(hosID, hosBoot)
@@ -275,8 +314,7 @@ func broadcastNewHosBoot(hosID, hosBoot string) {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"hos_id": hosID, "hos_boot": hosBoot})
 			logInfo("[BOOT] notify new hosBoot to %s", dst)
-			_, err := http.Post("http://"+dst+"/hosBootNotify", "application/json", strings.NewReader(string(body)))
-			if err != nil {
+			if err := postPeer(dst, "/hosBootNotify", body); err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
 		}(peer)
@@ -288,7 +326,7 @@ func broadcastNewHosBoot(hosID, hosBoot string) {
 // POST : /hosBootNotify
 func hosBootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -298,12 +336,12 @@ func hosBootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 주소 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.HosBoot == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.HosBoot); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.HosBoot)
 		return
 	}
@@ -316,8 +354,12 @@ func hosBootNotify(w http.ResponseWriter, r *http.Request) {
 
 func setBootAddr(addr string) {
 	bootAddrMu.Lock()
+	prev := boot
 	boot = addr
 	bootAddrMu.Unlock()
+	if prev != addr {
+		publishEvent(EventBootElected, map[string]any{"boot": addr, "prev": prev, "is_self": addr == self})
+	}
 }
 func getBootAddr() string {
 	bootAddrMu.RLock()
@@ -336,3 +378,36 @@ func getHosBootAddr(hosID string) string {
 	defer hosBootMapMu.RUnlock()
 	return hosBootMap[hosID]
 }
+
+// 부트노드 후보 추첨
+// 최고 높이 노드들 중 sha256("boot_election|<seed>|<addr>") 가 가장 작은 노드를 선택
+// seed 는 최고 높이 노드들의 최신 블록 해시 (분기로 다르면 사전순 최소)
+// 살아있는 노드 목록이 같으면 모든 노드가 같은 결과를 얻고,
+// 블록마다 순서가 바뀌므로 주소만으로 당첨을 예측/선점할 수 없음
+func pickBoot(live []nodeStatus) nodeStatus {
+	top := []nodeStatus{}
+	for _, x := range live {
+		if len(top) == 0 || x.Height > top[0].Height {
+			top = []nodeStatus{x}
+		} else if x.Height == top[0].Height {
+			top = append(top, x)
+		}
+	}
+	seed := top[0].LastHash
+	for _, x := range top[1:] {
+		if x.LastHash < seed {
+			seed = x.LastHash
+		}
+	}
+	winner, best := top[0], electionScore(seed, top[0].Addr)
+	for _, x := range top[1:] {
+		if s := electionScore(seed, x.Addr); s < best || (s == best && x.Addr < winner.Addr) {
+			winner, best = x, s
+		}
+	}
+	return winner
+}
+
+func electionScore(seed, addr string) string {
+	return sha256Hex([]byte("boot_election|" + seed + "|" + addr))
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 정규 JSON 인코딩 (jsonCanonical, 해시/서명 대상 직렬화)
+// ------------------------------------------------------------
+// - 규칙
+//   · 객체 키는 모든 깊이에서 바이트 순 정렬, 공백 없음, HTML 이스케이프 없음
+//   · 숫자는 float64 를 거치지 않고 원래 자릿수 유지 (json.Number)
+//     정수 리터럴은 그대로, 소수/지수 표기는 float64 값의 Go 표준 표기로 고정 (예: 1.50 => 1.5)
+//   · 최상위가 객체가 아니어도 (배열, 문자열 등) 그대로 인코딩
+// - 이전 방식(map 왕복)과의 차이
+//   · 2^53 이상 정수가 float64 로 반올림되어 서로 다른 값이 같은 해시가 되던 문제 제거
+//   · 최상위가 객체가 아니면 항상 "{}" 로 직렬화되던 문제 제거
+//   · 그 외 입력은 두 방식의 출력이 바이트 단위로 같음 (기존 서명/증명 그대로 검증됨)
+// - Gov 블록 해시는 PoW 헤더 해시(powhash.go)이므로 이 인코딩의 영향 없음 (Hos 는 시작 시 이전 블록 확인)
+////////////////////////////////////////////////////////////////////////////////
+
+// 정규 JSON 직렬화 (해시 재현성 확보)
+func jsonCanonical(obj interface{}) []byte {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	var v interface{}
+	if err := unmarshalNumbers(raw, &v); err != nil {
+		return nil
+	}
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	writeCanonical(buf, enc, v)
+	return buf.Bytes()
+}
+
+func writeCanonical(buf *bytes.Buffer, enc *json.Encoder, v interface{}) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, enc, k)
+			buf.WriteByte(':')
+			writeCanonical(buf, enc, x[k])
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range x {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonical(buf, enc, e)
+		}
+		buf.WriteByte(']')
+	case string:
+		writeCanonicalString(buf, enc, x)
+	case json.Number:
+		buf.WriteString(canonicalNumber(x))
+	case bool:
+		if x {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	default:
+		buf.WriteString("null")
+	}
+}
+
+// 숫자를 json.Number 로 유지하며 디코딩 (해시 대상 map 을 만들 때 사용)
+func unmarshalNumbers(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// 문자열은 encoding/json 이스케이프 규칙 그대로 (Encode 가 붙이는 줄바꿈 제거)
+func writeCanonicalString(buf *bytes.Buffer, enc *json.Encoder, s string) {
+	enc.Encode(s)
+	buf.Truncate(buf.Len() - 1)
+}
+
+// 정수 리터럴은 그대로, 그 외는 float64 의 Go 표준 표기
+func canonicalNumber(n json.Number) string {
+	s := string(n)
+	if !strings.ContainsAny(s, ".eE") {
+		return s
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return s
+	}
+	b, _ := json.Marshal(f)
+	return string(b)
+}
@@ -21,6 +21,7 @@ type UpperChain struct {
 	difficulty    int
 	pending       []AnchorRecord // 아직 블록에 포함되지 않은 Hos 루트 (HosID => Root)
 	pendingMu     sync.Mutex
+	pendingBytes  int       // pending 직렬화 크기 합 (pendinglimit.go)
 	lastBlockTime time.Time // 마지막 블록 생성 시각
 }
 
@@ -34,10 +35,9 @@ var (
 	bootAddrMu         sync.RWMutex                  // 부트노드 주소 접근 시 동시성 보호용 RW 잠금 객체
 	hosBootMap         = make(map[string]string)     // Gov 부트노드와 연결될 Hos 체인들의 부트노드 주소록
 	hosBootMapMu       sync.RWMutex                  // hosBootMap 접근 시 동시성 보호용 RW 잠금 객체
-	GlobalDifficulty   = 4                           // 전역 난이도 설정 (모든 노드 동일)
+	GenesisDifficulty  = 4                           // 제네시스 난이도, 이후는 온체인 규칙으로 결정 (difficulty.go)
 	isMining           atomic.Bool                   // 내부적인 채굴 상태 플래그
 	miningStop         atomic.Bool                   // 다른 노드에게 영향받는 채굴 중단 플래그 (다른 노드가 성공하면 true)
-	DiffStandardTime   = 20                          // 난이도 조정 기준 시간(20초)
 	MiningWatcherTime  = 1                           // 채굴 기준시간(30초)
 	NetworkWatcherTime = 60                          // 노드 관리 기준시간(60초)
 	ChainWatcherTime   = 300                         // 체인 관리 기준시간(300초)
@@ -49,27 +49,33 @@ var (
 func newUpperChain(govID string) (*UpperChain, error) {
 	ch = &UpperChain{
 		govID:         govID,
-		difficulty:    GlobalDifficulty,
+		difficulty:    GenesisDifficulty,
 		pending:       []AnchorRecord{},
 		lastBlockTime: time.Now(),
 	}
 
 	// 제네시스 블록 존재 여부 확인
 	genesis, err := getBlockByIndex(0)
+	// PoW 해시 규칙 결정 (제네시스 채굴 전)
+	if herr := initPoWHasher(PoWHashName, err == nil); herr != nil {
+		return nil, fmt.Errorf("pow hash: %w", herr)
+	}
+	// 난이도 조정 파라미터 결정 (제네시스에 기록된 규칙 우선, difficulty.go)
+	var recorded *UpperBlock
+	if err == nil {
+		recorded = &genesis
+	}
+	if derr := initDifficultyParams(DifficultyConfig, recorded); derr != nil {
+		return nil, fmt.Errorf("difficulty params: %w", derr)
+	}
 	// 제네시스 블록이 없는 경우
 	if err != nil {
 		log.Printf("[INIT] No genesis. Mining genesis...")
 		genesis = mineGenesisBlock(govID)
 
 		// 체인에 추가
-		if err := saveBlockToDB(genesis); err != nil {
-			return nil, fmt.Errorf("save genesis block: %w", err)
-		}
-		if err := updateIndicesForBlock(genesis); err != nil {
-			return nil, fmt.Errorf("update genesis indices: %w", err)
-		}
-		if err := setLatestHeight(genesis.Index); err != nil {
-			return nil, fmt.Errorf("set genesis height: %w", err)
+		if err := commitBlock(genesis); err != nil {
+			return nil, fmt.Errorf("commit genesis block: %w", err)
 		}
 		ch.lastBlockTime = time.Now()
 
@@ -91,56 +97,100 @@ func newUpperChain(govID string) (*UpperChain, error) {
 func onBlockReceived(ub UpperBlock) error {
 	miningStop.Store(true) // 다른 PoW 중단
 
+	// 검증과 반영 사이에 다른 블록이 끼어들지 않도록 chainMu 안에서 반영
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
 	// 이전 블록 확인
 	prev, err := getBlockByIndex(ub.Index - 1)
 	if err != nil {
 		return fmt.Errorf("load prev: %w", err)
 	}
 
-	// 검증
-	if ub.PrevHash != prev.BlockHash {
-		return fmt.Errorf("invalid prev hash")
-	}
-	if !validHash(ub.BlockHash, ub.Difficulty) {
-		return fmt.Errorf("invalid PoW hash")
+	// 검증 (연결, 머클 루트, PoW 해시, 타임스탬프, 온체인 난이도 규칙)
+	if err := validateUpperBlock(ub, prev, getBlockByIndex); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidBlock, err)
 	}
+	ub.Elapsed = blockInterval(ub, prev)
 
-	// 체인에 추가
-	if err := saveBlockToDB(ub); err != nil {
-		return fmt.Errorf("save block: %w", err)
-	}
-	if err := updateIndicesForBlock(ub); err != nil {
-		return fmt.Errorf("update indices: %w", err)
-	}
-	if err := setLatestHeight(ub.Index); err != nil {
-		return fmt.Errorf("set height: %w", err)
+	// 체인에 추가 (본문/색인/높이 원자적 반영)
+	if err := commitBlock(ub); err != nil {
+		return fmt.Errorf("commit block: %w", err)
 	}
+	publishBlockFinalized(ub)
+	takeDispatched() // 채굴 배치가 블록으로 확정됨
 
 	logInfo("[CHAIN][UPPER] Accepted UpperBlock #%d (%s)", ub.Index, ub.BlockHash[:12])
 	return nil
 }
 
-// 체인의 메모리풀인 pending에 앵커 내용 추가
+// 체인의 메모리풀인 pending에 앵커 내용 추가 (한도 무시, 외부 접수는 admitPending)
 func appendPending(records []AnchorRecord) {
+	size := pendingSize(records)
 	ch.pendingMu.Lock()
 	ch.pending = append(ch.pending, records...)
+	ch.pendingBytes += size
 	ch.pendingMu.Unlock()
 	log.Printf("[CHAIN][PENDING] Append pending entries (%d items)", len(records))
 }
 
-// 체인의 메모리풀인 pending에 앵커 내용 비우고 가져오기
+// 부트노드가 마지막으로 채굴 신호와 함께 보낸 앵커 (검증을 거쳐 자신의 메모리풀에서 꺼낸 것만)
+var (
+	dispatched   []AnchorRecord
+	dispatchedMu sync.Mutex
+)
+
+// 마지막 채굴 배치 기록 (sendMiningSignal)
+func setDispatched(records []AnchorRecord) {
+	dispatchedMu.Lock()
+	defer dispatchedMu.Unlock()
+	dispatched = records
+}
+
+// 마지막 채굴 배치를 꺼내고 비움 (블록 확정 시 폐기, 규칙 위반 블록으로 채굴이 중단되면 메모리풀로 복구)
+func takeDispatched() []AnchorRecord {
+	dispatchedMu.Lock()
+	defer dispatchedMu.Unlock()
+	records := dispatched
+	dispatched = nil
+	return records
+}
+
+// 체인의 메모리풀인 pending에서 다음 블록에 넣을 앵커 가져오기
+// 블록 크기 한도를 넘는 나머지는 메모리풀에 남김 (blocklimit.go)
 func popPending() []AnchorRecord {
 	ch.pendingMu.Lock()
 	defer ch.pendingMu.Unlock()
-	// 복사본 생성
-	entries := make([]AnchorRecord, len(ch.pending))
-	copy(entries, ch.pending)
-	// 원본 비우기
-	ch.pending = []AnchorRecord{}
-	log.Printf("[CHAIN][PENDING] Pop pending entries (%d items)", len(entries))
+	entries, deferred := splitForBlock(ch.pending)
+	ch.pending = deferred
+	ch.pendingBytes = pendingSize(deferred)
+	log.Printf("[CHAIN][PENDING] Pop pending entries (%d items, %d left)", len(entries), len(deferred))
 	return entries
 }
 
+// 채굴을 시작할 레코드가 있는지 확인
+//   - 조회 감사 기록만 있으면 가장 오래된 기록이 QueryAuditMaxWait 를 넘겼을 때만 (queryaudit.go)
+func pendingDue() bool {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	for _, rec := range ch.pending {
+		if rec.Kind != RecordKindQueryAudit {
+			return true
+		}
+		if ts, err := time.Parse(time.RFC3339Nano, rec.AnchorTimestamp); err != nil || time.Since(ts) >= QueryAuditMaxWait {
+			return true
+		}
+	}
+	return false
+}
+
+// 메모리풀 복사본과 직렬화 크기 합 (GET /pending)
+func pendingSnapshot() ([]AnchorRecord, int) {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	return append([]AnchorRecord{}, ch.pending...), ch.pendingBytes
+}
+
 // 메모리풀의 엔트리 개수 확인
 func getPendingCnt() int {
 	ch.pendingMu.Lock()
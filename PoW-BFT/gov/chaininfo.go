@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Chain Info (체인 식별 정보)
+// ------------------------------------------------------------
+// - GET /chain/info : 이 체인의 식별 정보를 한 번에 반환
+//   · chain_id / genesis_hash / genesis_timestamp : 제네시스 블록 기준 체인 신원
+//   · consensus          : 합의 방식 (Gov 체인은 pow, difficulty 함께 제공)
+//   · hash_profile       : 레코드/머클 해시 규칙 버전 (crypto_merkle.go)
+//   · pow_hash           : 블록 헤더 PoW 해시 규칙 (powhash.go)
+//   · difficulty_params  : 난이도 조정 파라미터 (목표 간격/구간/범위/비율, difficulty.go)
+//   · validator_set_hash : 현재 검증자(자신 + 피어) 주소 목록(정렬)의 해시
+//   · protocol_version   : 노드 간 블록/합의 메시지 규격 버전
+// - 사용처
+//   · 동기화 대상 선택 : 제네시스 해시/난이도 파라미터가 다른 피어는 fork 판정에서 제외 (p2p.go startChainWatcher)
+//   · 가입 신청 접수   : hos_boot 의 chain_id 가 신청 hos_id 와 다르면 거절 (onboarding.go)
+//   · 검증 게이트웨이  : 상위 체인 등록 시 chain_id / genesis_hash 기록 (gateway.go)
+// - 노드 간 요청 헤더 X-Chain-ID : 보내는 노드의 제네시스 블록 해시 (peerTransport 가 모든 요청에 기록)
+//   · chain_id(gov_id)는 구성값이라 같은 이름으로 새로 만든 체인과 구분되지 않음 => 제네시스 해시로 체인 식별
+//   · 같은 체인 노드끼리만 주고받는 엔드포인트(/receiveBlock, /mine/start, /addPeer 등)는 requireSameChain 으로
+//     헤더가 없거나 제네시스 해시가 다른 요청을 본문 처리 전에 거절 (같은 구성의 다른 체인 트래픽 혼입 방지)
+//   · Hos 등 다른 체인과 주고받는 엔드포인트는 확인하지 않음
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ProtocolVersion    = 2                          // 2 : 온체인 난이도 규칙 (difficulty.go)
+	HashProfileVersion = "sha256-canonical-json-v1" // SHA-256 + 키 정렬 JSON + merkle.PairHash 머클
+	ChainIDHeader      = "X-Chain-ID"
+	ConsensusType      = "pow"
+)
+
+type ChainInfo struct {
+	ChainID          string `json:"chain_id"`
+	GenesisHash      string `json:"genesis_hash"`
+	GenesisTimestamp string `json:"genesis_timestamp"`
+	Consensus        string `json:"consensus"`
+	HashProfile      string `json:"hash_profile"`
+	PoWHash          string `json:"pow_hash"`
+	ValidatorSetHash string `json:"validator_set_hash"`
+	Validators       int    `json:"validators"`
+	ProtocolVersion  int    `json:"protocol_version"`
+	Height           int    `json:"height"`
+	Difficulty       int    `json:"difficulty,omitempty"`
+	// 난이도 조정 파라미터 (이전 버전 노드는 생략)
+	DifficultyParams *DifficultyParams `json:"difficulty_params,omitempty"`
+}
+
+// 현재 검증자 집합 (자신 + 피어 주소, 정렬)
+func validatorSet() []string {
+	set := append([]string{self}, peersSnapshot()...)
+	sort.Strings(set)
+	return set
+}
+
+func buildChainInfo() (ChainInfo, error) {
+	genesis, err := getBlockByIndex(0)
+	if err != nil {
+		return ChainInfo{}, fmt.Errorf("no genesis: %w", err)
+	}
+	set := validatorSet()
+	height, _ := getLatestHeight()
+	params := diffParams
+	return ChainInfo{
+		ChainID:          genesis.GovID,
+		GenesisHash:      genesis.BlockHash,
+		GenesisTimestamp: genesis.Timestamp,
+		Consensus:        ConsensusType,
+		HashProfile:      HashProfileVersion,
+		PoWHash:          powHasher.Name(),
+		ValidatorSetHash: sha256Hex([]byte(strings.Join(set, "\n"))),
+		Validators:       len(set),
+		ProtocolVersion:  ProtocolVersion,
+		Height:           height,
+		Difficulty:       currentDifficulty(),
+		DifficultyParams: &params,
+	}, nil
+}
+
+// GET /chain/info
+func handleChainInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	info, err := buildChainInfo()
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// 노드 주소의 체인 식별 정보 조회
+func fetchChainInfo(addr string) (ChainInfo, error) {
+	var info ChainInfo
+	resp, err := nodeClient.Get(nodeURL(addr, "/chain/info"))
+	if err != nil {
+		return info, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return info, fmt.Errorf("status=%d", resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&info)
+	return info, err
+}
+
+// 피어가 같은 체인(제네시스/프로토콜)인지 확인
+// - 로컬 제네시스가 없거나 피어가 /chain/info 를 제공하지 않으면 판단하지 않음(true)
+func sameChain(addr string) bool {
+	local, err := getBlockByIndex(0)
+	if err != nil {
+		return true
+	}
+	info, err := fetchChainInfo(addr)
+	if err != nil {
+		return true
+	}
+	if info.DifficultyParams != nil && *info.DifficultyParams != diffParams {
+		return false
+	}
+	return info.GenesisHash == local.BlockHash && info.ProtocolVersion == ProtocolVersion
+}
+
+// X-Chain-ID 값 : 로컬 제네시스 블록 해시 (제네시스가 없으면 "")
+func localChainID() string {
+	genesis, err := getBlockByIndex(0)
+	if err != nil {
+		return ""
+	}
+	return genesis.BlockHash
+}
+
+// 노드 간 요청에 이 노드의 체인 식별값 기록 (peerTransport 에서 호출)
+func stampChainID(req *http.Request) *http.Request {
+	if req.Header.Get(ChainIDHeader) != "" {
+		return req
+	}
+	id := localChainID()
+	if id == "" {
+		return req
+	}
+	out := req.Clone(req.Context())
+	out.Header.Set(ChainIDHeader, id)
+	return out
+}
+
+// 같은 체인 노드 전용 엔드포인트 보호 : X-Chain-ID 가 없거나 로컬 제네시스 해시와 다른 요청 거절
+func requireSameChain(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, local := r.Header.Get(ChainIDHeader), localChainID()
+		if local == "" {
+			writeError(w, http.StatusServiceUnavailable, "no genesis")
+			return
+		}
+		if id != local {
+			log.Printf("[P2P][DENY] %s from %s: chain_id=%q (local=%q)", r.URL.Path, r.RemoteAddr, id, local)
+			writeErrorDetail(w, http.StatusForbidden, "chain_mismatch", "request is missing "+ChainIDHeader+" or is from a different chain",
+				map[string]string{"chain_id": id, "expected": local})
+			return
+		}
+		h(w, r)
+	}
+}
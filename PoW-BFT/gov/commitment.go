@@ -0,0 +1,194 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"net/http"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Chain Commitment (체인 상태 집계 커밋먼트)
+// ------------------------------------------------------------
+// - 감사용 단일 커밋먼트 : 높이, 최신 블록 해시,
+//   지금까지의 모든 블록 해시에 대한 Merkle Root, 모든 앵커 루트(LowerRoot)에 대한 Merkle Root
+// - 두 Merkle Root 모두 전체 재계산 없이 블록 추가 시 증분 갱신
+//   · 크기 2^k 완전 이진 트리의 루트(peak)만 레벨별로 보관 ("commit_state")
+//   · 루트 = 높은 레벨 peak부터 pairHash로 접어서 계산
+// - 노드 개인키(meta_gov_privkey)로 서명하여 "commit_latest"에 보관
+////////////////////////////////////////////////////////////////////////////////
+
+type ChainCommitment struct {
+	Height      int    `json:"height"`       // 최신 블록 번호
+	TipHash     string `json:"tip_hash"`     // 최신 블록 해시
+	BlocksRoot  string `json:"blocks_root"`  // 0..Height 블록 해시들의 Merkle Root
+	AnchorsRoot string `json:"anchors_root"` // 장부에 기록된 모든 앵커 루트들의 Merkle Root
+	Anchors     int    `json:"anchors"`      // 누적 앵커 수
+	Signer      string `json:"signer"`       // 서명한 노드 주소 (공개키 : /getPublicKey)
+	Sig         string `json:"sig"`          // 노드 ECDSA 서명 (hex, DER)
+}
+
+// 서명 대상 다이제스트 (서명 필드 제외)
+func (c ChainCommitment) digest() []byte {
+	body := struct {
+		Height      int    `json:"height"`
+		TipHash     string `json:"tip_hash"`
+		BlocksRoot  string `json:"blocks_root"`
+		AnchorsRoot string `json:"anchors_root"`
+		Anchors     int    `json:"anchors"`
+		Signer      string `json:"signer"`
+	}{c.Height, c.TipHash, c.BlocksRoot, c.AnchorsRoot, c.Anchors, c.Signer}
+	sum := sha256.Sum256(jsonCanonical(body))
+	return sum[:]
+}
+
+// 증분 Merkle 누적기 (peaks[k] = 크기 2^k 서브트리 루트, 없으면 "")
+type merkleAccumulator struct {
+	Count int      `json:"count"`
+	Peaks []string `json:"peaks"`
+}
+
+func (a *merkleAccumulator) add(leaf string) {
+	carry := leaf
+	for k := 0; ; k++ {
+		if k == len(a.Peaks) {
+			a.Peaks = append(a.Peaks, "")
+		}
+		if a.Peaks[k] == "" {
+			a.Peaks[k] = carry
+			break
+		}
+		carry = merkle.PairHash(a.Peaks[k], carry)
+		a.Peaks[k] = ""
+	}
+	a.Count++
+}
+
+func (a *merkleAccumulator) root() string {
+	root := ""
+	for k := len(a.Peaks) - 1; k >= 0; k-- {
+		if a.Peaks[k] == "" {
+			continue
+		}
+		if root == "" {
+			root = a.Peaks[k]
+		} else {
+			root = merkle.PairHash(root, a.Peaks[k])
+		}
+	}
+	if root == "" {
+		return sha256Hex([]byte{})
+	}
+	return root
+}
+
+// 커밋먼트 누적 상태 (블록 해시 / 앵커 루트)
+type commitState struct {
+	Blocks  merkleAccumulator `json:"blocks"`
+	Anchors merkleAccumulator `json:"anchors"`
+}
+
+func (s *commitState) add(block UpperBlock) {
+	s.Blocks.add(block.BlockHash)
+	for _, rec := range block.Records {
+		if rec.LowerRoot != "" {
+			s.Anchors.add(rec.LowerRoot)
+		}
+	}
+}
+
+// 블록 저장 시 커밋먼트 갱신
+// - 기능 도입 이전 장부라면 누락된 블록부터 채워 넣음
+func appendCommitment(tx *ledgerTxn, block UpperBlock) error {
+	var st commitState
+	if v, ok := tx.getMeta("commit_state"); ok {
+		_ = json.Unmarshal([]byte(v), &st)
+	}
+	if block.Index < st.Blocks.Count {
+		// 이미 반영된 높이의 블록이 교체된 경우(포크 정리) 처음부터 재구성
+		st = commitState{}
+	}
+	for i := st.Blocks.Count; i < block.Index; i++ {
+		b, err := getBlockByIndexFrom(tx, i)
+		if err != nil {
+			return fmt.Errorf("commitment catch-up block #%d: %w", i, err)
+		}
+		st.add(b)
+	}
+	st.add(block)
+
+	c := ChainCommitment{
+		Height:      block.Index,
+		TipHash:     block.BlockHash,
+		BlocksRoot:  st.Blocks.root(),
+		AnchorsRoot: st.Anchors.root(),
+		Anchors:     st.Anchors.Count,
+		Signer:      self,
+	}
+	if sig, err := signCommitment(c); err == nil {
+		c.Sig = sig
+	} else {
+		log.Printf("[COMMIT][WARN] commitment #%d left unsigned: %v", block.Index, err)
+	}
+
+	stData, _ := json.Marshal(st)
+	cData, _ := json.Marshal(c)
+	tx.Put([]byte("commit_state"), stData)
+	tx.Put([]byte("commit_latest"), cData)
+	return nil
+}
+
+// 노드 개인키로 커밋먼트 서명
+func signCommitment(c ChainCommitment) (string, error) {
+	return signWithGovKey(c.digest())
+}
+
+// 노드 개인키로 다이제스트 서명 (ASN.1 DER, hex)
+func signWithGovKey(digest []byte) (string, error) {
+	privPem, ok := getMeta("meta_gov_privkey")
+	if !ok {
+		return "", fmt.Errorf("private key not found")
+	}
+	block, _ := pem.Decode([]byte(privPem))
+	if block == nil {
+		return "", fmt.Errorf("invalid private key pem")
+	}
+	priv, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sig), nil
+}
+
+// 체인 상태 커밋먼트 조회
+// GET /commitment
+func handleCommitment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	v, ok := getMeta("commit_latest")
+	if !ok {
+		writeError(w, http.StatusNotFound, "commitment not available")
+		return
+	}
+	var c ChainCommitment
+	if err := json.Unmarshal([]byte(v), &c); err != nil {
+		log.Printf("[COMMIT][ERROR] invalid stored commitment: %v", err)
+		writeError(w, http.StatusInternalServerError, "invalid commitment")
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
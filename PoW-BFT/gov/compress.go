@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"wsconn"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 노드 간 전송 압축 (gzip Content-Encoding)
+// ------------------------------------------------------------
+// - 응답 : 요청에 Accept-Encoding: gzip 이 있으면 JSON/텍스트 응답을 gzip 압축 (withCompression)
+//   · Go 클라이언트(nodeClient/nodeBulkClient)는 gzip 을 자동 요청하고 자동 해제하므로 호출 코드 변경 없음
+//   · 이벤트 스트림(SSE/WebSocket), 부분 응답(206), 이미 인코딩된 응답은 그대로 전달
+// - 요청 : Content-Encoding: gzip 본문을 풀어서 핸들러에 전달
+//   · 모든 응답에 Accept-Encoding: gzip 을 실어 요청 본문 gzip 수신 가능을 알림 (RFC 7694)
+//   · peerTransport 는 이를 확인한 피어에게만 CompressMinBytes 이상 본문(블록 브로드캐스트, 채굴 신호 등)을 압축 전송
+//     => 압축을 모르는 이전 버전 노드와도 그대로 통신
+////////////////////////////////////////////////////////////////////////////////
+
+const CompressMinBytes = 1024 // 이보다 작은 요청 본문은 압축하지 않음
+
+var peerAcceptsGzip sync.Map // 피어 host => 요청 본문 gzip 수신 지원 여부
+
+// gzip 요청 본문 해제 + 응답 압축
+func withCompression(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Accept-Encoding", "gzip")
+		if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid gzip body")
+				return
+			}
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{zr, r.Body}
+			r.Header.Del("Content-Encoding")
+			r.ContentLength = -1
+		}
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") || wsconn.IsUpgrade(r) || strings.HasSuffix(r.URL.Path, "/events") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.finish()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// 압축할 응답 형식
+func compressibleType(ct string) bool {
+	ct, _, _ = strings.Cut(ct, ";")
+	switch strings.TrimSpace(ct) {
+	case "application/json", "text/plain", "text/html", "text/css", "text/javascript", "application/javascript":
+		return true
+	}
+	return false
+}
+
+// 헤더 기록 시점에 압축 여부를 정하는 ResponseWriter
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (g *gzipResponseWriter) WriteHeader(status int) {
+	if g.wroteHeader {
+		return
+	}
+	g.wroteHeader = true
+	h := g.Header()
+	if status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified && status != http.StatusPartialContent &&
+		h.Get("Content-Encoding") == "" && compressibleType(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", "gzip")
+		h.Add("Vary", "Accept-Encoding")
+		h.Del("Content-Length")
+		g.gz = gzip.NewWriter(g.ResponseWriter)
+	}
+	g.ResponseWriter.WriteHeader(status)
+}
+
+func (g *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !g.wroteHeader {
+		if g.Header().Get("Content-Type") == "" {
+			g.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		g.WriteHeader(http.StatusOK)
+	}
+	if g.gz != nil {
+		return g.gz.Write(p)
+	}
+	return g.ResponseWriter.Write(p)
+}
+
+func (g *gzipResponseWriter) Flush() {
+	if g.gz != nil {
+		_ = g.gz.Flush()
+	}
+	if f, ok := g.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (g *gzipResponseWriter) finish() {
+	if g.gz != nil {
+		_ = g.gz.Close()
+	}
+}
+
+// 요청 본문 gzip 압축 (피어가 지원을 알린 경우만, peerTransport 에서 호출)
+func compressRequest(req *http.Request) *http.Request {
+	if req.GetBody == nil || req.ContentLength < CompressMinBytes || req.Header.Get("Content-Encoding") != "" {
+		return req
+	}
+	if v, _ := peerAcceptsGzip.Load(req.URL.Host); v != true {
+		return req
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return req
+	}
+	defer body.Close()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.Copy(zw, body); err != nil || zw.Close() != nil {
+		return req
+	}
+	data := buf.Bytes()
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(data))
+	out.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(data)), nil }
+	out.ContentLength = int64(len(data))
+	out.Header.Set("Content-Encoding", "gzip")
+	return out
+}
+
+// 응답의 Accept-Encoding 으로 피어의 gzip 요청 본문 지원 여부 기록
+func notePeerEncoding(host string, resp *http.Response) {
+	peerAcceptsGzip.Store(host, strings.Contains(resp.Header.Get("Accept-Encoding"), "gzip"))
+}
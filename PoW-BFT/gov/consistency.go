@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Cross-chain Consistency Checker (앵커 루트 ↔ Hos 블록 정합성 점검)
+// ------------------------------------------------------------
+// - CONSISTENCY_CHECK=true 일 때 ConsistencyCheckInterval 마다 실행
+// - 앵커 이력(ahist_, anchorhistory.go)에서 ConsistencyCheckSample 건을 무작위 표본 추출
+// - 각 앵커의 Hos 부트노드에 GET /block/root?value=<root> 로 해당 블록을 받아 머클 루트 재계산
+//   · 본문이 있으면 레코드마다 leaf 해시를 다시 계산해 leaf_hashes 와 대조
+//   · 본문이 정리된(pruned) 블록은 leaf_hashes 로만 루트 재계산
+//   · 재계산 루트가 앵커 루트 / 블록의 merkle_root 와 다르거나, 블록을 찾을 수 없으면 불일치
+//     (Hos 장부 손상 또는 앵커 이후 Hos 체인 분기)
+// - 불일치 시 chain_consistency_mismatches_total 증가, consistency_mismatch 이벤트 발행, 경고 로그
+// - Hos 에 접속할 수 없는 경우는 불일치가 아니라 건너뜀 (chain_consistency_checks_total{result="skipped"})
+// - 서브 장부 앵커(hos_id 에 '@')는 Hos 메인 체인 조회 대상이 아니므로 제외
+////////////////////////////////////////////////////////////////////////////////
+
+var (
+	ConsistencyCheckEnabled  = false // CONSISTENCY_CHECK
+	ConsistencyCheckInterval = 600   // 초 (CONSISTENCY_CHECK_INTERVAL)
+	ConsistencyCheckSample   = 5     // 주기당 점검할 앵커 수 (CONSISTENCY_CHECK_SAMPLE)
+)
+
+// /block/root?value= 응답 중 필요한 부분 (레코드는 원문 그대로 leaf 해시 계산)
+type lowerBlockBody struct {
+	Index      int               `json:"index"`
+	HosID      string            `json:"hos_id"`
+	MerkleRoot string            `json:"merkle_root"`
+	Entries    []json.RawMessage `json:"entries"`
+	LeafHashes []string          `json:"leaf_hashes"`
+	Pruned     bool              `json:"pruned"`
+}
+
+// 점검 결과 1건
+type ConsistencyResult struct {
+	HosID           string `json:"hos_id"`
+	Root            string `json:"root"`
+	UpperBlockIndex int    `json:"upper_block_index"`
+	LowerBlockIndex int    `json:"lower_block_index,omitempty"`
+	Result          string `json:"result"` // ok | mismatch | skipped
+	Reason          string `json:"reason,omitempty"`
+}
+
+// 점검 루틴 (main 에서 실행)
+func startConsistencyChecker() {
+	if !ConsistencyCheckEnabled || ConsistencyCheckInterval <= 0 {
+		return
+	}
+	log.Printf("[CONSISTENCY] checker enabled (every %ds, sample=%d)", ConsistencyCheckInterval, ConsistencyCheckSample)
+	t := time.NewTicker(time.Duration(ConsistencyCheckInterval) * time.Second)
+	defer t.Stop()
+	for range t.C {
+		for _, res := range checkConsistency(sampleAnchors(ConsistencyCheckSample)) {
+			if res.Result == "mismatch" {
+				log.Printf("[CONSISTENCY][ALERT] hos=%s root=%s upper=#%d: %s", res.HosID, res.Root, res.UpperBlockIndex, res.Reason)
+			}
+		}
+	}
+}
+
+// 앵커 이력에서 n 건 무작위 추출 (reservoir sampling)
+func sampleAnchors(n int) []AnchorHistoryEntry {
+	out := make([]AnchorHistoryEntry, 0, n)
+	seen := 0
+	iter := db.NewIterator(util.BytesPrefix([]byte(anchorHistoryPrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var e AnchorHistoryEntry
+		if err := json.Unmarshal(iter.Value(), &e); err != nil || strings.Contains(e.HosID, "@") {
+			continue
+		}
+		seen++
+		if len(out) < n {
+			out = append(out, e)
+		} else if j := rand.IntN(seen); j < n {
+			out[j] = e
+		}
+	}
+	return out
+}
+
+// 표본 앵커들을 Hos 블록과 대조
+func checkConsistency(sample []AnchorHistoryEntry) []ConsistencyResult {
+	out := make([]ConsistencyResult, 0, len(sample))
+	for _, e := range sample {
+		res := checkAnchorConsistency(e)
+		incCounter("chain_consistency_checks_total", `result="`+res.Result+`"`)
+		if res.Result == "mismatch" {
+			incCounter("chain_consistency_mismatches_total", `hos_id="`+res.HosID+`"`)
+			publishEvent(EventConsistencyMismatch, res)
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+// 앵커 1건 점검
+func checkAnchorConsistency(e AnchorHistoryEntry) ConsistencyResult {
+	res := ConsistencyResult{HosID: e.HosID, Root: e.Root, UpperBlockIndex: e.UpperBlockIndex, Result: "ok"}
+	skip := func(reason string) ConsistencyResult {
+		res.Result, res.Reason = "skipped", reason
+		return res
+	}
+	mismatch := func(reason string) ConsistencyResult {
+		res.Result, res.Reason = "mismatch", reason
+		return res
+	}
+
+	addr := getHosBootAddr(e.HosID)
+	if addr == "" {
+		return skip("hos boot address unknown")
+	}
+	resp, err := nodeClient.Get(nodeURL(addr, "/block/root?value="+url.QueryEscape(e.Root)))
+	if err != nil {
+		return skip(err.Error())
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return mismatch("anchored root not found on hos chain")
+	case resp.StatusCode != http.StatusOK:
+		return skip(fmt.Sprintf("hos status %d", resp.StatusCode))
+	}
+	var blk lowerBlockBody
+	if err := json.NewDecoder(resp.Body).Decode(&blk); err != nil {
+		return skip("decode block: " + err.Error())
+	}
+	if blk.MerkleRoot == "" {
+		return skip("hos does not support root lookup") // value 를 무시하고 최신 루트만 주는 이전 Hos
+	}
+	res.LowerBlockIndex = blk.Index
+
+	leaves := blk.LeafHashes
+	if !blk.Pruned {
+		leaves = make([]string, len(blk.Entries))
+		for i, raw := range blk.Entries {
+			leaves[i] = recordLeafHash(raw)
+		}
+		if !slices.Equal(leaves, blk.LeafHashes) {
+			return mismatch(fmt.Sprintf("leaf_hashes of hos block #%d do not match its entries", blk.Index))
+		}
+	}
+	switch root := merkle.Root(leaves); {
+	case blk.HosID != e.HosID:
+		return mismatch(fmt.Sprintf("hos block #%d belongs to %s", blk.Index, blk.HosID))
+	case root != blk.MerkleRoot:
+		return mismatch(fmt.Sprintf("recomputed root %s != merkle_root of hos block #%d", root, blk.Index))
+	case root != e.Root:
+		return mismatch(fmt.Sprintf("recomputed root %s != anchored root", root))
+	}
+	return res
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Contract (계약 메타데이터 등록/검색)
+// ------------------------------------------------------------
+// - 부트노드에 등록된 계약(contract_<hosID>)은 이후 해당 Hos의 앵커에 ContractSnapshot으로 포함됨
+// - 블록 반영 시 장부에 기록된 계약 스냅샷으로 보조 인덱스를 구성
+//   · "ctr_latest_<hosID>"              => "bi:ei" (해당 Hos의 최신 앵커 위치)
+//   · "ctr_region_<region>_<hosID>"     => "bi:ei"
+//   · "ctr_exp_<expiry(UTC)>_<hosID>"   => "bi:ei" (만료 시각 순 범위 조회용)
+// - 인덱스는 후보 선정에만 사용하고, 최종 판정은 최신 앵커의 계약 스냅샷으로 다시 확인
+//   (계약이 갱신되어 남은 과거 인덱스 키는 자연스럽게 걸러짐)
+// - 계약 기반 앵커 수락 정책 (CONTRACT_POLICY, 기본 true)
+//   · 유효 계약 = 부트노드 등록 계약(contract_<hosID>), 없으면 장부의 최신 계약 스냅샷
+//     (부트노드 재선출 후에도 장부 기준으로 판정 가능)
+//   · 계약 없음/해지(revoked_contract_<hosID>) => 거절
+//   · expiry_ts 경과 => 거절
+//   · 서브 장부 앵커("<hosID>@<region>")는 regions 에 포함된 리전만 허용 (regions 미지정 시 전체)
+//   · allowed_clinic_ids 지정 시 앵커 블록의 clinic_id 가 모두 목록에 있어야 함
+// - 관리 API : GET/POST/DELETE /contracts
+////////////////////////////////////////////////////////////////////////////////
+
+// Hos 아카이브 매니페스트 앵커("<hosID>@archive")는 리전 검사 대상이 아님
+const archiveLedger = "archive"
+
+var contractPolicy = true // 계약 기반 앵커 수락 정책 (CONTRACT_POLICY)
+
+// 계약 검색 결과 (계약 + 최신 앵커 + 앵커를 봉인한 블록 정보)
+type ContractMatch struct {
+	HosID      string       `json:"hos_id"`
+	Contract   ContractData `json:"contract"`
+	Anchor     AnchorRecord `json:"anchor"`
+	BlockIndex int          `json:"block_index"`
+	BlockHash  string       `json:"block_hash"`
+	BlockRoot  string       `json:"block_root"`
+	BlockTs    string       `json:"block_ts"`
+}
+
+// 만료 시각을 UTC RFC3339로 정규화 (사전순 = 시간순)
+func normalizeExpiry(ts string) (string, bool) {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return "", false
+	}
+	return t.UTC().Format(time.RFC3339), true
+}
+
+// 앵커 레코드의 계약 스냅샷으로 보조 인덱스 등록
+func updateContractIndices(tx *ledgerTxn, ptr []byte, rec AnchorRecord) {
+	if rec.HosID == "" {
+		return
+	}
+	tx.Put([]byte("ctr_latest_"+rec.HosID), ptr)
+	c := rec.ContractSnapshot
+	for _, rg := range c.Regions {
+		rg = strings.ToLower(strings.TrimSpace(rg))
+		if rg == "" {
+			continue
+		}
+		tx.Put([]byte(fmt.Sprintf("ctr_region_%s_%s", rg, rec.HosID)), ptr)
+	}
+	if exp, ok := normalizeExpiry(c.ExpiryTimestamp); ok {
+		tx.Put([]byte(fmt.Sprintf("ctr_exp_%s_%s", exp, rec.HosID)), ptr)
+	}
+}
+
+// 부트노드에 등록된 계약 조회 (앵커 스냅샷 구성용)
+func getRegisteredContract(hosID string) ContractData {
+	data, err := db.Get([]byte("contract_"+hosID), nil)
+	if err != nil {
+		return ContractData{}
+	}
+	var c ContractData
+	if err := json.Unmarshal(data, &c); err != nil {
+		return ContractData{}
+	}
+	return c
+}
+
+func contractRevoked(hosID string) bool {
+	_, ok := getMeta("revoked_contract_" + hosID)
+	return ok
+}
+
+// 앵커 수락 판정에 쓰는 유효 계약 (등록 계약 우선, 없으면 장부의 최신 계약 스냅샷)
+func effectiveContract(hosID string) (ContractData, string, bool) {
+	if contractRevoked(hosID) {
+		return ContractData{}, "", false
+	}
+	if c := getRegisteredContract(hosID); c.HosID != "" {
+		return c, "registered", true
+	}
+	if m, ok := getLatestContractMatch(hosID); ok && m.Contract.HosID != "" {
+		return m.Contract, "ledger", true
+	}
+	return ContractData{}, "", false
+}
+
+// 앵커 제출 기관의 계약 상태 검사 (위반 시 사유 반환)
+func checkContractPolicy(hosID string, clinicIDs []string) error {
+	org := orgOf(hosID)
+	c, _, ok := effectiveContract(org)
+	if !ok {
+		return fmt.Errorf("no active contract for %s", org)
+	}
+	if exp, ok := normalizeExpiry(c.ExpiryTimestamp); ok && exp <= time.Now().UTC().Format(time.RFC3339) {
+		return fmt.Errorf("contract for %s expired at %s", org, exp)
+	}
+	if sub := strings.TrimPrefix(hosID, org+"@"); sub != hosID && sub != archiveLedger && len(c.Regions) > 0 {
+		if !containsRegion(c.Regions, strings.ToLower(sub)) {
+			return fmt.Errorf("region %s not allowed by contract (regions=%v)", sub, c.Regions)
+		}
+	}
+	if len(c.AllowedClinicIDs) > 0 {
+		allowed := make(map[string]bool, len(c.AllowedClinicIDs))
+		for _, id := range c.AllowedClinicIDs {
+			allowed[id] = true
+		}
+		for _, id := range clinicIDs {
+			if !allowed[id] {
+				return fmt.Errorf("clinic_id %s not allowed by contract", id)
+			}
+		}
+	}
+	return nil
+}
+
+// Hos별 최신 앵커와 해당 블록 조회
+func getLatestContractMatch(hosID string) (ContractMatch, bool) {
+	v, ok := getMeta("ctr_latest_" + hosID)
+	if !ok {
+		return ContractMatch{}, false
+	}
+	bi, ei, ok := parsePtr(v)
+	if !ok {
+		return ContractMatch{}, false
+	}
+	blk, err := getBlockByIndex(bi)
+	if err != nil || ei < 0 || ei >= len(blk.Records) {
+		return ContractMatch{}, false
+	}
+	rec := blk.Records[ei]
+	return ContractMatch{
+		HosID:      hosID,
+		Contract:   rec.ContractSnapshot,
+		Anchor:     rec,
+		BlockIndex: blk.Index,
+		BlockHash:  blk.BlockHash,
+		BlockRoot:  blk.MerkleRoot,
+		BlockTs:    blk.Timestamp,
+	}, true
+}
+
+// 인덱스 범위에서 후보 Hos ID 수집 (hosID에 "_"가 포함될 수 있으므로 포인터가 가리키는 앵커에서 읽음)
+func collectContractCandidates(r *util.Range, out map[string]bool) {
+	iter := db.NewIterator(r, nil)
+	defer iter.Release()
+	for iter.Next() {
+		bi, ei, ok := parsePtr(string(iter.Value()))
+		if !ok {
+			continue
+		}
+		blk, err := getBlockByIndex(bi)
+		if err != nil || ei < 0 || ei >= len(blk.Records) {
+			continue
+		}
+		out[blk.Records[ei].HosID] = true
+	}
+}
+
+// 지역/만료 조건으로 계약 검색
+func searchContracts(region, expiringBefore string) ([]ContractMatch, error) {
+	region = strings.ToLower(strings.TrimSpace(region))
+	var before string
+	if expiringBefore != "" {
+		b, ok := normalizeExpiry(expiringBefore)
+		if !ok {
+			return nil, fmt.Errorf("expiring_before must be RFC3339")
+		}
+		before = b
+	}
+
+	// 1) 인덱스로 후보 선정 (조건이 둘 다 있으면 범위가 좁은 지역 인덱스 사용)
+	candidates := map[string]bool{}
+	switch {
+	case region != "":
+		collectContractCandidates(util.BytesPrefix([]byte("ctr_region_"+region+"_")), candidates)
+	case before != "":
+		collectContractCandidates(&util.Range{Start: []byte("ctr_exp_"), Limit: []byte("ctr_exp_" + before)}, candidates)
+	default:
+		collectContractCandidates(util.BytesPrefix([]byte("ctr_latest_")), candidates)
+	}
+
+	// 2) 최신 앵커의 계약 스냅샷으로 조건 재확인
+	out := []ContractMatch{}
+	for hosID := range candidates {
+		m, ok := getLatestContractMatch(hosID)
+		if !ok {
+			continue
+		}
+		if region != "" && !containsRegion(m.Contract.Regions, region) {
+			continue
+		}
+		if before != "" {
+			exp, ok := normalizeExpiry(m.Contract.ExpiryTimestamp)
+			if !ok || exp >= before {
+				continue
+			}
+		}
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].HosID < out[j].HosID })
+	return out, nil
+}
+
+func containsRegion(regions []string, region string) bool {
+	for _, rg := range regions {
+		if strings.ToLower(strings.TrimSpace(rg)) == region {
+			return true
+		}
+	}
+	return false
+}
+
+// 계약 관리
+//   - GET    /contracts[?hos_id=] : 등록 계약 목록 또는 기관의 유효 계약 조회
+//   - POST   /contracts {ContractData} : 계약 등록/갱신
+//   - DELETE /contracts?hos_id= : 계약 해지 (이후 해당 기관 앵커 거절)
+func handleContracts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		handleGetContracts(w, r)
+	case http.MethodPost:
+		handleRegisterContract(w, r)
+	case http.MethodDelete:
+		handleRevokeContract(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// GET /contracts[?hos_id=]
+func handleGetContracts(w http.ResponseWriter, r *http.Request) {
+	if hosID := r.URL.Query().Get("hos_id"); hosID != "" {
+		c, source, ok := effectiveContract(hosID)
+		if !ok {
+			status := "unregistered"
+			if contractRevoked(hosID) {
+				status = "revoked"
+			}
+			writeErrorDetail(w, http.StatusNotFound, "contract_"+status, "no active contract for "+hosID, map[string]any{"hos_id": hosID, "status": status})
+			return
+		}
+		out := map[string]any{
+			"hos_id":   hosID,
+			"status":   "active",
+			"source":   source,
+			"contract": c,
+		}
+		if err := checkContractPolicy(hosID, nil); err != nil {
+			out["status"] = "rejected" // 앵커가 거절되는 상태 (만료 등)
+			out["reason"] = err.Error()
+		}
+		writeJSON(w, http.StatusOK, out)
+		return
+	}
+	out := []ContractData{}
+	iter := db.NewIterator(util.BytesPrefix([]byte("contract_")), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var c ContractData
+		if err := json.Unmarshal(iter.Value(), &c); err == nil {
+			out = append(out, c)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// DELETE /contracts?hos_id= (부트노드 전용)
+func handleRevokeContract(w http.ResponseWriter, r *http.Request) {
+	if !isBoot.Load() {
+		writeError(w, http.StatusForbidden, "only boot node can revoke contracts")
+		return
+	}
+	hosID := r.URL.Query().Get("hos_id")
+	if hosID == "" {
+		writeError(w, http.StatusBadRequest, "hos_id required")
+		return
+	}
+	ts := time.Now().UTC().Format(time.RFC3339)
+	if err := putMeta("revoked_contract_"+hosID, ts); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to revoke contract")
+		return
+	}
+	_ = db.Delete([]byte("contract_"+hosID), nil)
+	log.Printf("[CONTRACT] Revoked contract for %s", hosID)
+	writeJSON(w, http.StatusOK, map[string]string{"hos_id": hosID, "status": "revoked", "revoked_at": ts})
+}
+
+// 계약 등록 (부트노드 전용, 다음 앵커부터 스냅샷으로 장부에 기록)
+// POST /contracts {ContractData}
+func handleRegisterContract(w http.ResponseWriter, r *http.Request) {
+	if !isBoot.Load() {
+		writeError(w, http.StatusForbidden, "only boot node can register contracts")
+		return
+	}
+	var c ContractData
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+	if c.HosID == "" {
+		writeError(w, http.StatusBadRequest, "hos_id required")
+		return
+	}
+	if c.ExpiryTimestamp != "" {
+		if _, ok := normalizeExpiry(c.ExpiryTimestamp); !ok {
+			writeError(w, http.StatusBadRequest, "expiry_ts must be RFC3339")
+			return
+		}
+	}
+	if rt := c.Retention; rt != nil && (rt.MaxAgeDays <= 0 || (rt.Action != "archive" && rt.Action != "restrict")) {
+		writeError(w, http.StatusBadRequest, "retention requires max_age_days > 0 and action archive|restrict")
+		return
+	}
+	data, _ := json.Marshal(c)
+	if err := db.Put([]byte("contract_"+c.HosID), data, nil); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to save contract")
+		return
+	}
+	_ = db.Delete([]byte("revoked_contract_"+c.HosID), nil) // 재등록 시 해지 해제
+	log.Printf("[CONTRACT] Registered contract for %s (regions=%v, expiry=%s)", c.HosID, c.Regions, c.ExpiryTimestamp)
+	writeJSON(w, http.StatusOK, c)
+}
+
+// 계약 검색
+// GET /contracts/search?region=<region>&expiring_before=<RFC3339>
+func handleSearchContracts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	q := r.URL.Query()
+	out, err := searchContracts(q.Get("region"), q.Get("expiring_before"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
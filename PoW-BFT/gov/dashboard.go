@@ -0,0 +1,31 @@
+package main
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Dashboard (내장 실시간 대시보드)
+// ------------------------------------------------------------
+// - GET /dashboard : static/dashboard.html 을 바이너리에 포함해 제공 (./static 없이 배포해도 동작)
+// - Hos 노드와 같은 HTML, /v1/meta 의 node_role 이 gov 면 Gov 패널로 구성
+//   · /status        : 높이, 부트노드, 난이도, 채굴 중 여부 (PBFT 단계 대신 표시)
+//   · /blocks/recent, /pending (메모리풀 앵커), /peers?detail=true
+//   · /anchors/latest : Hos 별 최신 앵커와 Hos 부트노드 (anchorhistory.go)
+//   · /events (SSE)   : 블록 확정/앵커 수락·거부/부트노드/피어 이벤트마다 패널 갱신 + 이벤트 로그
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed static/dashboard.html
+var dashboardHTML []byte
+
+// GET /dashboard
+func handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(dashboardHTML)
+}
@@ -1,5 +1,7 @@
 package main
 
+import "encoding/json"
+
 ////////////////////////////////////////////////////////////////////////////////
 // Data Models (데이터 스키마)
 //
@@ -25,6 +27,8 @@ type ClinicRecord struct {
 	PrescCode string                 `json:"presc_code"`           // 처방 코드
 	ClinicHis map[string]interface{} `json:"clinic_his,omitempty"` // 진료 기록
 	Timestamp string                 `json:"timestamp"`            // 생성 시각
+	Sealed    json.RawMessage        `json:"sealed,omitempty"`     // Hos 가 암호화한 Info/ClinicHis (Gov 는 복호화하지 않음)
+	Salt      string                 `json:"salt,omitempty"`       // 필드별 머클 leaf 용 레코드 salt (Hos disclosure.go)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -60,11 +64,18 @@ type LowerBlock struct {
 ////////////////////////////////////////////////////////////////////////////////
 
 type ContractData struct {
-	HosID            string            `json:"hos_id"`             // Hos 식별자
-	ExpiryTimestamp  string            `json:"expiry_ts"`          // 계약 만료 시각
-	Regions          []string          `json:"regions,omitempty"`  // 서비스 허용 지역
-	AllowedClinicIDs []string          `json:"allowed_clinic_ids"` // 허용된 진료 정보 ID 목록
-	Meta             map[string]string `json:"meta,omitempty"`     // 추가적인 계약 정보 (버전, 조건 등)
+	HosID            string            `json:"hos_id"`              // Hos 식별자
+	ExpiryTimestamp  string            `json:"expiry_ts"`           // 계약 만료 시각
+	Regions          []string          `json:"regions,omitempty"`   // 서비스 허용 지역
+	AllowedClinicIDs []string          `json:"allowed_clinic_ids"`  // 허용된 진료 정보 ID 목록
+	Meta             map[string]string `json:"meta,omitempty"`      // 추가적인 계약 정보 (버전, 조건 등)
+	Retention        *RetentionRule    `json:"retention,omitempty"` // 레코드 보존 규칙 (retention.go)
+}
+
+// 레코드 보존 규칙 : 생성 후 MaxAgeDays 가 지난 레코드를 Action 으로 처리
+type RetentionRule struct {
+	MaxAgeDays int    `json:"max_age_days"` // 보존 일수 (X년 = 365*X)
+	Action     string `json:"action"`       // archive | restrict
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -76,6 +87,7 @@ type ContractData struct {
 // - LowerRoot: Hos 체인에서 전달된 서명된 Merkle Root
 // - AccessCatalog: 접근 가능한 진료 정보 목록
 // - AnchorTimestamp: 앵커가 제출된 시각
+// - Kind/Application/Vote: 기관 가입 신청 및 투표 기록 (onboarding.go)
 ////////////////////////////////////////////////////////////////////////////////
 
 type AnchorRecord struct {
@@ -84,4 +96,12 @@ type AnchorRecord struct {
 	LowerRoot        string       `json:"lower_root"`        // Hos 체인에서 전달된 머클 루트 (서명 포함)
 	AccessCatalog    []string     `json:"access_catalog"`    // 접근 가능한 진료 정보 리스트
 	AnchorTimestamp  string       `json:"anchor_ts"`         // 앵커가 제출된 시간
+
+	// 앵커 외 장부 기록 (기관 가입 절차, 키 교체, 조회 감사), 일반 앵커는 Kind 가 비어 있음
+	// - LowerRoot 에는 신청서/투표의 다이제스트가 들어가 블록 머클루트에 포함됨
+	Kind        string                 `json:"kind,omitempty"`         // "onboard_apply" | "onboard_vote" | "hos_key_rotation" | "query_audit"
+	Application *OnboardingApplication `json:"application,omitempty"`  // 가입 신청서
+	Vote        *OnboardingVote        `json:"vote,omitempty"`         // 검증자 투표
+	KeyRotation *HosKeyRotation        `json:"key_rotation,omitempty"` // Hos 노드 키 교체 (hoskeys.go)
+	QueryAudit  *AuditRecord           `json:"query_audit,omitempty"`  // Gov 중계 조회 감사 기록 (queryaudit.go)
 }
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 난이도 규칙 (온체인)
+// ------------------------------------------------------------
+// - 블록 N 의 난이도는 직전 window 개 구간의 블록 타임스탬프만으로 결정
+//   · 평균 간격 = (ts[N-1] - ts[N-1-window]) / window
+//   · 평균 간격 / target_block_time < raise_ratio 이면 +1, > lower_ratio 이면 -1 (min_difficulty ~ max_difficulty)
+//   · 구간이 제네시스에 걸치면 (고정 타임스탬프) 직전 난이도 유지
+// - 조정 파라미터(DifficultyParams)는 체인별 값 (제네시스 생성 시 DIFF_* 환경변수로 정하고 meta_difficulty_params 에 기록)
+//   · DIFF_TARGET_TIME(초) / DIFF_WINDOW(블록) / DIFF_MIN / DIFF_MAX / DIFF_RAISE_RATIO / DIFF_LOWER_RATIO
+//   · 기본값이 아니면 제네시스 블록에 difficulty_params 로 싣고 해시(params_hash)를 PoW 헤더에 묶음
+//     => 규칙이 다른 노드는 제네시스 해시부터 달라짐 (기본 규칙이면 생략되어 기존 제네시스 해시 그대로)
+//   · 재시작 시 환경변수가 아니라 제네시스(없으면 meta)에 기록된 값을 사용 (값이 바뀌면 기존 블록 난이도 검증이 달라지므로)
+//   · 기록이 없는 기존 DB 는 이전 빌드의 고정값 (20초, 3블록, 1~7, 0.85/1.25)
+//   · /chain/info 의 difficulty_params 가 다른 피어는 다른 체인으로 취급 (chaininfo.go sameChain)
+//   · 현재 조정 상태(평균 간격, 비율, 다음 난이도)는 /status 의 retarget
+// - 채굴 노드(mineBlock)와 수신 노드(onBlockReceived, validateUpperBlock)가 같은 함수로 계산
+//   · 승자 노드가 전파하는 난이도 값은 사용하지 않음 (노드 간 난이도 불일치 방지)
+//   · 규칙과 다른 난이도의 블록은 거부
+// - 타임스탬프 조작 제한 (난이도가 타임스탬프로 결정되므로)
+//   · median-time-past : 직전 MedianTimeWindow 개 블록 타임스탬프의 중앙값보다 이르면 거부
+//     (직전 블록 하나가 아닌 중앙값 기준이므로 노드 간 시계 오차로 정상 블록이 거부되지 않음)
+//   · 로컬 시각보다 MaxBlockFutureDrift 이상 앞서면 거부
+//   · 채굴 노드는 max(현재 시각, median-time-past) 를 타임스탬프로 사용
+// - Elapsed 는 채굴 노드가 보고한 값을 쓰지 않고 직전 블록과의 타임스탬프 간격으로 계산 (blockInterval)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	MedianTimeWindow    = 11 // median-time-past 계산에 쓰는 직전 블록 수
+	MaxBlockFutureDrift = 2 * time.Minute
+	MaxDifficultyLimit  = 64 // 난이도 상한의 최대값 (SHA-256 hex 자릿수)
+)
+
+const diffParamsMetaKey = "meta_difficulty_params"
+
+// 체인별 난이도 조정 파라미터
+type DifficultyParams struct {
+	TargetBlockTime int     `json:"target_block_time"` // 목표 블록 간격(초)
+	Window          int     `json:"window"`            // 평균 간격을 재는 블록 간격 수
+	MinDifficulty   int     `json:"min_difficulty"`
+	MaxDifficulty   int     `json:"max_difficulty"`
+	RaiseRatio      float64 `json:"raise_ratio"` // 평균 간격 / 목표 간격이 이보다 작으면 난이도 +1
+	LowerRatio      float64 `json:"lower_ratio"` // 이보다 크면 난이도 -1
+}
+
+// 난이도 조정 상태 (GET /status 의 retarget)
+type RetargetState struct {
+	Params       DifficultyParams `json:"params"`
+	Height       int              `json:"height"`          // 기준 블록 (로컬 최신 블록)
+	Difficulty   int              `json:"difficulty"`      // 기준 블록 난이도
+	Next         int              `json:"next_difficulty"` // 다음 블록 난이도
+	AvgBlockTime float64          `json:"avg_block_time"`  // 직전 window 개 구간 평균 간격(초), warmup 이면 0
+	Ratio        float64          `json:"ratio"`           // 평균 간격 / 목표 간격
+	Adjustment   string           `json:"adjustment"`      // raise | lower | hold | warmup (구간이 제네시스에 걸침)
+}
+
+// 이전 빌드의 고정 규칙 (기록이 없는 기존 체인)
+var defaultDifficultyParams = DifficultyParams{TargetBlockTime: 20, Window: 3, MinDifficulty: 1, MaxDifficulty: 7, RaiseRatio: 0.85, LowerRatio: 1.25}
+
+var (
+	DifficultyConfig = defaultDifficultyParams // 새 체인의 난이도 규칙 (DIFF_*, loadDifficultyConfig)
+	diffParams       = defaultDifficultyParams // 이 체인에 기록된 규칙 (initDifficultyParams)
+)
+
+func (p DifficultyParams) validate() error {
+	switch {
+	case p.TargetBlockTime < 1:
+		return fmt.Errorf("target_block_time must be >= 1: %d", p.TargetBlockTime)
+	case p.Window < 1:
+		return fmt.Errorf("window must be >= 1: %d", p.Window)
+	case p.MinDifficulty < 1 || p.MaxDifficulty > MaxDifficultyLimit || p.MinDifficulty > p.MaxDifficulty:
+		return fmt.Errorf("difficulty range must be within 1..%d: %d..%d", MaxDifficultyLimit, p.MinDifficulty, p.MaxDifficulty)
+	case GenesisDifficulty < p.MinDifficulty || GenesisDifficulty > p.MaxDifficulty:
+		return fmt.Errorf("difficulty range %d..%d must include genesis difficulty %d", p.MinDifficulty, p.MaxDifficulty, GenesisDifficulty)
+	case p.RaiseRatio <= 0 || p.RaiseRatio >= p.LowerRatio:
+		return fmt.Errorf("ratios must satisfy 0 < raise_ratio < lower_ratio: %g, %g", p.RaiseRatio, p.LowerRatio)
+	}
+	return nil
+}
+
+// 새 체인의 난이도 규칙 (DIFF_* 환경변수, 없으면 기본값) : main 에서 호출
+func loadDifficultyConfig() error {
+	c := defaultDifficultyParams
+	for env, dst := range map[string]*int{
+		"DIFF_TARGET_TIME": &c.TargetBlockTime,
+		"DIFF_WINDOW":      &c.Window,
+		"DIFF_MIN":         &c.MinDifficulty,
+		"DIFF_MAX":         &c.MaxDifficulty,
+	} {
+		if v := os.Getenv(env); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", env, err)
+			}
+			*dst = n
+		}
+	}
+	for env, dst := range map[string]*float64{
+		"DIFF_RAISE_RATIO": &c.RaiseRatio,
+		"DIFF_LOWER_RATIO": &c.LowerRatio,
+	} {
+		if v := os.Getenv(env); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("%s: %w", env, err)
+			}
+			*dst = f
+		}
+	}
+	if err := c.validate(); err != nil {
+		return err
+	}
+	DifficultyConfig = c
+	return nil
+}
+
+// 규칙 해시 (제네시스 PoW 헤더의 params_hash, nil 이면 "")
+func (p *DifficultyParams) hash() string {
+	if p == nil {
+		return ""
+	}
+	return sha256Hex(jsonCanonical(*p))
+}
+
+// 제네시스에 기록할 규칙 (기본 규칙이면 nil => 기존 제네시스와 같은 해시)
+func genesisDifficultyParams() *DifficultyParams {
+	if diffParams == defaultDifficultyParams {
+		return nil
+	}
+	p := diffParams
+	return &p
+}
+
+// 체인의 난이도 규칙 결정 (newUpperChain 에서 제네시스 채굴 전에 호출)
+//   - 제네시스에 기록된 규칙이 있으면 그대로 사용
+//   - 없으면 meta 에 기록된 값, 그것도 없으면 기존 DB(제네시스 존재)는 이전 고정값, 새 체인은 configured
+func initDifficultyParams(configured DifficultyParams, genesis *UpperBlock) error {
+	raw, recorded := getMeta(diffParamsMetaKey)
+	var p DifficultyParams
+	switch {
+	case genesis != nil && genesis.DifficultyParams != nil:
+		p = *genesis.DifficultyParams
+		if recorded && raw != "" {
+			var m DifficultyParams
+			if json.Unmarshal([]byte(raw), &m) != nil || m != p {
+				recorded = false // 제네시스 기준으로 meta 다시 기록
+			}
+		}
+	case recorded && raw != "":
+		if err := json.Unmarshal([]byte(raw), &p); err != nil {
+			return fmt.Errorf("recorded %s: %w", diffParamsMetaKey, err)
+		}
+	case genesis != nil:
+		p = defaultDifficultyParams
+	default:
+		p = configured
+	}
+	if err := p.validate(); err != nil {
+		return err
+	}
+	if configured != p && configured != defaultDifficultyParams {
+		log.Printf("[PoW][WARN] DIFF_* settings ignored: chain uses %+v", p)
+	}
+	diffParams = p
+	if !recorded {
+		data, _ := json.Marshal(p)
+		if err := putMeta(diffParamsMetaKey, string(data)); err != nil {
+			return err
+		}
+	}
+	log.Printf("[PoW] difficulty params: target=%ds window=%d range=%d..%d ratios=%g/%g",
+		p.TargetBlockTime, p.Window, p.MinDifficulty, p.MaxDifficulty, p.RaiseRatio, p.LowerRatio)
+	return nil
+}
+
+// 블록 번호로 조상 블록 조회 (로컬 장부 또는 수신 중인 분기)
+type blockLookup func(index int) (UpperBlock, error)
+
+// prev 다음 블록이 가져야 할 난이도
+func nextDifficulty(prev UpperBlock, lookup blockLookup) (int, error) {
+	st, err := retarget(prev, lookup)
+	return st.Next, err
+}
+
+// prev 기준 난이도 조정 계산 (체인에 기록된 diffParams 사용)
+func retarget(prev UpperBlock, lookup blockLookup) (RetargetState, error) {
+	p := diffParams
+	st := RetargetState{Params: p, Height: prev.Index, Difficulty: prev.Difficulty, Next: prev.Difficulty, Adjustment: "warmup"}
+	if prev.Index <= p.Window {
+		return st, nil
+	}
+	first, err := lookup(prev.Index - p.Window)
+	if err != nil {
+		return st, fmt.Errorf("load block #%d for difficulty: %w", prev.Index-p.Window, err)
+	}
+	t0, err := time.Parse(time.RFC3339, first.Timestamp)
+	if err != nil {
+		return st, fmt.Errorf("block #%d timestamp: %w", first.Index, err)
+	}
+	t1, err := time.Parse(time.RFC3339, prev.Timestamp)
+	if err != nil {
+		return st, fmt.Errorf("block #%d timestamp: %w", prev.Index, err)
+	}
+	st.AvgBlockTime = t1.Sub(t0).Seconds() / float64(p.Window)
+	st.Ratio = st.AvgBlockTime / float64(p.TargetBlockTime)
+
+	d := prev.Difficulty
+	if st.Ratio < p.RaiseRatio { // 너무 빨리 생성되면 난이도 올림
+		d++
+	} else if st.Ratio > p.LowerRatio { // 너무 오래 걸렸다면 난이도 낮춤
+		d--
+	}
+	st.Next = min(max(d, p.MinDifficulty), p.MaxDifficulty)
+	switch {
+	case st.Next > prev.Difficulty:
+		st.Adjustment = "raise"
+	case st.Next < prev.Difficulty:
+		st.Adjustment = "lower"
+	default:
+		st.Adjustment = "hold"
+	}
+	return st, nil
+}
+
+// 블록 타임스탬프와 난이도가 규칙에 맞는지 검사
+func checkDifficultyRule(newBlk, prevBlk UpperBlock, lookup blockLookup) error {
+	ts, err := time.Parse(time.RFC3339, newBlk.Timestamp)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", newBlk.Timestamp)
+	}
+	mtp, err := medianTimePast(prevBlk, lookup)
+	if err != nil {
+		return err
+	}
+	if ts.Before(mtp) {
+		return fmt.Errorf("timestamp %s before median time past %s", newBlk.Timestamp, mtp.Format(time.RFC3339))
+	}
+	if ts.After(time.Now().Add(MaxBlockFutureDrift)) {
+		return fmt.Errorf("timestamp %s too far in the future", newBlk.Timestamp)
+	}
+	want, err := nextDifficulty(prevBlk, lookup)
+	if err != nil {
+		return err
+	}
+	if newBlk.Difficulty != want {
+		return fmt.Errorf("difficulty mismatch: want=%d got=%d", want, newBlk.Difficulty)
+	}
+	return nil
+}
+
+// prev 까지 직전 MedianTimeWindow 개 블록 타임스탬프의 중앙값
+func medianTimePast(prev UpperBlock, lookup blockLookup) (time.Time, error) {
+	times := make([]time.Time, 0, MedianTimeWindow)
+	for i := prev.Index; i >= 0 && i > prev.Index-MedianTimeWindow; i-- {
+		b := prev
+		if i != prev.Index {
+			var err error
+			if b, err = lookup(i); err != nil {
+				return time.Time{}, fmt.Errorf("load block #%d for median time: %w", i, err)
+			}
+		}
+		t, err := time.Parse(time.RFC3339, b.Timestamp)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("block #%d timestamp: %w", i, err)
+		}
+		times = append(times, t)
+	}
+	sort.Slice(times, func(a, b int) bool { return times[a].Before(times[b]) })
+	return times[len(times)/2], nil
+}
+
+// 직전 블록과의 타임스탬프 간격(초), 블록 Elapsed 로 기록
+//   - 제네시스 타임스탬프는 고정값이므로 #1 은 0
+func blockInterval(newBlk, prevBlk UpperBlock) float32 {
+	if prevBlk.Index == 0 {
+		return 0
+	}
+	t1, err1 := time.Parse(time.RFC3339, newBlk.Timestamp)
+	t0, err0 := time.Parse(time.RFC3339, prevBlk.Timestamp)
+	if err1 != nil || err0 != nil || t1.Before(t0) {
+		return 0
+	}
+	return float32(t1.Sub(t0).Seconds())
+}
+
+// 로컬 체인 다음 블록의 난이도 (상태 조회용)
+func currentDifficulty() int {
+	h, ok := getLatestHeight()
+	if !ok {
+		return GenesisDifficulty
+	}
+	tip, err := getBlockByIndex(h)
+	if err != nil {
+		return GenesisDifficulty
+	}
+	d, err := nextDifficulty(tip, getBlockByIndex)
+	if err != nil {
+		return tip.Difficulty
+	}
+	return d
+}
+
+// 로컬 체인 다음 블록의 난이도 조정 상태 (/status 조회용, 계산할 수 없으면 nil)
+func currentRetarget() *RetargetState {
+	h, ok := getLatestHeight()
+	if !ok {
+		return nil
+	}
+	tip, err := getBlockByIndex(h)
+	if err != nil {
+		return nil
+	}
+	st, err := retarget(tip, getBlockByIndex)
+	if err != nil {
+		return nil
+	}
+	return &st
+}
+
+// 분기 교체 시 조상 조회 : 분기점 이후는 수신한 블록, 이전은 로컬 장부
+func branchLookup(fork int, branch []UpperBlock) blockLookup {
+	return func(index int) (UpperBlock, error) {
+		if i := index - fork - 1; index > fork && i < len(branch) {
+			return branch[i], nil
+		}
+		return getBlockByIndex(index)
+	}
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Fork Choice (누적 작업량 기준 체인 선택)
+// ------------------------------------------------------------
+// - 블록 작업량 = 16^difficulty (해시 앞자리 hex 0 개수가 난이도이므로 기대 해시 시도 횟수)
+// - 블록 저장 시 "work_<index>" 에 제네시스부터의 누적 작업량(10진수) 기록
+//   (기능 도입 이전 장부는 조회 시 누락된 높이부터 채움)
+// - /status 의 total_work 로 피어의 누적 작업량을 비교하여 가장 무거운 체인을 선택
+//   · 작업량이 같으면 최신 블록 해시가 사전순으로 앞선 체인 (모든 노드가 같은 체인으로 수렴)
+//   · 높이만 긴 저난이도 체인으로는 교체되지 않음
+// - 교체 절차 (reorgFromPeer)
+//   · 피어의 블록을 최신부터 거슬러 올라가며 공통 조상(분기점) 탐색 (제네시스가 다르면 중단)
+//   · 분기점 이후 원격 블록을 연결/머클/PoW 검증하고 실제 누적 작업량을 다시 계산
+//     (피어가 알린 total_work 는 신뢰하지 않음)
+//   · 원격 블록이 여전히 로컬 분기점에 이어지는지 chainMu 안에서 다시 확인한 뒤 교체
+//   · 로컬 블록 되돌리기와 원격 블록 반영을 leveldb.Batch 하나로 기록 (중간에 실패해도 반쯤 교체된 장부가 남지 않음)
+//   · 되돌리기는 블록마다 저장된 undo_<index>(블록이 바꾼 키의 이전 값)를 최신 블록부터 적용
+//     => 분기점 시점 상태로 복원, 제네시스부터 다시 색인하지 않음
+//     (undo 기록이 없는 기능 도입 이전 블록이 포함된 경우에만 영향받은 기관을 분기점까지 재반영)
+//   · 되돌린 블록의 앵커 중 새 체인에 없는 것은 부트노드 메모리풀로 되돌림
+////////////////////////////////////////////////////////////////////////////////
+
+const ForkPageSize = 50 // 분기점 탐색/원격 블록 수신 시 한 번에 받는 블록 수
+
+// 블록 하나의 작업량
+func blockWork(difficulty int) *big.Int {
+	if difficulty < 0 {
+		difficulty = 0
+	}
+	return new(big.Int).Lsh(big.NewInt(1), uint(4*difficulty))
+}
+
+func workKey(index int) []byte { return []byte(fmt.Sprintf("work_%d", index)) }
+
+// index 블록까지의 누적 작업량 (기록이 없으면 가장 가까운 기록부터 채움)
+func cumulativeWork(index int) (*big.Int, error) {
+	tx := newLedgerTxn()
+	work, err := cumulativeWorkIn(tx, index)
+	if err != nil {
+		return nil, err
+	}
+	return work, tx.commit()
+}
+
+// 트랜잭션 안에서 누적 작업량 계산 (채운 값도 tx 에 기록)
+func cumulativeWorkIn(tx *ledgerTxn, index int) (*big.Int, error) {
+	start, acc := index, new(big.Int)
+	for ; start >= 0; start-- {
+		v, err := tx.Get(workKey(start), nil)
+		if err != nil {
+			continue
+		}
+		if _, ok := acc.SetString(string(v), 10); !ok {
+			return nil, fmt.Errorf("invalid work_%d", start)
+		}
+		break
+	}
+	for i := start + 1; i <= index; i++ {
+		b, err := getBlockByIndexFrom(tx, i)
+		if err != nil {
+			return nil, fmt.Errorf("work catch-up block #%d: %w", i, err)
+		}
+		acc.Add(acc, blockWork(b.Difficulty))
+		tx.Put(workKey(i), []byte(acc.String()))
+	}
+	return acc, nil
+}
+
+// 블록 저장 시 누적 작업량 기록 (saveBlockToTxn 에서 호출)
+func putCumulativeWork(tx *ledgerTxn, block UpperBlock) error {
+	work := blockWork(block.Difficulty)
+	if block.Index > 0 {
+		prev, err := cumulativeWorkIn(tx, block.Index-1)
+		if err != nil {
+			return err
+		}
+		work.Add(work, prev)
+	}
+	tx.Put(workKey(block.Index), []byte(work.String()))
+	return nil
+}
+
+// 로컬 최신 블록 높이/해시/누적 작업량
+func localTip() (int, string, *big.Int) {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	h, ok := getLatestHeight()
+	if !ok || h < 0 {
+		return -1, "", new(big.Int)
+	}
+	blk, err := getBlockByIndex(h)
+	if err != nil {
+		return h, "", new(big.Int)
+	}
+	work, err := cumulativeWork(h)
+	if err != nil {
+		log.Printf("[FORK][WARN] cumulative work unavailable: %v", err)
+		return h, blk.BlockHash, new(big.Int)
+	}
+	return h, blk.BlockHash, work
+}
+
+// a 체인이 b 체인보다 우선하는지 (누적 작업량, 같으면 최신 블록 해시 사전순)
+func heavierTip(aWork *big.Int, aHash string, bWork *big.Int, bHash string) bool {
+	if c := aWork.Cmp(bWork); c != 0 {
+		return c > 0
+	}
+	return aHash != "" && aHash < bHash
+}
+
+// 공통 조상(분기점) 탐색 : 로컬과 원격에서 해시가 같은 가장 높은 블록 번호
+func findForkPoint(peer string, localH, remoteH int) (int, error) {
+	top := min(localH, remoteH)
+	for top >= 0 {
+		offset := max(0, top-ForkPageSize+1)
+		items, err := fetchBlockRange(peer, offset, top-offset+1)
+		if err != nil {
+			return -1, err
+		}
+		for i := len(items) - 1; i >= 0; i-- {
+			rb := items[i]
+			if rb.Index > top {
+				continue
+			}
+			lb, err := getBlockByIndex(rb.Index)
+			if err == nil && lb.BlockHash == rb.BlockHash {
+				return rb.Index, nil
+			}
+		}
+		top = offset - 1
+	}
+	return -1, fmt.Errorf("no common ancestor (different genesis)")
+}
+
+// 분기점 이후 원격 블록 수신 + 검증, 원격 체인의 누적 작업량 반환
+func fetchSuffix(peer string, fork, remoteH int) ([]UpperBlock, *big.Int, error) {
+	prev, err := getBlockByIndex(fork)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load fork block #%d: %w", fork, err)
+	}
+	work, err := cumulativeWork(fork)
+	if err != nil {
+		return nil, nil, err
+	}
+	var out []UpperBlock
+	for offset := fork + 1; offset <= remoteH; {
+		page, _, _, err := fetchBlocksPage(peer, offset, ForkPageSize, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+		for _, nb := range page.Items {
+			if err := validateUpperBlock(nb, prev, branchLookup(fork, out)); err != nil {
+				return nil, nil, fmt.Errorf("remote block #%d invalid: %w", nb.Index, err)
+			}
+			work.Add(work, blockWork(nb.Difficulty))
+			nb.Elapsed = blockInterval(nb, prev) // 피어가 기록한 값 대신 타임스탬프 간격
+			out = append(out, nb)
+			prev = nb
+		}
+		offset += len(page.Items) // 페이지 크기 제한으로 덜 받았으면 이어서 (blocktransfer.go)
+	}
+	return out, work, nil
+}
+
+// 피어 체인이 더 무거우면 분기점 이후만 교체
+func reorgFromPeer(peer string) error {
+	localH, localHash, localWork := localTip()
+	first, _, _, err := fetchBlocksPage(peer, 0, 1, "")
+	if err != nil {
+		return err
+	}
+	remoteH := first.Total - 1
+	if remoteH < 0 {
+		return fmt.Errorf("peer has no chain")
+	}
+	if localH < 0 {
+		syncChain(peer)
+		return nil
+	}
+
+	fork, err := findForkPoint(peer, localH, remoteH)
+	if err != nil {
+		return err
+	}
+	suffix, remoteWork, err := fetchSuffix(peer, fork, remoteH)
+	if err != nil {
+		return err
+	}
+	if len(suffix) == 0 {
+		return nil
+	}
+	remoteHash := suffix[len(suffix)-1].BlockHash
+	if !heavierTip(remoteWork, remoteHash, localWork, localHash) {
+		return fmt.Errorf("remote chain not heavier (local=%s remote=%s)", localWork, remoteWork)
+	}
+
+	chainMu.Lock()
+	// 수신 중 로컬 체인이 바뀌었으면 다음 주기에 다시 판단
+	if h, _ := getLatestHeight(); h != localH {
+		chainMu.Unlock()
+		return fmt.Errorf("local chain moved during reorg (height %d => %d)", localH, h)
+	}
+	// 검증한 원격 블록이 여전히 로컬 분기점 블록에 이어지는지 확인 (삭제 전에)
+	if fb, err := getBlockByIndex(fork); err != nil || fb.BlockHash != suffix[0].PrevHash {
+		chainMu.Unlock()
+		return fmt.Errorf("fork block #%d changed during reorg", fork)
+	}
+	// 되돌리기 + 원격 블록 반영을 하나의 Batch 로 기록
+	tx := newLedgerTxn()
+	orphans, err := rollbackTo(tx, fork, localH)
+	if err != nil {
+		chainMu.Unlock()
+		return fmt.Errorf("rollback to #%d: %w", fork, err)
+	}
+	for _, nb := range suffix {
+		if err := stageBlock(tx, nb); err != nil {
+			chainMu.Unlock()
+			return fmt.Errorf("stage block #%d: %w", nb.Index, err)
+		}
+	}
+	if err := tx.commit(); err != nil {
+		chainMu.Unlock()
+		return fmt.Errorf("commit reorg: %w", err)
+	}
+	chainMu.Unlock()
+	for _, nb := range suffix {
+		appendBlockLog(nb)
+	}
+
+	// 새 체인에 포함되지 않은 앵커는 부트노드 메모리풀로 되돌림 (부트노드만 채굴 신호를 보냄)
+	included := make(map[string]bool)
+	for _, nb := range suffix {
+		for _, rec := range nb.Records {
+			included[rec.LowerRoot] = true
+		}
+	}
+	requeue := []AnchorRecord{}
+	for _, rec := range orphans {
+		if !included[rec.LowerRoot] {
+			requeue = append(requeue, rec)
+		}
+	}
+	if len(requeue) > 0 && isBoot.Load() {
+		appendPending(requeue)
+	}
+
+	for _, nb := range suffix {
+		publishBlockFinalized(nb)
+	}
+	publishEvent(EventChainReorg, map[string]any{
+		"peer": peer, "fork": fork, "dropped": localH - fork, "added": len(suffix),
+		"height": remoteH, "tip": remoteHash, "total_work": remoteWork.String(),
+	})
+	incCounter("chain_reorgs_total", "")
+	log.Printf("[FORK] Reorg to %s: fork=#%d, dropped=%d, added=%d, requeued=%d (work %s => %s)",
+		peer, fork, localH-fork, len(suffix), len(requeue), localWork, remoteWork)
+	return nil
+}
+
+// 분기점 이후 로컬 블록 되돌리기를 트랜잭션에 적재 (chainMu 보유 상태에서 호출)
+// - 블록마다 저장된 되돌리기 기록을 최신 블록부터 적용하여 분기점 시점 상태로 복원
+// - 되돌리기 기록이 없는 블록이 있으면 rebuildTo 로 대체
+// - 되돌린 블록의 레코드 반환 (메모리풀 복원용)
+func rollbackTo(tx *ledgerTxn, fork, localH int) ([]AnchorRecord, error) {
+	var orphans []AnchorRecord
+	undos := make([]map[string]*string, 0, localH-fork)
+	legacy := false
+	for i := fork + 1; i <= localH; i++ {
+		b, err := getBlockByIndexFrom(tx, i)
+		if err != nil {
+			return nil, fmt.Errorf("load block_%d: %w", i, err)
+		}
+		orphans = append(orphans, b.Records...)
+		var u map[string]*string
+		if v, err := tx.Get(undoKey(i), nil); err != nil || json.Unmarshal(v, &u) != nil {
+			legacy = true
+			continue
+		}
+		undos = append(undos, u)
+	}
+	if legacy {
+		return orphans, rebuildTo(tx, fork, localH)
+	}
+	for i := len(undos) - 1; i >= 0; i-- {
+		for k, prev := range undos[i] {
+			if prev == nil {
+				tx.Delete([]byte(k))
+			} else {
+				tx.Put([]byte(k), []byte(*prev))
+			}
+		}
+		tx.Delete(undoKey(fork + 1 + i))
+	}
+	log.Printf("[FORK] Rolled back blocks #%d..#%d (%d records)", fork+1, localH, len(orphans))
+	return orphans, nil
+}
+
+// 되돌리기 기록이 없는 블록(기능 도입 이전 장부)이 포함된 경우의 되돌리기
+// 되돌린 블록을 가리키는 색인을 지우고, 레코드가 있던 기관은 색인/가입 현황/키 이력/조회 감사 이력/앵커 이력을
+// 지운 뒤 분기점까지의 블록으로 다시 반영
+func rebuildTo(tx *ledgerTxn, fork, localH int) error {
+	affected := make(map[string]bool)
+	for i := fork + 1; i <= localH; i++ {
+		b, err := getBlockByIndexFrom(tx, i)
+		if err != nil {
+			return fmt.Errorf("load block_%d: %w", i, err)
+		}
+		for _, rec := range b.Records {
+			if rec.HosID != "" {
+				affected[rec.HosID] = true
+			}
+			if rec.LowerRoot != "" {
+				tx.Delete(anchorRootKey(rec.LowerRoot))
+			}
+		}
+		tx.Delete([]byte(fmt.Sprintf("block_%d", i)))
+		tx.Delete([]byte("hash_" + b.BlockHash))
+		tx.Delete(workKey(i))
+		tx.Delete(undoKey(i))
+	}
+
+	// 되돌린 블록을 가리키는 계약 보조 색인 (ctr_region_*, ctr_exp_*)
+	for _, prefix := range []string{"ctr_region_", "ctr_exp_"} {
+		iter := db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+		for iter.Next() {
+			if bi, _, ok := parsePtr(string(iter.Value())); ok && bi > fork {
+				tx.Delete(append([]byte(nil), iter.Key()...))
+			}
+		}
+		iter.Release()
+	}
+
+	// 영향받은 기관의 블록 유래 상태
+	for hosID := range affected {
+		tx.Delete([]byte("anchor_" + hosID))
+		tx.Delete([]byte("ctr_latest_" + hosID))
+		if st, ok := getOnboardingStateFrom(tx, hosID); ok {
+			if st.DecidedBlock > fork {
+				tx.Delete([]byte("contract_" + hosID)) // 되돌린 블록에서 승인된 계약
+			}
+			tx.Delete([]byte(onboardKey(hosID)))
+		}
+		for _, prefix := range []string{hosKeyKey(hosID, ""), queryAuditPrefix + hosID + "|", anchorHistoryPrefix + hosID + "|"} {
+			iter := db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+			for iter.Next() {
+				tx.Delete(append([]byte(nil), iter.Key()...))
+			}
+			iter.Release()
+		}
+	}
+	tx.Delete([]byte("commit_state")) // 다음 블록 반영 시 처음부터 재구성 (commitment.go)
+	tx.Put([]byte("height_latest"), []byte(fmt.Sprint(fork)))
+
+	// 분기점까지의 블록으로 영향받은 기관 상태 재반영
+	if len(affected) > 0 {
+		for i := 0; i <= fork; i++ {
+			b, err := getBlockByIndexFrom(tx, i)
+			if err != nil {
+				return fmt.Errorf("load block_%d: %w", i, err)
+			}
+			for ei, rec := range b.Records {
+				if affected[rec.HosID] {
+					if err := indexRecord(tx, i, ei, rec); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if fb, err := getBlockByIndexFrom(tx, fork); err == nil {
+		tx.Put([]byte("root_latest"), []byte(fb.MerkleRoot))
+	}
+	ids := make([]string, 0, len(affected))
+	for id := range affected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	log.Printf("[FORK] Rolled back blocks #%d..#%d without undo records (re-indexed: %s)",
+		fork+1, localH, strings.Join(ids, ","))
+	return nil
+}
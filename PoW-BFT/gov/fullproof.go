@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Full Proof (하위 체인 레코드 => 상위 블록까지 이어지는 단일 증명 묶음)
+// ------------------------------------------------------------
+// GET /proof/full?hos_id=<id>&clinic_id=<id>
+// - Hos 부트노드 /search 로 clinic_id 의 최신 레코드와 Hos 블록 머클 경로를 받음
+//   · 같은 clinic_id 가 여러 번 기록되었으면 가장 마지막 블록의 레코드
+// - 그 Hos 블록 루트의 앵커 포함 증명(/anchor/proof, Gov 노드 키 서명)을 이어 붙임
+// - 클라이언트 오프라인 검증 순서
+//   1) sha256(정렬된 JSON(record)) == lower.leaf
+//   2) lower.leaf + lower.proof => lower.block_root  (merkle.Verify 규칙)
+//   3) anchor.root == lower.block_root, lower.block_root + anchor.merkle_proof => anchor.block.merkle_root
+//   4) anchor.block.block_hash 가 anchor.block.difficulty 만큼 0 으로 시작 (PoW)
+//   5) anchor.sig 를 Gov 공개키(/getPublicKey)로 검증
+// - checks : 위 1)~3) 을 이 노드가 미리 수행한 결과 (참고용)
+// - 앵커가 아직 블록에 포함되지 않았으면 404 (anchor_status 포함)
+////////////////////////////////////////////////////////////////////////////////
+
+const FullProofPageSize = 500 // Hos /search 최대 페이지 크기
+
+var errRecordNotFound = errors.New("record not found")
+
+type FullProof struct {
+	HosID    string          `json:"hos_id"`
+	ClinicID string          `json:"clinic_id"`
+	Record   json.RawMessage `json:"record"` // Hos 가 기록한 원본 그대로 (leaf 재계산용)
+	Lower    LowerProof      `json:"lower"`
+	Anchor   AnchorProof     `json:"anchor"`
+	Checks   FullProofChecks `json:"checks"`
+}
+
+// Hos 블록 안의 레코드 포함 증명
+type LowerProof struct {
+	BlockIndex   int         `json:"block_index"`
+	EntryIndex   int         `json:"entry_index"`
+	Leaf         string      `json:"leaf"`
+	Proof        [][2]string `json:"proof"`
+	ProofVersion int         `json:"proof_version"`
+	BlockRoot    string      `json:"block_root"`
+}
+
+type FullProofChecks struct {
+	LeafMatchesRecord bool `json:"leaf_matches_record"`
+	LowerProofValid   bool `json:"lower_proof_valid"`
+	AnchorProofValid  bool `json:"anchor_proof_valid"`
+}
+
+// Hos /search 응답 중 증명 묶음에 필요한 부분 (레코드는 원본 JSON 유지)
+type rawSearchItem struct {
+	Record       json.RawMessage `json:"record"`
+	BlockRoot    string          `json:"block_root"`
+	Leaf         string          `json:"leaf"`
+	Proof        [][2]string     `json:"proof"`
+	ProofVersion int             `json:"proof_version"`
+	Inclusion    Inclusion       `json:"inclusion"`
+}
+
+// clinic_id 의 최신 레코드 조회 (마지막 페이지만 받음)
+func fetchLatestHosRecord(hosAddr, clinicID string) (rawSearchItem, error) {
+	get := func(offset, limit int) ([]rawSearchItem, int, error) {
+		q := url.Values{"value": {clinicID}, "offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}, "proof_version": {strconv.Itoa(merkle.ProofVersion)}}
+		resp, err := nodeClient.Get(nodeURL(hosAddr, "/search?"+q.Encode()))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to reach Hos node: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound { // 매칭 레코드 없음
+			return nil, 0, errRecordNotFound
+		}
+		if resp.StatusCode != http.StatusOK {
+			b, _ := io.ReadAll(resp.Body)
+			return nil, 0, fmt.Errorf("hos error: %s", string(b))
+		}
+		var items []rawSearchItem
+		if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+			return nil, 0, fmt.Errorf("invalid JSON from Hos")
+		}
+		total, _ := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+		return items, total, nil
+	}
+
+	items, total, err := get(0, FullProofPageSize)
+	if err != nil {
+		return rawSearchItem{}, err
+	}
+	if total > FullProofPageSize {
+		if items, _, err = get(total-FullProofPageSize, FullProofPageSize); err != nil {
+			return rawSearchItem{}, err
+		}
+	}
+	// cCode 색인 매칭도 섞여 오므로 clinic_id 가 정확히 같은 것만 (블록 순 정렬 => 마지막이 최신)
+	for i := len(items) - 1; i >= 0; i-- {
+		var rec struct {
+			ClinicID string `json:"clinic_id"`
+		}
+		if json.Unmarshal(items[i].Record, &rec) == nil && rec.ClinicID == clinicID {
+			return items[i], nil
+		}
+	}
+	return rawSearchItem{}, errRecordNotFound
+}
+
+// GET /proof/full
+func handleFullProof(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	hosID, clinicID := r.URL.Query().Get("hos_id"), r.URL.Query().Get("clinic_id")
+	if hosID == "" || clinicID == "" {
+		writeError(w, http.StatusBadRequest, "hos_id and clinic_id required")
+		return
+	}
+	hosAddr := getHosBootAddr(hosID)
+	if hosAddr == "" {
+		writeError(w, http.StatusNotFound, "unknown hos_id (no boot address)")
+		return
+	}
+
+	it, err := fetchLatestHosRecord(hosAddr, clinicID)
+	if err == errRecordNotFound {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	anchor, ok := buildAnchorProof(hosID, it.BlockRoot)
+	if !ok {
+		st, _ := anchorStatusOf(hosID, it.BlockRoot)
+		writeErrorDetail(w, http.StatusNotFound, "not_anchored", "block root not anchored yet", map[string]string{
+			"block_root":    it.BlockRoot,
+			"anchor_status": st,
+		})
+		return
+	}
+	if err := signAnchorProof(&anchor); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to sign proof: "+err.Error())
+		return
+	}
+
+	fp := FullProof{
+		HosID:    hosID,
+		ClinicID: clinicID,
+		Record:   it.Record,
+		Lower: LowerProof{
+			BlockIndex:   it.Inclusion.BlockIndex,
+			EntryIndex:   it.Inclusion.EntryIndex,
+			Leaf:         it.Leaf,
+			Proof:        it.Proof,
+			ProofVersion: it.ProofVersion,
+			BlockRoot:    it.BlockRoot,
+		},
+		Anchor: anchor,
+	}
+	fp.Checks.LeafMatchesRecord = recordLeafHash(it.Record) == it.Leaf
+	fp.Checks.LowerProofValid = proofValid(it.ProofVersion, it.Leaf, it.Proof, it.BlockRoot)
+	fp.Checks.AnchorProofValid = proofValid(anchor.ProofVersion, it.BlockRoot, anchor.MerkleProof, anchor.Block.MerkleRoot)
+
+	logInfo("[FULL-PROOF] hos=%s clinic=%s -> lower #%d, upper #%d (checks=%+v)",
+		hosID, clinicID, fp.Lower.BlockIndex, anchor.UpperBlockIndex, fp.Checks)
+	writeJSON(w, http.StatusOK, fp)
+}
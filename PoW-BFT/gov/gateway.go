@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Gateway (통합 검증 게이트웨이)
+// ------------------------------------------------------------
+// 의료(hos/gov) 체인과 미디어(cp/ott) 체인을 함께 운영하는 기관을 위한 단일 검증 창구.
+// GATEWAY_MODE=true 로 실행하면 체인 노드가 아닌 게이트웨이로만 동작하며,
+//   - 여러 상위 체인(Gov/OTT)을 등록하고
+//   - 체인 유형(clinic/content)에 따라 검증 요청을 라우팅한 뒤
+//   - ClinicRecord / ContentRecord 구분 없이 동일한 검증 결과 스키마로 반환함
+// 등록 형식 (GATEWAY_CHAINS) : "<name>=<type>@<addr>,..."
+//   ex) "medical=clinic@gov-boot:5000,media=content@ott-boot:5000"
+////////////////////////////////////////////////////////////////////////////////
+
+// 체인 유형
+const (
+	ChainTypeClinic  = "clinic"  // hos/gov 체인 (ClinicRecord)
+	ChainTypeContent = "content" // cp/ott 체인 (ContentRecord)
+)
+
+// 체인 유형별 라우팅 규칙
+//   - providerParam : 상위 체인 /query 에 넘길 하위 체인 식별자 파라미터명
+//   - recordIDField : 레코드 본문에서 레코드 ID를 꺼낼 필드명
+type chainRoute struct {
+	providerParam string
+	recordIDField string
+}
+
+var chainRoutes = map[string]chainRoute{
+	ChainTypeClinic:  {providerParam: "hos_id", recordIDField: "clinic_id"},
+	ChainTypeContent: {providerParam: "cp_id", recordIDField: "content_id"},
+}
+
+// 게이트웨이에 등록된 상위 체인 정보
+type GatewayChain struct {
+	Name string `json:"name"` // 게이트웨이 내 체인 이름 (라우팅 키)
+	Type string `json:"type"` // clinic | content
+	Addr string `json:"addr"` // 상위 체인 부트노드 주소
+
+	// 등록 시 상위 체인 /chain/info 에서 조회 (미제공 체인은 빈 값)
+	ChainID     string `json:"chain_id,omitempty"`
+	GenesisHash string `json:"genesis_hash,omitempty"`
+}
+
+var (
+	gatewayChains   = map[string]GatewayChain{}
+	gatewayChainsMu sync.RWMutex
+)
+
+// 레코드 종류와 무관한 통합 검증 결과 (개별 레코드 단위)
+type VerifiedItem struct {
+	RecordType string          `json:"record_type"` // ClinicRecord | ContentRecord
+	RecordID   string          `json:"record_id"`   // clinic_id 또는 content_id
+	Record     json.RawMessage `json:"record"`      // 원본 레코드
+	Leaf       string          `json:"leaf"`
+	BlockRoot  string          `json:"block_root"`
+	LatestRoot string          `json:"latest_root"`
+	Proof      [][2]string     `json:"proof"`
+	Verified   bool            `json:"verified"` // 게이트웨이에서 재수행한 Merkle 증명 결과
+}
+
+// 통합 검증 결과 (응답 스키마)
+type VerificationResult struct {
+	Chain       string         `json:"chain"`
+	ChainType   string         `json:"chain_type"`
+	ChainID     string         `json:"chain_id,omitempty"`
+	GenesisHash string         `json:"genesis_hash,omitempty"`
+	ProviderID  string         `json:"provider_id"`
+	Keyword     string         `json:"keyword"`
+	Count       int            `json:"count"`
+	Verified    int            `json:"verified"`
+	Items       []VerifiedItem `json:"items"`
+	CheckedAt   string         `json:"checked_at"`
+}
+
+// 상위 체인 /query 응답 중 게이트웨이가 필요로 하는 필드만 정의
+// (SearchResponse와 동일한 구조이나 Record를 원본 그대로 보존)
+type upperQueryItem struct {
+	Record       json.RawMessage `json:"record"`
+	BlockRoot    string          `json:"block_root"`
+	LatestRoot   string          `json:"latest_root"`
+	Leaf         string          `json:"leaf"`
+	Proof        [][2]string     `json:"proof"`
+	ProofVersion int             `json:"proof_version"`
+}
+
+// 게이트웨이에 상위 체인 등록
+func registerGatewayChain(c GatewayChain) error {
+	if c.Name == "" || c.Addr == "" {
+		return fmt.Errorf("name and addr required")
+	}
+	if _, ok := chainRoutes[c.Type]; !ok {
+		return fmt.Errorf("unknown chain type: %s", c.Type)
+	}
+	if info, err := fetchChainInfo(c.Addr); err == nil {
+		c.ChainID, c.GenesisHash = info.ChainID, info.GenesisHash
+	} else {
+		log.Printf("[GATEWAY][WARN] chain info unavailable for %s: %v", c.Name, err)
+	}
+	gatewayChainsMu.Lock()
+	gatewayChains[c.Name] = c
+	gatewayChainsMu.Unlock()
+	log.Printf("[GATEWAY] chain registered: %s (type=%s, addr=%s)", c.Name, c.Type, c.Addr)
+	return nil
+}
+
+// 등록된 상위 체인 목록
+func gatewayChainsSnapshot() []GatewayChain {
+	gatewayChainsMu.RLock()
+	defer gatewayChainsMu.RUnlock()
+	out := make([]GatewayChain, 0, len(gatewayChains))
+	for _, c := range gatewayChains {
+		out = append(out, c)
+	}
+	return out
+}
+
+// 환경변수 문자열로부터 상위 체인 일괄 등록
+// "<name>=<type>@<addr>" 를 콤마로 구분
+func loadGatewayChains(spec string) {
+	for _, ent := range strings.Split(spec, ",") {
+		ent = strings.TrimSpace(ent)
+		if ent == "" {
+			continue
+		}
+		name, rest, ok1 := strings.Cut(ent, "=")
+		typ, addr, ok2 := strings.Cut(rest, "@")
+		if !ok1 || !ok2 {
+			log.Printf("[GATEWAY][WARN] invalid chain spec: %s", ent)
+			continue
+		}
+		if err := registerGatewayChain(GatewayChain{Name: name, Type: typ, Addr: addr}); err != nil {
+			log.Printf("[GATEWAY][WARN] %s: %v", ent, err)
+		}
+	}
+}
+
+// 체인 이름으로 라우팅하여 검증 수행
+func gatewayVerify(chainName, providerID, keyword string) (*VerificationResult, int, error) {
+	gatewayChainsMu.RLock()
+	c, ok := gatewayChains[chainName]
+	gatewayChainsMu.RUnlock()
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("unknown chain: %s", chainName)
+	}
+	route := chainRoutes[c.Type]
+
+	// 1) 상위 체인 /query 호출 (상위 체인은 앵커 루트 기준 1차 검증을 수행함)
+	q := url.Values{}
+	q.Set(route.providerParam, providerID)
+	q.Set("keyword", keyword)
+	resp, err := http.Get("http://" + c.Addr + "/query?" + q.Encode())
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to reach %s: %v", c.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, http.StatusBadGateway, fmt.Errorf("%s error: %s", c.Name, strings.TrimSpace(string(b)))
+	}
+
+	var raw []upperQueryItem
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("invalid JSON from %s", c.Name)
+	}
+
+	// 2) 통합 스키마로 변환 + Merkle 증명 재검증
+	res := &VerificationResult{
+		Chain:       c.Name,
+		ChainType:   c.Type,
+		ChainID:     c.ChainID,
+		GenesisHash: c.GenesisHash,
+		ProviderID:  providerID,
+		Keyword:     keyword,
+		Items:       make([]VerifiedItem, 0, len(raw)),
+		CheckedAt:   time.Now().Format(time.RFC3339),
+	}
+	for _, it := range raw {
+		vi := VerifiedItem{
+			RecordType: recordTypeOf(c.Type),
+			RecordID:   recordIDOf(it.Record, route.recordIDField),
+			Record:     it.Record,
+			Leaf:       it.Leaf,
+			BlockRoot:  it.BlockRoot,
+			LatestRoot: it.LatestRoot,
+			Proof:      it.Proof,
+			Verified:   proofValid(it.ProofVersion, it.Leaf, it.Proof, it.BlockRoot),
+		}
+		if vi.Verified {
+			res.Verified++
+		}
+		res.Items = append(res.Items, vi)
+	}
+	res.Count = len(res.Items)
+	logInfo("[GATEWAY] %s(%s) provider=%s keyword=%s -> %d/%d verified", c.Name, c.Type, providerID, keyword, res.Verified, res.Count)
+	return res, http.StatusOK, nil
+}
+
+func recordTypeOf(chainType string) string {
+	if chainType == ChainTypeContent {
+		return "ContentRecord"
+	}
+	return "ClinicRecord"
+}
+
+// 레코드 본문에서 ID 필드 추출 (레코드 구조를 몰라도 되도록 map으로 디코딩)
+func recordIDOf(rec json.RawMessage, field string) string {
+	var m map[string]any
+	if err := json.Unmarshal(rec, &m); err != nil {
+		return ""
+	}
+	if v, ok := m[field]; ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// 게이트웨이 API 등록
+func RegisterGatewayAPI(mux *http.ServeMux) {
+
+	// 등록된 상위 체인 조회 / 신규 등록
+	// GET  /gateway/chains
+	// POST /gateway/chains  {"name","type","addr"}
+	mux.HandleFunc("/gateway/chains", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, gatewayChainsSnapshot())
+		case http.MethodPost:
+			var c GatewayChain
+			if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid JSON")
+				return
+			}
+			defer r.Body.Close()
+			if err := registerGatewayChain(c); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			writeJSON(w, http.StatusOK, c)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
+	// 체인 유형에 따라 라우팅된 통합 검증
+	// GET /gateway/verify?chain=<name>&provider=<hos_id|cp_id>&keyword=<keyword>
+	mux.HandleFunc("/gateway/verify", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		chainName := r.URL.Query().Get("chain")
+		provider := r.URL.Query().Get("provider")
+		kw := r.URL.Query().Get("keyword")
+		if chainName == "" || provider == "" || kw == "" {
+			writeError(w, http.StatusBadRequest, "chain, provider and keyword required")
+			return
+		}
+
+		res, status, err := gatewayVerify(chainName, provider, kw)
+		if err != nil {
+			writeError(w, status, err.Error())
+			return
+		}
+		writeJSON(w, status, res)
+	})
+}
+
+// 게이트웨이 모드 실행 (체인 노드 기능은 실행하지 않음)
+func runGateway(addr string) {
+	loadGatewayChains(getEnvDefault("GATEWAY_CHAINS", ""))
+
+	mux := http.NewServeMux()
+	RegisterGatewayAPI(mux)
+
+	log.Println("[GATEWAY] Running on", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Hos Key Rotation (Hos 노드 서명 키 교체 기록)
+// ------------------------------------------------------------
+// - POST /hosKeyRotation {hos_boot, rotation} (Hos 부트노드 => Gov 부트노드)
+//   · 교체 공지는 이전 키(연속성)와 새 키(소유 증명)로 각각 서명됨
+//   · 이전 키가 장부에 기록된 현재 키(없으면 가입 신청서의 공개키)와 같아야 수락
+//     (기록도 신청서도 없는 노드는 첫 공지를 그대로 수락)
+//   · 확인 후 장부에 기록 (Kind = "hos_key_rotation")
+// - 블록 반영 시 모든 노드가 "hoskey_<hosID>|<node>" 에 현재 공개키 갱신
+// - GET /hosKeys?hos_id= : 기관 노드별 현재 공개키와 교체 블록 조회
+// - 앵커 서명은 기존처럼 Hos 부트노드 /getPublicKey 로 검증 (이 기록은 키 이력 감사용)
+////////////////////////////////////////////////////////////////////////////////
+
+const RecordKindKeyRotation = "hos_key_rotation"
+
+// 키 교체 공지 (Hos keystore.go 와 동일 규격)
+type HosKeyRotation struct {
+	HosID      string `json:"hos_id"`
+	Node       string `json:"node"`
+	PubKey     string `json:"pub_key"`
+	PrevPubKey string `json:"prev_pub_key"`
+	Ts         string `json:"ts"`
+	Sig        string `json:"sig"`     // 이전 키 서명
+	NewSig     string `json:"new_sig"` // 새 키 서명
+}
+
+func (k HosKeyRotation) digest() string {
+	return sha256Hex([]byte(fmt.Sprintf("key_rotation|%s|%s|%s|%s|%s",
+		k.HosID, k.Node, pemFingerprint(k.PrevPubKey), pemFingerprint(k.PubKey), k.Ts)))
+}
+
+func (k HosKeyRotation) verify() error {
+	switch {
+	case k.HosID == "" || k.Node == "" || k.PubKey == "" || k.PrevPubKey == "":
+		return fmt.Errorf("hos_id, node, pub_key and prev_pub_key required")
+	case !verifyPemSignature(k.PrevPubKey, k.digest(), k.Sig):
+		return fmt.Errorf("previous key signature invalid")
+	case !verifyPemSignature(k.PubKey, k.digest(), k.NewSig):
+		return fmt.Errorf("new key signature invalid")
+	}
+	return nil
+}
+
+// 장부에 기록된 Hos 노드의 현재 공개키
+type HosKeyState struct {
+	HosID     string `json:"hos_id"`
+	Node      string `json:"node"`
+	PubKey    string `json:"pub_key"`
+	KeyFP     string `json:"pubkey_fingerprint"`
+	PrevKeyFP string `json:"prev_pubkey_fingerprint"`
+	Block     int    `json:"block"` // 교체가 기록된 블록
+	Ts        string `json:"ts"`
+}
+
+// 공개키 PEM 의 SHA-256 지문 (hex, Hos pubKeyFingerprint 와 동일)
+func pemFingerprint(pubPem string) string {
+	block, _ := pem.Decode([]byte(pubPem))
+	if block == nil {
+		return ""
+	}
+	return certFingerprint(block.Bytes)
+}
+
+func hosKeyKey(hosID, node string) string { return "hoskey_" + hosID + "|" + node }
+
+func getHosKeyState(hosID, node string) (HosKeyState, bool) {
+	return getHosKeyStateFrom(db, hosID, node)
+}
+func getHosKeyStateFrom(rd kvReader, hosID, node string) (HosKeyState, bool) {
+	v, err := rd.Get([]byte(hosKeyKey(hosID, node)), nil)
+	if err != nil {
+		return HosKeyState{}, false
+	}
+	var st HosKeyState
+	if err := json.Unmarshal([]byte(v), &st); err != nil {
+		return HosKeyState{}, false
+	}
+	return st, true
+}
+
+// 교체 전 키로 알려진 공개키 지문 (장부 기록 > 가입 신청서, 없으면 "")
+func knownHosKeyFP(hosID, node string) string {
+	return knownHosKeyFPFrom(db, hosID, node)
+}
+func knownHosKeyFPFrom(rd kvReader, hosID, node string) string {
+	if st, ok := getHosKeyStateFrom(rd, hosID, node); ok {
+		return st.KeyFP
+	}
+	if ob, ok := getOnboardingStateFrom(rd, hosID); ok {
+		return pemFingerprint(ob.Application.PubKeys[node])
+	}
+	return ""
+}
+
+// 블록에 기록된 키 교체 반영 (updateIndicesForBlock 에서 호출)
+func applyKeyRotationRecord(tx *ledgerTxn, blockIndex int, rec AnchorRecord) error {
+	if rec.KeyRotation == nil {
+		return nil
+	}
+	k := *rec.KeyRotation
+	if rec.LowerRoot != k.digest() || k.verify() != nil {
+		log.Printf("[HOSKEY][WARN] Block #%d: invalid key rotation for %s", blockIndex, k.Node)
+		return nil
+	}
+	if known := knownHosKeyFPFrom(tx, k.HosID, k.Node); known != "" && known != pemFingerprint(k.PrevPubKey) {
+		log.Printf("[HOSKEY][WARN] Block #%d: key rotation for %s does not follow the recorded key", blockIndex, k.Node)
+		return nil
+	}
+	st := HosKeyState{
+		HosID:     k.HosID,
+		Node:      k.Node,
+		PubKey:    k.PubKey,
+		KeyFP:     pemFingerprint(k.PubKey),
+		PrevKeyFP: pemFingerprint(k.PrevPubKey),
+		Block:     blockIndex,
+		Ts:        k.Ts,
+	}
+	data, _ := json.Marshal(st)
+	log.Printf("[HOSKEY] %s (%s) key rotated in block #%d (%s...)", k.Node, k.HosID, blockIndex, st.KeyFP[:16])
+	tx.putMeta(hosKeyKey(k.HosID, k.Node), string(data))
+	return nil
+}
+
+// 키 교체 공지 수신 (부트노드 전용, Hos 부트노드 => Gov)
+// POST /hosKeyRotation {hos_boot, rotation}
+func handleHosKeyRotation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !isBoot.Load() {
+		writeError(w, http.StatusForbidden, "only boot node records key rotations")
+		return
+	}
+	var req struct {
+		HosBoot  string         `json:"hos_boot"`
+		Rotation HosKeyRotation `json:"rotation"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+	k := req.Rotation
+
+	// mTLS 활성 시 : 요청자의 인증서가 Hos 부트노드 주소에 고정된 지문과 같아야 함
+	if tlsEnabled {
+		if pin := clientCertPin(r); pin == "" || pin != peerCertPin(req.HosBoot) {
+			writeError(w, http.StatusForbidden, "client certificate does not match hos_boot")
+			return
+		}
+	}
+	if onboardingRequired && !isOnboarded(orgOf(k.HosID)) {
+		writeError(w, http.StatusForbidden, "hos_id not onboarded")
+		return
+	}
+	if err := k.verify(); err != nil {
+		log.Printf("[HOSKEY][INVALID] rotation from %s: %v", req.HosBoot, err)
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	switch known := knownHosKeyFP(k.HosID, k.Node); {
+	case known == pemFingerprint(k.PubKey): // 이미 기록됨
+		w.WriteHeader(http.StatusOK)
+		return
+	case known != "" && known != pemFingerprint(k.PrevPubKey):
+		writeError(w, http.StatusConflict, "prev_pub_key does not match recorded key")
+		return
+	}
+
+	appendPending([]AnchorRecord{{
+		HosID:           k.HosID,
+		Kind:            RecordKindKeyRotation,
+		LowerRoot:       k.digest(),
+		AccessCatalog:   []string{},
+		AnchorTimestamp: k.Ts,
+		KeyRotation:     &k,
+	}})
+	log.Printf("[HOSKEY] Key rotation of %s (%s) queued", k.Node, k.HosID)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// 기관 노드별 현재 공개키 조회
+// GET /hosKeys?hos_id=<id>
+func handleHosKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	prefix := "hoskey_"
+	if hosID := r.URL.Query().Get("hos_id"); hosID != "" {
+		prefix += hosID + "|"
+	}
+	out := []HosKeyState{}
+	iter := db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	for iter.Next() {
+		var st HosKeyState
+		if err := json.Unmarshal(iter.Value(), &st); err == nil {
+			out = append(out, st)
+		}
+	}
+	iter.Release()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].HosID != out[j].HosID {
+			return out[i].HosID < out[j].HosID
+		}
+		return out[i].Node < out[j].Node
+	})
+	writeJSON(w, http.StatusOK, out)
+}
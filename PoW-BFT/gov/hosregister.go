@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Hos Chain Registration (신규 Hos 체인 자동 등록)
+// ------------------------------------------------------------
+// - 기존에는 첫 앵커가 도착해야 hosBootMap 에 Hos 부트노드가 등록됨 (그 전에는 /query 불가)
+// - POST /registerHosChain {hos_id, hos_boot, pub_key, ts, sig} (Hos 부트노드 => Gov 부트노드)
+//   · sig : sha256("register_hos_chain|<hos_id>|<hos_boot>|<pub_key 지문>|<ts>") 의 Hos 노드 키 서명
+//   · ts 는 HosRegisterMaxSkew 이내, pub_key 는 hos_boot 의 /getPublicKey 와 같아야 함
+//   · hos_boot 의 /chain/info chain_id 가 hos_id 와 같아야 함 (미제공 노드는 생략)
+//   · 장부에 기록된 노드 키(키 교체/가입 신청서)가 있으면 그 키와 같아야 함
+// - 등록 시 hosBootMap 에 저장하고 모든 Gov 노드에 전파 (/hosBootNotify)
+// - 응답 : Gov 부트노드, Gov 노드 목록, 계약 템플릿, 가입 승인 필요 여부/현황
+//   · 계약 템플릿 = 등록된 계약(contract_<hosID>) > CONTRACT_TEMPLATE_FILE > 기본값 (ContractTemplateDays 후 만료)
+//   · Hos 는 ONBOARDING_CONTRACT_FILE 이 없으면 이 템플릿으로 가입 신청 (Hos onboarding.go)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	HosRegisterMaxSkew   = 5 * time.Minute // 등록 요청 시각 허용 오차
+	ContractTemplateDays = 365             // 기본 계약 템플릿 유효 기간
+)
+
+// CONTRACT_TEMPLATE_FILE 에서 읽은 계약 템플릿 (없으면 nil)
+var contractTemplateBase *ContractData
+
+type RegisterHosChainRequest struct {
+	HosID   string `json:"hos_id"`
+	HosBoot string `json:"hos_boot"`
+	PubKey  string `json:"pub_key"`
+	Ts      string `json:"ts"`
+	Sig     string `json:"sig"`
+}
+
+func (q RegisterHosChainRequest) digest() string {
+	return sha256Hex([]byte(fmt.Sprintf("register_hos_chain|%s|%s|%s|%s", q.HosID, q.HosBoot, pemFingerprint(q.PubKey), q.Ts)))
+}
+
+type RegisterHosChainResponse struct {
+	HosID              string       `json:"hos_id"`
+	GovID              string       `json:"gov_id"`
+	GovBoot            string       `json:"gov_boot"`
+	GovPeers           []string     `json:"gov_peers"` // Gov 부트노드 포함
+	ContractTemplate   ContractData `json:"contract_template"`
+	OnboardingRequired bool         `json:"onboarding_required"`
+	OnboardingStatus   string       `json:"onboarding_status,omitempty"` // 가입 신청 이력이 있을 때만
+}
+
+// CONTRACT_TEMPLATE_FILE 로드 (main 에서 호출, 경로가 비어 있으면 기본 템플릿 사용)
+func initContractTemplate(path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var c ContractData
+	if err := json.Unmarshal(data, &c); err != nil {
+		return fmt.Errorf("contract template %s: %w", path, err)
+	}
+	contractTemplateBase = &c
+	return nil
+}
+
+// hos_id 용 계약 템플릿
+func contractTemplate(hosID string) ContractData {
+	if c := getRegisteredContract(hosID); c.HosID != "" {
+		return c
+	}
+	c := ContractData{AllowedClinicIDs: []string{}}
+	if contractTemplateBase != nil {
+		c = *contractTemplateBase
+	}
+	c.HosID = hosID
+	if c.ExpiryTimestamp == "" {
+		c.ExpiryTimestamp = time.Now().UTC().AddDate(0, 0, ContractTemplateDays).Format(time.RFC3339)
+	}
+	return c
+}
+
+// 등록 요청 검증 (실패 시 응답 상태 반환)
+func verifyHosRegistration(r *http.Request, q RegisterHosChainRequest) (int, error) {
+	if q.HosID == "" || q.HosBoot == "" || q.PubKey == "" || q.Sig == "" {
+		return http.StatusBadRequest, fmt.Errorf("hos_id, hos_boot, pub_key and sig required")
+	}
+	t, err := time.Parse(time.RFC3339, q.Ts)
+	if err != nil || time.Since(t).Abs() > HosRegisterMaxSkew {
+		return http.StatusBadRequest, fmt.Errorf("ts expired or invalid")
+	}
+	// mTLS 활성 시 : 요청자의 인증서가 hos_boot 주소에 고정된 지문과 같아야 함
+	if tlsEnabled {
+		if pin := clientCertPin(r); pin == "" || pin != peerCertPin(q.HosBoot) {
+			return http.StatusForbidden, fmt.Errorf("client certificate does not match hos_boot")
+		}
+	}
+	pubPem, err := fetchPublicKey(q.HosBoot)
+	if err != nil {
+		return http.StatusBadGateway, fmt.Errorf("failed to fetch public key from hos_boot: %v", err)
+	}
+	if strings.TrimSpace(pubPem) != strings.TrimSpace(q.PubKey) {
+		return http.StatusForbidden, fmt.Errorf("pub_key does not match hos_boot key")
+	}
+	if !verifyPemSignature(pubPem, q.digest(), q.Sig) {
+		return http.StatusForbidden, fmt.Errorf("invalid signature")
+	}
+	if known := knownHosKeyFP(orgOf(q.HosID), q.HosBoot); known != "" && known != pemFingerprint(pubPem) {
+		return http.StatusForbidden, fmt.Errorf("pub_key does not match recorded key of %s", q.HosBoot)
+	}
+	if info, err := fetchChainInfo(q.HosBoot); err != nil {
+		log.Printf("[HOSREG][WARN] chain info unavailable from %s: %v", q.HosBoot, err)
+	} else if info.ChainID != q.HosID {
+		return http.StatusBadRequest, fmt.Errorf("hos_boot serves chain %s, not %s", info.ChainID, q.HosID)
+	}
+	return http.StatusOK, nil
+}
+
+// 신규 Hos 체인 등록 (부트노드 전용)
+// POST /registerHosChain {RegisterHosChainRequest}
+func handleRegisterHosChain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !isBoot.Load() {
+		writeError(w, http.StatusForbidden, "only boot node registers hos chains (boot="+getBootAddr()+")")
+		return
+	}
+	var q RegisterHosChainRequest
+	if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+	if status, err := verifyHosRegistration(r, q); err != nil {
+		log.Printf("[HOSREG][DENY] %s (%s): %v", q.HosID, q.HosBoot, err)
+		writeError(w, status, err.Error())
+		return
+	}
+
+	if prev := getHosBootAddr(q.HosID); prev != q.HosBoot {
+		broadcastNewHosBoot(q.HosID, q.HosBoot)
+		log.Printf("[HOSREG] %s registered (boot %q => %s)", q.HosID, prev, q.HosBoot)
+	}
+
+	resp := RegisterHosChainResponse{
+		HosID:              q.HosID,
+		GovID:              ch.govID,
+		GovBoot:            self,
+		GovPeers:           append([]string{self}, peersSnapshot()...),
+		ContractTemplate:   contractTemplate(orgOf(q.HosID)),
+		OnboardingRequired: onboardingRequired,
+	}
+	if st, ok := getOnboardingState(orgOf(q.HosID)); ok {
+		resp.OnboardingStatus = st.Status
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
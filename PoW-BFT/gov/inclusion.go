@@ -0,0 +1,171 @@
+package main
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"time"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Inclusion (조회 응답의 레코드 포함 정보)
+// ------------------------------------------------------------
+// - /query 응답의 각 항목에 inclusion 객체로 포함 (Hos /search 와 동일 구조)
+//   · block_index / entry_index / block_timestamp / confirmations / final : Hos 가 제공
+//   · anchor_status / upper_block_index : Gov 장부 기준으로 다시 판정
+// - GET /anchor/status?hos_id=&root= : Hos 가 자기 블록의 앵커 상태를 조회
+// - GET /anchor/proof?hos_id=&root= : 앵커가 상위 블록에 포함되었다는 증명 (Hos 가 제3자에게 제시)
+//   · upper_block_index / record_index : 상위 블록 번호와 블록 내 앵커 위치
+//   · merkle_proof : 앵커 LowerRoot => 상위 블록 MerkleRoot 경로 (merkle.Verify 규칙)
+//   · block : 상위 블록 헤더 (PoW 합의 증거 : prev_hash, nonce, difficulty, block_hash)
+//   · confirmations : 이후 쌓인 블록 수
+//   · 응답 전체를 Gov 노드 키로 서명 (공개키는 GET /getPublicKey, /verify 영수증과 동일 방식)
+//   · 아직 블록에 포함되지 않았으면 404 (상태는 /anchor/status 로 확인)
+////////////////////////////////////////////////////////////////////////////////
+
+type Inclusion struct {
+	BlockIndex      int    `json:"block_index"`
+	EntryIndex      int    `json:"entry_index"`
+	BlockTimestamp  string `json:"block_timestamp"`
+	Confirmations   int    `json:"confirmations"`
+	Final           bool   `json:"final"`
+	AnchorStatus    string `json:"anchor_status"`
+	UpperBlockIndex *int   `json:"upper_block_index,omitempty"` // anchored 인 경우 Gov 블록 번호
+}
+
+type AnchorStatusResponse struct {
+	HosID           string `json:"hos_id"`
+	Root            string `json:"root"`
+	AnchorStatus    string `json:"anchor_status"`
+	UpperBlockIndex *int   `json:"upper_block_index,omitempty"`
+}
+
+// hosID 의 root 앵커 상태 (anchored 면 상위 블록 번호 포함)
+func anchorStatusOf(hosID, root string) (string, *int) {
+	if b, ok := findAnchoredBlock(hosID, root); ok {
+		idx := b.Index
+		return AnchorStatusAnchored, &idx
+	}
+	if isPendingAnchor(hosID, root) {
+		return AnchorStatusPending, nil
+	}
+	return AnchorStatusUnknown, nil
+}
+
+// GET /anchor/status
+func handleAnchorStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	st := AnchorStatusResponse{
+		HosID: r.URL.Query().Get("hos_id"),
+		Root:  r.URL.Query().Get("root"),
+	}
+	if st.HosID == "" || st.Root == "" {
+		writeError(w, http.StatusBadRequest, "hos_id and root required")
+		return
+	}
+	st.AnchorStatus, st.UpperBlockIndex = anchorStatusOf(st.HosID, st.Root)
+	writeJSON(w, http.StatusOK, st)
+}
+
+type AnchorProof struct {
+	HosID           string           `json:"hos_id"`
+	Root            string           `json:"root"`
+	UpperBlockIndex int              `json:"upper_block_index"`
+	RecordIndex     int              `json:"record_index"`
+	MerkleProof     [][2]string      `json:"merkle_proof"`
+	ProofVersion    int              `json:"proof_version"` // merkle_proof 형식 버전 (merkle.ProofVersion)
+	Block           UpperBlockHeader `json:"block"`
+	Confirmations   int              `json:"confirmations"`
+	GovID           string           `json:"gov_id"`
+	Signer          string           `json:"signer"`
+	IssuedAt        string           `json:"issued_at"`
+	Sig             string           `json:"sig"`
+}
+
+// 서명 대상 다이제스트 (Sig 제외)
+func (p AnchorProof) digest() []byte {
+	body := p
+	body.Sig = ""
+	sum := sha256.Sum256(jsonCanonical(body))
+	return sum[:]
+}
+
+// hosID 의 root 앵커 포함 증명 생성 (블록 미포함이면 false)
+func buildAnchorProof(hosID, root string) (AnchorProof, bool) {
+	var (
+		p  AnchorProof
+		ok bool
+	)
+	_ = withReadSnapshot(func(rd dbReader) error {
+		b, found := findAnchoredBlockFrom(rd, hosID, root)
+		if !found {
+			return nil
+		}
+		leaves := make([]string, len(b.Records))
+		ri := -1
+		for i, rec := range b.Records {
+			leaves[i] = rec.LowerRoot // computeUpperMerkleRoot 와 같은 leaf
+			if ri < 0 && rec.Kind == "" && rec.HosID == hosID && rec.LowerRoot == root {
+				ri = i
+			}
+		}
+		if ri < 0 {
+			return nil
+		}
+		h, _ := getLatestHeightFrom(rd)
+		p = AnchorProof{
+			HosID:           hosID,
+			Root:            root,
+			UpperBlockIndex: b.Index,
+			RecordIndex:     ri,
+			MerkleProof:     merkle.Proof(leaves, ri),
+			ProofVersion:    merkle.ProofVersion,
+			Block:           b.header(),
+			Confirmations:   max(0, h-b.Index),
+		}
+		ok = true
+		return nil
+	})
+	return p, ok
+}
+
+// 발급 노드 정보 기록 후 Gov 노드 키로 서명
+func signAnchorProof(p *AnchorProof) error {
+	p.GovID = selfID()
+	p.Signer = self
+	p.IssuedAt = time.Now().UTC().Format(time.RFC3339)
+	sig, err := signWithGovKey(p.digest())
+	if err != nil {
+		return err
+	}
+	p.Sig = sig
+	return nil
+}
+
+// GET /anchor/proof
+func handleAnchorProof(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	hosID, root := r.URL.Query().Get("hos_id"), r.URL.Query().Get("root")
+	if hosID == "" || root == "" {
+		writeError(w, http.StatusBadRequest, "hos_id and root required")
+		return
+	}
+	p, ok := buildAnchorProof(hosID, root)
+	if !ok {
+		writeError(w, http.StatusNotFound, "anchor not included in a block (see /anchor/status)")
+		return
+	}
+	if err := signAnchorProof(&p); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to sign proof: "+err.Error())
+		return
+	}
+	logInfo("[ANCHOR-PROOF] hos=%s root=%s -> upper #%d (confirmations=%d)", hosID, root, p.UpperBlockIndex, p.Confirmations)
+	writeJSON(w, http.StatusOK, p)
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -17,8 +18,74 @@ func main() {
 	addr := getEnvDefault("PORT", "5000")
 	addr = ":" + addr
 
+	// 게이트웨이 모드 : 여러 상위 체인에 대한 통합 검증 창구로만 동작
+	if getEnvDefault("GATEWAY_MODE", "false") == "true" {
+		runGateway(addr)
+		return
+	}
+
 	boot = getEnvDefault("BOOTSTRAP_ADDR", "gov-boot:5000") // 부트노드 고정주소
-	self = getEnvDefault("NODE_ADDR", "gov-node-00:5000")   // 이 노드의 외부접속 주소
+	self = getEnvDefault("NODE_ADDR", "gov-node-00:5000")   // 이 노드의 외부접속 주소 (auto 면 탐지, advertise.go)
+	stunServer = getEnvDefault("STUN_SERVER", "")           // NODE_ADDR=auto 일 때 외부 IP 확인용 STUN 서버 (host:port)
+
+	onboardingRequired = getEnvDefault("ONBOARDING_REQUIRED", "true") == "true" // 승인된 기관의 앵커만 수락
+	contractPolicy = getEnvDefault("CONTRACT_POLICY", "true") == "true"         // 유효 계약이 있는 기관의 앵커만 수락
+	legacySunset = getEnvDefault("API_LEGACY_SUNSET", LegacySunsetDefault)      // 버전 없는 기존 API 경로 폐기 시각
+	initPHIDecryptRequesters(os.Getenv("PHI_DECRYPT_REQUESTERS"))               // 진료 정보 복호화 조회 허용 요청자
+	queryAuditEnabled = getEnvDefault("QUERY_AUDIT", "true") == "true"          // Hos 중계 조회를 장부에 감사 기록
+	if n, err := strconv.Atoi(getEnvDefault("MINING_WORKERS", "")); err == nil && n > 0 {
+		MiningWorkers = n // nonce 탐색 워커 수 (기본 GOMAXPROCS)
+	}
+	PoWHashName = getEnvDefault("POW_HASH", "") // 새 체인의 PoW 해시 규칙 (sha256-json | sha256d | sha3-256)
+	if err := loadDifficultyConfig(); err != nil {
+		log.Fatalf("[START] difficulty params: %v", err) // 새 체인의 난이도 조정 규칙 (DIFF_*, difficulty.go)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("READY_MAX_LAG", "")); err == nil && n >= 0 {
+		ReadyMaxLag = n // 준비 상태로 볼 최대 동기화 지연(블록)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("ANCHOR_RECONCILE_INTERVAL", "")); err == nil && n >= 0 {
+		AnchorReconcileInterval = n // Hos 누락 앵커 점검 주기(초, 0 이면 비활성)
+	}
+	ConsistencyCheckEnabled = getEnvDefault("CONSISTENCY_CHECK", "false") == "true" // 앵커 루트와 Hos 블록 정합성 표본 점검
+	if n, err := strconv.Atoi(getEnvDefault("CONSISTENCY_CHECK_INTERVAL", "")); err == nil && n > 0 {
+		ConsistencyCheckInterval = n // 정합성 점검 주기(초)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("CONSISTENCY_CHECK_SAMPLE", "")); err == nil && n > 0 {
+		ConsistencyCheckSample = n // 주기당 점검할 앵커 수
+	}
+	if n, err := strconv.Atoi(getEnvDefault("MAX_PENDING_ENTRIES", "")); err == nil && n >= 0 {
+		MaxPendingEntries = n // 메모리풀 최대 레코드 수 (0 이면 제한 없음)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("MAX_PENDING_BYTES", "")); err == nil && n >= 0 {
+		MaxPendingBytes = n // 메모리풀 최대 크기(바이트, 0 이면 제한 없음)
+	}
+	if PendingEvictPolicy = getEnvDefault("PENDING_EVICT_POLICY", PendingEvictReject); !validPendingEvictPolicy(PendingEvictPolicy) {
+		log.Fatalf("[START] PENDING_EVICT_POLICY must be %s or %s", PendingEvictReject, PendingEvictOldest) // 메모리풀 한도 초과 시 처리
+	}
+	if nodeRole = getEnvDefault("NODE_ROLE", RoleValidator); nodeRole != RoleValidator && nodeRole != RoleObserver {
+		log.Fatalf("[START] NODE_ROLE must be %s or %s", RoleValidator, RoleObserver) // observer : 동기화/조회 전용 (observer.go)
+	}
+	if err := initWebhooks(os.Getenv("WEBHOOK_URLS"), os.Getenv("WEBHOOK_EVENTS"), os.Getenv("WEBHOOK_SECRET")); err != nil {
+		log.Fatalf("[WEBHOOK] %v", err) // 블록/앵커/부트노드 이벤트 외부 통지 (webhook.go)
+	}
+	// 신규 Hos 체인 등록 시 내려줄 계약 템플릿 (CONTRACT_TEMPLATE_FILE, 없으면 기본값)
+	if err := initContractTemplate(os.Getenv("CONTRACT_TEMPLATE_FILE")); err != nil {
+		log.Fatal("[START] contract template: ", err)
+	}
+
+	// 노드 간 mTLS (TLS_CERT_FILE/TLS_KEY_FILE 지정 시)
+	initNodeTLS()
+	// 노드 간 HTTP 클라이언트 (제한시간, 재시도, 회로 차단 : peerclient.go)
+	initPeerClient()
+	// API 인증 설정 (API_KEYS / JWT_SECRET / PEER_TOKEN : auth.go)
+	initAuth()
+	// NODE_ADDR=auto 면 외부 접속 주소 탐지, NODE_ADVERTISE_ADDRS 는 추가 광고 주소 (advertise.go)
+	initAdvertisedAddrs(strings.TrimPrefix(addr, ":"), os.Getenv("NODE_ADVERTISE_ADDRS"))
+
+	// 시작 시 NTP 오프셋 측정, 피어 시계 오차 경고/차단 기준 (timesync.go)
+	if err := initClockSync(getEnvDefault("NTP_SERVERS", NTPServerDefault), os.Getenv("CLOCK_SKEW_MAX"), getEnvDefault("CLOCK_SKEW_MODE", ClockSkewWarn)); err != nil {
+		log.Fatalf("[CLOCK] %v", err)
+	}
 
 	// 2) DB 초기화
 	initDB(dbPath)
@@ -26,6 +93,7 @@ func main() {
 	log.Printf("[START] LevelDB: %s\n", dbPath)
 	loadAllAnchorsAtBoot()
 	log.Printf("[START] Load AnchorMap From LevelDB: %s\n", dbPath)
+	ensureKeyPair()
 
 	// 3) 체인 부팅 (제네시스 자동 생성/복구 포함)
 	chain, err := newUpperChain(govID)
@@ -34,8 +102,14 @@ func main() {
 	}
 	log.Printf("[START] UpperChain ready (gov_id=%s)\n", govID)
 
+	// 타 관할 Gov 체인 앵커 미러링 (읽기 전용, MIRROR_CHAINS 지정 시)
+	startMirrors(getEnvDefault("MIRROR_CHAINS", ""))
+
+	// 재시작 전 끝나지 않은 관리 작업 정리
+	recoverJobs()
+
 	// 4) HTTP 라우팅 등록
-	mux := http.NewServeMux()
+	mux := newRouteMux() // 등록 경로를 기록하여 OpenAPI 문서 생성 (openapi.go)
 	// 사용자와 상호작용을 위한 API 등록
 	RegisterAPI(mux, chain)
 	// 노드 간 통신 엔드포인트 등록
@@ -43,23 +117,113 @@ func main() {
 	//	   - /mine/start : 노드 간 채굴 요청 전파
 	//     - /receiveBlock : 다른 노드가 보낸 확정 블록 수신
 	//	   - /register : 부트노드가 신규노드를 네트워크에 참여시킴
+	//	   - /whoami : 요청 출발 주소 에코 (NAT 외부 주소 탐지), 이 노드의 광고 주소
 	//	   - /bootNotify : 부트노드 변경 수신
 	//	   - /addAnchor : Hos 체인으로부터 Anchor 수신, 해당 Hos의 부트노드 주소를 다른 Gov 노드에 전파
 	//	   - /hosBootNotify : Gov 부트노드로부터 전파된 Hos 부트노드 주소를 수신
-	mux.HandleFunc("/addPeer", addPeer)
-	mux.HandleFunc("/mine/start", handleMineStart)
-	mux.HandleFunc("/receiveBlock", receiveBlock)
-	mux.HandleFunc("/register", registerPeer)
-	mux.HandleFunc("/bootNotify", bootNotify)
-	mux.HandleFunc("/addAnchor", addAnchor)
-	mux.HandleFunc("/hosBootNotify", hosBootNotify)
+	//	   - /registerHosChain : 신규 Hos 체인 부트노드 등록 (서명 확인 후 모든 Gov 노드에 전파, Gov 노드 목록/계약 템플릿 반환)
+	//	   - /getPublicKey : 공개키 반환 (커밋먼트 서명 검증용)
+	//	   - /commitment : 체인 상태 집계 커밋먼트 조회
+	//	   - /chain/info : 체인 식별 정보 (chain ID, 제네시스, 합의 방식, 해시 규칙, 검증자 집합 해시, 프로토콜 버전)
+	//	   - /metrics : Prometheus 메트릭 (체인 높이, 채굴, 동기화 지연 등)
+	//	   - /onboarding/apply : Hos 부트노드의 기관 가입 신청 접수 (부트노드 전용)
+	//	   - /onboarding/vote : 운영자의 가입 승인/거절 투표 (operator 전용, 노드 키로 서명 후 부트노드에 전달)
+	//	   - /onboarding/ballot : 부트노드가 검증자 투표를 수신하여 장부에 기록
+	//	   - /onboarding/status : 기관 가입 현황 조회
+	//	   - /hosKeyRotation : Hos 부트노드가 전달한 노드 키 교체 공지를 장부에 기록 (부트노드 전용)
+	//	   - /hosKeys : 장부에 기록된 Hos 노드별 현재 공개키 조회
+	//	   - /mirror/chains : 미러링 중인 타 관할 Gov 체인 현황
+	//	   - /mirror/anchors : 미러링된 앵커 조회 (origin=foreign)
+	//	   - /mirror/verify : 미러 앵커 기준 검증 (origin=foreign)
+	//	   - /verify : 최종 사용자용 Merkle 증명 검증 (앵커 기록 대조 후 서명 영수증 반환)
+	//	   - /anchor/status : Hos 블록 루트의 앵커 상태 조회 (anchored/pending/unknown)
+	//	   - /anchors : Hos 별 앵커 이력 (포함된 상위 블록 번호/해시/시각, from/to 블록 범위)
+	//	   - /anchors/latest : Hos 별 최신 앵커와 Hos 부트노드 주소
+	//	   - /anchor/proof : 앵커 포함 증명 (상위 블록 번호, 블록 내 머클 경로, 블록 헤더, 서명)
+	//	   - /proof/full : Hos 레코드 => Hos 블록 루트 => 상위 블록까지 이어지는 단일 증명 묶음
+	//	   - /ws/events : 블록 확정/앵커 수락/부트노드 선출/피어 변동 이벤트 WebSocket 스트림 (Upgrade 없으면 SSE)
+	//	   - /events : 동일 이벤트의 SSE 스트림
+	//	   - /jobs, /jobs/{id} : 비동기 관리 작업 목록/상태 조회, 취소(DELETE)
+	//	   - /admin/reindex : 앵커 색인 재구성 작업 시작 (202 + 작업 ID)
+	//	   - /admin/audit : 장부 무결성 감사 작업 시작 (202 + 작업 ID)
+	//	   - /admin/finalize : 대기 중인 앵커로 즉시 채굴 시작
+	//	   - /admin/resync : 누적 작업량이 가장 큰 피어 체인과 즉시 분기 교체 작업 시작 (202 + 작업 ID)
+	//	   - /admin/backup : LevelDB 스냅샷 전체를 tar.gz 로 내려받음 (노드 중단 없이, backup.go)
+	//	   - /admin/restore : 백업 tar.gz 설치 후 높이/블록 해시 검증
+	//	   - /admin/export : 블록 전체를 NDJSON 아카이브로 스트리밍 (?from=N, chainarchive.go)
+	//	   - /admin/import : 아카이브 블록을 검증하며 로컬 체인에 이어 붙임 (새 노드 이관)
+	//	   - /admin/webhooks : 웹훅 대상별 전송/실패/버림 현황 (WEBHOOK_URLS, webhook.go)
+	//	   - /patient/records : Hos 환자별 레코드 조회를 이 노드 서명으로 중계 (X-Requester 필수)
+	//	   - /audit/queries : 장부에 기록된 중계 조회 감사 이력 조회 (POST 는 노드 간 감사 기록 전달)
+	//	   - /healthz : 프로세스 생존 확인 (liveness)
+	//	   - /readyz : DB 열림, 제네시스 존재, 최고 피어 대비 동기화 지연 확인 (readiness, 준비 안 되면 503)
+	//	   - /openapi.json : 등록된 경로로부터 생성한 OpenAPI 3 문서
+	//	   - /docs : API 문서 뷰어
+	//	   - /dashboard : 내장 실시간 대시보드 (블록/메모리풀/피어/채굴 상태/Hos 별 앵커 + 이벤트 스트림, dashboard.go)
+	//	   - /clock : NTP 오프셋, 피어별 시계 오차와 판정 (CLOCK_SKEW_MAX/CLOCK_SKEW_MODE, timesync.go)
+	//	   (mTLS 활성 시 노드 간 엔드포인트는 고정된 인증서를 제시한 노드만 호출 가능)
+	//	   (같은 체인 노드 간 엔드포인트는 X-Chain-ID 가 없거나 다른 요청 거절 : chaininfo.go)
+	//	   (인증 사용 시 노드 간 엔드포인트는 peer, 운영 엔드포인트는 operator 역할 필요 : auth.go)
+	//	   (NODE_ROLE=observer 면 앵커 접수/즉시 채굴 요청은 403 : observer.go)
+	//	   (모든 경로는 /v1/<경로> 로도 호출 가능, 버전 없는 경로는 폐기 예정 헤더 포함 / GET /v1/meta : 지원 기능 조회)
+	mux.HandleFunc("/addPeer", requireNodeCert(requireSameChain(requireRole(RolePeer, addPeer))))
+	mux.HandleFunc("/mine/start", requireNodeCert(requireSameChain(requireRole(RolePeer, handleMineStart))))
+	mux.HandleFunc("/receiveBlock", requireNodeCert(requireSameChain(requireRole(RolePeer, receiveBlock))))
+	mux.HandleFunc("/register", requireRole(RolePeer, registerPeer))
+	mux.HandleFunc("/whoami", handleWhoami)
+	mux.HandleFunc("/bootNotify", requireNodeCert(requireSameChain(requireRole(RolePeer, bootNotify))))
+	mux.HandleFunc("/addAnchor", countAnchorResults(addAnchor))
+	mux.HandleFunc("/hosBootNotify", requireNodeCert(requireRole(RolePeer, hosBootNotify)))
+	mux.HandleFunc("/registerHosChain", handleRegisterHosChain)
+	mux.HandleFunc("/contracts", handleContracts)
+	mux.HandleFunc("/contracts/search", handleSearchContracts)
+	mux.HandleFunc("/getPublicKey", getPublicKey)
+	mux.HandleFunc("/commitment", handleCommitment)
+	mux.HandleFunc("/chain/info", handleChainInfo)
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/onboarding/apply", handleOnboardingApply)
+	mux.HandleFunc("/onboarding/vote", requireRole(RoleOperator, handleOnboardingVote))
+	mux.HandleFunc("/onboarding/ballot", requireNodeCert(requireSameChain(requireRole(RolePeer, handleOnboardingBallot))))
+	mux.HandleFunc("/onboarding/status", handleOnboardingStatus)
+	mux.HandleFunc("/hosKeyRotation", handleHosKeyRotation)
+	mux.HandleFunc("/hosKeys", handleHosKeys)
+	mux.HandleFunc("/mirror/chains", handleMirrorChains)
+	mux.HandleFunc("/mirror/anchors", handleMirrorAnchors)
+	mux.HandleFunc("/mirror/verify", handleMirrorVerify)
+	mux.HandleFunc("/verify", handleVerify)
+	mux.HandleFunc("/anchor/status", handleAnchorStatus)
+	mux.HandleFunc("/anchors", handleAnchorHistory)
+	mux.HandleFunc("/anchors/latest", handleLatestAnchors)
+	mux.HandleFunc("/anchor/proof", handleAnchorProof)
+	mux.HandleFunc("/proof/full", handleFullProof)
+	mux.HandleFunc("/ws/events", handleWSEvents)
+	mux.HandleFunc("/events", handleSSEEvents)
+	mux.HandleFunc("/jobs", requireRole(RoleOperator, handleJobs))
+	mux.HandleFunc("/jobs/", requireRole(RoleOperator, handleJob))
+	mux.HandleFunc("/admin/reindex", requireRole(RoleOperator, handleStartJob("reindex", reindexJob)))
+	mux.HandleFunc("/admin/audit", requireRole(RoleOperator, handleStartJob("audit", auditJob)))
+	mux.HandleFunc("/admin/finalize", requireRole(RoleOperator, handleAdminFinalize))
+	mux.HandleFunc("/admin/resync", requireRole(RoleOperator, handleStartJob("resync", resyncJob)))
+	mux.HandleFunc("/admin/backup", requireRole(RoleOperator, handleAdminBackup))
+	mux.HandleFunc("/admin/restore", requireRole(RoleOperator, handleAdminRestore))
+	mux.HandleFunc("/admin/export", requireRole(RoleOperator, handleAdminExport))
+	mux.HandleFunc("/admin/import", requireRole(RoleOperator, handleAdminImport))
+	mux.HandleFunc("/admin/webhooks", requireRole(RoleOperator, handleWebhooks))
+	mux.HandleFunc("/patient/records", handlePatientRecords)
+	mux.HandleFunc("/audit/queries", handleQueryAudits)
+	mux.HandleFunc("/healthz", handleHealthz)
+	mux.HandleFunc("/readyz", handleReadyz)
+	mux.HandleFunc("/openapi.json", handleOpenAPI(mux, "Gov node API"))
+	mux.HandleFunc("/docs", handleDocs)
+	mux.HandleFunc("/dashboard", handleDashboard)
+	mux.HandleFunc("/clock", handleClock)
 
 	mux.Handle("/", http.FileServer(http.Dir("./static")))
 
 	// 5) 서버 시작
 	go func() {
 		log.Println("[START] NODE Running on", addr)
-		if err := http.ListenAndServe(addr, mux); err != nil {
+		if err := serveNode(addr, withCompression(withAPIVersion(mux.ServeMux))); err != nil {
 			log.Fatal(err)
 		}
 	}()
@@ -67,10 +231,10 @@ func main() {
 	// 6) 자동 부트스트랩
 	//  부트노드가 아니라면 부트노드에 자신의 주소를 등록 -> 부트노드로부터 노드 주소 목록 받아 등록 -> 체인 동기화
 	if boot != "" && self != "" && boot != self {
-		payload := map[string]string{"addr": self, "gov_id": govID}
+		payload := registerReq{Addr: self, GovID: govID, Role: nodeRole}
 		b, _ := json.Marshal(payload)
 
-		resp, err := http.Post("http://"+boot+"/register", "application/json", strings.NewReader(string(b)))
+		resp, err := nodeClient.Post(nodeURL(boot, "/register"), "application/json", strings.NewReader(string(b)))
 		if err != nil {
 			log.Printf("[BOOT] register failed: %v", err)
 			return
@@ -80,13 +244,15 @@ func main() {
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
 			log.Printf("[BOOT] register failed : status=%d body=%s", resp.StatusCode, string(body))
-			log.Println("[BOOT] Now, This is Boot Node. skipping auto-join")
-			isBoot.Store(true)
+			if isObserver() {
+				log.Printf("[OBSERVER] retry register on next network watch")
+			} else {
+				log.Println("[BOOT] Now, This is Boot Node. skipping auto-join")
+				isBoot.Store(true)
+			}
 		} else {
 
-			var reg struct {
-				Peers []string `json:"peers"`
-			}
+			var reg registerResp
 			if err := json.NewDecoder(resp.Body).Decode(&reg); err != nil {
 				log.Printf("[BOOT] decode peers failed: %v", err)
 				return
@@ -98,11 +264,16 @@ func main() {
 			for _, addr := range reg.Peers {
 				addPeerInternal(addr)
 			}
+			for addr, pin := range reg.CertPins {
+				pinPeerCert(addr, pin)
+			}
 
 			// 초기 체인 동기화(부트노드로부터)
 			go syncChain(boot)
 			log.Printf("[BOOT] Chain Initialized by %s(boot node); peers=%v", boot, reg.Peers)
 		}
+	} else if isObserver() {
+		log.Fatal("[START] NODE_ROLE=observer needs BOOTSTRAP_ADDR of another node")
 	} else {
 		log.Println("[BOOT] This is Boot Node, skipping auto-join")
 		isBoot.Store(true)
@@ -114,15 +285,28 @@ func main() {
 		startNetworkWatcher()
 	}()
 
+	// 관찰 노드는 채굴하지 않고 체인 감시(동기화)만 짧은 주기로 수행 (observer.go)
+	if isObserver() {
+		ChainWatcherTime = ObserverSyncTime
+		log.Printf("[OBSERVER] read-only observer mode; mining watcher disabled")
+	} else {
+		go func() {
+			log.Printf("[WATCHER] starting unified mining watcher (%ds interval)", MiningWatcherTime)
+			startMiningWatcher()
+		}()
+	}
+
 	go func() {
-		log.Printf("[WATCHER] starting unified mining watcher (%ds interval)", MiningWatcherTime)
-		startMiningWatcher()
+		log.Printf("[WATCHER] starting unified chain watcher (%ds interval)", ChainWatcherTime)
+		startChainWatcher()
 	}()
-	//
-	//go func() {
-	//	log.Printf("[WATCHER] starting unified chain watcher (%ds interval)", ChainWatcherTime)
-	//	startChainWatcher()
-	//}()
+
+	go func() {
+		log.Printf("[WATCHER] starting anchor reconciler (%ds interval)", AnchorReconcileInterval)
+		startAnchorReconciler()
+	}()
+
+	go startConsistencyChecker()
 
 	// 8) 메인 Go 루틴 유지
 	select {}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Mirror (타 관할 Gov 체인 앵커 미러링, 읽기 전용)
+// ------------------------------------------------------------
+// 국경 간 검증을 위해 다른 관할 Gov 체인의 앵커를 따라가며 보관.
+// - 등록 형식 (MIRROR_CHAINS) : "<name>@<addr>,..."
+//   ex) "jp@gov-jp-boot:5000,sg@gov-sg-boot:5000"
+// - 원격 /blocks 를 주기적으로 조회하여 체인 연결/머클루트/PoW 를 검증한 뒤
+//   확인 수가 MirrorConfirmations 이상인 블록의 앵커만 "mirror_<name>_" 네임스페이스에 저장
+// - 미러 앵커는 자체 장부/anchorMap 과 분리되어 앵커 수락·조회에 사용되지 않음 (쓰기 API 없음)
+// - POST /mirror/verify : 미러 앵커 기준 검증, 결과에 origin="foreign" 과 출처 체인을 명시
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	MirrorPollInterval  = 30 // 초
+	MirrorConfirmations = 6  // 포크 교체 가능성이 있는 최근 블록은 미러링하지 않음
+	MirrorPageSize      = 50
+	mirrorPrefix        = "mirror_"
+	OriginForeign       = "foreign"
+)
+
+// 미러링 대상 체인과 추적 상태
+type MirrorChain struct {
+	Name     string `json:"name"`
+	Addr     string `json:"addr"`
+	GovID    string `json:"gov_id"`    // 원격 제네시스의 gov_id
+	Height   int    `json:"height"`    // 미러링 완료된 마지막 블록 번호 (-1 : 없음)
+	Anchors  int    `json:"anchors"`   // 저장된 앵커 수
+	LastSync string `json:"last_sync"` // 마지막 동기화 시각
+	Error    string `json:"error,omitempty"`
+}
+
+// 미러링된 앵커 (읽기 전용)
+type MirroredAnchor struct {
+	Origin     string `json:"origin"` // 항상 "foreign"
+	Chain      string `json:"chain"`  // 미러 체인 이름
+	GovID      string `json:"gov_id"` // 원격 Gov 체인 식별자
+	HosID      string `json:"hos_id"`
+	Root       string `json:"root"`
+	AnchorTs   string `json:"anchor_ts"`
+	BlockIndex int    `json:"block_index"`
+	BlockHash  string `json:"block_hash"`
+}
+
+var (
+	mirrorChains   = map[string]*MirrorChain{}
+	mirrorChainsMu sync.RWMutex
+)
+
+func mirrorKey(chain string, parts ...string) []byte {
+	return []byte(mirrorPrefix + chain + "_" + strings.Join(parts, "_"))
+}
+
+// MIRROR_CHAINS 파싱 후 체인별 추적 루틴 시작
+func startMirrors(spec string) {
+	for _, ent := range strings.Split(spec, ",") {
+		ent = strings.TrimSpace(ent)
+		if ent == "" {
+			continue
+		}
+		name, addr, ok := strings.Cut(ent, "@")
+		if !ok || name == "" || addr == "" || strings.Contains(name, "_") {
+			log.Printf("[MIRROR][WARN] invalid mirror spec: %s", ent)
+			continue
+		}
+		mc := &MirrorChain{Name: name, Addr: addr, Height: -1}
+		if raw, ok := getMeta(string(mirrorKey(name, "last"))); ok {
+			var last UpperBlock
+			if err := json.Unmarshal([]byte(raw), &last); err == nil {
+				mc.Height = last.Index
+				mc.GovID = last.GovID
+			}
+		}
+		mc.Anchors = countMirroredAnchors(name)
+
+		mirrorChainsMu.Lock()
+		mirrorChains[name] = mc
+		mirrorChainsMu.Unlock()
+
+		log.Printf("[MIRROR] following %s (%s) from height %d", name, addr, mc.Height)
+		go func(name string) {
+			t := time.NewTicker(MirrorPollInterval * time.Second)
+			defer t.Stop()
+			for {
+				syncMirror(name)
+				<-t.C
+			}
+		}(name)
+	}
+}
+
+func countMirroredAnchors(chain string) int {
+	iter := db.NewIterator(util.BytesPrefix(mirrorKey(chain, "anchor", "")), nil)
+	defer iter.Release()
+	n := 0
+	for iter.Next() {
+		n++
+	}
+	return n
+}
+
+// 원격 체인의 다음 블록들을 검증 후 미러 네임스페이스에 반영
+func syncMirror(name string) {
+	mirrorChainsMu.RLock()
+	mc := *mirrorChains[name]
+	mirrorChainsMu.RUnlock()
+
+	var last UpperBlock
+	hasLast := false
+	if raw, ok := getMeta(string(mirrorKey(name, "last"))); ok {
+		hasLast = json.Unmarshal([]byte(raw), &last) == nil
+	}
+
+	added := 0
+	var syncErr error
+	for {
+		q := url.Values{}
+		q.Set("offset", strconv.Itoa(mc.Height+1))
+		q.Set("limit", strconv.Itoa(MirrorPageSize))
+		resp, err := http.Get("http://" + mc.Addr + "/blocks?" + q.Encode())
+		if err != nil {
+			syncErr = err
+			break
+		}
+		var page blocksPage
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			syncErr = fmt.Errorf("invalid /blocks: %v", err)
+			break
+		}
+
+		// 확인 수가 부족한 최근 블록은 다음 주기로 미룸
+		safeHeight := page.Total - 1 - MirrorConfirmations
+		progressed := false
+		for _, nb := range page.Items {
+			if nb.Index != mc.Height+1 || nb.Index > safeHeight {
+				break
+			}
+			if hasLast {
+				// 타 관할 체인의 조상 블록은 보관하지 않으므로 난이도 규칙은 생략 (PoW 해시 조건만 확인)
+				if err := validateUpperBlock(nb, last, nil); err != nil {
+					syncErr = fmt.Errorf("block #%d invalid: %v", nb.Index, err)
+					break
+				}
+			} else if nb.Index != 0 {
+				syncErr = fmt.Errorf("expected genesis, got #%d", nb.Index)
+				break
+			}
+			n, err := saveMirroredBlock(name, nb)
+			if err != nil {
+				syncErr = err
+				break
+			}
+			last, hasLast = nb, true
+			mc.Height, mc.GovID = nb.Index, nb.GovID
+			mc.Anchors += n
+			added += n
+			progressed = true
+		}
+		if syncErr != nil || !progressed || mc.Height >= safeHeight {
+			break
+		}
+	}
+
+	mc.LastSync = time.Now().Format(time.RFC3339)
+	mc.Error = ""
+	if syncErr != nil {
+		mc.Error = syncErr.Error()
+		log.Printf("[MIRROR][WARN] %s: %v", name, syncErr)
+	} else if added > 0 {
+		log.Printf("[MIRROR] %s synced to #%d (+%d anchors)", name, mc.Height, added)
+	}
+	mirrorChainsMu.Lock()
+	mirrorChains[name] = &mc
+	mirrorChainsMu.Unlock()
+}
+
+// 블록 내 일반 앵커(기관 가입 기록 제외)를 미러 앵커로 저장하고 추적 위치 갱신
+func saveMirroredBlock(chain string, b UpperBlock) (int, error) {
+	n := 0
+	for _, rec := range b.Records {
+		if rec.Kind != "" {
+			continue
+		}
+		ma := MirroredAnchor{
+			Origin:     OriginForeign,
+			Chain:      chain,
+			GovID:      b.GovID,
+			HosID:      rec.HosID,
+			Root:       rec.LowerRoot,
+			AnchorTs:   rec.AnchorTimestamp,
+			BlockIndex: b.Index,
+			BlockHash:  b.BlockHash,
+		}
+		data, _ := json.Marshal(ma)
+		if err := countDBError(db.Put(mirrorKey(chain, "anchor", rec.HosID, rec.LowerRoot), data, nil)); err != nil {
+			return n, err
+		}
+		n++
+	}
+	data, _ := json.Marshal(b)
+	return n, putMeta(string(mirrorKey(chain, "last")), string(data))
+}
+
+// 미러 앵커 조회 (hosID 가 비어 있으면 전체)
+func listMirroredAnchors(chain, hosID string) []MirroredAnchor {
+	prefix := mirrorKey(chain, "anchor", "")
+	if hosID != "" {
+		prefix = mirrorKey(chain, "anchor", hosID, "")
+	}
+	out := []MirroredAnchor{}
+	iter := db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var ma MirroredAnchor
+		if err := json.Unmarshal(iter.Value(), &ma); err == nil && (hosID == "" || ma.HosID == hosID) {
+			out = append(out, ma)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].BlockIndex < out[j].BlockIndex })
+	return out
+}
+
+func getMirroredAnchor(chain, hosID, root string) (MirroredAnchor, bool) {
+	var ma MirroredAnchor
+	data, err := db.Get(mirrorKey(chain, "anchor", hosID, root), nil)
+	if err != nil {
+		return ma, false
+	}
+	return ma, json.Unmarshal(data, &ma) == nil
+}
+
+func mirrorChainsSnapshot() []MirrorChain {
+	mirrorChainsMu.RLock()
+	defer mirrorChainsMu.RUnlock()
+	out := make([]MirrorChain, 0, len(mirrorChains))
+	for _, mc := range mirrorChains {
+		out = append(out, *mc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// 미러 앵커 기준 검증 요청
+// - block_root 가 원격 체인에 앵커된 루트인지 확인
+// - leaf/proof 가 주어지면 block_root 에 대한 Merkle 증명도 재수행
+type MirrorVerifyRequest struct {
+	Chain        string      `json:"chain"`
+	HosID        string      `json:"hos_id"`
+	BlockRoot    string      `json:"block_root"`
+	Leaf         string      `json:"leaf,omitempty"`
+	Proof        [][2]string `json:"proof,omitempty"`
+	ProofVersion int         `json:"proof_version,omitempty"` // 증명 형식 버전 (없으면 0)
+}
+
+type MirrorVerifyResult struct {
+	Origin     string          `json:"origin"` // 항상 "foreign" (자체 장부 검증 결과와 구분)
+	Chain      string          `json:"chain"`
+	HosID      string          `json:"hos_id"`
+	BlockRoot  string          `json:"block_root"`
+	Anchored   bool            `json:"anchored"`              // 원격 체인에 앵커된 루트인지
+	ProofValid *bool           `json:"proof_valid,omitempty"` // leaf/proof 제공 시에만
+	Verified   bool            `json:"verified"`
+	Anchor     *MirroredAnchor `json:"anchor,omitempty"`
+	MirrorAt   int             `json:"mirror_height"` // 검증 시점의 미러링 높이
+	CheckedAt  string          `json:"checked_at"`
+}
+
+// POST /mirror/verify
+func handleMirrorVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req MirrorVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+	if req.Chain == "" || req.HosID == "" || req.BlockRoot == "" {
+		writeError(w, http.StatusBadRequest, "chain, hos_id and block_root required")
+		return
+	}
+
+	mirrorChainsMu.RLock()
+	mc, ok := mirrorChains[req.Chain]
+	height := 0
+	if ok {
+		height = mc.Height
+	}
+	mirrorChainsMu.RUnlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown mirror chain: "+req.Chain)
+		return
+	}
+
+	res := MirrorVerifyResult{
+		Origin:    OriginForeign,
+		Chain:     req.Chain,
+		HosID:     req.HosID,
+		BlockRoot: req.BlockRoot,
+		MirrorAt:  height,
+		CheckedAt: time.Now().Format(time.RFC3339),
+	}
+	if ma, ok := getMirroredAnchor(req.Chain, req.HosID, req.BlockRoot); ok {
+		res.Anchored = true
+		res.Anchor = &ma
+	}
+	res.Verified = res.Anchored
+	if req.Leaf != "" {
+		valid := proofValid(req.ProofVersion, req.Leaf, req.Proof, req.BlockRoot)
+		res.ProofValid = &valid
+		res.Verified = res.Anchored && valid
+	}
+	logInfo("[MIRROR] verify chain=%s hos=%s root=%s -> anchored=%v verified=%v", req.Chain, req.HosID, req.BlockRoot, res.Anchored, res.Verified)
+	writeJSON(w, http.StatusOK, res)
+}
+
+// GET /mirror/chains
+func handleMirrorChains(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, mirrorChainsSnapshot())
+}
+
+// GET /mirror/anchors?chain=<name>&hos_id=<id>
+func handleMirrorAnchors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	chain := r.URL.Query().Get("chain")
+	mirrorChainsMu.RLock()
+	_, ok := mirrorChains[chain]
+	mirrorChainsMu.RUnlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown mirror chain: "+chain)
+		return
+	}
+	items := listMirroredAnchors(chain, r.URL.Query().Get("hos_id"))
+	writeJSON(w, http.StatusOK, map[string]any{
+		"origin": OriginForeign,
+		"chain":  chain,
+		"count":  len(items),
+		"items":  items,
+	})
+}
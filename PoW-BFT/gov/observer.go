@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Observer Node (읽기 전용 관찰 노드)
+// ------------------------------------------------------------
+// - NODE_ROLE=observer : 장부 동기화와 조회 API 만 제공 (채굴, 부트노드 선출에 참여하지 않음)
+//   · 부트노드 등록 시 role=observer 를 알림 => 부트노드는 피어 명단만 돌려주고 피어 목록에 추가하지 않음
+//     (채굴 신호/신규 블록 전파, 선출 후보에서 제외)
+//   · 채굴 watcher 를 실행하지 않음
+//   · 등록에 실패하거나 살아있는 피어가 없어도 스스로 부트노드가 되지 않음
+//   · 신규 블록을 전달받지 않으므로 체인 감시 주기를 ObserverSyncTime 으로 줄여 작업량이 가장 큰 피어를 따라감
+//   · 네트워크 감시 주기마다 부트노드에 다시 등록해 새 피어 반영
+//   · 앵커 접수(/addAnchor), 즉시 채굴 요청은 403
+// - NODE_ROLE=validator (기본) : 기존 동작
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RoleValidator    = "validator"
+	RoleObserver     = "observer"
+	ObserverSyncTime = 10 // 관찰 노드 체인 감시 주기(초)
+)
+
+var nodeRole = RoleValidator // NODE_ROLE
+
+var errObserverReadOnly = errors.New("read-only observer node")
+
+func isObserver() bool {
+	return nodeRole == RoleObserver
+}
+
+// 관찰 노드 : 부트노드에 다시 등록하여 피어 명단 갱신 (네트워크 감시 주기마다)
+func refreshObserverPeers() {
+	bootAddr := getBootAddr()
+	b, _ := json.Marshal(registerReq{Addr: self, GovID: selfID(), Role: RoleObserver})
+	resp, err := nodeClient.Post(nodeURL(bootAddr, "/register"), "application/json", strings.NewReader(string(b)))
+	if err != nil {
+		log.Printf("[OBSERVER] peer refresh from %s failed: %v", bootAddr, err)
+		return
+	}
+	defer resp.Body.Close()
+	var reg registerResp
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&reg) != nil {
+		log.Printf("[OBSERVER] peer refresh from %s failed (status=%d)", bootAddr, resp.StatusCode)
+		return
+	}
+	addPeerInternal(bootAddr)
+	for _, addr := range reg.Peers {
+		addPeerInternal(addr)
+	}
+	for addr, pin := range reg.CertPins {
+		pinPeerCert(addr, pin)
+	}
+}
@@ -0,0 +1,502 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Onboarding (기관 가입 승인 절차)
+// ------------------------------------------------------------
+// - Hos 부트노드가 가입 신청서(신원 서류 해시, 노드 공개키, 제안 계약)를 Gov 부트노드에 제출
+//   · POST /onboarding/apply (부트노드 전용) : 서명 검증 후 신청서를 장부에 기록 (Kind = "onboard_apply")
+// - Gov 검증자(노드)들이 장부에 투표를 기록
+//   · POST /onboarding/vote {hos_id, approve} : 운영자가 자기 노드에 요청 => 노드 키로 서명 후 부트노드에 전달
+//   · POST /onboarding/ballot (노드 간) : 부트노드가 투표자/서명 확인 후 장부에 기록 (Kind = "onboard_vote")
+// - 블록 반영 시 모든 노드가 신청/투표를 재집계 (Key: "onboard_<hosID>")
+//   · 승인 수 >= 정족수 => approved (제안 계약을 contract_<hosID> 로 등록)
+//   · 거절 수 >= 정족수 => rejected (재신청 가능)
+// - ONBOARDING_REQUIRED=true(기본) 이면 승인된 기관의 앵커만 수락
+// - GET /onboarding/status?hos_id= : 신청/투표 현황 조회 (hos_id 생략 시 전체)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RecordKindOnboardApply = "onboard_apply"
+	RecordKindOnboardVote  = "onboard_vote"
+
+	OnboardPending  = "pending"
+	OnboardApproved = "approved"
+	OnboardRejected = "rejected"
+)
+
+var onboardingRequired = true
+
+// 가입 신청서 (Hos 부트노드 서명)
+type OnboardingApplication struct {
+	HosID    string            `json:"hos_id"`
+	HosBoot  string            `json:"hos_boot"`
+	DocsHash string            `json:"docs_hash"`        // 신원 증빙 서류 해시
+	PubKeys  map[string]string `json:"pub_keys"`         // Hos 노드 주소 => 공개키 PEM
+	Contract ContractData      `json:"contract"`         // 제안 계약
+	Ts       string            `json:"ts"`               // 신청 시각
+	Sig      string            `json:"sig"`              // Hos 부트노드 서명 (digest 대상)
+	Quorum   int               `json:"quorum,omitempty"` // 승인/거절 확정 정족수 (Gov 부트노드가 접수 시 지정)
+}
+
+// 서명 대상 다이제스트 (hex) : Sig, Quorum 제외
+func (a OnboardingApplication) digest() string {
+	return sha256Hex(jsonCanonical(struct {
+		HosID    string            `json:"hos_id"`
+		HosBoot  string            `json:"hos_boot"`
+		DocsHash string            `json:"docs_hash"`
+		PubKeys  map[string]string `json:"pub_keys"`
+		Contract ContractData      `json:"contract"`
+		Ts       string            `json:"ts"`
+	}{a.HosID, a.HosBoot, a.DocsHash, a.PubKeys, a.Contract, a.Ts}))
+}
+
+// 검증자 투표 (Gov 노드 서명)
+type OnboardingVote struct {
+	HosID    string `json:"hos_id"`
+	AppHash  string `json:"app_hash"` // 대상 신청서 digest
+	Voter    string `json:"voter"`    // 투표한 Gov 노드 주소
+	Approve  bool   `json:"approve"`
+	Ts       string `json:"ts"`
+	VoterKey string `json:"voter_key"` // 투표자 공개키 PEM
+	Sig      string `json:"sig"`
+}
+
+func (v OnboardingVote) digest() string {
+	return sha256Hex([]byte(fmt.Sprintf("onboard_vote|%s|%s|%s|%t|%s", v.HosID, v.AppHash, v.Voter, v.Approve, v.Ts)))
+}
+
+// 장부 재집계 결과
+type OnboardingState struct {
+	HosID        string                `json:"hos_id"`
+	Status       string                `json:"status"`
+	Application  OnboardingApplication `json:"application"`
+	AppHash      string                `json:"app_hash"`
+	AppliedBlock int                   `json:"applied_block"`
+	Votes        map[string]bool       `json:"votes"` // 투표자 => 찬성 여부
+	Approvals    int                   `json:"approvals"`
+	Rejections   int                   `json:"rejections"`
+	DecidedBlock int                   `json:"decided_block,omitempty"`
+}
+
+var (
+	queuedBallots   = make(map[string]bool) // 부트노드 : 장부 반영 대기 중인 투표 (hosID|appHash|voter)
+	queuedBallotsMu sync.Mutex
+)
+
+func onboardKey(hosID string) string { return "onboard_" + hosID }
+
+func getOnboardingState(hosID string) (OnboardingState, bool) {
+	return getOnboardingStateFrom(db, hosID)
+}
+func getOnboardingStateFrom(rd kvReader, hosID string) (OnboardingState, bool) {
+	v, err := rd.Get([]byte(onboardKey(hosID)), nil)
+	if err != nil {
+		return OnboardingState{}, false
+	}
+	var st OnboardingState
+	if err := json.Unmarshal([]byte(v), &st); err != nil {
+		return OnboardingState{}, false
+	}
+	return st, true
+}
+
+func putOnboardingState(tx *ledgerTxn, st OnboardingState) {
+	data, _ := json.Marshal(st)
+	tx.putMeta(onboardKey(st.HosID), string(data))
+}
+
+// 리전 서브 장부 앵커("<hos_id>@<region>")는 소속 기관 기준으로 판단
+func orgOf(hosID string) string {
+	if i := strings.LastIndex(hosID, "@"); i >= 0 {
+		return hosID[:i]
+	}
+	return hosID
+}
+
+func isOnboarded(hosID string) bool {
+	st, ok := getOnboardingState(hosID)
+	return ok && st.Status == OnboardApproved
+}
+
+// 가입 확정 정족수 : 현재 Gov 노드 수의 과반
+func onboardingQuorum() int {
+	return (len(peersSnapshot())+1)/2 + 1
+}
+
+// PEM 공개키로 hex 다이제스트의 ASN.1 서명 검증
+func verifyPemSignature(pubPem, digestHex, sigHex string) bool {
+	block, _ := pem.Decode([]byte(pubPem))
+	if block == nil {
+		return false
+	}
+	pubIfc, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return false
+	}
+	pub, ok := pubIfc.(*ecdsa.PublicKey)
+	if !ok {
+		return false
+	}
+	digest, err1 := hex.DecodeString(digestHex)
+	sig, err2 := hex.DecodeString(sigHex)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return ecdsa.VerifyASN1(pub, digest, sig)
+}
+
+// 노드 주소의 공개키 조회 (/getPublicKey)
+func fetchPublicKey(addr string) (string, error) {
+	resp, err := nodeClient.Get(nodeURL(addr, "/getPublicKey"))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status=%d", resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	return string(b), err
+}
+
+// 블록에 기록된 가입 신청/투표 반영 (updateIndicesForBlock 에서 호출)
+func applyOnboardingRecord(tx *ledgerTxn, blockIndex int, rec AnchorRecord) error {
+	switch rec.Kind {
+	case RecordKindOnboardApply:
+		if rec.Application == nil {
+			return nil
+		}
+		app := *rec.Application
+		appHash := app.digest()
+		if rec.LowerRoot != appHash {
+			log.Printf("[ONBOARD][WARN] Block #%d: application digest mismatch for %s", blockIndex, rec.HosID)
+			return nil
+		}
+		// 동일 신청서 재반영(동기화 등)은 무시, 거절/미신청 상태에서만 새 신청 접수
+		if st, ok := getOnboardingStateFrom(tx, app.HosID); ok && (st.AppHash == appHash || st.Status != OnboardRejected) {
+			return nil
+		}
+		log.Printf("[ONBOARD] Application from %s recorded in block #%d (quorum=%d)", app.HosID, blockIndex, app.Quorum)
+		putOnboardingState(tx, OnboardingState{
+			HosID:        app.HosID,
+			Status:       OnboardPending,
+			Application:  app,
+			AppHash:      appHash,
+			AppliedBlock: blockIndex,
+			Votes:        map[string]bool{},
+		})
+		return nil
+
+	case RecordKindOnboardVote:
+		if rec.Vote == nil {
+			return nil
+		}
+		v := *rec.Vote
+		if rec.LowerRoot != v.digest() || !verifyPemSignature(v.VoterKey, v.digest(), v.Sig) {
+			log.Printf("[ONBOARD][WARN] Block #%d: invalid vote from %s", blockIndex, v.Voter)
+			return nil
+		}
+		st, ok := getOnboardingStateFrom(tx, v.HosID)
+		if !ok || st.AppHash != v.AppHash || st.Status != OnboardPending {
+			return nil
+		}
+		queuedBallotsMu.Lock()
+		delete(queuedBallots, v.HosID+"|"+v.AppHash+"|"+v.Voter)
+		queuedBallotsMu.Unlock()
+		if _, voted := st.Votes[v.Voter]; voted {
+			return nil
+		}
+		st.Votes[v.Voter] = v.Approve
+		if v.Approve {
+			st.Approvals++
+		} else {
+			st.Rejections++
+		}
+		switch {
+		case st.Approvals >= st.Application.Quorum:
+			st.Status = OnboardApproved
+			st.DecidedBlock = blockIndex
+			c := st.Application.Contract
+			c.HosID = st.HosID
+			data, _ := json.Marshal(c)
+			tx.putMeta("contract_"+st.HosID, string(data))
+			log.Printf("[ONBOARD][APPROVED] %s approved in block #%d (%d/%d)", st.HosID, blockIndex, st.Approvals, st.Application.Quorum)
+		case st.Rejections >= st.Application.Quorum:
+			st.Status = OnboardRejected
+			st.DecidedBlock = blockIndex
+			log.Printf("[ONBOARD][REJECTED] %s rejected in block #%d (%d/%d)", st.HosID, blockIndex, st.Rejections, st.Application.Quorum)
+		}
+		putOnboardingState(tx, st)
+	}
+	return nil
+}
+
+// 가입 신청 접수 (부트노드 전용)
+// POST /onboarding/apply {OnboardingApplication}
+func handleOnboardingApply(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !isBoot.Load() {
+		writeError(w, http.StatusForbidden, "only boot node accepts applications")
+		return
+	}
+	var app OnboardingApplication
+	if err := json.NewDecoder(r.Body).Decode(&app); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+	if app.HosID == "" || app.HosBoot == "" || app.DocsHash == "" || app.Sig == "" {
+		writeError(w, http.StatusBadRequest, "hos_id, hos_boot, docs_hash and sig required")
+		return
+	}
+
+	// 이미 진행 중이거나 승인된 신청이면 현재 상태만 반환 (재시작 시 중복 신청 방지)
+	if st, ok := getOnboardingState(app.HosID); ok && st.Status != OnboardRejected {
+		writeJSON(w, http.StatusOK, st)
+		return
+	}
+
+	// 신청 Hos 부트노드의 공개키로 서명 확인 (앵커 검증과 동일하게 부트노드 주소에서 조회)
+	pubPem, err := fetchPublicKey(app.HosBoot)
+	if err != nil {
+		log.Printf("[ONBOARD][ERROR] failed to fetch public key from %s: %v", app.HosBoot, err)
+		writeError(w, http.StatusBadGateway, "failed to fetch public key")
+		return
+	}
+	if tlsEnabled {
+		if pin := clientCertPin(r); pin == "" || pin != peerCertPin(app.HosBoot) {
+			writeError(w, http.StatusForbidden, "client certificate does not match hos_boot")
+			return
+		}
+	}
+	if k, ok := app.PubKeys[app.HosBoot]; ok && strings.TrimSpace(k) != strings.TrimSpace(pubPem) {
+		writeError(w, http.StatusBadRequest, "pub_keys does not match hos_boot key")
+		return
+	}
+	appHash := app.digest()
+	if !verifyPemSignature(pubPem, appHash, app.Sig) {
+		log.Printf("[ONBOARD][INVALID] Signature verification failed for %s", app.HosID)
+		writeError(w, http.StatusForbidden, "invalid signature")
+		return
+	}
+	// hos_boot 가 실제로 신청한 Hos 체인을 운영하는지 확인 (/chain/info 미제공 노드는 생략)
+	if info, err := fetchChainInfo(app.HosBoot); err != nil {
+		log.Printf("[ONBOARD][WARN] chain info unavailable from %s: %v", app.HosBoot, err)
+	} else if info.ChainID != app.HosID {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("hos_boot serves chain %s, not %s", info.ChainID, app.HosID))
+		return
+	}
+
+	app.Quorum = onboardingQuorum()
+	appendPending([]AnchorRecord{{
+		HosID:           app.HosID,
+		Kind:            RecordKindOnboardApply,
+		LowerRoot:       appHash,
+		AccessCatalog:   []string{},
+		AnchorTimestamp: app.Ts,
+		Application:     &app,
+	}})
+	log.Printf("[ONBOARD] Application from %s queued (docs=%s..., quorum=%d)", app.HosID, app.DocsHash[:min(8, len(app.DocsHash))], app.Quorum)
+	writeJSON(w, http.StatusAccepted, map[string]any{"hos_id": app.HosID, "status": "submitted", "app_hash": appHash})
+}
+
+// 검증자 투표 (운영자 => 자기 Gov 노드)
+// POST /onboarding/vote {hos_id, approve}
+//   - 노드 키로 투표에 서명하므로 operator 역할 필요 (main.go 에서 requireRole 로 감쌈)
+func handleOnboardingVote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req struct {
+		HosID   string `json:"hos_id"`
+		Approve bool   `json:"approve"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.HosID == "" {
+		writeError(w, http.StatusBadRequest, "hos_id required")
+		return
+	}
+	defer r.Body.Close()
+
+	st, ok := getOnboardingState(req.HosID)
+	if !ok || st.Status != OnboardPending {
+		writeError(w, http.StatusNotFound, "no pending application for hos_id")
+		return
+	}
+	if _, voted := st.Votes[self]; voted {
+		writeError(w, http.StatusConflict, "already voted")
+		return
+	}
+
+	pubPem, _ := getMeta("meta_gov_pubkey")
+	v := OnboardingVote{
+		HosID:    req.HosID,
+		AppHash:  st.AppHash,
+		Voter:    self,
+		Approve:  req.Approve,
+		Ts:       time.Now().UTC().Format(time.RFC3339),
+		VoterKey: pubPem,
+	}
+	digest, _ := hex.DecodeString(v.digest())
+	sig, err := signWithGovKey(digest)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "sign vote: "+err.Error())
+		return
+	}
+	v.Sig = sig
+
+	// 부트노드가 장부에 기록
+	if isBoot.Load() {
+		if status, err := acceptBallot(v); err != nil {
+			writeError(w, status, err.Error())
+			return
+		}
+	} else {
+		body, _ := json.Marshal(v)
+		resp, err := nodeClient.Post(nodeURL(getBootAddr(), "/onboarding/ballot"), "application/json", bytes.NewReader(body))
+		if err != nil {
+			writeError(w, http.StatusBadGateway, "boot node unreachable: "+err.Error())
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			msg, _ := io.ReadAll(resp.Body)
+			writeError(w, resp.StatusCode, strings.TrimSpace(string(msg)))
+			return
+		}
+	}
+	log.Printf("[ONBOARD] Voted %s for %s", map[bool]string{true: "approve", false: "reject"}[req.Approve], req.HosID)
+	writeJSON(w, http.StatusAccepted, v)
+}
+
+// 투표 수신 (부트노드, 노드 간)
+// POST /onboarding/ballot {OnboardingVote}
+func handleOnboardingBallot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !isBoot.Load() {
+		writeError(w, http.StatusForbidden, "only boot node records ballots")
+		return
+	}
+	var v OnboardingVote
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+	if status, err := acceptBallot(v); err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// 투표 확인 후 장부 기록 대기열에 추가
+func acceptBallot(v OnboardingVote) (int, error) {
+	// 투표자는 현재 Gov 네트워크의 노드여야 함
+	known := v.Voter == self
+	for _, p := range peersSnapshot() {
+		known = known || p == v.Voter
+	}
+	if !known {
+		return http.StatusForbidden, fmt.Errorf("voter %s is not a gov validator", v.Voter)
+	}
+	// 투표자 주소에서 공개키를 직접 조회하여 제출된 키와 비교
+	var pubPem string
+	if v.Voter == self {
+		pubPem, _ = getMeta("meta_gov_pubkey")
+	} else {
+		k, err := fetchPublicKey(v.Voter)
+		if err != nil {
+			return http.StatusBadGateway, fmt.Errorf("failed to fetch voter key: %v", err)
+		}
+		pubPem = k
+	}
+	if strings.TrimSpace(pubPem) != strings.TrimSpace(v.VoterKey) || !verifyPemSignature(pubPem, v.digest(), v.Sig) {
+		return http.StatusForbidden, fmt.Errorf("invalid vote signature")
+	}
+
+	st, ok := getOnboardingState(v.HosID)
+	if !ok || st.Status != OnboardPending || st.AppHash != v.AppHash {
+		return http.StatusConflict, fmt.Errorf("application is not pending")
+	}
+	if _, voted := st.Votes[v.Voter]; voted {
+		return http.StatusConflict, fmt.Errorf("already voted")
+	}
+	qk := v.HosID + "|" + v.AppHash + "|" + v.Voter
+	queuedBallotsMu.Lock()
+	if queuedBallots[qk] {
+		queuedBallotsMu.Unlock()
+		return http.StatusConflict, fmt.Errorf("vote already queued")
+	}
+	queuedBallots[qk] = true
+	queuedBallotsMu.Unlock()
+
+	appendPending([]AnchorRecord{{
+		HosID:           v.HosID,
+		Kind:            RecordKindOnboardVote,
+		LowerRoot:       v.digest(),
+		AccessCatalog:   []string{},
+		AnchorTimestamp: v.Ts,
+		Vote:            &v,
+	}})
+	log.Printf("[ONBOARD] Ballot from %s for %s queued", v.Voter, v.HosID)
+	return http.StatusAccepted, nil
+}
+
+// 가입 현황 조회
+// GET /onboarding/status?hos_id=<id>
+func handleOnboardingStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if hosID := r.URL.Query().Get("hos_id"); hosID != "" {
+		st, ok := getOnboardingState(hosID)
+		if !ok {
+			writeError(w, http.StatusNotFound, "no application for hos_id")
+			return
+		}
+		writeJSON(w, http.StatusOK, st)
+		return
+	}
+
+	out := []OnboardingState{}
+	iter := db.NewIterator(util.BytesPrefix([]byte("onboard_")), nil)
+	for iter.Next() {
+		var st OnboardingState
+		if err := json.Unmarshal(iter.Value(), &st); err == nil {
+			out = append(out, st)
+		}
+	}
+	iter.Release()
+	sort.Slice(out, func(i, j int) bool { return out[i].HosID < out[j].HosID })
+	writeJSON(w, http.StatusOK, map[string]any{
+		"required":     onboardingRequired,
+		"applications": out,
+	})
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// OpenAPI 문서 (/openapi.json, /docs)
+// ------------------------------------------------------------
+// - main.go 의 라우터(routeMux)가 등록된 경로 패턴을 기록 => 등록된 경로마다 OpenAPI 3 path 항목 생성
+//   · 메서드/요약/쿼리 파라미터/요청·응답 본문은 apiDocs 표에서 보충 (표에 없는 경로는 GET + 기본 응답)
+//   · 본문 스키마는 Go 구조체의 json 태그로부터 생성 (components.schemas, 구조체 이름 기준)
+//   · "/jobs/" 처럼 하위 경로를 받는 패턴은 apiDocs 의 Path 템플릿(/jobs/{id})으로 표기
+//   · 정적 대시보드("/")는 제외
+// - 서버 기준 경로는 /v1 (버전 없는 기존 경로도 동작, versioning.go)
+// - 오류 응답은 공통 ErrorBody 스키마 (errors.go)
+// - GET /openapi.json : 문서 JSON
+// - GET /docs : 문서 뷰어 (static/docs.html, 외부 CDN 없이 /openapi.json 을 읽어 표시)
+////////////////////////////////////////////////////////////////////////////////
+
+// 등록된 경로 패턴을 기록하는 ServeMux
+type routeMux struct {
+	*http.ServeMux
+	patterns []string
+}
+
+func newRouteMux() *routeMux {
+	return &routeMux{ServeMux: http.NewServeMux()}
+}
+
+func (m *routeMux) Handle(pattern string, h http.Handler) {
+	m.patterns = append(m.patterns, pattern)
+	m.ServeMux.Handle(pattern, h)
+}
+
+func (m *routeMux) HandleFunc(pattern string, h func(http.ResponseWriter, *http.Request)) {
+	m.patterns = append(m.patterns, pattern)
+	m.ServeMux.HandleFunc(pattern, h)
+}
+
+// 경로 문서 항목
+type apiDoc struct {
+	Path    string     // 하위 경로 패턴의 문서 경로 (예: /jobs/{id}), 비우면 패턴 그대로
+	Methods []string   // 비우면 GET
+	Summary string     // 한 줄 설명
+	Query   []apiParam // 쿼리 파라미터
+	Body    any        // 요청 본문 타입 (POST/PUT, 스키마 생성용 값)
+	Resp    any        // 성공 응답 본문 타입
+	Status  int        // 성공 상태 코드 (기본 200)
+	Peer    bool       // 노드 간 엔드포인트 (mTLS 활성 시 고정 인증서 필요)
+}
+
+type apiParam struct {
+	Name, Type, Desc string
+}
+
+func qp(name, typ, desc string) apiParam { return apiParam{name, typ, desc} }
+
+var pageParams = []apiParam{qp("offset", "integer", "건너뛸 개수"), qp("limit", "integer", "최대 반환 개수")}
+
+// 경로별 문서 (등록 패턴 기준)
+var apiDocs = map[string]apiDoc{
+	"/block/index":       {Summary: "번호로 블록 조회", Query: []apiParam{qp("id", "integer", "블록 번호")}, Resp: UpperBlock{}},
+	"/block/latest":      {Summary: "최신 블록", Resp: UpperBlock{}},
+	"/block/hash":        {Summary: "해시로 블록 조회", Query: []apiParam{qp("value", "string", "블록 해시")}, Resp: UpperBlock{}},
+	"/blocks":            {Summary: "블록 목록 (페이지, gzip/ETag)", Query: pageParams, Resp: blocksPage{}},
+	"/headers":           {Summary: "블록 헤더 페이지", Query: pageParams, Resp: headersPage{}},
+	"/blocks/recent":     {Summary: "최근 블록", Query: []apiParam{qp("count", "integer", "개수"), qp("full", "boolean", "본문 포함")}},
+	"/status":            {Summary: "노드 상태 (높이, 난이도와 조정 상태, 부트노드, Hos 부트노드, 피어)"},
+	"/peers":             {Summary: "피어 목록", Query: []apiParam{qp("detail", "boolean", "연결 상태/회로 차단 정보 포함")}},
+	"/pending":           {Summary: "메모리풀 앵커 (건수, 직렬화 크기, 채굴 중 여부)", Resp: []AnchorRecord{}},
+	"/query":             {Summary: "Hos 체인 레코드 중계 조회 (감사 기록)", Query: append([]apiParam{qp("hos_id", "string", "Hos 체인 ID"), qp("keyword", "string", "검색어")}, pageParams...)},
+	"/addPeer":           {Methods: []string{"POST"}, Summary: "부트노드의 신규 피어 알림", Peer: true},
+	"/mine/start":        {Methods: []string{"POST"}, Summary: "부트노드의 채굴 신호 수신", Peer: true},
+	"/receiveBlock":      {Methods: []string{"POST"}, Summary: "채굴 승자 노드의 신규 블록 수신", Peer: true},
+	"/whoami":            {Summary: "요청 출발 주소 에코 / 이 노드의 광고 주소", Resp: whoamiResp{}},
+	"/register":          {Methods: []string{"POST"}, Summary: "부트노드에 피어 등록", Body: registerReq{}, Resp: registerResp{}},
+	"/bootNotify":        {Methods: []string{"POST"}, Summary: "부트노드 변경 수신", Peer: true},
+	"/addAnchor":         {Methods: []string{"POST"}, Summary: "Hos 부트노드의 앵커 제출"},
+	"/hosBootNotify":     {Methods: []string{"POST"}, Summary: "Hos 부트노드 주소 변경 수신", Peer: true},
+	"/registerHosChain":  {Methods: []string{"POST"}, Summary: "Hos 체인 등록 (부트노드 전용)", Body: RegisterHosChainRequest{}, Resp: RegisterHosChainResponse{}},
+	"/contracts":         {Methods: []string{"GET", "POST", "DELETE"}, Summary: "계약 조회 / 등록 / 철회", Query: []apiParam{qp("hos_id", "string", "Hos 체인 ID (DELETE 필수)")}, Body: ContractData{}},
+	"/contracts/search":  {Summary: "계약 검색", Query: []apiParam{qp("region", "string", "리전"), qp("expiring_before", "string", "만료 기준 시각 (RFC3339)")}, Resp: []ContractMatch{}},
+	"/getPublicKey":      {Summary: "노드 공개키"},
+	"/commitment":        {Summary: "체인 상태 집계 커밋먼트", Resp: ChainCommitment{}},
+	"/chain/info":        {Summary: "체인 식별 정보", Resp: ChainInfo{}},
+	"/metrics":           {Summary: "Prometheus 메트릭 (text/plain)"},
+	"/onboarding/apply":  {Methods: []string{"POST"}, Summary: "Hos 체인 가입 신청", Body: OnboardingApplication{}, Status: http.StatusAccepted},
+	"/onboarding/vote":   {Methods: []string{"POST"}, Summary: "가입 신청 찬반 투표", Status: http.StatusAccepted},
+	"/onboarding/ballot": {Methods: []string{"POST"}, Summary: "Gov 노드의 서명된 투표 수신 (부트노드 전용)", Body: OnboardingVote{}, Status: http.StatusAccepted, Peer: true},
+	"/onboarding/status": {Summary: "가입 신청 상태", Query: []apiParam{qp("hos_id", "string", "Hos 체인 ID")}, Resp: OnboardingState{}},
+	"/hosKeyRotation":    {Methods: []string{"POST"}, Summary: "Hos 부트노드의 키 교체 공지 기록"},
+	"/hosKeys":           {Summary: "Hos 체인별 공개키 이력", Query: []apiParam{qp("hos_id", "string", "Hos 체인 ID")}, Resp: []HosKeyState{}},
+	"/mirror/chains":     {Summary: "미러링 중인 외부 체인", Resp: []MirrorChain{}},
+	"/mirror/anchors":    {Summary: "외부 체인에서 미러링한 앵커", Query: []apiParam{qp("chain", "string", "외부 체인 이름"), qp("hos_id", "string", "Hos 체인 ID")}},
+	"/mirror/verify":     {Methods: []string{"POST"}, Summary: "미러링 앵커로 레코드 검증", Body: MirrorVerifyRequest{}, Resp: MirrorVerifyResult{}},
+	"/verify":            {Summary: "레코드 포함 검증 영수증", Query: []apiParam{qp("hos_id", "string", "Hos 체인 ID"), qp("leaf", "string", "레코드 해시"), qp("block_root", "string", "Hos 블록 머클 루트"), qp("proof", "string", "머클 증명 (JSON)"), qp("proof_version", "integer", "증명 형식 버전 (없으면 0)")}, Resp: VerificationReceipt{}},
+	"/anchors":           {Summary: "Hos 별 앵커 이력 (포함 블록)", Query: append([]apiParam{qp("hos_id", "string", "Hos 체인 ID"), qp("from", "integer", "시작 상위 블록 번호"), qp("to", "integer", "끝 상위 블록 번호 (포함)")}, pageParams...), Resp: []AnchorHistoryEntry{}},
+	"/anchors/latest":    {Summary: "Hos 별 최신 앵커", Resp: []LatestAnchor{}},
+	"/clock":             {Summary: "NTP 오프셋과 피어별 시계 오차", Resp: ClockReport{}},
+	"/anchor/status":     {Summary: "앵커 포함 상태", Query: []apiParam{qp("hos_id", "string", "Hos 체인 ID"), qp("root", "string", "Hos 블록 머클 루트")}, Resp: AnchorStatusResponse{}},
+	"/anchor/proof":      {Summary: "앵커 포함 증명", Query: []apiParam{qp("hos_id", "string", "Hos 체인 ID"), qp("root", "string", "Hos 블록 머클 루트")}, Resp: AnchorProof{}},
+	"/proof/full":        {Summary: "레코드 => Hos 블록 => Gov 블록 전체 증명", Query: []apiParam{qp("hos_id", "string", "Hos 체인 ID"), qp("clinic_id", "string", "clinic_id")}, Resp: FullProof{}},
+	"/ws/events":         {Summary: "이벤트 WebSocket 스트림 (Upgrade 없으면 SSE)", Query: []apiParam{qp("types", "string", "이벤트 종류 필터 (쉼표 구분)")}},
+	"/events":            {Summary: "이벤트 SSE 스트림", Query: []apiParam{qp("types", "string", "이벤트 종류 필터 (쉼표 구분)")}},
+	"/jobs":              {Summary: "관리 작업 목록", Resp: []Job{}},
+	"/jobs/":             {Path: "/jobs/{id}", Methods: []string{"GET", "DELETE"}, Summary: "관리 작업 상태 조회 / 취소", Resp: Job{}},
+	"/admin/reindex":     {Methods: []string{"POST"}, Summary: "앵커 색인 재구성 작업 시작", Resp: Job{}, Status: http.StatusAccepted},
+	"/admin/audit":       {Methods: []string{"POST"}, Summary: "장부 무결성 감사 작업 시작", Resp: Job{}, Status: http.StatusAccepted},
+	"/admin/finalize":    {Methods: []string{"POST"}, Summary: "대기 중인 앵커로 즉시 채굴 시작"},
+	"/admin/resync":      {Methods: []string{"POST"}, Summary: "누적 작업량이 가장 큰 피어 체인과 분기 교체 작업 시작", Resp: Job{}, Status: http.StatusAccepted},
+	"/admin/backup":      {Methods: []string{"POST"}, Summary: "LevelDB 상태 핫 백업 (tar.gz: manifest.json + state.kv)"},
+	"/admin/restore":     {Methods: []string{"POST"}, Summary: "백업 tar.gz 복원 (설치 후 높이/블록 해시 검증)"},
+	"/admin/export":      {Summary: "블록 NDJSON 아카이브 (1행 매니페스트 + 블록 한 줄씩)", Query: []apiParam{qp("from", "integer", "시작 블록 번호")}},
+	"/admin/import":      {Methods: []string{"POST"}, Summary: "NDJSON 아카이브 검증 후 로컬 체인에 반영", Resp: ImportResult{}},
+	"/admin/webhooks":    {Summary: "웹훅 대상별 전송 현황", Resp: []WebhookStatus{}},
+	"/patient/records":   {Summary: "Hos 환자별 레코드 조회 중계 (X-Requester 필수)", Query: append([]apiParam{qp("hos_id", "string", "Hos 체인 ID"), qp("patient_id", "string", "환자 ID"), qp("decrypt", "boolean", "진료 정보 복호화")}, pageParams...)},
+	"/audit/queries":     {Methods: []string{"GET", "POST"}, Summary: "중계 조회 감사 이력 / 노드 간 감사 기록 전달", Query: append([]apiParam{qp("hos_id", "string", "Hos 체인 ID"), qp("requester", "string", "요청자")}, pageParams...), Body: AuditRecord{}, Resp: []AuditEntry{}},
+	"/healthz":           {Summary: "프로세스 생존 확인 (liveness)"},
+	"/readyz":            {Summary: "준비 상태 확인 (readiness, 준비되지 않으면 503 + 실패 항목)"},
+	"/openapi.json":      {Summary: "이 문서 (OpenAPI 3)"},
+	"/docs":              {Summary: "API 문서 뷰어 (HTML)"},
+	"/dashboard":         {Summary: "내장 실시간 대시보드 (HTML)"},
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPISpec []byte
+)
+
+// GET /openapi.json
+func handleOpenAPI(mux *routeMux, title string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		openAPIOnce.Do(func() {
+			openAPISpec, _ = json.Marshal(buildOpenAPI(mux.patterns, title))
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPISpec)
+	}
+}
+
+// GET /docs
+func handleDocs(w http.ResponseWriter, r *http.Request) {
+	http.ServeFile(w, r, "./static/docs.html")
+}
+
+// 등록된 패턴으로부터 OpenAPI 3 문서 생성
+func buildOpenAPI(patterns []string, title string) map[string]any {
+	schemas := map[string]any{"ErrorBody": schemaFor(reflect.TypeOf(ErrorBody{}), nil)}
+	paths := map[string]any{}
+	sorted := append([]string(nil), patterns...)
+	sort.Strings(sorted)
+	for _, p := range sorted {
+		if p == "/" {
+			continue
+		}
+		doc := apiDocs[p]
+		path := p
+		if doc.Path != "" {
+			path = doc.Path
+		}
+		methods := doc.Methods
+		if len(methods) == 0 {
+			methods = []string{"GET"}
+		}
+		item := map[string]any{}
+		for _, m := range methods {
+			item[strings.ToLower(m)] = buildOperation(path, m, doc, schemas)
+		}
+		paths[path] = item
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   title,
+			"version": APIVersion,
+		},
+		"servers":    []map[string]string{{"url": "/" + APIVersion}},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func buildOperation(path, method string, doc apiDoc, schemas map[string]any) map[string]any {
+	op := map[string]any{
+		"tags":        []string{strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]},
+		"operationId": strings.ToLower(method) + strings.NewReplacer("/", "_", "{", "", "}", "", ".", "_").Replace(path),
+	}
+	if doc.Summary != "" {
+		op["summary"] = doc.Summary
+	}
+	if doc.Peer {
+		op["description"] = "노드 간 엔드포인트 (mTLS 활성 시 고정된 노드 인증서 필요)"
+	}
+
+	params := []map[string]any{}
+	for _, seg := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(seg, "{"); ok {
+			params = append(params, map[string]any{
+				"name": strings.TrimSuffix(name, "}"), "in": "path", "required": true,
+				"schema": map[string]string{"type": "string"},
+			})
+		}
+	}
+	for _, q := range doc.Query {
+		params = append(params, map[string]any{
+			"name": q.Name, "in": "query", "description": q.Desc,
+			"schema": map[string]string{"type": q.Type},
+		})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if doc.Body != nil && (method == "POST" || method == "PUT") {
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(doc.Body), schemas)}},
+		}
+	}
+
+	status := doc.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	ok := map[string]any{"description": http.StatusText(status)}
+	if doc.Resp != nil && method != "DELETE" {
+		ok["content"] = map[string]any{"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(doc.Resp), schemas)}}
+	}
+	op["responses"] = map[string]any{
+		strconv.Itoa(status): ok,
+		"default": map[string]any{
+			"description": "오류",
+			"content":     map[string]any{"application/json": map[string]any{"schema": map[string]string{"$ref": "#/components/schemas/ErrorBody"}}},
+		},
+	}
+	return op
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// Go 타입의 JSON 스키마 (이름 있는 구조체는 schemas 에 등록 후 $ref, schemas 가 nil 이면 인라인)
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawType:
+		return map[string]any{}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" || schemas == nil {
+			return structSchema(t, schemas)
+		}
+		ref := map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+		if _, ok := schemas[t.Name()]; !ok {
+			schemas[t.Name()] = map[string]any{} // 자기 참조 구조체의 무한 재귀 방지
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return ref
+	}
+	return map[string]any{}
+}
+
+// 구조체 필드의 json 태그 기준 properties (내장 구조체 필드는 펼침)
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	props := map[string]any{}
+	var required []string
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if f.Anonymous && name == "" {
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = schemaFor(f.Type, schemas)
+			if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+				required = append(required, name)
+			}
+		}
+	}
+	walk(t)
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math/big"
 	"net/http"
 	"sync"
 	"time"
@@ -30,8 +33,11 @@ var chainMu sync.Mutex
 // - 순서: index 증가, prevHash 일치
 // - 머클루트/블록해시 재계산 일치
 // - Gov_id 일치(제네시스와 동일 체인인지 확인)
+// - 타임스탬프/난이도 온체인 규칙 (lookup 으로 조상 블록 조회, nil 이면 생략 : difficulty.go)
 // -----------------------------------------------------------------------------
-func validateUpperBlock(newBlk, prevBlk UpperBlock) error {
+var errInvalidBlock = errors.New("invalid block")
+
+func validateUpperBlock(newBlk, prevBlk UpperBlock, lookup blockLookup) error {
 	// 1) 인덱스 연속성
 	if prevBlk.Index+1 != newBlk.Index {
 		return fmt.Errorf("index not consecutive: prev=%d new=%d", prevBlk.Index, newBlk.Index)
@@ -44,22 +50,42 @@ func validateUpperBlock(newBlk, prevBlk UpperBlock) error {
 	if prevBlk.GovID != newBlk.GovID {
 		return fmt.Errorf("Gov_id mismatch: chain=%s new=%s", prevBlk.GovID, newBlk.GovID)
 	}
-	// 4) MerkleRoot 재계산
+	// 4) 블록 크기 한도 (blocklimit.go)
+	if err := checkBlockLimits(newBlk.Records); err != nil {
+		return err
+	}
+	// 5) MerkleRoot 재계산
 	expectedRoot := computeUpperMerkleRoot(newBlk.Records)
 	if expectedRoot != newBlk.MerkleRoot {
 		return fmt.Errorf("merkle_root mismatch: want=%s got=%s", expectedRoot, newBlk.MerkleRoot)
 	}
-	// 5) BlockHash 재계산
-	blockHash := newBlk.BlockHash
+	// 6) BlockHash 재계산 (채굴 헤더 기준, 난이도 값이 해시에 묶여 있는지 확인)
+	blockHash := computeHashForPoW(PoWHeader{
+		Index:      newBlk.Index,
+		PrevHash:   newBlk.PrevHash,
+		MerkleRoot: newBlk.MerkleRoot,
+		Timestamp:  newBlk.Timestamp,
+		Difficulty: newBlk.Difficulty,
+		ExtraNonce: newBlk.ExtraNonce,
+		ParamsHash: newBlk.DifficultyParams.hash(),
+		Nonce:      newBlk.Nonce,
+	})
 	if blockHash != newBlk.BlockHash {
-		return fmt.Errorf("block_hash mismatch")
+		return fmt.Errorf("block_hash mismatch: want=%s got=%s", blockHash, newBlk.BlockHash)
 	}
 
-	// 6) PoW 난이도 검증
+	// 7) PoW 난이도 검증
 	if !validHash(blockHash, newBlk.Difficulty) {
 		return fmt.Errorf("pow difficulty not satisfied (hash=%s diff=%d)",
 			blockHash, newBlk.Difficulty)
 	}
+
+	// 8) 타임스탬프/난이도 규칙
+	if lookup != nil {
+		if err := checkDifficultyRule(newBlk, prevBlk, lookup); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -69,34 +95,14 @@ func validateUpperBlock(newBlk, prevBlk UpperBlock) error {
 // - 원격 total > 로컬 total : 로컬 height+1 부터 순서대로 검증/append
 // -----------------------------------------------------------------------------
 type blocksPage struct {
-	Total      int          `json:"total"`
-	Offset     int          `json:"offset"`
-	Limit      int          `json:"limit"`
-	Items      []UpperBlock `json:"items"`
-	Difficulty int          `json:"difficulty"`
+	Total  int          `json:"total"`
+	Offset int          `json:"offset"`
+	Limit  int          `json:"limit"`
+	Items  []UpperBlock `json:"items"`
 }
 
 // 입력받은 주소의 노드에게 장부 정보를 제공받는 함수
 func syncChain(peer string) {
-	url := "http://" + peer + "/blocks"
-
-	// 원격에서 전체 블록 수신
-	resp, err := http.Get(url)
-	if err != nil {
-		log.Printf("[P2P] Failed to sync from %s: %v\n", peer, err)
-		return
-	}
-	var page blocksPage
-	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
-		_ = resp.Body.Close()
-		log.Printf("[P2P] Invalid /blocks from %s: %v\n", peer, err)
-		return
-	}
-	resp.Body.Close()
-
-	remoteTotal := page.Total
-	appended := 0
-
 	// 로컬 상태
 	chainMu.Lock()
 	localH, ok := getLatestHeight()
@@ -107,73 +113,105 @@ func syncChain(peer string) {
 		log.Printf("[P2P] No local blocks. Full sync from %s\n", peer)
 	}
 
-	// 난이도 변화 감지
-	if page.Difficulty > 0 && page.Difficulty != GlobalDifficulty {
-		log.Printf("[P2P] Difficulty update from peer=%s -> %d", peer, page.Difficulty)
-		GlobalDifficulty = page.Difficulty
-	}
-
-	// 원격이 최신보다 같거나 더 짧으면 필요 없음
-	if localH >= 0 && remoteTotal <= localH+1 {
-		log.Printf("[P2P] Up-to-date (local=%d, remote=%d)\n", localH+1, remoteTotal)
-		return
-	}
+	// 로컬 높이+1 부터 페이지 단위로 이어 받음 (blocktransfer.go)
+	etag := lastSyncETag(peer, localH)
+	appended := 0
+	for {
+		page, tag, notModified, err := fetchBlocksPage(peer, localH+1, BlocksPageMax, etag)
+		if err != nil {
+			log.Printf("[P2P] Failed to sync from %s at #%d: %v (+%d blocks so far)\n", peer, localH+1, err, appended)
+			return
+		}
+		if notModified {
+			log.Printf("[P2P] Up-to-date (local=%d, %s unchanged)\n", localH+1, peer)
+			return
+		}
+		etag = ""
+		observePeerHeight(peer, page.Total-1)
 
-	// 전체 블록을 순서대로 처리
-	for _, nb := range page.Items {
-		chainMu.Lock()
+		// 원격이 최신보다 같거나 더 짧으면 필요 없음
+		if localH >= 0 && page.Total <= localH+1 {
+			if appended == 0 {
+				log.Printf("[P2P] Up-to-date (local=%d, remote=%d)\n", localH+1, page.Total)
+			}
+			setSyncMark(peer, tag, localH)
+			break
+		}
+		if len(page.Items) == 0 {
+			break
+		}
 
-		if nb.Index != 0 {
-			prev, err := getBlockByIndex(nb.Index - 1)
-			if err != nil {
-				chainMu.Unlock()
-				log.Printf("[P2P] Missing prev block #%d\n", nb.Index-1)
-				return
+		// 페이지 블록을 순서대로 처리
+		before := appended
+		for _, nb := range page.Items {
+			if nb.Index <= localH {
+				continue
+			}
+			chainMu.Lock()
+
+			if nb.Index != 0 {
+				prev, err := getBlockByIndex(nb.Index - 1)
+				if err != nil {
+					chainMu.Unlock()
+					log.Printf("[P2P] Missing prev block #%d\n", nb.Index-1)
+					return
+				}
+
+				// 블록 검증
+				if err := validateUpperBlock(nb, prev, getBlockByIndex); err != nil {
+					chainMu.Unlock()
+					log.Printf("[P2P] Remote block invalid at #%d: %v\n", nb.Index, err)
+					return
+				}
+				nb.Elapsed = blockInterval(nb, prev) // 피어가 기록한 값 대신 타임스탬프 간격
+			} else {
+				log.Printf("[P2P] Fetching genesis from %s", peer)
 			}
 
-			// 블록 검증
-			if err := validateUpperBlock(nb, prev); err != nil {
+			// append
+			if err := commitBlock(nb); err != nil {
 				chainMu.Unlock()
-				log.Printf("[P2P] Remote block invalid at #%d: %v\n", nb.Index, err)
+				log.Printf("[P2P] commitBlock error: %v\n", err)
 				return
 			}
-		} else {
-			log.Printf("[P2P] Fetching genesis from %s", peer)
-		}
 
-		// append
-		if err := saveBlockToDB(nb); err != nil {
+			localH = nb.Index
+			appended++
 			chainMu.Unlock()
-			log.Printf("[P2P] saveBlockToDB error: %v\n", err)
-			return
+			publishBlockFinalized(nb)
 		}
-		if err := updateIndicesForBlock(nb); err != nil {
-			chainMu.Unlock()
-			log.Printf("[P2P] updateIndicesForBlock error: %v\n", err)
-			return
+		if appended == before {
+			break // 진행 없는 페이지 (원격 응답 이상) : 무한 반복 방지
 		}
-		if err := setLatestHeight(nb.Index); err != nil {
-			chainMu.Unlock()
-			log.Printf("[P2P] setLatestHeight error: %v\n", err)
-			return
-		}
-
-		localH = nb.Index
-		appended++
-		chainMu.Unlock()
 	}
 
-	log.Printf("[P2P] Chain synced from %s (+%d blocks, new height=%d)\n",
-		peer, appended, localH)
+	if appended > 0 {
+		log.Printf("[P2P] Chain synced from %s (+%d blocks, new height=%d)\n",
+			peer, appended, localH)
+	}
 }
 
 // 새로운 피어 등록
 func addPeer(w http.ResponseWriter, r *http.Request) {
-	var addr string
-	if err := json.NewDecoder(r.Body).Decode(&addr); err != nil {
-		http.Error(w, "invalid peer format", http.StatusBadRequest)
+	// 주소 문자열 또는 {"addr", "cert_pin"} 객체 (mTLS 사용 시)
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid peer format")
 		return
 	}
+	var addr string
+	if err := json.Unmarshal(raw, &addr); err != nil {
+		var req struct {
+			Addr    string `json:"addr"`
+			CertPin string `json:"cert_pin"`
+		}
+		if err := json.Unmarshal(raw, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid peer format")
+			return
+		}
+		addr = req.Addr
+		pinPeerCert(addr, req.CertPin)
+	}
 	if addPeerInternal(addr) {
 		w.Write([]byte("Peer added"))
 	} else {
@@ -195,6 +233,7 @@ func addPeerInternal(addr string) bool {
 		return false
 	}
 	log.Printf("[P2P][ADD] peer added: %s | total=%d", addr, len(peers))
+	publishEvent(EventPeerJoined, map[string]any{"peer": addr, "total": len(peers)})
 
 	// 생존 상태 초기화
 	aliveMu.Lock()
@@ -241,8 +280,10 @@ func removePeer(addr string) {
 	aliveMu.Lock()
 	delete(peerAliveMap, addr)
 	aliveMu.Unlock()
+	forgetPeerClock(addr)
 
 	log.Printf("[WATCHER] Dead Pear removed: %s", addr)
+	publishEvent(EventPeerLeft, map[string]any{"peer": addr, "total": len(peers)})
 }
 
 // 특정 노드 주소와 상태를 입력받아 기록
@@ -265,12 +306,20 @@ func startNetworkWatcher() {
 		if currentBoot == "" {
 			continue
 		}
+		// 관찰 노드는 부트노드에서 최신 피어 명단을 다시 받음 (observer.go)
+		if isObserver() {
+			refreshObserverPeers()
+		}
 
 		for _, addr := range peersSnapshot() {
 			// 노드 별 상태 조사
-			_, ok := probeStatus(addr)
+			st, ok := probeStatus(addr)
 			if ok {
 				markAlive(addr, true)
+				observePeerHeight(addr, st.Height)
+				// 피어가 여러 주소를 광고하면 후보별 도달성 점검
+				notePeerAddrs(addr, st.Addrs)
+				go probePeerAddrs(addr)
 				continue
 			}
 
@@ -288,9 +337,9 @@ func startNetworkWatcher() {
 	}
 }
 
-// 체인 fork 현상 완화 루틴 (생존 노드 중 가장 체인 height 긴 체인으로 동기화)
+// 체인 fork 현상 완화 루틴 (생존 노드 중 누적 작업량이 가장 큰 체인으로 교체, forkchoice.go)
 func startChainWatcher() {
-	t := time.NewTicker(time.Duration(ChainWatcherTime))
+	t := time.NewTicker(time.Duration(ChainWatcherTime) * time.Second)
 	defer t.Stop()
 
 	for range t.C {
@@ -300,60 +349,83 @@ func startChainWatcher() {
 		if isMining.Load() || getPendingCnt() != 0 {
 			continue
 		}
+		if _, err := reconcileChain(); err != nil {
+			log.Printf("[CHAIN-WATCHER] %v", err)
+		}
+	}
+}
 
-		// 가장 긴 노드의 주소, 높이, 최신블록해시
-		bestPeer := ""
-		bestHeight := -1
-		bestHash := ""
+// 체인 조정 1회 결과 (watcher, /admin/resync 공용)
+type ResyncResult struct {
+	Action      string `json:"action"` // none | reorg
+	Peer        string `json:"peer,omitempty"`
+	PeerWork    string `json:"peer_work,omitempty"`
+	LocalHeight int    `json:"local_height"` // 조정 전
+	LocalWork   string `json:"local_work"`
+	Height      int    `json:"height"` // 조정 후
+}
 
-		for _, p := range peersSnapshot() {
-			st, ok := probeStatus(p)
-			if !ok {
-				continue
-			}
-			// 높이가 최대인 노드를 탐색하여 주소, 높이, 해시 저장
-			if st.Height > bestHeight {
-				bestHeight = st.Height
-				bestHash = st.LastHash
-				bestPeer = p
-				continue
-			}
-			// height 같지만 hash가 다른 경우도 fork로 간주
-			if st.Height == bestHeight && st.LastHash != bestHash {
-				bestPeer = p
-				bestHeight = st.Height
-				bestHash = st.LastHash
-			}
-		}
-		// 발견되지 않았다면 다음 주기까지 중단
-		if bestPeer == "" {
+// 피어 중 누적 작업량이 가장 큰 체인이 로컬보다 무거우면 분기점 이후를 교체
+func reconcileChain() (ResyncResult, error) {
+	res := ResyncResult{Action: "none"}
+
+	// 가장 무거운 체인을 가진 노드의 주소, 누적 작업량, 최신블록해시
+	bestPeer := ""
+	bestWork := new(big.Int)
+	bestHash := ""
+
+	for _, p := range peersSnapshot() {
+		st, ok := probeStatus(p)
+		if !ok {
 			continue
 		}
-
-		// 로컬 상태
-		chainMu.Lock()
-		localH, _ := getLatestHeight()
-		localLastHash := ""
-		if localH >= 0 {
-			blk, _ := getBlockByIndex(localH)
-			localLastHash = blk.BlockHash
+		observePeerHeight(p, st.Height)
+		// 제네시스/프로토콜이 다른 체인의 노드는 fork 판정에서 제외 (chaininfo.go)
+		if !sameChain(p) {
+			continue
 		}
-		chainMu.Unlock()
-
-		// 로컬 노드와 비교하여 체인 동기화 여부 결정
-		needReset := false
-		// 가장 긴 노드의 height가 로컬 노드보다 클 때
-		if bestHeight > localH {
-			needReset = true
-		} else if bestHeight == localH && bestHash != localLastHash {
-			// 가장 긴 노드의 height가 로컬 노드와 같지만, hash가 다를 때
-			needReset = true
+		// 누적 작업량을 알리지 않는 (이전 버전) 노드는 제외
+		work, ok := new(big.Int).SetString(st.TotalWork, 10)
+		if !ok {
+			continue
 		}
-		// 로컬 장부 리셋 후, bestPeer에게 체인을 동기화받음
-		if needReset {
-			log.Printf("[CHAIN-WATCHER] fork/outdated detected → reset + sync from %s", bestPeer)
-			resetLocalDB()
-			syncChain(bestPeer)
+		if bestPeer == "" || heavierTip(work, st.LastHash, bestWork, bestHash) {
+			bestPeer = p
+			bestWork = work
+			bestHash = st.LastHash
 		}
 	}
+
+	localH, localHash, localWork := localTip()
+	res.LocalHeight, res.Height, res.LocalWork = localH, localH, localWork.String()
+	// 발견되지 않았다면 그대로 유지
+	if bestPeer == "" {
+		return res, nil
+	}
+	res.Peer, res.PeerWork = bestPeer, bestWork.String()
+
+	// 로컬 체인보다 무거울 때만 분기점 이후를 교체 (높이만 긴 저난이도 체인은 무시)
+	if !heavierTip(bestWork, bestHash, localWork, localHash) {
+		return res, nil
+	}
+	log.Printf("[CHAIN-WATCHER] heavier chain detected at %s (work %s > %s) => reorg", bestPeer, bestWork, localWork)
+	res.Action = "reorg"
+	if err := reorgFromPeer(bestPeer); err != nil {
+		return res, fmt.Errorf("reorg from %s failed: %w", bestPeer, err)
+	}
+	res.Height, _, _ = localTip()
+	return res, nil
+}
+
+// /admin/resync : 주기를 기다리지 않고 체인 조정 1회 수행
+func resyncJob(ctx context.Context, report func(done, total int)) (any, error) {
+	if isMining.Load() {
+		return nil, fmt.Errorf("mining in progress")
+	}
+	res, err := reconcileChain()
+	if err != nil {
+		return res, err
+	}
+	report(1, 1)
+	return res, nil
 }
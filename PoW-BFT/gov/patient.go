@@ -0,0 +1,138 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Patient Records 중계 (Hos 환자별 레코드 조회)
+// ------------------------------------------------------------
+// - Hos GET /patient/<patient_id>/records 는 Gov 노드가 서명한 요청만 허용 (Hos patient.go)
+// - GET /patient/records?hos_id=<id>&patient_id=<id>[&include_expired=true][&decrypt=true][&offset=<int>&limit=<int>]
+//   · X-Requester 헤더(요청 주체) 필수, Hos 에 그대로 전달되어 양쪽 로그에 남음
+//   · sha256("patient_records|<hos_id>|<patient_id>|<timestamp>") 를 이 노드 키로 서명해 Hos 부트노드에 요청
+//     (X-Gov-Signer = 이 노드 주소, X-Gov-Timestamp, X-Gov-Signature)
+//   · 결과는 /query 와 같이 앵커 루트 + Merkle 증명으로 검증된 항목만 반환, 전체 수는 X-Total-Count
+//   · decrypt=true : 봉인된 진료 정보 필드 복호화 조회 (PHI_DECRYPT_REQUESTERS 에 있는 요청자만)
+//   · Hos 에 전달된 조회는 /query 와 같이 장부에 감사 기록 (queryaudit.go)
+//     서명 대상이 "patient_records_decrypt|..." 로 바뀌어 일반 조회 서명으로는 Hos 가 복호화하지 않음
+////////////////////////////////////////////////////////////////////////////////
+
+// 복호화 조회를 허용할 요청자 (X-Requester, PHI_DECRYPT_REQUESTERS 쉼표 구분)
+var phiDecryptRequesters = map[string]bool{}
+
+func initPHIDecryptRequesters(list string) {
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			phiDecryptRequesters[s] = true
+		}
+	}
+}
+
+// Hos 가 검증하는 서명 대상 (Hos patientRequestDigest 와 같은 형식)
+func patientRequestDigest(hosID, patientID, ts string, decrypt bool) []byte {
+	scope := "patient_records"
+	if decrypt {
+		scope = "patient_records_decrypt"
+	}
+	h := sha256.Sum256([]byte(strings.Join([]string{scope, hosID, patientID, ts}, "|")))
+	return h[:]
+}
+
+// GET /patient/records
+func handlePatientRecords(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	q := r.URL.Query()
+	hosID, pid := q.Get("hos_id"), q.Get("patient_id")
+	if hosID == "" || pid == "" {
+		writeError(w, http.StatusBadRequest, "hos_id and patient_id required")
+		return
+	}
+	requester := r.Header.Get("X-Requester")
+	if requester == "" {
+		writeError(w, http.StatusUnauthorized, "X-Requester header required")
+		return
+	}
+	decrypt := q.Get("decrypt") == "true"
+	if decrypt && !phiDecryptRequesters[requester] {
+		log.Printf("[PATIENT][DENY] decrypt hos=%s patient=%s requester=%q", hosID, pid, requester)
+		writeError(w, http.StatusForbidden, "requester not permitted to decrypt")
+		return
+	}
+	hosAddr := getHosBootAddr(hosID)
+	if hosAddr == "" {
+		writeError(w, http.StatusBadGateway, "unknown hos_id")
+		return
+	}
+
+	items, total, status, err := requestPatientRecords(hosAddr, hosID, pid, requester, decrypt, q)
+	if err != nil {
+		recordQueryAudit(r, QueryAuditPatient, hosID, pid, 0, status)
+		writeError(w, status, err.Error())
+		return
+	}
+	verified, err := verifyHosResults(hosID, items)
+	if err != nil {
+		recordQueryAudit(r, QueryAuditPatient, hosID, pid, 0, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	recordQueryAudit(r, QueryAuditPatient, hosID, pid, len(verified), http.StatusOK)
+	log.Printf("[PATIENT] hos=%s patient=%s requester=%q decrypt=%v verified=%d/%d", hosID, pid, requester, decrypt, len(verified), len(items))
+	if total != "" {
+		w.Header().Set("X-Total-Count", total)
+	}
+	writeJSON(w, http.StatusOK, verified)
+}
+
+// Gov 서명 요청으로 Hos 환자 레코드 조회 (실패 시 Hos 응답 상태 그대로 전달)
+func requestPatientRecords(hosAddr, hosID, patientID, requester string, decrypt bool, page url.Values) ([]SearchResponse, string, int, error) {
+	ts := time.Now().UTC().Format(time.RFC3339)
+	sig, err := signWithGovKey(patientRequestDigest(hosID, patientID, ts, decrypt))
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, err
+	}
+	q := url.Values{}
+	for _, k := range []string{"offset", "limit", "include_expired"} {
+		if v := page.Get(k); v != "" {
+			q.Set(k, v)
+		}
+	}
+	if decrypt {
+		q.Set("decrypt", "true")
+	}
+	req, err := http.NewRequest(http.MethodGet, nodeURL(hosAddr, "/patient/"+url.PathEscape(patientID)+"/records?"+q.Encode()), nil)
+	if err != nil {
+		return nil, "", http.StatusBadRequest, err
+	}
+	req.Header.Set("X-Gov-Signer", self)
+	req.Header.Set("X-Gov-Timestamp", ts)
+	req.Header.Set("X-Gov-Signature", sig)
+	req.Header.Set("X-Requester", requester)
+
+	resp, err := nodeClient.Do(req)
+	if err != nil {
+		return nil, "", http.StatusBadGateway, fmt.Errorf("failed to reach hos node: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, "", resp.StatusCode, fmt.Errorf("hos error: %s", strings.TrimSpace(string(b)))
+	}
+	var items []SearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		return nil, "", http.StatusBadGateway, fmt.Errorf("invalid JSON from hos")
+	}
+	return items, resp.Header.Get("X-Total-Count"), http.StatusOK, nil
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Peer Client (노드 간 HTTP 클라이언트 : 제한시간, 연결 재사용, 재시도, 회로 차단)
+// ------------------------------------------------------------
+// - 모든 노드 간 요청은 nodeClient 사용 (initPeerClient 에서 생성, mTLS 활성 시 지문 고정 다이얼러)
+//   · PEER_TIMEOUT(기본 30초) : 요청 전체 제한시간, PEER_DIAL_TIMEOUT(기본 3초) : 연결 제한시간
+//   · 피어별 유휴 연결 PeerIdleConnsPerHost 개 유지 (keep-alive)
+//   · 전체 장부(/blocks) 다운로드는 같은 연결 풀의 nodeBulkClient 사용 (PEER_BULK_TIMEOUT, 기본 10분)
+// - 재시도 : GET/HEAD 와 Idempotency-Key 헤더가 있는 요청이 전송 오류로 실패하면 PEER_RETRIES 회까지 재시도 (지수 백오프)
+//   · postPeer 의 단방향 전파(합의 투표, 블록 전파, 부트노드 공지)는 수신 측에서 중복이 걸러지므로 키를 붙임
+//   · 그 외 POST(가입, 앵커 제출 등)는 재시도하지 않음
+// - 회로 차단 (피어 주소 단위)
+//   · 전송 오류(연결 거부, 제한시간 초과 등)가 PEER_CB_FAILURES 회 연속되면 open => 즉시 실패 (errCircuitOpen)
+//   · PEER_CB_COOLDOWN 초 후 half-open : 요청 1건만 통과시켜 성공하면 closed, 실패하면 다시 open
+//   · 응답을 받으면(상태 코드 무관) 성공으로 간주
+// - GET /peers?detail=true : 피어별 생존 여부와 회로 상태 조회 (기본 /peers 응답 형식은 유지)
+// - postPeer : 응답 본문을 버리고 닫는 단방향 전송 (연결 재사용을 위해 본문을 끝까지 읽음, 재시도 허용)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+
+	PeerIdleConnsPerHost = 8
+	peerRetryBackoff     = 200 * time.Millisecond
+)
+
+var (
+	peerTimeout     = 30 * time.Second // PEER_TIMEOUT
+	peerBulkTimeout = 10 * time.Minute // PEER_BULK_TIMEOUT
+	peerDialTimeout = 3 * time.Second  // PEER_DIAL_TIMEOUT
+	peerRetries     = 2                // PEER_RETRIES
+	circuitFailures = 5                // PEER_CB_FAILURES
+	circuitCooldown = 30 * time.Second // PEER_CB_COOLDOWN
+	errCircuitOpen  = errors.New("peer circuit open")
+	circuits        = make(map[string]*circuitState) // 피어 주소 => 회로 상태
+	circuitsMu      sync.Mutex
+)
+
+type circuitState struct {
+	State     string `json:"state"`
+	Failures  int    `json:"failures"` // 연속 전송 오류 수
+	OpenedAt  string `json:"opened_at,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+
+	openedAt time.Time
+	probing  bool // half-open 시험 요청 진행 중
+}
+
+// 환경변수로 설정 후 nodeClient 생성 (initNodeTLS 이후 호출)
+func initPeerClient() {
+	if d, err := time.ParseDuration(getEnvDefault("PEER_TIMEOUT", "")); err == nil && d > 0 {
+		peerTimeout = d
+	}
+	if d, err := time.ParseDuration(getEnvDefault("PEER_BULK_TIMEOUT", "")); err == nil && d > 0 {
+		peerBulkTimeout = d
+	}
+	if d, err := time.ParseDuration(getEnvDefault("PEER_DIAL_TIMEOUT", "")); err == nil && d > 0 {
+		peerDialTimeout = d
+	}
+	if n, err := strconv.Atoi(getEnvDefault("PEER_RETRIES", "")); err == nil && n >= 0 {
+		peerRetries = n
+	}
+	if n, err := strconv.Atoi(getEnvDefault("PEER_CB_FAILURES", "")); err == nil && n > 0 {
+		circuitFailures = n
+	}
+	if n, err := strconv.Atoi(getEnvDefault("PEER_CB_COOLDOWN", "")); err == nil && n > 0 {
+		circuitCooldown = time.Duration(n) * time.Second
+	}
+
+	dialer := &net.Dialer{Timeout: peerDialTimeout, KeepAlive: 30 * time.Second}
+	base := &http.Transport{
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   PeerIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   peerDialTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	if tlsEnabled {
+		base.DialTLSContext = dialPinnedTLS
+	}
+	transport := &peerTransport{base: base}
+	nodeClient = &http.Client{Timeout: peerTimeout, Transport: transport}
+	nodeBulkClient = &http.Client{Timeout: peerBulkTimeout, Transport: transport}
+	log.Printf("[PEER] client ready (timeout=%s, dial=%s, retries=%d, breaker=%d failures/%s)",
+		peerTimeout, peerDialTimeout, peerRetries, circuitFailures, circuitCooldown)
+}
+
+// 재시도 + 회로 차단 RoundTripper
+type peerTransport struct {
+	base http.RoundTripper
+}
+
+func (t *peerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if !circuitAllow(host) {
+		return nil, fmt.Errorf("%s: %w", host, errCircuitOpen)
+	}
+	req = stampChainID(req)    // 보내는 체인 식별 (chaininfo.go)
+	req = attachPeerToken(req) // 같은 체인 노드에게만 PEER_TOKEN 첨부 (auth.go)
+	req = compressRequest(req) // 지원을 알린 피어에게만 본문 gzip 압축 (compress.go)
+	retries := 0
+	if req.Method == http.MethodGet || req.Method == http.MethodHead || req.Header.Get("Idempotency-Key") != "" {
+		retries = peerRetries
+	}
+	for attempt := 0; ; attempt++ {
+		try := req
+		if attempt > 0 && req.GetBody != nil { // 재시도 시 본문 복원
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			try = req.Clone(req.Context())
+			try.Body = body
+		}
+		resp, err := t.base.RoundTrip(try)
+		if err == nil {
+			circuitResult(host, nil)
+			notePeerEncoding(host, resp)
+			return resp, nil
+		}
+		if attempt >= retries || req.Context().Err() != nil {
+			circuitResult(host, err)
+			return nil, err
+		}
+		select {
+		case <-time.After(peerRetryBackoff << attempt):
+		case <-req.Context().Done():
+			circuitResult(host, err)
+			return nil, err
+		}
+	}
+}
+
+// 요청 허용 여부 (open 이면 cooldown 후 시험 요청 1건만 허용)
+func circuitAllow(host string) bool {
+	circuitsMu.Lock()
+	defer circuitsMu.Unlock()
+	c := circuits[host]
+	if c == nil || c.State == CircuitClosed {
+		return true
+	}
+	if c.State == CircuitOpen && time.Since(c.openedAt) >= circuitCooldown {
+		c.State = CircuitHalfOpen
+	}
+	if c.State == CircuitHalfOpen && !c.probing {
+		c.probing = true
+		return true
+	}
+	return false
+}
+
+// 요청 결과 반영
+func circuitResult(host string, err error) {
+	circuitsMu.Lock()
+	defer circuitsMu.Unlock()
+	c := circuits[host]
+	if c == nil {
+		c = &circuitState{State: CircuitClosed}
+		circuits[host] = c
+	}
+	c.probing = false
+	if err == nil {
+		if c.State != CircuitClosed {
+			log.Printf("[PEER][CIRCUIT] %s closed", host)
+		}
+		c.State, c.Failures, c.OpenedAt, c.LastError = CircuitClosed, 0, "", ""
+		return
+	}
+	c.Failures++
+	c.LastError = err.Error()
+	if c.State == CircuitHalfOpen || (c.State == CircuitClosed && c.Failures >= circuitFailures) {
+		c.State = CircuitOpen
+		c.openedAt = time.Now()
+		c.OpenedAt = c.openedAt.UTC().Format(time.RFC3339)
+		incCounter("chain_peer_circuit_open_total", `peer="`+host+`"`)
+		log.Printf("[PEER][CIRCUIT] %s open after %d failures: %v", host, c.Failures, err)
+	}
+}
+
+func circuitSnapshot(host string) circuitState {
+	circuitsMu.Lock()
+	defer circuitsMu.Unlock()
+	if c := circuits[host]; c != nil {
+		if c.State == CircuitOpen && time.Since(c.openedAt) >= circuitCooldown {
+			return circuitState{State: CircuitHalfOpen, Failures: c.Failures, OpenedAt: c.OpenedAt, LastError: c.LastError}
+		}
+		return *c
+	}
+	return circuitState{State: CircuitClosed}
+}
+
+type PeerDetail struct {
+	Addr    string       `json:"addr"`
+	Alive   bool         `json:"alive"`
+	Circuit circuitState `json:"circuit"`
+	Addrs   []addrScore  `json:"addrs,omitempty"` // 후보 접속 주소 도달성 (advertise.go)
+}
+
+// GET /peers?detail=true 응답
+func peerDetails() []PeerDetail {
+	list := peersSnapshot()
+	sort.Strings(list)
+	out := make([]PeerDetail, 0, len(list))
+	for _, p := range list {
+		aliveMu.RLock()
+		alive := peerAliveMap[p]
+		aliveMu.RUnlock()
+		out = append(out, PeerDetail{Addr: p, Alive: alive, Circuit: circuitSnapshot(p), Addrs: peerAddrScores(p)})
+	}
+	return out
+}
+
+// 단방향 전송 (응답 본문 폐기, 전송 오류만 반환)
+func postPeer(addr, path string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, nodeURL(addr, path), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", sha256Hex(body))
+	resp, err := nodeClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 메모리풀 한도 (Pending Pool Limits / Backpressure)
+// ------------------------------------------------------------
+// - 메모리풀(ch.pending) 레코드 수 MaxPendingEntries, 직렬화 크기 합 MaxPendingBytes 제한 (0 이면 제한 없음)
+// - 한도 적용 대상 : 외부에서 계속 들어오는 앵커(/addAnchor)와 조회 감사 기록(/audit/queries)
+//   · 가입 신청/투표, 키 교체 기록은 서명 확인을 거친 드문 거버넌스 기록이므로 한도 없이 접수
+//   · 분기 교체/채굴 중단으로 되돌아온 레코드는 이미 수락된 것이므로 한도 무시 (appendPending)
+// - 한도 초과 시 PendingEvictPolicy 에 따라 처리
+//   · reject      : 접수 거절 => 429 pending_full + Retry-After (기본값)
+//   · drop-oldest : 가장 오래된 앵커/감사 기록을 밀어내고 접수
+//   · 요청 하나가 한도보다 크면 정책과 무관하게 거절
+// - 거절된 앵커는 Hos 앵커 큐가 백오프 후 재전송, 밀려난 앵커는 누락 앵커 점검(anchor_reconcile.go)이 재전송 요청
+// - 사용량 : chain_pending_entries / chain_pending_bytes 게이지, 거절은 chain_pending_rejected_total{reason="pool_full"}
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	PendingEvictReject = "reject"
+	PendingEvictOldest = "drop-oldest"
+
+	PendingFullRetryAfter = 5 // 초, 거절 응답의 Retry-After
+)
+
+var (
+	MaxPendingEntries  = 10000              // 메모리풀 최대 레코드 수 (MAX_PENDING_ENTRIES)
+	MaxPendingBytes    = 32 << 20           // 메모리풀 최대 크기, 바이트 (MAX_PENDING_BYTES)
+	PendingEvictPolicy = PendingEvictReject // 한도 초과 시 처리 (PENDING_EVICT_POLICY)
+)
+
+// 한도 초과로 접수 거절
+type PendingFullError struct {
+	Entries    int `json:"entries"`
+	Bytes      int `json:"bytes"`
+	Incoming   int `json:"incoming"`
+	MaxEntries int `json:"max_entries"`
+	MaxBytes   int `json:"max_bytes"`
+}
+
+func (e *PendingFullError) Error() string {
+	return fmt.Sprintf("pending pool full (%d/%d entries, %d/%d bytes)", e.Entries, e.MaxEntries, e.Bytes, e.MaxBytes)
+}
+
+// 정책 이름 확인 (main 에서 설정 시)
+func validPendingEvictPolicy(p string) bool {
+	return p == PendingEvictReject || p == PendingEvictOldest
+}
+
+// 레코드 직렬화 크기
+func pendingSize(records []AnchorRecord) int {
+	n := 0
+	for _, rec := range records {
+		data, _ := json.Marshal(rec)
+		n += len(data)
+	}
+	return n
+}
+
+func overPendingLimit(entries, bytes int) bool {
+	return (MaxPendingEntries > 0 && entries > MaxPendingEntries) || (MaxPendingBytes > 0 && bytes > MaxPendingBytes)
+}
+
+// 한도를 적용하여 메모리풀에 추가 (초과 시 *PendingFullError)
+func admitPending(records []AnchorRecord) error {
+	size := pendingSize(records)
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+
+	if overPendingLimit(len(ch.pending)+len(records), ch.pendingBytes+size) {
+		full := &PendingFullError{
+			Entries: len(ch.pending), Bytes: ch.pendingBytes, Incoming: len(records),
+			MaxEntries: MaxPendingEntries, MaxBytes: MaxPendingBytes,
+		}
+		if PendingEvictPolicy != PendingEvictOldest || overPendingLimit(len(records), size) {
+			incCounter("chain_pending_rejected_total", `reason="pool_full"`)
+			return full
+		}
+		if !evictPendingLocked(len(records), size) {
+			incCounter("chain_pending_rejected_total", `reason="pool_full"`)
+			return full
+		}
+	}
+	ch.pending = append(ch.pending, records...)
+	ch.pendingBytes += size
+	log.Printf("[CHAIN][PENDING] Append pending entries (%d items)", len(records))
+	return nil
+}
+
+// 앞에서부터 앵커/감사 기록을 밀어내 incoming 을 받을 공간 확보 (확보 못 하면 그대로 두고 false)
+func evictPendingLocked(incoming, size int) bool {
+	entries, bytes := len(ch.pending), ch.pendingBytes
+	drop := map[int]bool{}
+	for i, rec := range ch.pending {
+		if !overPendingLimit(entries+incoming, bytes+size) {
+			break
+		}
+		if rec.Kind == "" || rec.Kind == RecordKindQueryAudit {
+			drop[i] = true
+			entries--
+			bytes -= pendingSize(ch.pending[i : i+1])
+		}
+	}
+	if overPendingLimit(entries+incoming, bytes+size) {
+		return false
+	}
+	kept := make([]AnchorRecord, 0, entries)
+	for i, rec := range ch.pending {
+		if !drop[i] {
+			kept = append(kept, rec)
+		}
+	}
+	ch.pending, ch.pendingBytes = kept, bytes
+	incCounter("chain_pending_evicted_total", "")
+	log.Printf("[CHAIN][PENDING] Evicted %d oldest entries (pool limit, policy=%s)", len(drop), PendingEvictPolicy)
+	return true
+}
+
+// 메모리풀 크기 (바이트)
+func getPendingBytes() int {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	return ch.pendingBytes
+}
+
+// 429 pending_full 응답
+func writePendingFull(w http.ResponseWriter, full *PendingFullError) {
+	w.Header().Set("Retry-After", strconv.Itoa(PendingFullRetryAfter))
+	writeErrorDetail(w, http.StatusTooManyRequests, "pending_full", full.Error(), full)
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
-	"crypto/sha256"
+	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"log"
-	"math/rand"
+	"math"
 	"net/http"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,7 +22,15 @@ import (
 // - 모든 노드가 동시에 채굴 수행
 // - 난이도 조건을 가장 먼저 만족한 노드가 블록 브로드캐스트
 // - 다른 노드는 즉시 채굴 중단 후 검증(verifyBlock) → 체인에 추가
-// - 동일한 GlobalDifficulty 사용
+// - nonce 탐색은 MiningWorkers 개 워커가 nonce 공간을 나눠 병렬 수행 (MINING_WORKERS, 기본 GOMAXPROCS)
+//   · 워커 i 는 시작 nonce + i 부터 워커 수 간격으로 탐색, 하나가 찾거나 miningStop 이면 모두 중단
+//   · 탐색한 해시 수/초당 해시 수는 /metrics 로 노출 (chain_mining_hashes_total, chain_mining_hashrate)
+//   · 제네시스는 모든 노드가 같은 nonce 를 얻어야 하므로 단일 탐색 유지 (block.go)
+// - nonce 는 32비트 공간(NonceSpace)을 crypto/rand 로 고른 시작점부터 한 바퀴 탐색 (끝에 닿으면 0 으로 wraparound)
+//   · 한 바퀴를 다 돌면 헤더의 extra_nonce 를 올리고 새 시작점부터 다시 탐색 (같은 헤더를 두 번 해시하지 않음)
+//   · MiningTimestampRefresh 마다 타임스탬프를 현재 시각(median-time-past 이상)으로 갱신 후 탐색 재시작
+//     (오래 걸린 채굴 블록의 타임스탬프가 채굴 시작 시각에 묶여 난이도 계산을 흔들지 않도록)
+// - 난이도는 직전 블록 타임스탬프로 모든 노드가 같은 값을 계산 (difficulty.go)
 ////////////////////////////////////////////////////////////////////////////////
 
 // 채굴 시 해시 계산 대상 최소 정보
@@ -27,6 +40,8 @@ type PoWHeader struct {
 	MerkleRoot string `json:"merkle_root"`
 	Timestamp  string `json:"timestamp"`
 	Difficulty int    `json:"difficulty"`
+	ExtraNonce int    `json:"extra_nonce,omitempty"` // nonce 공간을 다 돌면 증가 (0 이면 생략되어 기존 블록 해시와 동일)
+	ParamsHash string `json:"params_hash,omitempty"` // 제네시스의 난이도 규칙 해시 (기본 규칙이면 생략되어 기존 제네시스 해시와 동일)
 	Nonce      int    `json:"nonce"`
 }
 
@@ -38,6 +53,27 @@ type MineResult struct {
 	Elapsed   float32
 }
 
+var (
+	MiningWorkers = runtime.GOMAXPROCS(0) // nonce 탐색 워커 수
+	hashRateBits  atomic.Uint64           // 마지막 채굴의 초당 해시 수 (float64 비트)
+)
+
+const (
+	hashCountBatch         = 1024             // 워커가 중단 플래그 확인/해시 수 집계를 하는 간격
+	NonceSpace             = 1 << 32          // 헤더 하나에서 탐색하는 nonce 범위 [0, NonceSpace)
+	MiningTimestampRefresh = 15 * time.Second // 채굴 중 타임스탬프 갱신 주기
+)
+
+// nonce 탐색 종료 사유
+type nonceSearch int
+
+const (
+	nonceFound     nonceSearch = iota
+	nonceStopped               // miningStop (다른 노드 블록 수신)
+	nonceExhausted             // nonce 공간 한 바퀴 소진 => extra_nonce 증가
+	nonceRefresh               // 타임스탬프 갱신 시점
+)
+
 // 채굴되지 않은 pending 을 감시해서 채굴 시작 신호 보내는 watcher
 func startMiningWatcher() {
 	t := time.NewTicker(time.Duration(MiningWatcherTime) * time.Second)
@@ -45,8 +81,13 @@ func startMiningWatcher() {
 
 	for range t.C {
 
-		// 이미 채굴 중이거나 메모리풀이 비었으면 아무것도 안함
-		if isMining.Load() || getPendingCnt() == 0 {
+		// 이미 채굴 중이거나 메모리풀이 비었으면 아무것도 안함 (조회 감사 기록만 있으면 대기 : pendingDue)
+		if isMining.Load() || !pendingDue() {
+			continue
+		}
+		// 준비되지 않은 노드(동기화 지연 등)는 채굴 신호를 보내지 않음 (health.go)
+		if !nodeReady() {
+			log.Printf("[WATCHER] Pending detected but node not ready => wait")
 			continue
 		}
 
@@ -57,8 +98,38 @@ func startMiningWatcher() {
 	}
 }
 
+// POST /admin/finalize : 대기 중인 앵커로 즉시 채굴 시작 신호 전파
+//   - 이미 채굴 중이거나 대기 앵커가 없으면 409
+func handleAdminFinalize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if isObserver() {
+		writeError(w, http.StatusForbidden, errObserverReadOnly.Error())
+		return
+	}
+	if isMining.Load() {
+		writeError(w, http.StatusConflict, "mining already in progress")
+		return
+	}
+	if failed := readinessFailures(); len(failed) > 0 {
+		writeErrorDetail(w, http.StatusServiceUnavailable, "not_ready", "node is not ready", failed)
+		return
+	}
+	records := popPending()
+	if len(records) == 0 {
+		writeError(w, http.StatusConflict, "no pending anchors")
+		return
+	}
+	log.Printf("[POW] manual finalize requested (%d anchors)", len(records))
+	sendMiningSignal(records)
+	writeJSON(w, http.StatusAccepted, map[string]any{"status": "mining signaled", "anchors": len(records)})
+}
+
 // 모든 노드에 채굴 요청 전파
 func sendMiningSignal(anchors []AnchorRecord) {
+	setDispatched(anchors)
 	req, _ := json.Marshal(map[string]any{"anchors": anchors})
 	log.Printf("[POW][NETWORK] Starting Network Mining Order")
 
@@ -66,7 +137,7 @@ func sendMiningSignal(anchors []AnchorRecord) {
 	nodes := append(peersSnapshot(), self)
 	for _, node := range nodes {
 		go func(addr string) {
-			http.Post("http://"+addr+"/mine/start", "application/json", strings.NewReader(string(req)))
+			_ = postPeer(addr, "/mine/start", req)
 			log.Printf("[POW][NETWORK] Broadcasted Mining signal to %s", addr)
 		}(node)
 	}
@@ -80,7 +151,7 @@ func handleMineStart(w http.ResponseWriter, r *http.Request) {
 		Anchors []AnchorRecord `json:"anchors"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid JSON")
 		return
 	}
 	defer r.Body.Close()
@@ -88,24 +159,34 @@ func handleMineStart(w http.ResponseWriter, r *http.Request) {
 	anchors := req.Anchors
 	if len(anchors) == 0 {
 		log.Printf("[PoW][NODE] No anchors to mine. Skip.")
+		writeError(w, http.StatusBadRequest, "no anchors to mine")
+		return
+	}
+	if err := checkBlockLimits(anchors); err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	// 준비되지 않은 노드(동기화 지연 등)는 지난 높이를 채굴하지 않도록 참여하지 않음 (health.go)
+	if failed := readinessFailures(); len(failed) > 0 {
+		writeErrorDetail(w, http.StatusServiceUnavailable, "not_ready", "node is not ready", failed)
 		return
 	}
 	// CAS: mining 시작 시점 보호
 	if !isMining.CompareAndSwap(false, true) {
 		log.Printf("[PoW][NODE] Mining already in progress => cancel new mining")
+		writeError(w, http.StatusConflict, "mining already in progress")
 		return
 	}
 
 	log.Printf("[PoW][NODE] Received mining start signal with anchors: %d", len(anchors))
 	go func(anchors []AnchorRecord) {
 		// entries를 활용해 실제 채굴 시작
-		result := mineBlock(GlobalDifficulty, anchors)
+		result := mineBlock(anchors)
 		if result.BlockHash == "" {
 			log.Printf("[POW][NODE] Mining aborted")
 			return
 		}
 		log.Printf("[PoW][NODE] ✅ Success New Block Mining #%d hash=%s elapsed=%.4fs", result.Header.Index, result.BlockHash[:12], result.Elapsed)
-		adjustDifficulty(result.Header.Index, result.Elapsed) // 채굴 난이도 조정
 		broadcastBlock(result, anchors)
 
 	}(anchors)
@@ -115,7 +196,7 @@ func handleMineStart(w http.ResponseWriter, r *http.Request) {
 
 // PoW 채굴 수행
 // 항상 현재 로컬 체인 상태 기반으로 시작
-func mineBlock(difficulty int, anchors []AnchorRecord) MineResult {
+func mineBlock(anchors []AnchorRecord) MineResult {
 
 	miningStop.Store(false)
 	mineStart := time.Now()
@@ -134,6 +215,18 @@ func mineBlock(difficulty int, anchors []AnchorRecord) MineResult {
 		return MineResult{}
 	}
 
+	// 온체인 규칙으로 다음 블록 난이도와 최소 타임스탬프 계산
+	var mtp time.Time
+	difficulty, err := nextDifficulty(prev, getBlockByIndex)
+	if err == nil {
+		mtp, err = medianTimePast(prev, getBlockByIndex)
+	}
+	if err != nil {
+		log.Printf("[PoW] Failed to compute difficulty: %v", err)
+		isMining.Store(false)
+		return MineResult{}
+	}
+
 	// 새로운 블록 헤더 구성
 	index := prev.Index + 1
 	prevHash := prev.BlockHash
@@ -145,49 +238,135 @@ func mineBlock(difficulty int, anchors []AnchorRecord) MineResult {
 		Index:      index,
 		PrevHash:   prevHash,
 		MerkleRoot: mergedRoot,
-		Timestamp:  time.Unix(time.Now().Unix(), 0).Format(time.RFC3339),
+		Timestamp:  miningTimestamp(mtp),
 		Difficulty: difficulty,
 	}
 
-	log.Printf("[PoW] Starting mining (index=%d prev=%s...)", index, prevHash[:8])
+	log.Printf("[PoW] Starting mining (index=%d prev=%s... difficulty=%d)", index, prevHash[:8], difficulty)
+
+	// Nonce 탐색 (워커별로 나눈 nonce 공간을 병렬 탐색, 소진/갱신 시점마다 헤더를 바꿔 재시작)
+	var (
+		found PoWHeader
+		hash  string
+	)
+	for {
+		var res nonceSearch
+		found, hash, res = searchNonce(header, difficulty, randomNonce(), MiningTimestampRefresh)
+		if res == nonceFound {
+			break
+		}
+		if res == nonceStopped {
+			log.Printf("[PoW] Stop PoW by Winner Node")
+			return MineResult{} // 다른 노드가 성공 시 중단
+		}
+		if res == nonceExhausted {
+			header.ExtraNonce++
+			log.Printf("[PoW] nonce space exhausted (index=%d) => extra_nonce=%d", index, header.ExtraNonce)
+		}
+		header.Timestamp = miningTimestamp(mtp)
+	}
+	// 채굴 성공 시
+	elapsed := time.Since(mineStart)
+	observeDuration("chain_mining_duration_seconds", elapsed.Seconds())
+	//isMining.Store(false) // nonce 찾기는 끝났지만, 아직 저장되지 않았으므로 플래그 변경하지 않음
+	return MineResult{BlockHash: hash, Nonce: found.Nonce, Header: found, Elapsed: float32(elapsed.Seconds())}
+}
 
-	// Nonce 탐색
-	rand.Seed(time.Now().UnixNano())
-	nonce := rand.Intn(4294967296)
+// 병렬 nonce 탐색 : start 부터 nonce 공간을 한 바퀴 돌며 유효한 해시를 찾은 헤더 반환
+//   - 워커 i 는 start+i, start+i+workers, ... (NonceSpace 에 닿으면 0 부터 이어서) 를 맡음
+//   - refresh 가 지나면 nonceRefresh, 모든 워커가 맡은 구간을 다 돌면 nonceExhausted
+func searchNonce(header PoWHeader, difficulty, start int, refresh time.Duration) (found PoWHeader, hash string, res nonceSearch) {
+	workers := max(1, MiningWorkers)
+	var (
+		done    atomic.Bool
+		expired atomic.Bool
+		once    sync.Once
+		wg      sync.WaitGroup
+		total   atomic.Int64
+	)
+	timer := time.AfterFunc(refresh, func() { expired.Store(true) })
+	defer timer.Stop()
+	began := time.Now()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(h PoWHeader, offset int) {
+			defer wg.Done()
+			search := powHasher.Searcher(h) // 워커별 미리 인코딩된 헤더
+			n, flushed := 0, 0              // 탐색한 해시 수, 그중 집계에 반영한 수
+			for ; offset < NonceSpace; offset += workers {
+				if n-flushed >= hashCountBatch {
+					addCounter("chain_mining_hashes_total", "", float64(n-flushed))
+					flushed = n
+					if done.Load() || miningStop.Load() || expired.Load() {
+						break
+					}
+				}
+				nonce := (start + offset) % NonceSpace
+				sum := search(nonce)
+				n++
+				if sumMeetsDifficulty(sum, difficulty) {
+					h.Nonce = nonce
+					once.Do(func() {
+						found, hash = h, hex.EncodeToString(sum[:])
+						done.Store(true)
+					})
+					break
+				}
+			}
+			addCounter("chain_mining_hashes_total", "", float64(n-flushed))
+			total.Add(int64(n))
+		}(header, i)
+	}
+	wg.Wait()
 
-	var hash string
+	if secs := time.Since(began).Seconds(); secs > 0 {
+		hashRateBits.Store(math.Float64bits(float64(total.Load()) / secs))
+	}
+	switch {
+	case done.Load():
+		return found, hash, nonceFound
+	case miningStop.Load():
+		return found, hash, nonceStopped
+	case expired.Load():
+		return found, hash, nonceRefresh
+	}
+	return found, hash, nonceExhausted
+}
 
-	for !miningStop.Load() {
-		header.Nonce = nonce
-		hash = computeHashForPoW(header)
-		// 채굴 성공 시
-		if validHash(hash, difficulty) {
-			mineEnd := time.Now()
-			elapsed := mineEnd.Sub(mineStart)
-			//isMining.Store(false) // nonce 찾기는 끝났지만, 아직 저장되지 않았으므로 플래그 변경하지 않음
-			return MineResult{BlockHash: hash, Nonce: nonce, Header: header, Elapsed: float32(elapsed.Seconds())}
-		}
-		nonce++
+// 탐색 시작 nonce (crypto/rand, 노드마다 다른 구간부터 탐색)
+func randomNonce() int {
+	var b [4]byte
+	rand.Read(b[:]) // Go 1.24 부터 실패하지 않음
+	return int(binary.BigEndian.Uint32(b[:]))
+}
+
+// 채굴 블록 타임스탬프 : 현재 시각, 로컬 시계가 늦으면 median-time-past (difficulty.go 규칙 충족)
+func miningTimestamp(mtp time.Time) string {
+	ts := time.Now()
+	if mtp.After(ts) {
+		ts = mtp
 	}
-	log.Printf("[PoW] Stop PoW by Winner Node")
-	return MineResult{} // 다른 노드가 성공 시 중단
+	return time.Unix(ts.Unix(), 0).Format(time.RFC3339)
+}
+
+// 마지막 채굴의 초당 해시 수
+func miningHashRate() float64 {
+	return math.Float64frombits(hashRateBits.Load())
 }
 
 // 채굴 성공 시 네트워크로 블록 전파
 func broadcastBlock(res MineResult, anchors []AnchorRecord) {
 	body, _ := json.Marshal(map[string]any{
-		"header":     res.Header,
-		"hash":       res.BlockHash,
-		"entries":    anchors,
-		"difficulty": GlobalDifficulty,
-		"elapsed":    res.Elapsed,
-		"winner":     self,
+		"header":  res.Header,
+		"hash":    res.BlockHash,
+		"entries": anchors,
+		"winner":  self,
 	})
 	// peerSnapshot은 자기자신을 포함하지 않으므로 추가
 	nodes := append(peersSnapshot(), self)
 	for _, node := range nodes {
 		go func(addr string) {
-			http.Post("http://"+addr+"/receiveBlock", "application/json", strings.NewReader(string(body)))
+			_ = postPeer(addr, "/receiveBlock", body)
 		}(node)
 	}
 	log.Printf("[PoW][P2P][BROADCAST] Winner sent NewBlock to peers: index=%d hash=%s", res.Header.Index, res.BlockHash)
@@ -197,46 +376,48 @@ func broadcastBlock(res MineResult, anchors []AnchorRecord) {
 // POST : /receive 요청을 통해 트리거
 func receiveBlock(w http.ResponseWriter, r *http.Request) {
 	var msg struct {
-		Header     PoWHeader      `json:"header"`
-		Hash       string         `json:"hash"`
-		Anchors    []AnchorRecord `json:"entries"`
-		Difficulty int            `json:"difficulty"`
-		Elapsed    float32        `json:"elapsed"`
-		Winner     string         `json:"winner"`
+		Header  PoWHeader      `json:"header"`
+		Hash    string         `json:"hash"`
+		Anchors []AnchorRecord `json:"entries"`
+		Winner  string         `json:"winner"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
-		http.Error(w, err.Error(), 400)
+		writeError(w, 400, err.Error())
 		return
 	}
 	// 이미 해당 인덱스의 블록이 존재하면 무시
 	if _, err := getBlockByIndex(msg.Header.Index); err == nil {
 		log.Printf("[PoW][NODE] Block #%d already exists -> ignore duplicate receiveBlock", msg.Header.Index)
-		return
+		return // 중복 전파는 성공으로 응답
 	}
 	// 들어온 블록이 중복된 블록이 아니라면, pow 즉시 중단
 	// 검증 없이 중단하면, 4번블록 채굴 중 3번블록 들어왔을 때 4번블록 채굴이 멈춤
 	miningStop.Store(true)
 	log.Printf("[PoW][NODE] The Winner Node is : %s", msg.Winner)
-	// PoW 유효성 검증 (기존 난이도로 검증)
-	if !validHash(msg.Hash, msg.Header.Difficulty) {
-		log.Printf("[PoW][BLOCK] Invalid hash rejected: index=%d", msg.Header.Index)
-		w.WriteHeader(http.StatusBadRequest)
+	// 검증 후 체인에 추가 (PoW 해시, 타임스탬프, 온체인 난이도 규칙 : onBlockReceived)
+	if err := addBlockToChain(msg.Header, msg.Hash, msg.Anchors); err != nil {
+		log.Printf("[PoW][BLOCK] Block rejected: index=%d from=%s: %v", msg.Header.Index, msg.Winner, err)
+		// 규칙 위반 블록으로 중단된 채굴의 앵커는 부트노드 메모리풀로 되돌려 다시 채굴
+		// (수신 블록의 앵커가 아니라 부트노드가 검증 후 내보낸 배치만 복구 : 검증 우회 방지)
+		if errors.Is(err, errInvalidBlock) {
+			incCounter("chain_blocks_rejected_total", "")
+			if isBoot.Load() {
+				if records := takeDispatched(); len(records) > 0 {
+					appendPending(records)
+				}
+			}
+		}
+		isMining.Store(false)
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	// 체인에 추가
-	addBlockToChain(msg.Header, msg.Hash, msg.Elapsed, msg.Anchors)
 	log.Printf("[PoW][CHAIN] Block accepted: index=%d hash=%s", msg.Header.Index, msg.Hash)
 	w.WriteHeader(http.StatusOK)
-
-	// 채굴된 블록의 난이도와 전달받은 난이도가 같지 않다면 전달받은 난이도를 전역변수에 반영
-	if msg.Difficulty != msg.Header.Difficulty {
-		GlobalDifficulty = msg.Difficulty
-	}
 	isMining.Store(false) // 장부 추가가 끝난 후 isMining 종료처리 => 다음 블록 채굴 가능한 상태가 됨
 }
 
 // 검증된 블록을 로컬 체인에 추가
-func addBlockToChain(header PoWHeader, hash string, elapsed float32, anchors []AnchorRecord) {
+func addBlockToChain(header PoWHeader, hash string, anchors []AnchorRecord) error {
 	block := UpperBlock{
 		Index:      header.Index,
 		GovID:      selfID(),
@@ -245,69 +426,16 @@ func addBlockToChain(header PoWHeader, hash string, elapsed float32, anchors []A
 		Records:    anchors,
 		MerkleRoot: header.MerkleRoot,
 		Nonce:      header.Nonce,
+		ExtraNonce: header.ExtraNonce,
 		Difficulty: header.Difficulty,
 		BlockHash:  hash,
-		Elapsed:    elapsed,
 	}
-	onBlockReceived(block)
-}
-
-// 세 블록 마다 채굴 소요시간에 따른 채굴 난이도 조정
-func adjustDifficulty(idx int, elapsed float32) {
-
-	log.Printf("[DIFF] Adjust Difficulty Start! Index = %d", idx)
-	// 3 블록의 소요시간 담을 배열 (0으로 초기화)
-	e := [3]float32{}
-	// 최신블록 채굴소요시간
-	e[2] = elapsed
-	if idx > 2 {
-		// 직전 블록 조회
-		b1, err1 := getBlockByIndex(idx - 1)
-		if err1 != nil {
-			log.Printf("[DIFF] Previous Block fetch error ")
-		} else { // 직전블록 채굴소요시간
-			e[1] = b1.Elapsed
-		}
-		// 전전 블록 조회
-		b2, err2 := getBlockByIndex(idx - 2)
-		if err2 != nil {
-			log.Printf("[DIFF] Pre-Previous Block fetch error")
-		} else { // 전전블록 채굴소요시간
-			e[0] = b2.Elapsed
-		}
-	}
-	avg := (float64)(e[0]+e[1]+e[2]) / 3.0
-	ratio := avg / float64(DiffStandardTime)
-
-	log.Printf("[DIFF] 3-block average elapsed = %.2f sec , ratio : %.2f (b0=%.2f b1=%.2f b2=%.2f)",
-		avg, ratio, e[0], e[1], e[2])
-
-	// 너무 일찍 끝났다면 난이도 올림
-	if ratio < 0.85 {
-		GlobalDifficulty++
-		log.Printf("[DIFF] Increased difficulty => %d", GlobalDifficulty)
-		if GlobalDifficulty == 8 {
-			GlobalDifficulty--
-			log.Printf("[DIFF] Decreased difficulty 8 => %d", GlobalDifficulty)
-		}
-
-	} else if ratio > 1.25 { // 너무 오래 걸렸다면 난이도 낮춤
-		GlobalDifficulty--
-		if GlobalDifficulty < 1 {
-			GlobalDifficulty = 1
-		}
-		log.Printf("[DIFF] Decreased difficulty => %d", GlobalDifficulty)
-	} else {
-		log.Printf("[DIFF] No difficulty change (within normal range)")
-	}
-
+	return onBlockReceived(block)
 }
 
-// 헤더 직렬화 후 SHA-256 해시 계산
+// 체인의 PoW 해시 규칙으로 헤더 해시 계산 (powhash.go)
 func computeHashForPoW(header PoWHeader) string {
-	data, _ := json.Marshal(header)
-	sum := sha256.Sum256(data)
-	return hex.EncodeToString(sum[:])
+	return powHasher.HeaderHash(header)
 }
 
 // 주어진 난이도 조건 검사
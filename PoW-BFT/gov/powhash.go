@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha3"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PoW 해시 규칙 (PoWHasher)
+// ------------------------------------------------------------
+// - 체인별로 하나의 규칙을 사용 (제네시스 생성 시 POW_HASH 로 선택, meta_pow_hash 에 기록)
+//   · 재시작 시 환경변수가 아니라 기록된 규칙을 사용 (규칙이 바뀌면 기존 블록 해시가 모두 무효가 되므로)
+//   · 기록이 없는 기존 DB 는 sha256-json (이전 빌드 규칙)
+//   · 규칙이 다르면 제네시스 해시가 달라지므로 다른 체인으로 취급됨 (chaininfo.go pow_hash 로 확인 가능)
+// - 규칙
+//   · sha256-json : SHA-256(헤더 JSON), 이전 빌드와 동일한 해시 (기본값)
+//   · sha256d     : SHA-256(SHA-256(이진 헤더))
+//   · sha3-256    : SHA3-256(이진 헤더)
+// - 채굴 시 헤더 인코딩은 워커마다 한 번만 만들고 nonce 자리만 덮어씀 (nonce 시도마다 JSON 직렬화/할당 없음)
+//   · JSON 규칙은 nonce 가 마지막 필드이므로 `..."nonce":` 까지를 미리 만들어 두고 숫자와 `}` 만 이어 붙임
+//   · 이진 헤더 : index(8) | difficulty(8) | prev_hash | merkle_root | timestamp (각 길이(2) + 바이트) | [extra_nonce(8)] | [params_hash(길이(2) + 바이트)] | nonce(8)
+//   · extra_nonce 는 0 이 아닐 때만 포함 (JSON 은 omitempty) => 추가 nonce 도입 이전 블록 해시 그대로
+//   · params_hash 는 기본값이 아닌 난이도 규칙을 기록한 제네시스에만 포함 (difficulty.go)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	PoWHashSHA256JSON = "sha256-json"
+	PoWHashSHA256d    = "sha256d"
+	PoWHashSHA3       = "sha3-256"
+)
+
+const powHashMetaKey = "meta_pow_hash"
+
+var PoWHashName = "" // 새 체인의 PoW 해시 규칙 (POW_HASH, 비어 있으면 sha256-json)
+
+type PoWHasher interface {
+	Name() string
+	// 헤더 해시 (hex, 블록 검증용)
+	HeaderHash(h PoWHeader) string
+	// nonce 만 바꿔 가며 해시하는 탐색 함수 (워커마다 하나, 동시 사용 불가)
+	Searcher(h PoWHeader) func(nonce int) [32]byte
+}
+
+var powHasher PoWHasher = jsonPoWHasher{}
+
+// 이름으로 규칙 선택
+func newPoWHasher(name string) (PoWHasher, error) {
+	switch name {
+	case PoWHashSHA256JSON:
+		return jsonPoWHasher{}, nil
+	case PoWHashSHA256d:
+		return binaryPoWHasher{name: name, sum: func(b []byte) [32]byte {
+			first := sha256.Sum256(b)
+			return sha256.Sum256(first[:])
+		}}, nil
+	case PoWHashSHA3:
+		return binaryPoWHasher{name: name, sum: sha3.Sum256}, nil
+	}
+	return nil, fmt.Errorf("unknown PoW hash %q (want %s, %s or %s)", name, PoWHashSHA256JSON, PoWHashSHA256d, PoWHashSHA3)
+}
+
+// 체인의 PoW 해시 규칙 결정 (newUpperChain 에서 제네시스 채굴 전에 호출)
+//   - 기록된 규칙이 있으면 그대로 사용, 없으면 기존 DB(제네시스 존재)는 sha256-json, 새 체인은 configured
+func initPoWHasher(configured string, haveGenesis bool) error {
+	name, recorded := getMeta(powHashMetaKey)
+	switch {
+	case recorded && name != "":
+		if configured != "" && configured != name {
+			log.Printf("[PoW][WARN] POW_HASH=%s ignored: chain uses %s", configured, name)
+		}
+	case haveGenesis:
+		name = PoWHashSHA256JSON
+	case configured != "":
+		name = configured
+	default:
+		name = PoWHashSHA256JSON
+	}
+	h, err := newPoWHasher(name)
+	if err != nil {
+		return err
+	}
+	powHasher = h
+	if !recorded {
+		if err := putMeta(powHashMetaKey, name); err != nil {
+			return err
+		}
+	}
+	log.Printf("[PoW] hash rule: %s", name)
+	return nil
+}
+
+// 헤더가 난이도(앞자리 0 의 hex 자릿수)를 만족하는지 (hex 변환 없이 바이트로 확인)
+func sumMeetsDifficulty(sum [32]byte, difficulty int) bool {
+	for i := 0; i < difficulty; i++ {
+		if i/2 >= len(sum) {
+			return false
+		}
+		b := sum[i/2]
+		if i%2 == 0 {
+			b >>= 4
+		}
+		if b&0x0F != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sha256-json : 이전 빌드 규칙 (json.Marshal(PoWHeader) 와 같은 바이트)
+type jsonPoWHasher struct{}
+
+func (jsonPoWHasher) Name() string { return PoWHashSHA256JSON }
+
+func (jsonPoWHasher) HeaderHash(h PoWHeader) string {
+	data, _ := json.Marshal(h)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (jsonPoWHasher) Searcher(h PoWHeader) func(nonce int) [32]byte {
+	h.Nonce = 0
+	data, _ := json.Marshal(h)
+	cut := bytes.LastIndex(data, []byte(`"nonce":`)) + len(`"nonce":`)
+	buf := make([]byte, cut, cut+24)
+	copy(buf, data[:cut])
+	return func(nonce int) [32]byte {
+		b := strconv.AppendInt(buf, int64(nonce), 10)
+		b = append(b, '}')
+		return sha256.Sum256(b)
+	}
+}
+
+// 이진 헤더 규칙
+type binaryPoWHasher struct {
+	name string
+	sum  func([]byte) [32]byte
+}
+
+func (b binaryPoWHasher) Name() string { return b.name }
+
+func (b binaryPoWHasher) HeaderHash(h PoWHeader) string {
+	sum := b.sum(encodePoWHeader(h))
+	return hex.EncodeToString(sum[:])
+}
+
+func (b binaryPoWHasher) Searcher(h PoWHeader) func(nonce int) [32]byte {
+	buf := encodePoWHeader(h)
+	tail := buf[len(buf)-8:]
+	return func(nonce int) [32]byte {
+		binary.BigEndian.PutUint64(tail, uint64(nonce))
+		return b.sum(buf)
+	}
+}
+
+// 이진 헤더 인코딩 (nonce 는 마지막 8바이트)
+func encodePoWHeader(h PoWHeader) []byte {
+	buf := make([]byte, 0, 16+4*2+len(h.PrevHash)+len(h.MerkleRoot)+len(h.Timestamp)+len(h.ParamsHash)+16)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(h.Index))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(h.Difficulty))
+	for _, s := range []string{h.PrevHash, h.MerkleRoot, h.Timestamp} {
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(s)))
+		buf = append(buf, s...)
+	}
+	if h.ExtraNonce != 0 {
+		buf = binary.BigEndian.AppendUint64(buf, uint64(h.ExtraNonce))
+	}
+	if h.ParamsHash != "" {
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(h.ParamsHash)))
+		buf = append(buf, h.ParamsHash...)
+	}
+	return binary.BigEndian.AppendUint64(buf, uint64(h.Nonce))
+}
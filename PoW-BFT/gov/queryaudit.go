@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Query Audit (Gov 중계 조회 감사 기록)
+// ------------------------------------------------------------
+// - Gov 가 Hos 체인에 중계한 조회를 장부에 기록 (Kind = "query_audit")
+//   · 대상 : GET /query (search), GET /patient/records (patient_records)
+//   · 기록 : 요청자(X-Requester), hos_id, 검색어 sha256, 조회 시각, 반환 건수, Hos 응답 상태
+//     (검색어/환자 ID 원문은 장부에 남기지 않음)
+//   · 조회를 처리한 Gov 노드가 자기 키로 서명 => 부트노드가 노드/서명 확인 후 메모리풀에 추가
+//   · 조회 응답은 기록을 기다리지 않음 (기록 실패는 [AUDIT][ERROR] 로그만)
+//   · 감사 기록만으로는 바로 채굴하지 않고 다음 앵커 블록에 함께 포함
+//     (앵커가 없으면 QueryAuditMaxWait 경과 후 채굴 => 반복 조회가 블록을 계속 만들지 않음)
+// - 블록 반영 시 모든 노드가 "qaudit_<hosID>|<block>:<entry>" 에 기록 저장
+//   · 분기 교체 시 해당 기관의 다른 블록 유래 상태와 함께 되돌림 (forkchoice.go rollbackTo)
+// - POST /audit/queries (노드 간) : 부트노드가 다른 Gov 노드의 감사 기록 수신
+// - GET /audit/queries?hos_id=<id>[&requester=<id>][&offset=<int>&limit=<int>] : 장부에 기록된 조회 이력
+// - QUERY_AUDIT=false 이면 기록하지 않음
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RecordKindQueryAudit = "query_audit"
+
+	QueryAuditSearch  = "search"
+	QueryAuditPatient = "patient_records"
+
+	queryAuditPrefix       = "qaudit_"
+	QueryAuditDefaultLimit = 50
+	QueryAuditMaxWait      = 60 * time.Second // 감사 기록만 있을 때 채굴까지 최대 대기
+)
+
+var queryAuditEnabled = true
+
+// 중계 조회 감사 기록
+type AuditRecord struct {
+	Query       string `json:"query"` // search | patient_records
+	Requester   string `json:"requester,omitempty"`
+	HosID       string `json:"hos_id"`
+	KeywordHash string `json:"keyword_hash"` // sha256(검색어 또는 환자 ID)
+	Ts          string `json:"ts"`
+	ResultCount int    `json:"result_count"`
+	Status      int    `json:"status"` // 요청자에게 돌려준 HTTP 상태
+	Node        string `json:"node"`   // 조회를 처리한 Gov 노드
+	NodeKey     string `json:"node_key"`
+	Sig         string `json:"sig"`
+}
+
+func (a AuditRecord) digest() string {
+	return sha256Hex([]byte(fmt.Sprintf("query_audit|%s|%s|%s|%s|%s|%d|%d|%s",
+		a.Query, a.Requester, a.HosID, a.KeywordHash, a.Ts, a.ResultCount, a.Status, a.Node)))
+}
+
+// 장부에 기록된 감사 기록 (블록 위치 포함)
+type AuditEntry struct {
+	AuditRecord
+	Block int `json:"block"`
+	Entry int `json:"entry"`
+}
+
+func queryAuditKey(hosID string, bi, ei int) string {
+	return fmt.Sprintf("%s%s|%010d:%04d", queryAuditPrefix, hosID, bi, ei)
+}
+
+// 응답 본문(JSON 배열)의 항목 수
+func resultCount(body []byte) int {
+	var items []json.RawMessage
+	if json.Unmarshal(body, &items) != nil {
+		return 0
+	}
+	return len(items)
+}
+
+// 중계 조회 감사 기록 제출 (핸들러에서 호출, 비동기)
+func recordQueryAudit(r *http.Request, query, hosID, keyword string, count, status int) {
+	if !queryAuditEnabled {
+		return
+	}
+	a := AuditRecord{
+		Query:       query,
+		Requester:   r.Header.Get("X-Requester"),
+		HosID:       hosID,
+		KeywordHash: sha256Hex([]byte(keyword)),
+		Ts:          time.Now().UTC().Format(time.RFC3339Nano),
+		ResultCount: count,
+		Status:      status,
+		Node:        self,
+	}
+	go func() {
+		if err := submitQueryAudit(a); err != nil {
+			log.Printf("[AUDIT][ERROR] %s hos=%s requester=%q : %v", a.Query, a.HosID, a.Requester, err)
+		}
+	}()
+}
+
+// 노드 키로 서명 후 부트노드 메모리풀에 추가 (이 노드가 부트노드면 직접 추가)
+func submitQueryAudit(a AuditRecord) error {
+	pubPem, _ := getMeta("meta_gov_pubkey")
+	a.NodeKey = pubPem
+	digest, _ := hex.DecodeString(a.digest())
+	sig, err := signWithGovKey(digest)
+	if err != nil {
+		return err
+	}
+	a.Sig = sig
+
+	if isBoot.Load() {
+		_, err := acceptQueryAudit(a)
+		return err
+	}
+	body, _ := json.Marshal(a)
+	resp, err := nodeClient.Post(nodeURL(getBootAddr(), "/audit/queries"), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("boot node unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("boot node rejected audit: %s", strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// 감사 기록 확인 후 메모리풀에 추가 (부트노드)
+func acceptQueryAudit(a AuditRecord) (int, error) {
+	// 기록한 노드는 현재 Gov 네트워크의 노드여야 함
+	known := a.Node == self
+	for _, p := range peersSnapshot() {
+		known = known || p == a.Node
+	}
+	if !known {
+		return http.StatusForbidden, fmt.Errorf("node %s is not a gov node", a.Node)
+	}
+	var pubPem string
+	if a.Node == self {
+		pubPem, _ = getMeta("meta_gov_pubkey")
+	} else {
+		k, err := fetchPublicKey(a.Node)
+		if err != nil {
+			return http.StatusBadGateway, fmt.Errorf("failed to fetch node key: %v", err)
+		}
+		pubPem = k
+	}
+	if strings.TrimSpace(pubPem) != strings.TrimSpace(a.NodeKey) || !verifyPemSignature(pubPem, a.digest(), a.Sig) {
+		return http.StatusForbidden, fmt.Errorf("invalid audit signature")
+	}
+
+	if err := admitPending([]AnchorRecord{{
+		HosID:           a.HosID,
+		Kind:            RecordKindQueryAudit,
+		LowerRoot:       a.digest(),
+		AccessCatalog:   []string{},
+		AnchorTimestamp: a.Ts,
+		QueryAudit:      &a,
+	}}); err != nil {
+		return http.StatusTooManyRequests, err
+	}
+	return http.StatusAccepted, nil
+}
+
+// 블록에 기록된 감사 기록 반영 (updateIndicesForBlock 에서 호출)
+func applyQueryAuditRecord(tx *ledgerTxn, blockIndex, entryIndex int, rec AnchorRecord) error {
+	if rec.QueryAudit == nil {
+		return nil
+	}
+	a := *rec.QueryAudit
+	if rec.LowerRoot != a.digest() || !verifyPemSignature(a.NodeKey, a.digest(), a.Sig) {
+		log.Printf("[AUDIT][WARN] Block #%d: invalid query audit from %s", blockIndex, a.Node)
+		return nil
+	}
+	data, _ := json.Marshal(AuditEntry{AuditRecord: a, Block: blockIndex, Entry: entryIndex})
+	tx.putMeta(queryAuditKey(a.HosID, blockIndex, entryIndex), string(data))
+	return nil
+}
+
+// POST /audit/queries : 다른 Gov 노드의 감사 기록 수신 (노드 간)
+// GET  /audit/queries : 장부에 기록된 조회 이력 (블록 순, 키가 블록/엔트리 번호로 정렬됨)
+func handleQueryAudits(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		requireNodeCert(receiveQueryAudit)(w, r)
+
+	case http.MethodGet:
+		q := r.URL.Query()
+		hosID := q.Get("hos_id")
+		if hosID == "" {
+			writeError(w, http.StatusBadRequest, "hos_id required")
+			return
+		}
+		requester := q.Get("requester")
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if offset < 0 {
+			writeError(w, http.StatusBadRequest, "invalid offset")
+			return
+		}
+		if limit <= 0 {
+			limit = QueryAuditDefaultLimit
+		}
+
+		var all []AuditEntry
+		iter := db.NewIterator(util.BytesPrefix([]byte(queryAuditPrefix+hosID+"|")), nil)
+		for iter.Next() {
+			var e AuditEntry
+			if err := json.Unmarshal(iter.Value(), &e); err == nil && (requester == "" || e.Requester == requester) {
+				all = append(all, e)
+			}
+		}
+		iter.Release()
+		out := []AuditEntry{}
+		if offset < len(all) {
+			out = all[offset:min(offset+limit, len(all))]
+		}
+		w.Header().Set("X-Total-Count", strconv.Itoa(len(all)))
+		writeJSON(w, http.StatusOK, out)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// 감사 기록 수신 (부트노드 전용)
+func receiveQueryAudit(w http.ResponseWriter, r *http.Request) {
+	if !isBoot.Load() {
+		writeError(w, http.StatusForbidden, "only boot node records query audits")
+		return
+	}
+	var a AuditRecord
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+	if status, err := acceptQueryAudit(a); err != nil {
+		log.Printf("[AUDIT][DENY] from %s : %v", a.Node, err)
+		if full, ok := err.(*PendingFullError); ok {
+			writePendingFull(w, full)
+			return
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
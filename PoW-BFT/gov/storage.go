@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -26,7 +27,7 @@ var db *leveldb.DB
 
 // ---- 내부 메타키 헬퍼 ---------------------------------------------------------
 func putMeta(key, val string) error {
-	return db.Put([]byte(key), []byte(val), nil)
+	return countDBError(db.Put([]byte(key), []byte(val), nil))
 }
 func getMeta(key string) (string, bool) {
 	v, err := db.Get([]byte(key), nil)
@@ -37,17 +38,17 @@ func getMeta(key string) (string, bool) {
 }
 
 func getLatestHeight() (int, bool) {
-	if s, ok := getMeta("height_latest"); ok {
-		h, err := strconv.Atoi(s)
+	return getLatestHeightFrom(db)
+}
+func getLatestHeightFrom(rd kvReader) (int, bool) {
+	if v, err := rd.Get([]byte("height_latest"), nil); err == nil {
+		h, err := strconv.Atoi(string(v))
 		if err == nil {
 			return h, true
 		}
 	}
 	return 0, false
 }
-func setLatestHeight(h int) error {
-	return putMeta("height_latest", strconv.Itoa(h))
-}
 
 // DB 초기화
 func initDB(path string) {
@@ -75,40 +76,156 @@ func closeDB() {
 // - Key1: "block_<Index>"     => UpperBlock JSON (번호 기반 접근)
 // - Key2: "hash_<BlockHash>"  => UpperBlock JSON (해시 기반 접근)
 // 주: 키 형식은 기존 코드와의 호환을 위해 유지
-func saveBlockToDB(block UpperBlock) error {
+func saveBlockToTxn(tx *ledgerTxn, block UpperBlock) error {
 	data, err := json.Marshal(block)
 	if err != nil {
 		return err
 	}
 
 	// 블록 번호 기반 저장
-	keyByIndex := fmt.Sprintf("block_%d", block.Index)
-	if err := db.Put([]byte(keyByIndex), data, nil); err != nil {
-		return err
-	}
+	tx.Put([]byte(fmt.Sprintf("block_%d", block.Index)), data)
 
 	// 블록 해시 기반 저장
-	keyByHash := fmt.Sprintf("hash_%s", block.BlockHash)
-	if err := db.Put([]byte(keyByHash), data, nil); err != nil {
+	tx.Put([]byte(fmt.Sprintf("hash_%s", block.BlockHash)), data)
+
+	// 최신 루트 캐시(선택)
+	tx.Put([]byte("root_latest"), []byte(block.MerkleRoot))
+
+	// 누적 작업량 기록 (fork-choice 기준, forkchoice.go)
+	if err := putCumulativeWork(tx, block); err != nil {
 		return err
 	}
 
-	// 최신 루트 캐시(선택)
-	if err := db.Put([]byte("root_latest"), []byte(block.MerkleRoot), nil); err != nil {
+	// 체인 상태 커밋먼트 갱신
+	return appendCommitment(tx, block)
+}
+
+// 블록 본문 + 색인 + 최신 높이 + 되돌리기 기록을 트랜잭션에 적재
+// - 블록이 바꾸는 키의 이전 값을 "undo_<Index>" 에 함께 기록 (분기 교체 시 그대로 복원, forkchoice.go)
+func stageBlock(tx *ledgerTxn, block UpperBlock) error {
+	tx.beginUndo()
+	if err := saveBlockToTxn(tx, block); err != nil {
 		return err
 	}
+	if err := updateIndicesForBlock(tx, block); err != nil {
+		return err
+	}
+	tx.Put([]byte("height_latest"), []byte(strconv.Itoa(block.Index)))
+	tx.endUndo(undoKey(block.Index))
+	return nil
+}
 
-	log.Printf("[DB] Block #%d saved (Hash=%s)\n", block.Index, block.BlockHash)
+// 블록 하나를 하나의 Batch 로 원자적 반영
+// - 중간에 프로세스가 죽어도 "본문만 있고 색인/높이가 없는" 블록이 남지 않음
+func commitBlock(block UpperBlock) error {
+	tx := newLedgerTxn()
+	if err := stageBlock(tx, block); err != nil {
+		return err
+	}
+	if err := tx.commit(); err != nil {
+		return err
+	}
+	log.Printf("[DB] Block #%d committed (Hash=%s)\n", block.Index, block.BlockHash)
 	appendBlockLog(block)
 	return nil
 }
 
+////////////////////////////////////////////////////////////////////////////////
+// 장부 트랜잭션 (ledgerTxn)
+//  - 블록 반영/분기 교체의 모든 쓰기를 leveldb.Batch 하나에 모아 한 번에 기록
+//  - 트랜잭션 안의 조회는 아직 기록되지 않은 쓰기를 먼저 반영해서 읽음
+//    (같은 트랜잭션에서 앞 블록이 바꾼 가입 현황/커밋먼트를 다음 블록이 이어서 사용)
+//  - beginUndo ~ endUndo 사이에 바뀐 키는 처음 바뀌기 전 값을 모아 되돌리기 기록으로 저장
+////////////////////////////////////////////////////////////////////////////////
+
+// 블록 조회에 필요한 최소 읽기 인터페이스 (*leveldb.DB, *leveldb.Snapshot, *ledgerTxn)
+type kvReader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+}
+
+type ledgerTxn struct {
+	batch *leveldb.Batch
+	vals  map[string][]byte  // 이 트랜잭션에서 쓴 값 (nil = 삭제)
+	undo  map[string]*string // 되돌리기 기록 중인 키의 이전 값 (nil = 없던 키)
+}
+
+func newLedgerTxn() *ledgerTxn {
+	return &ledgerTxn{batch: new(leveldb.Batch), vals: map[string][]byte{}}
+}
+
+func undoKey(index int) []byte { return []byte(fmt.Sprintf("undo_%d", index)) }
+
+func (t *ledgerTxn) Get(key []byte, _ *opt.ReadOptions) ([]byte, error) {
+	if v, ok := t.vals[string(key)]; ok {
+		if v == nil {
+			return nil, leveldb.ErrNotFound
+		}
+		return v, nil
+	}
+	return db.Get(key, nil)
+}
+
+func (t *ledgerTxn) Put(key, val []byte) {
+	t.capture(key)
+	t.batch.Put(key, val)
+	t.vals[string(key)] = append([]byte{}, val...)
+}
+
+func (t *ledgerTxn) Delete(key []byte) {
+	t.capture(key)
+	t.batch.Delete(key)
+	t.vals[string(key)] = nil
+}
+
+func (t *ledgerTxn) getMeta(key string) (string, bool) {
+	v, err := t.Get([]byte(key), nil)
+	if err != nil {
+		return "", false
+	}
+	return string(v), true
+}
+
+func (t *ledgerTxn) putMeta(key, val string) {
+	t.Put([]byte(key), []byte(val))
+}
+
+func (t *ledgerTxn) beginUndo() { t.undo = map[string]*string{} }
+
+// 되돌리기 기록을 key 에 저장하고 기록 종료
+func (t *ledgerTxn) endUndo(key []byte) {
+	data, _ := json.Marshal(t.undo)
+	t.undo = nil
+	t.Put(key, data)
+}
+
+func (t *ledgerTxn) capture(key []byte) {
+	if t.undo == nil {
+		return
+	}
+	if _, ok := t.undo[string(key)]; ok {
+		return
+	}
+	var prev *string
+	if v, err := t.Get(key, nil); err == nil {
+		s := string(v)
+		prev = &s
+	}
+	t.undo[string(key)] = prev
+}
+
+func (t *ledgerTxn) commit() error {
+	return countDBError(db.Write(t.batch, nil))
+}
+
 // 인덱스로 블록 조회
 func getBlockByIndex(index int) (UpperBlock, error) {
+	return getBlockByIndexFrom(db, index)
+}
+func getBlockByIndexFrom(rd kvReader, index int) (UpperBlock, error) {
 	key := fmt.Sprintf("block_%d", index)
-	data, err := db.Get([]byte(key), nil)
+	data, err := rd.Get([]byte(key), nil)
 	if err != nil {
-		return UpperBlock{}, err
+		return UpperBlock{}, countDBError(err)
 	}
 	var block UpperBlock
 	if err := json.Unmarshal(data, &block); err != nil {
@@ -133,7 +250,10 @@ func getBlockByHash(hash string) (UpperBlock, error) {
 
 // 최신 루트 캐시 조회(없으면 빈 문자열)
 func getLatestRoot() string {
-	if v, err := db.Get([]byte("root_latest"), nil); err == nil {
+	return getLatestRootFrom(db)
+}
+func getLatestRootFrom(rd dbReader) string {
+	if v, err := rd.Get([]byte("root_latest"), nil); err == nil {
 		return string(v)
 	}
 	return ""
@@ -141,16 +261,10 @@ func getLatestRoot() string {
 
 // UpperBlock 내의 AnchorRecord(각 Hos별 앵커 데이터)를 기반으로
 // LevelDB에 색인 정보를 갱신하는 함수
-func updateIndicesForBlock(block UpperBlock) error {
-	ptr := func(bi, ei int) []byte { return []byte(fmt.Sprintf("%d:%d", bi, ei)) }
-
+func updateIndicesForBlock(tx *ledgerTxn, block UpperBlock) error {
 	for ei, rec := range block.Records {
-		// Hos별 앵커 색인 등록
-		if rec.HosID != "" {
-			keyByHos := fmt.Sprintf("anchor_%s", rec.HosID)
-			if err := db.Put([]byte(keyByHos), ptr(block.Index, ei), nil); err != nil {
-				return err
-			}
+		if err := indexRecord(tx, block.Index, ei, rec); err != nil {
+			return err
 		}
 	}
 
@@ -159,6 +273,35 @@ func updateIndicesForBlock(block UpperBlock) error {
 	return nil
 }
 
+// 블록 bi 의 ei 번째 레코드 색인 반영 (포크 정리 시 기관 단위 재반영에도 사용, forkchoice.go)
+func indexRecord(tx *ledgerTxn, bi, ei int, rec AnchorRecord) error {
+	ptr := []byte(fmt.Sprintf("%d:%d", bi, ei))
+
+	// 기관 가입 신청/투표, 키 교체, 조회 감사 기록은 앵커 색인 대신 가입 현황/키 이력/감사 이력에 반영
+	if rec.Kind != "" {
+		switch rec.Kind {
+		case RecordKindKeyRotation:
+			return applyKeyRotationRecord(tx, bi, rec)
+		case RecordKindQueryAudit:
+			return applyQueryAuditRecord(tx, bi, ei, rec)
+		}
+		return applyOnboardingRecord(tx, bi, rec)
+	}
+	// Hos별 앵커 색인 등록
+	if rec.HosID != "" {
+		tx.Put([]byte(fmt.Sprintf("anchor_%s", rec.HosID)), ptr)
+		// 앵커 루트 색인 (GET /verify 용)
+		tx.Put(anchorRootKey(rec.LowerRoot), ptr)
+		// 앵커 이력 (GET /anchors 용, anchorhistory.go)
+		if err := putAnchorHistory(tx, bi, ei, rec); err != nil {
+			return err
+		}
+	}
+	// 계약 메타데이터 보조 인덱스 등록
+	updateContractIndices(tx, ptr, rec)
+	return nil
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // 검색 유틸
 ////////////////////////////////////////////////////////////////////////////////
@@ -178,12 +321,21 @@ func parsePtr(s string) (int, int, bool) {
 // 전체 장부(블록) 조회 유틸
 // ==========================
 
-// 전체 블록 조회
+// 전체 블록 조회 (하나의 스냅샷에서 읽음, snapshot.go)
 func listAllBlocks() ([]UpperBlock, error) {
-	h, ok := getLatestHeight()
+	var out []UpperBlock
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		out, err = listAllBlocksFrom(rd)
+		return err
+	})
+	return out, err
+}
+func listAllBlocksFrom(rd dbReader) ([]UpperBlock, error) {
+	h, ok := getLatestHeightFrom(rd)
 	if !ok {
 		// 제네시스만 있을 수도 있으니 0만 확인
-		b0, err := getBlockByIndex(0)
+		b0, err := getBlockByIndexFrom(rd, 0)
 		if err != nil {
 			return nil, fmt.Errorf("no chain: %w", err)
 		}
@@ -191,7 +343,7 @@ func listAllBlocks() ([]UpperBlock, error) {
 	}
 	out := make([]UpperBlock, 0, h+1)
 	for i := 0; i <= h; i++ {
-		b, err := getBlockByIndex(i)
+		b, err := getBlockByIndexFrom(rd, i)
 		if err != nil {
 			return nil, fmt.Errorf("load block_%d: %w", i, err)
 		}
@@ -201,14 +353,25 @@ func listAllBlocks() ([]UpperBlock, error) {
 }
 
 // 페이지네이션 조회 : ffset에서 최대 limit개 반환, total(=height+1)도 함께 반환
+// - total 과 블록 목록은 같은 스냅샷에서 읽음
 func listBlocksPaginated(offset, limit int) ([]UpperBlock, int, error) {
 	if offset < 0 || limit <= 0 {
 		return nil, 0, fmt.Errorf("invalid offset/limit")
 	}
-	h, ok := getLatestHeight()
+	var out []UpperBlock
+	total := 0
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		out, total, err = listBlocksPaginatedFrom(rd, offset, limit)
+		return err
+	})
+	return out, total, err
+}
+func listBlocksPaginatedFrom(rd dbReader, offset, limit int) ([]UpperBlock, int, error) {
+	h, ok := getLatestHeightFrom(rd)
 	if !ok {
 		// 제네시스만 있는지 확인
-		if _, err := getBlockByIndex(0); err != nil {
+		if _, err := getBlockByIndexFrom(rd, 0); err != nil {
 			return nil, 0, fmt.Errorf("no chain: %w", err)
 		}
 		h = 0
@@ -223,7 +386,7 @@ func listBlocksPaginated(offset, limit int) ([]UpperBlock, int, error) {
 	}
 	out := make([]UpperBlock, 0, end-offset+1)
 	for i := offset; i <= end; i++ {
-		b, err := getBlockByIndex(i)
+		b, err := getBlockByIndexFrom(rd, i)
 		if err != nil {
 			return nil, total, fmt.Errorf("load block_%d: %w", i, err)
 		}
@@ -232,6 +395,42 @@ func listBlocksPaginated(offset, limit int) ([]UpperBlock, int, error) {
 	return out, total, nil
 }
 
+const MaxRecentBlocks = 100 // /blocks/recent 한 번에 반환하는 최대 블록 수
+
+// 최신 블록부터 최대 count개를 역순으로 조회
+// - height 메타데이터에서 바로 시작하므로 offset 0부터 순회할 필요 없음
+func listRecentBlocks(count int) ([]UpperBlock, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid count")
+	}
+	var out []UpperBlock
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		out, err = listRecentBlocksFrom(rd, count)
+		return err
+	})
+	return out, err
+}
+func listRecentBlocksFrom(rd dbReader, count int) ([]UpperBlock, error) {
+	h, ok := getLatestHeightFrom(rd)
+	if !ok {
+		// 제네시스만 있는지 확인
+		if _, err := getBlockByIndexFrom(rd, 0); err != nil {
+			return nil, fmt.Errorf("no chain: %w", err)
+		}
+		h = 0
+	}
+	out := make([]UpperBlock, 0, min(count, h+1))
+	for i := h; i >= 0 && len(out) < count; i-- {
+		b, err := getBlockByIndexFrom(rd, i)
+		if err != nil {
+			return nil, fmt.Errorf("load block_%d: %w", i, err)
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
 // 현재 노드의 Hos 식별자 반환 (메타데이터에서 읽기)
 func selfID() string {
 	if v, ok := getMeta("meta_gov_id"); ok {
@@ -257,36 +456,6 @@ func appendBlockLog(block UpperBlock) {
 	log.Printf("[LOG][WRITE] Success to Write BlockHistory: %v", err)
 }
 
-// 로컬 체인을 완전히 초기화하고 제네시스 블록만 재생성
-func resetLocalDB() error {
-	chainMu.Lock()
-	defer chainMu.Unlock()
-
-	log.Printf("[CHAIN] Local chain RESET in progress...")
-
-	// LevelDB 전체 삭제
-	iter := db.NewIterator(nil, nil)
-	for iter.Next() {
-		key := iter.Key()
-		if err := db.Delete(key, nil); err != nil {
-			iter.Release()
-			return fmt.Errorf("failed to delete key %s: %v", string(key), err)
-		}
-	}
-	iter.Release()
-	if err := iter.Error(); err != nil {
-		return fmt.Errorf("iterator error during db clear: %v", err)
-	}
-
-	// 로컬 height 초기화
-	if err := setLatestHeight(-1); err != nil {
-		return fmt.Errorf("failed to reset height: %v", err)
-	}
-
-	log.Printf("[CHAIN] Local chain RESET complete ")
-	return nil
-}
-
 type AnchorInfo struct {
 	Root string `json:"root"`
 	Ts   string `json:"ts"`
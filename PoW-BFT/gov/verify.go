@@ -0,0 +1,174 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Verify (최종 사용자용 Merkle 증명 검증 + 서명 영수증)
+// ------------------------------------------------------------
+// GET /verify?hos_id=<id>&leaf=<hex>&block_root=<hex>&proof=<JSON [["L"|"R","<hex>"],...]>
+// - proof 로 leaf => block_root 재계산
+// - block_root 가 해당 Hos 의 AnchorRecord 로 장부에 기록되었는지 확인
+//   · anchored : 상위 블록에 포함됨 (upper_block_index 제공)
+//   · pending  : 앵커는 수락되었으나 아직 블록에 포함되지 않음
+//   · unknown  : 앵커 기록 없음
+// - 결과를 Gov 노드 키로 서명한 영수증으로 반환 (공개키는 GET /getPublicKey)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	AnchorStatusAnchored = "anchored"
+	AnchorStatusPending  = "pending"
+	AnchorStatusUnknown  = "unknown"
+)
+
+type VerificationReceipt struct {
+	HosID           string      `json:"hos_id"`
+	Leaf            string      `json:"leaf"`
+	BlockRoot       string      `json:"block_root"`
+	Proof           [][2]string `json:"proof"`
+	ProofVersion    int         `json:"proof_version"` // 검증에 사용한 증명 형식 버전 (?proof_version=, 없으면 0)
+	ProofValid      bool        `json:"proof_valid"`
+	AnchorStatus    string      `json:"anchor_status"`
+	UpperBlockIndex *int        `json:"upper_block_index,omitempty"`
+	UpperBlockHash  string      `json:"upper_block_hash,omitempty"`
+	Verified        bool        `json:"verified"` // proof_valid && anchored
+	GovID           string      `json:"gov_id"`
+	Signer          string      `json:"signer"`
+	IssuedAt        string      `json:"issued_at"`
+	Sig             string      `json:"sig"`
+}
+
+// 서명 대상 다이제스트 (Sig 제외)
+func (v VerificationReceipt) digest() []byte {
+	body := v
+	body.Sig = ""
+	sum := sha256.Sum256(jsonCanonical(body))
+	return sum[:]
+}
+
+// 앵커 루트 색인 키 (anchor_ 접두어는 최신 앵커 복원에 쓰이므로 별도 접두어 사용)
+func anchorRootKey(root string) []byte {
+	return []byte("aroot_" + root)
+}
+
+// hosID 의 block_root 가 기록된 상위 블록 조회 (색인 우선, 색인 이전 블록은 전체 스캔)
+// - 색인과 블록 본문은 같은 스냅샷에서 읽음
+func findAnchoredBlock(hosID, root string) (UpperBlock, bool) {
+	var (
+		found UpperBlock
+		ok    bool
+	)
+	_ = withReadSnapshot(func(rd dbReader) error {
+		found, ok = findAnchoredBlockFrom(rd, hosID, root)
+		return nil
+	})
+	return found, ok
+}
+func findAnchoredBlockFrom(rd dbReader, hosID, root string) (UpperBlock, bool) {
+	if v, err := rd.Get(anchorRootKey(root), nil); err == nil {
+		if bi, ei, ok := parsePtr(string(v)); ok {
+			if b, err := getBlockByIndexFrom(rd, bi); err == nil && ei < len(b.Records) &&
+				b.Records[ei].HosID == hosID && b.Records[ei].LowerRoot == root {
+				return b, true
+			}
+		}
+	}
+	blocks, err := listAllBlocksFrom(rd)
+	if err != nil {
+		return UpperBlock{}, false
+	}
+	for _, b := range blocks {
+		for _, rec := range b.Records {
+			if rec.Kind == "" && rec.HosID == hosID && rec.LowerRoot == root {
+				return b, true
+			}
+		}
+	}
+	return UpperBlock{}, false
+}
+
+// 수락되었으나 아직 블록에 포함되지 않은 앵커인지 확인
+func isPendingAnchor(hosID, root string) bool {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	for _, rec := range ch.pending {
+		if rec.Kind == "" && rec.HosID == hosID && rec.LowerRoot == root {
+			return true
+		}
+	}
+	return false
+}
+
+// 증명 형식 버전에 맞춰 Merkle 증명 검증 (지원하지 않는 버전은 실패)
+func proofValid(version int, leaf string, proof [][2]string, root string) bool {
+	ok, err := merkle.VerifyVersion(version, leaf, proof, root)
+	if err != nil {
+		logInfo("[PROOF][WARN] %v", err)
+	}
+	return ok
+}
+
+// GET /verify
+func handleVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	q := r.URL.Query()
+	rc := VerificationReceipt{
+		HosID:     q.Get("hos_id"),
+		Leaf:      q.Get("leaf"),
+		BlockRoot: q.Get("block_root"),
+		Proof:     [][2]string{},
+	}
+	if rc.HosID == "" || rc.Leaf == "" || rc.BlockRoot == "" {
+		writeError(w, http.StatusBadRequest, "hos_id, leaf and block_root required")
+		return
+	}
+	if p := q.Get("proof"); p != "" {
+		if err := json.Unmarshal([]byte(p), &rc.Proof); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid proof (expected JSON [[\"L|R\",\"hash\"],...]): %v", err))
+			return
+		}
+	}
+	if v := q.Get("proof_version"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || !merkle.ProofVersionSupported(n) {
+			writeErrorDetail(w, http.StatusBadRequest, "unsupported_proof_version", "unsupported proof_version", map[string]any{"supported": merkle.SupportedProofVersions()})
+			return
+		}
+		rc.ProofVersion = n
+	}
+
+	rc.ProofValid = proofValid(rc.ProofVersion, rc.Leaf, rc.Proof, rc.BlockRoot)
+	rc.AnchorStatus = AnchorStatusUnknown
+	if b, ok := findAnchoredBlock(rc.HosID, rc.BlockRoot); ok {
+		idx := b.Index
+		rc.AnchorStatus = AnchorStatusAnchored
+		rc.UpperBlockIndex = &idx
+		rc.UpperBlockHash = b.BlockHash
+	} else if isPendingAnchor(rc.HosID, rc.BlockRoot) {
+		rc.AnchorStatus = AnchorStatusPending
+	}
+	rc.Verified = rc.ProofValid && rc.AnchorStatus == AnchorStatusAnchored
+	rc.GovID = selfID()
+	rc.Signer = self
+	rc.IssuedAt = time.Now().UTC().Format(time.RFC3339)
+
+	sig, err := signWithGovKey(rc.digest())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to sign receipt: "+err.Error())
+		return
+	}
+	rc.Sig = sig
+	logInfo("[VERIFY] hos=%s root=%s -> proof=%v anchor=%s", rc.HosID, rc.BlockRoot, rc.ProofValid, rc.AnchorStatus)
+	writeJSON(w, http.StatusOK, rc)
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Webhooks (블록/앵커/부트노드 이벤트 외부 통지)
+// ------------------------------------------------------------
+// - 외부 시스템(과금, CMS, 병원 EMR)이 폴링 없이 반응하도록 이벤트를 운영자가 지정한 URL 로 POST
+// - 설정
+//   · WEBHOOK_URLS   : 쉼표로 구분한 수신 URL (http/https), 비어 있으면 비활성
+//   · WEBHOOK_EVENTS : 보낼 이벤트 유형 (기본 block_finalized,anchor_accepted,anchor_rejected,boot_elected, "*" 면 전체)
+//   · WEBHOOK_SECRET : HMAC-SHA256 서명 키 (없으면 서명 헤더 생략)
+// - 요청 본문은 /events 와 같은 NodeEvent JSON, 헤더
+//   · X-Webhook-Event     : 이벤트 유형
+//   · X-Webhook-Id        : 전송 ID (재시도해도 같음 => 수신 측 중복 제거용)
+//   · X-Webhook-Timestamp : 전송 시각 (unix 초)
+//   · X-Webhook-Signature : "sha256=" + hex(HMAC(secret, timestamp + "." + body)), 재시도마다 새 시각으로 다시 서명
+// - 재시도 : 전송 오류/5xx/429 면 WebhookRetries 회까지 지수 백오프 (1s, 2s, 4s ...), 그 외 4xx 는 바로 포기
+// - URL 마다 전송 큐 하나 (이벤트 순서 유지), 큐(WebhookQueueSize)가 차면 버리고 dropped 집계 (노드 처리를 막지 않음)
+// - GET /admin/webhooks : 대상별 전송/실패/버림 횟수와 마지막 오류 (URL 의 사용자 정보/쿼리는 가림)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	WebhookQueueSize = 256
+	WebhookRetries   = 5
+	WebhookTimeout   = 10 // 초
+)
+
+var webhookDefaultEvents = []string{EventBlockFinalized, EventAnchorAccepted, EventAnchorRejected, EventBootElected}
+
+// 대상별 전송 현황 (GET /admin/webhooks)
+type WebhookStatus struct {
+	URL         string `json:"url"`
+	Delivered   int    `json:"delivered"`
+	Failed      int    `json:"failed"`  // 재시도 후에도 실패
+	Dropped     int    `json:"dropped"` // 큐가 가득 차 버림
+	Queued      int    `json:"queued"`
+	LastError   string `json:"last_error,omitempty"`
+	LastSuccess string `json:"last_success,omitempty"`
+}
+
+type webhookDelivery struct {
+	id   string
+	typ  string
+	body []byte
+}
+
+type webhookTarget struct {
+	url   string
+	queue chan webhookDelivery
+
+	mu    sync.Mutex
+	stats WebhookStatus
+}
+
+var (
+	webhookTargets []*webhookTarget
+	webhookEvents  map[string]bool // nil 이면 전체
+	webhookSecret  []byte
+	webhookClient  = &http.Client{Timeout: WebhookTimeout * time.Second}
+)
+
+// 설정 해석 + 대상별 전송 루틴 시작 (main 에서 호출)
+func initWebhooks(urls, events, secret string) error {
+	for _, raw := range strings.Split(urls, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook url %q", raw)
+		}
+		t := &webhookTarget{url: raw, queue: make(chan webhookDelivery, WebhookQueueSize)}
+		t.stats.URL = (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+		webhookTargets = append(webhookTargets, t)
+	}
+	if len(webhookTargets) == 0 {
+		return nil
+	}
+	if events = strings.TrimSpace(events); events == "" {
+		events = strings.Join(webhookDefaultEvents, ",")
+	}
+	if events != "*" {
+		webhookEvents = map[string]bool{}
+		for _, e := range strings.Split(events, ",") {
+			webhookEvents[strings.TrimSpace(e)] = true
+		}
+	}
+	webhookSecret = []byte(secret)
+	for _, t := range webhookTargets {
+		go t.run()
+	}
+	log.Printf("[WEBHOOK] %d targets (events=%s, signed=%v)", len(webhookTargets), events, len(webhookSecret) > 0)
+	return nil
+}
+
+// 이 유형의 이벤트를 웹훅으로 보내는지
+func webhookWanted(typ string) bool {
+	return len(webhookTargets) > 0 && (webhookEvents == nil || webhookEvents[typ])
+}
+
+// 대상별 큐에 넣기 (가득 차면 버림, publishEvent 에서 호출)
+func dispatchWebhooks(ev NodeEvent) {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		log.Printf("[WEBHOOK][ERROR] encode %s: %v", ev.Type, err)
+		return
+	}
+	d := webhookDelivery{id: newJobID(), typ: ev.Type, body: body}
+	for _, t := range webhookTargets {
+		select {
+		case t.queue <- d:
+		default:
+			t.mu.Lock()
+			t.stats.Dropped++
+			t.mu.Unlock()
+			incCounter("chain_webhook_deliveries_total", `result="dropped"`)
+		}
+	}
+}
+
+func (t *webhookTarget) run() {
+	for d := range t.queue {
+		err := t.deliver(d)
+		t.mu.Lock()
+		if err != nil {
+			t.stats.Failed++
+			t.stats.LastError = fmt.Sprintf("%s %s: %v", d.typ, d.id, err)
+		} else {
+			t.stats.Delivered++
+			t.stats.LastSuccess = time.Now().UTC().Format(time.RFC3339)
+		}
+		t.mu.Unlock()
+		if err != nil {
+			log.Printf("[WEBHOOK][ERROR] %s to %s failed: %v", d.typ, t.stats.URL, err)
+			incCounter("chain_webhook_deliveries_total", `result="failed"`)
+		} else {
+			incCounter("chain_webhook_deliveries_total", `result="ok"`)
+		}
+	}
+}
+
+// 재시도 포함 전송
+func (t *webhookTarget) deliver(d webhookDelivery) error {
+	var err error
+	for attempt := 0; attempt <= WebhookRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Second << (attempt - 1))
+		}
+		var retry bool
+		if retry, err = t.post(d); err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+// 1회 전송 (retry : 다시 보낼 만한 실패인지)
+func (t *webhookTarget) post(d webhookDelivery) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, t.url, bytes.NewReader(d.body))
+	if err != nil {
+		return false, err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", d.typ)
+	req.Header.Set("X-Webhook-Id", d.id)
+	req.Header.Set("X-Webhook-Timestamp", ts)
+	if len(webhookSecret) > 0 {
+		req.Header.Set("X-Webhook-Signature", "sha256="+webhookSignature(ts, d.body))
+	}
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return false, fmt.Errorf("status %d", resp.StatusCode)
+}
+
+// hex(HMAC-SHA256(secret, timestamp + "." + body))
+func webhookSignature(ts string, body []byte) string {
+	mac := hmac.New(sha256.New, webhookSecret)
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GET /admin/webhooks
+func handleWebhooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	out := []WebhookStatus{}
+	for _, t := range webhookTargets {
+		t.mu.Lock()
+		s := t.stats
+		t.mu.Unlock()
+		s.Queued = len(t.queue)
+		out = append(out, s)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"bytes"
 	"crypto/ecdsa"
-	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/asn1"
@@ -14,8 +12,11 @@ import (
 	"log"
 	"math/big"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
-	"time"
+
+	"merkle"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -26,32 +27,12 @@ import (
 // 그 해시들을 기반으로 Merkle Root를 계산하여 블록 헤더에 저장
 ////////////////////////////////////////////////////////////////////////////////
 
-// 개인키, 공개키 자동 생성 (최초 실행 시)
-func ensureKeyPair() {
-	if _, ok := getMeta("meta_hos_privkey"); ok {
-		return
-	}
-
-	priv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	privBytes, _ := x509.MarshalECPrivateKey(priv)
-	pubBytes, _ := x509.MarshalPKIXPublicKey(&priv.PublicKey)
-
-	privPem := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privBytes}))
-	pubPem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}))
-
-	putMeta("meta_hos_privkey", privPem)
-	putMeta("meta_hos_pubkey", pubPem)
-	log.Println("[ANCHOR][INIT] Generated private key : %s", privPem)
-	log.Println("[ANCHOR][INIT] Generated public key : %s", pubPem)
-	log.Println("[ANCHOR][INIT] Generated ECDSA key pair for Hos node")
-}
-
 // 공개키 조회 API (Gov가 요청할 때 사용)
 // GET /getPublicKey
 func getPublicKey(w http.ResponseWriter, r *http.Request) {
 	pubPem, ok := getMeta("meta_hos_pubkey")
 	if !ok {
-		http.Error(w, "public key not found", http.StatusNotFound)
+		writeError(w, http.StatusNotFound, "public key not found")
 		return
 	}
 	w.Header().Set("Content-Type", "application/x-pem-file")
@@ -80,132 +61,199 @@ func makeAnchorSignature(privPem, hashStr, _ string) string {
 }
 
 // Gov로 MerkleRoot 제출 (부트노드에서만 실행됨)
+//   - 큐에 먼저 기록한 뒤 재시도 루틴이 순서대로 전송 (anchor_queue.go)
 func submitAnchor(block LowerBlock) {
-	ensureKeyPair() // 키 없으면 생성
-	privPem, _ := getMeta("meta_hos_privkey")
+	if err := enqueueAnchor(block); err != nil {
+		log.Printf("[ANCHOR][ERROR] failed to queue anchor (root=%s): %v", block.MerkleRoot[:8], err)
+		return
+	}
+	kickAnchorQueue()
+}
+
+// 검색 응답 구조체
+type SearchResponse struct {
+	Record       ClinicRecord      `json:"record"`
+	BlockRoot    string            `json:"block_root"`
+	LatestRoot   string            `json:"latest_root"`
+	Leaf         string            `json:"leaf"`
+	Proof        [][2]string       `json:"proof"`
+	ProofVersion int               `json:"proof_version"`       // 증명 형식 버전 (merkle.ProofVersion)
+	Inclusion    Inclusion         `json:"inclusion"`           // 블록/엔트리 위치, 확인 수, 앵커 상태
+	Retention    string            `json:"retention,omitempty"` // 보존 기한 만료 시 archive | restrict
+	Revoked      *RevocationStatus `json:"revoked,omitempty"`   // 철회된 레코드면 툼스톤 정보 (revoke.go)
+	Decrypted    *PlainPHI         `json:"decrypted,omitempty"` // 복호화 조회 시 봉인 필드 평문 (phi.go)
+}
 
-	ts := time.Unix(time.Now().Unix(), 0).Format(time.RFC3339)
-	sig := makeAnchorSignature(privPem, block.MerkleRoot, ts)
+const (
+	SearchDefaultLimit = 50  // /search 기본 페이지 크기
+	SearchMaxLimit     = 500 // /search 최대 페이지 크기
+)
 
-	req := map[string]any{
-		"hos_id":   selfID(),
-		"hos_boot": self, // ex: "hos-boot:5000"
-		"root":     block.MerkleRoot,
-		"ts":       ts,
-		"sig":      sig,
+// 검색 페이지 파라미터 (offset, limit) 해석, 증명 형식 버전 협상 포함
+func searchPage(r *http.Request) (int, int, error) {
+	if err := checkProofVersion(r); err != nil {
+		return 0, 0, err
 	}
-
-	body, _ := json.Marshal(req)
-	govURL := "http://" + govBoot + "/addAnchor"
-	log.Printf("[ANCHOR] Anchor Sent to Gov BOOT : %s", govBoot)
-	resp, err := http.Post(govURL, "application/json", bytes.NewReader(body))
-	if err != nil {
-		log.Printf("[ANCHOR][ERROR] failed to submit anchor: %v", err)
-		return
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if offset < 0 {
+		return 0, 0, fmt.Errorf("invalid offset")
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusOK {
-		log.Printf("[ANCHOR][OK] Anchor submitted to Gov (root=%s)", block.MerkleRoot[:8])
-	} else {
-		log.Printf("[ANCHOR][WARN] Gov rejected anchor (status=%d)", resp.StatusCode)
+	if limit <= 0 {
+		limit = SearchDefaultLimit
 	}
+	return offset, min(limit, SearchMaxLimit), nil
 }
 
-// 검색 응답 구조체
-type SearchResponse struct {
-	Record     ClinicRecord `json:"record"`
-	BlockRoot  string       `json:"block_root"`
-	LatestRoot string       `json:"latest_root"`
-	Leaf       string       `json:"leaf"`
-	Proof      [][2]string  `json:"proof"`
+// ?proof_version= (요청 측이 읽을 수 있는 최고 버전) 협상, 없으면 현재 버전
+func checkProofVersion(r *http.Request) error {
+	raw := r.URL.Query().Get("proof_version")
+	if raw == "" {
+		return nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return fmt.Errorf("invalid proof_version")
+	}
+	_, err = merkle.NegotiateProofVersion(v)
+	return err
 }
 
 // 쿼리 수행 함수
-func searchClinic(keyword string) ([]SearchResponse, error) {
-	// 키워드를 가진 블록 찾기
-	blk, err := getBlockByClinicForQuery(keyword)
-	if err != nil {
-		return nil, err
+//   - 모든 블록에서 keyword 에 매칭되는 레코드를 (블록, 엔트리) 순서로 모아 offset/limit 구간만 반환
+//   - total 은 페이지와 무관한 전체 매칭 수
+//   - includeExpired : 보존 기한이 지나 만료 표시된 레코드도 포함 (retention.go)
+//   - 색인/블록/최신 루트·높이는 하나의 스냅샷에서 읽음 (snapshot.go)
+func searchClinic(keyword string, includeExpired bool, offset, limit int) ([]SearchResponse, int, error) {
+	var (
+		results []SearchResponse
+		total   int
+	)
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		results, total, err = searchClinicFrom(rd, keyword, includeExpired, offset, limit)
+		return err
+	})
+	return results, total, err
+}
+
+func searchClinicFrom(rd dbReader, keyword string, includeExpired bool, offset, limit int) ([]SearchResponse, int, error) {
+	// 키워드 색인에서 레코드 위치 목록 조회
+	ptrs := getPointersForQuery(rd, keyword)
+	if len(ptrs) == 0 {
+		return nil, 0, fmt.Errorf("no block found for keyword: %s", keyword)
 	}
+	return searchPointersFrom(rd, ptrs, func(rec ClinicRecord) bool { return matchesKeyword(rec, keyword) }, includeExpired, offset, limit)
+}
 
-	// 블록 내부에서 레코드 매칭
-	matches := findMatchesInBlock(blk, keyword)
+// 색인 포인터 목록 => 검색 응답 (/search, /search/fulltext 공통)
+//   - keep : 포인터가 가리키는 레코드의 추가 확인 (nil 이면 모두 채택)
+func searchPointersFrom(rd dbReader, ptrs []RecordPtr, keep func(ClinicRecord) bool, includeExpired bool, offset, limit int) ([]SearchResponse, int, error) {
+	// 위치별 레코드 매칭 (같은 블록은 한 번만 읽음)
+	blocks := map[int]*LowerBlock{}
+	matches := []Match{}
+	expired := 0
+	for _, p := range ptrs {
+		blk, ok := blocks[p.BlockIndex]
+		if !ok {
+			b, err := getBlockByIndexForPointer(rd, p.BlockIndex)
+			if err != nil {
+				return nil, 0, err
+			}
+			blk, blocks[p.BlockIndex] = b, b
+		}
+		if p.EntryIndex >= len(blk.Entries) || (keep != nil && !keep(blk.Entries[p.EntryIndex])) {
+			continue
+		}
+		mark := retentionMarkFrom(rd, blk.Index, p.EntryIndex)
+		if mark != "" && !includeExpired {
+			expired++
+			continue
+		}
+		matches = append(matches, Match{Record: blk.Entries[p.EntryIndex], Block: blk, EntryIndex: p.EntryIndex, Retention: mark})
+	}
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("no matching record")
+		if expired > 0 {
+			return nil, 0, fmt.Errorf("no matching record (expired records excluded)")
+		}
+		return nil, 0, fmt.Errorf("no matching record")
 	}
 
-	// 결과 구조 생성
-	results := make([]SearchResponse, 0, len(matches))
-	for _, m := range matches {
-		results = append(results, buildSearchResponse(m.Record, blk, m.EntryIndex))
+	// 결과 구조 생성 (요청 페이지 구간만)
+	total := len(matches)
+	results := []SearchResponse{}
+	for _, m := range matches[min(offset, total):min(offset+limit, total)] {
+		res := buildSearchResponse(rd, m.Record, m.Block, m.EntryIndex)
+		res.Retention = m.Retention
+		res.Revoked = revocationFrom(rd, res.Leaf)
+		results = append(results, res)
 	}
-
-	return results, nil
+	return results, total, nil
 }
 
 type Match struct {
 	Record     ClinicRecord
+	Block      *LowerBlock
 	EntryIndex int
+	Retention  string
 }
 
-// 블록 내부에서 keyword에 맞는 레코드를 찾음
-func findMatchesInBlock(blk *LowerBlock, keyword string) []Match {
-	matches := []Match{}
-
-	for ei, rec := range blk.Entries {
-		if rec.ClinicID == keyword {
-			matches = append(matches, Match{rec, ei})
-			continue
-		}
-		if cCode, ok := rec.Info["cCode"]; ok {
-			if strings.EqualFold(fmt.Sprint(cCode), keyword) {
-				matches = append(matches, Match{rec, ei})
-			}
-		}
+// 레코드가 keyword 에 맞는지 확인 (ClinicID 일치 또는 cCode 대소문자 무시 일치)
+func matchesKeyword(rec ClinicRecord, keyword string) bool {
+	if rec.ClinicID == keyword {
+		return true
 	}
-
-	return matches
+	if cCode, ok := rec.Info["cCode"]; ok {
+		return strings.EqualFold(fmt.Sprint(cCode), keyword)
+	}
+	return false
 }
 
-func buildSearchResponse(rec ClinicRecord, blk *LowerBlock, entryIndex int) SearchResponse {
+func buildSearchResponse(rd dbReader, rec ClinicRecord, blk *LowerBlock, entryIndex int) SearchResponse {
 
 	// 1) 찾은 블록에서 해당 엔트리에 대한 leaf hash 꺼냄
 	leaf := blk.LeafHashes[entryIndex]
 
 	// 2) 검색된 레코드가 속한 블록을 기준으로 Merkle Proof 생성
-	proof := merkleProof(blk.LeafHashes, entryIndex)
+	proof := merkle.Proof(blk.LeafHashes, entryIndex)
 
 	// 3) 최종 결과 패키징
 	return SearchResponse{
-		Record:     rec,
-		BlockRoot:  blk.MerkleRoot,  // 레코드가 존재하는 블록 루트 (블록 유효성 검증)
-		LatestRoot: getLatestRoot(), // 현재 노드의 최신 블록 루트 (체인 유효성 검증)
-		Leaf:       leaf,
-		Proof:      proof,
+		Record:       rec,
+		BlockRoot:    blk.MerkleRoot,        // 레코드가 존재하는 블록 루트 (블록 유효성 검증)
+		LatestRoot:   getLatestRootFrom(rd), // 현재 노드의 최신 블록 루트 (체인 유효성 검증)
+		Leaf:         leaf,
+		Proof:        proof,
+		ProofVersion: merkle.ProofVersion,
+		Inclusion:    buildInclusion(rd, blk, entryIndex),
 	}
 }
 
-// 키워드로 블록 조회
-//   - keyword가 Info(cCode 등)에 매칭되면
-//     해당 포인터("bi:ei")를 통해 블록을 찾아 반환
-//   - 여러 매칭이 가능할 수 있으나, 여기서는 최초 매칭 1개만 반환
-func getBlockByClinicForQuery(keyword string) (*LowerBlock, error) {
-
-	// Info(cCode 등) 색인 조회 (소문자 normalize)
-	if v, err := db.Get([]byte("info_cCode_"+strings.ToLower(keyword)), nil); err == nil {
-		if bi, _, ok := parsePtr(string(v)); ok {
-			return getBlockByIndexForPointer(bi)
+// 키워드로 레코드 위치 목록 조회
+//   - Info(cCode, 소문자 normalize) 색인과 ClinicID 색인의 포인터 목록을 합침
+//   - (블록, 엔트리) 순으로 정렬, 중복 제거
+func getPointersForQuery(rd dbReader, keyword string) []RecordPtr {
+	seen := map[RecordPtr]bool{}
+	out := []RecordPtr{}
+	for _, key := range []string{"info_cCode_" + strings.ToLower(keyword), "cid_" + keyword} {
+		for _, p := range indexPointers(rd, key) {
+			if !seen[p] {
+				seen[p] = true
+				out = append(out, p)
+			}
 		}
 	}
-
-	return nil, fmt.Errorf("no block found for keyword: %s", keyword)
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].BlockIndex != out[b].BlockIndex {
+			return out[a].BlockIndex < out[b].BlockIndex
+		}
+		return out[a].EntryIndex < out[b].EntryIndex
+	})
+	return out
 }
 
-func getBlockByIndexForPointer(index int) (*LowerBlock, error) {
-	key := fmt.Sprintf("block_%d", index)
-
-	data, err := db.Get([]byte(key), nil)
+func getBlockByIndexForPointer(rd dbReader, index int) (*LowerBlock, error) {
+	data, err := rd.Get(blockKey(index), nil)
 	if err != nil {
 		return nil, fmt.Errorf("block_%d not found: %w", index, err)
 	}
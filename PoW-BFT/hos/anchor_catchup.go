@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Anchor Catch-up (Gov 가 누락을 감지한 블록의 앵커 재전송)
+// ------------------------------------------------------------
+// - 블록 확정 직후 부트노드가 종료되면 앵커가 큐에 기록되기 전에 유실될 수 있음
+// - Gov 부트노드가 주기적으로 /headers 의 merkle_root 를 자신의 앵커 기록과 대조하고
+//   기록되지 않은 블록 번호를 POST /anchor/resend 로 요청 (Gov anchor_reconcile.go)
+// - Hos 부트노드는 요청된 블록을 읽어 앵커 큐에 다시 기록 (이미 큐에 있는 루트는 건너뜀)
+//   · 서명/전송은 기존 앵커 큐가 담당 (anchor_queue.go)
+//   · 부트노드만 앵커를 제출하므로 다른 노드는 409
+////////////////////////////////////////////////////////////////////////////////
+
+const MaxAnchorResend = 100 // 요청 1건당 최대 블록 수
+
+type anchorResendRequest struct {
+	BlockIndices []int `json:"block_indices"`
+}
+
+// root 앵커가 이미 큐에서 전송 대기 중인지
+func anchorQueued(root string) bool {
+	anchorQueueMu.Lock()
+	defer anchorQueueMu.Unlock()
+	iter := db.NewIterator(util.BytesPrefix([]byte(anchorQueuePrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var item QueuedAnchor
+		if err := json.Unmarshal(iter.Value(), &item); err == nil && item.Root == root {
+			return true
+		}
+	}
+	return false
+}
+
+// 블록 앵커를 다시 큐에 기록 (큐에 기록했으면 true)
+func resendAnchor(index int) (bool, error) {
+	blk, err := getBlockByIndex(index)
+	if err != nil {
+		return false, fmt.Errorf("block #%d not found", index)
+	}
+	if blk.Index == 0 || blk.MerkleRoot == "" || anchorQueued(blk.MerkleRoot) {
+		return false, nil
+	}
+	if err := enqueueAnchor(blk); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// POST /anchor/resend {"block_indices":[...]}
+func handleAnchorResend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if self != boot {
+		writeError(w, http.StatusConflict, "only the boot node submits anchors (boot="+boot+")")
+		return
+	}
+	var req anchorResendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	if len(req.BlockIndices) == 0 || len(req.BlockIndices) > MaxAnchorResend {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("block_indices must have 1..%d entries", MaxAnchorResend))
+		return
+	}
+
+	queued, skipped := []int{}, []int{}
+	for _, idx := range req.BlockIndices {
+		ok, err := resendAnchor(idx)
+		if err != nil {
+			log.Printf("[ANCHOR][CATCHUP][WARN] block #%d: %v", idx, err)
+		}
+		if ok {
+			queued = append(queued, idx)
+		} else {
+			skipped = append(skipped, idx)
+		}
+	}
+	if len(queued) > 0 {
+		log.Printf("[ANCHOR][CATCHUP] re-queued %d anchors requested by Gov: %v", len(queued), queued)
+		kickAnchorQueue()
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"queued": queued, "skipped": skipped})
+}
@@ -3,6 +3,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -18,13 +19,22 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 }
 
 // main.go에서 mux와 LowerChain을 넘겨받아 API 핸들 등록
-func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
+func RegisterAPI(mux *routeMux, chain *LowerChain) {
 
-	// 최신 머클루트 (Gov 앵커용)
-	// GET /block/root
+	// 최신 머클루트 (Gov 앵커용), value 지정 시 해당 루트의 블록 (Gov 앵커 정합성 점검용)
+	// GET /block/root[?value=<merkle_root>]
 	mux.HandleFunc("/block/root", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		if v := r.URL.Query().Get("value"); v != "" {
+			blk, err := getBlockByRoot(v)
+			if err != nil {
+				writeError(w, http.StatusNotFound, "block not found")
+				return
+			}
+			writeJSON(w, http.StatusOK, blk)
 			return
 		}
 		root := getLatestRoot() // storage의 getLatestRoot 사용
@@ -35,94 +45,149 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /block/index?id=<int>
 	mux.HandleFunc("/block/index", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		q := r.URL.Query().Get("id")
 		if q == "" {
-			http.Error(w, "id parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id parameter required")
 			return
 		}
 		idx, err := strconv.Atoi(q)
 		if err != nil {
-			http.Error(w, "id must be integer", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id must be integer")
 			return
 		}
 		blk, err := getBlockByIndex(idx)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
 		writeJSON(w, http.StatusOK, blk)
 	})
 
+	// 최신 블록 조회
+	// GET /block/latest
+	mux.HandleFunc("/block/latest", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		blocks, err := listRecentBlocks(1)
+		if err != nil || len(blocks) == 0 {
+			writeError(w, http.StatusNotFound, "block not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, blocks[0])
+	})
+
 	// 블록 조회: 해시
 	// GET /block/hash?value=<hash>
 	mux.HandleFunc("/block/hash", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		hash := r.URL.Query().Get("value")
 		if hash == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
 			return
 		}
 		blk, err := getBlockByHash(hash)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
 		writeJSON(w, http.StatusOK, blk)
 	})
 
 	// 키워드로 레코드 검색
-	// GET /search?value=<keyword>
+	// GET /search?value=<keyword>[&include_expired=true][&fields=<field,...>][&offset=<int>&limit=<int>]
+	//  - 모든 블록의 매칭 레코드를 반환 (응답 본문은 배열, 전체 매칭 수는 X-Total-Count 헤더)
+	//  - fields 지정 시 레코드 본문 대신 해당 필드만 필드 증명과 함께 공개 (disclosure.go)
 	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 
 		kw := r.URL.Query().Get("value")
 		if kw == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
+			return
+		}
+		offset, limit, err := searchPage(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 		logInfo("search query keyword: %s", kw)
 		// 검색 수행
-		results, err := searchClinic(kw)
+		results, total, err := searchClinic(kw, r.URL.Query().Get("include_expired") == "true", offset, limit)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			writeError(w, http.StatusNotFound, err.Error())
 			return
 		}
-		logInfo("query response's length: %s", len(results))
+		logInfo("query response's length: %d (total %d)", len(results), total)
 		// 결과 반환
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		if fields := disclosureFields(r.URL.Query().Get("fields")); len(fields) > 0 {
+			writeJSON(w, http.StatusOK, disclosedResults(results, fields))
+			return
+		}
 		writeJSON(w, http.StatusOK, results)
 	})
 
-	// 전체 장부 조회 (페이지네이션)
+	// Info(title, description, category) 토큰 접두어 검색 (fulltext.go)
+	// GET /search/fulltext?q=<words>[&include_expired=true][&offset=<int>&limit=<int>]
+	mux.HandleFunc("/search/fulltext", handleFulltextSearch)
+
+	// 포함 증명 조회 (레코드 본문 없이, 정리된 블록 포함)
+	// GET /proof?block=<int>&entry=<int>
+	mux.HandleFunc("/proof", handleProof)
+
+	// 전체 장부 조회 (페이지네이션, gzip/ETag 지원 : blocktransfer.go)
 	// GET /blocks?offset=<int>&limit=<int>
-	mux.HandleFunc("/blocks", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/blocks", handleBlocks)
+
+	// 최신 블록 N개 조회 (대시보드용, 최신순)
+	// GET /blocks/recent?count=<int>&full=<bool>
+	//  - 기본은 헤더만 반환, full=true 이면 전체 블록 반환
+	mux.HandleFunc("/blocks/recent", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
-		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-		if limit <= 0 {
-			limit = 50
+		count := 10
+		if q := r.URL.Query().Get("count"); q != "" {
+			n, err := strconv.Atoi(q)
+			if err != nil || n <= 0 {
+				writeError(w, http.StatusBadRequest, "count must be positive integer")
+				return
+			}
+			count = min(n, MaxRecentBlocks)
 		}
-		blocks, total, err := listBlocksPaginated(offset, limit)
+		blocks, err := listRecentBlocks(count)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("list blocks error: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("list blocks error: %v", err))
 			return
 		}
+		var items any = blocks
+		if r.URL.Query().Get("full") != "true" {
+			headers := make([]LowerBlockHeader, 0, len(blocks))
+			for _, b := range blocks {
+				headers = append(headers, b.header())
+			}
+			items = headers
+		}
+		height := -1
+		if len(blocks) > 0 {
+			height = blocks[0].Index
+		}
 		writeJSON(w, http.StatusOK, map[string]any{
-			"total":  total,
-			"offset": offset,
-			"limit":  limit,
-			"items":  blocks,
+			"height": height,
+			"count":  len(blocks),
+			"items":  items,
 		})
 	})
 
@@ -143,21 +208,41 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 		writeJSON(w, http.StatusOK, map[string]any{
 			"hos_id":     ch.hosID,
 			"addr":       self,
+			"addrs":      advertisedAddrs(),
 			"height":     height,
-			"proposer":   proposer,
+			"proposer":   leaderFor(height+1, 0),
 			"is_boot":    isBoot.Load(),
 			"bootAddr":   boot,
 			"started_at": startedAt.Format(time.RFC3339),
 			"peers":      peersSnapshot(),
 			"gov_boot":   getGovBoot(),
+			"gov_peers":  govPeers(),
 			"last_hash":  lastHash,
 			"batch_size": ConsensusBatchSize,
+			"region":     region,
+			"cert_pin":   selfCertPin,
+			"pruned_to":  getPruneHeight(),
+			"time":       time.Now().UTC().Format(time.RFC3339Nano),
 		})
 	})
 
+	// 리전 내/간 트래픽 통계
+	// GET /traffic
+	mux.HandleFunc("/traffic", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		writeJSON(w, http.StatusOK, trafficSnapshot())
+	})
+
 	// 현재 노드가 알고 있는 피어 리스트 반환
-	// GET /peers
+	// GET /peers[?detail=true] : detail 이면 피어별 생존 여부와 회로 차단 상태 (peerclient.go)
 	mux.HandleFunc("/peers", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("detail") == "true" {
+			writeJSON(w, http.StatusOK, peerDetails())
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
 
 		// 주소 리스트만 보내는 대신, 주소:공개키 맵을 보냄
@@ -169,17 +254,121 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
 		var rec []ClinicRecord
 		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
-			http.Error(w, "invalid Clinic record", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "invalid Clinic record")
 			return
 		}
 		defer r.Body.Close()
+		for _, e := range rec {
+			if e.Revocation != nil { // 툼스톤은 대상 확인을 거치는 /revoke 로만 접수
+				writeError(w, http.StatusBadRequest, "revocation entries must be submitted via /revoke")
+				return
+			}
+			if e.Validator != nil { // 검증자 변경은 노드 등록/POST /validators 로만 접수
+				writeError(w, http.StatusBadRequest, "validator changes must be submitted via /validators")
+				return
+			}
+			if e.Evidence != nil { // 증거는 합의 중 탐지한 노드만 접수
+				writeError(w, http.StatusBadRequest, "evidence entries are submitted by validators only")
+				return
+			}
+		}
 
-		appendPending(rec) // 데이터 저장
+		count, rejected, status, err := submitRecords(rec)
+		if full := (*PendingFullError)(nil); errors.As(err, &full) {
+			writePendingFull(w, full)
+			return
+		}
+		if err != nil {
+			writeError(w, status, err.Error())
+			return
+		}
+		if status == http.StatusConflict {
+			writeErrorDetail(w, http.StatusConflict, "all_rejected", "All entries rejected", map[string]any{
+				"count":    0,
+				"rejected": rejected,
+			})
+			return
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{
-			"status": "Uploading Request Submitted",
-			"count":  len(rec),
+			"status":   "Uploading Request Submitted",
+			"count":    count,
+			"rejected": rejected,
 		})
 	})
+
+	// 아직 블록에 확정되지 않은 메모리풀 레코드 조회 (LevelDB 기준)
+	// GET /pending
+	mux.HandleFunc("/pending", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		items, err := loadPendingFromDB()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("load pending error: %v", err))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"count":     len(items),
+			"in_memory": getPendingCnt(),
+			"items":     items,
+		})
+	})
+}
+
+// 레코드 접수 (/upload, gRPC SubmitRecords 공통)
+//   - 반환 : 접수 수, 거부 목록, HTTP 상태 (모두 거부면 409), 오류
+func submitRecords(rec []ClinicRecord) (int, []ReplayRejection, int, error) {
+	if isObserver() {
+		return 0, nil, http.StatusForbidden, errObserverReadOnly
+	}
+	if err := checkRecordPriority(rec); err != nil {
+		return 0, nil, http.StatusBadRequest, err
+	}
+	if err := checkRecordSize(rec); err != nil {
+		return 0, nil, http.StatusRequestEntityTooLarge, err
+	}
+	// 진료 정보 필드 암호화 (PHI_KEY 설정 시, phi.go) - 이후 해시/중복 검사는 봉인된 레코드 기준
+	rec, err := sealRecords(rec)
+	if err != nil {
+		log.Printf("[PHI][ERROR] %v", err)
+		return 0, nil, http.StatusInternalServerError, fmt.Errorf("failed to encrypt entries")
+	}
+	// 필드별 머클 leaf 용 salt 부여 (봉인 후 레코드 기준, disclosure.go)
+	rec = saltRecords(rec)
+
+	// 이미 접수/확정된 레코드, 재전송 창을 벗어난 레코드 제외 (dedup.go)
+	fresh, rejected := filterReplays(rec)
+	if len(rejected) > 0 {
+		log.Printf("[DEDUP] upload rejected %d of %d entries", len(rejected), len(rec))
+	}
+	if len(fresh) == 0 && len(rec) > 0 {
+		return 0, rejected, http.StatusConflict, nil
+	}
+
+	// 상주 리전이 지정된 레코드는 같은 리전 노드에서만 접수하여 리전 서브 장부로 분리
+	open, resident, err := splitByResidency(fresh)
+	if err != nil {
+		return 0, rejected, http.StatusForbidden, err
+	}
+	if len(resident) > 0 {
+		if err := submitResidentRecords(resident); err != nil {
+			log.Printf("[RESIDENCY][ERROR] %v", err)
+			return 0, rejected, http.StatusServiceUnavailable, fmt.Errorf("failed to submit region-resident entries")
+		}
+	}
+
+	// 데이터 저장 (LevelDB 선기록 실패 시 접수하지 않음) 후 다른 노드 메모리풀로 중계 (proposer.go)
+	if len(open) > 0 {
+		if err := appendPending(open); err != nil {
+			if full := (*PendingFullError)(nil); errors.As(err, &full) {
+				return 0, rejected, http.StatusTooManyRequests, full
+			}
+			return 0, rejected, http.StatusInternalServerError, fmt.Errorf("failed to persist pending entries")
+		}
+		relayPending(open)
+	}
+	return len(fresh), rejected, http.StatusOK, nil
 }
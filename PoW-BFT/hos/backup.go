@@ -0,0 +1,386 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Hot Backup / Restore (노드를 멈추지 않는 LevelDB 상태 백업/복원)
+// ------------------------------------------------------------
+// - POST /admin/backup : LevelDB 스냅샷 하나의 전체 키를 tar.gz 로 내려받음
+//   · 스냅샷은 블록 경계에서 찍히므로(snapshot.go) 백업 중 블록 반영을 막지 않고도 일관된 상태
+//   · 구성 : manifest.json (chain_id, 제네시스/최신 블록 해시, 높이, 키 수, state.kv 의 SHA-256)
+//            state.kv      (uvarint 키 길이 + 키 + uvarint 값 길이 + 값 반복)
+//   · 노드 서명 키(meta_hos_*)도 포함되므로 백업 파일은 비밀 정보로 보관
+// - POST /admin/restore : 본문으로 받은 백업을 로컬 DB 에 설치
+//   · 설치 전 : state.kv 다이제스트, 백업 안의 높이/최신 블록 해시/제네시스 해시가 매니페스트와 일치하는지,
+//               최신 블록이 이전 블록에 연결되는지, 로컬 체인과 chain_id 가 같은지 확인
+//   · 설치 : 기존 키 삭제 + 백업 키 기록을 하나의 Batch 로 반영 (이 노드의 서명 키는 로컬 값 유지)
+//   · 설치 후 : DB 의 높이/최신 블록 해시를 매니페스트와 다시 대조, 메모리풀/검증자 캐시 재적재
+//   · 합의 진행 중에는 409 (라운드가 끝난 뒤 다시 요청)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	BackupFormatVersion = 1
+	backupManifestName  = "manifest.json"
+	backupStateName     = "state.kv"
+)
+
+// 복원 시 로컬 값을 유지하는 키 (노드 신원)
+var backupLocalKeys = []string{privKeyMetaKey, privKeyEncMetaKey, "meta_hos_pubkey"}
+
+// 백업 매니페스트
+type BackupManifest struct {
+	Version     int    `json:"version"`
+	ChainID     string `json:"chain_id"`
+	GenesisHash string `json:"genesis_hash"`
+	Height      int    `json:"height"`
+	BlockHash   string `json:"block_hash"`
+	Keys        int    `json:"keys"`
+	StateSHA256 string `json:"state_sha256"`
+	Node        string `json:"node"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// POST /admin/backup
+func handleAdminBackup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	tmp, err := os.CreateTemp("", "hos-backup-*.kv")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("backup temp file: %v", err))
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	var m BackupManifest
+	err = withReadSnapshot(func(rd dbReader) error {
+		var err error
+		m, err = dumpState(rd, tmp)
+		return err
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("backup error: %v", err))
+		return
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("backup temp file: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%d.tar.gz"`, m.ChainID, m.Height))
+	if err := writeBackupArchive(w, m, tmp, size); err != nil {
+		log.Printf("[BACKUP][ERROR] archive stream aborted: %v", err)
+		return
+	}
+	log.Printf("[BACKUP] snapshot #%d exported (%d keys, %d bytes)", m.Height, m.Keys, size)
+}
+
+// 스냅샷의 전체 키를 out 에 기록하고 매니페스트 작성
+func dumpState(rd dbReader, out io.Writer) (BackupManifest, error) {
+	m := BackupManifest{Version: BackupFormatVersion, Node: self, CreatedAt: time.Now().UTC().Format(time.RFC3339)}
+	h, ok := getLatestHeightFrom(rd)
+	if !ok {
+		return m, fmt.Errorf("no chain")
+	}
+	genesis, err := getBlockByIndexFrom(rd, 0)
+	if err != nil {
+		return m, fmt.Errorf("load genesis: %w", err)
+	}
+	tip, err := getBlockByIndexFrom(rd, h)
+	if err != nil {
+		return m, fmt.Errorf("load block #%d: %w", h, err)
+	}
+	m.ChainID, m.GenesisHash, m.Height, m.BlockHash = genesis.HosID, genesis.BlockHash, h, tip.BlockHash
+
+	sum := sha256.New()
+	bw := bufio.NewWriter(io.MultiWriter(out, sum))
+	iter := rd.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		if err := writeKV(bw, iter.Key(), iter.Value()); err != nil {
+			return m, err
+		}
+		m.Keys++
+	}
+	if err := iter.Error(); err != nil {
+		return m, countDBError(err)
+	}
+	if err := bw.Flush(); err != nil {
+		return m, err
+	}
+	m.StateSHA256 = hex.EncodeToString(sum.Sum(nil))
+	return m, nil
+}
+
+// 매니페스트 + state.kv 를 tar.gz 로 기록
+func writeBackupArchive(w io.Writer, m BackupManifest, state io.Reader, size int64) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	manifest, _ := json.MarshalIndent(m, "", "  ")
+	now := time.Now()
+	if err := tw.WriteHeader(&tar.Header{Name: backupManifestName, Mode: 0600, Size: int64(len(manifest)), ModTime: now}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: backupStateName, Mode: 0600, Size: size, ModTime: now}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, state); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeKV(w io.Writer, key, val []byte) error {
+	buf := binary.AppendUvarint(nil, uint64(len(key)))
+	buf = append(buf, key...)
+	buf = binary.AppendUvarint(buf, uint64(len(val)))
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	_, err := w.Write(val)
+	return err
+}
+
+func readKV(r *bufio.Reader) (key, val []byte, err error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, nil, err // 정상 종료면 io.EOF
+	}
+	key = make([]byte, n)
+	if _, err := io.ReadFull(r, key); err != nil {
+		return nil, nil, err
+	}
+	if n, err = binary.ReadUvarint(r); err != nil {
+		return nil, nil, err
+	}
+	val = make([]byte, n)
+	if _, err := io.ReadFull(r, val); err != nil {
+		return nil, nil, err
+	}
+	return key, val, nil
+}
+
+// POST /admin/restore (본문 : /admin/backup 이 만든 tar.gz)
+func handleAdminRestore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if consensusInProgress.Load() {
+		writeError(w, http.StatusConflict, "consensus in progress")
+		return
+	}
+	m, staged, err := readBackupArchive(r.Body)
+	if err != nil {
+		writeErrorDetail(w, http.StatusBadRequest, "invalid_backup", err.Error(), nil)
+		return
+	}
+	defer staged.Close()
+	if err := verifyBackupState(m, staged); err != nil {
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "backup_mismatch", err.Error(), m)
+		return
+	}
+	if local, err := getBlockByIndex(0); err == nil && local.HosID != m.ChainID {
+		writeErrorDetail(w, http.StatusConflict, "chain_mismatch", "backup is from a different chain",
+			map[string]string{"chain_id": m.ChainID, "expected": local.HosID})
+		return
+	}
+	if err := installBackup(staged); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("restore error: %v", err))
+		return
+	}
+
+	// 설치 후 장부 상태 재확인
+	h, _ := getLatestHeight()
+	tip, err := getBlockByIndex(h)
+	if err != nil || h != m.Height || tip.BlockHash != m.BlockHash {
+		log.Printf("[BACKUP][ERROR] restored state mismatch: height=%d hash=%.12s (want #%d %.12s)", h, tip.BlockHash, m.Height, m.BlockHash)
+		writeErrorDetail(w, http.StatusInternalServerError, "restore_verify_failed", "restored ledger does not match backup manifest", m)
+		return
+	}
+	reloadRestoredState()
+	log.Printf("[BACKUP] restored snapshot #%d (%.12s, %d keys) taken by %s at %s", m.Height, m.BlockHash, m.Keys, m.Node, m.CreatedAt)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"status":     "restored",
+		"height":     h,
+		"block_hash": tip.BlockHash,
+		"keys":       m.Keys,
+	})
+}
+
+// tar.gz 를 읽어 매니페스트와 메모리 DB 에 올린 상태 반환 (state.kv 다이제스트 확인)
+func readBackupArchive(body io.Reader) (BackupManifest, *leveldb.DB, error) {
+	var m BackupManifest
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		return m, nil, fmt.Errorf("not a gzip archive: %w", err)
+	}
+	staged, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return m, nil, err
+	}
+	digest, keys := "", 0
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			staged.Close()
+			return m, nil, err
+		}
+		switch hdr.Name {
+		case backupManifestName:
+			if err := json.NewDecoder(tr).Decode(&m); err != nil {
+				staged.Close()
+				return m, nil, fmt.Errorf("manifest: %w", err)
+			}
+		case backupStateName:
+			if digest, keys, err = stageState(tr, staged); err != nil {
+				staged.Close()
+				return m, nil, fmt.Errorf("state: %w", err)
+			}
+		}
+	}
+	switch {
+	case m.Version != BackupFormatVersion:
+		err = fmt.Errorf("unsupported backup version %d", m.Version)
+	case digest == "":
+		err = fmt.Errorf("missing %s", backupStateName)
+	case digest != m.StateSHA256 || keys != m.Keys:
+		err = fmt.Errorf("state digest mismatch (%d keys, sha256 %.12s)", keys, digest)
+	}
+	if err != nil {
+		staged.Close()
+		return m, nil, err
+	}
+	return m, staged, nil
+}
+
+// state.kv 를 메모리 DB 에 적재 (다이제스트, 키 수 반환)
+func stageState(r io.Reader, staged *leveldb.DB) (string, int, error) {
+	sum := sha256.New()
+	br := bufio.NewReader(io.TeeReader(r, sum))
+	keys := 0
+	batch := new(leveldb.Batch)
+	for {
+		k, v, err := readKV(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", 0, err
+		}
+		batch.Put(k, v)
+		keys++
+	}
+	if err := staged.Write(batch, nil); err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(sum.Sum(nil)), keys, nil
+}
+
+// 백업 안의 장부가 매니페스트와 맞는지 (높이, 최신/제네시스 해시, 최신 블록 연결)
+func verifyBackupState(m BackupManifest, staged *leveldb.DB) error {
+	h, ok := getLatestHeightFrom(staged)
+	if !ok || h != m.Height {
+		return fmt.Errorf("height %d != manifest %d", h, m.Height)
+	}
+	genesis, err := getBlockByIndexFrom(staged, 0)
+	if err != nil || genesis.BlockHash != m.GenesisHash || genesis.HosID != m.ChainID {
+		return fmt.Errorf("genesis does not match manifest")
+	}
+	tip, err := getBlockByIndexFrom(staged, h)
+	if err != nil || tip.BlockHash != m.BlockHash {
+		return fmt.Errorf("block #%d hash does not match manifest", h)
+	}
+	if h > 0 {
+		if prev, err := getBlockByIndexFrom(staged, h-1); err == nil && tip.PrevHash != prev.BlockHash {
+			return fmt.Errorf("block #%d does not link to #%d", h, h-1)
+		}
+	}
+	return nil
+}
+
+// 기존 키 삭제 + 백업 키 기록을 하나의 Batch 로 반영 (노드 신원 키는 로컬 값 유지)
+func installBackup(staged *leveldb.DB) error {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	batch := new(leveldb.Batch)
+	iter := db.NewIterator(nil, nil)
+	for iter.Next() {
+		batch.Delete(append([]byte(nil), iter.Key()...))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return countDBError(err)
+	}
+	src := staged.NewIterator(nil, nil)
+	for src.Next() {
+		batch.Put(append([]byte(nil), src.Key()...), append([]byte(nil), src.Value()...))
+	}
+	src.Release()
+	if err := src.Error(); err != nil {
+		return err
+	}
+	for _, k := range backupLocalKeys {
+		if v, ok := getMeta(k); ok {
+			batch.Put([]byte(k), []byte(v))
+		} else {
+			batch.Delete([]byte(k))
+		}
+	}
+	return countDBError(db.Write(batch, nil))
+}
+
+// 복원한 DB 기준으로 메모리 상태 재적재
+func reloadRestoredState() {
+	restored, err := loadPendingFromDB()
+	if err != nil {
+		log.Printf("[BACKUP][ERROR] reload pending: %v", err)
+	}
+	ch.pendingMu.Lock()
+	ch.pending = restored
+	ch.pendingBytes = pendingSize(restored)
+	ch.pendingMu.Unlock()
+
+	regionPendingMu.Lock()
+	regionPending = nil
+	regionPendingMu.Unlock()
+	loadRegionPending()
+
+	invalidateValidatorCache()
+	ch.lastBlockTime = time.Now()
+}
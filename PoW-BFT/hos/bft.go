@@ -1,11 +1,11 @@
 package main
 
 import (
-	"bytes"
-	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +13,9 @@ import (
 
 var consensusInProgress atomic.Bool
 
+// /admin/finalize 요청 : 다음 watcher 주기에 배치/대기시간 조건과 무관하게 합의 시작
+var forceConsensus atomic.Bool
+
 const (
 	PhaseIdle int32 = iota
 	PhasePrePrepare
@@ -21,24 +24,35 @@ const (
 	PhaseFinal
 	ConsensusBatchSize = 200
 	ConsensusTimeout   = 10
+	ViewChangeTimeout  = 15 // 라운드별 합의 제한시간(초), 라운드마다 2배씩 증가
 )
 
+// view 를 연 뒤 이 시간(초) 안에 확정되지 않으면 합의 포기 (0 이면 포기하지 않음)
+//   - 네트워크 분할 등으로 view-change 를 거듭해도 정족수가 모이지 않는 경우
+//   - view 상태 삭제, 제안 레코드 메모리풀 복구, 합의 진행 상태 해제 => 다음 watcher 주기에 새로 제안
+var ViewAbandonTimeout = 300
+
 type voteCollector struct {
-	mu    sync.Mutex
-	votes map[string]string
+	mu     sync.Mutex
+	votes  map[string]string
+	keyFPs map[string]string // 주소 => 서명을 검증한 공개키 지문
 }
 
 func newCollector() *voteCollector {
-	return &voteCollector{votes: make(map[string]string)}
+	return &voteCollector{votes: make(map[string]string), keyFPs: make(map[string]string)}
 }
 
-func (c *voteCollector) add(addr, sig string) bool {
+// pub : 서명을 검증한 공개키 (블록 서명 지문 기록용, view-change 처럼 필요 없으면 "")
+func (c *voteCollector) add(addr, sig, pub string) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if _, exists := c.votes[addr]; exists {
 		return false
 	}
 	c.votes[addr] = sig
+	if pub != "" {
+		c.keyFPs[addr] = pubKeyFingerprint(pub)
+	}
 	return true
 }
 
@@ -48,23 +62,32 @@ func (c *voteCollector) count() int {
 	return len(c.votes)
 }
 
-func (c *voteCollector) all() []string {
+// 수집된 서명을 서명 노드 주소 순으로 반환 (지문은 투표를 검증한 키 기준)
+func (c *voteCollector) signatures() []ConsensusSig {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	sigs := make([]string, 0, len(c.votes))
-	for _, s := range c.votes {
-		sigs = append(sigs, s)
+	sigs := make([]ConsensusSig, 0, len(c.votes))
+	for addr, sig := range c.votes {
+		sigs = append(sigs, ConsensusSig{Addr: addr, KeyFP: c.keyFPs[addr], Sig: sig})
 	}
+	sort.Slice(sigs, func(i, j int) bool { return sigs[i].Addr < sigs[j].Addr })
 	return sigs
 }
 
+// view : 합의 대상 블록 높이(height+1)
+// Round : 동일 view 내에서 리더 교체 횟수 (0 = view 의 기본 제안자가 리더, proposer.go)
 type viewState struct {
-	mu        sync.Mutex
-	Phase     int32
-	Block     LowerBlock
-	Prepare   *voteCollector
-	Commit    *voteCollector
-	Finalized bool
+	mu         sync.Mutex
+	Phase      int32
+	Round      int
+	Block      LowerBlock
+	Prepare    *voteCollector
+	Commit     *voteCollector
+	Finalized  bool
+	OpenedAt   time.Time              // view 생성 시각 (포기 기준)
+	StartedAt  time.Time              // 현재 라운드 시작 시각 (타임아웃 기준)
+	VotedRound int                    // 이 노드가 view-change 투표한 가장 높은 라운드
+	ViewChange map[int]*voteCollector // 라운드별 view-change 투표
 }
 
 var (
@@ -77,7 +100,7 @@ func getOrCreateView(view int) *viewState {
 	defer viewMu.Unlock()
 	vs, ok := viewStates[view]
 	if !ok {
-		vs = &viewState{Phase: PhaseIdle, Prepare: newCollector(), Commit: newCollector()}
+		vs = &viewState{Phase: PhaseIdle, Prepare: newCollector(), Commit: newCollector(), ViewChange: make(map[int]*voteCollector), OpenedAt: time.Now()}
 		viewStates[view] = vs
 	}
 	return vs
@@ -85,14 +108,140 @@ func getOrCreateView(view int) *viewState {
 
 func deleteView(view int) {
 	viewMu.Lock()
-	defer viewMu.Unlock()
 	delete(viewStates, view)
+	viewMu.Unlock()
+	clearVoteLog(view)
+	releaseProposal(view)
+}
+
+// 이 노드가 서명한 투표 (view => "round|단계" => 블록 해시)
+//   - view 포기(deleteView)로 상태가 지워져도 유지 => 같은 round/단계에서 다른 블록에 다시 서명하지 않음
+//     (포기 후 round 0 재제안에 서명하면 이중 서명 증거 대상이 되므로 view-change 로 다음 라운드에서 합류)
+//   - 확정된 높이 이하는 정리
+var (
+	ownVotes   = make(map[int]map[string]string)
+	ownVotesMu sync.Mutex
+)
+
+// 서명 전 확인 : 같은 view/round/단계에서 다른 블록에 서명한 적이 없으면 기록 후 true
+func reserveVote(view, round int, phase, hash string) bool {
+	height, _ := getLatestHeight()
+	ownVotesMu.Lock()
+	defer ownVotesMu.Unlock()
+	for v := range ownVotes {
+		if v <= height {
+			delete(ownVotes, v)
+		}
+	}
+	votes, ok := ownVotes[view]
+	if !ok {
+		votes = make(map[string]string)
+		ownVotes[view] = votes
+	}
+	key := fmt.Sprintf("%d|%s", round, phase)
+	if prev, seen := votes[key]; seen && prev != hash {
+		return false
+	}
+	votes[key] = hash
+	return true
+}
+
+// 이 노드가 메모리풀에서 꺼내 제안한 레코드 (view => 레코드)
+//   - 합의 진행 상태(consensusInProgress)는 남은 제안이 있는 동안만 유지
+//   - view 가 정리될 때 확정되지 않은 레코드는 메모리풀로 복구 (확정된 레코드는 restorePending 이 제외)
+var (
+	ownProposals   = make(map[int][]ClinicRecord)
+	ownProposalsMu sync.Mutex
+)
+
+func trackProposal(view int, records []ClinicRecord) {
+	ownProposalsMu.Lock()
+	defer ownProposalsMu.Unlock()
+	ownProposals[view] = records
+	consensusInProgress.Store(true)
+}
+
+func proposedViews() []int {
+	ownProposalsMu.Lock()
+	defer ownProposalsMu.Unlock()
+	views := make([]int, 0, len(ownProposals))
+	for v := range ownProposals {
+		views = append(views, v)
+	}
+	return views
+}
+
+func releaseProposal(view int) {
+	ownProposalsMu.Lock()
+	records, ok := ownProposals[view]
+	delete(ownProposals, view)
+	if len(ownProposals) == 0 {
+		consensusInProgress.Store(false)
+	}
+	ownProposalsMu.Unlock()
+	if ok {
+		restorePending(records)
+	}
+}
+
+// 투표 메시지 서명자의 공개키 (자신은 meta, 피어는 pkMu 보호 하에 peerPubKeys 에서 조회)
+// view 의 투표 검증 키 후보
+//   - 장부에 기록된 view 시점 키 (현재 피어 키가 아니라 그 높이의 검증자 집합 기준)
+//   - 그 키가 교체 공지로 은퇴했으면(keystore.go 이력) 교체 체인 끝의 현재 키
+func voterKeysAt(addr string, view int) []string {
+	committed := validatorKeyAt(addr, view)
+	if committed == "" {
+		return nil
+	}
+	keys := []string{committed}
+	if cur, ok := voterPubKey(addr); ok && cur != committed && retiredPubKey(addr, pubKeyFingerprint(committed)) != "" {
+		keys = append(keys, cur)
+	}
+	return keys
+}
+
+// 후보 키 중 sig(hash) 를 검증한 키 (없으면 "")
+func verifyingKey(keys []string, hash, sig string) string {
+	for _, pub := range keys {
+		if verifyHashSig(pub, hash, sig) {
+			return pub
+		}
+	}
+	return ""
+}
+
+// 이 노드의 현재 공개키 (자신의 투표 지문 기록용)
+func selfPubKey() string {
+	pub, _ := getMeta(pubKeyMetaKey)
+	return pub
+}
+
+// 현재 알려진 공개키 (교체 공지 반영, keystore.go)
+func voterPubKey(addr string) (string, bool) {
+	if addr == self {
+		return getMeta("meta_hos_pubkey")
+	}
+	pkMu.RLock()
+	defer pkMu.RUnlock()
+	pub, ok := peerPubKeys[addr]
+	return pub, ok
+}
+
+// 라운드별 제한시간 (지수 백오프)
+func roundTimeout(round int) time.Duration {
+	if round > 5 {
+		round = 5
+	}
+	return time.Duration(ViewChangeTimeout<<round) * time.Second
 }
 
-func quorumSize() int {
-	n := len(peersSnapshot()) + 1
-	f := (n - 1) / 3
-	return 2*f + 1
+// 새 라운드 진입 : 투표 초기화 (제안 블록은 재제안을 위해 유지)
+func (vs *viewState) enterRound(round int) {
+	vs.Round = round
+	vs.Phase = PhaseIdle
+	vs.Prepare = newCollector()
+	vs.Commit = newCollector()
+	vs.StartedAt = time.Now()
 }
 
 func startConsensusWatcher() {
@@ -100,7 +249,7 @@ func startConsensusWatcher() {
 	var lastConsensusTime time.Time // 초기화를 하지 않음
 
 	for range ticker.C {
-		if self != boot || consensusInProgress.Load() {
+		if consensusInProgress.Load() {
 			continue
 		}
 
@@ -117,42 +266,65 @@ func startConsensusWatcher() {
 
 		// 메모리풀의 엔트리 수가 임계값 이상이거나, 마지막 합의 시점부터 임계대기시간 이후로 지났을 때 합의 수행
 		timeSinceLastConsensus := time.Since(lastConsensusTime)
-		shouldStart := pendingCnt >= ConsensusBatchSize || timeSinceLastConsensus >= ConsensusTimeout*time.Second
+		forced := forceConsensus.Swap(false)
+		shouldStart := forced || pendingCnt >= ConsensusBatchSize || timeSinceLastConsensus >= ConsensusTimeout*time.Second
 
 		reason := "Timeout"
 		// 합의 이유(Reason) 결정
 		if pendingCnt >= ConsensusBatchSize {
 			reason = "Full-Batch"
+		} else if forced {
+			reason = "Manual"
 		}
 
 		if !shouldStart {
 			// 아직 조건 미달이므로 대기
 			continue
 		}
-		records := popPending()
-		// 그사이 비워졌을 경우를 대비한 방어 로직
-		if len(records) == 0 {
-			continue
-		}
-		// PBFT 합의 프로세스 진입
 		height, _ := getLatestHeight()
 		view := height + 1
 
+		// 이번 view 의 제안자가 아니면 view 타이머만 시작
+		// (제안자가 제한시간 내에 제안하지 않으면 view-change 로 다음 제안자에게 넘어감)
+		if leader := leaderFor(view, 0); leader != self {
+			vs := getOrCreateView(view)
+			vs.mu.Lock()
+			if vs.StartedAt.IsZero() {
+				vs.StartedAt = time.Now()
+				log.Printf("[PBFT][WAIT] View=%d awaiting proposal from %s (pending=%d)", view, leader, pendingCnt)
+			}
+			vs.mu.Unlock()
+			continue
+		}
+		// 준비되지 않은 노드(동기화 지연 등)는 제안하지 않음 (health.go)
+		if !nodeReady() {
+			log.Printf("[PBFT][WAIT] View=%d not proposing: node not ready", view)
+			continue
+		}
+
 		vs := getOrCreateView(view)
 		vs.mu.Lock()
-		if vs.Phase != PhaseIdle {
+		if vs.Phase != PhaseIdle || vs.Round != 0 {
+			vs.mu.Unlock()
+			continue
+		}
+		records := popPending()
+		// 그사이 비워졌을 경우를 대비한 방어 로직
+		if len(records) == 0 {
 			vs.mu.Unlock()
 			continue
 		}
+		// PBFT 합의 프로세스 진입 (합의 진행 상태 갱신, 확정되지 않으면 view 정리 시 메모리풀로 복구)
+		trackProposal(view, records)
 
 		// 제안 블록 생성 및 상태 전이
 		block := createProposedBlock(records)
 		vs.Block = block
 		vs.Phase = PhasePrePrepare
+		vs.StartedAt = time.Now()
+		round := vs.Round
 		vs.mu.Unlock()
-
-		// 합의 진행 상태 원자적 갱신
-		consensusInProgress.Store(true)
+		countPhase(PhasePrePrepare)
 
 		log.Printf("[PBFT][START] View=%d, Entries=%d (Reason: %s, Elapsed: %.1fs)",
 			view,
@@ -161,20 +333,85 @@ func startConsensusWatcher() {
 			timeSinceLastConsensus.Seconds(),
 		)
 
-		// 합의 시작 신호 브로드캐스트
-		broadcast("/bft/start", map[string]any{"view": view, "block": block})
+		// 합의 시작 신호 브로드캐스트 (제안 블록 해시에 서명 : 잘못된 제안의 증거, evidence.go)
+		broadcast("/bft/start", signedProposal(view, round, block))
 
 		// 마지막 합의 시간 갱신 (반드시 루프 마지막이나 시작 시점에 갱신 확인)
 		lastConsensusTime = time.Now()
 	}
 }
 
+// POST /admin/finalize : 메모리풀 레코드로 즉시 합의 라운드 시작
+//   - 다음 블록의 제안자가 아니면 제안자에게 전달 (status = "forwarded")
+//     전달받은 요청(?forwarded=true)은 다시 전달하지 않음 (노드 간 높이가 달라 제안자 판단이 어긋날 때의 순환 방지)
+//   - 메모리풀이 비었으면 409
+func handleAdminFinalize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if isObserver() {
+		writeError(w, http.StatusForbidden, errObserverReadOnly.Error())
+		return
+	}
+	pending := getPendingCnt()
+	if pending == 0 {
+		writeError(w, http.StatusConflict, "no pending records")
+		return
+	}
+	status := "scheduled"
+	if leader := nextProposer(); leader != self && r.URL.Query().Get("forwarded") != "true" {
+		if err := postPeer(leader, "/admin/finalize?forwarded=true", nil); err != nil {
+			writeError(w, http.StatusBadGateway, "proposer "+leader+" unreachable")
+			return
+		}
+		status = "forwarded"
+	} else {
+		forceConsensus.Store(true)
+	}
+	log.Printf("[PBFT] manual finalize requested (pending=%d, %s)", pending, status)
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"status":      status,
+		"pending":     pending,
+		"proposer":    nextProposer(),
+		"in_progress": consensusInProgress.Load(),
+	})
+}
+
 func handleBftStart(w http.ResponseWriter, r *http.Request) {
-	var msg struct {
-		View  int        `json:"view"`
-		Block LowerBlock `json:"block"`
+	var msg bftProposal
+	if err := decodeWire(r, &msg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid proposal message")
+		return
+	}
+
+	// 해당 view/round 의 제안자가 보낸 제안만 수용 (round 0 블록은 제안자 본인이 생성한 블록이어야 함)
+	if expected := leaderFor(msg.View, msg.Round); msg.Leader != expected {
+		log.Printf("[PBFT][START] Reject proposal from non-leader %s (view=%d round=%d expected=%s)", msg.Leader, msg.View, msg.Round, expected)
+		writeErrorDetail(w, http.StatusForbidden, "not_leader", "proposal from non-leader", map[string]string{"expected": expected})
+		return
+	}
+	if msg.Block.Index != msg.View || (msg.Round == 0 && msg.Block.Proposer != msg.Leader) {
+		log.Printf("[PBFT][START] Reject proposal #%d by %s for view %d", msg.Block.Index, msg.Block.Proposer, msg.View)
+		writeError(w, http.StatusBadRequest, "proposal block does not match view/leader")
+		return
+	}
+	leaderPub := verifyingKey(voterKeysAt(msg.Leader, msg.View), msg.Block.BlockHash, msg.Sig)
+	if leaderPub == "" {
+		log.Printf("[PBFT][START] Reject unsigned proposal from %s (view=%d round=%d)", msg.Leader, msg.View, msg.Round)
+		writeError(w, http.StatusForbidden, "invalid proposal signature")
+		return
+	}
+	if !verifyHashSig(leaderPub, voteDigest(PhaseNameStart, msg.View, msg.Round, msg.Block.BlockHash), msg.Auth) {
+		log.Printf("[PBFT][START] Reject proposal from %s not bound to view=%d round=%d", msg.Leader, msg.View, msg.Round)
+		writeErrorDetail(w, http.StatusForbidden, "unbound_message", "proposal signature does not bind this view/round", nil)
+		return
 	}
-	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+	// 서명된 블록의 본문이 잘못됐으면 증거 접수 후 거부 (evidence.go)
+	if err := checkProposalBody(msg.Block); err != nil {
+		log.Printf("[PBFT][START] Reject proposal for view %d: %v", msg.View, err)
+		observeInvalidProposal(msg.View, msg.Round, msg.Leader, msg.Sig, msg.Block, err)
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "invalid_proposal", err.Error(), nil)
 		return
 	}
 
@@ -182,37 +419,67 @@ func handleBftStart(w http.ResponseWriter, r *http.Request) {
 	vs.mu.Lock()
 	defer vs.mu.Unlock()
 
+	if vs.Finalized || msg.Round < vs.Round {
+		writeErrorDetail(w, http.StatusConflict, "stale_view", "view already finalized or moved to a later round", nil)
+		return
+	}
+	// view-change 정족수를 놓쳤더라도 새 리더의 제안이 오면 해당 라운드로 합류
+	if msg.Round > vs.Round {
+		vs.enterRound(msg.Round)
+	}
 	if vs.Phase != PhaseIdle {
+		writeErrorDetail(w, http.StatusConflict, "already_proposed", "a proposal is already being voted in this round", nil)
 		return
 	}
 
-	// 블록 검증 로직 (필요시 추가)
+	// 데이터 상주 규칙 위반, 허용되지 않는 검증자 변경이 있는 블록은 Prepare 하지 않음
+	if err := checkBlockResidency(msg.Block); err != nil {
+		log.Printf("[PBFT][START] Reject proposal for view %d: %v", msg.View, err)
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "invalid_proposal", err.Error(), nil)
+		return
+	}
+	if err := checkValidatorChanges(msg.Block); err != nil {
+		log.Printf("[PBFT][START] Reject proposal for view %d: %v", msg.View, err)
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "invalid_proposal", err.Error(), nil)
+		return
+	}
+	if err := checkEvidenceRecords(msg.Block.Entries); err != nil {
+		log.Printf("[PBFT][START] Reject proposal for view %d: %v", msg.View, err)
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "invalid_proposal", err.Error(), nil)
+		return
+	}
+	// 제안자 시계가 틀어진 블록 (timesync.go)
+	if err := checkProposalTime(msg.Block); err != nil {
+		log.Printf("[PBFT][START] Reject proposal for view %d: %v", msg.View, err)
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "invalid_timestamp", err.Error(), nil)
+		return
+	}
+	if !reserveVote(msg.View, vs.Round, PhaseNamePrepare, msg.Block.BlockHash) {
+		log.Printf("[PBFT][START] Not preparing view %d round %d: already signed another block in this round", msg.View, vs.Round)
+		writeErrorDetail(w, http.StatusConflict, "conflicting_vote", "already voted for another block in this round", nil)
+		return
+	}
 	vs.Block = msg.Block
 	vs.Phase = PhasePrepare
+	countPhase(PhasePrepare)
+	if vs.StartedAt.IsZero() {
+		vs.StartedAt = time.Now()
+	}
 
-	myPriv, _ := getMeta("meta_hos_privkey")
-	sig := makeAnchorSignature(myPriv, vs.Block.BlockHash, "")
-	vs.Prepare.add(self, sig)
+	vote := signedVote(PhaseNamePrepare, msg.View, vs.Round, vs.Block)
+	vs.Prepare.add(self, vote.Sig, selfPubKey())
 
-	log.Printf("[PBFT][PREPARE] Send Prepare for View %d", msg.View)
-	broadcast("/bft/prepare", map[string]any{
-		"view": msg.View,
-		"addr": self,
-		"sig":  sig,
-		"hash": vs.Block.BlockHash,
-	})
+	log.Printf("[PBFT][PREPARE] Send Prepare for View %d (round=%d)", msg.View, vs.Round)
+	broadcast("/bft/prepare", vote)
 }
 
 func handleReceivePrepare(w http.ResponseWriter, r *http.Request) {
-	var msg struct {
-		View int
-		Addr string
-		Sig  string
-		Hash string
-	}
-	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+	var msg bftVote
+	if err := decodeWire(r, &msg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid vote message")
 		return
 	}
+	observeVote(msg.View, msg.Round, PhaseNamePrepare, msg.Addr, msg.Hash, msg.Sig, msg.Auth, msg.Header)
 
 	vs := getOrCreateView(msg.View)
 	vs.mu.Lock()
@@ -233,97 +500,116 @@ func handleReceivePrepare(w http.ResponseWriter, r *http.Request) {
 
 	// 리더로부터 BftStart(Pre-Prepare)를 아예 못 받은 경우
 	if vs.Block.BlockHash == "" {
+		writeErrorDetail(w, http.StatusConflict, "no_proposal", "no proposal received for this view", nil)
+		return
+	}
+	// 다른 라운드의 투표는 무시
+	if msg.Round != vs.Round {
+		writeErrorDetail(w, http.StatusConflict, "round_mismatch", fmt.Sprintf("vote for round %d, current round %d", msg.Round, vs.Round), nil)
 		return
 	}
 
 	// 해시 미스매치 검사
 	if vs.Block.BlockHash != msg.Hash {
 		log.Printf("[DEBUG] Hash mismatch in Prepare: Expected %s, Got %s", vs.Block.BlockHash, msg.Hash)
+		writeErrorDetail(w, http.StatusConflict, "hash_mismatch", "vote is for a different block", nil)
 		return
 	}
 
-	var pub string
-	var ok bool
-	if msg.Addr == self {
-		pub, ok = getMeta("meta_hos_pubkey")
-	} else {
-		pub, ok = peerPubKeys[msg.Addr]
+	// 이 view 의 장부 검증자 투표만 집계 (validators.go)
+	if !isValidatorAt(msg.Addr, msg.View) {
+		writeErrorDetail(w, http.StatusForbidden, "not_validator", msg.Addr+" is not a validator at this view", nil)
+		return
 	}
-
-	if !ok {
+	// 이 view 의 장부 키로 검증 (현재 피어 키 아님)
+	keys := voterKeysAt(msg.Addr, msg.View)
+	if len(keys) == 0 {
+		writeError(w, http.StatusForbidden, "unknown voter key")
 		return
 	}
-	hashBytes, _ := hex.DecodeString(msg.Hash)
-	if !verifyECDSA(pub, hashBytes, msg.Sig) {
+	pub := verifyingKey(keys, msg.Hash, msg.Sig)
+	if pub == "" {
+		writeError(w, http.StatusForbidden, "invalid vote signature")
 		return
 	}
-
-	if !vs.Prepare.add(msg.Addr, msg.Sig) {
+	// 다른 view/round/단계의 서명 재전송 차단
+	if !verifyHashSig(pub, voteDigest(PhaseNamePrepare, msg.View, msg.Round, msg.Hash), msg.Auth) {
+		writeErrorDetail(w, http.StatusForbidden, "unbound_message", "vote signature does not bind this view/round/phase", nil)
 		return
 	}
 
+	if !vs.Prepare.add(msg.Addr, msg.Sig, pub) {
+		return // 이미 집계된 투표 (재전송은 성공으로 응답)
+	}
+
 	// 정족수 확인 후 Commit 단계 진입
-	if vs.Prepare.count() >= quorumSize() && vs.Phase == PhasePrepare {
+	if vs.Prepare.count() >= quorumAt(msg.View) && vs.Phase == PhasePrepare {
+		if !reserveVote(msg.View, vs.Round, PhaseNameCommit, vs.Block.BlockHash) {
+			log.Printf("[PBFT][COMMIT] Not committing view %d round %d: already signed another block in this round", msg.View, vs.Round)
+			return
+		}
 		vs.Phase = PhaseCommit
-		myPriv, _ := getMeta("meta_hos_privkey")
+		countPhase(PhaseCommit)
+		vote := signedVote(PhaseNameCommit, msg.View, vs.Round, vs.Block)
+		vs.Commit.add(self, vote.Sig, selfPubKey())
 
-		sig := makeAnchorSignature(myPriv, vs.Block.BlockHash, "")
-		vs.Commit.add(self, sig)
-
-		log.Printf("[PBFT][COMMIT] Quorum reached! Broadcast Commit for View %d", msg.View)
-		broadcast("/bft/commit", map[string]any{
-			"view": msg.View,
-			"addr": self,
-			"sig":  sig,
-			"hash": vs.Block.BlockHash,
-		})
+		log.Printf("[PBFT][COMMIT] Quorum reached! Broadcast Commit for View %d (round=%d)", msg.View, vs.Round)
+		broadcast("/bft/commit", vote)
 	}
 }
 
 func handleReceiveCommit(w http.ResponseWriter, r *http.Request) {
-	var msg struct {
-		View int
-		Addr string
-		Sig  string
-		Hash string
-	}
-	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+	var msg bftVote
+	if err := decodeWire(r, &msg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid vote message")
 		return
 	}
+	observeVote(msg.View, msg.Round, PhaseNameCommit, msg.Addr, msg.Hash, msg.Sig, msg.Auth, msg.Header)
 
 	vs := getOrCreateView(msg.View)
 	vs.mu.Lock()
 	defer vs.mu.Unlock()
 
-	if vs.Block.BlockHash != msg.Hash {
+	if vs.Block.BlockHash != msg.Hash || msg.Round != vs.Round {
+		writeErrorDetail(w, http.StatusConflict, "hash_mismatch", "vote is for a different block or round", nil)
 		return
 	}
 
-	var pub string
-	var ok bool
-	if msg.Addr == self {
-		pub, ok = getMeta("meta_hos_pubkey")
-	} else {
-		pub, ok = peerPubKeys[msg.Addr]
+	// 이 view 의 장부 검증자 투표만 집계 (validators.go)
+	if !isValidatorAt(msg.Addr, msg.View) {
+		writeErrorDetail(w, http.StatusForbidden, "not_validator", msg.Addr+" is not a validator at this view", nil)
+		return
 	}
-
-	if !ok {
+	// 이 view 의 장부 키로 검증 (현재 피어 키 아님)
+	keys := voterKeysAt(msg.Addr, msg.View)
+	if len(keys) == 0 {
+		writeError(w, http.StatusForbidden, "unknown voter key")
 		return
 	}
-	hashBytes, _ := hex.DecodeString(msg.Hash)
-	if !verifyECDSA(pub, hashBytes, msg.Sig) {
+	pub := verifyingKey(keys, msg.Hash, msg.Sig)
+	if pub == "" {
+		writeError(w, http.StatusForbidden, "invalid vote signature")
 		return
 	}
-
-	if !vs.Commit.add(msg.Addr, msg.Sig) {
+	// 다른 view/round/단계의 서명 재전송 차단
+	if !verifyHashSig(pub, voteDigest(PhaseNameCommit, msg.View, msg.Round, msg.Hash), msg.Auth) {
+		writeErrorDetail(w, http.StatusForbidden, "unbound_message", "vote signature does not bind this view/round/phase", nil)
 		return
 	}
 
+	if !vs.Commit.add(msg.Addr, msg.Sig, pub) {
+		return // 이미 집계된 투표 (재전송은 성공으로 응답)
+	}
+
 	// 최종 확정 및 저장
-	if vs.Commit.count() >= quorumSize() && !vs.Finalized {
+	if vs.Commit.count() >= quorumAt(msg.View) && !vs.Finalized {
 		vs.Finalized = true
 		vs.Phase = PhaseFinal
-		vs.Block.Signatures = vs.Commit.all()
+		vs.Block.Signatures = vs.Commit.signatures()
+		countPhase(PhaseFinal)
+		if !vs.StartedAt.IsZero() {
+			observeDuration("chain_consensus_duration_seconds", time.Since(vs.StartedAt).Seconds())
+		}
 
 		log.Printf("[PBFT][FINALIZED] View %d Finalized. Saving to DB...", msg.View)
 
@@ -336,8 +622,322 @@ func handleReceiveCommit(w http.ResponseWriter, r *http.Request) {
 
 func broadcast(path string, data any) {
 	body, _ := json.Marshal(data)
-	nodes := append(peersSnapshot(), self)
+	// protobuf 를 지원하는 피어에게는 바이너리로 전송 (wire.go)
+	var wire []byte
+	if m, ok := data.(wireCodec); ok && WireFormat == WireFormatProto {
+		wire, _ = m.marshalWire()
+	}
+	// 같은 리전 노드부터 전송
+	nodes := orderByLocality(append(peersSnapshot(), self))
 	for _, node := range nodes {
-		go http.Post("http://"+node+path, "application/json", bytes.NewReader(body))
+		ct, payload := "application/json", body
+		if wire != nil && peerWantsProto(node) {
+			ct, payload = WireContentType, wire
+		}
+		if node != self {
+			recordTraffic(node, int64(len(payload)))
+		}
+		go postPeerAs(node, path, ct, payload)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// View-Change (리더 장애 시 라운드 교체)
+//  - 각 노드는 진행 중인 view가 라운드 제한시간 내에 확정되지 않으면 다음 라운드로 view-change 투표
+//  - f+1개의 view-change를 받으면 아직 투표하지 않은 노드도 합류 (PBFT 진행성 보장)
+//  - 2f+1개(정족수)가 모이면 새 라운드로 전환, 새 리더가 기존 제안 블록(없으면 메모리풀)을 재제안
+////////////////////////////////////////////////////////////////////////////////
+
+// 합의 메시지 단계 (메시지 인증 다이제스트, 증거 기록 공용)
+const (
+	PhaseNameStart   = "start"
+	PhaseNamePrepare = "prepare"
+	PhaseNameCommit  = "commit"
+)
+
+// 합의 메시지 인증 다이제스트 (hex) : 단계/view/round/블록 해시를 함께 서명
+// - 블록 해시만 서명한 Sig 는 블록 합의 증거(Signatures)와 이중 서명 증거에 그대로 사용
+// - Auth 는 이 다이제스트에 대한 서명 => 이전 라운드/다른 단계의 서명을 재전송해도 거부됨
+func voteDigest(phase string, view, round int, hash string) string {
+	return sha256Hex([]byte(fmt.Sprintf("bft|%s|%d|%d|%s", phase, view, round, hash)))
+}
+
+// 내 키로 서명한 제안 메시지
+func signedProposal(view, round int, block LowerBlock) *bftProposal {
+	myPriv, _ := nodePrivKey()
+	return &bftProposal{
+		View:   view,
+		Round:  round,
+		Leader: self,
+		Block:  block,
+		Sig:    makeAnchorSignature(myPriv, block.BlockHash, ""), // 잘못된 제안의 증거 (evidence.go)
+		Auth:   makeAnchorSignature(myPriv, voteDigest(PhaseNameStart, view, round, block.BlockHash), ""),
+	}
+}
+
+// 내 키로 서명한 prepare/commit 투표
+func signedVote(phase string, view, round int, block LowerBlock) *bftVote {
+	myPriv, _ := nodePrivKey()
+	header := block.header()
+	return &bftVote{
+		View:   view,
+		Round:  round,
+		Addr:   self,
+		Sig:    makeAnchorSignature(myPriv, block.BlockHash, ""),
+		Auth:   makeAnchorSignature(myPriv, voteDigest(phase, view, round, block.BlockHash), ""),
+		Hash:   block.BlockHash,
+		Header: &header,
+	}
+}
+
+// view-change 서명 대상 다이제스트 (hex)
+func viewChangeDigest(view, round int) string {
+	return sha256Hex([]byte(fmt.Sprintf("viewchange|%d|%d", view, round)))
+}
+
+// 라운드 제한시간 감시 루틴
+func startViewChangeWatcher() {
+	ticker := time.NewTicker(time.Second)
+	for range ticker.C {
+		height, _ := getLatestHeight()
+
+		// view 상태 없이 남은 이전 높이의 제안 정리 (레코드 복구, 합의 진행 상태 해제)
+		for _, v := range proposedViews() {
+			if v <= height {
+				releaseProposal(v)
+			}
+		}
+
+		viewMu.Lock()
+		views := make(map[int]*viewState, len(viewStates))
+		for v, vs := range viewStates {
+			views[v] = vs
+		}
+		viewMu.Unlock()
+
+		for view, vs := range views {
+			// 이미 장부에 반영된 view는 정리
+			if view <= height {
+				deleteView(view)
+				continue
+			}
+			vs.mu.Lock()
+			abandon := !vs.Finalized && ViewAbandonTimeout > 0 && time.Since(vs.OpenedAt) > time.Duration(ViewAbandonTimeout)*time.Second
+			expired := !vs.Finalized && !vs.StartedAt.IsZero() &&
+				time.Since(vs.StartedAt) > roundTimeout(vs.Round) && vs.VotedRound <= vs.Round
+			next, phase := vs.Round+1, vs.Phase
+			vs.mu.Unlock()
+
+			if abandon {
+				abandonView(view, next-1, phase)
+				continue
+			}
+			if expired {
+				// 제한시간이 지난 제안은 진행 중으로 보지 않음 (체인 감시/재동기화가 다시 동작하도록)
+				consensusInProgress.Store(false)
+				log.Printf("[PBFT][VIEWCHANGE] View %d round %d timed out => vote round %d", view, next-1, next)
+				sendViewChange(view, next)
+			}
+		}
+	}
+}
+
+// 확정되지 않는 view 포기 (제안 레코드는 deleteView 가 메모리풀로 복구)
+func abandonView(view, round int, phase int32) {
+	log.Printf("[PBFT][ABANDON] View %d not finalized within %ds (round %d, phase %s) => abandoned", view, ViewAbandonTimeout, round, phaseName(phase))
+	deleteView(view)
+	incCounter("chain_consensus_abandoned_total", `phase="`+phaseName(phase)+`"`)
+	publishEvent(EventConsensusAbandoned, map[string]any{"view": view, "round": round, "phase": phaseName(phase)})
+}
+
+// view-change 투표 브로드캐스트 (라운드당 1회)
+func sendViewChange(view, round int) {
+	vs := getOrCreateView(view)
+	vs.mu.Lock()
+	if vs.VotedRound >= round {
+		vs.mu.Unlock()
+		return
+	}
+	vs.VotedRound = round
+	vs.mu.Unlock()
+
+	myPriv, _ := nodePrivKey()
+	sig := makeAnchorSignature(myPriv, viewChangeDigest(view, round), "")
+	broadcast("/bft/viewchange", &bftViewChange{View: view, Round: round, Addr: self, Sig: sig})
+}
+
+// view-change 투표 수신
+// POST /bft/viewchange
+func handleViewChange(w http.ResponseWriter, r *http.Request) {
+	var msg bftViewChange
+	if err := decodeWire(r, &msg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid view-change message")
+		return
+	}
+
+	// 이 view 의 장부 검증자 투표만 집계 (validators.go)
+	if !isValidatorAt(msg.Addr, msg.View) {
+		writeErrorDetail(w, http.StatusForbidden, "not_validator", msg.Addr+" is not a validator at this view", nil)
+		return
+	}
+	keys := voterKeysAt(msg.Addr, msg.View)
+	if len(keys) == 0 {
+		writeError(w, http.StatusForbidden, "unknown voter key")
+		return
+	}
+	if verifyingKey(keys, viewChangeDigest(msg.View, msg.Round), msg.Sig) == "" {
+		writeError(w, http.StatusForbidden, "invalid view-change signature")
+		return
+	}
+
+	vs := getOrCreateView(msg.View)
+	vs.mu.Lock()
+	if vs.Finalized || msg.Round <= vs.Round {
+		vs.mu.Unlock()
+		writeErrorDetail(w, http.StatusConflict, "stale_view", "view already finalized or at this round", nil)
+		return
+	}
+	vc, exists := vs.ViewChange[msg.Round]
+	if !exists {
+		vc = newCollector()
+		vs.ViewChange[msg.Round] = vc
+	}
+	if !vc.add(msg.Addr, msg.Sig, "") {
+		vs.mu.Unlock()
+		return // 이미 집계된 투표 (재전송은 성공으로 응답)
+	}
+	votes := vc.count()
+	voted := vs.VotedRound >= msg.Round
+	vs.mu.Unlock()
+
+	// f+1개 이상이면 최소 1개의 정상 노드가 타임아웃을 겪은 것이므로 함께 투표
+	f := (len(validatorsAt(msg.View)) - 1) / 3
+	if !voted && votes >= f+1 {
+		sendViewChange(msg.View, msg.Round)
+	}
+
+	if votes < quorumAt(msg.View) {
+		return
+	}
+
+	// 정족수 도달 : 새 라운드 진입
+	vs.mu.Lock()
+	if msg.Round <= vs.Round || vs.Finalized {
+		vs.mu.Unlock()
+		return
+	}
+	vs.enterRound(msg.Round)
+	block := vs.Block
+	vs.mu.Unlock()
+	incCounter("chain_bft_view_changes_total", "")
+
+	leader := leaderFor(msg.View, msg.Round)
+	log.Printf("[PBFT][VIEWCHANGE] View %d moved to round %d (leader=%s)", msg.View, msg.Round, leader)
+	if leader == self && nodeReady() {
+		reProposeView(msg.View, msg.Round, block)
+	}
+}
+
+// 새 리더의 재제안
+func reProposeView(view, round int, block LowerBlock) {
+	// 이전 라운드의 제안을 받지 못했다면 자신의 메모리풀로 새 블록 구성
+	var records []ClinicRecord
+	if block.BlockHash == "" {
+		records = popPending()
+		if len(records) == 0 {
+			consensusInProgress.Store(false)
+			log.Printf("[PBFT][VIEWCHANGE] No block to re-propose for View %d", view)
+			return
+		}
+		block = createProposedBlock(records)
+	}
+
+	vs := getOrCreateView(view)
+	vs.mu.Lock()
+	if vs.Round != round || vs.Phase != PhaseIdle {
+		vs.mu.Unlock()
+		// 그사이 라운드가 바뀌었으면 꺼낸 레코드는 메모리풀로 복구
+		restorePending(records)
+		consensusInProgress.Store(false)
+		return
+	}
+	vs.Block = block
+	vs.Phase = PhasePrePrepare
+	vs.mu.Unlock()
+	countPhase(PhasePrePrepare)
+
+	if len(records) > 0 {
+		trackProposal(view, records)
+	} else {
+		consensusInProgress.Store(true)
+	}
+	log.Printf("[PBFT][VIEWCHANGE] Re-proposing View %d in round %d", view, round)
+	broadcast("/bft/start", signedProposal(view, round, block))
+}
+
+// 진행 중인 view 의 합의 상태 (GET /consensus/state, 대시보드용)
+type ViewStatus struct {
+	View        int     `json:"view"`
+	Round       int     `json:"round"`
+	Phase       string  `json:"phase"`
+	Leader      string  `json:"leader"`
+	BlockHash   string  `json:"block_hash,omitempty"`
+	Entries     int     `json:"entries"`
+	Prepare     int     `json:"prepare"`
+	Commit      int     `json:"commit"`
+	Quorum      int     `json:"quorum"`
+	Finalized   bool    `json:"finalized"`
+	OpenSeconds float64 `json:"open_seconds"`
+}
+
+type ConsensusState struct {
+	Height       int          `json:"height"`
+	NextProposer string       `json:"next_proposer"`
+	InProgress   bool         `json:"in_progress"`
+	Syncing      bool         `json:"syncing"`
+	Views        []ViewStatus `json:"views"`
+}
+
+// GET /consensus/state
+func handleConsensusState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	height, _ := getLatestHeight()
+	st := ConsensusState{
+		Height:       height,
+		NextProposer: leaderFor(height+1, 0),
+		InProgress:   consensusInProgress.Load(),
+		Syncing:      syncInProgress.Load(),
+		Views:        []ViewStatus{},
+	}
+
+	// view 목록만 잠근 채 복사 (view 별 잠금은 viewMu 밖에서)
+	viewMu.Lock()
+	views := make(map[int]*viewState, len(viewStates))
+	for v, vs := range viewStates {
+		views[v] = vs
+	}
+	viewMu.Unlock()
+
+	for v, vs := range views {
+		vs.mu.Lock()
+		st.Views = append(st.Views, ViewStatus{
+			View:        v,
+			Round:       vs.Round,
+			Phase:       phaseName(vs.Phase),
+			Leader:      leaderFor(v, vs.Round),
+			BlockHash:   vs.Block.BlockHash,
+			Entries:     len(vs.Block.Entries),
+			Prepare:     vs.Prepare.count(),
+			Commit:      vs.Commit.count(),
+			Quorum:      quorumAt(v),
+			Finalized:   vs.Finalized,
+			OpenSeconds: time.Since(vs.OpenedAt).Seconds(),
+		})
+		vs.mu.Unlock()
 	}
+	sort.Slice(st.Views, func(i, j int) bool { return st.Views[i].View < st.Views[j].View })
+	writeJSON(w, http.StatusOK, st)
 }
@@ -2,9 +2,12 @@ package main
 
 import (
 	"encoding/json"
+	"encoding/pem"
 	"log"
 	"strings"
 	"time"
+
+	"merkle"
 )
 
 // //////////////////////////////////////////////////////////////////////////////
@@ -15,17 +18,74 @@ import (
 // 그 해시들을 기반으로 Merkle Root를 계산하여 블록 헤더에 저장
 // //////////////////////////////////////////////////////////////////////////////
 type LowerBlock struct {
-	Index      int            `json:"index"`       // 블록 번호
-	HosID      string         `json:"hos_id"`      // Hos 체인 식별자
-	PrevHash   string         `json:"prev_hash"`   // 이전 블록의 해시
-	Timestamp  string         `json:"timestamp"`   // 생성 시간 (RFC3339Nano 권장)
-	Entries    []ClinicRecord `json:"entries"`     // 블록 내 진료 정보 목록
-	MerkleRoot string         `json:"merkle_root"` // Entries의 해시 기반 머클루트
-	Proposer   string         `json:"proposer"`    // 해당 블록의 합의 집행자
-	Signatures []string       `json:"signatures"`  // 2f+1개 이상의 노드 서명 목록 (합의 증거)
-	BlockHash  string         `json:"block_hash"`  // 블록 전체 해시 (헤더 기준)
-	Elapsed    float32        `json:"elapsed"`     // 소요 시간
-	LeafHashes []string       `json:"leaf_hashes"` // Merkle Proof 재현을 위한 해시값 모음
+	Index      int            `json:"index"`            // 블록 번호
+	HosID      string         `json:"hos_id"`           // Hos 체인 식별자
+	PrevHash   string         `json:"prev_hash"`        // 이전 블록의 해시
+	Timestamp  string         `json:"timestamp"`        // 생성 시간 (RFC3339Nano 권장)
+	Entries    []ClinicRecord `json:"entries"`          // 블록 내 진료 정보 목록
+	MerkleRoot string         `json:"merkle_root"`      // Entries의 해시 기반 머클루트
+	Proposer   string         `json:"proposer"`         // 해당 블록의 합의 집행자
+	Signatures []ConsensusSig `json:"signatures"`       // 2f+1개 이상의 노드 서명 목록 (합의 증거)
+	BlockHash  string         `json:"block_hash"`       // 블록 전체 해시 (헤더 기준)
+	Elapsed    float32        `json:"elapsed"`          // 소요 시간
+	LeafHashes []string       `json:"leaf_hashes"`      // Merkle Proof 재현을 위한 해시값 모음
+	Pruned     bool           `json:"pruned,omitempty"` // Entries 가 정리된 블록 (prune.go)
+}
+
+// 합의 서명 (서명 노드 주소 + 공개키 지문 + 블록 해시에 대한 ECDSA 서명)
+// - 검증 시 Addr 의 공개키 하나로만 확인 (countValidSignatures)
+type ConsensusSig struct {
+	Addr  string `json:"addr"`
+	KeyFP string `json:"pubkey_fingerprint"` // 서명 시점 공개키의 SHA-256 지문 (pubKeyFingerprint)
+	Sig   string `json:"sig"`                // hex, DER
+}
+
+// 기존 블록의 서명 문자열("<sig hex>")도 서명자 없는 ConsensusSig 로 읽음
+func (s *ConsensusSig) UnmarshalJSON(data []byte) error {
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		*s = ConsensusSig{Sig: legacy}
+		return nil
+	}
+	type plain ConsensusSig
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// 공개키 PEM 의 지문 (DER 의 SHA-256 hex, 인증서 지문과 같은 형식)
+func pubKeyFingerprint(pubPem string) string {
+	block, _ := pem.Decode([]byte(pubPem))
+	if block == nil {
+		return ""
+	}
+	return certFingerprint(block.Bytes)
+}
+
+// 블록 헤더 (대시보드 목록 조회용, Entries/LeafHashes/Signatures 본문 제외)
+type LowerBlockHeader struct {
+	Index      int            `json:"index"`
+	HosID      string         `json:"hos_id"`
+	PrevHash   string         `json:"prev_hash"`
+	Timestamp  string         `json:"timestamp"`
+	MerkleRoot string         `json:"merkle_root"`
+	Proposer   string         `json:"proposer"`
+	BlockHash  string         `json:"block_hash"`
+	Elapsed    float32        `json:"elapsed"`
+	EntryCount int            `json:"entry_count"`          // 블록 내 진료 정보 수
+	Signatures []ConsensusSig `json:"signatures,omitempty"` // /headers?sigs=true 일 때만 (라이트 클라이언트 합의 검증용)
+}
+
+func (b LowerBlock) header() LowerBlockHeader {
+	return LowerBlockHeader{
+		Index:      b.Index,
+		HosID:      b.HosID,
+		PrevHash:   b.PrevHash,
+		Timestamp:  b.Timestamp,
+		MerkleRoot: b.MerkleRoot,
+		Proposer:   b.Proposer,
+		BlockHash:  b.BlockHash,
+		Elapsed:    b.Elapsed,
+		EntryCount: max(len(b.Entries), len(b.LeafHashes)), // 정리된 블록은 LeafHashes 기준
+	}
 }
 
 // 제네시스 블록 생성
@@ -38,8 +98,8 @@ func createGenesisBlock(hosID string) LowerBlock {
 		Timestamp:  "2026-01-21 T01:07:18Z",
 		Entries:    []ClinicRecord{},
 		MerkleRoot: "",
-		Proposer:   "SYSTEM",   // 제네시스는 시스템에 의해 생성됨
-		Signatures: []string{}, // 제네시스는 투표 절차 생략
+		Proposer:   "SYSTEM",         // 제네시스는 시스템에 의해 생성됨
+		Signatures: []ConsensusSig{}, // 제네시스는 투표 절차 생략
 		Elapsed:    0,
 		LeafHashes: []string{},
 	}
@@ -50,7 +110,17 @@ func createGenesisBlock(hosID string) LowerBlock {
 
 // 블록의 식별자인 Hash 값 계산
 func (b LowerBlock) computeHash() string {
-	hdr := struct {
+	return b.header().computeHash()
+}
+
+// 헤더 필드만으로 블록 해시 계산 (본문 없이 헤더 체인 검증 가능 : fastsync.go)
+func (b LowerBlockHeader) computeHash() string {
+	return sha256Hex(jsonCanonical(b.hashFields()))
+}
+
+// 블록 해시 대상 필드
+func (b LowerBlockHeader) hashFields() interface{} {
+	return struct {
 		Index      int    `json:"index"`
 		HosID      string `json:"hos_id"`
 		PrevHash   string `json:"prev_hash"`
@@ -65,7 +135,6 @@ func (b LowerBlock) computeHash() string {
 		MerkleRoot: b.MerkleRoot,
 		Proposer:   b.Proposer,
 	}
-	return sha256Hex(jsonCanonical(hdr))
 }
 
 func createProposedBlock(entries []ClinicRecord) LowerBlock {
@@ -77,24 +146,21 @@ func createProposedBlock(entries []ClinicRecord) LowerBlock {
 		Index:      height + 1,
 		HosID:      selfID(),
 		PrevHash:   prevBlock.BlockHash,
-		Timestamp:  time.Now().UTC().Format(time.RFC3339Nano),
+		Timestamp:  proposalTime(prevBlock).Format(time.RFC3339Nano),
 		Entries:    entries,
 		Proposer:   self,
-		Signatures: []string{},
+		Signatures: []ConsensusSig{},
 		Elapsed:    0,
 	}
 
-	// Leaf Hash 생성
-	leafHashes := make([]string, len(entries))
-	for i, r := range entries {
-		leafHashes[i] = hashClinicRecord(r)
-	}
+	// Leaf Hash 생성 (확정 후 /proof 는 저장된 LeafHashes 로 증명을 만듦)
+	leafHashes := entryLeafHashes(entries)
 
 	newBlock.LeafHashes = leafHashes
 
 	// Merkle Root 계산
 	if len(leafHashes) > 0 {
-		newBlock.MerkleRoot = merkleRootHex(leafHashes)
+		newBlock.MerkleRoot = merkle.Root(leafHashes)
 	}
 
 	// Block Hash 계산
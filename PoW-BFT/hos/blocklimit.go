@@ -0,0 +1,40 @@
+package main
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+// 블록 크기 한도 (Block Size / Entry-count Limits)
+// ------------------------------------------------------------
+// - 합의 상수 : 모든 노드가 같은 값을 써야 하므로 환경변수로 바꾸지 않음
+//   · MaxBlockEntries : 블록당 최대 레코드 수
+//   · MaxBlockBytes   : 블록 레코드 직렬화 크기 합 최대값 (pendingSize 기준)
+// - 제안자는 메모리풀에서 우선순위 순으로 한도까지만 꺼내고 나머지는 다음 블록으로 (selectForBlock)
+// - 수신 블록/제안 검증(validateLowerBlock, checkProposalBody)에서 한도 초과 블록 거부
+// - 레코드 하나가 MaxBlockBytes 를 넘으면 어떤 블록에도 들어갈 수 없으므로 접수 단계에서 거절
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	MaxBlockEntries = 2000
+	MaxBlockBytes   = 8 << 20
+)
+
+// 블록 레코드 한도 검증
+func checkBlockLimits(entries []ClinicRecord) error {
+	if len(entries) > MaxBlockEntries {
+		return fmt.Errorf("too many entries: %d > %d", len(entries), MaxBlockEntries)
+	}
+	if n := pendingSize(entries); n > MaxBlockBytes {
+		return fmt.Errorf("block too large: %d > %d bytes", n, MaxBlockBytes)
+	}
+	return nil
+}
+
+// 접수 레코드가 블록 하나에 들어갈 수 있는지 확인
+func checkRecordSize(entries []ClinicRecord) error {
+	for _, rec := range entries {
+		if n := pendingSize([]ClinicRecord{rec}); n > MaxBlockBytes {
+			return fmt.Errorf("record %s too large: %d > %d bytes", rec.ClinicID, n, MaxBlockBytes)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 블록 구간 전송 (Ranged / Resumable Block Transfer)
+// ------------------------------------------------------------
+// - GET /blocks?offset=<int>&limit=<int>
+//   · limit 기본 BlocksPageDefault, 최대 BlocksPageMax
+//   · 응답 본문이 BlocksPageBytes 를 넘으면 그 앞까지만 전송 (최소 1블록) => items 수가 limit 보다 적을 수 있음
+//   · Accept-Encoding: gzip 이면 gzip 압축 (노드 공통 미들웨어, compress.go)
+//   · Accept: application/x-protobuf 이면 protobuf 페이지 (노드 간 동기화, wire.go)
+//   · ETag : "<total>-<최신 블록 해시 앞 16자>" => If-None-Match 가 같으면 304 (장부 변화 없음)
+// - 동기화(syncChain)는 로컬 높이+1 부터 페이지를 이어 받으며 블록마다 검증/저장
+//   · 페이지 요청 실패 시 SyncPageRetries 회 재시도 (1s, 2s, 4s ...)
+//   · 중간에 끊겨도 이미 저장한 블록 다음부터 다시 받음 (다음 동기화 시 로컬 높이 기준)
+//   · 마지막으로 끝까지 받은 피어 ETag 를 기억했다가, 로컬 높이가 그대로면 첫 요청에 If-None-Match 로 사용
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	BlocksPageDefault = 50
+	BlocksPageMax     = 500
+	BlocksPageBytes   = 4 << 20 // 페이지당 블록 JSON 합 (바이트)
+	SyncPageRetries   = 3
+)
+
+// 피어별 마지막 완료 동기화 (그때의 원격 ETag, 로컬 높이)
+type syncMark struct {
+	etag   string
+	height int
+}
+
+var (
+	syncMarks   = make(map[string]syncMark)
+	syncMarksMu sync.Mutex
+)
+
+// 장부 상태 ETag (블록 수 + 최신 블록 해시)
+func blocksETag(rd dbReader, total int) string {
+	tip := ""
+	if total > 0 {
+		if b, err := getBlockByIndexFrom(rd, total-1); err == nil {
+			tip = b.BlockHash
+		}
+	}
+	return fmt.Sprintf(`"%d-%.16s"`, total, tip)
+}
+
+// offset/limit 파싱 (limit 은 기본값/최대값으로 보정)
+func parseBlocksPage(r *http.Request) (offset, limit int, err error) {
+	q := r.URL.Query()
+	if s := q.Get("offset"); s != "" {
+		if offset, err = strconv.Atoi(s); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid offset")
+		}
+	}
+	if s := q.Get("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 0 {
+			return 0, 0, fmt.Errorf("invalid limit")
+		}
+	}
+	if limit == 0 {
+		limit = BlocksPageDefault
+	}
+	return offset, min(limit, BlocksPageMax), nil
+}
+
+// GET /blocks
+func handleBlocks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	offset, limit, err := parseBlocksPage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// 블록 범위를 스냅샷 Iterator로 스캔하며 바로 응답에 기록
+	err = withReadSnapshot(func(rd dbReader) error {
+		total, err := blockTotalFrom(rd)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("list blocks error: %v", err))
+			return nil
+		}
+		etag := blocksETag(rd, total)
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+		if acceptsWire(r) {
+			if err := writeWireBlocksPage(w, rd, offset, limit, total); err != nil {
+				log.Printf("[API] /blocks protobuf page failed: %v", err)
+			}
+			return nil
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := streamBlocksPage(w, rd, offset, limit); err != nil {
+			log.Printf("[API] /blocks stream aborted: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("snapshot error: %v", err))
+	}
+}
+
+// 피어 /blocks 한 페이지 수신 (실패 시 재시도, etag 가 같으면 notModified)
+func fetchBlocksPage(peer string, offset, limit int, etag string) (page blocksPage, tag string, notModified bool, err error) {
+	req, err := http.NewRequest(http.MethodGet, nodeURL(peer, fmt.Sprintf("/blocks?offset=%d&limit=%d", offset, limit)), nil)
+	if err != nil {
+		return page, "", false, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if WireFormat == WireFormatProto {
+		req.Header.Set("Accept", WireContentType+", application/json")
+	}
+	for attempt := 0; ; attempt++ {
+		var resp *http.Response
+		if resp, err = nodeBulkClient.Do(req); err == nil {
+			tag = resp.Header.Get("ETag")
+			switch resp.StatusCode {
+			case http.StatusNotModified:
+				resp.Body.Close()
+				return page, tag, true, nil
+			case http.StatusOK:
+				body := &countingReader{r: resp.Body}
+				if ct, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); ct == WireContentType {
+					page, err = decodeWireBlocksPage(body)
+				} else {
+					err = json.NewDecoder(body).Decode(&page)
+				}
+				resp.Body.Close()
+				recordTraffic(peer, body.n)
+				if err == nil {
+					return page, tag, false, nil
+				}
+			default:
+				resp.Body.Close()
+				err = fmt.Errorf("/blocks status=%d", resp.StatusCode)
+			}
+		}
+		if attempt >= SyncPageRetries {
+			return page, "", false, err
+		}
+		log.Printf("[P2P] /blocks offset=%d from %s failed (%v), retry %d/%d", offset, peer, err, attempt+1, SyncPageRetries)
+		time.Sleep(time.Duration(1<<attempt) * time.Second)
+	}
+}
+
+// 로컬 높이가 마지막 완료 동기화 때와 같으면 그때의 피어 ETag
+func lastSyncETag(peer string, height int) string {
+	syncMarksMu.Lock()
+	defer syncMarksMu.Unlock()
+	if m, ok := syncMarks[peer]; ok && m.height == height {
+		return m.etag
+	}
+	return ""
+}
+
+func setSyncMark(peer, etag string, height int) {
+	if etag == "" {
+		return
+	}
+	syncMarksMu.Lock()
+	defer syncMarksMu.Unlock()
+	syncMarks[peer] = syncMark{etag: etag, height: height}
+}
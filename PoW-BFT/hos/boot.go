@@ -1,11 +1,9 @@
 package main
 
 import (
-	"bytes"
 	"encoding/json"
 	"log"
 	"net/http"
-	"strings"
 	"sync"
 )
 
@@ -16,44 +14,73 @@ import (
 // 신규 노드에게 현재 피어 목록을 제공함
 type registerReq struct {
 	HosID  string `json:"hos_id"`
-	Addr   string `json:"addr"`    // 신규 노드의 접근 주소 (예: "host:port")
-	PubKey string `json:"pub_key"` // 신규 노드의 공개키
+	Addr   string `json:"addr"`           // 신규 노드의 접근 주소 (예: "host:port")
+	PubKey string `json:"pub_key"`        // 신규 노드의 공개키
+	Nonce  string `json:"nonce"`          // /register/challenge 로 발급받은 nonce
+	Sig    string `json:"sig"`            // 신규 노드 키로 서명한 registerDigest (registration.go)
+	Role   string `json:"role,omitempty"` // observer 면 피어 목록/검증자 집합에 넣지 않음 (observer.go)
 }
 type registerResp struct {
 	Peers    []string          `json:"peers"`
 	PeerKeys map[string]string `json:"peer_keys"`
+	CertPins map[string]string `json:"cert_pins,omitempty"` // mTLS 인증서 지문 (노드 주소 => 지문)
 }
 
 // 신규노드가 네트워크 진입 시 부트노드에게 다른 노드들의 주소를 제공받기 위한 함수
 func registerPeer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req registerReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Addr == "" || req.PubKey == "" {
-		http.Error(w, "invalid body", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid body")
 		return
 	}
 
 	// 체인 ID 확인
 	blk0, err := getBlockByIndex(0)
 	if err != nil || blk0.HosID != req.HosID {
-		http.Error(w, "hos_id mismatch", http.StatusForbidden)
+		writeError(w, http.StatusForbidden, "hos_id mismatch")
 		log.Printf("[BOOT] Join denied: hos_id mismatch (%s)", req.HosID)
 		return
 	}
 
-	// 신규 노드 등록
+	// 등록 서명/nonce 확인, 허용 목록 모드에서는 승인 여부 확인
+	if status, err := verifyRegistration(req); err != nil {
+		if status == http.StatusAccepted {
+			writeJSON(w, status, map[string]string{"status": "pending_approval", "pubkey_fingerprint": pubKeyFingerprint(req.PubKey)})
+			return
+		}
+		writeError(w, status, err.Error())
+		log.Printf("[BOOT] Join denied for %s: %v", req.Addr, err)
+		return
+	}
+
+	// mTLS 활성 시 신규 노드가 제시한 인증서 지문을 주소에 고정
+	certPin, err := registrationPin(r)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		log.Printf("[BOOT] Join denied for %s: %v", req.Addr, err)
+		return
+	}
+	pinPeerCert(req.Addr, certPin)
+	observer := req.Role == RoleObserver
+
+	// 신규 노드 등록 (관찰 노드는 명단만 받아 감)
 	peerMu.Lock()
 	pkMu.Lock()
 	// 등록된 주소가 아니라면 추가
-	if !addressYN(req.Addr) {
+	if observer {
+		log.Printf("[P2P][REGISTER] observer synced peer list: %s (hos_id=%s)", req.Addr, req.HosID)
+	} else if !addressYN(req.Addr) {
 		peers = append(peers, req.Addr)
 		log.Printf("[P2P][REGISTER] new peer joined: %s (hos_id=%s) | total=%d", req.Addr, req.HosID, len(peers))
 	}
-	peerPubKeys[req.Addr] = req.PubKey
+	if !observer {
+		setPeerPubKeyLocked(req.Addr, req.PubKey)
+	}
 
 	outPeers := make([]string, 0)
 	outKeys := make(map[string]string)
@@ -72,24 +99,33 @@ func registerPeer(w http.ResponseWriter, r *http.Request) {
 	pkMu.Unlock()
 	peerMu.Unlock()
 
-	// 신규 노드는 peerAliveMap에 초기 상태 초기화
-	markAlive(req.Addr, true)
+	if !observer {
+		// 신규 노드는 peerAliveMap에 초기 상태 초기화
+		markAlive(req.Addr, true)
 
-	// 기존 피어들에게 새로운 노드의 주소와 공개키를 넘김
-	go notifyNewPeerWithKey(req.Addr, req.PubKey)
+		// 기존 피어들에게 새로운 노드의 주소와 공개키를 넘김
+		go notifyNewPeerWithKey(req.Addr, req.PubKey, certPin)
+		// 신규 노드를 장부 검증자 집합에 추가 (validators.go)
+		go reconcileValidatorSet()
+	}
 
 	// 현재까지 등록된 모든 노드의 공개키 맵을 반환
 	resp := registerResp{
 		Peers:    outPeers,
 		PeerKeys: outKeys,
 	}
+	if tlsEnabled {
+		resp.CertPins = certPinsSnapshot()
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
-// 기존 노드들에게 신규 노드의 주소와 공개키를 전파
-func notifyNewPeerWithKey(newAddr, newPubKey string) {
+// 기존 노드들에게 신규 노드의 주소와 공개키를 전파 (부트노드 키로 서명)
+func notifyNewPeerWithKey(newAddr, newPubKey, certPin string) {
+	privPem, _ := nodePrivKey()
+	sig := makeAnchorSignature(privPem, addPeerDigest(newAddr, newPubKey, certPin), "")
 	peerList := peersSnapshot()
 	for _, p := range peerList {
 		if p == newAddr || p == self {
@@ -97,10 +133,13 @@ func notifyNewPeerWithKey(newAddr, newPubKey string) {
 		}
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{
-				"addr":    newAddr,
-				"pub_key": newPubKey,
+				"addr":     newAddr,
+				"pub_key":  newPubKey,
+				"cert_pin": certPin,
+				"boot":     self,
+				"sig":      sig,
 			})
-			_, err := http.Post("http://"+dst+"/addPeer", "application/json", bytes.NewReader(body))
+			err := postPeer(dst, "/addPeer", body)
 			if err != nil {
 				log.Printf("[BOOT] Failed to notify %s about new peer", dst)
 			}
@@ -115,13 +154,15 @@ func notifyNewPeerWithKey(newAddr, newPubKey string) {
 // 부트노드 선출 및 전환
 // 네트워크 상의 모든 노드(peers + self)를 조사
 // 1) 가장 높은 블록 높이를 가진 노드를 찾음
-// 2) 동률이면 주소 사전순으로 가장 앞선 노드를 부트노드로 지정
+// 2) 동률이면 최신 블록 해시 기반 무작위 값으로 선택 (pickBoot)
 // 현재 노드가 그 승자라면 => self를 부트노드로 승격
 // 그렇지 않으면 => 해당 승자를 부트노드로 인식
 func electAndSwitch() {
-	// 후보: peers + self
+	// 후보: peers + self (관찰 노드는 후보가 아님)
 	cand := peersSnapshot()
-	cand = append(cand, self)
+	if !isObserver() {
+		cand = append(cand, self)
+	}
 
 	// 상태 수집
 	type info struct {
@@ -160,28 +201,32 @@ func electAndSwitch() {
 			markAlive(r.ns.Addr, false) // 노드 상태 false로 기록
 		}
 	}
-	// 살아있는 노드가 없다면 자기 자신을 부트로 승격
+	// 살아있는 노드가 없다면 자기 자신을 부트로 승격 (관찰 노드는 기존 부트노드 주소 유지)
+	if len(live) == 0 && isObserver() {
+		log.Printf("[BOOT] no live validators; observer keeps waiting for %s", getBootAddr())
+		return
+	}
 	if len(live) == 0 {
-		isBoot.Store(true)
+		wasBoot := isBoot.Swap(true)
 		setBootAddr(self)
 		log.Printf("[BOOT] no live peers; self-promoted as boot: %s", self)
+		if !wasBoot {
+			go registerWithGov()
+		}
 		return
 	}
 
-	// 부트노드 선정 기준: 높이 최댓값, 동률이면 주소 사전순 최소
-	winner := live[0]
-	for _, x := range live[1:] {
-		if x.Height > winner.Height ||
-			(x.Height == winner.Height && x.Addr < winner.Addr) {
-			winner = x
-		}
-	}
+	// 부트노드 선정 기준: 높이 최댓값, 동률이면 최신 블록 해시 기반 추첨
+	winner := pickBoot(live)
 
 	if winner.Addr == self {
-		isBoot.Store(true)
+		wasBoot := isBoot.Swap(true)
 		setBootAddr(self)
 		broadcastNewBoot(self)
 		log.Printf("[BOOT] elected as new bootnode (height=%d)", winner.Height)
+		if !wasBoot {
+			go registerWithGov() // 새 부트노드 주소를 Gov 에 등록
+		}
 	} else {
 		isBoot.Store(false)
 		setBootAddr(winner.Addr)
@@ -194,7 +239,7 @@ func broadcastNewBoot(newBoot string) {
 	for _, p := range peersSnapshot() {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"addr": newBoot})
-			_, err := http.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
+			err := postPeer(dst, "/bootNotify", body)
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -205,7 +250,7 @@ func broadcastNewBoot(newBoot string) {
 // 부트노드 변경 수신(모든 노드 수행)
 func bootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -214,12 +259,12 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.Addr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.Addr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.Addr)
 		return
 	}
@@ -241,7 +286,7 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 // POST /chgGovBoot
 func chgGovBoot(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -250,12 +295,12 @@ func chgGovBoot(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.GovAddr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.GovAddr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new Gov Boot addr (%s) but not reachable", in.GovAddr)
 		return
 	}
@@ -273,7 +318,7 @@ func broadcastNewGovBoot(govBoot string) {
 		go func(dst string) {
 			log.Printf("[BOOT][Gov] HosBOOT is now sending New GovBootNode's Addr to : %s", dst)
 			body, _ := json.Marshal(map[string]string{"addr": govBoot})
-			_, err := http.Post("http://"+dst+"/govBootNotify", "application/json", strings.NewReader(string(body)))
+			err := postPeer(dst, "/govBootNotify", body)
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -285,7 +330,7 @@ func broadcastNewGovBoot(govBoot string) {
 // POST : /govBootNotify
 func govBootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -294,12 +339,12 @@ func govBootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.GovAddr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 gov 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.GovAddr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.GovAddr)
 		return
 	}
@@ -313,8 +358,12 @@ func govBootNotify(w http.ResponseWriter, r *http.Request) {
 
 func setBootAddr(addr string) {
 	bootAddrMu.Lock()
+	prev := boot
 	boot = addr
 	bootAddrMu.Unlock()
+	if prev != addr {
+		publishEvent(EventBootElected, map[string]any{"boot": addr, "prev": prev, "is_self": addr == self})
+	}
 }
 func getBootAddr() string {
 	bootAddrMu.RLock()
@@ -323,11 +372,48 @@ func getBootAddr() string {
 }
 func setGovBoot(addr string) {
 	govBootMu.Lock()
+	prev := govBoot
 	govBoot = addr
 	govBootMu.Unlock()
+	if prev != addr {
+		retargetAnchorQueue()
+	}
 }
 func getGovBoot() string {
 	govBootMu.RLock()
 	defer govBootMu.RUnlock()
 	return govBoot
 }
+
+// 부트노드 후보 추첨
+// 최고 높이 노드들 중 sha256("boot_election|<seed>|<addr>") 가 가장 작은 노드를 선택
+// seed 는 최고 높이 노드들의 최신 블록 해시 (분기로 다르면 사전순 최소)
+// 살아있는 노드 목록이 같으면 모든 노드가 같은 결과를 얻고,
+// 블록마다 순서가 바뀌므로 주소만으로 당첨을 예측/선점할 수 없음
+func pickBoot(live []nodeStatus) nodeStatus {
+	top := []nodeStatus{}
+	for _, x := range live {
+		if len(top) == 0 || x.Height > top[0].Height {
+			top = []nodeStatus{x}
+		} else if x.Height == top[0].Height {
+			top = append(top, x)
+		}
+	}
+	seed := top[0].LastHash
+	for _, x := range top[1:] {
+		if x.LastHash < seed {
+			seed = x.LastHash
+		}
+	}
+	winner, best := top[0], electionScore(seed, top[0].Addr)
+	for _, x := range top[1:] {
+		if s := electionScore(seed, x.Addr); s < best || (s == best && x.Addr < winner.Addr) {
+			winner, best = x, s
+		}
+	}
+	return winner
+}
+
+func electionScore(seed, addr string) string {
+	return sha256Hex([]byte("boot_election|" + seed + "|" + addr))
+}
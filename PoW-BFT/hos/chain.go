@@ -1,7 +1,7 @@
 package main
 
 import (
-	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"sync"
@@ -17,6 +17,7 @@ type LowerChain struct {
 	hosID         string
 	pending       []ClinicRecord // 아직 블록에 포함되지 않은 Hos 루트 (HosID => Root)
 	pendingMu     sync.Mutex     // pending의 동시성 보장 객체
+	pendingBytes  int            // pending 직렬화 크기 합 (메모리풀 한도, pendinglimit.go)
 	lastBlockTime time.Time      // 마지막 블록 생성 시각
 }
 
@@ -26,7 +27,6 @@ var (
 	chainMu            sync.Mutex   // 내부 체인 상태 보호용 뮤텍스
 	self               string       // 현재 노드 주소 NODE_ADDR (예: "hos-node-01:5000")
 	boot               string       // 현재 네트워크 상의 부트노드 주소
-	proposer           string       // BFT 합의를 위한 리더노드 주소
 	startedAt          = time.Now() // 현재 노드 시작 시간
 	isBoot             atomic.Bool  // 현재 노드가 부트노드인지 여부
 	bootAddrMu         sync.RWMutex // 부트노드 주소 접근 시 동시성 보호용 RW 잠금 객체
@@ -58,14 +58,8 @@ func newLowerChain(hosID string) (*LowerChain, error) {
 		genesis = createGenesisBlock(hosID)
 
 		// 체인에 추가
-		if err := saveBlockToDB(genesis); err != nil {
-			return nil, fmt.Errorf("save genesis block: %w", err)
-		}
-		if err := updateIndicesForBlock(genesis); err != nil {
-			return nil, fmt.Errorf("update genesis indices: %w", err)
-		}
-		if err := setLatestHeight(genesis.Index); err != nil {
-			return nil, fmt.Errorf("set genesis height: %w", err)
+		if err := commitBlock(genesis); err != nil {
+			return nil, fmt.Errorf("commit genesis block: %w", err)
 		}
 
 		ch.lastBlockTime = time.Now()
@@ -78,6 +72,17 @@ func newLowerChain(hosID string) (*LowerChain, error) {
 		return nil, err
 	}
 
+	// 재시작 전 확정되지 못한 메모리풀 레코드 복원
+	restored, err := loadPendingFromDB()
+	if err != nil {
+		return nil, fmt.Errorf("load pending: %w", err)
+	}
+	ch.pending = restored
+	ch.pendingBytes = pendingSize(restored)
+	if len(restored) > 0 {
+		log.Printf("[INIT] Restored %d pending entries from LevelDB", len(restored))
+	}
+
 	return ch, nil
 }
 
@@ -93,21 +98,16 @@ func onBlockReceived(lb LowerBlock) error {
 		log.Printf("[CHAIN] Block #%d already processed. Skipping.", lb.Index)
 		return nil
 	}
-	// 로컬 장부 반영 (DB 저장)
-	if err := saveBlockToDB(lb); err != nil {
-		log.Printf("[CHAIN][ERROR] saveBlockToDB failed: %v", err)
-		return fmt.Errorf("save block: %w", err)
-	}
-
-	// 인덱스 및 최신 높이 업데이트
-	if err := updateIndicesForBlock(lb); err != nil {
-		return fmt.Errorf("update indices: %w", err)
-	}
-	if err := setLatestHeight(lb.Index); err != nil {
-		return fmt.Errorf("set height: %w", err)
+	// 로컬 장부 반영 (블록 본문 + 인덱스 + 최신 높이를 한 번에 저장)
+	if err := commitBlock(lb); err != nil {
+		log.Printf("[CHAIN][ERROR] commitBlock failed: %v", err)
+		return fmt.Errorf("commit block: %w", err)
 	}
 	ch.lastBlockTime = time.Now()
 
+	// 확정된 레코드는 메모리풀(메모리/LevelDB)에서 제거
+	removePendingFinalized(lb.LeafHashes)
+
 	// 합의 상태 초기화
 	consensusInProgress.Store(false)
 
@@ -123,88 +123,172 @@ func onBlockReceived(lb LowerBlock) error {
 
 // 블록 내 2f+1개 이상의 유효한 서명이 있는지 확인
 func verifyConsensusEvidence(lb LowerBlock) error {
-	// 1. 정족수 계산
-	peers := peersSnapshot()
-	n := len(peers) + 1 // 피어들 + 나(Self)
-	f := (n - 1) / 3
-	required := 2*f + 1
+	// 1. 정족수 계산 (블록 높이의 장부 검증자 집합 기준, validators.go)
+	required := quorumAt(lb.Index)
 
 	// 서명 개수 자체가 부족하면 즉시 리턴
 	if len(lb.Signatures) < required {
 		return fmt.Errorf("insufficient signatures: %d/%d", len(lb.Signatures), required)
 	}
 
-	// 2. 검증할 메시지 해시 생성 (블록 해시 기준)
-	msgHash := sha256.Sum256([]byte(lb.BlockHash))
-
-	validCount := 0
-	checkedPeers := make(map[string]bool) // 동일 노드의 중복 서명 방지용
-
-	// 3. 서명 슬라이스 순회 (여기서 addr은 인덱스 int입니다)
-	for _, sigHex := range lb.Signatures {
-		found := false
+	// 2. 검증할 메시지 해시 (합의 단계에서 서명한 블록 해시 원문)
+	msgHash, _ := hex.DecodeString(lb.BlockHash)
 
-		// 내 서명인지 먼저 확인 (가장 빠름)
-		myPubKey, _ := getMeta("meta_hos_pubkey")
-		if !checkedPeers[self] && verifyECDSA(myPubKey, msgHash[:], sigHex) {
-			validCount++
-			checkedPeers[self] = true
-			found = true
-		}
+	// 3. 검증자별 유효 서명 수
+	validCount := countValidSignatures(lb, msgHash, validatorKeysAt(lb.Index))
 
-		// 내 서명이 아니라면 피어들 명단에서 대조
-		if !found {
-			for _, pAddr := range peers {
-				if checkedPeers[pAddr] {
-					continue // 이미 검증 완료된 피어는 스킵
-				}
+	// 4. 유효 정족수 최종 확인
+	if validCount < required {
+		return fmt.Errorf("valid signatures insufficient: %d/%d (required %d)", validCount, required, required)
+	}
 
-				pubPem := peerPubKeys[pAddr]
-				if pubPem == "" {
-					continue
-				}
+	log.Printf("[BFT] Block #%d verified with %d valid signatures", lb.Index, validCount)
+	return nil
+}
 
-				// ECDSA 대조 연산 (CPU 집약적)
-				if verifyECDSA(pubPem, msgHash[:], sigHex) {
-					validCount++
-					checkedPeers[pAddr] = true
-					found = true
+// 블록 서명 중 keys(주소 => 공개키) 검증자의 유효 서명 수 (노드당 1개)
+// - 서명마다 명시된 서명자(Addr)의 공개키로만 검증, 지문이 현재 공개키와 다르면 교체 전 공개키 이력에서 조회 (없으면 무효)
+// - 서명자 정보가 없는 기존 블록 서명만 모든 공개키와 대조
+func countValidSignatures(b LowerBlock, hash []byte, keys map[string]string) int {
+	signed := make(map[string]bool) // 동일 노드의 중복 서명 방지용
+	for _, s := range b.Signatures {
+		if s.Addr == "" {
+			for addr, pub := range keys {
+				if !signed[addr] && verifyECDSA(pub, hash, s.Sig) {
+					signed[addr] = true
 					break // 이 서명의 주인을 찾았으므로 다음 서명으로
 				}
 			}
+			continue
+		}
+		pub, ok := keys[s.Addr]
+		if !ok || signed[s.Addr] {
+			continue // 이 높이의 검증자 집합에 없는 서명자(탈퇴/제재된 노드 포함)는 세지 않음
+		}
+		if s.KeyFP != "" && s.KeyFP != pubKeyFingerprint(pub) {
+			pub = historicalPubKey(s.Addr, s.KeyFP) // 키 교체 전에 서명된 블록 (validators.go)
+		}
+		if pub == "" {
+			continue
+		}
+		if verifyECDSA(pub, hash, s.Sig) {
+			signed[s.Addr] = true
 		}
 	}
+	return len(signed)
+}
 
-	// 4. 유효 정족수 최종 확인
-	if validCount < required {
-		return fmt.Errorf("valid signatures insufficient: %d/%d (required %d)", validCount, required, required)
-	}
+// 체인의 메모리풀인 pending에 컨텐츠 내용 추가
+// LevelDB에 먼저 기록한 후 메모리에 반영 (재시작 시 유실 방지)
+// 이미 접수/확정된 레코드는 조용히 제외 (동시 요청 대비 재확인, dedup.go)
+//   - 메모리풀 한도를 넘으면 *PendingFullError (drop-oldest 정책이면 오래된 레코드를 밀어내고 접수)
+func appendPending(entries []ClinicRecord) error {
+	return addPending(entries, true)
+}
 
-	log.Printf("[BFT] Block #%d verified with %d valid signatures", lb.Index, validCount)
-	return nil
+// 분기 교체로 되돌린 블록의 레코드를 메모리풀로 복구 (이미 접수된 레코드이므로 한도 무시)
+func requeuePending(entries []ClinicRecord) error {
+	return addPending(entries, false)
 }
 
-// 체인의 메모리풀인 pending에 컨텐츠 내용 추가
-func appendPending(entries []ClinicRecord) {
+func addPending(entries []ClinicRecord, limited bool) error {
 	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	if entries, _ = filterReplays(entries); len(entries) == 0 {
+		return nil
+	}
+	size := pendingSize(entries)
+	evict := 0
+	if limited {
+		n, err := pendingEvictCount(len(entries), size)
+		if err != nil {
+			return err
+		}
+		evict = n
+	}
+	if err := savePendingToDB(entries); err != nil {
+		log.Printf("[CHAIN][PENDING][ERROR] write-ahead failed: %v", err)
+		return err
+	}
+	evictPending(evict)
 	ch.pending = append(ch.pending, entries...)
-	ch.pendingMu.Unlock()
+	ch.pendingBytes += size
 	log.Printf("[CHAIN][PENDING] Append pending entries (%d items)", len(entries))
+	return nil
+}
+
+// 블록에 포함되어 확정된 레코드를 메모리풀에서 제거
+func removePendingFinalized(leafHashes []string) {
+	if len(leafHashes) == 0 {
+		return
+	}
+	finalized := make(map[string]bool, len(leafHashes))
+	for _, h := range leafHashes {
+		finalized[h] = true
+	}
+
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	kept := ch.pending[:0]
+	for _, rec := range ch.pending {
+		if !finalized[hashClinicRecord(rec)] {
+			kept = append(kept, rec)
+		} else {
+			ch.pendingBytes -= pendingSize([]ClinicRecord{rec})
+		}
+	}
+	ch.pending = kept
+
+	n, err := clearPendingFromDB(leafHashes)
+	if err != nil {
+		log.Printf("[CHAIN][PENDING][ERROR] clear finalized entries failed: %v", err)
+		return
+	}
+	log.Printf("[CHAIN][PENDING] Cleared %d finalized entries from LevelDB", n)
 }
 
-// 체인의 메모리풀인 pending에 컨텐츠 내용 비우고 가져오기
+// 체인의 메모리풀인 pending에서 다음 블록에 포함할 컨텐츠 가져오기
+// 우선순위 순으로 정렬, 환자별 한도를 넘는 레코드는 메모리풀에 남김 (priority.go)
 func popPending() []ClinicRecord {
 	ch.pendingMu.Lock()
 	defer ch.pendingMu.Unlock()
-	// 복사본 생성
+	// 복사본 생성 (이미 다른 블록에 확정된 레코드 제외)
 	entries := make([]ClinicRecord, len(ch.pending))
 	copy(entries, ch.pending)
-	// 원본 비우기
-	ch.pending = []ClinicRecord{}
+	entries, deferred := selectForBlock(dropCommitted(entries))
+	// 원본에는 다음 블록으로 미룬 레코드만 유지
+	ch.pending = deferred
+	ch.pendingBytes = pendingSize(deferred)
 	log.Printf("[CHAIN][PENDING] Pop pending entries (%d items)", len(entries))
 	return entries
 }
 
+// 확정되지 않은 제안의 레코드를 메모리풀로 복구 (LevelDB 에는 확정 전까지 남아 있으므로 메모리만 갱신)
+// 이미 확정되었거나 메모리풀에 있는 레코드는 제외
+func restorePending(entries []ClinicRecord) {
+	if len(entries) == 0 {
+		return
+	}
+	entries = dropCommitted(append([]ClinicRecord(nil), entries...))
+
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	present := make(map[string]bool, len(ch.pending))
+	for _, rec := range ch.pending {
+		present[hashClinicRecord(rec)] = true
+	}
+	restored := 0
+	for _, rec := range entries {
+		if h := hashClinicRecord(rec); !present[h] {
+			present[h] = true
+			ch.pending = append(ch.pending, rec)
+			ch.pendingBytes += pendingSize([]ClinicRecord{rec})
+			restored++
+		}
+	}
+	log.Printf("[CHAIN][PENDING] Restored %d entries from an unfinalized proposal", restored)
+}
+
 // 메모리풀의 엔트리 개수 확인
 func getPendingCnt() int {
 	ch.pendingMu.Lock()
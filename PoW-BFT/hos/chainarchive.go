@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Chain Archive Export / Import (이식 가능한 블록 아카이브 파일)
+// ------------------------------------------------------------
+// - GET /admin/export?from=<n> : 블록을 NDJSON(한 줄에 JSON 하나)으로 스트리밍
+//   · 1행 : ChainArchiveManifest (archive="hos-chain", chain_id, 제네시스/최신 블록 해시, 구간)
+//   · 2행부터 : from 번 블록부터 최신 블록까지 저장된 LowerBlock JSON 그대로 한 줄씩
+//   · 하나의 읽기 스냅샷에서 기록하므로 내보내는 중 새 블록이 붙어도 구간이 섞이지 않음
+// - POST /admin/import : 본문으로 받은 아카이브를 검증하며 로컬 체인에 이어 붙임 (새 노드 이관용)
+//   · 매니페스트의 chain_id / 제네시스 해시가 로컬 제네시스와 같아야 함 (다르면 409)
+//   · 로컬에 이미 있는 번호는 해시가 같으면 건너뜀 (다르면 409), 다음 번호부터 블록마다
+//     validateLowerBlock (연결/머클 루트/해시/상주 규칙) + 장부에 검증자 집합이 기록된 높이는 합의 서명 정족수 확인
+//   · 실패 지점까지 반영된 블록은 유효하므로 남겨 둠 => 같은 파일로 다시 가져오면 이어서 진행
+//   · 끝까지 받은 뒤 최신 블록 번호/해시가 매니페스트와 다르면 422 (잘린 파일)
+//   · 합의 진행 중에는 409
+// - 오프라인 감사 : 같은 파일을 REPLAY_SOURCE=file:<path> 로 재생 가능 (replay.go)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ChainArchiveFormat  = "hos-chain"
+	ChainArchiveVersion = 1
+	ChainArchiveLineMax = 64 << 20 // 아카이브 한 줄(블록 JSON) 최대 크기
+)
+
+// 아카이브 첫 줄
+type ChainArchiveManifest struct {
+	Archive     string `json:"archive"`
+	Version     int    `json:"version"`
+	ChainID     string `json:"chain_id"`
+	GenesisHash string `json:"genesis_hash"`
+	From        int    `json:"from"`
+	Height      int    `json:"height"`
+	BlockHash   string `json:"block_hash"`
+	Node        string `json:"node"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// 가져오기 결과
+type ImportResult struct {
+	Status    string `json:"status"`
+	Imported  int    `json:"imported"` // 새로 반영한 블록 수
+	Skipped   int    `json:"skipped"`  // 로컬에 이미 있던 블록 수
+	Height    int    `json:"height"`
+	BlockHash string `json:"block_hash"`
+}
+
+// 가져오기 중단 지점
+type importError struct {
+	status int
+	code   string
+	index  int
+	err    error
+}
+
+func (e *importError) Error() string { return fmt.Sprintf("block #%d: %v", e.index, e.err) }
+
+// GET /admin/export
+func handleAdminExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	from := 0
+	if v := r.URL.Query().Get("from"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid from")
+			return
+		}
+		from = n
+	}
+	started, blocks := false, 0
+	err := withReadSnapshot(func(rd dbReader) error {
+		m, err := chainArchiveManifest(rd, from)
+		if err != nil {
+			return err
+		}
+		line, _ := json.Marshal(m)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%d-%d.ndjson"`, m.ChainID, m.From, m.Height))
+		started = true
+		bw := bufio.NewWriter(w)
+		if _, err := bw.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		err = scanBlocksFrom(rd, from, m.Height, func(raw []byte) error {
+			blocks++
+			if _, err := bw.Write(raw); err != nil {
+				return err
+			}
+			return bw.WriteByte('\n')
+		})
+		if err != nil {
+			return err
+		}
+		return bw.Flush()
+	})
+	if err != nil {
+		if !started {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("export error: %v", err))
+			return
+		}
+		log.Printf("[ARCHIVE][ERROR] export stream aborted after %d blocks: %v", blocks, err)
+		return
+	}
+	log.Printf("[ARCHIVE] exported %d blocks from #%d", blocks, from)
+}
+
+// 스냅샷 기준 아카이브 매니페스트
+func chainArchiveManifest(rd dbReader, from int) (ChainArchiveManifest, error) {
+	m := ChainArchiveManifest{Archive: ChainArchiveFormat, Version: ChainArchiveVersion, From: from, Node: self,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339)}
+	h, ok := getLatestHeightFrom(rd)
+	if !ok {
+		return m, fmt.Errorf("no chain")
+	}
+	if from > h {
+		return m, fmt.Errorf("from %d beyond height %d", from, h)
+	}
+	genesis, err := getBlockByIndexFrom(rd, 0)
+	if err != nil {
+		return m, fmt.Errorf("load genesis: %w", err)
+	}
+	tip, err := getBlockByIndexFrom(rd, h)
+	if err != nil {
+		return m, fmt.Errorf("load block #%d: %w", h, err)
+	}
+	m.ChainID, m.GenesisHash, m.Height, m.BlockHash = genesis.HosID, genesis.BlockHash, h, tip.BlockHash
+	return m, nil
+}
+
+// 아카이브 첫 줄 읽기 + 형식 확인
+func readChainArchiveManifest(sc *bufio.Scanner) (ChainArchiveManifest, error) {
+	var m ChainArchiveManifest
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return m, err
+		}
+		return m, fmt.Errorf("empty archive")
+	}
+	if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+		return m, fmt.Errorf("manifest: %w", err)
+	}
+	if m.Archive != ChainArchiveFormat {
+		return m, fmt.Errorf("not a %s archive (%q)", ChainArchiveFormat, m.Archive)
+	}
+	if m.Version != ChainArchiveVersion {
+		return m, fmt.Errorf("unsupported archive version %d", m.Version)
+	}
+	return m, nil
+}
+
+func newChainArchiveScanner(r io.Reader) *bufio.Scanner {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 1<<20), ChainArchiveLineMax)
+	return sc
+}
+
+// POST /admin/import (본문 : /admin/export 가 만든 NDJSON)
+func handleAdminImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	defer r.Body.Close()
+	if consensusInProgress.Load() {
+		writeError(w, http.StatusConflict, "consensus in progress")
+		return
+	}
+	sc := newChainArchiveScanner(r.Body)
+	m, err := readChainArchiveManifest(sc)
+	if err != nil {
+		writeErrorDetail(w, http.StatusBadRequest, "invalid_archive", err.Error(), nil)
+		return
+	}
+	genesis, err := getBlockByIndex(0)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("load genesis: %v", err))
+		return
+	}
+	if genesis.HosID != m.ChainID || genesis.BlockHash != m.GenesisHash {
+		writeErrorDetail(w, http.StatusConflict, "chain_mismatch", "archive is from a different chain",
+			map[string]string{"chain_id": m.ChainID, "expected": genesis.HosID})
+		return
+	}
+
+	syncInProgress.Store(true) // 가져오는 동안 비핵심 요청 제한 (loadshed.go)
+	res, err := importChainArchive(sc, m)
+	syncInProgress.Store(false)
+	if res.Imported > 0 {
+		reloadRestoredState() // 검증자 캐시/메모리풀 재적재 (backup.go)
+	}
+	if err != nil {
+		var ie *importError
+		if errors.As(err, &ie) {
+			log.Printf("[ARCHIVE][ERROR] import stopped at #%d after %d blocks: %v", ie.index, res.Imported, ie.err)
+			writeErrorDetail(w, ie.status, ie.code, ie.Error(), res)
+			return
+		}
+		writeErrorDetail(w, http.StatusBadRequest, "invalid_archive", err.Error(), res)
+		return
+	}
+	log.Printf("[ARCHIVE] imported %d blocks (skipped %d) up to #%d from %s archive taken at %s",
+		res.Imported, res.Skipped, res.Height, m.Node, m.CreatedAt)
+	writeJSON(w, http.StatusOK, res)
+}
+
+// 아카이브 블록을 순서대로 검증/반영
+func importChainArchive(sc *bufio.Scanner, m ChainArchiveManifest) (ImportResult, error) {
+	res := ImportResult{Status: "imported", Height: -1}
+	next := m.From
+	for sc.Scan() {
+		var b LowerBlock
+		if err := json.Unmarshal(sc.Bytes(), &b); err != nil {
+			return res, fmt.Errorf("block line %d: %w", next-m.From+2, err)
+		}
+		if b.Index != next {
+			return res, &importError{http.StatusUnprocessableEntity, "archive_gap", b.Index, fmt.Errorf("expected block #%d", next)}
+		}
+		skipped, err := importBlock(b)
+		if err != nil {
+			return res, err
+		}
+		if skipped {
+			res.Skipped++
+		} else {
+			res.Imported++
+		}
+		res.Height, res.BlockHash = b.Index, b.BlockHash
+		next++
+	}
+	if err := sc.Err(); err != nil {
+		return res, err
+	}
+	if res.Height != m.Height || res.BlockHash != m.BlockHash {
+		return res, &importError{http.StatusUnprocessableEntity, "archive_truncated", res.Height,
+			fmt.Errorf("archive ends before manifest tip #%d", m.Height)}
+	}
+	return res, nil
+}
+
+// 블록 하나 반영 (이미 같은 블록이 있으면 skipped)
+func importBlock(b LowerBlock) (skipped bool, err error) {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	h, _ := getLatestHeight()
+	if b.Index <= h {
+		local, err := getBlockByIndex(b.Index)
+		if err != nil {
+			return false, &importError{http.StatusInternalServerError, "load_failed", b.Index, err}
+		}
+		if local.BlockHash != b.BlockHash {
+			return false, &importError{http.StatusConflict, "chain_diverged", b.Index,
+				fmt.Errorf("local block hash %.12s differs from archive %.12s", local.BlockHash, b.BlockHash)}
+		}
+		return true, nil
+	}
+	if b.Index != h+1 {
+		return false, &importError{http.StatusConflict, "archive_gap", b.Index, fmt.Errorf("local height is %d", h)}
+	}
+	prev, err := getBlockByIndex(h)
+	if err != nil {
+		return false, &importError{http.StatusInternalServerError, "load_failed", b.Index, err}
+	}
+	if err := validateLowerBlock(b, prev); err != nil {
+		return false, &importError{http.StatusUnprocessableEntity, "invalid_block", b.Index, err}
+	}
+	// 등록 노드 기준 집합은 이 노드의 피어 구성이라 원본 네트워크와 다를 수 있으므로 장부 집합이 있는 높이만 확인
+	if validatorSnapshotAt(b.Index).set != nil {
+		if err := verifyConsensusEvidence(b); err != nil {
+			return false, &importError{http.StatusUnprocessableEntity, "invalid_evidence", b.Index, err}
+		}
+	}
+	if err := commitBlock(b); err != nil {
+		return false, &importError{http.StatusInternalServerError, "commit_failed", b.Index, err}
+	}
+	return false, nil
+}
+
+// 아카이브의 블록 전체 (재생 모드 파일 소스, replay.go)
+func readChainArchive(r io.Reader) (ChainArchiveManifest, []LowerBlock, error) {
+	sc := newChainArchiveScanner(r)
+	m, err := readChainArchiveManifest(sc)
+	if err != nil {
+		return m, nil, err
+	}
+	var blocks []LowerBlock
+	for sc.Scan() {
+		var b LowerBlock
+		if err := json.Unmarshal(sc.Bytes(), &b); err != nil {
+			return m, nil, fmt.Errorf("block line %d: %w", len(blocks)+2, err)
+		}
+		blocks = append(blocks, b)
+	}
+	return m, blocks, sc.Err()
+}
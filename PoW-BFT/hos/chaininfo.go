@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Chain Info (체인 식별 정보)
+// ------------------------------------------------------------
+// - GET /chain/info : 이 체인의 식별 정보를 한 번에 반환
+//   · chain_id / genesis_hash / genesis_timestamp : 제네시스 블록 기준 체인 신원
+//   · consensus          : 합의 방식 (Hos 체인은 pbft)
+//   · hash_profile       : 레코드/머클 해시 규칙 버전 (crypto_merkle.go)
+//   · validator_set_hash : 현재 검증자(자신 + 피어) 주소 => 공개키 맵의 해시
+//   · protocol_version   : 노드 간 블록/합의 메시지 규격 버전
+// - 사용처
+//   · 동기화 대상 선택 : 제네시스 해시가 다른 피어는 제외 (region.go pickSyncPeer)
+//   · Gov 가입 신청    : Gov 가 hos_boot 의 chain_id 를 신청 hos_id 와 대조
+// - 노드 간 요청 헤더 X-Chain-ID : 보내는 노드의 제네시스 블록 해시 (peerTransport 가 모든 요청에 기록)
+//   · chain_id(hos_id)는 구성값이라 같은 이름으로 새로 만든 체인과 구분되지 않음 => 제네시스 해시로 체인 식별
+//   · 같은 체인 노드끼리만 주고받는 엔드포인트(/bft/*, /pending/relay, /addPeer 등)는 requireSameChain 으로
+//     헤더가 없거나 제네시스 해시가 다른 요청을 본문 처리 전에 거절 (같은 구성의 다른 체인 트래픽 혼입 방지)
+//   · Gov 등 다른 체인과 주고받는 엔드포인트는 확인하지 않음
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ProtocolVersion    = 2                          // 2 : salt 레코드의 필드별 머클 leaf (disclosure.go)
+	HashProfileVersion = "sha256-canonical-json-v2" // SHA-256 + 키 정렬 JSON + merkle.PairHash 머클 (+ 필드 트리 leaf)
+	ChainIDHeader      = "X-Chain-ID"
+	ConsensusType      = "pbft"
+)
+
+type ChainInfo struct {
+	ChainID          string `json:"chain_id"`
+	GenesisHash      string `json:"genesis_hash"`
+	GenesisTimestamp string `json:"genesis_timestamp"`
+	Consensus        string `json:"consensus"`
+	HashProfile      string `json:"hash_profile"`
+	ValidatorSetHash string `json:"validator_set_hash"`
+	Validators       int    `json:"validators"`
+	ProtocolVersion  int    `json:"protocol_version"`
+	Height           int    `json:"height"`
+}
+
+// 현재 검증자 집합 (주소 => 공개키 PEM, 자신 포함)
+func validatorKeys() map[string]string {
+	keys := map[string]string{}
+	if pub, ok := getMeta("meta_hos_pubkey"); ok {
+		keys[self] = pub
+	}
+	pkMu.RLock()
+	for addr, pub := range peerPubKeys {
+		keys[addr] = pub
+	}
+	pkMu.RUnlock()
+	return keys
+}
+
+func buildChainInfo() (ChainInfo, error) {
+	genesis, err := getBlockByIndex(0)
+	if err != nil {
+		return ChainInfo{}, fmt.Errorf("no genesis: %w", err)
+	}
+	height, _ := getLatestHeight()
+	keys := validatorKeysAt(height + 1)
+	return ChainInfo{
+		ChainID:          genesis.HosID,
+		GenesisHash:      genesis.BlockHash,
+		GenesisTimestamp: genesis.Timestamp,
+		Consensus:        ConsensusType,
+		HashProfile:      HashProfileVersion,
+		ValidatorSetHash: sha256Hex(jsonCanonical(keys)),
+		Validators:       len(keys),
+		ProtocolVersion:  ProtocolVersion,
+		Height:           height,
+	}, nil
+}
+
+// GET /chain/info
+func handleChainInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	info, err := buildChainInfo()
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// 노드 주소의 체인 식별 정보 조회
+func fetchChainInfo(addr string) (ChainInfo, error) {
+	var info ChainInfo
+	resp, err := nodeClient.Get(nodeURL(addr, "/chain/info"))
+	if err != nil {
+		return info, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return info, fmt.Errorf("status=%d", resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&info)
+	return info, err
+}
+
+// 피어가 같은 체인(제네시스/프로토콜)인지 확인
+// - 로컬 제네시스가 없거나 피어가 /chain/info 를 제공하지 않으면 판단하지 않음(true)
+func sameChain(addr string) bool {
+	local, err := getBlockByIndex(0)
+	if err != nil {
+		return true
+	}
+	info, err := fetchChainInfo(addr)
+	if err != nil {
+		return true
+	}
+	return info.GenesisHash == local.BlockHash && info.ProtocolVersion == ProtocolVersion
+}
+
+// X-Chain-ID 값 : 로컬 제네시스 블록 해시 (제네시스가 없으면 "")
+func localChainID() string {
+	genesis, err := getBlockByIndex(0)
+	if err != nil {
+		return ""
+	}
+	return genesis.BlockHash
+}
+
+// 노드 간 요청에 이 노드의 체인 식별값 기록 (peerTransport 에서 호출)
+func stampChainID(req *http.Request) *http.Request {
+	if req.Header.Get(ChainIDHeader) != "" {
+		return req
+	}
+	id := localChainID()
+	if id == "" {
+		return req
+	}
+	out := req.Clone(req.Context())
+	out.Header.Set(ChainIDHeader, id)
+	return out
+}
+
+// 같은 체인 노드 전용 엔드포인트 보호 : X-Chain-ID 가 없거나 로컬 제네시스 해시와 다른 요청 거절
+func requireSameChain(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, local := r.Header.Get(ChainIDHeader), localChainID()
+		if local == "" {
+			writeError(w, http.StatusServiceUnavailable, "no genesis")
+			return
+		}
+		if id != local {
+			log.Printf("[P2P][DENY] %s from %s: chain_id=%q (local=%q)", r.URL.Path, r.RemoteAddr, id, local)
+			writeErrorDetail(w, http.StatusForbidden, "chain_mismatch", "request is missing "+ChainIDHeader+" or is from a different chain",
+				map[string]string{"chain_id": id, "expected": local})
+			return
+		}
+		h(w, r)
+	}
+}
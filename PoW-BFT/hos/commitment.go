@@ -0,0 +1,145 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Chain Commitment (체인 상태 집계 커밋먼트)
+// ------------------------------------------------------------
+// - 감사용 단일 커밋먼트 : 높이, 최신 블록 해시, 지금까지의 모든 블록 해시에 대한 Merkle Root
+// - 블록 해시 Merkle Root는 전체 재계산 없이 블록 추가 시 증분 갱신
+//   · 크기 2^k 완전 이진 트리의 루트(peak)만 레벨별로 보관 ("commit_peaks")
+//   · 루트 = 높은 레벨 peak부터 pairHash로 접어서 계산
+// - 블록 반영(commitBlock)과 같은 Batch로 갱신되며, 노드 개인키로 서명하여 "commit_latest"에 보관
+////////////////////////////////////////////////////////////////////////////////
+
+type ChainCommitment struct {
+	Height     int    `json:"height"`      // 최신 블록 번호
+	TipHash    string `json:"tip_hash"`    // 최신 블록 해시
+	BlocksRoot string `json:"blocks_root"` // 0..Height 블록 해시들의 Merkle Root
+	Signer     string `json:"signer"`      // 서명한 노드 주소 (공개키 : /getPublicKey)
+	Sig        string `json:"sig"`         // 노드 ECDSA 서명 (hex, DER)
+}
+
+// 서명 대상 다이제스트 (hex, 서명 필드 제외)
+func (c ChainCommitment) digest() string {
+	body := struct {
+		Height     int    `json:"height"`
+		TipHash    string `json:"tip_hash"`
+		BlocksRoot string `json:"blocks_root"`
+		Signer     string `json:"signer"`
+	}{c.Height, c.TipHash, c.BlocksRoot, c.Signer}
+	sum := sha256.Sum256(jsonCanonical(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// 증분 Merkle 누적기 (peaks[k] = 크기 2^k 서브트리 루트, 없으면 "")
+type merkleAccumulator struct {
+	Count int      `json:"count"`
+	Peaks []string `json:"peaks"`
+}
+
+func (a *merkleAccumulator) add(leaf string) {
+	carry := leaf
+	for k := 0; ; k++ {
+		if k == len(a.Peaks) {
+			a.Peaks = append(a.Peaks, "")
+		}
+		if a.Peaks[k] == "" {
+			a.Peaks[k] = carry
+			break
+		}
+		carry = merkle.PairHash(a.Peaks[k], carry)
+		a.Peaks[k] = ""
+	}
+	a.Count++
+}
+
+func (a *merkleAccumulator) root() string {
+	root := ""
+	for k := len(a.Peaks) - 1; k >= 0; k-- {
+		if a.Peaks[k] == "" {
+			continue
+		}
+		if root == "" {
+			root = a.Peaks[k]
+		} else {
+			root = merkle.PairHash(root, a.Peaks[k])
+		}
+	}
+	if root == "" {
+		return sha256Hex([]byte{})
+	}
+	return root
+}
+
+func loadAccumulator(rd kvReader, key string) merkleAccumulator {
+	var a merkleAccumulator
+	if v, err := rd.Get([]byte(key), nil); err == nil {
+		_ = json.Unmarshal(v, &a)
+	}
+	return a
+}
+
+// 블록 반영 시 커밋먼트 갱신 (stageBlock 의 트랜잭션에 함께 기록)
+// - 기능 도입 이전 장부라면 누락된 블록 해시부터 채워 넣음
+func appendCommitment(tx *ledgerTxn, block LowerBlock) error {
+	acc := loadAccumulator(tx, "commit_peaks")
+	if block.Index < acc.Count {
+		// 이미 반영된 높이 (재처리)
+		return nil
+	}
+	for i := acc.Count; i < block.Index; i++ {
+		b, err := getBlockByIndexFrom(tx, i)
+		if err != nil {
+			return fmt.Errorf("commitment catch-up block #%d: %w", i, err)
+		}
+		acc.add(b.BlockHash)
+	}
+	acc.add(block.BlockHash)
+
+	c := ChainCommitment{
+		Height:     block.Index,
+		TipHash:    block.BlockHash,
+		BlocksRoot: acc.root(),
+		Signer:     self,
+	}
+	if privPem, ok := nodePrivKey(); ok {
+		c.Sig = makeAnchorSignature(privPem, c.digest(), "")
+	}
+
+	accData, _ := json.Marshal(acc)
+	cData, _ := json.Marshal(c)
+	tx.Put([]byte("commit_peaks"), accData)
+	tx.Put([]byte("commit_latest"), cData)
+	return nil
+}
+
+// 체인 상태 커밋먼트 조회
+// GET /commitment
+func handleCommitment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	v, ok := getMeta("commit_latest")
+	if !ok {
+		writeError(w, http.StatusNotFound, "commitment not available")
+		return
+	}
+	var c ChainCommitment
+	if err := json.Unmarshal([]byte(v), &c); err != nil {
+		log.Printf("[COMMIT][ERROR] invalid stored commitment: %v", err)
+		writeError(w, http.StatusInternalServerError, "invalid commitment")
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Dashboard (내장 실시간 대시보드)
+// ------------------------------------------------------------
+// - GET /dashboard : static/dashboard.html 을 바이너리에 포함해 제공 (./static 없이 배포해도 동작)
+// - 새 데이터 API 없이 기존 조회 API 와 이벤트 스트림만 사용
+//   · /v1/meta       : node_role(hos/gov) + role(validator/observer) => 패널 구성 결정
+//   · /status, /chain/info, /blocks/recent, /pending, /peers?detail=true
+//   · /consensus/state : 진행 중인 PBFT view 의 단계/라운드/투표 수 (bft.go)
+//   · /anchor/queue    : Gov 제출 대기 앵커 (anchor_queue.go)
+//   · /events (SSE)    : 블록 확정/앵커/부트노드/피어 이벤트마다 해당 패널을 다시 읽고 이벤트 로그에 추가
+// - Gov 노드도 같은 HTML 을 내장 => Hos/Gov 의 검증자·관찰 노드 네 종류 모두 /dashboard 로 확인
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed static/dashboard.html
+var dashboardHTML []byte
+
+// GET /dashboard
+func handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(dashboardHTML)
+}
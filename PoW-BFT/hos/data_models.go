@@ -26,6 +26,20 @@ type ClinicRecord struct {
 	PrescCode string                 `json:"presc_code"`           // 처방 코드
 	ClinicHis map[string]interface{} `json:"clinic_his,omitempty"` // 진료 기록
 	Timestamp string                 `json:"timestamp"`            // 생성 시각
+	Residency string                 `json:"residency,omitempty"`  // 상주 리전 (지정 시 해당 리전 서브 장부에만 기록)
+	Priority  int                    `json:"priority,omitempty"`   // 블록 포함 우선순위 0~9, 높을수록 먼저 (priority.go)
+
+	Revocation *RevocationRecord `json:"revocation,omitempty"` // 철회 레코드(툼스톤)일 때만 설정 (revoke.go)
+	Sealed     *SealedPHI        `json:"sealed,omitempty"`     // 암호화된 Info/ClinicHis (이때 Info/ClinicHis 는 비움, phi.go)
+	Salt       string            `json:"salt,omitempty"`       // 필드별 머클 leaf 용 레코드 salt (접수 시 부여, disclosure.go)
+	Validator  *ValidatorChange  `json:"validator,omitempty"`  // 검증자 변경 레코드일 때만 설정 (validators.go)
+	Evidence   *EvidenceRecord   `json:"evidence,omitempty"`   // 부정 행위 증거 레코드일 때만 설정 (evidence.go)
+}
+
+// 툼스톤 : 이전에 확정된 레코드(leaf 해시)를 철회
+type RevocationRecord struct {
+	Targets []string `json:"targets"`          // 철회 대상 레코드 leaf 해시
+	Reason  string   `json:"reason,omitempty"` // 철회 사유
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -39,11 +53,18 @@ type ClinicRecord struct {
 ////////////////////////////////////////////////////////////////////////////////
 
 type ContractData struct {
-	HosID            string            `json:"hos_id"`             // Hos 식별자
-	ExpiryTimestamp  string            `json:"expiry_ts"`          // 계약 만료 시각
-	Regions          []string          `json:"regions,omitempty"`  // 서비스 허용 지역
-	AllowedClinicIDs []string          `json:"allowed_clinic_ids"` // 허용된 진료 정보 ID 목록
-	Meta             map[string]string `json:"meta,omitempty"`     // 추가적인 계약 정보 (버전, 조건 등)
+	HosID            string            `json:"hos_id"`              // Hos 식별자
+	ExpiryTimestamp  string            `json:"expiry_ts"`           // 계약 만료 시각
+	Regions          []string          `json:"regions,omitempty"`   // 서비스 허용 지역
+	AllowedClinicIDs []string          `json:"allowed_clinic_ids"`  // 허용된 진료 정보 ID 목록
+	Meta             map[string]string `json:"meta,omitempty"`      // 추가적인 계약 정보 (버전, 조건 등)
+	Retention        *RetentionRule    `json:"retention,omitempty"` // 레코드 보존 규칙 (retention.go)
+}
+
+// 레코드 보존 규칙 : 생성 후 MaxAgeDays 가 지난 레코드를 Action 으로 처리
+type RetentionRule struct {
+	MaxAgeDays int    `json:"max_age_days"` // 보존 일수 (X년 = 365*X)
+	Action     string `json:"action"`       // archive | restrict
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Dedup / Replay Protection (메모리풀 중복·재전송 차단)
+// ------------------------------------------------------------
+// - 레코드 내용 해시(hashClinicRecord = leaf hash)를 키로 seen-set 을 LevelDB 에 유지
+//   · seen_<hash> => {first_seen, block}  (block = -1 이면 메모리풀 대기 중)
+//   · 메모리풀 선기록 / 블록 커밋과 같은 배치로 기록
+// - 접수 거부 사유
+//   · duplicate : 재전송 창(REPLAY_WINDOW, 기본 86400초) 안에 이미 접수/확정된 레코드
+//   · stale     : 레코드 timestamp 가 현재 시각 기준 재전송 창을 벗어남
+//     (창이 지난 seen 항목은 정리되므로, 오래된 레코드 재전송은 timestamp 로 차단)
+// - 블록 제안 시 이미 다른 블록에 확정된 레코드는 메모리풀에서 제외
+// - 창이 지난 seen 항목은 SeenPruneInterval 주기로 정리
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	seenPrefix          = "seen_"
+	DefaultReplayWindow = 86400 // 초
+	SeenPruneInterval   = 600   // 초
+
+	ReplayDuplicate = "duplicate"
+	ReplayStale     = "stale"
+)
+
+var ReplayWindow = DefaultReplayWindow // REPLAY_WINDOW 로 변경 가능
+
+type SeenMark struct {
+	FirstSeen int64 `json:"first_seen"` // unix 초
+	Block     int   `json:"block"`      // 포함된 블록 번호 (-1 이면 메모리풀 대기)
+}
+
+// 접수 거부된 레코드 (index 는 요청 배열 내 위치)
+type ReplayRejection struct {
+	Index    int    `json:"index"`
+	ClinicID string `json:"clinic_id"`
+	Hash     string `json:"hash"`
+	Reason   string `json:"reason"`
+}
+
+func seenKey(hash string) []byte {
+	return []byte(seenPrefix + hash)
+}
+
+func replayWindow() time.Duration {
+	return time.Duration(ReplayWindow) * time.Second
+}
+
+// 재전송 창 안의 seen 항목 조회
+func loadSeen(hash string) (SeenMark, bool) {
+	data, err := db.Get(seenKey(hash), nil)
+	if err != nil {
+		return SeenMark{}, false
+	}
+	var m SeenMark
+	if err := json.Unmarshal(data, &m); err != nil {
+		return SeenMark{}, false
+	}
+	if time.Since(time.Unix(m.FirstSeen, 0)) > replayWindow() {
+		return SeenMark{}, false
+	}
+	return m, true
+}
+
+// 레코드 접수 가능 여부 ("" 이면 접수 가능)
+func replayReason(rec ClinicRecord, hash string, now time.Time) string {
+	if _, ok := loadSeen(hash); ok {
+		return ReplayDuplicate
+	}
+	if t, err := time.Parse(time.RFC3339Nano, rec.Timestamp); err == nil {
+		if d := now.Sub(t); d > replayWindow() || -d > replayWindow() {
+			return ReplayStale
+		}
+	}
+	return ""
+}
+
+// 중복/재전송 레코드 제외 (같은 요청 안의 중복도 제외)
+func filterReplays(entries []ClinicRecord) ([]ClinicRecord, []ReplayRejection) {
+	now := time.Now()
+	fresh := make([]ClinicRecord, 0, len(entries))
+	rejected := []ReplayRejection{}
+	inBatch := make(map[string]bool, len(entries))
+	for i, rec := range entries {
+		hash := hashClinicRecord(rec)
+		reason := replayReason(rec, hash, now)
+		if reason == "" && inBatch[hash] {
+			reason = ReplayDuplicate
+		}
+		if reason != "" {
+			rejected = append(rejected, ReplayRejection{Index: i, ClinicID: rec.ClinicID, Hash: hash, Reason: reason})
+			incCounter("chain_pending_rejected_total", `reason="`+reason+`"`)
+			continue
+		}
+		inBatch[hash] = true
+		fresh = append(fresh, rec)
+	}
+	return fresh, rejected
+}
+
+// seen 항목 기록 (최초 접수 시각은 유지, 블록 번호만 갱신)
+func markSeen(batch kvWriter, hashes []string, block int) {
+	now := time.Now().Unix()
+	for _, h := range hashes {
+		m := SeenMark{FirstSeen: now, Block: block}
+		if prev, ok := loadSeen(h); ok {
+			m.FirstSeen = prev.FirstSeen
+		}
+		if data, err := json.Marshal(m); err == nil {
+			batch.Put(seenKey(h), data)
+		}
+	}
+}
+
+func recordHashes(entries []ClinicRecord) []string {
+	out := make([]string, len(entries))
+	for i, rec := range entries {
+		out[i] = hashClinicRecord(rec)
+	}
+	return out
+}
+
+// 이미 블록에 확정된 레코드 제외 (블록 제안 직전 호출)
+func dropCommitted(entries []ClinicRecord) []ClinicRecord {
+	kept := entries[:0]
+	for _, rec := range entries {
+		if m, ok := loadSeen(hashClinicRecord(rec)); ok && m.Block >= 0 {
+			log.Printf("[DEDUP] drop record %s already committed in block #%d", rec.ClinicID, m.Block)
+			continue
+		}
+		kept = append(kept, rec)
+	}
+	return kept
+}
+
+// 재전송 창이 지난 seen 항목 정리 (main 에서 실행)
+func startSeenPruner() {
+	ticker := time.NewTicker(SeenPruneInterval * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		cutoff := time.Now().Add(-replayWindow()).Unix()
+		batch := new(leveldb.Batch)
+		iter := db.NewIterator(util.BytesPrefix([]byte(seenPrefix)), nil)
+		for iter.Next() {
+			var m SeenMark
+			if err := json.Unmarshal(iter.Value(), &m); err != nil || m.FirstSeen < cutoff {
+				batch.Delete(append([]byte{}, iter.Key()...))
+			}
+		}
+		iter.Release()
+		if batch.Len() == 0 {
+			continue
+		}
+		if err := countDBError(db.Write(batch, nil)); err != nil {
+			log.Printf("[DEDUP][ERROR] prune seen-set failed: %v", err)
+			continue
+		}
+		log.Printf("[DEDUP] Pruned %d seen entries older than %ds", batch.Len(), ReplayWindow)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Selective Disclosure (필드 단위 선택 공개 증명)
+// ------------------------------------------------------------
+// - 접수 시 레코드마다 salt 부여 (submitRecords => saltRecords)
+//   · salt = HMAC(KEK, "record-salt" || sha256(레코드)) (PHI_KEY 없으면 sha256("record-salt" || sha256(레코드)))
+//     => 같은 레코드는 같은 salt 가 되어 재전송/중복 차단(dedup.go)이 그대로 동작
+// - salt 가 있는 레코드의 leaf = 필드별 미니 머클 트리의 루트
+//   · 필드 = 레코드 JSON 의 최상위 키 (salt 제외, 키 정렬 순서)
+//   · 필드 leaf = sha256(정렬 JSON {"field": 키, "salt": 필드 salt, "value": 값})
+//   · 필드 salt = HMAC-SHA256(레코드 salt, 키) hex => 공개한 필드의 salt 로 다른 필드 값을 추측 검증할 수 없음
+//   · salt 없는 기존 레코드는 기존 규칙(레코드 전체 정렬 JSON 해시) 그대로
+// - GET /search?value=<keyword>&fields=<필드,...>
+//   · 레코드 본문 대신 지정 필드의 값 + 필드 salt + 필드 증명(필드 leaf => 레코드 leaf)만 반환
+//   · 레코드 leaf => block_root 증명(proof)은 /search 와 동일 => 앵커까지 그대로 검증 가능
+//   · 레코드에 없는 필드는 missing, salt 없는 레코드는 disclosure 없이 note 만 표시
+////////////////////////////////////////////////////////////////////////////////
+
+// 공개한 필드 하나 (필드 leaf = sha256(정렬 JSON {field, salt, value}))
+type FieldDisclosure struct {
+	Field string      `json:"field"`
+	Value interface{} `json:"value"`
+	Salt  string      `json:"salt"`
+	Proof [][2]string `json:"proof"` // 필드 leaf => 레코드 leaf
+}
+
+type Disclosure struct {
+	Fields     []FieldDisclosure `json:"fields"`
+	FieldCount int               `json:"field_count"`       // 레코드 전체 필드 수 (필드 트리 크기)
+	Missing    []string          `json:"missing,omitempty"` // 레코드에 없는 요청 필드
+}
+
+// /search?fields= 응답 항목 (레코드 본문 제외)
+type DisclosedSearchResponse struct {
+	Disclosure   *Disclosure       `json:"disclosure,omitempty"`
+	Note         string            `json:"note,omitempty"`
+	BlockRoot    string            `json:"block_root"`
+	LatestRoot   string            `json:"latest_root"`
+	Leaf         string            `json:"leaf"`
+	Proof        [][2]string       `json:"proof"`
+	ProofVersion int               `json:"proof_version"`
+	Inclusion    Inclusion         `json:"inclusion"`
+	Retention    string            `json:"retention,omitempty"`
+	Revoked      *RevocationStatus `json:"revoked,omitempty"`
+}
+
+// 레코드 salt 유도 (salt 제외 레코드 기준)
+func recordSalt(rec ClinicRecord) string {
+	rec.Salt = ""
+	digest := sha256.Sum256(jsonCanonical(rec))
+	if phiEnabled() {
+		return hex.EncodeToString(hmacSum(phiKeys.byID[phiKeys.current], "record-salt", digest[:]))
+	}
+	return sha256Hex(append([]byte("record-salt"), digest[:]...))
+}
+
+// 접수 레코드 salt 부여 (이미 salt 가 있으면 그대로)
+func saltRecords(recs []ClinicRecord) []ClinicRecord {
+	out := make([]ClinicRecord, len(recs))
+	for i, r := range recs {
+		if r.Salt == "" {
+			r.Salt = recordSalt(r)
+		}
+		out[i] = r
+	}
+	return out
+}
+
+func fieldSalt(salt, field string) string {
+	m := hmac.New(sha256.New, []byte(salt))
+	m.Write([]byte(field))
+	return hex.EncodeToString(m.Sum(nil))
+}
+
+// 레코드 최상위 필드 (salt 제외) 와 정렬된 키 목록
+func recordFields(rec ClinicRecord) (map[string]interface{}, []string) {
+	b, _ := json.Marshal(rec)
+	var m map[string]interface{}
+	unmarshalNumbers(b, &m)
+	delete(m, "salt")
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return m, keys
+}
+
+func fieldLeaf(field, salt string, value interface{}) string {
+	return sha256Hex(jsonCanonical(map[string]interface{}{"field": field, "salt": salt, "value": value}))
+}
+
+// 필드 leaf 목록 (키 정렬 순서)
+func fieldLeaves(rec ClinicRecord) ([]string, []string, map[string]interface{}) {
+	m, keys := recordFields(rec)
+	leaves := make([]string, len(keys))
+	for i, k := range keys {
+		leaves[i] = fieldLeaf(k, fieldSalt(rec.Salt, k), m[k])
+	}
+	return leaves, keys, m
+}
+
+// salt 가 있는 레코드의 leaf (필드 트리 루트)
+func fieldMerkleRoot(rec ClinicRecord) string {
+	leaves, _, _ := fieldLeaves(rec)
+	return merkle.Root(leaves)
+}
+
+// 레코드의 지정 필드 공개 증명
+func discloseFields(rec ClinicRecord, fields []string) *Disclosure {
+	leaves, keys, m := fieldLeaves(rec)
+	d := &Disclosure{Fields: []FieldDisclosure{}, FieldCount: len(keys)}
+	for _, f := range fields {
+		i := slices.Index(keys, f)
+		if i < 0 {
+			d.Missing = append(d.Missing, f)
+			continue
+		}
+		d.Fields = append(d.Fields, FieldDisclosure{
+			Field: f,
+			Value: m[f],
+			Salt:  fieldSalt(rec.Salt, f),
+			Proof: merkle.Proof(leaves, i),
+		})
+	}
+	return d
+}
+
+// ?fields= 파라미터 파싱 (쉼표 구분, 중복 제거)
+func disclosureFields(raw string) []string {
+	var out []string
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" && !slices.Contains(out, f) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// 검색 결과를 선택 공개 응답으로 변환
+func disclosedResults(results []SearchResponse, fields []string) []DisclosedSearchResponse {
+	out := make([]DisclosedSearchResponse, len(results))
+	for i, res := range results {
+		d := DisclosedSearchResponse{
+			BlockRoot:    res.BlockRoot,
+			LatestRoot:   res.LatestRoot,
+			Leaf:         res.Leaf,
+			Proof:        res.Proof,
+			ProofVersion: res.ProofVersion,
+			Inclusion:    res.Inclusion,
+			Retention:    res.Retention,
+			Revoked:      res.Revoked,
+		}
+		if res.Record.Salt == "" {
+			d.Note = "record has no field salt (legacy leaf), selective disclosure unavailable"
+		} else {
+			d.Disclosure = discloseFields(res.Record, fields)
+		}
+		out[i] = d
+	}
+	return out
+}
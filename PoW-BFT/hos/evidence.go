@@ -0,0 +1,427 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Misbehavior Evidence (이중 서명 / 잘못된 제안 증거 기록)
+// ------------------------------------------------------------
+// - 증거 종류
+//   · double_sign : 같은 노드가 같은 view/round/단계에서 서로 다른 블록에 서명
+//     prepare/commit 메시지에 블록 헤더를 함께 보내므로, 두 헤더(같은 높이, 같은 제안자)와
+//     각 투표의 voteDigest(단계, view, round, 헤더 해시) 서명(auth) 두 개로 누구나 검증 가능
+//     (블록 해시만 서명한 sig 는 round/단계를 묶지 않아 라운드가 바뀐 정상 재제안과 구분할 수 없으므로 증거로 쓰지 않음)
+//   · invalid_proposal : 제안자가 서명한(/bft/start 의 sig) 블록이 본문 검증에 실패
+//     (블록 해시/머클 루트 불일치, 메인 체인 블록에 상주 레코드) => 블록 본문과 서명으로 검증
+// - 탐지한 노드가 증거 레코드를 메모리풀에 접수 => 메모리풀 중계로 전파 (proposer.go relayPending)
+//   · 증거 레코드 = ClinicRecord{clinic_id: "evidence:<증거 ID>", timestamp, evidence{...}}
+//   · 중계 수신/제안 블록 검증 시 증거를 다시 검증, 검증 실패 레코드는 거부
+//   · 같은 증거(ID 기준)는 한 번만 반영 (여러 노드가 동시에 접수해도 첫 기록만 유효)
+// - 블록 반영 시 "evid_<block>:<entry>" 에 저장 (분기 교체 시 분기점 이후 삭제, reorg.go rollbackTo)
+// - 처벌 : 증거가 기록된 블록 다음 높이부터 EvidencePenaltyBlocks 개 블록 동안 검증자 집합에서 제외
+//   (정족수/제안자 순환/투표 집계 모두, validators.go validatorsAt)
+//   · 처벌 기간은 모든 노드 공통 상수, 레코드에 싣지 않고 반영 시 계산
+//     (먼저 접수한 노드가 처벌 기간을 정하지 못하도록)
+// - 서명 검증 공개키 = 증거 높이(view) 의 장부 검증자 공개키 (키 교체 전 서명이면 지문으로 이력 조회)
+// - GET /evidence : 장부에 기록된 증거와 다음 블록 기준 제외 중인 노드
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	evidencePrefix       = "evid_"
+	evidenceRecordPrefix = "evidence:"
+
+	EvidenceDoubleSign      = "double_sign"
+	EvidenceInvalidProposal = "invalid_proposal"
+)
+
+// 증거 반영 후 검증자에서 제외되는 블록 수 (합의 규칙이므로 노드별 설정 불가)
+const EvidencePenaltyBlocks = 100
+
+// 서명된 투표 (sig 대상 = Header.computeHash(), auth 대상 = voteDigest(phase, view, round, 헤더 해시))
+type SignedVote struct {
+	Header LowerBlockHeader `json:"header"`
+	Sig    string           `json:"sig"`
+	Round  int              `json:"round"`
+	Phase  string           `json:"phase"`
+	Auth   string           `json:"auth"`
+}
+
+// 부정 행위 증거
+type EvidenceRecord struct {
+	Type     string       `json:"type"` // double_sign | invalid_proposal
+	Offender string       `json:"offender"`
+	KeyFP    string       `json:"pubkey_fingerprint"` // 서명 당시 공개키 지문
+	View     int          `json:"view"`
+	Round    int          `json:"round"`
+	Phase    string       `json:"phase,omitempty"` // double_sign : prepare | commit
+	Votes    []SignedVote `json:"votes,omitempty"` // double_sign : 서로 다른 블록 서명 2개
+	Block    *LowerBlock  `json:"block,omitempty"` // invalid_proposal : 제안된 블록
+	BlockSig string       `json:"block_sig,omitempty"`
+	Reason   string       `json:"reason,omitempty"`
+	Reporter string       `json:"reporter"`
+}
+
+// 증거 식별자 (같은 부정 행위면 탐지 노드와 무관하게 같은 값)
+func (e EvidenceRecord) id() string {
+	hashes := []string{}
+	for _, v := range e.Votes {
+		hashes = append(hashes, v.Header.computeHash())
+	}
+	if e.Block != nil {
+		hashes = append(hashes, e.Block.BlockHash)
+	}
+	sort.Strings(hashes)
+	return sha256Hex([]byte(fmt.Sprintf("evidence|%s|%s|%d|%d|%s|%s", e.Type, e.Offender, e.View, e.Round, e.Phase, strings.Join(hashes, ","))))
+}
+
+// 장부에 기록된 증거 (블록 위치, 처벌 구간 포함)
+type EvidenceEntry struct {
+	ID string `json:"id"`
+	EvidenceRecord
+	BlockIndex   int `json:"block_index"`
+	EntryIndex   int `json:"entry_index"`
+	PenaltyFrom  int `json:"penalty_from"`
+	PenaltyUntil int `json:"penalty_until"`
+}
+
+func evidenceKey(bi, ei int) []byte {
+	return []byte(fmt.Sprintf("%s%010d:%04d", evidencePrefix, bi, ei))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 검증
+////////////////////////////////////////////////////////////////////////////////
+
+// 상태와 무관한 제안 블록 본문 검증 (모든 노드가 같은 결론)
+func checkProposalBody(b LowerBlock) error {
+	if b.BlockHash != b.computeHash() {
+		return fmt.Errorf("block_hash mismatch")
+	}
+	leaves := entryLeafHashes(b.Entries)
+	if len(leaves) > 0 && merkle.Root(leaves) != b.MerkleRoot {
+		return fmt.Errorf("merkle_root mismatch")
+	}
+	if !slices.Equal(leaves, b.LeafHashes) {
+		return fmt.Errorf("leaf_hashes mismatch")
+	}
+	if err := checkEntryOrder(b.Entries); err != nil {
+		return err
+	}
+	if err := checkBlockLimits(b.Entries); err != nil {
+		return err
+	}
+	if subLedgerRegion(b.HosID) == "" {
+		for _, rec := range b.Entries {
+			if rec.Residency != "" {
+				return fmt.Errorf("residency violation: record %s (residency=%q) in main chain", rec.ClinicID, rec.Residency)
+			}
+		}
+	}
+	return nil
+}
+
+// 높이 h 에서 서명한 노드의 공개키 (장부 검증자 키와 지문이 다르면 교체 전 키)
+func offenderKey(addr, fp string, h int) string {
+	pub := validatorKeyAt(addr, h)
+	if pub == "" {
+		return "" // 높이 h 의 검증자가 아니면 과거 키로 대체하지 않음
+	}
+	if fp != "" && fp != pubKeyFingerprint(pub) {
+		pub = historicalPubKey(addr, fp)
+	}
+	return pub
+}
+
+func verifyHashSig(pub, hash, sig string) bool {
+	b, err := hex.DecodeString(hash)
+	return err == nil && pub != "" && verifyECDSA(pub, b, sig)
+}
+
+// 증거 검증
+func verifyEvidence(e EvidenceRecord) error {
+	pub := offenderKey(e.Offender, e.KeyFP, e.View)
+	if pub == "" {
+		return fmt.Errorf("unknown key for %s at height %d", e.Offender, e.View)
+	}
+	switch e.Type {
+	case EvidenceDoubleSign:
+		if len(e.Votes) != 2 {
+			return fmt.Errorf("double_sign requires 2 votes")
+		}
+		if e.Phase != PhaseNamePrepare && e.Phase != PhaseNameCommit {
+			return fmt.Errorf("double_sign requires phase prepare or commit")
+		}
+		a, b := e.Votes[0].Header, e.Votes[1].Header
+		if a.Index != e.View || b.Index != e.View || a.Proposer != b.Proposer {
+			return fmt.Errorf("votes are not for the same view/proposer")
+		}
+		if a.computeHash() == b.computeHash() {
+			return fmt.Errorf("votes are for the same block")
+		}
+		for _, v := range e.Votes {
+			if v.Round != e.Round || v.Phase != e.Phase {
+				return fmt.Errorf("votes are not for the same round/phase")
+			}
+			// round/단계를 묶은 서명이어야 함 (view 포기 후 재제안 등 다른 라운드 투표로 정상 검증자를 처벌하지 않도록)
+			if !verifyHashSig(pub, voteDigest(v.Phase, e.View, v.Round, v.Header.computeHash()), v.Auth) {
+				return fmt.Errorf("invalid vote signature")
+			}
+		}
+	case EvidenceInvalidProposal:
+		if e.Block == nil || e.Block.Index != e.View {
+			return fmt.Errorf("invalid_proposal requires the proposed block")
+		}
+		if !verifyHashSig(pub, e.Block.BlockHash, e.BlockSig) {
+			return fmt.Errorf("invalid proposal signature")
+		}
+		if checkProposalBody(*e.Block) == nil {
+			return fmt.Errorf("proposed block is valid")
+		}
+	default:
+		return fmt.Errorf("unknown evidence type %q", e.Type)
+	}
+	return nil
+}
+
+// 제안 블록/중계 레코드의 증거 확인
+func checkEvidenceRecords(entries []ClinicRecord) error {
+	for _, rec := range entries {
+		if rec.Evidence == nil {
+			continue
+		}
+		if err := verifyEvidence(*rec.Evidence); err != nil {
+			return fmt.Errorf("evidence %s: %w", rec.ClinicID, err)
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 탐지 (prepare/commit 투표 기록)
+////////////////////////////////////////////////////////////////////////////////
+
+var (
+	voteLog   = map[int]map[string]SignedVote{} // view => "round|phase|addr" => 처음 본 투표
+	voteLogMu sync.Mutex
+)
+
+// 투표 기록 후 같은 round/단계에서 다른 블록에 대한 서명이면 증거 접수
+// (헤더가 없거나 해시/높이가 맞지 않는 투표, 서명이 유효하지 않은 투표는 기록하지 않음)
+func observeVote(view, round int, phase, addr, hash, sig, auth string, hdr *LowerBlockHeader) {
+	if hdr == nil || hdr.Index != view || hdr.computeHash() != hash {
+		return
+	}
+	// 이미 확정된 높이의 늦은 투표는 기록하지 않음 (voteLog 는 deleteView 에서 정리)
+	if height, _ := getLatestHeight(); view <= height {
+		return
+	}
+	// round/단계를 묶은 서명(auth)이 검증된 투표만 기록 (증거 검증 기준과 동일)
+	pub := verifyingKey(voterKeysAt(addr, view), voteDigest(phase, view, round, hash), auth)
+	if pub == "" {
+		return
+	}
+	key := fmt.Sprintf("%d|%s|%s", round, phase, addr)
+	vote := SignedVote{Header: *hdr, Sig: sig, Round: round, Phase: phase, Auth: auth}
+
+	voteLogMu.Lock()
+	votes, ok := voteLog[view]
+	if !ok {
+		votes = map[string]SignedVote{}
+		voteLog[view] = votes
+	}
+	prev, seen := votes[key]
+	if !seen {
+		votes[key] = vote
+	}
+	voteLogMu.Unlock()
+
+	if !seen || prev.Header.computeHash() == hash || prev.Header.Proposer != hdr.Proposer {
+		return
+	}
+	log.Printf("[EVIDENCE] double sign by %s (view=%d round=%d %s): %.12s / %.12s", addr, view, round, phase, prev.Header.computeHash(), hash)
+	go reportEvidence(EvidenceRecord{
+		Type:     EvidenceDoubleSign,
+		Offender: addr,
+		KeyFP:    pubKeyFingerprint(pub),
+		View:     view,
+		Round:    round,
+		Phase:    phase,
+		Votes:    []SignedVote{prev, vote},
+	})
+}
+
+// 서명된 제안이 본문 검증에 실패하면 증거 접수 (handleBftStart 에서 호출)
+func observeInvalidProposal(view, round int, leader, sig string, b LowerBlock, reason error) {
+	pub := validatorKeys()[leader]
+	if !verifyHashSig(pub, b.BlockHash, sig) {
+		return
+	}
+	log.Printf("[EVIDENCE] invalid proposal by %s (view=%d round=%d): %v", leader, view, round, reason)
+	go reportEvidence(EvidenceRecord{
+		Type:     EvidenceInvalidProposal,
+		Offender: leader,
+		KeyFP:    pubKeyFingerprint(pub),
+		View:     view,
+		Round:    round,
+		Block:    &b,
+		BlockSig: sig,
+		Reason:   reason.Error(),
+	})
+}
+
+func clearVoteLog(view int) {
+	voteLogMu.Lock()
+	defer voteLogMu.Unlock()
+	delete(voteLog, view)
+}
+
+// 증거 레코드 접수 (이미 기록됐거나 접수된 증거는 생략)
+func reportEvidence(e EvidenceRecord) {
+	e.Reporter = self
+	id := e.id()
+	if evidenceKnown(id) {
+		return
+	}
+	rec := ClinicRecord{
+		ClinicID:  evidenceRecordPrefix + id,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Evidence:  &e,
+	}
+	if _, _, status, err := submitRecords([]ClinicRecord{rec}); err != nil || status != http.StatusOK {
+		log.Printf("[EVIDENCE][ERROR] submit %s against %s failed (status=%d): %v", e.Type, e.Offender, status, err)
+		return
+	}
+	incCounter("chain_evidence_reported_total", `type="`+e.Type+`"`)
+	log.Printf("[EVIDENCE] %s against %s submitted (id=%.12s, penalty=%d blocks)", e.Type, e.Offender, id, EvidencePenaltyBlocks)
+}
+
+// 장부 또는 메모리풀에 같은 증거가 있는지
+func evidenceKnown(id string) bool {
+	for _, e := range committedEvidence() {
+		if e.ID == id {
+			return true
+		}
+	}
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	for _, rec := range ch.pending {
+		if rec.Evidence != nil && rec.Evidence.id() == id {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 장부 반영 / 처벌 구간
+////////////////////////////////////////////////////////////////////////////////
+
+// 블록의 증거 저장 (updateIndicesForBlock 에서 호출, 이미 기록된 증거는 생략)
+// - 같은 트랜잭션에서 되돌린 증거는 제외하고 앞 블록이 넣은 증거는 포함해서 판단
+func putEvidence(tx *ledgerTxn, block LowerBlock) {
+	recorded := map[string]bool{}
+	tx.scanPrefix(evidencePrefix, func(_, val []byte) {
+		var e EvidenceEntry
+		if json.Unmarshal(val, &e) == nil {
+			recorded[e.ID] = true
+		}
+	})
+	for ei, rec := range block.Entries {
+		if rec.Evidence == nil {
+			continue
+		}
+		e := EvidenceEntry{ID: rec.Evidence.id(), EvidenceRecord: *rec.Evidence, BlockIndex: block.Index, EntryIndex: ei}
+		if recorded[e.ID] {
+			continue
+		}
+		recorded[e.ID] = true
+		e.PenaltyFrom = block.Index + 1
+		e.PenaltyUntil = block.Index + EvidencePenaltyBlocks
+		data, _ := json.Marshal(e)
+		tx.Put(evidenceKey(block.Index, ei), data)
+	}
+}
+
+// 분기점 이후 블록의 증거 삭제 (rollbackTo 에서 호출)
+func deleteEvidence(batch kvWriter, fork int) {
+	iter := db.NewIterator(util.BytesPrefix([]byte(evidencePrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		if bi, _, ok := parsePtr(strings.TrimPrefix(string(iter.Key()), evidencePrefix)); ok && bi > fork {
+			batch.Delete(append([]byte(nil), iter.Key()...))
+		}
+	}
+}
+
+// 장부에 기록된 증거 (기록 순서)
+func committedEvidence() []EvidenceEntry {
+	out := []EvidenceEntry{}
+	iter := db.NewIterator(util.BytesPrefix([]byte(evidencePrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var e EvidenceEntry
+		if json.Unmarshal(iter.Value(), &e) == nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// 높이 h 블록에서 처벌 중인 노드
+func penalizedAt(h int) map[string]bool {
+	out := map[string]bool{}
+	for _, e := range committedEvidence() {
+		if h >= e.PenaltyFrom && h <= e.PenaltyUntil {
+			out[e.Offender] = true
+		}
+	}
+	return out
+}
+
+// 높이 h 블록에서 처벌 중인 노드 (주소 정렬)
+func penalizedList(h int) []string {
+	out := []string{}
+	for addr := range penalizedAt(h) {
+		out = append(out, addr)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// GET /evidence[?height=<int>]
+func handleEvidence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	height, _ := getLatestHeight()
+	h := height + 1
+	if v := r.URL.Query().Get("height"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid height")
+			return
+		}
+		h = n
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"height":    h,
+		"penalized": penalizedList(h),
+		"evidence":  committedEvidence(),
+	})
+}
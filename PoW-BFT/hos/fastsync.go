@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Fast Sync (헤더 우선 동기화 + 본문 병렬 다운로드)
+// ------------------------------------------------------------
+// - 로컬과 원격 높이 차이가 FastSyncMinGap 이상이면 syncChain 이 이 경로를 사용
+//   (FAST_SYNC=false 이거나 원격이 /headers 를 제공하지 않으면 기존 순차 동기화)
+// - 1단계 : 동기화 대상 피어에서 헤더만 HeaderPageSize 개씩 받아 로컬 최신 블록부터 검증
+//   · index 연속, prev_hash 연결, hos_id 일치, 헤더 필드로 재계산한 block_hash 일치
+// - 2단계 : 본문을 BodyChunkSize 개 구간으로 나눠 FastSyncWorkers 개 루틴이 여러 피어에서 병렬 수신
+//   · 구간별로 피어를 돌아가며 요청, 실패 시 다음 피어로 재시도
+//   · 본문은 검증된 헤더의 해시와 일치해야 하며 머클 루트/상주 규칙도 재검증 (validateLowerBlock)
+// - 3단계 : 받은 구간을 블록 번호 순서대로 커밋 (중간 실패 시 그때까지 커밋한 블록은 유지)
+// - GET /headers?offset=<int>&limit=<int> : 블록 헤더 페이지 (본문/서명 제외)
+//   · sigs=true 면 합의 서명 포함 (본문 없이 헤더와 서명만으로 확정 여부를 검증하는 라이트 클라이언트용)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	FastSyncMinGap  = 64   // 블록
+	HeaderPageSize  = 500  // /headers 기본 페이지 크기
+	MaxHeaderPage   = 2000 // /headers 최대 페이지 크기
+	BodyChunkSize   = 50   // 본문 요청 1건당 블록 수
+	FastSyncWorkers = 4
+)
+
+var fastSyncEnabled = true // FAST_SYNC
+
+type headersPage struct {
+	Total  int                `json:"total"`
+	Offset int                `json:"offset"`
+	Limit  int                `json:"limit"`
+	Items  []LowerBlockHeader `json:"items"`
+}
+
+// GET /headers?offset=&limit=[&sigs=true]
+func handleHeaders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if offset < 0 {
+		writeError(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+	if limit <= 0 {
+		limit = HeaderPageSize
+	}
+	limit = min(limit, MaxHeaderPage)
+	sigs := r.URL.Query().Get("sigs") == "true"
+
+	page := headersPage{Offset: offset, Limit: limit, Items: []LowerBlockHeader{}}
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		if page.Total, err = blockTotalFrom(rd); err != nil || offset >= page.Total {
+			return err
+		}
+		return scanBlocksFrom(rd, offset, min(offset+limit, page.Total)-1, func(raw []byte) error {
+			var b LowerBlock
+			if err := json.Unmarshal(raw, &b); err != nil {
+				return err
+			}
+			h := b.header()
+			if sigs {
+				h.Signatures = b.Signatures
+			}
+			page.Items = append(page.Items, h)
+			return nil
+		})
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("list headers error: %v", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func fetchHeaders(peer string, offset, limit int) (headersPage, error) {
+	var page headersPage
+	resp, err := nodeClient.Get(nodeURL(peer, fmt.Sprintf("/headers?offset=%d&limit=%d", offset, limit)))
+	if err != nil {
+		return page, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return page, fmt.Errorf("status=%d", resp.StatusCode)
+	}
+	body := &countingReader{r: resp.Body}
+	err = json.NewDecoder(body).Decode(&page)
+	recordTraffic(peer, body.n)
+	return page, err
+}
+
+// 본문 구간 수신 (페이지 크기 제한으로 나뉘어 오면 이어 받음, blocktransfer.go)
+func fetchBodies(peer string, offset, limit int) ([]LowerBlock, error) {
+	out := []LowerBlock{}
+	for len(out) < limit {
+		page, _, _, err := fetchBlocksPage(peer, offset+len(out), limit-len(out), "")
+		if err != nil {
+			return out, err
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+		out = append(out, page.Items...)
+	}
+	return out, nil
+}
+
+// 헤더 체인 검증 (tip : 로컬 최신 블록 헤더)
+func verifyHeaderChain(tip LowerBlockHeader, headers []LowerBlockHeader) error {
+	prev := tip
+	for _, h := range headers {
+		switch {
+		case h.Index != prev.Index+1:
+			return fmt.Errorf("header index not consecutive: prev=%d got=%d", prev.Index, h.Index)
+		case h.PrevHash != prev.BlockHash:
+			return fmt.Errorf("header #%d prev_hash mismatch", h.Index)
+		case h.HosID != prev.HosID:
+			return fmt.Errorf("header #%d hos_id mismatch: %s", h.Index, h.HosID)
+		case h.computeHash() != h.BlockHash:
+			return fmt.Errorf("header #%d block_hash mismatch", h.Index)
+		}
+		prev = h
+	}
+	return nil
+}
+
+// 검증된 헤더 구간에 맞는 본문인지 확인 (prev : 구간 직전 헤더)
+func verifyBodies(prev LowerBlockHeader, headers []LowerBlockHeader, blocks []LowerBlock) error {
+	if len(blocks) != len(headers) {
+		return fmt.Errorf("got %d bodies, want %d", len(blocks), len(headers))
+	}
+	prevBlk := LowerBlock{Index: prev.Index, HosID: prev.HosID, BlockHash: prev.BlockHash}
+	for i, b := range blocks {
+		if b.BlockHash != headers[i].BlockHash {
+			return fmt.Errorf("body #%d does not match header", b.Index)
+		}
+		if err := validateLowerBlock(b, prevBlk); err != nil {
+			return fmt.Errorf("body #%d invalid: %w", b.Index, err)
+		}
+		prevBlk = b
+	}
+	return nil
+}
+
+type bodyChunk struct {
+	blocks []LowerBlock
+	err    error
+}
+
+// 헤더 우선 동기화 (처리했으면 true, 기존 순차 동기화로 넘길 경우 false)
+func fastSync(peer string) bool {
+	chainMu.Lock()
+	localH, ok := getLatestHeight()
+	tipBlk, err := getBlockByIndex(localH)
+	chainMu.Unlock()
+	if !ok || err != nil {
+		return false
+	}
+
+	// 원격 높이 확인 (/headers 미지원 피어는 순차 동기화)
+	probe, err := fetchHeaders(peer, localH+1, 1)
+	if err != nil || probe.Total-(localH+1) < FastSyncMinGap {
+		return false
+	}
+	observePeerHeight(peer, probe.Total-1)
+	started := time.Now()
+	log.Printf("[SYNC][FAST] local=%d remote=%d from %s : downloading headers", localH+1, probe.Total, peer)
+
+	// 1) 헤더 체인 수신 및 검증
+	headers := make([]LowerBlockHeader, 0, probe.Total-(localH+1))
+	for off := localH + 1; off < probe.Total; off += HeaderPageSize {
+		page, err := fetchHeaders(peer, off, HeaderPageSize)
+		if err != nil {
+			log.Printf("[SYNC][FAST][ERROR] headers @%d from %s: %v", off, peer, err)
+			return true
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+		headers = append(headers, page.Items...)
+	}
+	tip := tipBlk.header()
+	if err := verifyHeaderChain(tip, headers); err != nil {
+		log.Printf("[SYNC][FAST][ERROR] header chain from %s rejected: %v", peer, err)
+		return true
+	}
+
+	// 2) 본문 병렬 수신 (구간별로 피어를 돌아가며 요청)
+	sources := []string{peer}
+	for _, p := range peersSnapshot() {
+		if p != peer && p != self {
+			sources = append(sources, p)
+		}
+	}
+	nChunks := (len(headers) + BodyChunkSize - 1) / BodyChunkSize
+	results := make([]chan bodyChunk, nChunks)
+	for i := range results {
+		results[i] = make(chan bodyChunk, 1)
+	}
+	jobs := make(chan int)
+	stop := make(chan struct{}) // 커밋 중단 시 남은 구간 요청 중지
+	defer close(stop)
+	for w := 0; w < min(FastSyncWorkers, nChunks); w++ {
+		go func() {
+			for c := range jobs {
+				lo, hi := c*BodyChunkSize, min((c+1)*BodyChunkSize, len(headers))
+				prev := tip
+				if lo > 0 {
+					prev = headers[lo-1]
+				}
+				var res bodyChunk
+				for attempt := 0; attempt < len(sources); attempt++ {
+					src := sources[(c+attempt)%len(sources)]
+					blocks, err := fetchBodies(src, headers[lo].Index, hi-lo)
+					if err == nil {
+						err = verifyBodies(prev, headers[lo:hi], blocks)
+					}
+					if err == nil {
+						res = bodyChunk{blocks: blocks}
+						break
+					}
+					res.err = fmt.Errorf("%s: %w", src, err)
+					log.Printf("[SYNC][FAST][RETRY] bodies #%d-#%d: %v", headers[lo].Index, headers[hi-1].Index, res.err)
+				}
+				results[c] <- res
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for c := 0; c < nChunks; c++ {
+			select {
+			case jobs <- c:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	// 3) 블록 번호 순서대로 커밋
+	committed := 0
+	for c := 0; c < nChunks; c++ {
+		res := <-results[c]
+		if res.err != nil {
+			log.Printf("[SYNC][FAST][ERROR] stopped after %d blocks: %v", committed, res.err)
+			break
+		}
+		if err := commitSyncedBlocks(res.blocks); err != nil {
+			log.Printf("[SYNC][FAST][ERROR] stopped after %d blocks: %v", committed, err)
+			break
+		}
+		committed += len(res.blocks)
+	}
+	log.Printf("[SYNC][FAST] committed %d/%d blocks from %d peers in %s",
+		committed, len(headers), len(sources), time.Since(started).Round(time.Millisecond))
+	return true
+}
+
+// 검증된 본문 커밋 (로컬 높이가 그사이 바뀌었으면 중단)
+func commitSyncedBlocks(blocks []LowerBlock) error {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	for _, b := range blocks {
+		h, _ := getLatestHeight()
+		if h+1 != b.Index {
+			return fmt.Errorf("local height moved to %d before #%d", h, b.Index)
+		}
+		if err := commitBlock(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Full-text Search (Info 메타데이터 토큰 색인 + 접두어 검색)
+// ------------------------------------------------------------
+// - info_<key>_<value> 색인은 값 전체가 소문자로 정확히 일치해야만 검색됨
+// - FulltextFields(title, description, category) 값을 토큰 단위로 추가 색인
+//   · 소문자화 후 문자/숫자가 아닌 글자 기준으로 분리 (한글 포함)
+//   · FulltextMinToken 글자 미만 토큰은 제외, 레코드당 FulltextMaxTokens 개까지
+//   · ft_<token> => "bi:ei,bi:ei,..." (cid/pc/info 색인과 같은 포인터 목록 형식)
+// - GET /search/fulltext?q=<words>[&include_expired=true][&offset=<int>&limit=<int>]
+//   · 각 검색어는 토큰 접두어로 매칭, 검색어가 여러 개면 모두 포함한 레코드만 (AND)
+//   · 응답은 /search 와 같은 배열 + 증명, 전체 매칭 수는 X-Total-Count 헤더
+// - 이 기능 이전에 저장된 블록은 POST /admin/reindex 로 토큰 색인 생성
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	fulltextPrefix    = "ft_"
+	FulltextMinToken  = 2  // 글자 수
+	FulltextMaxTokens = 64 // 레코드당 색인 토큰 수 상한
+	FulltextMaxTerms  = 8  // 검색어 수 상한
+)
+
+// 토큰 색인 대상 Info 필드
+var FulltextFields = []string{"title", "description", "category"}
+
+// 문자열 => 소문자 토큰 목록 (중복 제거, 등장 순서 유지)
+func tokenize(s string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, t := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(t) < FulltextMinToken || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// 레코드의 전문 색인 키 목록 (updateIndicesForBlock 에서 호출)
+func fulltextKeys(rec ClinicRecord) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, field := range FulltextFields {
+		v, ok := rec.Info[field]
+		if !ok {
+			continue
+		}
+		for _, t := range tokenize(fmt.Sprintf("%v", v)) {
+			if len(keys) >= FulltextMaxTokens {
+				return keys
+			}
+			if !seen[t] {
+				seen[t] = true
+				keys = append(keys, fulltextPrefix+t)
+			}
+		}
+	}
+	return keys
+}
+
+// 토큰 접두어에 매칭되는 모든 레코드 위치
+func fulltextPrefixPointers(rd dbReader, prefix string) map[RecordPtr]bool {
+	out := map[RecordPtr]bool{}
+	iter := rd.NewIterator(util.BytesPrefix([]byte(fulltextPrefix+prefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		for _, p := range parsePtrList(string(iter.Value())) {
+			out[p] = true
+		}
+	}
+	return out
+}
+
+// 검색어(접두어) 목록을 모두 포함하는 레코드 위치 ((블록, 엔트리) 순)
+func fulltextPointers(rd dbReader, terms []string) []RecordPtr {
+	var hits map[RecordPtr]bool
+	for _, term := range terms {
+		cur := fulltextPrefixPointers(rd, term)
+		if hits != nil {
+			for p := range hits {
+				if !cur[p] {
+					delete(hits, p)
+				}
+			}
+		} else {
+			hits = cur
+		}
+		if len(hits) == 0 {
+			return nil
+		}
+	}
+	out := make([]RecordPtr, 0, len(hits))
+	for p := range hits {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].BlockIndex != out[b].BlockIndex {
+			return out[a].BlockIndex < out[b].BlockIndex
+		}
+		return out[a].EntryIndex < out[b].EntryIndex
+	})
+	return out
+}
+
+// 전문 검색 수행 (색인/블록/최신 루트는 하나의 스냅샷에서 읽음)
+func searchFulltext(terms []string, includeExpired bool, offset, limit int) ([]SearchResponse, int, error) {
+	var (
+		results []SearchResponse
+		total   int
+	)
+	err := withReadSnapshot(func(rd dbReader) error {
+		ptrs := fulltextPointers(rd, terms)
+		if len(ptrs) == 0 {
+			return fmt.Errorf("no record found for: %s", strings.Join(terms, " "))
+		}
+		var err error
+		results, total, err = searchPointersFrom(rd, ptrs, nil, includeExpired, offset, limit)
+		return err
+	})
+	return results, total, err
+}
+
+// GET /search/fulltext?q=<words>
+func handleFulltextSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	terms := tokenize(r.URL.Query().Get("q"))
+	if len(terms) == 0 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("q parameter required (words of %d+ characters)", FulltextMinToken))
+		return
+	}
+	if len(terms) > FulltextMaxTerms {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("too many terms (max %d)", FulltextMaxTerms))
+		return
+	}
+	offset, limit, err := searchPage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	logInfo("fulltext query terms: %v", terms)
+
+	results, total, err := searchFulltext(terms, r.URL.Query().Get("include_expired") == "true", offset, limit)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSON(w, http.StatusOK, results)
+}
@@ -1,7 +1,25 @@
 module hos
 
-go 1.25
+go 1.25.0
 
-require github.com/syndtr/goleveldb v1.0.0
+require (
+	github.com/syndtr/goleveldb v1.0.0
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.11
+)
 
-require github.com/golang/snappy v1.0.0 // indirect
+require (
+	github.com/golang/snappy v1.0.0 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+)
+
+require wsconn v0.0.0
+
+replace wsconn => ../../wsconn
+
+require merkle v0.0.0
+
+replace merkle => ../../merkle
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Gov Registration (Hos 체인 자동 등록)
+// ------------------------------------------------------------
+// - 부트노드가 되면 Gov 부트노드(GOV_BOOTSTRAP_ADDR)의 POST /registerHosChain 으로 체인 등록
+//   · {hos_id, hos_boot, pub_key, ts, sig}
+//     sig = sha256("register_hos_chain|<hos_id>|<hos_boot>|<pub_key 지문>|<ts>") 의 노드 키 서명
+//   · Gov 는 hos_boot 를 모든 Gov 노드에 전파 => 첫 앵커 전에도 Gov /query 로 조회 가능
+//   · 시작 시(가입 신청 전), 부트노드로 선출될 때마다 등록 (실패 시 재시도)
+// - 응답 반영
+//   · gov_boot  : Gov 부트노드 주소 갱신
+//   · gov_peers : Gov 노드 목록 (/status 의 gov_peers)
+//   · contract_template : ONBOARDING_CONTRACT_FILE 이 없을 때 가입 신청 계약으로 사용 (onboarding.go)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	GovRegisterRetryInterval = 10 // 초
+	GovRegisterMaxAttempts   = 6
+)
+
+// Gov /registerHosChain 응답 (Gov RegisterHosChainResponse 와 동일 규격)
+type GovRegistration struct {
+	HosID              string       `json:"hos_id"`
+	GovID              string       `json:"gov_id"`
+	GovBoot            string       `json:"gov_boot"`
+	GovPeers           []string     `json:"gov_peers"`
+	ContractTemplate   ContractData `json:"contract_template"`
+	OnboardingRequired bool         `json:"onboarding_required"`
+	OnboardingStatus   string       `json:"onboarding_status,omitempty"`
+}
+
+var govLink = struct {
+	sync.RWMutex
+	reg *GovRegistration // 마지막 등록 응답 (등록 전이면 nil)
+}{}
+
+func govRegistration() *GovRegistration {
+	govLink.RLock()
+	defer govLink.RUnlock()
+	return govLink.reg
+}
+
+// 등록된 Gov 노드 목록 (등록 전이면 nil)
+func govPeers() []string {
+	if reg := govRegistration(); reg != nil {
+		return reg.GovPeers
+	}
+	return nil
+}
+
+func govRegisterDigest(hosID, hosBoot, pubPem, ts string) string {
+	return sha256Hex([]byte(fmt.Sprintf("register_hos_chain|%s|%s|%s|%s", hosID, hosBoot, pubKeyFingerprint(pubPem), ts)))
+}
+
+// Gov 부트노드에 한 번 등록 요청 (status : Gov 응답 상태, 전송 실패면 0)
+func requestGovRegistration() (*GovRegistration, int, error) {
+	pubPem, _ := getMeta("meta_hos_pubkey")
+	privPem, _ := nodePrivKey()
+	ts := time.Now().UTC().Format(time.RFC3339)
+	body, _ := json.Marshal(map[string]string{
+		"hos_id":   selfID(),
+		"hos_boot": self,
+		"pub_key":  pubPem,
+		"ts":       ts,
+		"sig":      makeAnchorSignature(privPem, govRegisterDigest(selfID(), self, pubPem, ts), ""),
+	})
+	resp, err := nodeClient.Post(nodeURL(getGovBoot(), "/registerHosChain"), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, resp.StatusCode, fmt.Errorf("%s", strings.TrimSpace(string(msg)))
+	}
+	var reg GovRegistration
+	if err := json.NewDecoder(resp.Body).Decode(&reg); err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("invalid registration response: %w", err)
+	}
+	return &reg, resp.StatusCode, nil
+}
+
+// Gov 체인에 이 Hos 체인 등록 (부트노드에서만, 실패 시 재시도)
+func registerWithGov() {
+	for attempt := 1; attempt <= GovRegisterMaxAttempts; attempt++ {
+		if !isBoot.Load() {
+			return
+		}
+		reg, status, err := requestGovRegistration()
+		if err == nil {
+			govLink.Lock()
+			govLink.reg = reg
+			govLink.Unlock()
+			if reg.GovBoot != "" && reg.GovBoot != getGovBoot() {
+				setGovBoot(reg.GovBoot)
+			}
+			log.Printf("[GOVREG][OK] Registered with Gov %s (%s), %d gov nodes, onboarding_required=%v status=%q",
+				reg.GovID, getGovBoot(), len(reg.GovPeers), reg.OnboardingRequired, reg.OnboardingStatus)
+			return
+		}
+		if status != 0 && status < 500 {
+			log.Printf("[GOVREG][WARN] Gov rejected registration (status=%d): %v", status, err)
+			return
+		}
+		log.Printf("[GOVREG][ERROR] attempt %d failed: %v", attempt, err)
+		time.Sleep(GovRegisterRetryInterval * time.Second)
+	}
+	log.Printf("[GOVREG][ERROR] giving up after %d attempts", GovRegisterMaxAttempts)
+}
@@ -0,0 +1,367 @@
+package main
+
+//go:generate protoc --go_out=. --go_opt=module=hos --go-grpc_out=. --go-grpc_opt=module=hos -I proto proto/hos.proto
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net"
+	"net/http"
+
+	"hos/hospb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// gRPC 서비스 (REST API 와 같은 저장소/체인 계층을 쓰는 타입 있는 API)
+// ------------------------------------------------------------
+// - GRPC_PORT 지정 시에만 기동 (미지정이면 비활성), 서비스 정의는 proto/hos.proto (생성 코드 : hospb)
+// - mTLS 활성 시 노드 인증서로 TLS 제공 (클라이언트 인증서는 요구하지 않음)
+// - RPC 와 REST 대응
+//   · GetBlock / GetLatestBlock / ListBlocks : /block/index, /block/hash, /block/latest, /blocks
+//   · SubmitRecords : POST /upload (중복/재전송 거부, 상주 리전 분리 동일)
+//   · SearchRecords / GetProof : /search, /proof (포함 정보와 앵커 상태 포함)
+//   · GetAnchorStatus : 블록 루트의 Gov 앵커 상태 (inclusion.go 캐시 사용)
+//   · SubscribeBlocks : block_finalized 이벤트 구독 후 확정 블록 본문 push
+//     (from_index 지정 시 기존 블록부터, 이벤트 버퍼가 넘쳐 건너뛴 블록은 장부에서 다시 읽어 채움)
+////////////////////////////////////////////////////////////////////////////////
+
+const GRPCDefaultPageSize = 50
+
+type hosGRPC struct {
+	hospb.UnimplementedHosChainServer
+}
+
+// GRPC_PORT 로 gRPC 서버 기동 (블로킹)
+func serveGRPC(port string) error {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+	var opts []grpc.ServerOption
+	if tlsEnabled {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{tlsCert},
+			MinVersion:   tls.VersionTLS12,
+		})))
+	}
+	srv := grpc.NewServer(opts...)
+	hospb.RegisterHosChainServer(srv, &hosGRPC{})
+	log.Printf("[GRPC] serving HosChain on :%s (tls=%v)", port, tlsEnabled)
+	return srv.Serve(lis)
+}
+
+func (s *hosGRPC) GetBlock(_ context.Context, req *hospb.GetBlockRequest) (*hospb.Block, error) {
+	var (
+		b   LowerBlock
+		err error
+	)
+	switch by := req.By.(type) {
+	case *hospb.GetBlockRequest_Index:
+		b, err = getBlockByIndex(int(by.Index))
+	case *hospb.GetBlockRequest_Hash:
+		b, err = getBlockByHash(by.Hash)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "index or hash required")
+	}
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+	return blockToPB(b), nil
+}
+
+func (s *hosGRPC) GetLatestBlock(context.Context, *hospb.GetLatestBlockRequest) (*hospb.Block, error) {
+	blocks, err := listRecentBlocks(1)
+	if err != nil || len(blocks) == 0 {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+	return blockToPB(blocks[0]), nil
+}
+
+func (s *hosGRPC) ListBlocks(_ context.Context, req *hospb.ListBlocksRequest) (*hospb.ListBlocksResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = GRPCDefaultPageSize
+	}
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid offset")
+	}
+	blocks, total, err := listBlocksPaginated(int(req.Offset), limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list blocks error: %v", err)
+	}
+	out := &hospb.ListBlocksResponse{Total: int64(total)}
+	for _, b := range blocks {
+		out.Blocks = append(out.Blocks, blockToPB(b))
+	}
+	return out, nil
+}
+
+func (s *hosGRPC) SubmitRecords(_ context.Context, req *hospb.SubmitRecordsRequest) (*hospb.SubmitRecordsResponse, error) {
+	recs := make([]ClinicRecord, 0, len(req.Records))
+	for _, r := range req.Records {
+		recs = append(recs, recordFromPB(r))
+	}
+	count, rejected, code, err := submitRecords(recs)
+	if err != nil {
+		return nil, status.Error(httpToGRPCCode(code), err.Error())
+	}
+	out := &hospb.SubmitRecordsResponse{Accepted: int64(count)}
+	for _, rj := range rejected {
+		out.Rejected = append(out.Rejected, &hospb.RejectedRecord{
+			Index:    int64(rj.Index),
+			ClinicId: rj.ClinicID,
+			Hash:     rj.Hash,
+			Reason:   rj.Reason,
+		})
+	}
+	return out, nil
+}
+
+func (s *hosGRPC) SearchRecords(_ context.Context, req *hospb.SearchRecordsRequest) (*hospb.SearchRecordsResponse, error) {
+	if req.Keyword == "" {
+		return nil, status.Error(codes.InvalidArgument, "keyword required")
+	}
+	if req.Offset < 0 || req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset and limit must be non-negative")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = SearchDefaultLimit
+	}
+	results, total, err := searchClinic(req.Keyword, req.IncludeExpired, int(req.Offset), min(limit, SearchMaxLimit))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	out := &hospb.SearchRecordsResponse{Total: int64(total)}
+	for _, r := range results {
+		out.Items = append(out.Items, &hospb.RecordProof{
+			Record: recordToPB(r.Record),
+			Proof: proofToPB(ProofResponse{
+				BlockRoot:  r.BlockRoot,
+				LatestRoot: r.LatestRoot,
+				Leaf:       r.Leaf,
+				Proof:      r.Proof,
+				Inclusion:  r.Inclusion,
+			}),
+			Retention: r.Retention,
+		})
+	}
+	return out, nil
+}
+
+func (s *hosGRPC) GetProof(_ context.Context, req *hospb.GetProofRequest) (*hospb.Proof, error) {
+	if req.BlockIndex < 0 || req.EntryIndex < 0 {
+		return nil, status.Error(codes.InvalidArgument, "block_index and entry_index must be non-negative")
+	}
+	res, err := proofFor(int(req.BlockIndex), int(req.EntryIndex))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return proofToPB(res), nil
+}
+
+func (s *hosGRPC) GetAnchorStatus(_ context.Context, req *hospb.GetAnchorStatusRequest) (*hospb.AnchorStatus, error) {
+	if req.Root == "" {
+		return nil, status.Error(codes.InvalidArgument, "root required")
+	}
+	st, upper := lookupAnchorStatus(ch.hosID, req.Root)
+	out := &hospb.AnchorStatus{HosId: ch.hosID, Root: req.Root, AnchorStatus: st}
+	if upper != nil {
+		u := int64(*upper)
+		out.UpperBlockIndex = &u
+	}
+	return out, nil
+}
+
+func (s *hosGRPC) SubscribeBlocks(req *hospb.SubscribeBlocksRequest, stream grpc.ServerStreamingServer[hospb.Block]) error {
+	// 기존 블록 전송 중 확정된 블록을 놓치지 않도록 먼저 구독
+	sub := subscribeEvents()
+	defer unsubscribeEvents(sub)
+
+	next := -1 // 다음으로 보낼 블록 번호 (-1 : 아직 기준 없음)
+	if req.FromIndex != nil {
+		next = int(*req.FromIndex)
+	}
+	// next 부터 upto 까지 장부에서 읽어 전송
+	sendUpTo := func(upto int) error {
+		for ; next <= upto; next++ {
+			b, err := getBlockByIndex(next)
+			if err != nil {
+				return status.Errorf(codes.Internal, "load block #%d: %v", next, err)
+			}
+			if err := stream.Send(blockToPB(b)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if next >= 0 {
+		h, _ := getLatestHeight() // 제네시스만 있으면 0
+		if err := sendUpTo(h); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case ev := <-sub:
+			if ev.Type != EventBlockFinalized {
+				continue
+			}
+			hdr, ok := ev.Data.(LowerBlockHeader)
+			if !ok {
+				continue
+			}
+			if next < 0 {
+				next = hdr.Index
+			}
+			if err := sendUpTo(hdr.Index); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// REST 상태 코드 => gRPC 코드 (공통 처리 함수가 HTTP 상태를 돌려주는 경우)
+func httpToGRPCCode(code int) codes.Code {
+	switch code {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests, http.StatusRequestEntityTooLarge:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 변환 (장부 구조체 <=> protobuf 메시지)
+////////////////////////////////////////////////////////////////////////////////
+
+func blockToPB(b LowerBlock) *hospb.Block {
+	out := &hospb.Block{
+		Index:      int64(b.Index),
+		HosId:      b.HosID,
+		PrevHash:   b.PrevHash,
+		Timestamp:  b.Timestamp,
+		MerkleRoot: b.MerkleRoot,
+		Proposer:   b.Proposer,
+		BlockHash:  b.BlockHash,
+		Elapsed:    b.Elapsed,
+		LeafHashes: b.LeafHashes,
+		Pruned:     b.Pruned,
+	}
+	for _, e := range b.Entries {
+		out.Entries = append(out.Entries, recordToPB(e))
+	}
+	for _, s := range b.Signatures {
+		out.Signatures = append(out.Signatures, &hospb.ConsensusSig{Addr: s.Addr, PubkeyFingerprint: s.KeyFP, Sig: s.Sig})
+	}
+	return out
+}
+
+func recordToPB(r ClinicRecord) *hospb.ClinicRecord {
+	out := &hospb.ClinicRecord{
+		ClinicId:  r.ClinicID,
+		Info:      mapToStruct(r.Info),
+		PatientId: r.PatientID,
+		PrescCode: r.PrescCode,
+		ClinicHis: mapToStruct(r.ClinicHis),
+		Timestamp: r.Timestamp,
+		Residency: r.Residency,
+		Salt:      r.Salt,
+		Priority:  int32(r.Priority),
+	}
+	if r.Revocation != nil {
+		out.Revocation = &hospb.RevocationRecord{Targets: r.Revocation.Targets, Reason: r.Revocation.Reason}
+	}
+	if s := r.Sealed; s != nil {
+		out.Sealed = &hospb.SealedPHI{Kid: s.KeyID, Alg: s.Alg, WrappedKey: s.WrappedKey, Nonce: s.Nonce, Ciphertext: s.Ciphertext}
+	}
+	if v := r.Validator; v != nil {
+		out.Validator = &hospb.ValidatorChange{Op: v.Op, Addr: v.Addr, PubKey: v.PubKey, EffectiveHeight: int64(v.EffectiveHeight)}
+	}
+	if e := r.Evidence; e != nil {
+		out.Evidence = &hospb.Evidence{Type: e.Type, Offender: e.Offender, PubkeyFingerprint: e.KeyFP, View: int64(e.View), Round: int64(e.Round),
+			Phase: e.Phase, Reason: e.Reason, Reporter: e.Reporter}
+	}
+	return out
+}
+
+func recordFromPB(r *hospb.ClinicRecord) ClinicRecord {
+	rec := ClinicRecord{
+		ClinicID:  r.GetClinicId(),
+		PatientID: r.GetPatientId(),
+		PrescCode: r.GetPrescCode(),
+		Timestamp: r.GetTimestamp(),
+		Residency: r.GetResidency(),
+		Salt:      r.GetSalt(),
+		Priority:  int(r.GetPriority()),
+	}
+	if r.GetInfo() != nil {
+		rec.Info = r.GetInfo().AsMap()
+	}
+	if r.GetClinicHis() != nil {
+		rec.ClinicHis = r.GetClinicHis().AsMap()
+	}
+	// 툼스톤은 /revoke 로만 접수하므로 revocation 은 읽지 않음
+	if s := r.GetSealed(); s != nil {
+		rec.Sealed = &SealedPHI{KeyID: s.GetKid(), Alg: s.GetAlg(), WrappedKey: s.GetWrappedKey(), Nonce: s.GetNonce(), Ciphertext: s.GetCiphertext()}
+	}
+	return rec
+}
+
+// JSON 으로 읽은 map 은 항상 변환 가능 (실패 시 필드 생략)
+func mapToStruct(m map[string]interface{}) *structpb.Struct {
+	if m == nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		log.Printf("[GRPC][WARN] map field not representable: %v", err)
+		return nil
+	}
+	return s
+}
+
+func proofToPB(p ProofResponse) *hospb.Proof {
+	out := &hospb.Proof{
+		BlockRoot:  p.BlockRoot,
+		LatestRoot: p.LatestRoot,
+		Leaf:       p.Leaf,
+		Pruned:     p.Pruned,
+		Inclusion: &hospb.Inclusion{
+			BlockIndex:     int64(p.Inclusion.BlockIndex),
+			EntryIndex:     int64(p.Inclusion.EntryIndex),
+			BlockTimestamp: p.Inclusion.BlockTimestamp,
+			Confirmations:  int64(p.Inclusion.Confirmations),
+			Final:          p.Inclusion.Final,
+			AnchorStatus:   p.Inclusion.AnchorStatus,
+		},
+	}
+	if p.Inclusion.UpperBlockIndex != nil {
+		u := int64(*p.Inclusion.UpperBlockIndex)
+		out.Inclusion.UpperBlockIndex = &u
+	}
+	for _, step := range p.Proof {
+		out.Proof = append(out.Proof, &hospb.ProofStep{Direction: step[0], Sibling: step[1]})
+	}
+	return out
+}
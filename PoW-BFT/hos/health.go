@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Liveness / Readiness (/healthz, /readyz)
+// ------------------------------------------------------------
+// - GET /healthz : 프로세스 생존 확인 (DB/피어 조회 없이 항상 200, liveness probe 용)
+// - GET /readyz  : 요청을 받을 준비 여부 (readiness probe 용, 준비되지 않으면 503 + 실패 항목)
+//   · db      : LevelDB 가 열려 있고 읽기 가능
+//   · genesis : block_0 존재
+//   · synced  : 확인된 피어 최대 높이와의 차이가 ReadyMaxLag 블록 이내 (READY_MAX_LAG)
+//   · clock   : CLOCK_SKEW_MODE=refuse 일 때 시계 오차가 CLOCK_SKEW_MAX 이내 (timesync.go)
+// - 무거운 /status 와 달리 피어 조회/통계 집계 없음, 부하 제한 대상 아님 (loadshed.go)
+// - 준비되지 않은 노드는 블록 제안(/bft/start)을 피어에 전파하지 않음
+//   (뒤처진 노드가 이미 지나간 높이를 제안하지 않도록, 제안 차례는 view-change 로 다음 노드에게 넘어감)
+////////////////////////////////////////////////////////////////////////////////
+
+var ReadyMaxLag = 5 // 준비 상태로 볼 최대 동기화 지연 (블록)
+
+// 준비 상태 점검 (항목 => 실패 사유, 모두 통과하면 빈 맵)
+func readinessFailures() map[string]string {
+	failed := map[string]string{}
+	if db == nil {
+		failed["db"] = "not open"
+		return failed
+	}
+	if _, err := db.Get([]byte("height_latest"), nil); err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		failed["db"] = err.Error()
+		return failed
+	}
+	if _, err := getBlockByIndex(0); err != nil {
+		failed["genesis"] = "missing"
+	}
+	height, _ := getLatestHeight()
+	if lag := syncLag(height); lag > ReadyMaxLag {
+		failed["synced"] = fmt.Sprintf("%d blocks behind best peer (max %d)", lag, ReadyMaxLag)
+	}
+	if reason := clockReadinessFailure(); reason != "" {
+		failed["clock"] = reason
+	}
+	return failed
+}
+
+// 이 노드가 준비 상태인지
+func nodeReady() bool {
+	return len(readinessFailures()) == 0
+}
+
+// GET /healthz
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GET /readyz
+func handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if failed := readinessFailures(); len(failed) > 0 {
+		writeErrorDetail(w, http.StatusServiceUnavailable, "not_ready", "node is not ready", failed)
+		return
+	}
+	height, _ := getLatestHeight()
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ready", "height": height})
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Record History (clinic_id 별 레코드 버전 이력)
+// ------------------------------------------------------------
+// - 같은 clinic_id 로 다시 접수된 레코드는 이전 레코드를 대체하지 않고 새 버전으로 누적
+//   · 버전 N = 장부 순서상 N 번째로 확정된 레코드 (cid_<ClinicID> 포인터 목록의 N 번째)
+//   · cidv_<ClinicID>_v<N> => "bi:ei" 로 버전별 위치를 바로 조회
+//     (cid_ 와 접두어를 나눠 "_v<N>" 으로 끝나는 clinic_id 와 키가 겹치지 않게 함)
+//   · 색인 재구성(/admin/reindex) 시 기존 레코드의 버전 키도 채워짐
+//   · 분기 교체로 cid_ 목록이 줄면 그 뒤 버전 키도 삭제 (reorg.go rollbackTo)
+// - GET /content/<clinic_id>/history[?version=<N>][&offset=<int>&limit=<int>]
+//   · 버전별 레코드 + 포함 증명(block_root, proof, inclusion) + 철회 표시(revoked)
+//   · 본문이 정리된 블록의 버전은 record 없이 증명만 제공 (pruned=true)
+//   · version 지정 시 해당 버전 하나만 반환 (없으면 404)
+//   · 응답 본문은 오래된 버전부터 배열, 전체 버전 수는 X-Total-Count 헤더
+////////////////////////////////////////////////////////////////////////////////
+
+const versionPrefix = "cidv_"
+
+// 레코드 버전 하나 (포함 증명 포함)
+type HistoryEntry struct {
+	Version int           `json:"version"`
+	Record  *ClinicRecord `json:"record,omitempty"` // 정리된 블록이면 없음
+	ProofResponse
+}
+
+func versionKey(clinicID string, n int) []byte {
+	return []byte(fmt.Sprintf("%s%s_v%d", versionPrefix, clinicID, n))
+}
+
+// cid_ 포인터 목록(list) 중 이번 블록에서 추가된 포인터(ptrs)의 버전 키 기록
+func putVersionKeys(batch kvWriter, clinicID string, list, ptrs []string) {
+	pos := make(map[string]int, len(list))
+	for i, p := range list {
+		pos[p] = i + 1
+	}
+	for _, p := range ptrs {
+		if n, ok := pos[p]; ok {
+			batch.Put(versionKey(clinicID, n), []byte(p))
+		}
+	}
+}
+
+// 버전 from+1 ~ to 의 버전 키 삭제 (분기 교체로 cid_ 목록이 from 개로 줄었을 때)
+func deleteVersionKeys(batch kvWriter, clinicID string, from, to int) {
+	for n := from + 1; n <= to; n++ {
+		batch.Delete(versionKey(clinicID, n))
+	}
+}
+
+// 버전 n 의 위치 (버전 키가 없으면 cid_ 목록 순서로 조회 : 재색인 전 데이터)
+func versionPointer(rd dbReader, clinicID string, n int) (RecordPtr, bool) {
+	if v, err := rd.Get(versionKey(clinicID, n), nil); err == nil {
+		if bi, ei, ok := parsePtr(string(v)); ok {
+			return RecordPtr{bi, ei}, true
+		}
+	}
+	ptrs := indexPointers(rd, "cid_"+clinicID)
+	if n < 1 || n > len(ptrs) {
+		return RecordPtr{}, false
+	}
+	return ptrs[n-1], true
+}
+
+// 포인터 위치의 버전 항목 생성
+func historyEntryFrom(rd dbReader, version int, p RecordPtr) (HistoryEntry, error) {
+	blk, err := getBlockByIndexForPointer(rd, p.BlockIndex)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	if p.EntryIndex >= len(blk.LeafHashes) {
+		return HistoryEntry{}, fmt.Errorf("entry %d not in block_%d", p.EntryIndex, p.BlockIndex)
+	}
+	e := HistoryEntry{Version: version, ProofResponse: proofFrom(rd, blk, p.EntryIndex)}
+	if p.EntryIndex < len(blk.Entries) {
+		rec := blk.Entries[p.EntryIndex]
+		e.Record = &rec
+	}
+	return e, nil
+}
+
+// clinic_id 의 버전 이력 (offset/limit 구간만), 전체 버전 수 반환
+func recordHistory(clinicID string, offset, limit int) ([]HistoryEntry, int, error) {
+	var out []HistoryEntry
+	total := 0
+	err := withReadSnapshot(func(rd dbReader) error {
+		ptrs := indexPointers(rd, "cid_"+clinicID)
+		total = len(ptrs)
+		for i := min(offset, total); i < min(offset+limit, total); i++ {
+			e, err := historyEntryFrom(rd, i+1, ptrs[i])
+			if err != nil {
+				return err
+			}
+			out = append(out, e)
+		}
+		return nil
+	})
+	return out, total, err
+}
+
+// clinic_id 의 버전 n 하나
+func recordVersion(clinicID string, n int) (HistoryEntry, error) {
+	var e HistoryEntry
+	err := withReadSnapshot(func(rd dbReader) error {
+		p, ok := versionPointer(rd, clinicID, n)
+		if !ok {
+			return fmt.Errorf("version %d of %s not found", n, clinicID)
+		}
+		var err error
+		e, err = historyEntryFrom(rd, n, p)
+		return err
+	})
+	return e, err
+}
+
+// GET /content/<clinic_id>/history
+func handleContentHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	id, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/content/"), "/history")
+	if !ok || id == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if v := r.URL.Query().Get("version"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "version must be a positive integer")
+			return
+		}
+		e, err := recordVersion(id, n)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, e)
+		return
+	}
+
+	offset, limit, err := searchPage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	items, total, err := recordHistory(id, offset, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if total == 0 {
+		writeError(w, http.StatusNotFound, "no record for clinic_id: "+id)
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSON(w, http.StatusOK, items)
+}
@@ -0,0 +1,2545 @@
+// Hos 체인 gRPC 서비스 정의 (REST API 와 같은 저장소/체인 계층 공유, grpc.go)
+// 생성 : go generate (hospb 패키지)
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: hos.proto
+
+package hospb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ClinicRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClinicId      string                 `protobuf:"bytes,1,opt,name=clinic_id,json=clinicId,proto3" json:"clinic_id,omitempty"`
+	Info          *structpb.Struct       `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	PatientId     string                 `protobuf:"bytes,3,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	PrescCode     string                 `protobuf:"bytes,4,opt,name=presc_code,json=prescCode,proto3" json:"presc_code,omitempty"`
+	ClinicHis     *structpb.Struct       `protobuf:"bytes,5,opt,name=clinic_his,json=clinicHis,proto3" json:"clinic_his,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Residency     string                 `protobuf:"bytes,7,opt,name=residency,proto3" json:"residency,omitempty"`
+	Revocation    *RevocationRecord      `protobuf:"bytes,8,opt,name=revocation,proto3" json:"revocation,omitempty"` // 툼스톤일 때만 (revoke.go)
+	Sealed        *SealedPHI             `protobuf:"bytes,9,opt,name=sealed,proto3" json:"sealed,omitempty"`         // 암호화된 info/clinic_his (phi.go)
+	Salt          string                 `protobuf:"bytes,10,opt,name=salt,proto3" json:"salt,omitempty"`            // 필드별 머클 leaf 용 레코드 salt (disclosure.go)
+	Validator     *ValidatorChange       `protobuf:"bytes,11,opt,name=validator,proto3" json:"validator,omitempty"`  // 검증자 변경 레코드일 때만 (validators.go)
+	Evidence      *Evidence              `protobuf:"bytes,12,opt,name=evidence,proto3" json:"evidence,omitempty"`    // 부정 행위 증거 레코드일 때만 (evidence.go, 투표/블록 본문은 REST 로 조회)
+	Priority      int32                  `protobuf:"varint,13,opt,name=priority,proto3" json:"priority,omitempty"`   // 블록 포함 우선순위 0~9 (priority.go)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClinicRecord) Reset() {
+	*x = ClinicRecord{}
+	mi := &file_hos_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClinicRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClinicRecord) ProtoMessage() {}
+
+func (x *ClinicRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClinicRecord.ProtoReflect.Descriptor instead.
+func (*ClinicRecord) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ClinicRecord) GetClinicId() string {
+	if x != nil {
+		return x.ClinicId
+	}
+	return ""
+}
+
+func (x *ClinicRecord) GetInfo() *structpb.Struct {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *ClinicRecord) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+func (x *ClinicRecord) GetPrescCode() string {
+	if x != nil {
+		return x.PrescCode
+	}
+	return ""
+}
+
+func (x *ClinicRecord) GetClinicHis() *structpb.Struct {
+	if x != nil {
+		return x.ClinicHis
+	}
+	return nil
+}
+
+func (x *ClinicRecord) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *ClinicRecord) GetResidency() string {
+	if x != nil {
+		return x.Residency
+	}
+	return ""
+}
+
+func (x *ClinicRecord) GetRevocation() *RevocationRecord {
+	if x != nil {
+		return x.Revocation
+	}
+	return nil
+}
+
+func (x *ClinicRecord) GetSealed() *SealedPHI {
+	if x != nil {
+		return x.Sealed
+	}
+	return nil
+}
+
+func (x *ClinicRecord) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *ClinicRecord) GetValidator() *ValidatorChange {
+	if x != nil {
+		return x.Validator
+	}
+	return nil
+}
+
+func (x *ClinicRecord) GetEvidence() *Evidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *ClinicRecord) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type Evidence struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Type              string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // double_sign | invalid_proposal
+	Offender          string                 `protobuf:"bytes,2,opt,name=offender,proto3" json:"offender,omitempty"`
+	PubkeyFingerprint string                 `protobuf:"bytes,3,opt,name=pubkey_fingerprint,json=pubkeyFingerprint,proto3" json:"pubkey_fingerprint,omitempty"`
+	View              int64                  `protobuf:"varint,4,opt,name=view,proto3" json:"view,omitempty"`
+	Round             int64                  `protobuf:"varint,5,opt,name=round,proto3" json:"round,omitempty"`
+	Phase             string                 `protobuf:"bytes,6,opt,name=phase,proto3" json:"phase,omitempty"`
+	Reason            string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Reporter          string                 `protobuf:"bytes,9,opt,name=reporter,proto3" json:"reporter,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Evidence) Reset() {
+	*x = Evidence{}
+	mi := &file_hos_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Evidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evidence) ProtoMessage() {}
+
+func (x *Evidence) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evidence.ProtoReflect.Descriptor instead.
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Evidence) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Evidence) GetOffender() string {
+	if x != nil {
+		return x.Offender
+	}
+	return ""
+}
+
+func (x *Evidence) GetPubkeyFingerprint() string {
+	if x != nil {
+		return x.PubkeyFingerprint
+	}
+	return ""
+}
+
+func (x *Evidence) GetView() int64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *Evidence) GetRound() int64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Evidence) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *Evidence) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Evidence) GetReporter() string {
+	if x != nil {
+		return x.Reporter
+	}
+	return ""
+}
+
+type ValidatorChange struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Op              string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"` // join | leave
+	Addr            string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	PubKey          string                 `protobuf:"bytes,3,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	EffectiveHeight int64                  `protobuf:"varint,4,opt,name=effective_height,json=effectiveHeight,proto3" json:"effective_height,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ValidatorChange) Reset() {
+	*x = ValidatorChange{}
+	mi := &file_hos_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatorChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorChange) ProtoMessage() {}
+
+func (x *ValidatorChange) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorChange.ProtoReflect.Descriptor instead.
+func (*ValidatorChange) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValidatorChange) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ValidatorChange) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *ValidatorChange) GetPubKey() string {
+	if x != nil {
+		return x.PubKey
+	}
+	return ""
+}
+
+func (x *ValidatorChange) GetEffectiveHeight() int64 {
+	if x != nil {
+		return x.EffectiveHeight
+	}
+	return 0
+}
+
+type RevocationRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Targets       []string               `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevocationRecord) Reset() {
+	*x = RevocationRecord{}
+	mi := &file_hos_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevocationRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevocationRecord) ProtoMessage() {}
+
+func (x *RevocationRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevocationRecord.ProtoReflect.Descriptor instead.
+func (*RevocationRecord) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RevocationRecord) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *RevocationRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SealedPHI struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kid           string                 `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`
+	Alg           string                 `protobuf:"bytes,2,opt,name=alg,proto3" json:"alg,omitempty"`
+	WrappedKey    string                 `protobuf:"bytes,3,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	Nonce         string                 `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Ciphertext    string                 `protobuf:"bytes,5,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SealedPHI) Reset() {
+	*x = SealedPHI{}
+	mi := &file_hos_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SealedPHI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SealedPHI) ProtoMessage() {}
+
+func (x *SealedPHI) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SealedPHI.ProtoReflect.Descriptor instead.
+func (*SealedPHI) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SealedPHI) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *SealedPHI) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
+}
+
+func (x *SealedPHI) GetWrappedKey() string {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return ""
+}
+
+func (x *SealedPHI) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *SealedPHI) GetCiphertext() string {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return ""
+}
+
+type ConsensusSig struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Addr              string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	PubkeyFingerprint string                 `protobuf:"bytes,2,opt,name=pubkey_fingerprint,json=pubkeyFingerprint,proto3" json:"pubkey_fingerprint,omitempty"`
+	Sig               string                 `protobuf:"bytes,3,opt,name=sig,proto3" json:"sig,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConsensusSig) Reset() {
+	*x = ConsensusSig{}
+	mi := &file_hos_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsensusSig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusSig) ProtoMessage() {}
+
+func (x *ConsensusSig) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusSig.ProtoReflect.Descriptor instead.
+func (*ConsensusSig) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConsensusSig) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *ConsensusSig) GetPubkeyFingerprint() string {
+	if x != nil {
+		return x.PubkeyFingerprint
+	}
+	return ""
+}
+
+func (x *ConsensusSig) GetSig() string {
+	if x != nil {
+		return x.Sig
+	}
+	return ""
+}
+
+type Block struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	HosId         string                 `protobuf:"bytes,2,opt,name=hos_id,json=hosId,proto3" json:"hos_id,omitempty"`
+	PrevHash      string                 `protobuf:"bytes,3,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Entries       []*ClinicRecord        `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	MerkleRoot    string                 `protobuf:"bytes,6,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	Proposer      string                 `protobuf:"bytes,7,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Signatures    []*ConsensusSig        `protobuf:"bytes,8,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	BlockHash     string                 `protobuf:"bytes,9,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Elapsed       float32                `protobuf:"fixed32,10,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	LeafHashes    []string               `protobuf:"bytes,11,rep,name=leaf_hashes,json=leafHashes,proto3" json:"leaf_hashes,omitempty"`
+	Pruned        bool                   `protobuf:"varint,12,opt,name=pruned,proto3" json:"pruned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_hos_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Block) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Block) GetHosId() string {
+	if x != nil {
+		return x.HosId
+	}
+	return ""
+}
+
+func (x *Block) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *Block) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *Block) GetEntries() []*ClinicRecord {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *Block) GetMerkleRoot() string {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return ""
+}
+
+func (x *Block) GetProposer() string {
+	if x != nil {
+		return x.Proposer
+	}
+	return ""
+}
+
+func (x *Block) GetSignatures() []*ConsensusSig {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *Block) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *Block) GetElapsed() float32 {
+	if x != nil {
+		return x.Elapsed
+	}
+	return 0
+}
+
+func (x *Block) GetLeafHashes() []string {
+	if x != nil {
+		return x.LeafHashes
+	}
+	return nil
+}
+
+func (x *Block) GetPruned() bool {
+	if x != nil {
+		return x.Pruned
+	}
+	return false
+}
+
+type GetBlockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to By:
+	//
+	//	*GetBlockRequest_Index
+	//	*GetBlockRequest_Hash
+	By            isGetBlockRequest_By `protobuf_oneof:"by"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	mi := &file_hos_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBlockRequest) GetBy() isGetBlockRequest_By {
+	if x != nil {
+		return x.By
+	}
+	return nil
+}
+
+func (x *GetBlockRequest) GetIndex() int64 {
+	if x != nil {
+		if x, ok := x.By.(*GetBlockRequest_Index); ok {
+			return x.Index
+		}
+	}
+	return 0
+}
+
+func (x *GetBlockRequest) GetHash() string {
+	if x != nil {
+		if x, ok := x.By.(*GetBlockRequest_Hash); ok {
+			return x.Hash
+		}
+	}
+	return ""
+}
+
+type isGetBlockRequest_By interface {
+	isGetBlockRequest_By()
+}
+
+type GetBlockRequest_Index struct {
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3,oneof"`
+}
+
+type GetBlockRequest_Hash struct {
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3,oneof"`
+}
+
+func (*GetBlockRequest_Index) isGetBlockRequest_By() {}
+
+func (*GetBlockRequest_Hash) isGetBlockRequest_By() {}
+
+type GetLatestBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestBlockRequest) Reset() {
+	*x = GetLatestBlockRequest{}
+	mi := &file_hos_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestBlockRequest) ProtoMessage() {}
+
+func (x *GetLatestBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestBlockRequest) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{8}
+}
+
+type ListBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 0 이면 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksRequest) Reset() {
+	*x = ListBlocksRequest{}
+	mi := &file_hos_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksRequest) ProtoMessage() {}
+
+func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlocksRequest.ProtoReflect.Descriptor instead.
+func (*ListBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListBlocksRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListBlocksRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListBlocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Blocks        []*Block               `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksResponse) Reset() {
+	*x = ListBlocksResponse{}
+	mi := &file_hos_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksResponse) ProtoMessage() {}
+
+func (x *ListBlocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlocksResponse.ProtoReflect.Descriptor instead.
+func (*ListBlocksResponse) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListBlocksResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListBlocksResponse) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type SubmitRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*ClinicRecord        `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitRecordsRequest) Reset() {
+	*x = SubmitRecordsRequest{}
+	mi := &file_hos_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitRecordsRequest) ProtoMessage() {}
+
+func (x *SubmitRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitRecordsRequest.ProtoReflect.Descriptor instead.
+func (*SubmitRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubmitRecordsRequest) GetRecords() []*ClinicRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type RejectedRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // 요청 내 위치
+	ClinicId      string                 `protobuf:"bytes,2,opt,name=clinic_id,json=clinicId,proto3" json:"clinic_id,omitempty"`
+	Hash          string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectedRecord) Reset() {
+	*x = RejectedRecord{}
+	mi := &file_hos_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectedRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedRecord) ProtoMessage() {}
+
+func (x *RejectedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedRecord.ProtoReflect.Descriptor instead.
+func (*RejectedRecord) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RejectedRecord) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RejectedRecord) GetClinicId() string {
+	if x != nil {
+		return x.ClinicId
+	}
+	return ""
+}
+
+func (x *RejectedRecord) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *RejectedRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SubmitRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      int64                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      []*RejectedRecord      `protobuf:"bytes,2,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitRecordsResponse) Reset() {
+	*x = SubmitRecordsResponse{}
+	mi := &file_hos_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitRecordsResponse) ProtoMessage() {}
+
+func (x *SubmitRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitRecordsResponse.ProtoReflect.Descriptor instead.
+func (*SubmitRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SubmitRecordsResponse) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *SubmitRecordsResponse) GetRejected() []*RejectedRecord {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+type SearchRecordsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Keyword        string                 `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	IncludeExpired bool                   `protobuf:"varint,2,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`
+	Offset         int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit          int64                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // 0 이면 50, 최대 500
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchRecordsRequest) Reset() {
+	*x = SearchRecordsRequest{}
+	mi := &file_hos_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRecordsRequest) ProtoMessage() {}
+
+func (x *SearchRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRecordsRequest.ProtoReflect.Descriptor instead.
+func (*SearchRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchRecordsRequest) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *SearchRecordsRequest) GetIncludeExpired() bool {
+	if x != nil {
+		return x.IncludeExpired
+	}
+	return false
+}
+
+func (x *SearchRecordsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchRecordsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Items         []*RecordProof         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRecordsResponse) Reset() {
+	*x = SearchRecordsResponse{}
+	mi := &file_hos_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRecordsResponse) ProtoMessage() {}
+
+func (x *SearchRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRecordsResponse.ProtoReflect.Descriptor instead.
+func (*SearchRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SearchRecordsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchRecordsResponse) GetItems() []*RecordProof {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ProofStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Direction     string                 `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"` // "L" | "R"
+	Sibling       string                 `protobuf:"bytes,2,opt,name=sibling,proto3" json:"sibling,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProofStep) Reset() {
+	*x = ProofStep{}
+	mi := &file_hos_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProofStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofStep) ProtoMessage() {}
+
+func (x *ProofStep) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofStep.ProtoReflect.Descriptor instead.
+func (*ProofStep) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ProofStep) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ProofStep) GetSibling() string {
+	if x != nil {
+		return x.Sibling
+	}
+	return ""
+}
+
+type Inclusion struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BlockIndex      int64                  `protobuf:"varint,1,opt,name=block_index,json=blockIndex,proto3" json:"block_index,omitempty"`
+	EntryIndex      int64                  `protobuf:"varint,2,opt,name=entry_index,json=entryIndex,proto3" json:"entry_index,omitempty"`
+	BlockTimestamp  string                 `protobuf:"bytes,3,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	Confirmations   int64                  `protobuf:"varint,4,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Final           bool                   `protobuf:"varint,5,opt,name=final,proto3" json:"final,omitempty"`
+	AnchorStatus    string                 `protobuf:"bytes,6,opt,name=anchor_status,json=anchorStatus,proto3" json:"anchor_status,omitempty"` // anchored | pending | unknown
+	UpperBlockIndex *int64                 `protobuf:"varint,7,opt,name=upper_block_index,json=upperBlockIndex,proto3,oneof" json:"upper_block_index,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Inclusion) Reset() {
+	*x = Inclusion{}
+	mi := &file_hos_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Inclusion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inclusion) ProtoMessage() {}
+
+func (x *Inclusion) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inclusion.ProtoReflect.Descriptor instead.
+func (*Inclusion) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Inclusion) GetBlockIndex() int64 {
+	if x != nil {
+		return x.BlockIndex
+	}
+	return 0
+}
+
+func (x *Inclusion) GetEntryIndex() int64 {
+	if x != nil {
+		return x.EntryIndex
+	}
+	return 0
+}
+
+func (x *Inclusion) GetBlockTimestamp() string {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return ""
+}
+
+func (x *Inclusion) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Inclusion) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+func (x *Inclusion) GetAnchorStatus() string {
+	if x != nil {
+		return x.AnchorStatus
+	}
+	return ""
+}
+
+func (x *Inclusion) GetUpperBlockIndex() int64 {
+	if x != nil && x.UpperBlockIndex != nil {
+		return *x.UpperBlockIndex
+	}
+	return 0
+}
+
+type Proof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockRoot     string                 `protobuf:"bytes,1,opt,name=block_root,json=blockRoot,proto3" json:"block_root,omitempty"`
+	LatestRoot    string                 `protobuf:"bytes,2,opt,name=latest_root,json=latestRoot,proto3" json:"latest_root,omitempty"`
+	Leaf          string                 `protobuf:"bytes,3,opt,name=leaf,proto3" json:"leaf,omitempty"`
+	Proof         []*ProofStep           `protobuf:"bytes,4,rep,name=proof,proto3" json:"proof,omitempty"`
+	Inclusion     *Inclusion             `protobuf:"bytes,5,opt,name=inclusion,proto3" json:"inclusion,omitempty"`
+	Pruned        bool                   `protobuf:"varint,6,opt,name=pruned,proto3" json:"pruned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Proof) Reset() {
+	*x = Proof{}
+	mi := &file_hos_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Proof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proof) ProtoMessage() {}
+
+func (x *Proof) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proof.ProtoReflect.Descriptor instead.
+func (*Proof) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Proof) GetBlockRoot() string {
+	if x != nil {
+		return x.BlockRoot
+	}
+	return ""
+}
+
+func (x *Proof) GetLatestRoot() string {
+	if x != nil {
+		return x.LatestRoot
+	}
+	return ""
+}
+
+func (x *Proof) GetLeaf() string {
+	if x != nil {
+		return x.Leaf
+	}
+	return ""
+}
+
+func (x *Proof) GetProof() []*ProofStep {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *Proof) GetInclusion() *Inclusion {
+	if x != nil {
+		return x.Inclusion
+	}
+	return nil
+}
+
+func (x *Proof) GetPruned() bool {
+	if x != nil {
+		return x.Pruned
+	}
+	return false
+}
+
+type RecordProof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Record        *ClinicRecord          `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	Proof         *Proof                 `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	Retention     string                 `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordProof) Reset() {
+	*x = RecordProof{}
+	mi := &file_hos_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordProof) ProtoMessage() {}
+
+func (x *RecordProof) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordProof.ProtoReflect.Descriptor instead.
+func (*RecordProof) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecordProof) GetRecord() *ClinicRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *RecordProof) GetProof() *Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *RecordProof) GetRetention() string {
+	if x != nil {
+		return x.Retention
+	}
+	return ""
+}
+
+type GetProofRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockIndex    int64                  `protobuf:"varint,1,opt,name=block_index,json=blockIndex,proto3" json:"block_index,omitempty"`
+	EntryIndex    int64                  `protobuf:"varint,2,opt,name=entry_index,json=entryIndex,proto3" json:"entry_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProofRequest) Reset() {
+	*x = GetProofRequest{}
+	mi := &file_hos_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProofRequest) ProtoMessage() {}
+
+func (x *GetProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProofRequest.ProtoReflect.Descriptor instead.
+func (*GetProofRequest) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetProofRequest) GetBlockIndex() int64 {
+	if x != nil {
+		return x.BlockIndex
+	}
+	return 0
+}
+
+func (x *GetProofRequest) GetEntryIndex() int64 {
+	if x != nil {
+		return x.EntryIndex
+	}
+	return 0
+}
+
+type GetAnchorStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Root          string                 `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnchorStatusRequest) Reset() {
+	*x = GetAnchorStatusRequest{}
+	mi := &file_hos_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnchorStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnchorStatusRequest) ProtoMessage() {}
+
+func (x *GetAnchorStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnchorStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetAnchorStatusRequest) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetAnchorStatusRequest) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+type AnchorStatus struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	HosId           string                 `protobuf:"bytes,1,opt,name=hos_id,json=hosId,proto3" json:"hos_id,omitempty"`
+	Root            string                 `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	AnchorStatus    string                 `protobuf:"bytes,3,opt,name=anchor_status,json=anchorStatus,proto3" json:"anchor_status,omitempty"`
+	UpperBlockIndex *int64                 `protobuf:"varint,4,opt,name=upper_block_index,json=upperBlockIndex,proto3,oneof" json:"upper_block_index,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AnchorStatus) Reset() {
+	*x = AnchorStatus{}
+	mi := &file_hos_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnchorStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnchorStatus) ProtoMessage() {}
+
+func (x *AnchorStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnchorStatus.ProtoReflect.Descriptor instead.
+func (*AnchorStatus) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AnchorStatus) GetHosId() string {
+	if x != nil {
+		return x.HosId
+	}
+	return ""
+}
+
+func (x *AnchorStatus) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *AnchorStatus) GetAnchorStatus() string {
+	if x != nil {
+		return x.AnchorStatus
+	}
+	return ""
+}
+
+func (x *AnchorStatus) GetUpperBlockIndex() int64 {
+	if x != nil && x.UpperBlockIndex != nil {
+		return *x.UpperBlockIndex
+	}
+	return 0
+}
+
+type SubscribeBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromIndex     *int64                 `protobuf:"varint,1,opt,name=from_index,json=fromIndex,proto3,oneof" json:"from_index,omitempty"` // 미지정 시 새 블록만
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeBlocksRequest) Reset() {
+	*x = SubscribeBlocksRequest{}
+	mi := &file_hos_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeBlocksRequest) ProtoMessage() {}
+
+func (x *SubscribeBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeBlocksRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubscribeBlocksRequest) GetFromIndex() int64 {
+	if x != nil && x.FromIndex != nil {
+		return *x.FromIndex
+	}
+	return 0
+}
+
+type WireBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	HosId         string                 `protobuf:"bytes,2,opt,name=hos_id,json=hosId,proto3" json:"hos_id,omitempty"`
+	PrevHash      string                 `protobuf:"bytes,3,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Entries       [][]byte               `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"` // 레코드별 JSON
+	MerkleRoot    string                 `protobuf:"bytes,6,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	Proposer      string                 `protobuf:"bytes,7,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Signatures    []*ConsensusSig        `protobuf:"bytes,8,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	BlockHash     string                 `protobuf:"bytes,9,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Elapsed       float32                `protobuf:"fixed32,10,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	LeafHashes    []string               `protobuf:"bytes,11,rep,name=leaf_hashes,json=leafHashes,proto3" json:"leaf_hashes,omitempty"`
+	Pruned        bool                   `protobuf:"varint,12,opt,name=pruned,proto3" json:"pruned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WireBlock) Reset() {
+	*x = WireBlock{}
+	mi := &file_hos_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WireBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireBlock) ProtoMessage() {}
+
+func (x *WireBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireBlock.ProtoReflect.Descriptor instead.
+func (*WireBlock) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WireBlock) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *WireBlock) GetHosId() string {
+	if x != nil {
+		return x.HosId
+	}
+	return ""
+}
+
+func (x *WireBlock) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *WireBlock) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *WireBlock) GetEntries() [][]byte {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *WireBlock) GetMerkleRoot() string {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return ""
+}
+
+func (x *WireBlock) GetProposer() string {
+	if x != nil {
+		return x.Proposer
+	}
+	return ""
+}
+
+func (x *WireBlock) GetSignatures() []*ConsensusSig {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *WireBlock) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *WireBlock) GetElapsed() float32 {
+	if x != nil {
+		return x.Elapsed
+	}
+	return 0
+}
+
+func (x *WireBlock) GetLeafHashes() []string {
+	if x != nil {
+		return x.LeafHashes
+	}
+	return nil
+}
+
+func (x *WireBlock) GetPruned() bool {
+	if x != nil {
+		return x.Pruned
+	}
+	return false
+}
+
+type WireHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	HosId         string                 `protobuf:"bytes,2,opt,name=hos_id,json=hosId,proto3" json:"hos_id,omitempty"`
+	PrevHash      string                 `protobuf:"bytes,3,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MerkleRoot    string                 `protobuf:"bytes,5,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	Proposer      string                 `protobuf:"bytes,6,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	BlockHash     string                 `protobuf:"bytes,7,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Elapsed       float32                `protobuf:"fixed32,8,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	EntryCount    int64                  `protobuf:"varint,9,opt,name=entry_count,json=entryCount,proto3" json:"entry_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WireHeader) Reset() {
+	*x = WireHeader{}
+	mi := &file_hos_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WireHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireHeader) ProtoMessage() {}
+
+func (x *WireHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireHeader.ProtoReflect.Descriptor instead.
+func (*WireHeader) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WireHeader) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *WireHeader) GetHosId() string {
+	if x != nil {
+		return x.HosId
+	}
+	return ""
+}
+
+func (x *WireHeader) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *WireHeader) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *WireHeader) GetMerkleRoot() string {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return ""
+}
+
+func (x *WireHeader) GetProposer() string {
+	if x != nil {
+		return x.Proposer
+	}
+	return ""
+}
+
+func (x *WireHeader) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *WireHeader) GetElapsed() float32 {
+	if x != nil {
+		return x.Elapsed
+	}
+	return 0
+}
+
+func (x *WireHeader) GetEntryCount() int64 {
+	if x != nil {
+		return x.EntryCount
+	}
+	return 0
+}
+
+type BftProposal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          int64                  `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Round         int64                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Leader        string                 `protobuf:"bytes,3,opt,name=leader,proto3" json:"leader,omitempty"`
+	Block         *WireBlock             `protobuf:"bytes,4,opt,name=block,proto3" json:"block,omitempty"`
+	Sig           string                 `protobuf:"bytes,5,opt,name=sig,proto3" json:"sig,omitempty"`
+	Auth          string                 `protobuf:"bytes,6,opt,name=auth,proto3" json:"auth,omitempty"` // view/round 바인딩 서명
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BftProposal) Reset() {
+	*x = BftProposal{}
+	mi := &file_hos_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BftProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BftProposal) ProtoMessage() {}
+
+func (x *BftProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BftProposal.ProtoReflect.Descriptor instead.
+func (*BftProposal) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BftProposal) GetView() int64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *BftProposal) GetRound() int64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BftProposal) GetLeader() string {
+	if x != nil {
+		return x.Leader
+	}
+	return ""
+}
+
+func (x *BftProposal) GetBlock() *WireBlock {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *BftProposal) GetSig() string {
+	if x != nil {
+		return x.Sig
+	}
+	return ""
+}
+
+func (x *BftProposal) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+type BftVote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          int64                  `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Round         int64                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Addr          string                 `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`
+	Sig           string                 `protobuf:"bytes,4,opt,name=sig,proto3" json:"sig,omitempty"`
+	Hash          string                 `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	Header        *WireHeader            `protobuf:"bytes,6,opt,name=header,proto3" json:"header,omitempty"` // 이중 서명 증거용
+	Auth          string                 `protobuf:"bytes,7,opt,name=auth,proto3" json:"auth,omitempty"`     // 단계/view/round 바인딩 서명
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BftVote) Reset() {
+	*x = BftVote{}
+	mi := &file_hos_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BftVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BftVote) ProtoMessage() {}
+
+func (x *BftVote) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BftVote.ProtoReflect.Descriptor instead.
+func (*BftVote) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BftVote) GetView() int64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *BftVote) GetRound() int64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BftVote) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *BftVote) GetSig() string {
+	if x != nil {
+		return x.Sig
+	}
+	return ""
+}
+
+func (x *BftVote) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *BftVote) GetHeader() *WireHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *BftVote) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+type BftViewChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          int64                  `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Round         int64                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Addr          string                 `protobuf:"bytes,3,opt,name=addr,proto3" json:"addr,omitempty"`
+	Sig           string                 `protobuf:"bytes,4,opt,name=sig,proto3" json:"sig,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BftViewChange) Reset() {
+	*x = BftViewChange{}
+	mi := &file_hos_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BftViewChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BftViewChange) ProtoMessage() {}
+
+func (x *BftViewChange) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BftViewChange.ProtoReflect.Descriptor instead.
+func (*BftViewChange) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BftViewChange) GetView() int64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *BftViewChange) GetRound() int64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BftViewChange) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *BftViewChange) GetSig() string {
+	if x != nil {
+		return x.Sig
+	}
+	return ""
+}
+
+type WireBlocksPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Items         []*WireBlock           `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WireBlocksPage) Reset() {
+	*x = WireBlocksPage{}
+	mi := &file_hos_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WireBlocksPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireBlocksPage) ProtoMessage() {}
+
+func (x *WireBlocksPage) ProtoReflect() protoreflect.Message {
+	mi := &file_hos_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireBlocksPage.ProtoReflect.Descriptor instead.
+func (*WireBlocksPage) Descriptor() ([]byte, []int) {
+	return file_hos_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WireBlocksPage) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *WireBlocksPage) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *WireBlocksPage) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *WireBlocksPage) GetItems() []*WireBlock {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_hos_proto protoreflect.FileDescriptor
+
+const file_hos_proto_rawDesc = "" +
+	"\n" +
+	"\thos.proto\x12\x06hos.v1\x1a\x1cgoogle/protobuf/struct.proto\"\x84\x04\n" +
+	"\fClinicRecord\x12\x1b\n" +
+	"\tclinic_id\x18\x01 \x01(\tR\bclinicId\x12+\n" +
+	"\x04info\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x04info\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x03 \x01(\tR\tpatientId\x12\x1d\n" +
+	"\n" +
+	"presc_code\x18\x04 \x01(\tR\tprescCode\x126\n" +
+	"\n" +
+	"clinic_his\x18\x05 \x01(\v2\x17.google.protobuf.StructR\tclinicHis\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\tR\ttimestamp\x12\x1c\n" +
+	"\tresidency\x18\a \x01(\tR\tresidency\x128\n" +
+	"\n" +
+	"revocation\x18\b \x01(\v2\x18.hos.v1.RevocationRecordR\n" +
+	"revocation\x12)\n" +
+	"\x06sealed\x18\t \x01(\v2\x11.hos.v1.SealedPHIR\x06sealed\x12\x12\n" +
+	"\x04salt\x18\n" +
+	" \x01(\tR\x04salt\x125\n" +
+	"\tvalidator\x18\v \x01(\v2\x17.hos.v1.ValidatorChangeR\tvalidator\x12,\n" +
+	"\bevidence\x18\f \x01(\v2\x10.hos.v1.EvidenceR\bevidence\x12\x1a\n" +
+	"\bpriority\x18\r \x01(\x05R\bpriority\"\xe3\x01\n" +
+	"\bEvidence\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\boffender\x18\x02 \x01(\tR\boffender\x12-\n" +
+	"\x12pubkey_fingerprint\x18\x03 \x01(\tR\x11pubkeyFingerprint\x12\x12\n" +
+	"\x04view\x18\x04 \x01(\x03R\x04view\x12\x14\n" +
+	"\x05round\x18\x05 \x01(\x03R\x05round\x12\x14\n" +
+	"\x05phase\x18\x06 \x01(\tR\x05phase\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x1a\n" +
+	"\breporter\x18\t \x01(\tR\breporterJ\x04\b\b\x10\t\"y\n" +
+	"\x0fValidatorChange\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x17\n" +
+	"\apub_key\x18\x03 \x01(\tR\x06pubKey\x12)\n" +
+	"\x10effective_height\x18\x04 \x01(\x03R\x0feffectiveHeight\"D\n" +
+	"\x10RevocationRecord\x12\x18\n" +
+	"\atargets\x18\x01 \x03(\tR\atargets\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x86\x01\n" +
+	"\tSealedPHI\x12\x10\n" +
+	"\x03kid\x18\x01 \x01(\tR\x03kid\x12\x10\n" +
+	"\x03alg\x18\x02 \x01(\tR\x03alg\x12\x1f\n" +
+	"\vwrapped_key\x18\x03 \x01(\tR\n" +
+	"wrappedKey\x12\x14\n" +
+	"\x05nonce\x18\x04 \x01(\tR\x05nonce\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x05 \x01(\tR\n" +
+	"ciphertext\"c\n" +
+	"\fConsensusSig\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12-\n" +
+	"\x12pubkey_fingerprint\x18\x02 \x01(\tR\x11pubkeyFingerprint\x12\x10\n" +
+	"\x03sig\x18\x03 \x01(\tR\x03sig\"\x84\x03\n" +
+	"\x05Block\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x15\n" +
+	"\x06hos_id\x18\x02 \x01(\tR\x05hosId\x12\x1b\n" +
+	"\tprev_hash\x18\x03 \x01(\tR\bprevHash\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12.\n" +
+	"\aentries\x18\x05 \x03(\v2\x14.hos.v1.ClinicRecordR\aentries\x12\x1f\n" +
+	"\vmerkle_root\x18\x06 \x01(\tR\n" +
+	"merkleRoot\x12\x1a\n" +
+	"\bproposer\x18\a \x01(\tR\bproposer\x124\n" +
+	"\n" +
+	"signatures\x18\b \x03(\v2\x14.hos.v1.ConsensusSigR\n" +
+	"signatures\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\t \x01(\tR\tblockHash\x12\x18\n" +
+	"\aelapsed\x18\n" +
+	" \x01(\x02R\aelapsed\x12\x1f\n" +
+	"\vleaf_hashes\x18\v \x03(\tR\n" +
+	"leafHashes\x12\x16\n" +
+	"\x06pruned\x18\f \x01(\bR\x06pruned\"E\n" +
+	"\x0fGetBlockRequest\x12\x16\n" +
+	"\x05index\x18\x01 \x01(\x03H\x00R\x05index\x12\x14\n" +
+	"\x04hash\x18\x02 \x01(\tH\x00R\x04hashB\x04\n" +
+	"\x02by\"\x17\n" +
+	"\x15GetLatestBlockRequest\"A\n" +
+	"\x11ListBlocksRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"Q\n" +
+	"\x12ListBlocksResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12%\n" +
+	"\x06blocks\x18\x02 \x03(\v2\r.hos.v1.BlockR\x06blocks\"F\n" +
+	"\x14SubmitRecordsRequest\x12.\n" +
+	"\arecords\x18\x01 \x03(\v2\x14.hos.v1.ClinicRecordR\arecords\"o\n" +
+	"\x0eRejectedRecord\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1b\n" +
+	"\tclinic_id\x18\x02 \x01(\tR\bclinicId\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"g\n" +
+	"\x15SubmitRecordsResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x03R\baccepted\x122\n" +
+	"\brejected\x18\x02 \x03(\v2\x16.hos.v1.RejectedRecordR\brejected\"\x87\x01\n" +
+	"\x14SearchRecordsRequest\x12\x18\n" +
+	"\akeyword\x18\x01 \x01(\tR\akeyword\x12'\n" +
+	"\x0finclude_expired\x18\x02 \x01(\bR\x0eincludeExpired\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x03R\x05limit\"X\n" +
+	"\x15SearchRecordsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12)\n" +
+	"\x05items\x18\x02 \x03(\v2\x13.hos.v1.RecordProofR\x05items\"C\n" +
+	"\tProofStep\x12\x1c\n" +
+	"\tdirection\x18\x01 \x01(\tR\tdirection\x12\x18\n" +
+	"\asibling\x18\x02 \x01(\tR\asibling\"\x9e\x02\n" +
+	"\tInclusion\x12\x1f\n" +
+	"\vblock_index\x18\x01 \x01(\x03R\n" +
+	"blockIndex\x12\x1f\n" +
+	"\ventry_index\x18\x02 \x01(\x03R\n" +
+	"entryIndex\x12'\n" +
+	"\x0fblock_timestamp\x18\x03 \x01(\tR\x0eblockTimestamp\x12$\n" +
+	"\rconfirmations\x18\x04 \x01(\x03R\rconfirmations\x12\x14\n" +
+	"\x05final\x18\x05 \x01(\bR\x05final\x12#\n" +
+	"\ranchor_status\x18\x06 \x01(\tR\fanchorStatus\x12/\n" +
+	"\x11upper_block_index\x18\a \x01(\x03H\x00R\x0fupperBlockIndex\x88\x01\x01B\x14\n" +
+	"\x12_upper_block_index\"\xcd\x01\n" +
+	"\x05Proof\x12\x1d\n" +
+	"\n" +
+	"block_root\x18\x01 \x01(\tR\tblockRoot\x12\x1f\n" +
+	"\vlatest_root\x18\x02 \x01(\tR\n" +
+	"latestRoot\x12\x12\n" +
+	"\x04leaf\x18\x03 \x01(\tR\x04leaf\x12'\n" +
+	"\x05proof\x18\x04 \x03(\v2\x11.hos.v1.ProofStepR\x05proof\x12/\n" +
+	"\tinclusion\x18\x05 \x01(\v2\x11.hos.v1.InclusionR\tinclusion\x12\x16\n" +
+	"\x06pruned\x18\x06 \x01(\bR\x06pruned\"~\n" +
+	"\vRecordProof\x12,\n" +
+	"\x06record\x18\x01 \x01(\v2\x14.hos.v1.ClinicRecordR\x06record\x12#\n" +
+	"\x05proof\x18\x02 \x01(\v2\r.hos.v1.ProofR\x05proof\x12\x1c\n" +
+	"\tretention\x18\x03 \x01(\tR\tretention\"S\n" +
+	"\x0fGetProofRequest\x12\x1f\n" +
+	"\vblock_index\x18\x01 \x01(\x03R\n" +
+	"blockIndex\x12\x1f\n" +
+	"\ventry_index\x18\x02 \x01(\x03R\n" +
+	"entryIndex\",\n" +
+	"\x16GetAnchorStatusRequest\x12\x12\n" +
+	"\x04root\x18\x01 \x01(\tR\x04root\"\xa5\x01\n" +
+	"\fAnchorStatus\x12\x15\n" +
+	"\x06hos_id\x18\x01 \x01(\tR\x05hosId\x12\x12\n" +
+	"\x04root\x18\x02 \x01(\tR\x04root\x12#\n" +
+	"\ranchor_status\x18\x03 \x01(\tR\fanchorStatus\x12/\n" +
+	"\x11upper_block_index\x18\x04 \x01(\x03H\x00R\x0fupperBlockIndex\x88\x01\x01B\x14\n" +
+	"\x12_upper_block_index\"K\n" +
+	"\x16SubscribeBlocksRequest\x12\"\n" +
+	"\n" +
+	"from_index\x18\x01 \x01(\x03H\x00R\tfromIndex\x88\x01\x01B\r\n" +
+	"\v_from_index\"\xf2\x02\n" +
+	"\tWireBlock\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x15\n" +
+	"\x06hos_id\x18\x02 \x01(\tR\x05hosId\x12\x1b\n" +
+	"\tprev_hash\x18\x03 \x01(\tR\bprevHash\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x18\n" +
+	"\aentries\x18\x05 \x03(\fR\aentries\x12\x1f\n" +
+	"\vmerkle_root\x18\x06 \x01(\tR\n" +
+	"merkleRoot\x12\x1a\n" +
+	"\bproposer\x18\a \x01(\tR\bproposer\x124\n" +
+	"\n" +
+	"signatures\x18\b \x03(\v2\x14.hos.v1.ConsensusSigR\n" +
+	"signatures\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\t \x01(\tR\tblockHash\x12\x18\n" +
+	"\aelapsed\x18\n" +
+	" \x01(\x02R\aelapsed\x12\x1f\n" +
+	"\vleaf_hashes\x18\v \x03(\tR\n" +
+	"leafHashes\x12\x16\n" +
+	"\x06pruned\x18\f \x01(\bR\x06pruned\"\x8b\x02\n" +
+	"\n" +
+	"WireHeader\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x15\n" +
+	"\x06hos_id\x18\x02 \x01(\tR\x05hosId\x12\x1b\n" +
+	"\tprev_hash\x18\x03 \x01(\tR\bprevHash\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x1f\n" +
+	"\vmerkle_root\x18\x05 \x01(\tR\n" +
+	"merkleRoot\x12\x1a\n" +
+	"\bproposer\x18\x06 \x01(\tR\bproposer\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\a \x01(\tR\tblockHash\x12\x18\n" +
+	"\aelapsed\x18\b \x01(\x02R\aelapsed\x12\x1f\n" +
+	"\ventry_count\x18\t \x01(\x03R\n" +
+	"entryCount\"\x9e\x01\n" +
+	"\vBftProposal\x12\x12\n" +
+	"\x04view\x18\x01 \x01(\x03R\x04view\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x03R\x05round\x12\x16\n" +
+	"\x06leader\x18\x03 \x01(\tR\x06leader\x12'\n" +
+	"\x05block\x18\x04 \x01(\v2\x11.hos.v1.WireBlockR\x05block\x12\x10\n" +
+	"\x03sig\x18\x05 \x01(\tR\x03sig\x12\x12\n" +
+	"\x04auth\x18\x06 \x01(\tR\x04auth\"\xad\x01\n" +
+	"\aBftVote\x12\x12\n" +
+	"\x04view\x18\x01 \x01(\x03R\x04view\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x03R\x05round\x12\x12\n" +
+	"\x04addr\x18\x03 \x01(\tR\x04addr\x12\x10\n" +
+	"\x03sig\x18\x04 \x01(\tR\x03sig\x12\x12\n" +
+	"\x04hash\x18\x05 \x01(\tR\x04hash\x12*\n" +
+	"\x06header\x18\x06 \x01(\v2\x12.hos.v1.WireHeaderR\x06header\x12\x12\n" +
+	"\x04auth\x18\a \x01(\tR\x04auth\"_\n" +
+	"\rBftViewChange\x12\x12\n" +
+	"\x04view\x18\x01 \x01(\x03R\x04view\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x03R\x05round\x12\x12\n" +
+	"\x04addr\x18\x03 \x01(\tR\x04addr\x12\x10\n" +
+	"\x03sig\x18\x04 \x01(\tR\x03sig\"}\n" +
+	"\x0eWireBlocksPage\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12'\n" +
+	"\x05items\x18\x04 \x03(\v2\x11.hos.v1.WireBlockR\x05items2\xa0\x04\n" +
+	"\bHosChain\x122\n" +
+	"\bGetBlock\x12\x17.hos.v1.GetBlockRequest\x1a\r.hos.v1.Block\x12>\n" +
+	"\x0eGetLatestBlock\x12\x1d.hos.v1.GetLatestBlockRequest\x1a\r.hos.v1.Block\x12C\n" +
+	"\n" +
+	"ListBlocks\x12\x19.hos.v1.ListBlocksRequest\x1a\x1a.hos.v1.ListBlocksResponse\x12L\n" +
+	"\rSubmitRecords\x12\x1c.hos.v1.SubmitRecordsRequest\x1a\x1d.hos.v1.SubmitRecordsResponse\x12L\n" +
+	"\rSearchRecords\x12\x1c.hos.v1.SearchRecordsRequest\x1a\x1d.hos.v1.SearchRecordsResponse\x122\n" +
+	"\bGetProof\x12\x17.hos.v1.GetProofRequest\x1a\r.hos.v1.Proof\x12G\n" +
+	"\x0fGetAnchorStatus\x12\x1e.hos.v1.GetAnchorStatusRequest\x1a\x14.hos.v1.AnchorStatus\x12B\n" +
+	"\x0fSubscribeBlocks\x12\x1e.hos.v1.SubscribeBlocksRequest\x1a\r.hos.v1.Block0\x01B\vZ\thos/hospbb\x06proto3"
+
+var (
+	file_hos_proto_rawDescOnce sync.Once
+	file_hos_proto_rawDescData []byte
+)
+
+func file_hos_proto_rawDescGZIP() []byte {
+	file_hos_proto_rawDescOnce.Do(func() {
+		file_hos_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hos_proto_rawDesc), len(file_hos_proto_rawDesc)))
+	})
+	return file_hos_proto_rawDescData
+}
+
+var file_hos_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_hos_proto_goTypes = []any{
+	(*ClinicRecord)(nil),           // 0: hos.v1.ClinicRecord
+	(*Evidence)(nil),               // 1: hos.v1.Evidence
+	(*ValidatorChange)(nil),        // 2: hos.v1.ValidatorChange
+	(*RevocationRecord)(nil),       // 3: hos.v1.RevocationRecord
+	(*SealedPHI)(nil),              // 4: hos.v1.SealedPHI
+	(*ConsensusSig)(nil),           // 5: hos.v1.ConsensusSig
+	(*Block)(nil),                  // 6: hos.v1.Block
+	(*GetBlockRequest)(nil),        // 7: hos.v1.GetBlockRequest
+	(*GetLatestBlockRequest)(nil),  // 8: hos.v1.GetLatestBlockRequest
+	(*ListBlocksRequest)(nil),      // 9: hos.v1.ListBlocksRequest
+	(*ListBlocksResponse)(nil),     // 10: hos.v1.ListBlocksResponse
+	(*SubmitRecordsRequest)(nil),   // 11: hos.v1.SubmitRecordsRequest
+	(*RejectedRecord)(nil),         // 12: hos.v1.RejectedRecord
+	(*SubmitRecordsResponse)(nil),  // 13: hos.v1.SubmitRecordsResponse
+	(*SearchRecordsRequest)(nil),   // 14: hos.v1.SearchRecordsRequest
+	(*SearchRecordsResponse)(nil),  // 15: hos.v1.SearchRecordsResponse
+	(*ProofStep)(nil),              // 16: hos.v1.ProofStep
+	(*Inclusion)(nil),              // 17: hos.v1.Inclusion
+	(*Proof)(nil),                  // 18: hos.v1.Proof
+	(*RecordProof)(nil),            // 19: hos.v1.RecordProof
+	(*GetProofRequest)(nil),        // 20: hos.v1.GetProofRequest
+	(*GetAnchorStatusRequest)(nil), // 21: hos.v1.GetAnchorStatusRequest
+	(*AnchorStatus)(nil),           // 22: hos.v1.AnchorStatus
+	(*SubscribeBlocksRequest)(nil), // 23: hos.v1.SubscribeBlocksRequest
+	(*WireBlock)(nil),              // 24: hos.v1.WireBlock
+	(*WireHeader)(nil),             // 25: hos.v1.WireHeader
+	(*BftProposal)(nil),            // 26: hos.v1.BftProposal
+	(*BftVote)(nil),                // 27: hos.v1.BftVote
+	(*BftViewChange)(nil),          // 28: hos.v1.BftViewChange
+	(*WireBlocksPage)(nil),         // 29: hos.v1.WireBlocksPage
+	(*structpb.Struct)(nil),        // 30: google.protobuf.Struct
+}
+var file_hos_proto_depIdxs = []int32{
+	30, // 0: hos.v1.ClinicRecord.info:type_name -> google.protobuf.Struct
+	30, // 1: hos.v1.ClinicRecord.clinic_his:type_name -> google.protobuf.Struct
+	3,  // 2: hos.v1.ClinicRecord.revocation:type_name -> hos.v1.RevocationRecord
+	4,  // 3: hos.v1.ClinicRecord.sealed:type_name -> hos.v1.SealedPHI
+	2,  // 4: hos.v1.ClinicRecord.validator:type_name -> hos.v1.ValidatorChange
+	1,  // 5: hos.v1.ClinicRecord.evidence:type_name -> hos.v1.Evidence
+	0,  // 6: hos.v1.Block.entries:type_name -> hos.v1.ClinicRecord
+	5,  // 7: hos.v1.Block.signatures:type_name -> hos.v1.ConsensusSig
+	6,  // 8: hos.v1.ListBlocksResponse.blocks:type_name -> hos.v1.Block
+	0,  // 9: hos.v1.SubmitRecordsRequest.records:type_name -> hos.v1.ClinicRecord
+	12, // 10: hos.v1.SubmitRecordsResponse.rejected:type_name -> hos.v1.RejectedRecord
+	19, // 11: hos.v1.SearchRecordsResponse.items:type_name -> hos.v1.RecordProof
+	16, // 12: hos.v1.Proof.proof:type_name -> hos.v1.ProofStep
+	17, // 13: hos.v1.Proof.inclusion:type_name -> hos.v1.Inclusion
+	0,  // 14: hos.v1.RecordProof.record:type_name -> hos.v1.ClinicRecord
+	18, // 15: hos.v1.RecordProof.proof:type_name -> hos.v1.Proof
+	5,  // 16: hos.v1.WireBlock.signatures:type_name -> hos.v1.ConsensusSig
+	24, // 17: hos.v1.BftProposal.block:type_name -> hos.v1.WireBlock
+	25, // 18: hos.v1.BftVote.header:type_name -> hos.v1.WireHeader
+	24, // 19: hos.v1.WireBlocksPage.items:type_name -> hos.v1.WireBlock
+	7,  // 20: hos.v1.HosChain.GetBlock:input_type -> hos.v1.GetBlockRequest
+	8,  // 21: hos.v1.HosChain.GetLatestBlock:input_type -> hos.v1.GetLatestBlockRequest
+	9,  // 22: hos.v1.HosChain.ListBlocks:input_type -> hos.v1.ListBlocksRequest
+	11, // 23: hos.v1.HosChain.SubmitRecords:input_type -> hos.v1.SubmitRecordsRequest
+	14, // 24: hos.v1.HosChain.SearchRecords:input_type -> hos.v1.SearchRecordsRequest
+	20, // 25: hos.v1.HosChain.GetProof:input_type -> hos.v1.GetProofRequest
+	21, // 26: hos.v1.HosChain.GetAnchorStatus:input_type -> hos.v1.GetAnchorStatusRequest
+	23, // 27: hos.v1.HosChain.SubscribeBlocks:input_type -> hos.v1.SubscribeBlocksRequest
+	6,  // 28: hos.v1.HosChain.GetBlock:output_type -> hos.v1.Block
+	6,  // 29: hos.v1.HosChain.GetLatestBlock:output_type -> hos.v1.Block
+	10, // 30: hos.v1.HosChain.ListBlocks:output_type -> hos.v1.ListBlocksResponse
+	13, // 31: hos.v1.HosChain.SubmitRecords:output_type -> hos.v1.SubmitRecordsResponse
+	15, // 32: hos.v1.HosChain.SearchRecords:output_type -> hos.v1.SearchRecordsResponse
+	18, // 33: hos.v1.HosChain.GetProof:output_type -> hos.v1.Proof
+	22, // 34: hos.v1.HosChain.GetAnchorStatus:output_type -> hos.v1.AnchorStatus
+	6,  // 35: hos.v1.HosChain.SubscribeBlocks:output_type -> hos.v1.Block
+	28, // [28:36] is the sub-list for method output_type
+	20, // [20:28] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_hos_proto_init() }
+func file_hos_proto_init() {
+	if File_hos_proto != nil {
+		return
+	}
+	file_hos_proto_msgTypes[7].OneofWrappers = []any{
+		(*GetBlockRequest_Index)(nil),
+		(*GetBlockRequest_Hash)(nil),
+	}
+	file_hos_proto_msgTypes[17].OneofWrappers = []any{}
+	file_hos_proto_msgTypes[22].OneofWrappers = []any{}
+	file_hos_proto_msgTypes[23].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hos_proto_rawDesc), len(file_hos_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   30,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hos_proto_goTypes,
+		DependencyIndexes: file_hos_proto_depIdxs,
+		MessageInfos:      file_hos_proto_msgTypes,
+	}.Build()
+	File_hos_proto = out.File
+	file_hos_proto_goTypes = nil
+	file_hos_proto_depIdxs = nil
+}
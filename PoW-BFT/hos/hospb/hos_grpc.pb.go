@@ -0,0 +1,410 @@
+// Hos 체인 gRPC 서비스 정의 (REST API 와 같은 저장소/체인 계층 공유, grpc.go)
+// 생성 : go generate (hospb 패키지)
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: hos.proto
+
+package hospb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HosChain_GetBlock_FullMethodName        = "/hos.v1.HosChain/GetBlock"
+	HosChain_GetLatestBlock_FullMethodName  = "/hos.v1.HosChain/GetLatestBlock"
+	HosChain_ListBlocks_FullMethodName      = "/hos.v1.HosChain/ListBlocks"
+	HosChain_SubmitRecords_FullMethodName   = "/hos.v1.HosChain/SubmitRecords"
+	HosChain_SearchRecords_FullMethodName   = "/hos.v1.HosChain/SearchRecords"
+	HosChain_GetProof_FullMethodName        = "/hos.v1.HosChain/GetProof"
+	HosChain_GetAnchorStatus_FullMethodName = "/hos.v1.HosChain/GetAnchorStatus"
+	HosChain_SubscribeBlocks_FullMethodName = "/hos.v1.HosChain/SubscribeBlocks"
+)
+
+// HosChainClient is the client API for HosChain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HosChainClient interface {
+	// 블록 조회 (번호 또는 해시) : GET /block/index, /block/hash
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// 최신 블록 : GET /block/latest
+	GetLatestBlock(ctx context.Context, in *GetLatestBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// 블록 목록 (페이지네이션) : GET /blocks
+	ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error)
+	// 레코드 접수 (메모리풀) : POST /upload
+	SubmitRecords(ctx context.Context, in *SubmitRecordsRequest, opts ...grpc.CallOption) (*SubmitRecordsResponse, error)
+	// 키워드 검색 + 포함 증명 : GET /search
+	SearchRecords(ctx context.Context, in *SearchRecordsRequest, opts ...grpc.CallOption) (*SearchRecordsResponse, error)
+	// 포함 증명 (블록, 엔트리 위치) : GET /proof
+	GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*Proof, error)
+	// 블록 루트의 Gov 앵커 상태
+	GetAnchorStatus(ctx context.Context, in *GetAnchorStatusRequest, opts ...grpc.CallOption) (*AnchorStatus, error)
+	// 확정 블록 구독 (from_index 부터 기존 블록을 먼저 보낸 뒤 새 블록 push)
+	SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Block], error)
+}
+
+type hosChainClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHosChainClient(cc grpc.ClientConnInterface) HosChainClient {
+	return &hosChainClient{cc}
+}
+
+func (c *hosChainClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, HosChain_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hosChainClient) GetLatestBlock(ctx context.Context, in *GetLatestBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, HosChain_GetLatestBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hosChainClient) ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlocksResponse)
+	err := c.cc.Invoke(ctx, HosChain_ListBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hosChainClient) SubmitRecords(ctx context.Context, in *SubmitRecordsRequest, opts ...grpc.CallOption) (*SubmitRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitRecordsResponse)
+	err := c.cc.Invoke(ctx, HosChain_SubmitRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hosChainClient) SearchRecords(ctx context.Context, in *SearchRecordsRequest, opts ...grpc.CallOption) (*SearchRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchRecordsResponse)
+	err := c.cc.Invoke(ctx, HosChain_SearchRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hosChainClient) GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*Proof, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Proof)
+	err := c.cc.Invoke(ctx, HosChain_GetProof_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hosChainClient) GetAnchorStatus(ctx context.Context, in *GetAnchorStatusRequest, opts ...grpc.CallOption) (*AnchorStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnchorStatus)
+	err := c.cc.Invoke(ctx, HosChain_GetAnchorStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hosChainClient) SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Block], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HosChain_ServiceDesc.Streams[0], HosChain_SubscribeBlocks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeBlocksRequest, Block]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HosChain_SubscribeBlocksClient = grpc.ServerStreamingClient[Block]
+
+// HosChainServer is the server API for HosChain service.
+// All implementations must embed UnimplementedHosChainServer
+// for forward compatibility.
+type HosChainServer interface {
+	// 블록 조회 (번호 또는 해시) : GET /block/index, /block/hash
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	// 최신 블록 : GET /block/latest
+	GetLatestBlock(context.Context, *GetLatestBlockRequest) (*Block, error)
+	// 블록 목록 (페이지네이션) : GET /blocks
+	ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error)
+	// 레코드 접수 (메모리풀) : POST /upload
+	SubmitRecords(context.Context, *SubmitRecordsRequest) (*SubmitRecordsResponse, error)
+	// 키워드 검색 + 포함 증명 : GET /search
+	SearchRecords(context.Context, *SearchRecordsRequest) (*SearchRecordsResponse, error)
+	// 포함 증명 (블록, 엔트리 위치) : GET /proof
+	GetProof(context.Context, *GetProofRequest) (*Proof, error)
+	// 블록 루트의 Gov 앵커 상태
+	GetAnchorStatus(context.Context, *GetAnchorStatusRequest) (*AnchorStatus, error)
+	// 확정 블록 구독 (from_index 부터 기존 블록을 먼저 보낸 뒤 새 블록 push)
+	SubscribeBlocks(*SubscribeBlocksRequest, grpc.ServerStreamingServer[Block]) error
+	mustEmbedUnimplementedHosChainServer()
+}
+
+// UnimplementedHosChainServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHosChainServer struct{}
+
+func (UnimplementedHosChainServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedHosChainServer) GetLatestBlock(context.Context, *GetLatestBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatestBlock not implemented")
+}
+func (UnimplementedHosChainServer) ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocks not implemented")
+}
+func (UnimplementedHosChainServer) SubmitRecords(context.Context, *SubmitRecordsRequest) (*SubmitRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitRecords not implemented")
+}
+func (UnimplementedHosChainServer) SearchRecords(context.Context, *SearchRecordsRequest) (*SearchRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRecords not implemented")
+}
+func (UnimplementedHosChainServer) GetProof(context.Context, *GetProofRequest) (*Proof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProof not implemented")
+}
+func (UnimplementedHosChainServer) GetAnchorStatus(context.Context, *GetAnchorStatusRequest) (*AnchorStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnchorStatus not implemented")
+}
+func (UnimplementedHosChainServer) SubscribeBlocks(*SubscribeBlocksRequest, grpc.ServerStreamingServer[Block]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
+func (UnimplementedHosChainServer) mustEmbedUnimplementedHosChainServer() {}
+func (UnimplementedHosChainServer) testEmbeddedByValue()                  {}
+
+// UnsafeHosChainServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HosChainServer will
+// result in compilation errors.
+type UnsafeHosChainServer interface {
+	mustEmbedUnimplementedHosChainServer()
+}
+
+func RegisterHosChainServer(s grpc.ServiceRegistrar, srv HosChainServer) {
+	// If the following call pancis, it indicates UnimplementedHosChainServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HosChain_ServiceDesc, srv)
+}
+
+func _HosChain_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HosChainServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HosChain_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HosChainServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HosChain_GetLatestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HosChainServer).GetLatestBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HosChain_GetLatestBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HosChainServer).GetLatestBlock(ctx, req.(*GetLatestBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HosChain_ListBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HosChainServer).ListBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HosChain_ListBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HosChainServer).ListBlocks(ctx, req.(*ListBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HosChain_SubmitRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HosChainServer).SubmitRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HosChain_SubmitRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HosChainServer).SubmitRecords(ctx, req.(*SubmitRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HosChain_SearchRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HosChainServer).SearchRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HosChain_SearchRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HosChainServer).SearchRecords(ctx, req.(*SearchRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HosChain_GetProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HosChainServer).GetProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HosChain_GetProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HosChainServer).GetProof(ctx, req.(*GetProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HosChain_GetAnchorStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnchorStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HosChainServer).GetAnchorStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HosChain_GetAnchorStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HosChainServer).GetAnchorStatus(ctx, req.(*GetAnchorStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HosChain_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HosChainServer).SubscribeBlocks(m, &grpc.GenericServerStream[SubscribeBlocksRequest, Block]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HosChain_SubscribeBlocksServer = grpc.ServerStreamingServer[Block]
+
+// HosChain_ServiceDesc is the grpc.ServiceDesc for HosChain service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HosChain_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hos.v1.HosChain",
+	HandlerType: (*HosChainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _HosChain_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetLatestBlock",
+			Handler:    _HosChain_GetLatestBlock_Handler,
+		},
+		{
+			MethodName: "ListBlocks",
+			Handler:    _HosChain_ListBlocks_Handler,
+		},
+		{
+			MethodName: "SubmitRecords",
+			Handler:    _HosChain_SubmitRecords_Handler,
+		},
+		{
+			MethodName: "SearchRecords",
+			Handler:    _HosChain_SearchRecords_Handler,
+		},
+		{
+			MethodName: "GetProof",
+			Handler:    _HosChain_GetProof_Handler,
+		},
+		{
+			MethodName: "GetAnchorStatus",
+			Handler:    _HosChain_GetAnchorStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _HosChain_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "hos.proto",
+}
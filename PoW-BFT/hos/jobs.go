@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Jobs (장시간 관리 작업의 비동기 실행)
+// ------------------------------------------------------------
+// - 재색인/감사 등 오래 걸리는 작업을 HTTP 핸들러 밖에서 실행하고 작업 ID 로 추적
+// - 작업 상태 : queued => running => succeeded | failed | canceled
+// - 진행률(done/total)과 결과는 LevelDB(job_<id>)에 기록
+//   · 재시작 시 실행 중이던 작업은 failed("interrupted by restart") 로 정리
+// - 같은 종류의 작업은 동시에 하나만 실행 (중복 요청은 409)
+// - API
+//   · GET    /jobs      : 작업 목록 (최신순)
+//   · GET    /jobs/{id} : 작업 상태 조회
+//   · DELETE /jobs/{id} : 실행 중이면 취소, 종료된 작업이면 기록 삭제
+//   · POST   /admin/reindex : 저장된 블록으로 검색 색인 재구성
+//   · POST   /admin/audit   : 제네시스부터 장부 무결성 검증
+//   · POST   /admin/prune   : 오래된 블록 본문 정리 (prune.go)
+//   · POST   /admin/resync  : 가장 긴 피어 체인과 즉시 동기화/분기 교체 (p2p.go)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	JobCanceled  = "canceled"
+
+	jobPrefix        = "job_"
+	JobProgressFlush = 1 // 초, 진행률 기록 최소 간격
+)
+
+type Job struct {
+	ID         string          `json:"id"`
+	Kind       string          `json:"kind"`
+	Status     string          `json:"status"`
+	Done       int             `json:"done"`
+	Total      int             `json:"total"`
+	Error      string          `json:"error,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+	StartedAt  string          `json:"started_at,omitempty"`
+	FinishedAt string          `json:"finished_at,omitempty"`
+}
+
+// 작업 본문 : ctx 가 취소되면 중단, report 로 진행률 보고
+type JobFunc func(ctx context.Context, report func(done, total int)) (any, error)
+
+var (
+	jobsMu     sync.Mutex
+	jobCancels = make(map[string]context.CancelFunc) // 실행 중인 작업 ID => 취소 함수
+	jobKinds   = make(map[string]string)             // 실행 중인 작업 ID => 종류
+)
+
+func saveJob(j Job) {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return
+	}
+	_ = countDBError(db.Put([]byte(jobPrefix+j.ID), data, nil))
+}
+
+func loadJob(id string) (Job, bool) {
+	data, err := db.Get([]byte(jobPrefix+id), nil)
+	if err != nil {
+		return Job{}, false
+	}
+	var j Job
+	if err := json.Unmarshal(data, &j); err != nil {
+		return Job{}, false
+	}
+	return j, true
+}
+
+func listJobs() []Job {
+	out := []Job{}
+	iter := db.NewIterator(util.BytesPrefix([]byte(jobPrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var j Job
+		if err := json.Unmarshal(iter.Value(), &j); err == nil {
+			out = append(out, j)
+		}
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].CreatedAt > out[b].CreatedAt })
+	return out
+}
+
+// 재시작 시 끝나지 않은 작업 정리 (main 에서 DB 초기화 후 호출)
+func recoverJobs() {
+	for _, j := range listJobs() {
+		if j.Status == JobQueued || j.Status == JobRunning {
+			j.Status = JobFailed
+			j.Error = "interrupted by restart"
+			j.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+			saveJob(j)
+			log.Printf("[JOBS] job %s (%s) interrupted by restart", j.ID, j.Kind)
+		}
+	}
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// 작업 등록 후 비동기 실행
+func startJob(kind string, fn JobFunc) (Job, error) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	for id, k := range jobKinds {
+		if k == kind {
+			return Job{}, fmt.Errorf("%s job already running: %s", kind, id)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j := Job{
+		ID:        newJobID(),
+		Kind:      kind,
+		Status:    JobQueued,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	saveJob(j)
+	jobCancels[j.ID] = cancel
+	jobKinds[j.ID] = kind
+	go runJob(ctx, j, fn)
+	log.Printf("[JOBS] job %s (%s) queued", j.ID, kind)
+	return j, nil
+}
+
+func runJob(ctx context.Context, j Job, fn JobFunc) {
+	defer func() {
+		jobsMu.Lock()
+		if cancel, ok := jobCancels[j.ID]; ok {
+			cancel()
+		}
+		delete(jobCancels, j.ID)
+		delete(jobKinds, j.ID)
+		jobsMu.Unlock()
+	}()
+
+	var mu sync.Mutex
+	j.Status = JobRunning
+	j.StartedAt = time.Now().UTC().Format(time.RFC3339)
+	saveJob(j)
+
+	last := time.Now()
+	report := func(done, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		j.Done, j.Total = done, total
+		if time.Since(last) >= JobProgressFlush*time.Second {
+			saveJob(j)
+			last = time.Now()
+		}
+	}
+
+	res, err := fn(ctx, report)
+
+	mu.Lock()
+	defer mu.Unlock()
+	switch {
+	case ctx.Err() != nil:
+		j.Status = JobCanceled
+	case err != nil:
+		j.Status = JobFailed
+		j.Error = err.Error()
+	default:
+		j.Status = JobSucceeded
+		if res != nil {
+			j.Result, _ = json.Marshal(res)
+		}
+	}
+	j.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	saveJob(j)
+	log.Printf("[JOBS] job %s (%s) %s (%d/%d)", j.ID, j.Kind, j.Status, j.Done, j.Total)
+}
+
+// POST /admin/<kind> 공통 처리 : 작업 시작 후 202 + 작업 정보 반환
+func handleStartJob(kind string, fn JobFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		j, err := startJob(kind, fn)
+		if err != nil {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		w.Header().Set("Location", "/jobs/"+j.ID)
+		writeJSON(w, http.StatusAccepted, j)
+	}
+}
+
+// GET /jobs
+func handleJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, listJobs())
+}
+
+// GET /jobs/{id}, DELETE /jobs/{id}
+func handleJob(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/jobs/")
+	j, ok := loadJob(id)
+	if id == "" || !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, j)
+	case http.MethodDelete:
+		jobsMu.Lock()
+		cancel, running := jobCancels[id]
+		jobsMu.Unlock()
+		if running {
+			cancel()
+			log.Printf("[JOBS] job %s (%s) cancel requested", j.ID, j.Kind)
+			writeJSON(w, http.StatusAccepted, j)
+			return
+		}
+		if err := countDBError(db.Delete([]byte(jobPrefix+id), nil)); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to delete job: "+err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"deleted": id})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 관리 작업
+////////////////////////////////////////////////////////////////////////////////
+
+// 감사 결과 (비호환 지점은 재생 모드와 같은 형식)
+type AuditReport struct {
+	Checked int            `json:"checked"`
+	Height  int            `json:"height"`
+	OK      bool           `json:"ok"`
+	Failure *ReplayFailure `json:"failure,omitempty"`
+}
+
+// 저장된 블록으로 검색 색인(cid/pc/info/ft) 재구성
+func reindexJob(ctx context.Context, report func(done, total int)) (any, error) {
+	h, ok := getLatestHeight()
+	if !ok {
+		return nil, fmt.Errorf("no chain")
+	}
+	for i := 0; i <= h; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		b, err := getBlockByIndex(i)
+		if err != nil {
+			return nil, fmt.Errorf("load block_%d: %w", i, err)
+		}
+		tx := newLedgerTxn()
+		updateIndicesForBlock(tx, b)
+		if err := tx.commit(); err != nil {
+			return nil, fmt.Errorf("write indices for block_%d: %w", i, err)
+		}
+		report(i+1, h+1)
+	}
+	return map[string]int{"reindexed": h + 1}, nil
+}
+
+// 제네시스부터 블록 연결/머클 루트/해시/상주 규칙 재검증
+func auditJob(ctx context.Context, report func(done, total int)) (any, error) {
+	h, ok := getLatestHeight()
+	if !ok {
+		return nil, fmt.Errorf("no chain")
+	}
+	prev, err := getBlockByIndex(0)
+	if err != nil {
+		return nil, fmt.Errorf("load genesis: %w", err)
+	}
+	rep := AuditReport{Checked: 1, Height: h, OK: true}
+	report(1, h+1)
+	for i := 1; i <= h; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		b, err := getBlockByIndex(i)
+		if err == nil {
+			err = validateStoredBlock(b, prev)
+		}
+		if err != nil {
+			rep.OK = false
+			rep.Failure = &ReplayFailure{Index: i, Hash: b.BlockHash, Reason: err.Error()}
+			log.Printf("[JOBS][AUDIT] block #%d failed: %v", i, err)
+			break
+		}
+		prev = b
+		rep.Checked++
+		report(i+1, h+1)
+	}
+	return rep, nil
+}
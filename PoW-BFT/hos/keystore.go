@@ -0,0 +1,453 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Key Store (앵커/합의 서명용 노드 개인키 보관 + 키 교체)
+// ------------------------------------------------------------
+// - 개인키 암호화 보관 (AES-256-GCM, 키 유도 PBKDF2-SHA256)
+//   · 비밀값 출처 (우선순위) : KEY_KMS_URL(GET 응답 본문) > KEY_PASSPHRASE_FILE > KEY_PASSPHRASE
+//   · 비밀값이 있으면 "meta_hos_privkey_enc" 에 암호문만 저장, 기존 평문 키는 최초 기동 시 암호화 후 삭제
+//   · 비밀값이 없으면 기존처럼 평문 저장 (경고 로그)
+//   · 복호화한 키는 메모리에만 보관 (nodePrivKey), 로그에는 공개키 지문만 출력
+// - POST /rotateKey : 새 키 쌍 생성 후 교체 공지(KeyRotation) 전파 (합의 진행 중에는 409)
+//   · 공지는 이전 키(연속성)와 새 키(소유 증명)로 각각 서명
+//   · 일반 노드 => Hos 부트노드 /keyRotation => 나머지 피어에 전달
+//   · 부트노드 => Gov 부트노드 /hosKeyRotation (상위 체인 장부에 기록)
+// - 교체된 이전 공개키는 "pkhist_<addr>|<지문>" 에 보관 => 과거 블록 서명 검증에 사용
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	privKeyMetaKey    = "meta_hos_privkey"     // 평문 PEM (비밀값 미설정 시)
+	privKeyEncMetaKey = "meta_hos_privkey_enc" // 암호화 보관 (sealedKey JSON)
+	pubKeyMetaKey     = "meta_hos_pubkey"
+	keyKDFIterations  = 600000
+	kmsFetchTimeout   = 10 // 초
+)
+
+var (
+	keyMu      sync.RWMutex
+	cachedPriv string // 복호화된 개인키 PEM (메모리 전용)
+)
+
+// 암호화된 개인키 레코드
+type sealedKey struct {
+	KDF   string `json:"kdf"`
+	Iter  int    `json:"iter"`
+	Salt  string `json:"salt"`
+	Nonce string `json:"nonce"`
+	CT    string `json:"ct"`
+}
+
+// 키 교체 공지 (이전 키/새 키 서명 포함)
+type KeyRotation struct {
+	HosID      string `json:"hos_id"`
+	Node       string `json:"node"`         // 키를 교체한 노드 주소
+	PubKey     string `json:"pub_key"`      // 새 공개키 PEM
+	PrevPubKey string `json:"prev_pub_key"` // 이전 공개키 PEM
+	Ts         string `json:"ts"`
+	Sig        string `json:"sig"`     // 이전 키 서명
+	NewSig     string `json:"new_sig"` // 새 키 서명
+}
+
+// 서명 대상 다이제스트 (hex)
+func (k KeyRotation) digest() string {
+	return sha256Hex([]byte(fmt.Sprintf("key_rotation|%s|%s|%s|%s|%s",
+		k.HosID, k.Node, pubKeyFingerprint(k.PrevPubKey), pubKeyFingerprint(k.PubKey), k.Ts)))
+}
+
+// 이전 키 서명과 새 키 서명을 모두 확인
+func (k KeyRotation) verify() error {
+	d := k.digest()
+	hash, _ := hex.DecodeString(d)
+	switch {
+	case k.Node == "" || k.PubKey == "" || k.PrevPubKey == "":
+		return fmt.Errorf("node, pub_key and prev_pub_key required")
+	case !verifyECDSA(k.PrevPubKey, hash, k.Sig):
+		return fmt.Errorf("previous key signature invalid")
+	case !verifyECDSA(k.PubKey, hash, k.NewSig):
+		return fmt.Errorf("new key signature invalid")
+	}
+	return nil
+}
+
+// 키 암호화 비밀값 (없으면 "")
+func keySecret() (string, error) {
+	if u := os.Getenv("KEY_KMS_URL"); u != "" {
+		client := &http.Client{Timeout: kmsFetchTimeout * time.Second}
+		resp, err := client.Get(u)
+		if err != nil {
+			return "", fmt.Errorf("kms: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("kms: status=%d", resp.StatusCode)
+		}
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("kms: %w", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	if f := os.Getenv("KEY_PASSPHRASE_FILE"); f != "" {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return "", fmt.Errorf("passphrase file: %w", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	return os.Getenv("KEY_PASSPHRASE"), nil
+}
+
+func sealPrivKey(privPem, secret string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	gcm, err := keyCipher(secret, salt, keyKDFIterations)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	ct := gcm.Seal(nil, nonce, []byte(privPem), []byte(privKeyEncMetaKey))
+	data, _ := json.Marshal(sealedKey{
+		KDF:   "pbkdf2-sha256",
+		Iter:  keyKDFIterations,
+		Salt:  hex.EncodeToString(salt),
+		Nonce: hex.EncodeToString(nonce),
+		CT:    hex.EncodeToString(ct),
+	})
+	return string(data), nil
+}
+
+func openPrivKey(sealed, secret string) (string, error) {
+	var s sealedKey
+	if err := json.Unmarshal([]byte(sealed), &s); err != nil {
+		return "", fmt.Errorf("sealed key: %w", err)
+	}
+	salt, err1 := hex.DecodeString(s.Salt)
+	nonce, err2 := hex.DecodeString(s.Nonce)
+	ct, err3 := hex.DecodeString(s.CT)
+	if err1 != nil || err2 != nil || err3 != nil || s.KDF != "pbkdf2-sha256" {
+		return "", fmt.Errorf("sealed key: malformed record")
+	}
+	gcm, err := keyCipher(secret, salt, s.Iter)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("sealed key: bad nonce")
+	}
+	pt, err := gcm.Open(nil, nonce, ct, []byte(privKeyEncMetaKey))
+	if err != nil {
+		return "", fmt.Errorf("wrong passphrase or corrupted key")
+	}
+	return string(pt), nil
+}
+
+func keyCipher(secret string, salt []byte, iter int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, secret, salt, iter, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// 새 ECDSA 키 쌍 (PEM)
+func generateKeyPair() (privPem, pubPem string) {
+	priv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privBytes, _ := x509.MarshalECPrivateKey(priv)
+	pubBytes, _ := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	privPem = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privBytes}))
+	pubPem = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}))
+	return privPem, pubPem
+}
+
+// 개인키/공개키 저장 (비밀값이 있으면 암호화, 평문 키는 삭제)
+func storeKeyPair(privPem, pubPem, secret string) error {
+	batch := new(leveldb.Batch)
+	if secret != "" {
+		sealed, err := sealPrivKey(privPem, secret)
+		if err != nil {
+			return err
+		}
+		batch.Put([]byte(privKeyEncMetaKey), []byte(sealed))
+		batch.Delete([]byte(privKeyMetaKey))
+	} else {
+		batch.Put([]byte(privKeyMetaKey), []byte(privPem))
+	}
+	batch.Put([]byte(pubKeyMetaKey), []byte(pubPem))
+	if err := countDBError(db.Write(batch, nil)); err != nil {
+		return err
+	}
+	keyMu.Lock()
+	cachedPriv = privPem
+	keyMu.Unlock()
+	return nil
+}
+
+// 개인키, 공개키 준비 (최초 실행 시 생성, 암호화 키는 복호화해 메모리에 보관)
+func ensureKeyPair() {
+	keyMu.RLock()
+	loaded := cachedPriv != ""
+	keyMu.RUnlock()
+	if loaded {
+		return
+	}
+	secret, err := keySecret()
+	if err != nil {
+		log.Fatalf("[KEY] cannot obtain key passphrase: %v", err)
+	}
+	pubPem, _ := getMeta(pubKeyMetaKey)
+
+	switch sealed, hasSealed := getMeta(privKeyEncMetaKey); {
+	case hasSealed:
+		if secret == "" {
+			log.Fatal("[KEY] private key is encrypted; set KEY_PASSPHRASE, KEY_PASSPHRASE_FILE or KEY_KMS_URL")
+		}
+		privPem, err := openPrivKey(sealed, secret)
+		if err != nil {
+			log.Fatalf("[KEY] cannot decrypt private key: %v", err)
+		}
+		keyMu.Lock()
+		cachedPriv = privPem
+		keyMu.Unlock()
+		log.Printf("[KEY] Loaded encrypted key pair (pubkey=%s)", shortFP(pubPem))
+
+	default:
+		privPem, hasPlain := getMeta(privKeyMetaKey)
+		if !hasPlain {
+			privPem, pubPem = generateKeyPair()
+			log.Printf("[KEY][INIT] Generated ECDSA key pair for Hos node (pubkey=%s)", shortFP(pubPem))
+		} else if secret != "" {
+			log.Printf("[KEY] Encrypting plaintext private key at rest (pubkey=%s)", shortFP(pubPem))
+		}
+		if !hasPlain || secret != "" {
+			if err := storeKeyPair(privPem, pubPem, secret); err != nil {
+				log.Fatalf("[KEY] cannot store key pair: %v", err)
+			}
+			if hasPlain {
+				// 삭제된 평문 키가 로그/이전 테이블 파일에 남지 않도록 해당 키 구간 컴팩션
+				k := []byte(privKeyMetaKey)
+				if err := db.CompactRange(util.Range{Start: k, Limit: append(k, 0)}); err != nil {
+					log.Printf("[KEY][WARN] compaction after encrypting key: %v", err)
+				}
+			}
+		} else {
+			keyMu.Lock()
+			cachedPriv = privPem
+			keyMu.Unlock()
+		}
+		if secret == "" {
+			log.Printf("[KEY][WARN] private key stored unencrypted; set KEY_PASSPHRASE, KEY_PASSPHRASE_FILE or KEY_KMS_URL")
+		}
+	}
+}
+
+// 서명용 개인키 PEM (ensureKeyPair 이전이면 false)
+func nodePrivKey() (string, bool) {
+	keyMu.RLock()
+	defer keyMu.RUnlock()
+	return cachedPriv, cachedPriv != ""
+}
+
+// 로그 출력용 공개키 지문 앞부분
+func shortFP(pubPem string) string {
+	fp := pubKeyFingerprint(pubPem)
+	return fp[:min(16, len(fp))]
+}
+
+// ---- 교체된 공개키 이력 ------------------------------------------------------
+
+func retiredKeyKey(addr, fp string) []byte { return []byte("pkhist_" + addr + "|" + fp) }
+
+// 교체된 공개키 보관 (과거 블록 서명 검증용)
+func retirePubKey(addr, pubPem string) {
+	if addr == "" || pubPem == "" {
+		return
+	}
+	if err := countDBError(db.Put(retiredKeyKey(addr, pubKeyFingerprint(pubPem)), []byte(pubPem), nil)); err != nil {
+		log.Printf("[KEY][ERROR] retire key of %s: %v", addr, err)
+	}
+}
+
+// 지문으로 교체 전 공개키 조회 (없으면 "")
+func retiredPubKey(addr, fp string) string {
+	v, err := db.Get(retiredKeyKey(addr, fp), nil)
+	if err != nil {
+		return ""
+	}
+	return string(v)
+}
+
+// 피어 공개키 갱신 (키가 바뀌면 이전 키를 이력에 보관, pkMu 보유 상태에서 호출)
+func setPeerPubKeyLocked(addr, pubPem string) {
+	if old := peerPubKeys[addr]; old != "" && old != pubPem {
+		retirePubKey(addr, old)
+		log.Printf("[KEY] %s public key changed (%s => %s)", addr, shortFP(old), shortFP(pubPem))
+	}
+	peerPubKeys[addr] = pubPem
+}
+
+// ---- 키 교체 -----------------------------------------------------------------
+
+// POST /rotateKey : 이 노드의 키 쌍 교체
+func handleRotateKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if consensusInProgress.Load() {
+		writeError(w, http.StatusConflict, "consensus in progress, retry later")
+		return
+	}
+	rot, err := rotateKey()
+	if err != nil {
+		log.Printf("[KEY][ERROR] rotation failed: %v", err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"node":                    rot.Node,
+		"pubkey_fingerprint":      pubKeyFingerprint(rot.PubKey),
+		"prev_pubkey_fingerprint": pubKeyFingerprint(rot.PrevPubKey),
+		"ts":                      rot.Ts,
+	})
+}
+
+func rotateKey() (KeyRotation, error) {
+	oldPriv, ok := nodePrivKey()
+	oldPub, _ := getMeta(pubKeyMetaKey)
+	if !ok || oldPub == "" {
+		return KeyRotation{}, fmt.Errorf("no current key pair")
+	}
+	secret, err := keySecret()
+	if err != nil {
+		return KeyRotation{}, err
+	}
+	newPriv, newPub := generateKeyPair()
+	rot := KeyRotation{
+		HosID:      selfID(),
+		Node:       self,
+		PubKey:     newPub,
+		PrevPubKey: oldPub,
+		Ts:         time.Now().UTC().Format(time.RFC3339),
+	}
+	rot.Sig = makeAnchorSignature(oldPriv, rot.digest(), "")
+	rot.NewSig = makeAnchorSignature(newPriv, rot.digest(), "")
+
+	retirePubKey(self, oldPub)
+	if err := storeKeyPair(newPriv, newPub, secret); err != nil {
+		return KeyRotation{}, err
+	}
+	log.Printf("[KEY][ROTATE] key pair rotated (%s => %s)", shortFP(oldPub), shortFP(newPub))
+
+	if isBoot.Load() {
+		go relayKeyRotation(rot)
+	} else {
+		go postKeyRotation(boot, "/keyRotation", rot)
+	}
+	return rot, nil
+}
+
+// POST /keyRotation (노드 간) : 피어의 키 교체 공지 수신
+func handleKeyRotation(w http.ResponseWriter, r *http.Request) {
+	var rot KeyRotation
+	if err := json.NewDecoder(r.Body).Decode(&rot); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+	if rot.HosID != selfID() {
+		writeError(w, http.StatusForbidden, "hos_id mismatch")
+		return
+	}
+	if err := rot.verify(); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	pkMu.Lock()
+	cur, known := peerPubKeys[rot.Node]
+	switch {
+	case !known:
+		pkMu.Unlock()
+		writeError(w, http.StatusNotFound, "unknown node")
+		return
+	case cur == rot.PubKey: // 이미 반영된 공지 (중복 전달)
+		pkMu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		return
+	case cur != rot.PrevPubKey:
+		pkMu.Unlock()
+		writeError(w, http.StatusConflict, "prev_pub_key does not match known key")
+		return
+	}
+	setPeerPubKeyLocked(rot.Node, rot.PubKey)
+	pkMu.Unlock()
+
+	if isBoot.Load() {
+		go relayKeyRotation(rot)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// 부트노드 : 나머지 피어와 Gov 부트노드에 키 교체 공지 전달
+func relayKeyRotation(rot KeyRotation) {
+	for _, p := range peersSnapshot() {
+		if p != self && p != rot.Node {
+			go postKeyRotation(p, "/keyRotation", rot)
+		}
+	}
+	postKeyRotation(getGovBoot(), "/hosKeyRotation", map[string]any{
+		"hos_boot": self,
+		"rotation": rot,
+	})
+}
+
+func postKeyRotation(dst, path string, payload any) {
+	if dst == "" {
+		return
+	}
+	body, _ := json.Marshal(payload)
+	resp, err := nodeClient.Post(nodeURL(dst, path), "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("[KEY][ERROR] notify %s%s: %v", dst, path, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("[KEY][WARN] %s%s rejected key rotation (status=%d)", dst, path, resp.StatusCode)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Load Shedding (합의/동기화 중 비핵심 엔드포인트 제한)
+// ------------------------------------------------------------
+// - 요청을 우선순위 등급으로 분류 : consensus > sync > query > export
+//   · consensus : PBFT/상주 장부 합의, 피어 등록/부트노드 전파 등 노드 간 제어 메시지
+//   · sync      : 피어의 블록 동기화(/blocks, /headers, /snapshot 을 고정 인증서로 호출), 상태/피어/메트릭 조회, 레코드 접수
+//   · query     : 검색, 포함 증명, 블록 조회(페이지네이션), 메모리풀/이벤트 조회
+//   · export    : 전체 장부 덤프(/blocks 페이지 지정 없음), 관리 작업, 아카이브 매니페스트, 정적 파일
+//   (mTLS 미사용 시 피어 동기화 /blocks 는 export 로 분류되어 다음 동기화 주기에 재시도됨)
+// - 내부 지연 감시 (LoadShedProbeInterval 주기)
+//   · LevelDB 단건 읽기 시간 + 감시 루틴 깨어남 지연의 EWMA
+// - 부하 단계
+//   · elevated : 블록 합의/체인 동기화 진행 중이거나 내부 지연 > LOADSHED_LATENCY_MS
+//   · high     : 내부 지연 > 2 * LOADSHED_LATENCY_MS, 또는 합의/동기화 중 지연 > LOADSHED_LATENCY_MS
+// - 단계별 처리 (consensus/sync 는 항상 통과)
+//   · elevated : export 거절, query 는 LOADSHED_QUERY_SLOTS 개까지 동시 처리(최대 LoadShedQueueWait 대기)
+//   · high     : export, query 거절
+//   · 거절 응답 : 503 + Retry-After
+// - LOADSHED_ENABLED=false 로 끌 수 있음
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ClassConsensus = iota
+	ClassSync
+	ClassQuery
+	ClassExport
+)
+
+const (
+	PressureNone = iota
+	PressureElevated
+	PressureHigh
+)
+
+const (
+	LoadShedProbeInterval = 500 // ms
+	LoadShedQueueWait     = 2   // 초, elevated 단계 query 대기 상한
+	LoadShedRetryAfter    = 2   // 초, elevated 단계 Retry-After
+	LoadShedRetryHigh     = 5   // 초, high 단계 Retry-After
+	loadShedEWMAWeight    = 0.3
+)
+
+var (
+	loadShedEnabled   = true
+	loadShedLatencyMs = 200 // LOADSHED_LATENCY_MS
+	loadShedSlots     = 4   // LOADSHED_QUERY_SLOTS
+
+	querySlots     chan struct{}
+	loadPressure   atomic.Int32
+	internalLatNs  atomic.Int64 // 내부 지연 EWMA (ns)
+	syncInProgress atomic.Bool  // syncChain 진행 중
+)
+
+var classNames = []string{"consensus", "sync", "query", "export"}
+var pressureNames = []string{"none", "elevated", "high"}
+
+// 노드 간 제어 메시지 경로
+var consensusPaths = map[string]bool{
+	"/healthz": true, "/readyz": true,
+	"/addPeer": true, "/register": true, "/register/challenge": true, "/whoami": true, "/bootNotify": true, "/getPublicKey": true, "/keyRotation": true,
+	"/chgGovBoot": true, "/govBootNotify": true,
+	"/residency/pending": true, "/residency/prepare": true, "/residency/commit": true,
+}
+
+// 동기화/운영 경로
+var syncPaths = map[string]bool{
+	"/status": true, "/peers": true, "/chain/info": true, "/commitment": true,
+	"/metrics": true, "/upload": true, "/block/root": true, "/anchor/resend": true, "/clock": true,
+}
+
+// 요청 등급 분류 (/v1 접두어는 제외하고 판단)
+func classifyRequest(r *http.Request) int {
+	p := strings.TrimPrefix(r.URL.Path, "/"+APIVersion)
+	switch {
+	case strings.HasPrefix(p, "/bft/") || consensusPaths[p]:
+		return ClassConsensus
+	case syncPaths[p]:
+		return ClassSync
+	case p == "/blocks" || p == "/headers" || p == "/snapshot" || p == "/residency/blocks":
+		if tlsEnabled && isKnownPin(clientCertPin(r)) {
+			return ClassSync
+		}
+		if p == "/blocks" && r.URL.Query().Get("offset") == "" && r.URL.Query().Get("limit") == "" {
+			return ClassExport
+		}
+		return ClassQuery
+	case strings.HasPrefix(p, "/admin/") || p == "/retention/manifests":
+		return ClassExport
+	case strings.HasPrefix(p, "/search") || strings.HasPrefix(p, "/block") || p == "/proof" || strings.HasPrefix(p, "/content/") || strings.HasPrefix(p, "/patient/") ||
+		p == "/pending" || p == "/traffic" || p == "/consensus/state" || p == "/anchor/queue" || p == "/events" || p == "/ws/events" || strings.HasPrefix(p, "/jobs"):
+		return ClassQuery
+	}
+	return ClassExport // 정적 파일 등
+}
+
+// 내부 지연 감시 및 부하 단계 갱신 (main 에서 실행)
+func startLoadMonitor() {
+	interval := LoadShedProbeInterval * time.Millisecond
+	threshold := time.Duration(loadShedLatencyMs) * time.Millisecond
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := time.Now()
+	for now := range ticker.C {
+		// 감시 루틴 깨어남 지연 (CPU 포화 시 증가)
+		lag := now.Sub(last) - interval
+		last = now
+		// LevelDB 단건 읽기 시간 (쓰기/컴팩션 경합 시 증가)
+		start := time.Now()
+		_, _ = db.Get([]byte("height_latest"), nil)
+		sample := time.Since(start) + max(lag, 0)
+
+		prev := time.Duration(internalLatNs.Load())
+		ewma := time.Duration(loadShedEWMAWeight*float64(sample) + (1-loadShedEWMAWeight)*float64(prev))
+		internalLatNs.Store(int64(ewma))
+
+		busy := consensusInProgress.Load() || syncInProgress.Load()
+		level := PressureNone
+		switch {
+		case ewma > 2*threshold || (busy && ewma > threshold):
+			level = PressureHigh
+		case busy || ewma > threshold:
+			level = PressureElevated
+		}
+		if old := loadPressure.Swap(int32(level)); old != int32(level) && (old == PressureHigh || level == PressureHigh) {
+			log.Printf("[LOADSHED] pressure %s -> %s (latency=%s, busy=%v)", pressureNames[old], pressureNames[level], ewma.Round(time.Millisecond), busy)
+		}
+	}
+}
+
+// 부하 단계에 따라 비핵심 요청 거절/대기 (withAPIVersion 바깥에서 감쌈)
+func withLoadShedding(next http.Handler) http.Handler {
+	querySlots = make(chan struct{}, loadShedSlots)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		level := int(loadPressure.Load())
+		if !loadShedEnabled || level == PressureNone {
+			next.ServeHTTP(w, r)
+			return
+		}
+		class := classifyRequest(r)
+		switch {
+		case class <= ClassSync:
+			next.ServeHTTP(w, r)
+		case class == ClassQuery && level == PressureElevated:
+			select {
+			case querySlots <- struct{}{}:
+				defer func() { <-querySlots }()
+				next.ServeHTTP(w, r)
+			case <-time.After(LoadShedQueueWait * time.Second):
+				shedRequest(w, class, level)
+			case <-r.Context().Done():
+			}
+		default:
+			shedRequest(w, class, level)
+		}
+	})
+}
+
+func shedRequest(w http.ResponseWriter, class, level int) {
+	retry := LoadShedRetryAfter
+	if level == PressureHigh {
+		retry = LoadShedRetryHigh
+	}
+	incCounter("chain_requests_shed_total", `class="`+classNames[class]+`"`)
+	w.Header().Set("Retry-After", strconv.Itoa(retry))
+	writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("node under load (%s), retry later", pressureNames[level]))
+}
@@ -2,11 +2,10 @@
 package main
 
 import (
-	"encoding/json"
-	"io"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -17,9 +16,86 @@ func main() {
 	addr := getEnvDefault("PORT", "5000")
 	addr = ":" + addr
 
-	boot = getEnvDefault("BOOTSTRAP_ADDR", "hos-boot:5000")        // Hos체인 부트노드 주소
-	self = getEnvDefault("NODE_ADDR", "hos-node-00:5000")          // 이 노드의 외부접속 주소
-	govBoot = getEnvDefault("GOV_BOOTSTRAP_ADDR", "gov-boot:5000") // GOV체인 부트노드 주소
+	boot = getEnvDefault("BOOTSTRAP_ADDR", "hos-boot:5000")                // Hos체인 부트노드 주소
+	self = getEnvDefault("NODE_ADDR", "hos-node-00:5000")                  // 이 노드의 외부접속 주소 (auto 면 탐지, advertise.go)
+	stunServer = getEnvDefault("STUN_SERVER", "")                          // NODE_ADDR=auto 일 때 외부 IP 확인용 STUN 서버 (host:port)
+	govBoot = getEnvDefault("GOV_BOOTSTRAP_ADDR", "gov-boot:5000")         // GOV체인 부트노드 주소
+	region = getEnvDefault("NODE_REGION", "default")                       // 이 노드의 리전 라벨
+	legacySunset = getEnvDefault("API_LEGACY_SUNSET", LegacySunsetDefault) // 버전 없는 기존 API 경로 폐기 시각
+	if n, err := strconv.Atoi(getEnvDefault("RETENTION_WATCHER_TIME", "")); err == nil && n > 0 {
+		RetentionWatcherTime = n // 계약 보존 규칙 평가 주기(초)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("REPLAY_WINDOW", "")); err == nil && n > 0 {
+		ReplayWindow = n // 중복/재전송 차단 창(초)
+	}
+	fastSyncEnabled = getEnvDefault("FAST_SYNC", "true") == "true"         // 헤더 우선 병렬 동기화
+	snapshotSyncEnabled = getEnvDefault("SNAPSHOT_SYNC", "true") == "true" // 신규 노드 체크포인트 부트스트랩
+	if n, err := strconv.Atoi(getEnvDefault("CHECKPOINT_INTERVAL", "")); err == nil && n >= 0 {
+		CheckpointInterval = n // 상태 체크포인트 주기(블록, 0 이면 생성 안 함)
+	}
+	archiveMode = getEnvDefault("ARCHIVE_NODE", "true") == "true" // false 면 오래된 블록 본문 정리
+	if n, err := strconv.Atoi(getEnvDefault("PRUNE_RETAIN_BLOCKS", "")); err == nil && n > 0 {
+		PruneRetainBlocks = n // 본문을 유지할 최신 블록 수
+	}
+	loadShedEnabled = getEnvDefault("LOADSHED_ENABLED", "true") == "true" // 부하 시 비핵심 요청 제한
+	if n, err := strconv.Atoi(getEnvDefault("LOADSHED_LATENCY_MS", "")); err == nil && n > 0 {
+		loadShedLatencyMs = n // 부하 판단 내부 지연 기준(ms)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("LOADSHED_QUERY_SLOTS", "")); err == nil && n > 0 {
+		loadShedSlots = n // 부하 시 조회 동시 처리 수
+	}
+	if n, err := strconv.Atoi(getEnvDefault("VIEW_ABANDON_TIMEOUT", "")); err == nil && n >= 0 {
+		ViewAbandonTimeout = n // 확정되지 않은 view 포기 기준(초, 0 이면 포기 안 함)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("READY_MAX_LAG", "")); err == nil && n >= 0 {
+		ReadyMaxLag = n // 준비 상태로 볼 최대 동기화 지연(블록)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("MAX_PENDING_ENTRIES", "")); err == nil && n >= 0 {
+		MaxPendingEntries = n // 메모리풀 최대 레코드 수 (0 이면 제한 없음)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("MAX_PENDING_BYTES", "")); err == nil && n >= 0 {
+		MaxPendingBytes = n // 메모리풀 최대 크기(바이트, 0 이면 제한 없음)
+	}
+	if n, err := strconv.Atoi(getEnvDefault("BLOCK_PATIENT_QUOTA", "")); err == nil && n >= 0 {
+		BlockPatientQuota = n // 블록당 환자별 최대 레코드 수 (0 이면 제한 없음)
+	}
+	if PendingEvictPolicy = getEnvDefault("PENDING_EVICT_POLICY", PendingEvictReject); !validPendingEvictPolicy(PendingEvictPolicy) {
+		log.Fatalf("[START] PENDING_EVICT_POLICY must be %s or %s", PendingEvictReject, PendingEvictOldest) // 메모리풀 한도 초과 시 처리
+	}
+	if WireFormat = getEnvDefault("WIRE_FORMAT", WireFormatProto); WireFormat != WireFormatProto && WireFormat != WireFormatJSON {
+		log.Fatalf("[START] WIRE_FORMAT must be %s or %s", WireFormatProto, WireFormatJSON) // 노드 간 합의/동기화 메시지 형식
+	}
+	registerAllowlist = getEnvDefault("REGISTER_ALLOWLIST", "false") == "true" // 운영자 승인 키만 피어 가입 허용
+	patientAuthMode = getEnvDefault("PATIENT_AUTH", PatientAuthGov)            // 환자별 조회 접근 제어 : gov | off
+	if nodeRole = getEnvDefault("NODE_ROLE", RoleValidator); nodeRole != RoleValidator && nodeRole != RoleObserver {
+		log.Fatalf("[START] NODE_ROLE must be %s or %s", RoleValidator, RoleObserver) // observer : 동기화/조회 전용 (observer.go)
+	}
+	if err := initWebhooks(os.Getenv("WEBHOOK_URLS"), os.Getenv("WEBHOOK_EVENTS"), os.Getenv("WEBHOOK_SECRET")); err != nil {
+		log.Fatalf("[WEBHOOK] %v", err) // 블록/앵커/부트노드 이벤트 외부 통지 (webhook.go)
+	}
+	if err := initPHIKeys(os.Getenv("PHI_KEY"), os.Getenv("PHI_PREV_KEYS")); err != nil {
+		log.Fatalf("[PHI] %v", err) // 진료 정보 필드 암호화 키 (phi.go)
+	}
+
+	// 노드 간 mTLS (TLS_CERT_FILE/TLS_KEY_FILE 지정 시)
+	initNodeTLS()
+	// 노드 간 HTTP 클라이언트 (제한시간, 재시도, 회로 차단 : peerclient.go)
+	initPeerClient()
+	// API 인증 설정 (API_KEYS / JWT_SECRET / PEER_TOKEN : auth.go)
+	initAuth()
+	// NODE_ADDR=auto 면 외부 접속 주소 탐지, NODE_ADVERTISE_ADDRS 는 추가 광고 주소 (advertise.go)
+	initAdvertisedAddrs(strings.TrimPrefix(addr, ":"), os.Getenv("NODE_ADVERTISE_ADDRS"))
+
+	// 재생 모드 : 장부 이력을 빈 DB에 재생하여 현재 빌드와의 호환성만 확인하고 종료
+	if getEnvDefault("REPLAY_MODE", "false") == "true" {
+		runReplay(os.Getenv("REPLAY_SOURCE"), getEnvDefault("REPLAY_DB_PATH", "replay_db"))
+		return
+	}
+
+	// 시작 시 NTP 오프셋 측정, 피어 시계 오차 경고/차단 기준 (timesync.go)
+	if err := initClockSync(getEnvDefault("NTP_SERVERS", NTPServerDefault), os.Getenv("CLOCK_SKEW_MAX"), getEnvDefault("CLOCK_SKEW_MODE", ClockSkewWarn)); err != nil {
+		log.Fatalf("[CLOCK] %v", err)
+	}
 
 	// 2) DB 초기화
 	initDB(dbPath)
@@ -32,110 +108,254 @@ func main() {
 		log.Fatal("[START] chain init error: ", err)
 	}
 	log.Printf("[START] LowerChain ready (hos_id=%s)\n", hosID)
+	// 저장된 블록 해시를 정규 인코딩으로 확인 (최초 1회, canonical.go)
+	if err := migrateCanonicalEncoding(); err != nil {
+		log.Fatal("[START] canonical encoding: ", err)
+	}
+
+	// 이 노드 리전의 서브 장부(상주 레코드 전용) 준비
+	ensureSubGenesis()
+	loadRegionPending()
+
+	// 재시작 전 끝나지 않은 관리 작업 정리
+	recoverJobs()
+
+	// 피어 가입 허용 목록 초기값 (공개키 지문, 쉼표 구분)
+	seedAllowlist(os.Getenv("REGISTER_ALLOWED_KEYS"))
 
 	// 4) HTTP 라우팅 등록
-	mux := http.NewServeMux()
+	mux := newRouteMux() // 등록 경로를 기록하여 OpenAPI 문서 생성 (openapi.go)
 	// 사용자와 상호작용을 위한 API 등록
 	RegisterAPI(mux, chain)
 	// 노드 간 통신 엔드포인트 등록
 	//     - /addPeer : 기존 노드들이 신규 노드를 추가
 	//	   - /bft/start : Pre-Prepare 수신용
 	//	   - /bft/prepare : Prepare 서명 교환용
-	//	   - /register : 부트노드 연결 및 네트워크 연결
+	//	   - /bft/viewchange : 리더 장애 시 라운드 교체 투표
+	//	   - /register : 부트노드 연결 및 네트워크 연결 (nonce 서명 필수)
+	//	   - /register/challenge : 등록용 1회성 nonce 발급
+	//	   - /whoami : 요청 출발 주소 에코 (NAT 외부 주소 탐지), 이 노드의 광고 주소
 	//	   - /bootNotify : 부트노드 변경 수신
 	//	   - /getPublicKey : 공개키 반환
+	//	   - /keyRotation : 피어의 키 교체 공지 수신 (이전 키/새 키 서명 확인, 부트노드는 피어와 Gov 에 전달)
+	//	   - /rotateKey : 이 노드의 서명 키 쌍 교체 (운영자용)
+	//	   - /commitment : 체인 상태 집계 커밋먼트 조회
+	//	   - /headers : 블록 헤더 페이지 (헤더 우선 동기화용, 본문 제외)
+	//	   - /snapshot : 서명된 상태 체크포인트 (신규 노드 부트스트랩용)
+	//	   - /chain/info : 체인 식별 정보 (chain ID, 제네시스, 합의 방식, 해시 규칙, 검증자 집합 해시, 프로토콜 버전)
+	//	   - /metrics : Prometheus 메트릭 (체인 높이, 합의, 동기화 지연 등)
 	//	   - /chgGovBoot : 신규 선출된 Gov 부트노드 주소를 Hos 부트노드가 수신
 	//	   - /govBootNotify : Hos 부트노드로부터 전파된 Gov 부트노드 주소 수신
-	mux.HandleFunc("/addPeer", addPeer)
-	mux.HandleFunc("/bft/start", handleBftStart)
-	mux.HandleFunc("/bft/prepare", handleReceivePrepare)
-	mux.HandleFunc("/bft/commit", handleReceiveCommit)
-	mux.HandleFunc("/register", registerPeer)
-	mux.HandleFunc("/bootNotify", bootNotify)
+	//	   - /anchor/resend : Gov 가 누락을 감지한 블록 번호의 앵커를 큐에 다시 기록 (부트노드 전용)
+	//	   - /residency/pending : 같은 리전 노드가 접수한 상주 레코드를 리전 리더가 수신
+	//	   - /residency/prepare : 리전 리더의 서브 장부 블록 제안 검증 및 서명
+	//	   - /residency/commit : 리전 정족수 서명이 포함된 서브 장부 블록 수신
+	//	   - /residency/blocks : 이 노드 리전의 서브 장부 조회
+	//	   - /ws/events : 블록 확정/앵커 수락/부트노드 선출/피어 변동 이벤트 WebSocket 스트림 (Upgrade 없으면 SSE)
+	//	   - /events : 동일 이벤트의 SSE 스트림
+	//	   - /jobs, /jobs/{id} : 비동기 관리 작업 목록/상태 조회, 취소(DELETE)
+	//	   - /admin/reindex : 검색 색인 재구성 작업 시작 (202 + 작업 ID)
+	//	   - /admin/audit : 장부 무결성 감사 작업 시작 (202 + 작업 ID)
+	//	   - /admin/retention : 계약 보존 규칙 즉시 평가 작업 시작 (202 + 작업 ID)
+	//	   - /admin/prune : 오래된 블록 본문 정리 작업 시작 (ARCHIVE_NODE=false 일 때만 정리)
+	//	   - /admin/allowlist : 피어 가입 허용 목록 조회/승인/취소 (REGISTER_ALLOWLIST=true 일 때 적용, operator 전용)
+	//	   - /admin/finalize : 메모리풀 레코드로 즉시 합의 라운드 시작 (부트노드 전용)
+	//	   - /admin/resync : 가장 긴 피어 체인과 즉시 동기화/분기 교체 작업 시작 (202 + 작업 ID)
+	//	   - /admin/backup : LevelDB 스냅샷 전체를 tar.gz 로 내려받음 (노드 중단 없이, backup.go)
+	//	   - /admin/restore : 백업 tar.gz 설치 후 높이/블록 해시 검증
+	//	   - /admin/export : 블록 전체를 NDJSON 아카이브로 스트리밍 (?from=N, chainarchive.go)
+	//	   - /admin/import : 아카이브 블록을 검증하며 로컬 체인에 이어 붙임 (새 노드 이관)
+	//	   - /admin/webhooks : 웹훅 대상별 전송/실패/버림 현황 (WEBHOOK_URLS, webhook.go)
+	//	   - /content/{clinic_id}/history : clinic_id 의 레코드 버전 이력과 버전별 포함 증명 (?version=N 으로 단일 버전)
+	//	   - /patient/{patient_id}/records : 환자별 레코드 + 포함 증명 (Gov 서명 요청만 허용, PATIENT_AUTH)
+	//	   - /revoke : 확정 레코드 철회 (툼스톤 레코드를 다음 블록에 기록, 이후 /search·/proof 에 revoked 표시)
+	//	   - /retention/manifests : 보존 기한 만료 레코드의 아카이브 매니페스트 조회
+	//	   - /healthz : 프로세스 생존 확인 (liveness)
+	//	   - /readyz : DB 열림, 제네시스 존재, 최고 피어 대비 동기화 지연 확인 (readiness, 준비 안 되면 503)
+	//	   - /openapi.json : 등록된 경로로부터 생성한 OpenAPI 3 문서
+	//	   - /docs : API 문서 뷰어
+	//	   - /dashboard : 내장 실시간 대시보드 (블록/메모리풀/피어/PBFT 단계/앵커 큐 + 이벤트 스트림, dashboard.go)
+	//	   - /consensus/state : 진행 중인 PBFT view 별 단계/라운드/리더/투표 수
+	//	   - /anchor/queue : Gov 제출 대기 앵커 큐 (재시도 횟수/다음 재시도/마지막 오류)
+	//	   - /clock : NTP 오프셋, 피어별 시계 오차와 판정 (CLOCK_SKEW_MAX/CLOCK_SKEW_MODE, timesync.go)
+	//	   (mTLS 활성 시 노드 간 엔드포인트는 고정된 인증서를 제시한 노드만 호출 가능)
+	//	   (같은 체인 노드 간 엔드포인트는 X-Chain-ID 가 없거나 다른 요청 거절 : chaininfo.go)
+	//	   (인증 사용 시 노드 간 엔드포인트는 peer, 운영 엔드포인트는 operator 역할 필요 : auth.go)
+	//	   (NODE_ROLE=observer 면 레코드 접수/즉시 합의 요청은 403 : observer.go)
+	//	   (모든 경로는 /v1/<경로> 로도 호출 가능, 버전 없는 경로는 폐기 예정 헤더 포함 / GET /v1/meta : 지원 기능 조회)
+	//	   (합의/동기화 중에는 조회·내보내기 요청을 대기시키거나 503 + Retry-After 로 거절 : loadshed.go)
+	mux.HandleFunc("/addPeer", requireNodeCert(requireSameChain(requireRole(RolePeer, addPeer))))
+	mux.HandleFunc("/bft/start", requireNodeCert(requireSameChain(requireRole(RolePeer, handleBftStart))))
+	mux.HandleFunc("/bft/prepare", requireNodeCert(requireSameChain(requireRole(RolePeer, handleReceivePrepare))))
+	mux.HandleFunc("/bft/commit", requireNodeCert(requireSameChain(requireRole(RolePeer, handleReceiveCommit))))
+	mux.HandleFunc("/bft/viewchange", requireNodeCert(requireSameChain(requireRole(RolePeer, handleViewChange))))
+	mux.HandleFunc("/pending/relay", requireNodeCert(requireSameChain(requireRole(RolePeer, handlePendingRelay))))
+	mux.HandleFunc("/validators", requireRoleForWrites(RoleOperator, handleValidators))
+	mux.HandleFunc("/evidence", requireRole(RoleOperator, handleEvidence))
+	mux.HandleFunc("/register", requireRole(RolePeer, registerPeer))
+	mux.HandleFunc("/register/challenge", handleRegisterChallenge)
+	mux.HandleFunc("/whoami", handleWhoami)
+	mux.HandleFunc("/bootNotify", requireNodeCert(requireSameChain(requireRole(RolePeer, bootNotify))))
 	mux.HandleFunc("/getPublicKey", getPublicKey)
-	mux.HandleFunc("/chgGovBoot", chgGovBoot)
-	mux.HandleFunc("/govBootNotify", govBootNotify)
+	mux.HandleFunc("/keyRotation", requireNodeCert(requireSameChain(requireRole(RolePeer, handleKeyRotation))))
+	mux.HandleFunc("/rotateKey", requireRole(RoleOperator, handleRotateKey))
+	mux.HandleFunc("/commitment", handleCommitment)
+	mux.HandleFunc("/headers", handleHeaders)
+	mux.HandleFunc("/snapshot", handleSnapshot)
+	mux.HandleFunc("/chain/info", handleChainInfo)
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/chgGovBoot", requireNodeCert(chgGovBoot))
+	mux.HandleFunc("/govBootNotify", requireNodeCert(requireRole(RolePeer, govBootNotify)))
+	mux.HandleFunc("/anchor/resend", requireNodeCert(handleAnchorResend))
+	mux.HandleFunc("/residency/pending", requireNodeCert(requireSameChain(requireRole(RolePeer, handleResidencyPending))))
+	mux.HandleFunc("/residency/prepare", requireNodeCert(requireSameChain(requireRole(RolePeer, handleResidencyPrepare))))
+	mux.HandleFunc("/residency/commit", requireNodeCert(requireSameChain(requireRole(RolePeer, handleResidencyCommit))))
+	mux.HandleFunc("/residency/blocks", handleResidencyBlocks)
+	mux.HandleFunc("/ws/events", handleWSEvents)
+	mux.HandleFunc("/events", handleSSEEvents)
+	mux.HandleFunc("/jobs", requireRole(RoleOperator, handleJobs))
+	mux.HandleFunc("/jobs/", requireRole(RoleOperator, handleJob))
+	mux.HandleFunc("/admin/reindex", requireRole(RoleOperator, handleStartJob("reindex", reindexJob)))
+	mux.HandleFunc("/admin/audit", requireRole(RoleOperator, handleStartJob("audit", auditJob)))
+	mux.HandleFunc("/admin/retention", requireRole(RoleOperator, handleStartJob("retention", retentionJob)))
+	mux.HandleFunc("/admin/prune", requireRole(RoleOperator, handleStartJob("prune", pruneJob)))
+	mux.HandleFunc("/admin/allowlist", requireRole(RoleOperator, handleAllowlist))
+	mux.HandleFunc("/admin/finalize", requireOperatorOrForwarded(handleAdminFinalize))
+	mux.HandleFunc("/admin/resync", requireRole(RoleOperator, handleStartJob("resync", resyncJob)))
+	mux.HandleFunc("/admin/backup", requireRole(RoleOperator, handleAdminBackup))
+	mux.HandleFunc("/admin/restore", requireRole(RoleOperator, handleAdminRestore))
+	mux.HandleFunc("/admin/export", requireRole(RoleOperator, handleAdminExport))
+	mux.HandleFunc("/admin/import", requireRole(RoleOperator, handleAdminImport))
+	mux.HandleFunc("/admin/webhooks", requireRole(RoleOperator, handleWebhooks))
+	mux.HandleFunc("/retention/manifests", handleRetentionManifests)
+	mux.HandleFunc("/revoke", requireRole(RoleOperator, handleRevoke))
+	mux.HandleFunc("/content/", handleContentHistory)
+	mux.HandleFunc("/patient/", handlePatientRecords)
+	mux.HandleFunc("/healthz", handleHealthz)
+	mux.HandleFunc("/readyz", handleReadyz)
+	mux.HandleFunc("/openapi.json", handleOpenAPI(mux, "Hos node API"))
+	mux.HandleFunc("/docs", handleDocs)
+	mux.HandleFunc("/dashboard", handleDashboard)
+	mux.HandleFunc("/consensus/state", handleConsensusState)
+	mux.HandleFunc("/anchor/queue", handleAnchorQueue)
+	mux.HandleFunc("/clock", handleClock)
 
 	mux.Handle("/", http.FileServer(http.Dir("./static")))
 
-	// 5) 앵커 서명을 위한 key pair 생성
+	// 5) 앵커 서명을 위한 key pair 생성/복호화 (KEY_PASSPHRASE, KEY_PASSPHRASE_FILE, KEY_KMS_URL : keystore.go)
 	ensureKeyPair()
 
 	// 6) 서버 시작 (REST 요청 수신 가능한 상태로 돌입)
 	go func() {
 		log.Println("[START] NODE Running on", addr)
-		if err := http.ListenAndServe(addr, mux); err != nil {
+		if err := serveNode(addr, withCompression(withWireFormat(withLoadShedding(withAPIVersion(mux.ServeMux))))); err != nil {
 			log.Fatal(err)
 		}
 	}()
 
+	// gRPC 서버 (GRPC_PORT 지정 시, grpc.go)
+	if port := getEnvDefault("GRPC_PORT", ""); port != "" {
+		go func() {
+			if err := serveGRPC(port); err != nil {
+				log.Printf("[GRPC][ERROR] %v", err)
+			}
+		}()
+	}
+
 	// 7) 자동 부트스트랩
 	//  부트노드가 아니라면 부트노드에 자신의 주소를 등록 -> 부트노드로부터 노드 주소 목록 받아 등록 -> 체인 동기화
 	if boot != "" && self != "" && boot != self {
 
-		// 내 공개키를 meta에서 가져옴
-		myPubKey, ok := getMeta("meta_hos_pubkey")
-		if !ok {
-			log.Fatal("[BOOT] Public key not found in meta. Check ensureKeyPair.")
-		}
-
-		payload := map[string]string{
-			"hos_id":  hosID,
-			"addr":    self,
-			"pub_key": myPubKey,
-		}
-		b, _ := json.Marshal(payload)
-
-		resp, err := http.Post("http://"+boot+"/register", "application/json", strings.NewReader(string(b)))
+		// 부트노드 nonce 에 내 키로 서명하여 등록 (허용 목록 모드면 승인될 때까지 대기, registration.go)
+		reg, status, err := registerWithBoot(hosID)
 		if err != nil {
 			log.Printf("[BOOT] register failed: %v", err)
 			return
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			log.Printf("[BOOT] register failed : status=%d body=%s", resp.StatusCode, string(body))
+		if status != http.StatusOK && isObserver() {
+			log.Printf("[OBSERVER] register with %s failed (status=%d); retry on next network watch", boot, status)
+		} else if status != http.StatusOK {
 			log.Println("[BOOT] Now, This is Boot Node. skipping auto-join")
 			isBoot.Store(true)
 		} else {
-
-			var reg struct {
-				Peers    []string          `json:"peers"`
-				PeerKeys map[string]string `json:"peer_keys"`
-			}
-			if err := json.NewDecoder(resp.Body).Decode(&reg); err != nil {
-				log.Printf("[BOOT] decode peers failed: %v", err)
-				return
-			}
 			log.Printf("[BOOT-JOIN] received %d peers from %s: %v", len(reg.Peers), boot, reg.Peers)
 
 			// 수신된 명단을 순회하며 주소와 공개키를 함께 저장
 			for addr, pubKey := range reg.PeerKeys {
 				addPeerInternal(addr, pubKey)
 			}
+			for addr, pin := range reg.CertPins {
+				pinPeerCert(addr, pin)
+			}
 
-			// 초기 체인 동기화(부트노드로부터)
-			go syncChain(boot)
+			// 초기 체인 동기화(같은 리전 피어 우선, 없으면 부트노드로부터)
+			go syncChain(pickSyncPeer(boot))
 			log.Printf("[BOOT] Chain Initialized by %s(boot node); peers=%v", boot, reg.Peers)
 		}
+	} else if isObserver() {
+		log.Fatal("[START] NODE_ROLE=observer needs BOOTSTRAP_ADDR of another node")
 	} else {
 		log.Println("[BOOT] This is Boot Node, skipping auto-join")
 		isBoot.Store(true)
 	}
+	// 부트노드는 Gov 체인에 체인 등록 후 기관 가입 신청 (승인 전에는 앵커가 거부됨)
+	// 부트노드는 장부 검증자 집합에 없는 노드의 join 접수 (validators.go)
+	if isBoot.Load() {
+		go reconcileValidatorSet()
+		go func() {
+			registerWithGov()
+			submitOnboarding()
+		}()
+	}
+
 	// 8) 네트워크, 채굴, 체인 감시 루틴 실행
 	go func() {
 		log.Printf("[WATCHER] starting unified network watcher (%ds interval)", NetworkWatcherTime)
 		startNetworkWatcher()
 	}()
+	// 관찰 노드는 합의에 참여하지 않고 체인 감시(동기화)만 짧은 주기로 수행 (observer.go)
+	if isObserver() {
+		ChainWatcherTime = ObserverSyncTime
+		log.Printf("[OBSERVER] read-only observer mode; consensus watchers disabled")
+	} else {
+		go func() {
+			log.Printf("[WATCHER] starting unified mining watcher (%ds interval)", ConsWatcherTime)
+			startConsensusWatcher()
+		}()
+		go func() {
+			log.Printf("[WATCHER] starting view-change watcher (%ds base timeout)", ViewChangeTimeout)
+			startViewChangeWatcher()
+		}()
+		go func() {
+			log.Printf("[WATCHER] starting residency sub-ledger watcher (region=%s)", region)
+			startResidencyWatcher()
+		}()
+	}
+	go func() {
+		log.Printf("[WATCHER] starting retention watcher (%ds interval)", RetentionWatcherTime)
+		startRetentionWatcher()
+	}()
+	go func() {
+		log.Printf("[WATCHER] starting block pruner (%ds interval, archive=%v, retain %d blocks)", PruneWatcherTime, archiveMode, PruneRetainBlocks)
+		startPruneWatcher()
+	}()
+	go func() {
+		log.Printf("[WATCHER] starting seen-set pruner (%ds interval, window %ds)", SeenPruneInterval, ReplayWindow)
+		startSeenPruner()
+	}()
+	go func() {
+		log.Printf("[WATCHER] starting load monitor (%dms probe, latency limit %dms, enabled=%v)", LoadShedProbeInterval, loadShedLatencyMs, loadShedEnabled)
+		startLoadMonitor()
+	}()
+	go func() {
+		log.Printf("[WATCHER] starting anchor retry queue (backoff %d..%ds)", AnchorRetryBase, AnchorRetryMax)
+		startAnchorQueueWatcher()
+	}()
 	go func() {
-		log.Printf("[WATCHER] starting unified mining watcher (%ds interval)", ConsWatcherTime)
-		startConsensusWatcher()
+		log.Printf("[WATCHER] starting unified chain watcher (%ds interval)", ChainWatcherTime)
+		startChainWatcher()
 	}()
-	//go func() {
-	//	log.Printf("[WATCHER] starting unified chain watcher (%ds interval)", ChainWatcherTime)
-	//	startChainWatcher()
-	//}()
 
 	// 9) 메인 Go 루틴 유지
 	select {}
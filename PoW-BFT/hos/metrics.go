@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Prometheus 메트릭 (GET /metrics, text exposition format 0.0.4)
+// ------------------------------------------------------------
+// - 게이지(조회 시점 계산) : 체인 높이, 메모리풀 수, 피어 수, 동기화 지연(피어 최대 높이 - 내 높이),
+//   부하 단계/내부 지연 (loadshed.go)
+// - 카운터/히스토그램(이벤트 누적) : 합의 소요시간, PBFT 단계 전이, view-change,
+//   LevelDB 오류, Gov 앵커 제출 결과, 부하 제한으로 거절된 요청
+// - 모든 시계열에 node_role(hos), chain_id(Hos 식별자) 라벨을 붙여 cp/ott/hos/gov 노드를 한 대시보드에서 구분
+////////////////////////////////////////////////////////////////////////////////
+
+const metricsNodeRole = "hos"
+
+// 소요시간 히스토그램 구간 상한(초)
+var metricDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type metricHistogram struct {
+	counts []uint64 // 구간별 건수 (출력 시 누적)
+	sum    float64
+	count  uint64
+}
+
+var (
+	metricsMu      sync.Mutex
+	metricCounters = make(map[string]map[string]float64) // 이름 => 라벨 => 값
+	metricHists    = make(map[string]*metricHistogram)   // 이름 => 히스토그램
+
+	peerHeights   = make(map[string]int) // 피어 주소 => 마지막으로 확인한 높이
+	peerHeightsMu sync.Mutex
+)
+
+var metricHelp = map[string][2]string{
+	"chain_height":                      {"gauge", "Latest block height of this node."},
+	"chain_pending_entries":             {"gauge", "Entries waiting in the mempool."},
+	"chain_peers":                       {"gauge", "Number of known peers."},
+	"chain_sync_lag_blocks":             {"gauge", "Highest peer height seen minus local height."},
+	"chain_consensus_duration_seconds":  {"histogram", "Time from proposal to finalization of a PBFT view."},
+	"chain_bft_phase_transitions_total": {"counter", "PBFT phase transitions by phase entered."},
+	"chain_consensus_abandoned_total":   {"counter", "Views abandoned without finalization by phase reached."},
+	"chain_bft_view_changes_total":      {"counter", "PBFT rounds changed by view-change quorum."},
+	"chain_leveldb_errors_total":        {"counter", "LevelDB operation errors (excluding not-found)."},
+	"chain_anchor_submissions_total":    {"counter", "Anchor submissions to the Gov chain by result."},
+	"chain_pending_bytes":               {"gauge", "Serialized size of entries waiting in the mempool."},
+	"chain_pending_rejected_total":      {"counter", "Submitted entries rejected as duplicate, replayed or because the mempool is full, by reason."},
+	"chain_pending_evicted_total":       {"counter", "Times the oldest mempool entries were evicted to admit new submissions (PENDING_EVICT_POLICY=drop-oldest)."},
+	"chain_load_pressure":               {"gauge", "Load-shedding pressure level (0 none, 1 elevated, 2 high)."},
+	"chain_internal_latency_seconds":    {"gauge", "EWMA of internal probe latency (LevelDB read + scheduler lag)."},
+	"chain_requests_shed_total":         {"counter", "Requests rejected with 503 by load shedding, by endpoint class."},
+	"chain_peer_circuit_open_total":     {"counter", "Peer circuit breakers opened after consecutive transport failures, by peer."},
+	"chain_evidence_reported_total":     {"counter", "Misbehavior evidence records submitted by this node, by type."},
+	"chain_reorgs_total":                {"counter", "Chain reorganizations that replaced a divergent local branch."},
+	"chain_webhook_deliveries_total":    {"counter", "Webhook deliveries by result (ok, failed after retries, dropped on a full queue)."},
+	"chain_clock_offset_seconds":        {"gauge", "Local clock minus reference time (NTP when measured, otherwise median of peer clocks)."},
+}
+
+// 카운터 증가 (labels 는 `key="value",...` 형식, 없으면 "")
+func incCounter(name, labels string) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	m, ok := metricCounters[name]
+	if !ok {
+		m = make(map[string]float64)
+		metricCounters[name] = m
+	}
+	m[labels]++
+}
+
+func observeDuration(name string, seconds float64) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	h, ok := metricHists[name]
+	if !ok {
+		h = &metricHistogram{counts: make([]uint64, len(metricDurationBuckets))}
+		metricHists[name] = h
+	}
+	for i, ub := range metricDurationBuckets {
+		if seconds <= ub {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// LevelDB 오류 집계 후 그대로 반환 (ErrNotFound 는 정상 흐름이므로 제외)
+func countDBError(err error) error {
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		incCounter("chain_leveldb_errors_total", "")
+	}
+	return err
+}
+
+// PBFT 단계 전이 기록
+func countPhase(phase int32) {
+	if phase != PhaseIdle {
+		incCounter("chain_bft_phase_transitions_total", `phase="`+phaseName(phase)+`"`)
+	}
+}
+
+// 메트릭/이벤트용 PBFT 단계 이름
+func phaseName(phase int32) string {
+	switch phase {
+	case PhasePrePrepare:
+		return "pre_prepare"
+	case PhasePrepare:
+		return "prepare"
+	case PhaseCommit:
+		return "commit"
+	case PhaseFinal:
+		return "final"
+	}
+	return "idle"
+}
+
+// 피어 높이 기록 (동기화 지연 계산용)
+func observePeerHeight(addr string, h int) {
+	peerHeightsMu.Lock()
+	peerHeights[addr] = h
+	peerHeightsMu.Unlock()
+}
+
+// 현재 피어 중 최대 높이 - 내 높이 (음수면 0)
+func syncLag(local int) int {
+	peers := peersSnapshot()
+	peerHeightsMu.Lock()
+	defer peerHeightsMu.Unlock()
+	best := local
+	for _, p := range peers {
+		if h, ok := peerHeights[p]; ok && h > best {
+			best = h
+		}
+	}
+	return best - local
+}
+
+// GET /metrics
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	height, _ := getLatestHeight()
+	gauges := map[string]float64{
+		"chain_height":                   float64(height),
+		"chain_pending_entries":          float64(getPendingCnt()),
+		"chain_pending_bytes":            float64(getPendingBytes()),
+		"chain_peers":                    float64(len(peersSnapshot())),
+		"chain_sync_lag_blocks":          float64(syncLag(height)),
+		"chain_load_pressure":            float64(loadPressure.Load()),
+		"chain_internal_latency_seconds": time.Duration(internalLatNs.Load()).Seconds(),
+		"chain_clock_offset_seconds":     clockOffsetSeconds(),
+	}
+
+	var sb strings.Builder
+	role := fmt.Sprintf(`node_role=%q,chain_id=%q`, metricsNodeRole, selfID())
+	withRole := func(labels string) string {
+		if labels == "" {
+			return "{" + role + "}"
+		}
+		return "{" + role + "," + labels + "}"
+	}
+
+	metricsMu.Lock()
+	names := make([]string, 0, len(metricHelp))
+	for n := range metricHelp {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		meta := metricHelp[n]
+		fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s %s\n", n, meta[1], n, meta[0])
+		switch meta[0] {
+		case "gauge":
+			fmt.Fprintf(&sb, "%s%s %g\n", n, withRole(""), gauges[n])
+		case "counter":
+			series := metricCounters[n]
+			if len(series) == 0 {
+				fmt.Fprintf(&sb, "%s%s 0\n", n, withRole(""))
+				continue
+			}
+			labels := make([]string, 0, len(series))
+			for l := range series {
+				labels = append(labels, l)
+			}
+			sort.Strings(labels)
+			for _, l := range labels {
+				fmt.Fprintf(&sb, "%s%s %g\n", n, withRole(l), series[l])
+			}
+		case "histogram":
+			h := metricHists[n]
+			if h == nil {
+				h = &metricHistogram{counts: make([]uint64, len(metricDurationBuckets))}
+			}
+			var cum uint64
+			for i, ub := range metricDurationBuckets {
+				cum += h.counts[i]
+				fmt.Fprintf(&sb, "%s_bucket%s %d\n", n, withRole(`le="`+strconv.FormatFloat(ub, 'g', -1, 64)+`"`), cum)
+			}
+			fmt.Fprintf(&sb, "%s_bucket%s %d\n", n, withRole(`le="+Inf"`), h.count)
+			fmt.Fprintf(&sb, "%s_sum%s %g\n", n, withRole(""), h.sum)
+			fmt.Fprintf(&sb, "%s_count%s %d\n", n, withRole(""), h.count)
+		}
+	}
+	metricsMu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(sb.String()))
+}
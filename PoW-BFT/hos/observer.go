@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Observer Node (읽기 전용 관찰 노드)
+// ------------------------------------------------------------
+// - NODE_ROLE=observer : 장부 동기화와 조회 API 만 제공 (합의 투표, 부트노드 선출에 참여하지 않음)
+//   · 부트노드 등록 시 role=observer 를 알림 => 부트노드는 피어 명단/공개키만 돌려주고
+//     피어 목록과 검증자 집합에 추가하지 않음 (다른 노드의 정족수, 브로드캐스트, 선출 후보에서 제외)
+//   · 합의/view-change/서브 장부 watcher 를 실행하지 않음
+//   · 등록에 실패하거나 살아있는 피어가 없어도 스스로 부트노드가 되지 않음
+//     (부트노드 장애 시 남은 피어 중 선출 규칙으로 새 부트노드를 인식만 함)
+//   · 확정 블록을 전달받지 않으므로 체인 감시 주기를 ObserverSyncTime 으로 줄여 가장 긴 피어를 따라감
+//   · 네트워크 감시 주기마다 부트노드에 다시 등록해 새 피어/공개키 반영
+//   · 레코드 접수(/upload, /revoke, gRPC SubmitRecords), 즉시 합의 요청은 403
+// - NODE_ROLE=validator (기본) : 기존 동작
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RoleValidator    = "validator"
+	RoleObserver     = "observer"
+	ObserverSyncTime = 10 // 관찰 노드 체인 감시 주기(초)
+)
+
+var nodeRole = RoleValidator // NODE_ROLE
+
+var errObserverReadOnly = errors.New("read-only observer node")
+
+func isObserver() bool {
+	return nodeRole == RoleObserver
+}
+
+// 관찰 노드 : 부트노드에 다시 등록하여 피어 명단/공개키 갱신 (네트워크 감시 주기마다)
+func refreshObserverPeers() {
+	reg, status, err := registerWithBoot(selfID())
+	if err != nil || status != http.StatusOK {
+		log.Printf("[OBSERVER] peer refresh from %s failed (status=%d, err=%v)", getBootAddr(), status, err)
+		return
+	}
+	for addr, pubKey := range reg.PeerKeys {
+		addPeerInternal(addr, pubKey)
+	}
+	for addr, pin := range reg.CertPins {
+		pinPeerCert(addr, pin)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Onboarding (Gov 체인 기관 가입 신청)
+// ------------------------------------------------------------
+// - Gov 체인은 가입 승인된 기관의 앵커만 수락하므로, 부트노드가 시작 시 가입 신청서를 제출
+//   · 신원 증빙 서류 해시 : ONBOARDING_DOCS_HASH (미지정 시 신청 생략)
+//   · 제안 계약          : ONBOARDING_CONTRACT_FILE (ContractData JSON, 선택, 없으면 Gov 등록 시 받은 계약 템플릿)
+//   · 노드 공개키        : 자신 + 등록된 피어들의 공개키
+// - 신청서 다이제스트를 Hos 키로 서명하여 Gov 부트노드의 /onboarding/apply 로 전송
+// - 승인 여부는 Gov 의 GET /onboarding/status?hos_id= 로 확인
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	OnboardingRetryInterval = 10 // 초
+	OnboardingMaxAttempts   = 6
+)
+
+// 가입 신청서 (Gov 의 OnboardingApplication 과 동일 규격)
+type OnboardingApplication struct {
+	HosID    string            `json:"hos_id"`
+	HosBoot  string            `json:"hos_boot"`
+	DocsHash string            `json:"docs_hash"` // 신원 증빙 서류 해시
+	PubKeys  map[string]string `json:"pub_keys"`  // Hos 노드 주소 => 공개키 PEM
+	Contract ContractData      `json:"contract"`  // 제안 계약
+	Ts       string            `json:"ts"`
+	Sig      string            `json:"sig"`
+}
+
+// 서명 대상 다이제스트 (hex) : Sig 제외
+func (a OnboardingApplication) digest() string {
+	return sha256Hex(jsonCanonical(struct {
+		HosID    string            `json:"hos_id"`
+		HosBoot  string            `json:"hos_boot"`
+		DocsHash string            `json:"docs_hash"`
+		PubKeys  map[string]string `json:"pub_keys"`
+		Contract ContractData      `json:"contract"`
+		Ts       string            `json:"ts"`
+	}{a.HosID, a.HosBoot, a.DocsHash, a.PubKeys, a.Contract, a.Ts}))
+}
+
+// 신청서 구성 및 서명
+func buildOnboardingApplication(docsHash, contractFile string) (OnboardingApplication, error) {
+	var c ContractData
+	if reg := govRegistration(); reg != nil && contractFile == "" {
+		c = reg.ContractTemplate // Gov 가 등록 시 내려준 계약 템플릿 (govregister.go)
+	}
+	if contractFile != "" {
+		data, err := os.ReadFile(contractFile)
+		if err != nil {
+			return OnboardingApplication{}, err
+		}
+		if err := json.Unmarshal(data, &c); err != nil {
+			return OnboardingApplication{}, err
+		}
+	}
+	c.HosID = selfID()
+
+	keys := validatorKeys()
+
+	app := OnboardingApplication{
+		HosID:    selfID(),
+		HosBoot:  self,
+		DocsHash: docsHash,
+		PubKeys:  keys,
+		Contract: c,
+		Ts:       time.Now().UTC().Format(time.RFC3339),
+	}
+	privPem, _ := nodePrivKey()
+	app.Sig = makeAnchorSignature(privPem, app.digest(), app.Ts)
+	return app, nil
+}
+
+// Gov 부트노드에 가입 신청 (부트노드에서만 실행, 실패 시 재시도)
+func submitOnboarding() {
+	docsHash := os.Getenv("ONBOARDING_DOCS_HASH")
+	if docsHash == "" {
+		log.Println("[ONBOARD] ONBOARDING_DOCS_HASH not set; skipping Gov onboarding application")
+		return
+	}
+	app, err := buildOnboardingApplication(docsHash, os.Getenv("ONBOARDING_CONTRACT_FILE"))
+	if err != nil {
+		log.Printf("[ONBOARD][ERROR] failed to build application: %v", err)
+		return
+	}
+	body, _ := json.Marshal(app)
+
+	for attempt := 1; attempt <= OnboardingMaxAttempts; attempt++ {
+		resp, err := nodeClient.Post(nodeURL(getGovBoot(), "/onboarding/apply"), "application/json", bytes.NewReader(body))
+		if err == nil {
+			msg, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusAccepted {
+				log.Printf("[ONBOARD][OK] Application submitted to Gov (%s): %s", getGovBoot(), strings.TrimSpace(string(msg)))
+				return
+			}
+			log.Printf("[ONBOARD][WARN] Gov rejected application (status=%d): %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+			if resp.StatusCode < 500 {
+				return
+			}
+		} else {
+			log.Printf("[ONBOARD][ERROR] attempt %d failed: %v", attempt, err)
+		}
+		time.Sleep(OnboardingRetryInterval * time.Second)
+	}
+	log.Printf("[ONBOARD][ERROR] giving up after %d attempts", OnboardingMaxAttempts)
+}
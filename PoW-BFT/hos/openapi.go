@@ -0,0 +1,352 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// OpenAPI 문서 (/openapi.json, /docs)
+// ------------------------------------------------------------
+// - main.go 의 라우터(routeMux)가 등록된 경로 패턴을 기록 => 등록된 경로마다 OpenAPI 3 path 항목 생성
+//   · 메서드/요약/쿼리 파라미터/요청·응답 본문은 apiDocs 표에서 보충 (표에 없는 경로는 GET + 기본 응답)
+//   · 본문 스키마는 Go 구조체의 json 태그로부터 생성 (components.schemas, 구조체 이름 기준)
+//   · "/jobs/" 처럼 하위 경로를 받는 패턴은 apiDocs 의 Path 템플릿(/jobs/{id})으로 표기
+//   · 정적 대시보드("/")는 제외
+// - 서버 기준 경로는 /v1 (버전 없는 기존 경로도 동작, versioning.go)
+// - 오류 응답은 공통 ErrorBody 스키마 (errors.go)
+// - GET /openapi.json : 문서 JSON
+// - GET /docs : 문서 뷰어 (static/docs.html, 외부 CDN 없이 /openapi.json 을 읽어 표시)
+////////////////////////////////////////////////////////////////////////////////
+
+// 등록된 경로 패턴을 기록하는 ServeMux
+type routeMux struct {
+	*http.ServeMux
+	patterns []string
+}
+
+func newRouteMux() *routeMux {
+	return &routeMux{ServeMux: http.NewServeMux()}
+}
+
+func (m *routeMux) Handle(pattern string, h http.Handler) {
+	m.patterns = append(m.patterns, pattern)
+	m.ServeMux.Handle(pattern, h)
+}
+
+func (m *routeMux) HandleFunc(pattern string, h func(http.ResponseWriter, *http.Request)) {
+	m.patterns = append(m.patterns, pattern)
+	m.ServeMux.HandleFunc(pattern, h)
+}
+
+// 경로 문서 항목
+type apiDoc struct {
+	Path    string     // 하위 경로 패턴의 문서 경로 (예: /jobs/{id}), 비우면 패턴 그대로
+	Methods []string   // 비우면 GET
+	Summary string     // 한 줄 설명
+	Query   []apiParam // 쿼리 파라미터
+	Body    any        // 요청 본문 타입 (POST/PUT, 스키마 생성용 값)
+	Resp    any        // 성공 응답 본문 타입
+	Status  int        // 성공 상태 코드 (기본 200)
+	Peer    bool       // 노드 간 엔드포인트 (mTLS 활성 시 고정 인증서 필요)
+}
+
+type apiParam struct {
+	Name, Type, Desc string
+}
+
+func qp(name, typ, desc string) apiParam { return apiParam{name, typ, desc} }
+
+var pageParams = []apiParam{qp("offset", "integer", "건너뛸 개수"), qp("limit", "integer", "최대 반환 개수")}
+
+// 증명을 돌려주는 조회의 증명 형식 버전 협상 파라미터 (+ 페이지)
+var (
+	proofVersionParam = qp("proof_version", "integer", "읽을 수 있는 최고 증명 형식 버전")
+	proofPageParams   = append([]apiParam{proofVersionParam}, pageParams...)
+)
+
+// 경로별 문서 (등록 패턴 기준)
+var apiDocs = map[string]apiDoc{
+	"/block/root":          {Summary: "최신 블록 머클 루트 (value 지정 시 해당 루트의 블록)", Query: []apiParam{qp("value", "string", "조회할 머클 루트")}},
+	"/block/index":         {Summary: "번호로 블록 조회", Query: []apiParam{qp("id", "integer", "블록 번호")}, Resp: LowerBlock{}},
+	"/block/latest":        {Summary: "최신 블록", Resp: LowerBlock{}},
+	"/block/hash":          {Summary: "해시로 블록 조회", Query: []apiParam{qp("value", "string", "블록 해시")}, Resp: LowerBlock{}},
+	"/search":              {Summary: "clinic_id 키워드 검색", Query: append([]apiParam{qp("value", "string", "검색어"), qp("include_expired", "boolean", "보존 기한 만료 레코드 포함"), qp("fields", "string", "선택 공개 필드 (쉼표 구분)")}, proofPageParams...), Resp: []SearchResponse{}},
+	"/search/fulltext":     {Summary: "진료 정보 전문 검색", Query: append([]apiParam{qp("q", "string", "검색어"), qp("include_expired", "boolean", "보존 기한 만료 레코드 포함")}, proofPageParams...), Resp: []SearchResponse{}},
+	"/proof":               {Summary: "레코드 포함 증명", Query: []apiParam{qp("block", "integer", "블록 번호"), qp("entry", "integer", "블록 내 엔트리 번호"), proofVersionParam}, Resp: ProofResponse{}},
+	"/blocks":              {Summary: "블록 목록 (페이지, gzip/ETag/protobuf)", Query: pageParams, Resp: blocksPage{}},
+	"/blocks/recent":       {Summary: "최근 블록", Query: []apiParam{qp("count", "integer", "개수"), qp("full", "boolean", "본문 포함")}},
+	"/status":              {Summary: "노드 상태 (높이, 부트노드, 제안자, 피어)"},
+	"/traffic":             {Summary: "리전별 트래픽 집계"},
+	"/peers":               {Summary: "피어 목록", Query: []apiParam{qp("detail", "boolean", "연결 상태/회로 차단 정보 포함")}},
+	"/upload":              {Methods: []string{"POST"}, Summary: "진료 레코드 접수 (메모리풀)", Body: []ClinicRecord{}},
+	"/pending":             {Summary: "메모리풀 레코드", Resp: []ClinicRecord{}},
+	"/addPeer":             {Methods: []string{"POST"}, Summary: "부트노드의 신규 피어 알림", Peer: true},
+	"/bft/start":           {Methods: []string{"POST"}, Summary: "PBFT Pre-Prepare 수신", Peer: true},
+	"/bft/prepare":         {Methods: []string{"POST"}, Summary: "PBFT Prepare 투표 수신", Peer: true},
+	"/bft/commit":          {Methods: []string{"POST"}, Summary: "PBFT Commit 투표 수신", Peer: true},
+	"/bft/viewchange":      {Methods: []string{"POST"}, Summary: "view-change 투표 수신", Peer: true},
+	"/pending/relay":       {Methods: []string{"POST"}, Summary: "다음 제안자에게 메모리풀 레코드 전달", Body: []ClinicRecord{}, Peer: true},
+	"/validators":          {Methods: []string{"GET", "POST"}, Summary: "검증자 집합 조회 / 변경 제안", Query: []apiParam{qp("height", "integer", "기준 블록 높이 (GET)")}, Body: ValidatorChange{}},
+	"/evidence":            {Summary: "이중 서명 증거 목록", Query: []apiParam{qp("height", "integer", "블록 높이")}},
+	"/register":            {Methods: []string{"POST"}, Summary: "부트노드에 피어 등록 (nonce 서명 필수)", Body: registerReq{}, Resp: registerResp{}},
+	"/whoami":              {Summary: "요청 출발 주소 에코 / 이 노드의 광고 주소", Resp: whoamiResp{}},
+	"/register/challenge":  {Summary: "등록용 1회성 nonce 발급", Query: []apiParam{qp("addr", "string", "등록할 노드 주소")}},
+	"/bootNotify":          {Methods: []string{"POST"}, Summary: "부트노드 변경 수신", Peer: true},
+	"/getPublicKey":        {Summary: "노드 공개키"},
+	"/keyRotation":         {Methods: []string{"POST"}, Summary: "피어의 키 교체 공지 수신", Body: KeyRotation{}, Peer: true},
+	"/rotateKey":           {Methods: []string{"POST"}, Summary: "이 노드의 서명 키 쌍 교체 (운영자용)"},
+	"/commitment":          {Summary: "체인 상태 집계 커밋먼트", Resp: ChainCommitment{}},
+	"/headers":             {Summary: "블록 헤더 페이지", Query: append([]apiParam{qp("sigs", "boolean", "합의 서명 포함")}, pageParams...), Resp: headersPage{}},
+	"/snapshot":            {Summary: "서명된 상태 체크포인트", Query: []apiParam{qp("manifest", "boolean", "매니페스트만 반환")}, Resp: Checkpoint{}},
+	"/chain/info":          {Summary: "체인 식별 정보", Resp: ChainInfo{}},
+	"/metrics":             {Summary: "Prometheus 메트릭 (text/plain)"},
+	"/chgGovBoot":          {Methods: []string{"POST"}, Summary: "신규 Gov 부트노드 주소 수신", Peer: true},
+	"/govBootNotify":       {Methods: []string{"POST"}, Summary: "Hos 부트노드가 전파한 Gov 부트노드 주소 수신", Peer: true},
+	"/anchor/resend":       {Methods: []string{"POST"}, Summary: "누락된 블록 앵커 재전송 (Gov 요청, 부트노드 전용)", Body: anchorResendRequest{}, Peer: true},
+	"/residency/pending":   {Methods: []string{"POST"}, Summary: "리전 리더의 상주 레코드 수신", Body: []ClinicRecord{}, Peer: true},
+	"/residency/prepare":   {Methods: []string{"POST"}, Summary: "서브 장부 블록 제안 검증 및 서명", Body: LowerBlock{}, Peer: true},
+	"/residency/commit":    {Methods: []string{"POST"}, Summary: "리전 정족수 서명이 포함된 서브 장부 블록 수신", Body: LowerBlock{}, Peer: true},
+	"/residency/blocks":    {Summary: "이 노드 리전의 서브 장부", Query: []apiParam{qp("region", "string", "리전 (이 노드 리전만)")}, Resp: []LowerBlock{}},
+	"/ws/events":           {Summary: "이벤트 WebSocket 스트림 (Upgrade 없으면 SSE)", Query: []apiParam{qp("types", "string", "이벤트 종류 필터 (쉼표 구분)")}},
+	"/events":              {Summary: "이벤트 SSE 스트림", Query: []apiParam{qp("types", "string", "이벤트 종류 필터 (쉼표 구분)")}},
+	"/jobs":                {Summary: "관리 작업 목록", Resp: []Job{}},
+	"/jobs/":               {Path: "/jobs/{id}", Methods: []string{"GET", "DELETE"}, Summary: "관리 작업 상태 조회 / 취소", Resp: Job{}},
+	"/admin/reindex":       {Methods: []string{"POST"}, Summary: "검색 색인 재구성 작업 시작", Resp: Job{}, Status: http.StatusAccepted},
+	"/admin/audit":         {Methods: []string{"POST"}, Summary: "장부 무결성 감사 작업 시작", Resp: Job{}, Status: http.StatusAccepted},
+	"/admin/retention":     {Methods: []string{"POST"}, Summary: "계약 보존 규칙 평가 작업 시작", Resp: Job{}, Status: http.StatusAccepted},
+	"/admin/prune":         {Methods: []string{"POST"}, Summary: "오래된 블록 본문 정리 작업 시작", Resp: Job{}, Status: http.StatusAccepted},
+	"/admin/allowlist":     {Methods: []string{"GET", "POST", "DELETE"}, Summary: "피어 가입 허용 목록 조회/승인/취소", Query: []apiParam{qp("fp", "string", "취소할 공개키 지문 (DELETE)")}, Resp: []AllowedKey{}},
+	"/admin/finalize":      {Methods: []string{"POST"}, Summary: "메모리풀 레코드로 즉시 합의 라운드 시작"},
+	"/admin/resync":        {Methods: []string{"POST"}, Summary: "가장 긴 피어 체인과 동기화 작업 시작", Resp: Job{}, Status: http.StatusAccepted},
+	"/admin/backup":        {Methods: []string{"POST"}, Summary: "LevelDB 상태 핫 백업 (tar.gz: manifest.json + state.kv)"},
+	"/admin/restore":       {Methods: []string{"POST"}, Summary: "백업 tar.gz 복원 (설치 후 높이/블록 해시 검증)"},
+	"/admin/export":        {Summary: "블록 NDJSON 아카이브 (1행 매니페스트 + 블록 한 줄씩)", Query: []apiParam{qp("from", "integer", "시작 블록 번호")}},
+	"/admin/import":        {Methods: []string{"POST"}, Summary: "NDJSON 아카이브 검증 후 로컬 체인에 반영", Resp: ImportResult{}},
+	"/admin/webhooks":      {Summary: "웹훅 대상별 전송 현황", Resp: []WebhookStatus{}},
+	"/retention/manifests": {Summary: "보존 기한 만료 레코드의 아카이브 매니페스트", Resp: []ArchiveManifest{}},
+	"/revoke":              {Methods: []string{"POST"}, Summary: "확정 레코드 철회 (툼스톤 기록)", Body: RevokeRequest{}},
+	"/content/":            {Path: "/content/{clinic_id}/history", Summary: "레코드 버전 이력과 버전별 포함 증명", Query: append([]apiParam{qp("version", "integer", "단일 버전")}, proofPageParams...), Resp: []HistoryEntry{}},
+	"/patient/":            {Path: "/patient/{patient_id}/records", Summary: "환자별 레코드 + 포함 증명 (Gov 서명 요청만 허용)", Query: append([]apiParam{qp("decrypt", "boolean", "진료 정보 복호화"), qp("include_expired", "boolean", "보존 기한 만료 레코드 포함")}, proofPageParams...), Resp: []SearchResponse{}},
+	"/healthz":             {Summary: "프로세스 생존 확인 (liveness)"},
+	"/readyz":              {Summary: "준비 상태 확인 (readiness, 준비되지 않으면 503 + 실패 항목)"},
+	"/openapi.json":        {Summary: "이 문서 (OpenAPI 3)"},
+	"/docs":                {Summary: "API 문서 뷰어 (HTML)"},
+	"/dashboard":           {Summary: "내장 실시간 대시보드 (HTML)"},
+	"/consensus/state":     {Summary: "진행 중인 PBFT view 별 단계/투표 수", Resp: ConsensusState{}},
+	"/anchor/queue":        {Summary: "Gov 제출 대기 앵커 큐", Resp: []QueuedAnchor{}},
+	"/clock":               {Summary: "NTP 오프셋과 피어별 시계 오차", Resp: ClockReport{}},
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPISpec []byte
+)
+
+// GET /openapi.json
+func handleOpenAPI(mux *routeMux, title string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		openAPIOnce.Do(func() {
+			openAPISpec, _ = json.Marshal(buildOpenAPI(mux.patterns, title))
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPISpec)
+	}
+}
+
+// GET /docs
+func handleDocs(w http.ResponseWriter, r *http.Request) {
+	http.ServeFile(w, r, "./static/docs.html")
+}
+
+// 등록된 패턴으로부터 OpenAPI 3 문서 생성
+func buildOpenAPI(patterns []string, title string) map[string]any {
+	schemas := map[string]any{"ErrorBody": schemaFor(reflect.TypeOf(ErrorBody{}), nil)}
+	paths := map[string]any{}
+	sorted := append([]string(nil), patterns...)
+	sort.Strings(sorted)
+	for _, p := range sorted {
+		if p == "/" {
+			continue
+		}
+		doc := apiDocs[p]
+		path := p
+		if doc.Path != "" {
+			path = doc.Path
+		}
+		methods := doc.Methods
+		if len(methods) == 0 {
+			methods = []string{"GET"}
+		}
+		item := map[string]any{}
+		for _, m := range methods {
+			item[strings.ToLower(m)] = buildOperation(path, m, doc, schemas)
+		}
+		paths[path] = item
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   title,
+			"version": APIVersion,
+		},
+		"servers":    []map[string]string{{"url": "/" + APIVersion}},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func buildOperation(path, method string, doc apiDoc, schemas map[string]any) map[string]any {
+	op := map[string]any{
+		"tags":        []string{strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]},
+		"operationId": strings.ToLower(method) + strings.NewReplacer("/", "_", "{", "", "}", "", ".", "_").Replace(path),
+	}
+	if doc.Summary != "" {
+		op["summary"] = doc.Summary
+	}
+	if doc.Peer {
+		op["description"] = "노드 간 엔드포인트 (mTLS 활성 시 고정된 노드 인증서 필요)"
+	}
+
+	params := []map[string]any{}
+	for _, seg := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(seg, "{"); ok {
+			params = append(params, map[string]any{
+				"name": strings.TrimSuffix(name, "}"), "in": "path", "required": true,
+				"schema": map[string]string{"type": "string"},
+			})
+		}
+	}
+	for _, q := range doc.Query {
+		params = append(params, map[string]any{
+			"name": q.Name, "in": "query", "description": q.Desc,
+			"schema": map[string]string{"type": q.Type},
+		})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if doc.Body != nil && (method == "POST" || method == "PUT") {
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(doc.Body), schemas)}},
+		}
+	}
+
+	status := doc.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	ok := map[string]any{"description": http.StatusText(status)}
+	if doc.Resp != nil && method != "DELETE" {
+		ok["content"] = map[string]any{"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(doc.Resp), schemas)}}
+	}
+	op["responses"] = map[string]any{
+		strconv.Itoa(status): ok,
+		"default": map[string]any{
+			"description": "오류",
+			"content":     map[string]any{"application/json": map[string]any{"schema": map[string]string{"$ref": "#/components/schemas/ErrorBody"}}},
+		},
+	}
+	return op
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// Go 타입의 JSON 스키마 (이름 있는 구조체는 schemas 에 등록 후 $ref, schemas 가 nil 이면 인라인)
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawType:
+		return map[string]any{}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" || schemas == nil {
+			return structSchema(t, schemas)
+		}
+		ref := map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+		if _, ok := schemas[t.Name()]; !ok {
+			schemas[t.Name()] = map[string]any{} // 자기 참조 구조체의 무한 재귀 방지
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return ref
+	}
+	return map[string]any{}
+}
+
+// 구조체 필드의 json 태그 기준 properties (내장 구조체 필드는 펼침)
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	props := map[string]any{}
+	var required []string
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if f.Anonymous && name == "" {
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = schemaFor(f.Type, schemas)
+			if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+				required = append(required, name)
+			}
+		}
+	}
+	walk(t)
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
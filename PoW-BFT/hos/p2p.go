@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"time"
+
+	"merkle"
 )
 
 // 노드 상태 구조체, /status API 호출 시 응답받는 JSON 구조
@@ -15,6 +19,9 @@ type nodeStatus struct {
 	IsBoot   bool     `json:"is_boot"`   // 부트노드 여부
 	Peers    []string `json:"peers"`     // 연결된 피어 목록
 	LastHash string   `json:"last_hash"` // 최신 블록의 해시
+	Region   string   `json:"region"`    // 노드 리전 라벨
+	Addrs    []string `json:"addrs"`     // 광고 접속 주소 (advertise.go)
+	Time     string   `json:"time"`      // 응답 시점의 노드 시계 (timesync.go)
 }
 
 // 다른 노드 상태 조회
@@ -22,10 +29,12 @@ type nodeStatus struct {
 // 해당 노드의 현재 상태(nodeStatus)를 가져옴
 func probeStatus(addr string) (nodeStatus, bool) {
 	var s nodeStatus
-	resp, err := http.Get("http://" + addr + "/status")
+	sent := time.Now()
+	resp, err := nodeClient.Get(nodeURL(addr, "/status"))
 	if err != nil {
 		return s, false
 	}
+	recv := time.Now()
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
 		return s, false
@@ -33,6 +42,7 @@ func probeStatus(addr string) (nodeStatus, bool) {
 	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
 		return s, false
 	}
+	observePeerClock(addr, s.Time, sent, recv)
 	return s, true
 }
 
@@ -55,20 +65,33 @@ func validateLowerBlock(newBlk, prevBlk LowerBlock) error {
 	if prevBlk.HosID != newBlk.HosID {
 		return fmt.Errorf("hos_id mismatch: chain=%s new=%s", prevBlk.HosID, newBlk.HosID)
 	}
-	// 4) MerkleRoot 재계산
-	leaf := make([]string, len(newBlk.Entries))
-	for i, r := range newBlk.Entries {
-		leaf[i] = hashClinicRecord(r)
+	// 4) MerkleRoot 재계산 (본문이 정리된 블록은 재검증 불가)
+	if newBlk.Pruned {
+		return fmt.Errorf("block body pruned")
 	}
-	expectedRoot := merkleRootHex(leaf)
+	leaf := entryLeafHashes(newBlk.Entries)
+	expectedRoot := merkle.Root(leaf)
 	if expectedRoot != newBlk.MerkleRoot {
 		return fmt.Errorf("merkle_root mismatch")
 	}
+	// 저장된 LeafHashes 로 증명을 제공하므로 본문과 일치해야 함
+	if !slices.Equal(leaf, newBlk.LeafHashes) {
+		return fmt.Errorf("leaf_hashes mismatch")
+	}
 	// 5) BlockHash 재계산
 	if newBlk.BlockHash != newBlk.computeHash() {
 		return fmt.Errorf("block_hash mismatch")
 	}
-	return nil
+	// 6) 레코드 우선순위 순서 (priority.go)
+	if err := checkEntryOrder(newBlk.Entries); err != nil {
+		return err
+	}
+	// 7) 블록 크기 한도 (blocklimit.go)
+	if err := checkBlockLimits(newBlk.Entries); err != nil {
+		return err
+	}
+	// 8) 데이터 상주 규칙 (메인 체인에 상주 레코드 불가, 서브 장부는 같은 리전만)
+	return checkBlockResidency(newBlk)
 }
 
 // -----------------------------------------------------------------------------
@@ -86,25 +109,16 @@ type blocksPage struct {
 
 // 입력받은 주소의 노드에게 장부 정보를 제공받는 함수
 func syncChain(peer string) {
-	url := "http://" + peer + "/blocks"
+	syncInProgress.Store(true) // 동기화 중에는 비핵심 요청 제한 (loadshed.go)
+	defer syncInProgress.Store(false)
 
-	// 원격에서 전체 블록 수신
-	resp, err := http.Get(url)
-	if err != nil {
-		log.Printf("[P2P] Failed to sync from %s: %v\n", peer, err)
-		return
-	}
-	var page blocksPage
-	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
-		_ = resp.Body.Close()
-		log.Printf("[P2P] Invalid /blocks from %s: %v\n", peer, err)
+	// 신규 노드는 서명된 체크포인트로 먼저 부트스트랩 (checkpoint.go)
+	syncFromCheckpoint(peer)
+
+	// 높이 차이가 크면 헤더 우선 병렬 동기화 (fastsync.go)
+	if fastSyncEnabled && fastSync(peer) {
 		return
 	}
-	resp.Body.Close()
-
-	remoteTotal := page.Total
-	appended := 0
-
 	// 로컬 상태
 	chainMu.Lock()
 	localH, ok := getLatestHeight()
@@ -115,71 +129,103 @@ func syncChain(peer string) {
 		log.Printf("[P2P] No local blocks. Full sync from %s\n", peer)
 	}
 
-	// 원격이 최신보다 같거나 더 짧으면 필요 없음
-	if localH >= 0 && remoteTotal <= localH+1 {
-		log.Printf("[P2P] Up-to-date (local=%d, remote=%d)\n", localH+1, remoteTotal)
-		return
-	}
+	// 로컬 높이+1 부터 페이지 단위로 이어 받음 (blocktransfer.go)
+	etag := lastSyncETag(peer, localH)
+	appended := 0
+	for {
+		page, tag, notModified, err := fetchBlocksPage(peer, localH+1, BlocksPageMax, etag)
+		if err != nil {
+			log.Printf("[P2P] Failed to sync from %s at #%d: %v (+%d blocks so far)\n", peer, localH+1, err, appended)
+			return
+		}
+		if notModified {
+			log.Printf("[P2P] Up-to-date (local=%d, %s unchanged)\n", localH+1, peer)
+			return
+		}
+		etag = ""
+		observePeerHeight(peer, page.Total-1)
 
-	// 전체 블록을 순서대로 처리
-	for _, nb := range page.Items {
-		chainMu.Lock()
+		// 원격이 최신보다 같거나 더 짧으면 필요 없음
+		if localH >= 0 && page.Total <= localH+1 {
+			if appended == 0 {
+				log.Printf("[P2P] Up-to-date (local=%d, remote=%d)\n", localH+1, page.Total)
+			}
+			setSyncMark(peer, tag, localH)
+			break
+		}
+		if len(page.Items) == 0 {
+			break
+		}
 
-		if nb.Index != 0 {
-			prev, err := getBlockByIndex(nb.Index - 1)
-			if err != nil {
-				chainMu.Unlock()
-				log.Printf("[P2P] Missing prev block #%d\n", nb.Index-1)
-				return
+		// 페이지 블록을 순서대로 처리
+		before := appended
+		for _, nb := range page.Items {
+			if nb.Index <= localH {
+				continue
+			}
+			chainMu.Lock()
+
+			if nb.Index != 0 {
+				prev, err := getBlockByIndex(nb.Index - 1)
+				if err != nil {
+					chainMu.Unlock()
+					log.Printf("[P2P] Missing prev block #%d\n", nb.Index-1)
+					return
+				}
+
+				// 블록 검증
+				if err := validateLowerBlock(nb, prev); err != nil {
+					chainMu.Unlock()
+					log.Printf("[P2P] Remote block invalid at #%d: %v\n", nb.Index, err)
+					return
+				}
+			} else {
+				log.Printf("[P2P] Fetching genesis from %s", peer)
 			}
 
-			// 블록 검증
-			if err := validateLowerBlock(nb, prev); err != nil {
+			// append
+			if err := commitBlock(nb); err != nil {
 				chainMu.Unlock()
-				log.Printf("[P2P] Remote block invalid at #%d: %v\n", nb.Index, err)
+				log.Printf("[P2P] commitBlock error: %v\n", err)
 				return
 			}
-		} else {
-			log.Printf("[P2P] Fetching genesis from %s", peer)
-		}
 
-		// append
-		if err := saveBlockToDB(nb); err != nil {
+			localH = nb.Index
+			appended++
 			chainMu.Unlock()
-			log.Printf("[P2P] saveBlockToDB error: %v\n", err)
-			return
 		}
-		if err := updateIndicesForBlock(nb); err != nil {
-			chainMu.Unlock()
-			log.Printf("[P2P] updateIndicesForBlock error: %v\n", err)
-			return
-		}
-		if err := setLatestHeight(nb.Index); err != nil {
-			chainMu.Unlock()
-			log.Printf("[P2P] setLatestHeight error: %v\n", err)
-			return
+		if appended == before {
+			break // 진행 없는 페이지 (원격 응답 이상) : 무한 반복 방지
 		}
-
-		localH = nb.Index
-		appended++
-		chainMu.Unlock()
 	}
 
-	log.Printf("[P2P] Chain synced from %s (+%d blocks, new height=%d)\n",
-		peer, appended, localH)
+	if appended > 0 {
+		log.Printf("[P2P] Chain synced from %s (+%d blocks, new height=%d)\n",
+			peer, appended, localH)
+	}
 }
 
 // 새로운 피어 등록
 func addPeer(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		Addr   string `json:"addr"`
-		PubKey string `json:"pub_key"` // 공개키 필드 추가
+		Addr    string `json:"addr"`
+		PubKey  string `json:"pub_key"`  // 공개키 필드 추가
+		CertPin string `json:"cert_pin"` // mTLS 인증서 지문
+		Boot    string `json:"boot"`     // 알림을 보낸 부트노드
+		Sig     string `json:"sig"`      // 부트노드 서명 (addPeerDigest)
 	}
 	// 부트노드가 보낸 JSON 객체 파싱해
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid peer format", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid peer format")
+		return
+	}
+	// 현재 부트노드가 서명한 알림만 반영 (registration.go)
+	if !verifyBootSignature(req.Boot, addPeerDigest(req.Addr, req.PubKey, req.CertPin), req.Sig) {
+		log.Printf("[P2P][DENY] unsigned or invalid addPeer for %s (boot=%q)", req.Addr, req.Boot)
+		writeError(w, http.StatusForbidden, "addPeer must be signed by the boot node")
 		return
 	}
+	pinPeerCert(req.Addr, req.CertPin)
 
 	if addPeerInternal(req.Addr, req.PubKey) { // 공개키 함께 전달
 		w.Write([]byte("Peer added"))
@@ -200,17 +246,18 @@ func addPeerInternal(addr string, pubKey string) bool {
 		peers = append(peers, addr)
 
 		pkMu.Lock()
-		peerPubKeys[addr] = pubKey
+		setPeerPubKeyLocked(addr, pubKey)
 		pkMu.Unlock()
 
 		log.Printf("[P2P][ADD] peer added: %s (PubKey: %s...)", addr, pubKey[:10])
 	} else {
 		pkMu.Lock()
-		peerPubKeys[addr] = pubKey
+		setPeerPubKeyLocked(addr, pubKey)
 		pkMu.Unlock()
 		return false
 	}
 	log.Printf("[P2P][ADD] peer added: %s | total=%d", addr, len(peers))
+	publishEvent(EventPeerJoined, map[string]any{"peer": addr, "total": len(peers)})
 
 	// 생존 상태 초기화
 	aliveMu.Lock()
@@ -258,8 +305,10 @@ func removePeer(addr string) {
 	aliveMu.Lock()
 	delete(peerAliveMap, addr)
 	aliveMu.Unlock()
+	forgetPeerClock(addr)
 
 	log.Printf("[WATCHER] Dead Pear removed: %s", addr)
+	publishEvent(EventPeerLeft, map[string]any{"peer": addr, "total": len(peers)})
 }
 
 // 특정 노드 주소와 상태를 입력받아 기록
@@ -282,12 +331,20 @@ func startNetworkWatcher() {
 		if currentBoot == "" {
 			continue
 		}
+		if isObserver() {
+			refreshObserverPeers()
+		}
 
 		for _, addr := range peersSnapshot() {
 			// 노드 별 상태 조사
-			_, ok := probeStatus(addr)
+			st, ok := probeStatus(addr)
 			if ok {
 				markAlive(addr, true)
+				setPeerRegion(addr, st.Region)
+				observePeerHeight(addr, st.Height)
+				// 피어가 여러 주소를 광고하면 후보별 도달성 점검
+				notePeerAddrs(addr, st.Addrs)
+				go probePeerAddrs(addr)
 				continue
 			}
 
@@ -305,72 +362,102 @@ func startNetworkWatcher() {
 	}
 }
 
-// 체인 fork 현상 완화 루틴 (생존 노드 중 가장 체인 height 긴 체인으로 동기화)
-//func startChainWatcher() {
-//	t := time.NewTicker(time.Duration(ChainWatcherTime))
-//	defer t.Stop()
-//
-//	for range t.C {
-//
-//		// 이미 채굴 중이거나 메모리풀이 비어있지 않으면 수행하지 않음
-//		// 블록 생성이 기대되지 않는 상황에서 혼자 체인이 짧은 경우를 판별하기 위함
-//		//if isMining.Load() || !getPendingCnt == 0() {
-//		//	continue
-//		//}
-//
-//		// 가장 긴 노드의 주소, 높이, 최신블록해시
-//		bestPeer := ""
-//		bestHeight := -1
-//		bestHash := ""
-//
-//		for _, p := range peersSnapshot() {
-//			st, ok := probeStatus(p)
-//			if !ok {
-//				continue
-//			}
-//			// 높이가 최대인 노드를 탐색하여 주소, 높이, 해시 저장
-//			if st.Height > bestHeight {
-//				bestHeight = st.Height
-//				bestHash = st.LastHash
-//				bestPeer = p
-//				continue
-//			}
-//			// height 같지만 hash가 다른 경우도 fork로 간주
-//			if st.Height == bestHeight && st.LastHash != bestHash {
-//				bestPeer = p
-//				bestHeight = st.Height
-//				bestHash = st.LastHash
-//			}
-//		}
-//		// 발견되지 않았다면 다음 주기까지 중단
-//		if bestPeer == "" {
-//			continue
-//		}
-//
-//		// 로컬 상태
-//		chainMu.Lock()
-//		localH, _ := getLatestHeight()
-//		localLastHash := ""
-//		if localH >= 0 {
-//			blk, _ := getBlockByIndex(localH)
-//			localLastHash = blk.BlockHash
-//		}
-//		chainMu.Unlock()
-//
-//		// 로컬 노드와 비교하여 체인 동기화 여부 결정
-//		needReset := false
-//		// 가장 긴 노드의 height가 로컬 노드보다 클 때
-//		if bestHeight > localH {
-//			needReset = true
-//		} else if bestHeight == localH && bestHash != localLastHash {
-//			// 가장 긴 노드의 height가 로컬 노드와 같지만, hash가 다를 때
-//			needReset = true
-//		}
-//		// 로컬 장부 리셋 후, bestPeer에게 체인을 동기화받음
-//		if needReset {
-//			log.Printf("[CHAIN-WATCHER] fork/outdated detected → reset + sync from %s", bestPeer)
-//			resetLocalDB()
-//			syncChain(bestPeer)
-//		}
-//	}
-//}
+// 체인 fork 현상 완화 루틴 (생존 노드 중 가장 긴 체인과 갈라졌으면 분기 구간만 교체, reorg.go)
+func startChainWatcher() {
+	t := time.NewTicker(time.Duration(ChainWatcherTime) * time.Second)
+	defer t.Stop()
+
+	for range t.C {
+		// 합의/동기화 중에는 높이가 계속 바뀌므로 수행하지 않음
+		if consensusInProgress.Load() || syncInProgress.Load() {
+			continue
+		}
+		if _, err := reconcileChain(); err != nil {
+			log.Printf("[CHAIN-WATCHER] %v", err)
+		}
+	}
+}
+
+// 체인 조정 1회 결과 (watcher, /admin/resync 공용)
+type ResyncResult struct {
+	Action      string `json:"action"` // none | sync | reorg
+	Peer        string `json:"peer,omitempty"`
+	PeerHeight  int    `json:"peer_height"`
+	LocalHeight int    `json:"local_height"` // 조정 전
+	Height      int    `json:"height"`       // 조정 후
+}
+
+// 피어 중 가장 긴 체인과 비교해 뒤처졌으면 동기화, 갈라졌으면 분기 구간 교체
+func reconcileChain() (ResyncResult, error) {
+	res := ResyncResult{Action: "none", PeerHeight: -1}
+
+	// 가장 긴 노드의 주소, 높이, 최신블록해시
+	bestPeer := ""
+	bestHeight := -1
+	bestHash := ""
+
+	for _, p := range peersSnapshot() {
+		st, ok := probeStatus(p)
+		if !ok {
+			continue
+		}
+		observePeerHeight(p, st.Height)
+		// 제네시스/프로토콜이 다른 체인의 노드는 fork 판정에서 제외 (chaininfo.go)
+		if !sameChain(p) {
+			continue
+		}
+		if preferRemote(st.Height, st.LastHash, bestHeight, bestHash) {
+			bestPeer = p
+			bestHeight = st.Height
+			bestHash = st.LastHash
+		}
+	}
+
+	// 로컬 상태
+	chainMu.Lock()
+	localH, _ := getLatestHeight()
+	localLastHash := ""
+	if blk, err := getBlockByIndex(localH); err == nil {
+		localLastHash = blk.BlockHash
+	}
+	chainMu.Unlock()
+	res.LocalHeight, res.Height = localH, localH
+
+	// 발견되지 않았거나 로컬 체인이 우선이면 그대로 유지
+	if bestPeer == "" {
+		return res, nil
+	}
+	res.Peer, res.PeerHeight = bestPeer, bestHeight
+	if !preferRemote(bestHeight, bestHash, localH, localLastHash) {
+		return res, nil
+	}
+	// 단순히 뒤처진 경우는 기존 동기화, 갈라진 경우만 분기 구간 교체
+	if bestHeight > localH {
+		if fork, err := findForkPoint(bestPeer, localH, bestHeight); err == nil && fork == localH {
+			res.Action = "sync"
+			syncChain(bestPeer)
+			res.Height, _ = getLatestHeight()
+			return res, nil
+		}
+	}
+	log.Printf("[CHAIN-WATCHER] fork detected (local=#%d %.12s, %s=#%d %.12s) => reorg", localH, localLastHash, bestPeer, bestHeight, bestHash)
+	res.Action = "reorg"
+	if err := reorgFromPeer(bestPeer); err != nil {
+		return res, fmt.Errorf("reorg from %s failed: %w", bestPeer, err)
+	}
+	res.Height, _ = getLatestHeight()
+	return res, nil
+}
+
+// /admin/resync : 주기를 기다리지 않고 체인 조정 1회 수행
+func resyncJob(ctx context.Context, report func(done, total int)) (any, error) {
+	if consensusInProgress.Load() || syncInProgress.Load() {
+		return nil, fmt.Errorf("consensus or sync in progress")
+	}
+	res, err := reconcileChain()
+	if err != nil {
+		return res, err
+	}
+	report(1, 1)
+	return res, nil
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Patient Records (환자별 레코드 조회 + 접근 제어)
+// ------------------------------------------------------------
+// - 환자 색인 : pid_<PatientID> => "bi:ei,..." (updateIndicesForBlock, 분기 교체 시 함께 되돌림)
+//   · /search 키워드 검색 대상이 아님 (환자 ID 로는 이 API 로만 조회)
+// - GET /patient/<patient_id>/records[?include_expired=true][&decrypt=true][&offset=<int>&limit=<int>]
+//   · 응답은 /search 와 같은 형식 (레코드 + 포함 증명 + 만료/철회 표시), 전체 수는 X-Total-Count
+//   · 민감 정보이므로 patientAuthorizer 통과 요청만 허용
+//   · decrypt=true : 봉인된 진료 정보 필드를 복호화해 decrypted 로 제공 (Gov 가 복호화 조회로 서명한 요청만, phi.go)
+// - 접근 제어 (PATIENT_AUTH)
+//   · gov (기본) : Gov 노드가 서명한 요청만 허용
+//       X-Gov-Signer    : 서명한 Gov 노드 주소 (Gov 부트노드 또는 그 피어 목록에 있어야 함)
+//       X-Gov-Timestamp : RFC3339 서명 시각 (PatientAuthMaxSkew 이내)
+//       X-Gov-Signature : sha256("patient_records|<hos_id>|<patient_id>|<timestamp>") 의 ECDSA 서명 (hex)
+//                         (복호화 조회는 "patient_records_decrypt|..." => 일반 조회 서명으로 복호화 불가)
+//       (공개키는 서명 노드의 GET /getPublicKey, 노드별 캐시)
+//   · off : 검사하지 않음 (개발/데브넷 전용, 복호화 조회는 허용하지 않음)
+// - 허용/거부 모두 [PATIENT] 로그로 남김 (요청자 : X-Requester)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	patientPrefix = "pid_"
+
+	PatientAuthGov = "gov"
+	PatientAuthOff = "off"
+
+	PatientAuthMaxSkew   = 5 * time.Minute  // 서명 시각 허용 오차
+	GovMemberRefreshTime = 30 * time.Second // Gov 노드 목록 캐시 유지 시간
+)
+
+var patientAuthMode = PatientAuthGov
+
+// 환자 레코드 조회 권한 검사 (nil 이면 허용, decrypt : 복호화 조회 여부)
+//   - 다른 정책(기관 토큰 등)이 필요하면 이 함수를 교체
+var patientAuthorizer = authorizeGovSigned
+
+// 환자 레코드 조회 권한 오류 (status : 401 | 403 | 502)
+type patientAuthError struct {
+	status int
+	msg    string
+}
+
+func (e *patientAuthError) Error() string { return e.msg }
+
+// Gov 서명 대상 다이제스트 (Gov requestPatientRecords 와 같은 형식)
+func patientRequestDigest(hosID, patientID, ts string, decrypt bool) []byte {
+	scope := "patient_records"
+	if decrypt {
+		scope = "patient_records_decrypt"
+	}
+	h := sha256.Sum256([]byte(strings.Join([]string{scope, hosID, patientID, ts}, "|")))
+	return h[:]
+}
+
+var govKeys = struct {
+	sync.Mutex
+	members   []string          // Gov 부트노드 + 피어
+	fetchedAt time.Time         // members 조회 시각
+	pub       map[string]string // 노드 주소 => 공개키 PEM
+}{pub: map[string]string{}}
+
+// 서명 노드가 현재 Gov 노드인지 확인 (Gov 부트노드의 /peers 기준, 캐시)
+func isGovMember(addr string) (bool, error) {
+	govKeys.Lock()
+	defer govKeys.Unlock()
+	gb := getGovBoot()
+	if addr == gb {
+		return true, nil
+	}
+	if time.Since(govKeys.fetchedAt) > GovMemberRefreshTime {
+		resp, err := nodeClient.Get(nodeURL(gb, "/peers"))
+		if err != nil {
+			return false, err
+		}
+		defer resp.Body.Close()
+		var peers []string
+		if err := json.NewDecoder(resp.Body).Decode(&peers); err != nil {
+			return false, fmt.Errorf("invalid gov peers response: %w", err)
+		}
+		govKeys.members, govKeys.fetchedAt = peers, time.Now()
+	}
+	return slices.Contains(govKeys.members, addr), nil
+}
+
+// Gov 노드 공개키 (노드별 캐시)
+func govPublicKey(addr string) (string, error) {
+	govKeys.Lock()
+	pub, ok := govKeys.pub[addr]
+	govKeys.Unlock()
+	if ok {
+		return pub, nil
+	}
+	resp, err := nodeClient.Get(nodeURL(addr, "/getPublicKey"))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("public key of %s: status=%d", addr, resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	govKeys.Lock()
+	govKeys.pub[addr] = string(b)
+	govKeys.Unlock()
+	return string(b), nil
+}
+
+// PATIENT_AUTH=gov : Gov 노드 서명 요청 검사
+func authorizeGovSigned(r *http.Request, patientID string, decrypt bool) error {
+	if patientAuthMode == PatientAuthOff && !decrypt {
+		return nil
+	}
+	signer := r.Header.Get("X-Gov-Signer")
+	ts := r.Header.Get("X-Gov-Timestamp")
+	sig := r.Header.Get("X-Gov-Signature")
+	if signer == "" || ts == "" || sig == "" {
+		return &patientAuthError{http.StatusUnauthorized, "gov signed request required"}
+	}
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil || time.Since(t).Abs() > PatientAuthMaxSkew {
+		return &patientAuthError{http.StatusUnauthorized, "request timestamp expired or invalid"}
+	}
+	member, err := isGovMember(signer)
+	if err != nil {
+		return &patientAuthError{http.StatusBadGateway, fmt.Sprintf("gov membership lookup failed: %v", err)}
+	}
+	if !member {
+		return &patientAuthError{http.StatusForbidden, "signer is not a gov node"}
+	}
+	pub, err := govPublicKey(signer)
+	if err != nil {
+		return &patientAuthError{http.StatusBadGateway, fmt.Sprintf("gov public key lookup failed: %v", err)}
+	}
+	if !verifyECDSA(pub, patientRequestDigest(selfID(), patientID, ts, decrypt), sig) {
+		return &patientAuthError{http.StatusForbidden, "invalid gov signature"}
+	}
+	return nil
+}
+
+// 환자 레코드 조회 (offset/limit 구간), 전체 수 반환
+func patientRecords(patientID string, includeExpired bool, offset, limit int) ([]SearchResponse, int, error) {
+	var (
+		results []SearchResponse
+		total   int
+	)
+	err := withReadSnapshot(func(rd dbReader) error {
+		ptrs := indexPointers(rd, patientPrefix+patientID)
+		if len(ptrs) == 0 {
+			return fmt.Errorf("no record for patient")
+		}
+		var err error
+		results, total, err = searchPointersFrom(rd, ptrs, func(rec ClinicRecord) bool { return rec.PatientID == patientID }, includeExpired, offset, limit)
+		return err
+	})
+	return results, total, err
+}
+
+// GET /patient/<patient_id>/records
+func handlePatientRecords(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	pid, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/patient/"), "/records")
+	if !ok || pid == "" {
+		http.NotFound(w, r)
+		return
+	}
+	requester := r.Header.Get("X-Requester")
+	decrypt := r.URL.Query().Get("decrypt") == "true"
+	if err := patientAuthorizer(r, pid, decrypt); err != nil {
+		status := http.StatusForbidden
+		if ae, ok := err.(*patientAuthError); ok {
+			status = ae.status
+		}
+		log.Printf("[PATIENT][DENY] patient=%s requester=%q signer=%q : %v", pid, requester, r.Header.Get("X-Gov-Signer"), err)
+		writeError(w, status, err.Error())
+		return
+	}
+
+	offset, limit, err := searchPage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	results, total, err := patientRecords(pid, r.URL.Query().Get("include_expired") == "true", offset, limit)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if decrypt {
+		for i := range results {
+			if results[i].Record.Sealed == nil {
+				continue
+			}
+			p, err := openRecord(results[i].Record)
+			if err != nil {
+				log.Printf("[PATIENT][ERROR] decrypt patient=%s block=%d entry=%d : %v", pid, results[i].Inclusion.BlockIndex, results[i].Inclusion.EntryIndex, err)
+				writeError(w, http.StatusInternalServerError, "failed to decrypt record")
+				return
+			}
+			results[i].Decrypted = p
+		}
+	}
+	log.Printf("[PATIENT] patient=%s requester=%q signer=%q decrypt=%v returned=%d total=%d", pid, requester, r.Header.Get("X-Gov-Signer"), decrypt, len(results), total)
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	writeJSON(w, http.StatusOK, results)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 메모리풀 한도 (Pending Pool Limits / Backpressure)
+// ------------------------------------------------------------
+// - 메모리풀(ch.pending) 레코드 수 MaxPendingEntries, 직렬화 크기 합 MaxPendingBytes 제한 (0 이면 제한 없음)
+// - 한도를 넘는 접수(/upload, gRPC SubmitRecords, /pending/relay)는 PendingEvictPolicy 에 따라 처리
+//   · reject      : 접수 거절 => 429 pending_full + Retry-After (기본값)
+//   · drop-oldest : 가장 오래된 레코드를 밀어내고 접수
+//     (밀려난 레코드는 LevelDB 선기록과 중복 표시도 지워 나중에 다시 접수 가능)
+//   · 요청 하나가 한도보다 크면 정책과 무관하게 거절
+// - 분기 교체/미확정 제안에서 되돌아온 레코드는 이미 접수된 것이므로 한도와 무관하게 복구
+// - 사용량 : chain_pending_entries / chain_pending_bytes 게이지, 거절은 chain_pending_rejected_total{reason="pool_full"}
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	PendingEvictReject = "reject"
+	PendingEvictOldest = "drop-oldest"
+
+	PendingFullRetryAfter = 5 // 초, 거절 응답의 Retry-After
+)
+
+var (
+	MaxPendingEntries  = 10000              // 메모리풀 최대 레코드 수 (MAX_PENDING_ENTRIES)
+	MaxPendingBytes    = 64 << 20           // 메모리풀 최대 크기, 바이트 (MAX_PENDING_BYTES)
+	PendingEvictPolicy = PendingEvictReject // 한도 초과 시 처리 (PENDING_EVICT_POLICY)
+)
+
+// 한도 초과로 접수 거절
+type PendingFullError struct {
+	Entries    int `json:"entries"`
+	Bytes      int `json:"bytes"`
+	Incoming   int `json:"incoming"`
+	MaxEntries int `json:"max_entries"`
+	MaxBytes   int `json:"max_bytes"`
+}
+
+func (e *PendingFullError) Error() string {
+	return fmt.Sprintf("pending pool full (%d/%d entries, %d/%d bytes)", e.Entries, e.MaxEntries, e.Bytes, e.MaxBytes)
+}
+
+// 정책 이름 확인 (main 에서 설정 시)
+func validPendingEvictPolicy(p string) bool {
+	return p == PendingEvictReject || p == PendingEvictOldest
+}
+
+// 레코드 직렬화 크기
+func pendingSize(entries []ClinicRecord) int {
+	n := 0
+	for _, rec := range entries {
+		data, _ := json.Marshal(rec)
+		n += len(data)
+	}
+	return n
+}
+
+func overPendingLimit(entries, bytes int) bool {
+	return (MaxPendingEntries > 0 && entries > MaxPendingEntries) || (MaxPendingBytes > 0 && bytes > MaxPendingBytes)
+}
+
+// incoming(크기 size) 접수를 위해 밀어낼 앞쪽 레코드 수 (pendingMu 보유 상태에서 호출)
+func pendingEvictCount(incoming, size int) (int, error) {
+	if !overPendingLimit(len(ch.pending)+incoming, ch.pendingBytes+size) {
+		return 0, nil
+	}
+	full := &PendingFullError{
+		Entries: len(ch.pending), Bytes: ch.pendingBytes, Incoming: incoming,
+		MaxEntries: MaxPendingEntries, MaxBytes: MaxPendingBytes,
+	}
+	if PendingEvictPolicy != PendingEvictOldest || overPendingLimit(incoming, size) {
+		incCounter("chain_pending_rejected_total", `reason="pool_full"`)
+		return 0, full
+	}
+	n, bytes := 0, ch.pendingBytes
+	for n < len(ch.pending) && overPendingLimit(len(ch.pending)-n+incoming, bytes+size) {
+		bytes -= pendingSize(ch.pending[n : n+1])
+		n++
+	}
+	return n, nil
+}
+
+// 메모리풀 앞쪽 n 건을 밀어내고 선기록/중복 표시 삭제 (pendingMu 보유 상태에서 호출)
+func evictPending(n int) {
+	if n == 0 {
+		return
+	}
+	evicted := ch.pending[:n]
+	ch.pending = append([]ClinicRecord(nil), ch.pending[n:]...)
+	ch.pendingBytes -= pendingSize(evicted)
+
+	hashes := recordHashes(evicted)
+	if _, err := clearPendingFromDB(hashes); err != nil {
+		log.Printf("[CHAIN][PENDING][ERROR] clear evicted entries failed: %v", err)
+	}
+	batch := new(leveldb.Batch)
+	for _, h := range hashes {
+		batch.Delete(seenKey(h))
+	}
+	_ = countDBError(db.Write(batch, nil))
+	incCounter("chain_pending_evicted_total", "")
+	log.Printf("[CHAIN][PENDING] Evicted %d oldest entries (pool limit, policy=%s)", n, PendingEvictPolicy)
+}
+
+// 메모리풀 크기 (바이트)
+func getPendingBytes() int {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	return ch.pendingBytes
+}
+
+// 429 pending_full 응답
+func writePendingFull(w http.ResponseWriter, full *PendingFullError) {
+	w.Header().Set("Retry-After", strconv.Itoa(PendingFullRetryAfter))
+	writeErrorDetail(w, http.StatusTooManyRequests, "pending_full", full.Error(), full)
+}
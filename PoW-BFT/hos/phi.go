@@ -0,0 +1,208 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PHI Field Encryption (진료 정보 필드 봉투 암호화)
+// ------------------------------------------------------------
+// - 기관(Hos 체인) 마스터 키(KEK)를 가진 노드는 접수 시 Info / ClinicHis 를 암호화
+//   · PHI_KEY       : 현재 KEK (base64, 32바이트) - 비어 있으면 암호화하지 않음
+//   · PHI_PREV_KEYS : 이전 KEK 목록 (쉼표 구분, 교체 전 레코드 복호화용)
+//   · 같은 기관의 모든 Hos 노드가 같은 키를 사용, Gov 는 키를 갖지 않음
+// - 봉투 구조 (ClinicRecord.sealed)
+//   · 레코드 키(DEK)로 {info, clinic_his} 를 AES-256-GCM 암호화 (AAD = clinic_id|patient_id|timestamp)
+//   · DEK 는 KEK 로 AES-256-GCM 암호화해 wrapped_key 로 저장 (AAD = kid)
+//   · DEK / nonce 는 KEK 와 평문 레코드 해시로 유도 (HMAC-SHA256)
+//     => 같은 레코드는 같은 봉투가 되어 재전송/중복 차단(dedup.go)이 그대로 동작
+// - Merkle leaf 는 지금처럼 레코드 해시 (봉인된 레코드 기준) => 장부/증명/앵커에는 평문이 없음
+//   · Info 기반 색인(info_, ft_)은 봉인된 레코드에 대해 만들지 않음 (clinic_id / presc_code / patient_id 색인만)
+// - 복호화 조회 : Gov 가 복호화 권한을 서명한 /patient/<id>/records?decrypt=true 만 (patient.go)
+//   · 응답의 record 는 봉인 그대로(증명 검증용), 평문은 decrypted 로 별도 제공
+////////////////////////////////////////////////////////////////////////////////
+
+const PHIAlg = "AES-256-GCM"
+
+// 봉인된 진료 정보 필드
+type SealedPHI struct {
+	KeyID      string `json:"kid"`         // KEK 식별자 (sha256(KEK) 앞 8바이트 hex)
+	Alg        string `json:"alg"`         // AES-256-GCM
+	WrappedKey string `json:"wrapped_key"` // base64(nonce || KEK 로 암호화한 DEK)
+	Nonce      string `json:"nonce"`       // base64, 본문 암호화 nonce
+	Ciphertext string `json:"ciphertext"`  // base64, {info, clinic_his} JSON 암호문
+}
+
+// 봉인 대상 평문 필드
+type PlainPHI struct {
+	Info      map[string]interface{} `json:"info,omitempty"`
+	ClinicHis map[string]interface{} `json:"clinic_his,omitempty"`
+}
+
+var phiKeys = struct {
+	current string            // 봉인에 쓰는 kid ("" 이면 암호화 비활성)
+	byID    map[string][]byte // kid => KEK
+}{byID: map[string][]byte{}}
+
+func phiKeyID(kek []byte) string {
+	h := sha256.Sum256(kek)
+	return hex.EncodeToString(h[:8])
+}
+
+// PHI_KEY / PHI_PREV_KEYS 로드 (main 에서 호출)
+func initPHIKeys(current, prev string) error {
+	add := func(s string) (string, error) {
+		kek, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+		if err != nil || len(kek) != 32 {
+			return "", fmt.Errorf("phi key must be base64 of 32 bytes")
+		}
+		kid := phiKeyID(kek)
+		phiKeys.byID[kid] = kek
+		return kid, nil
+	}
+	for _, s := range strings.Split(prev, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		if _, err := add(s); err != nil {
+			return err
+		}
+	}
+	if current == "" {
+		return nil
+	}
+	kid, err := add(current)
+	if err != nil {
+		return err
+	}
+	phiKeys.current = kid
+	log.Printf("[PHI] Field encryption enabled (kid=%s, %d keys)", kid, len(phiKeys.byID))
+	return nil
+}
+
+func phiEnabled() bool { return phiKeys.current != "" }
+
+func hmacSum(key []byte, label string, data []byte) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(label))
+	m.Write(data)
+	return m.Sum(nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// 본문 암호문을 레코드 헤더 필드에 묶음 (다른 레코드로 옮기면 복호화 실패)
+func phiAAD(rec ClinicRecord) []byte {
+	return []byte(strings.Join([]string{rec.ClinicID, rec.PatientID, rec.Timestamp}, "|"))
+}
+
+// 레코드의 Info / ClinicHis 봉인 (암호화 비활성, 봉인 대상 없음, 이미 봉인된 경우 그대로)
+func sealRecord(rec ClinicRecord) (ClinicRecord, error) {
+	if !phiEnabled() || rec.Sealed != nil || (rec.Info == nil && rec.ClinicHis == nil) {
+		return rec, nil
+	}
+	kid := phiKeys.current
+	kek := phiKeys.byID[kid]
+	plain, err := json.Marshal(PlainPHI{Info: rec.Info, ClinicHis: rec.ClinicHis})
+	if err != nil {
+		return rec, err
+	}
+
+	// 평문 레코드 해시로 DEK / nonce 유도
+	digest := sha256.Sum256(jsonCanonical(rec))
+	dek := hmacSum(kek, "phi-dek", digest[:])
+	body, err := newGCM(dek)
+	if err != nil {
+		return rec, err
+	}
+	wrap, err := newGCM(kek)
+	if err != nil {
+		return rec, err
+	}
+	nonce := hmacSum(kek, "phi-nonce", digest[:])[:body.NonceSize()]
+	wrapNonce := hmacSum(kek, "phi-wrap", digest[:])[:wrap.NonceSize()]
+
+	out := rec
+	out.Info, out.ClinicHis = nil, nil
+	out.Sealed = &SealedPHI{
+		KeyID:      kid,
+		Alg:        PHIAlg,
+		WrappedKey: base64.StdEncoding.EncodeToString(wrap.Seal(wrapNonce, wrapNonce, dek, []byte(kid))),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(body.Seal(nil, nonce, plain, phiAAD(out))),
+	}
+	return out, nil
+}
+
+// 접수 레코드 일괄 봉인
+func sealRecords(recs []ClinicRecord) ([]ClinicRecord, error) {
+	if !phiEnabled() {
+		return recs, nil
+	}
+	out := make([]ClinicRecord, len(recs))
+	for i, r := range recs {
+		s, err := sealRecord(r)
+		if err != nil {
+			return nil, fmt.Errorf("seal entry %d: %w", i, err)
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+// 봉인된 레코드 복호화
+func openRecord(rec ClinicRecord) (*PlainPHI, error) {
+	s := rec.Sealed
+	if s == nil {
+		return nil, fmt.Errorf("record is not sealed")
+	}
+	kek, ok := phiKeys.byID[s.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("phi key %s not available", s.KeyID)
+	}
+	wrap, err := newGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err1 := base64.StdEncoding.DecodeString(s.WrappedKey)
+	nonce, err2 := base64.StdEncoding.DecodeString(s.Nonce)
+	ct, err3 := base64.StdEncoding.DecodeString(s.Ciphertext)
+	if err1 != nil || err2 != nil || err3 != nil || len(wrapped) < wrap.NonceSize() {
+		return nil, fmt.Errorf("malformed sealed fields")
+	}
+	dek, err := wrap.Open(nil, wrapped[:wrap.NonceSize()], wrapped[wrap.NonceSize():], []byte(s.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("unwrap record key: %w", err)
+	}
+	body, err := newGCM(dek)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != body.NonceSize() {
+		return nil, fmt.Errorf("malformed sealed fields")
+	}
+	plain, err := body.Open(nil, nonce, ct, phiAAD(rec))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt record: %w", err)
+	}
+	var p PlainPHI
+	if err := json.Unmarshal(plain, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 레코드 우선순위 (Mempool Priority / Per-patient Quota)
+// ------------------------------------------------------------
+// - 레코드의 priority(0~MaxRecordPriority, 생략 시 0)가 높을수록 블록에 먼저 포함
+//   · 같은 우선순위는 접수 순서 (FIFO)
+//   · priority 는 레코드 본문이므로 leaf 해시에 포함 (생략 시 기존 레코드와 해시 동일)
+// - BlockPatientQuota > 0 이면 블록당 환자(patient_id)별 레코드 수 제한
+//   · 한도를 넘는 레코드는 메모리풀에 남아 다음 블록에 포함 (한 환자의 대량 접수가 블록을 독점하지 않도록)
+//   · 제안자 측 선택 규칙이므로 블록 검증 대상은 아님
+// - 블록 검증 (validateLowerBlock / 제안 검증 checkProposalBody)
+//   · 모든 레코드 priority 가 0~MaxRecordPriority 범위
+//   · 레코드가 priority 내림차순 (같은 값끼리의 순서는 검증하지 않음)
+//   · 기능 도입 이전 블록은 모두 0 이므로 그대로 유효
+////////////////////////////////////////////////////////////////////////////////
+
+const MaxRecordPriority = 9
+
+var BlockPatientQuota = 0 // 블록당 환자별 최대 레코드 수, 0 이면 제한 없음 (BLOCK_PATIENT_QUOTA)
+
+// 접수 레코드의 priority 범위 확인
+func checkRecordPriority(entries []ClinicRecord) error {
+	for _, rec := range entries {
+		if rec.Priority < 0 || rec.Priority > MaxRecordPriority {
+			return fmt.Errorf("record %s: priority must be 0..%d", rec.ClinicID, MaxRecordPriority)
+		}
+	}
+	return nil
+}
+
+// priority 내림차순 정렬 (같은 값은 기존 순서 유지)
+func orderByPriority(entries []ClinicRecord) []ClinicRecord {
+	out := slices.Clone(entries)
+	slices.SortStableFunc(out, func(a, b ClinicRecord) int { return b.Priority - a.Priority })
+	return out
+}
+
+// 메모리풀 레코드 중 다음 블록에 포함할 레코드 선택 (나머지는 deferred 로 메모리풀에 남김)
+func selectForBlock(entries []ClinicRecord) (selected, deferred []ClinicRecord) {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return entries[b].Priority - entries[a].Priority })
+
+	selected, deferred = []ClinicRecord{}, []ClinicRecord{}
+	skip := make([]bool, len(entries))
+	perPatient := map[string]int{}
+	bytes := 0
+	for _, i := range order {
+		rec := entries[i]
+		if BlockPatientQuota > 0 && rec.PatientID != "" && perPatient[rec.PatientID] >= BlockPatientQuota {
+			skip[i] = true
+			continue
+		}
+		// 블록 크기 한도 (blocklimit.go) : 들어가지 않는 레코드는 다음 블록으로
+		size := pendingSize([]ClinicRecord{rec})
+		if len(selected) >= MaxBlockEntries || bytes+size > MaxBlockBytes {
+			skip[i] = true
+			continue
+		}
+		if rec.PatientID != "" {
+			perPatient[rec.PatientID]++
+		}
+		bytes += size
+		selected = append(selected, rec)
+	}
+	// 남은 레코드는 원래 접수 순서로 메모리풀에 유지
+	for i, rec := range entries {
+		if skip[i] {
+			deferred = append(deferred, rec)
+		}
+	}
+	if len(deferred) > 0 {
+		log.Printf("[CHAIN][PENDING] Deferred %d entries to a later block (patient quota=%d, block limits=%d entries/%d bytes)", len(deferred), BlockPatientQuota, MaxBlockEntries, MaxBlockBytes)
+	}
+	return selected, deferred
+}
+
+// 블록 레코드 순서 규칙 검증
+func checkEntryOrder(entries []ClinicRecord) error {
+	if err := checkRecordPriority(entries); err != nil {
+		return err
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Priority > entries[i-1].Priority {
+			return fmt.Errorf("entries not ordered by priority at #%d", i)
+		}
+	}
+	return nil
+}
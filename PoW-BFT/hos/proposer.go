@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Proposer Rotation (view 별 제안자 순환)
+// ------------------------------------------------------------
+// - 부트노드가 모든 블록을 제안하던 방식 => view(블록 높이) 마다 제안자 순환
+//   · 검증자 집합 = view 높이의 장부 검증자 집합 주소 정렬 목록 (validators.go validatorsAt)
+//   · leaderFor(view, round) = 집합[(view + round) % n] => view 번호만으로 누구나 계산 가능
+//   · /bft/start 는 해당 view/round 의 제안자가 보낸 것만 수용 (handleBftStart)
+// - 메모리풀 중계 : 어느 노드가 접수한 레코드든 다음 제안자가 블록에 담을 수 있도록
+//   접수 노드가 다른 노드들에 POST /pending/relay 로 전달 (수신 노드는 재중계하지 않음)
+//   · 확정된 레코드는 블록 반영 시 모든 노드의 메모리풀에서 제거 (removePendingFinalized)
+// - 제안자가 응답하지 않으면 : 합의 조건을 만족한 비제안 노드가 view 타이머를 시작
+//   => 라운드 제한시간 후 view-change 로 다음 제안자에게 넘어감 (startViewChangeWatcher)
+// - Gov 앵커 제출/부트노드 선출은 기존대로 부트노드 담당
+////////////////////////////////////////////////////////////////////////////////
+
+// view/round 의 제안자 : 검증자 집합에서 view + round 위치 (round 마다 다음 노드로 이동)
+func leaderFor(view, round int) string {
+	nodes := validatorsAt(view)
+	return nodes[(view+round)%len(nodes)]
+}
+
+// 다음 블록(view = 높이+1)의 제안자
+func nextProposer() string {
+	height, _ := getLatestHeight()
+	return leaderFor(height+1, 0)
+}
+
+// 접수한 레코드를 다른 노드 메모리풀로 중계
+func relayPending(entries []ClinicRecord) {
+	if len(entries) == 0 {
+		return
+	}
+	body, _ := json.Marshal(entries)
+	for _, p := range peersSnapshot() {
+		go func(dst string) {
+			recordTraffic(dst, int64(len(body)))
+			if err := postPeer(dst, "/pending/relay", body); err != nil {
+				log.Printf("[PENDING][RELAY] failed to relay %d entries to %s: %v", len(entries), dst, err)
+			}
+		}(p)
+	}
+}
+
+// POST /pending/relay : 다른 노드가 접수한 레코드 수신 (노드 간)
+func handlePendingRelay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var entries []ClinicRecord
+	if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid Clinic record")
+		return
+	}
+	// 상주 레코드는 리전 밖으로 중계되면 안 됨
+	if _, resident, err := splitByResidency(entries); err != nil || len(resident) > 0 {
+		writeError(w, http.StatusForbidden, "residency violation")
+		return
+	}
+	// 증거 레코드는 수신 노드에서도 검증 (evidence.go)
+	if err := checkEvidenceRecords(entries); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := checkRecordPriority(entries); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := checkRecordSize(entries); err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	if err := appendPending(entries); err != nil {
+		if full := (*PendingFullError)(nil); errors.As(err, &full) {
+			writePendingFull(w, full)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to persist pending entries")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(entries)})
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Pruning (오래된 블록 본문 삭제로 디스크 사용량 제한)
+// ------------------------------------------------------------
+// - ARCHIVE_NODE=false 일 때만 동작 (기본 true : 전체 장부 보관)
+// - 최신 PRUNE_RETAIN_BLOCKS 개(기본 10000)보다 오래된 블록의 Entries 를 삭제
+//   · 헤더 필드, MerkleRoot, LeafHashes, Signatures 는 유지 => 블록 해시/머클 증명은 계속 제공
+//   · 정리된 블록은 pruned=true 로 표시, "prune_height" 에 정리된 최고 블록 번호 기록
+//   · 검색 색인은 그대로 두되, 본문이 없는 레코드는 /search 결과에서 제외
+// - 주기 작업(PruneWatcherTime)으로 실행, POST /admin/prune 으로 즉시 실행 (비동기 작업)
+//   · 체크포인트 부트스트랩 후 과거 본문 백필이 끝나기 전에는 실행하지 않음
+// - GET /proof?block=<int>&entry=<int> : 레코드 본문 없이 포함 증명만 조회 (정리된 블록 포함)
+// - 정리된 블록은 본문 재검증이 불가하므로 동기화 피어에서 받을 때는 거부됨 (validateLowerBlock)
+//   · 감사(/admin/audit)는 LeafHashes 로 머클 루트를 검증 (validateStoredBlock)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	DefaultPruneRetain = 10000 // 블록
+	PruneBatchBlocks   = 200   // 배치 1건당 정리 블록 수
+	PruneWatcherTime   = 600   // 초
+	pruneHeightKey     = "prune_height"
+)
+
+var (
+	archiveMode       = true               // ARCHIVE_NODE
+	PruneRetainBlocks = DefaultPruneRetain // PRUNE_RETAIN_BLOCKS
+)
+
+type PruneResult struct {
+	Pruned      int    `json:"pruned"`       // 이번 실행에서 정리한 블록 수
+	PruneHeight int    `json:"prune_height"` // 정리된 최고 블록 번호 (-1 이면 없음)
+	Note        string `json:"note,omitempty"`
+}
+
+// 정리된 최고 블록 번호 (없으면 -1)
+func getPruneHeight() int {
+	if v, ok := getMeta(pruneHeightKey); ok {
+		if h, err := strconv.Atoi(v); err == nil {
+			return h
+		}
+	}
+	return -1
+}
+
+// 블록 정리 작업 (prune_height+1 .. 최신-PRUNE_RETAIN_BLOCKS, 제네시스 제외)
+func pruneJob(ctx context.Context, report func(done, total int)) (any, error) {
+	res := PruneResult{PruneHeight: getPruneHeight()}
+	if archiveMode {
+		res.Note = "archive node (ARCHIVE_NODE=true)"
+		return res, nil
+	}
+	if _, backfilling := getMeta(historyLowKey); backfilling {
+		res.Note = "block history backfill in progress"
+		return res, nil
+	}
+	h, ok := getLatestHeight()
+	if !ok {
+		return res, nil
+	}
+	from, to := max(res.PruneHeight+1, 1), h-PruneRetainBlocks
+	if to < from {
+		return res, nil
+	}
+	started := time.Now()
+	for lo := from; lo <= to; lo += PruneBatchBlocks {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+		hi := min(lo+PruneBatchBlocks-1, to)
+		batch := new(leveldb.Batch)
+		err := scanBlocksFrom(db, lo, hi, func(raw []byte) error {
+			var b LowerBlock
+			if err := json.Unmarshal(raw, &b); err != nil {
+				return err
+			}
+			if b.Pruned {
+				return nil
+			}
+			b.Entries = nil
+			b.Pruned = true
+			data, err := json.Marshal(b)
+			if err != nil {
+				return err
+			}
+			batch.Put(blockKey(b.Index), data)
+			batch.Put([]byte(fmt.Sprintf("hash_%s", b.BlockHash)), data)
+			res.Pruned++
+			return nil
+		})
+		if err != nil {
+			return res, fmt.Errorf("prune blocks #%d-#%d: %w", lo, hi, err)
+		}
+		batch.Put([]byte(pruneHeightKey), []byte(strconv.Itoa(hi)))
+		if err := countDBError(db.Write(batch, nil)); err != nil {
+			return res, fmt.Errorf("write pruned blocks #%d-#%d: %w", lo, hi, err)
+		}
+		res.PruneHeight = hi
+		report(hi-from+1, to-from+1)
+	}
+	// 삭제된 본문 공간 회수
+	if err := db.CompactRange(util.Range{}); err != nil {
+		log.Printf("[PRUNE][ERROR] compaction: %v", err)
+	}
+	log.Printf("[PRUNE] pruned %d blocks up to #%d (%s)", res.Pruned, res.PruneHeight, time.Since(started).Round(time.Millisecond))
+	return res, nil
+}
+
+func startPruneWatcher() {
+	ticker := time.NewTicker(PruneWatcherTime * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := startJob("prune", pruneJob); err != nil {
+			log.Printf("[PRUNE] skipped: %v", err)
+		}
+	}
+}
+
+// 로컬에 저장된 블록 검증 (정리된 블록은 LeafHashes 로 머클 루트 확인)
+func validateStoredBlock(b, prev LowerBlock) error {
+	if !b.Pruned {
+		return validateLowerBlock(b, prev)
+	}
+	switch {
+	case prev.Index+1 != b.Index:
+		return fmt.Errorf("index not consecutive: prev=%d new=%d", prev.Index, b.Index)
+	case prev.BlockHash != b.PrevHash:
+		return fmt.Errorf("prev_hash mismatch: want=%s got=%s", prev.BlockHash, b.PrevHash)
+	case prev.HosID != b.HosID:
+		return fmt.Errorf("hos_id mismatch: chain=%s new=%s", prev.HosID, b.HosID)
+	case merkle.Root(b.LeafHashes) != b.MerkleRoot:
+		return fmt.Errorf("merkle_root mismatch (pruned)")
+	case b.computeHash() != b.BlockHash:
+		return fmt.Errorf("block_hash mismatch")
+	}
+	return nil
+}
+
+// 레코드 본문 없는 포함 증명
+type ProofResponse struct {
+	BlockRoot    string            `json:"block_root"`
+	LatestRoot   string            `json:"latest_root"`
+	Leaf         string            `json:"leaf"`
+	Proof        [][2]string       `json:"proof"`
+	ProofVersion int               `json:"proof_version"` // 증명 형식 버전 (merkle.ProofVersion)
+	Inclusion    Inclusion         `json:"inclusion"`
+	Pruned       bool              `json:"pruned"`            // 블록 본문이 정리됨 (레코드는 /search 로 조회 불가)
+	Revoked      *RevocationStatus `json:"revoked,omitempty"` // 철회된 레코드면 툼스톤 정보 (revoke.go)
+}
+
+// GET /proof?block=<int>&entry=<int>
+func handleProof(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if err := checkProofVersion(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	bi, err1 := strconv.Atoi(r.URL.Query().Get("block"))
+	ei, err2 := strconv.Atoi(r.URL.Query().Get("entry"))
+	if err1 != nil || err2 != nil || bi < 0 || ei < 0 {
+		writeError(w, http.StatusBadRequest, "block and entry must be non-negative integers")
+		return
+	}
+	res, err := proofFor(bi, ei)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// 블록 bi 의 ei 번째 엔트리 포함 증명 (/proof, gRPC GetProof 공통)
+func proofFor(bi, ei int) (ProofResponse, error) {
+	var res ProofResponse
+	err := withReadSnapshot(func(rd dbReader) error {
+		blk, err := getBlockByIndexForPointer(rd, bi)
+		if err != nil {
+			return err
+		}
+		if ei >= len(blk.LeafHashes) {
+			return fmt.Errorf("entry %d not in block_%d", ei, bi)
+		}
+		res = proofFrom(rd, blk, ei)
+		return nil
+	})
+	return res, err
+}
+
+// 읽은 블록 기준 포함 증명 (ei 는 LeafHashes 범위 내)
+func proofFrom(rd dbReader, blk *LowerBlock, ei int) ProofResponse {
+	return ProofResponse{
+		BlockRoot:    blk.MerkleRoot,
+		LatestRoot:   getLatestRootFrom(rd),
+		Leaf:         blk.LeafHashes[ei],
+		Proof:        merkle.Proof(blk.LeafHashes, ei),
+		ProofVersion: merkle.ProofVersion,
+		Inclusion:    buildInclusion(rd, blk, ei),
+		Pruned:       blk.Pruned,
+		Revoked:      revocationFrom(rd, blk.LeafHashes[ei]),
+	}
+}
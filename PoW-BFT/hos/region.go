@@ -0,0 +1,139 @@
+package main
+
+import (
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Region (멀티 리전 배포 지원)
+// ------------------------------------------------------------
+// - 각 노드는 NODE_REGION 환경변수로 리전 라벨을 가짐 (/status 로 노출)
+// - 피어의 리전은 /status 조회(네트워크 감시, 동기화 대상 선정) 시 학습
+// - 동기화(syncChain)는 같은 리전 피어를 우선 사용, 브로드캐스트는 같은 리전부터 전송
+// - 리전 내/간 트래픽(메시지 수, 바이트)을 집계하여 GET /traffic 으로 노출
+////////////////////////////////////////////////////////////////////////////////
+
+var (
+	region        string                    // 현재 노드의 리전 라벨
+	peerRegions   = make(map[string]string) // 피어 주소 => 리전
+	peerRegionsMu sync.RWMutex
+)
+
+// 리전 내/간 트래픽 카운터
+type trafficCounter struct {
+	Msgs  atomic.Int64
+	Bytes atomic.Int64
+}
+
+var (
+	sameRegionTraffic  trafficCounter
+	crossRegionTraffic trafficCounter
+)
+
+func setPeerRegion(addr, r string) {
+	if r == "" {
+		return
+	}
+	peerRegionsMu.Lock()
+	peerRegions[addr] = r
+	peerRegionsMu.Unlock()
+}
+
+// 피어의 리전 조회 (모르면 빈 문자열)
+func peerRegion(addr string) string {
+	if addr == self {
+		return region
+	}
+	peerRegionsMu.RLock()
+	defer peerRegionsMu.RUnlock()
+	return peerRegions[addr]
+}
+
+// 리전을 모르는 피어는 원거리로 간주
+func isSameRegion(addr string) bool {
+	return peerRegion(addr) == region
+}
+
+// 같은 리전 노드를 앞쪽으로 정렬 (리전 내부 순서는 유지)
+func orderByLocality(nodes []string) []string {
+	out := make([]string, len(nodes))
+	copy(out, nodes)
+	sort.SliceStable(out, func(i, j int) bool {
+		return isSameRegion(out[i]) && !isSameRegion(out[j])
+	})
+	return out
+}
+
+// 트래픽 기록 (메시지 1건 + 바이트)
+func recordTraffic(addr string, n int64) {
+	c := &crossRegionTraffic
+	if isSameRegion(addr) {
+		c = &sameRegionTraffic
+	}
+	c.Msgs.Add(1)
+	c.Bytes.Add(n)
+}
+
+// 수신 바이트 집계용 Reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// 동기화 대상 선정 : 같은 리전 피어 중 fallback 노드 이상의 높이를 가진 노드를 우선 선택
+func pickSyncPeer(fallback string) string {
+	target := -1
+	if st, ok := probeStatus(fallback); ok {
+		setPeerRegion(fallback, st.Region)
+		if st.Region == region {
+			return fallback
+		}
+		target = st.Height
+	}
+
+	for _, p := range peersSnapshot() {
+		if p == fallback {
+			continue
+		}
+		st, ok := probeStatus(p)
+		if !ok {
+			continue
+		}
+		setPeerRegion(p, st.Region)
+		if st.Region == region && st.Height >= target {
+			// 제네시스/프로토콜이 다른 체인의 노드는 동기화 대상에서 제외 (chaininfo.go)
+			if !sameChain(p) {
+				log.Printf("[REGION] Skip peer %s for sync: different chain", p)
+				continue
+			}
+			log.Printf("[REGION] Prefer same-region peer %s (region=%s) for sync", p, region)
+			return p
+		}
+	}
+	return fallback
+}
+
+// 트래픽 통계 스냅샷
+func trafficSnapshot() map[string]any {
+	return map[string]any{
+		"region": region,
+		"same_region": map[string]int64{
+			"msgs":  sameRegionTraffic.Msgs.Load(),
+			"bytes": sameRegionTraffic.Bytes.Load(),
+		},
+		"cross_region": map[string]int64{
+			"msgs":  crossRegionTraffic.Msgs.Load(),
+			"bytes": crossRegionTraffic.Bytes.Load(),
+		},
+	}
+}
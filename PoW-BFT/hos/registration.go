@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Signed Registration (서명된 피어 등록 + 운영자 승인 허용 목록)
+// ------------------------------------------------------------
+// - 신규 노드 => 부트노드 GET /register/challenge?addr= : 1회용 nonce 발급 (RegisterChallengeTTL 초 유효)
+// - POST /register 본문에 nonce 와 서명(sig) 포함
+//   · 서명 대상 : registerDigest(hos_id, addr, 공개키 지문, nonce), 제출한 공개키로 검증
+//   · 이미 다른 공개키로 등록된 주소는 거절 (키 교체는 /rotateKey 사용)
+// - 허용 목록 모드 (REGISTER_ALLOWLIST=true)
+//   · 공개키 지문이 허용 목록에 있거나 이미 피어로 등록된 키만 가입 가능
+//   · 목록에 없는 키는 승인 대기("joinreq_<지문>")로 기록하고 202 응답 => 신규 노드는 주기적으로 재시도
+//   · REGISTER_ALLOWED_KEYS : 시작 시 허용 목록에 넣을 지문 (쉼표 구분)
+//   · GET /admin/allowlist : 허용/대기 목록, POST {pubkey_fingerprint, addr} : 승인, DELETE ?fp= : 승인 취소
+//   · 허용 목록은 노드별 저장 => 부트노드 재선출에 대비해 모든 노드에 같은 목록을 설정
+//     (승인 취소는 이후 가입만 막고, 이미 참여한 피어를 제거하지는 않음)
+// - 부트노드가 기존 피어에 보내는 /addPeer 알림은 부트노드 키로 서명 (addPeerDigest)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RegisterChallengeTTL  = 60   // 초
+	MaxRegisterChallenges = 1024 // 동시에 유효한 nonce 상한
+	RegisterRetryInterval = 30   // 초, 승인 대기 중 재시도 간격
+	MaxJoinRequests       = 256  // 승인 대기 기록 상한 (초과 시 기록 없이 202)
+	allowPrefix           = "allow_"
+	joinReqPrefix         = "joinreq_"
+)
+
+var registerAllowlist = false // REGISTER_ALLOWLIST
+
+type regChallenge struct {
+	addr    string
+	expires time.Time
+}
+
+var (
+	challenges   = make(map[string]regChallenge) // nonce => 발급 정보
+	challengesMu sync.Mutex
+)
+
+// 허용 목록 항목
+type AllowedKey struct {
+	KeyFP      string `json:"pubkey_fingerprint"`
+	Addr       string `json:"addr,omitempty"` // 지정 시 이 주소로만 가입 가능
+	ApprovedAt string `json:"approved_at"`
+}
+
+// 승인 대기 중인 가입 요청
+type JoinRequest struct {
+	KeyFP     string `json:"pubkey_fingerprint"`
+	Addr      string `json:"addr"`
+	HosID     string `json:"hos_id"`
+	FirstSeen string `json:"first_seen"`
+	LastSeen  string `json:"last_seen"`
+}
+
+// 등록 서명 대상 다이제스트 (hex)
+func registerDigest(hosID, addr, pubPem, nonce string) string {
+	return sha256Hex([]byte(fmt.Sprintf("register|%s|%s|%s|%s", hosID, addr, pubKeyFingerprint(pubPem), nonce)))
+}
+
+// /addPeer 알림 서명 대상 다이제스트 (hex)
+func addPeerDigest(addr, pubPem, certPin string) string {
+	return sha256Hex([]byte(fmt.Sprintf("add_peer|%s|%s|%s", addr, pubKeyFingerprint(pubPem), certPin)))
+}
+
+// GET /register/challenge?addr=<host:port>
+func handleRegisterChallenge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	addr := r.URL.Query().Get("addr")
+	if addr == "" {
+		writeError(w, http.StatusBadRequest, "addr required")
+		return
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		writeError(w, http.StatusInternalServerError, "nonce generation failed")
+		return
+	}
+	nonce := hex.EncodeToString(buf)
+	expires := time.Now().Add(RegisterChallengeTTL * time.Second)
+
+	challengesMu.Lock()
+	if len(challenges) >= MaxRegisterChallenges {
+		now := time.Now()
+		for n, c := range challenges {
+			if now.After(c.expires) {
+				delete(challenges, n)
+			}
+		}
+	}
+	full := len(challenges) >= MaxRegisterChallenges
+	if !full {
+		challenges[nonce] = regChallenge{addr: addr, expires: expires}
+	}
+	challengesMu.Unlock()
+	if full {
+		w.Header().Set("Retry-After", fmt.Sprint(RegisterChallengeTTL))
+		writeError(w, http.StatusServiceUnavailable, "too many outstanding challenges")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"nonce": nonce, "expires_at": expires.UTC().Format(time.RFC3339)})
+}
+
+// nonce 1회 사용 처리 (발급받은 주소와 유효 시간 확인)
+func consumeChallenge(nonce, addr string) bool {
+	challengesMu.Lock()
+	defer challengesMu.Unlock()
+	c, ok := challenges[nonce]
+	if !ok {
+		return false
+	}
+	delete(challenges, nonce)
+	return c.addr == addr && time.Now().Before(c.expires)
+}
+
+// 등록 요청 검증 (부트노드)
+// - 202 : 허용 목록 모드에서 운영자 승인 대기
+func verifyRegistration(req registerReq) (int, error) {
+	if req.Nonce == "" || req.Sig == "" {
+		return http.StatusUnauthorized, fmt.Errorf("signed registration required (nonce, sig)")
+	}
+	if !consumeChallenge(req.Nonce, req.Addr) {
+		return http.StatusUnauthorized, fmt.Errorf("unknown or expired challenge")
+	}
+	hash, _ := hex.DecodeString(registerDigest(req.HosID, req.Addr, req.PubKey, req.Nonce))
+	if !verifyECDSA(req.PubKey, hash, req.Sig) {
+		return http.StatusUnauthorized, fmt.Errorf("registration signature invalid")
+	}
+	if req.Addr == self {
+		return http.StatusConflict, fmt.Errorf("addr is this boot node")
+	}
+	pkMu.RLock()
+	cur, known := peerPubKeys[req.Addr]
+	pkMu.RUnlock()
+	if known && cur != req.PubKey {
+		return http.StatusConflict, fmt.Errorf("addr already registered with a different key (use /rotateKey)")
+	}
+	if registerAllowlist && !known && !isAllowedKey(pubKeyFingerprint(req.PubKey), req.Addr) {
+		recordJoinRequest(req)
+		return http.StatusAccepted, fmt.Errorf("pending operator approval")
+	}
+	return http.StatusOK, nil
+}
+
+func isAllowedKey(fp, addr string) bool {
+	v, ok := getMeta(allowPrefix + fp)
+	if !ok {
+		return false
+	}
+	var a AllowedKey
+	if err := json.Unmarshal([]byte(v), &a); err != nil {
+		return false
+	}
+	return a.Addr == "" || a.Addr == addr
+}
+
+func recordJoinRequest(req registerReq) {
+	fp := pubKeyFingerprint(req.PubKey)
+	now := time.Now().UTC().Format(time.RFC3339)
+	jr := JoinRequest{KeyFP: fp, Addr: req.Addr, HosID: req.HosID, FirstSeen: now, LastSeen: now}
+	if v, ok := getMeta(joinReqPrefix + fp); ok {
+		var prev JoinRequest
+		if json.Unmarshal([]byte(v), &prev) == nil {
+			jr.FirstSeen = prev.FirstSeen
+		}
+	} else if countPrefix(joinReqPrefix) >= MaxJoinRequests {
+		return
+	} else {
+		log.Printf("[BOOT][ALLOWLIST] join request from %s (key %s) awaiting approval", req.Addr, shortFP(req.PubKey))
+	}
+	data, _ := json.Marshal(jr)
+	putMeta(joinReqPrefix+fp, string(data))
+}
+
+func countPrefix(prefix string) int {
+	n := 0
+	iter := db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	for iter.Next() {
+		n++
+	}
+	iter.Release()
+	return n
+}
+
+// 허용 목록 추가 (승인 대기 항목 제거)
+func allowKey(fp, addr string) error {
+	data, _ := json.Marshal(AllowedKey{KeyFP: fp, Addr: addr, ApprovedAt: time.Now().UTC().Format(time.RFC3339)})
+	if err := putMeta(allowPrefix+fp, string(data)); err != nil {
+		return err
+	}
+	return countDBError(db.Delete([]byte(joinReqPrefix+fp), nil))
+}
+
+// REGISTER_ALLOWED_KEYS 의 지문을 허용 목록에 추가 (main 에서 호출)
+func seedAllowlist(keys string) {
+	for _, fp := range strings.Split(keys, ",") {
+		fp = strings.ToLower(strings.TrimSpace(fp))
+		if fp == "" {
+			continue
+		}
+		if _, ok := getMeta(allowPrefix + fp); ok {
+			continue
+		}
+		if err := allowKey(fp, ""); err != nil {
+			log.Printf("[BOOT][ALLOWLIST][ERROR] seed %s: %v", fp, err)
+		}
+	}
+}
+
+// GET/POST/DELETE /admin/allowlist (operator 전용 : 승인 권한이 곧 가입 권한이므로 mTLS 만으로는 부족)
+func handleAllowlist(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		allowed, pending := []AllowedKey{}, []JoinRequest{}
+		err := withReadSnapshot(func(rd dbReader) error {
+			iter := rd.NewIterator(util.BytesPrefix([]byte(allowPrefix)), nil)
+			for iter.Next() {
+				var a AllowedKey
+				if json.Unmarshal(iter.Value(), &a) == nil {
+					allowed = append(allowed, a)
+				}
+			}
+			iter.Release()
+			iter = rd.NewIterator(util.BytesPrefix([]byte(joinReqPrefix)), nil)
+			for iter.Next() {
+				var jr JoinRequest
+				if json.Unmarshal(iter.Value(), &jr) == nil {
+					pending = append(pending, jr)
+				}
+			}
+			iter.Release()
+			return iter.Error()
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		sort.Slice(pending, func(i, j int) bool { return pending[i].FirstSeen < pending[j].FirstSeen })
+		writeJSON(w, http.StatusOK, map[string]any{"enabled": registerAllowlist, "allowed": allowed, "pending": pending})
+
+	case http.MethodPost:
+		var req struct {
+			KeyFP string `json:"pubkey_fingerprint"`
+			Addr  string `json:"addr"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.KeyFP) != 64 {
+			writeError(w, http.StatusBadRequest, "pubkey_fingerprint (sha-256 hex) required")
+			return
+		}
+		fp := strings.ToLower(req.KeyFP)
+		if err := allowKey(fp, req.Addr); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		log.Printf("[BOOT][ALLOWLIST] approved key %s (addr=%q)", fp[:16], req.Addr)
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodDelete:
+		fp := strings.ToLower(r.URL.Query().Get("fp"))
+		if fp == "" {
+			writeError(w, http.StatusBadRequest, "fp required")
+			return
+		}
+		if err := countDBError(db.Delete([]byte(allowPrefix+fp), nil)); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		log.Printf("[BOOT][ALLOWLIST] revoked key %s", fp[:min(16, len(fp))])
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// 부트노드 키로 서명된 /addPeer 알림인지 확인
+func verifyBootSignature(bootAddr, digestHex, sigHex string) bool {
+	if bootAddr == "" || bootAddr != getBootAddr() {
+		return false
+	}
+	pkMu.RLock()
+	pub := peerPubKeys[bootAddr]
+	pkMu.RUnlock()
+	hash, _ := hex.DecodeString(digestHex)
+	return verifyECDSA(pub, hash, sigHex)
+}
+
+// 신규 노드 : 부트노드에 서명된 등록 요청 (승인 대기 중이면 RegisterRetryInterval 마다 재시도)
+// - 반환 status 가 200 이 아니면 reg 는 비어 있음
+func registerWithBoot(hosID string) (reg registerResp, status int, err error) {
+	pubPem, ok := getMeta(pubKeyMetaKey)
+	privPem, ok2 := nodePrivKey()
+	if !ok || !ok2 {
+		log.Fatal("[BOOT] Key pair not found. Check ensureKeyPair.")
+	}
+	for {
+		var nonce struct {
+			Nonce string `json:"nonce"`
+		}
+		resp, err := nodeClient.Get(nodeURL(getBootAddr(), "/register/challenge?addr="+url.QueryEscape(self)))
+		if err != nil {
+			return reg, 0, err
+		}
+		if resp.StatusCode == http.StatusOK {
+			err = json.NewDecoder(resp.Body).Decode(&nonce)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return reg, 0, fmt.Errorf("decode challenge: %w", err)
+		}
+
+		payload := registerReq{HosID: hosID, Addr: self, PubKey: pubPem, Nonce: nonce.Nonce, Role: nodeRole}
+		if nonce.Nonce != "" {
+			payload.Sig = makeAnchorSignature(privPem, registerDigest(hosID, self, pubPem, nonce.Nonce), "")
+		}
+		b, _ := json.Marshal(payload)
+		resp, err = nodeClient.Post(nodeURL(getBootAddr(), "/register"), "application/json", bytes.NewReader(b))
+		if err != nil {
+			return reg, 0, err
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			err = json.NewDecoder(resp.Body).Decode(&reg)
+			resp.Body.Close()
+			return reg, http.StatusOK, err
+		case http.StatusAccepted:
+			resp.Body.Close()
+			log.Printf("[BOOT] registration pending operator approval at %s (key %s), retry in %ds", getBootAddr(), pubKeyFingerprint(pubPem), RegisterRetryInterval)
+			time.Sleep(RegisterRetryInterval * time.Second)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			log.Printf("[BOOT] register failed : status=%d body=%s", resp.StatusCode, strings.TrimSpace(string(body)))
+			return reg, resp.StatusCode, nil
+		}
+	}
+}
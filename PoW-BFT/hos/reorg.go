@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Incremental Reorg (분기 구간만 되돌리는 체인 교체)
+// ------------------------------------------------------------
+// - 체인 감시 루틴(startChainWatcher)이 로컬과 갈라진 더 긴 체인을 발견하면 호출
+//   (DB 전체를 지우고 처음부터 받던 resetLocalDB 대체, 메타/키/작업 기록은 그대로 유지)
+// - 1) 피어의 /headers 를 최신부터 거슬러 올라가며 로컬과 해시가 같은 가장 높은 블록(분기점) 탐색
+// - 2) 분기점 이후 원격 본문을 받아 연결/머클/상주 규칙과 2f+1 합의 서명까지 검증
+// - 3) chainMu 안에서 로컬의 분기점 이후 블록을 되돌림
+//   · 블록 본문/해시 키, 해당 블록을 가리키는 색인 포인터(cid_/pc_/info_/ft_), 만료 표시, 중복 접수 표시
+//   · 체인 커밋먼트 누적기는 다음 커밋 때 제네시스부터 재구성, 분기 이후 체크포인트는 폐기
+// - 4) 되돌리기와 새 분기 블록 반영을 한 트랜잭션(ledgerTxn)에 모아 한 번에 기록
+//   (중간 실패 시 로컬 체인은 그대로 남고 다음 주기에 다시 시도)
+// - 되돌린 레코드 중 새 분기에 없는 것은 메모리풀로 되돌림 (모든 검증자가 차례로 제안)
+// - 체크포인트로 부트스트랩 후 과거 블록을 받는 중(ckpt_history_low)에는 수행하지 않음
+////////////////////////////////////////////////////////////////////////////////
+
+// 원격 분기를 택할지 (더 길거나, 같은 높이면 최신 블록 해시가 사전순으로 앞선 쪽)
+func preferRemote(remoteH int, remoteHash string, localH int, localHash string) bool {
+	if remoteH != localH {
+		return remoteH > localH
+	}
+	return remoteHash != "" && remoteHash != localHash && remoteHash < localHash
+}
+
+// 공통 조상(분기점) 탐색 : 로컬과 원격에서 해시가 같은 가장 높은 블록 번호
+func findForkPoint(peer string, localH, remoteH int) (int, error) {
+	top := min(localH, remoteH)
+	for top >= 0 {
+		offset := max(0, top-HeaderPageSize+1)
+		page, err := fetchHeaders(peer, offset, top-offset+1)
+		if err != nil {
+			return -1, fmt.Errorf("headers from %s: %w", peer, err)
+		}
+		for i := len(page.Items) - 1; i >= 0; i-- {
+			h := page.Items[i]
+			if h.Index > top {
+				continue
+			}
+			if lb, err := getBlockByIndex(h.Index); err == nil && lb.BlockHash == h.BlockHash {
+				return h.Index, nil
+			}
+		}
+		top = offset - 1
+	}
+	return -1, fmt.Errorf("no common ancestor with %s", peer)
+}
+
+// 분기점 이후 원격 블록 수신 + 검증
+func fetchBranch(peer string, fork, remoteH int) ([]LowerBlock, error) {
+	prev, err := getBlockByIndex(fork)
+	if err != nil {
+		return nil, fmt.Errorf("load fork block #%d: %w", fork, err)
+	}
+	var out []LowerBlock
+	for offset := fork + 1; offset <= remoteH; offset += BodyChunkSize {
+		blocks, err := fetchBodies(peer, offset, BodyChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(blocks) == 0 {
+			break
+		}
+		for _, b := range blocks {
+			if err := validateLowerBlock(b, prev); err != nil {
+				return nil, fmt.Errorf("remote block #%d invalid: %w", b.Index, err)
+			}
+			if err := verifyConsensusEvidence(b); err != nil {
+				return nil, fmt.Errorf("remote block #%d: %w", b.Index, err)
+			}
+			out = append(out, b)
+			prev = b
+		}
+	}
+	return out, nil
+}
+
+// 피어 체인이 우선하면 분기점 이후만 교체
+func reorgFromPeer(peer string) error {
+	if v, ok := getMeta(historyLowKey); ok && v != "" {
+		return fmt.Errorf("block history still backfilling below #%s", v)
+	}
+	chainMu.Lock()
+	localH, _ := getLatestHeight()
+	tip, err := getBlockByIndex(localH)
+	chainMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("load local tip: %w", err)
+	}
+
+	first, err := fetchHeaders(peer, 0, 1)
+	if err != nil {
+		return fmt.Errorf("headers from %s: %w", peer, err)
+	}
+	remoteH := first.Total - 1
+	fork, err := findForkPoint(peer, localH, remoteH)
+	if err != nil {
+		return err
+	}
+	branch, err := fetchBranch(peer, fork, remoteH)
+	if err != nil {
+		return err
+	}
+	if len(branch) == 0 {
+		return nil
+	}
+	newTip := branch[len(branch)-1]
+	if !preferRemote(newTip.Index, newTip.BlockHash, localH, tip.BlockHash) {
+		return fmt.Errorf("remote branch not preferred (local=#%d, remote=#%d)", localH, newTip.Index)
+	}
+
+	chainMu.Lock()
+	// 수신 중 로컬 체인이 바뀌었으면 다음 주기에 다시 판단
+	if h, _ := getLatestHeight(); h != localH {
+		chainMu.Unlock()
+		return fmt.Errorf("local chain moved during reorg (height %d => %d)", localH, h)
+	}
+	// 같은 높이에서 분기점 블록이 교체됐어도 다시 판단
+	if fb, err := getBlockByIndex(fork); err != nil || fb.BlockHash != branch[0].PrevHash {
+		chainMu.Unlock()
+		return fmt.Errorf("fork block #%d changed during reorg", fork)
+	}
+	tx := newLedgerTxn()
+	orphans, err := rollbackTo(tx, fork, localH)
+	if err != nil {
+		chainMu.Unlock()
+		return fmt.Errorf("rollback to #%d: %w", fork, err)
+	}
+	for _, b := range branch {
+		if err := stageBlock(tx, b); err != nil {
+			chainMu.Unlock()
+			return fmt.Errorf("stage block #%d: %w", b.Index, err)
+		}
+	}
+	if err := tx.commit(); err != nil {
+		chainMu.Unlock()
+		return fmt.Errorf("write reorg to #%d: %w", newTip.Index, err)
+	}
+	invalidateValidatorCache()
+	for _, b := range branch {
+		afterCommit(b)
+	}
+	ch.lastBlockTime = time.Now()
+	chainMu.Unlock()
+
+	// 되돌린 높이의 합의 상태 정리
+	for v := fork + 1; v <= newTip.Index+1; v++ {
+		deleteView(v)
+	}
+
+	// 새 분기에 포함되지 않은 레코드는 메모리풀로 되돌림 (어느 검증자든 다음 제안에 포함)
+	included := make(map[string]bool)
+	for _, b := range branch {
+		for _, h := range b.LeafHashes {
+			included[h] = true
+		}
+	}
+	requeue := []ClinicRecord{}
+	for _, rec := range orphans {
+		if !included[hashClinicRecord(rec)] {
+			requeue = append(requeue, rec)
+		}
+	}
+	if len(requeue) > 0 {
+		if err := requeuePending(requeue); err != nil {
+			log.Printf("[REORG][WARN] requeue %d orphaned records: %v", len(requeue), err)
+		}
+	}
+
+	incCounter("chain_reorgs_total", "")
+	log.Printf("[REORG] Switched to %s: fork=#%d, dropped=%d, added=%d, requeued=%d (tip %s)",
+		peer, fork, localH-fork, len(branch), len(requeue), newTip.BlockHash[:12])
+	return nil
+}
+
+// 분기점 이후 로컬 블록과 그 블록을 가리키는 색인 되돌리기 (chainMu 보유 상태에서 호출)
+// - 쓰기는 tx 에만 추가 (기록은 새 분기 블록과 함께 호출자가 tx.commit)
+// - 되돌린 블록의 레코드 반환 (메모리풀 복원용)
+func rollbackTo(tx *ledgerTxn, fork, localH int) ([]ClinicRecord, error) {
+	var orphans []ClinicRecord
+	touched := map[string]bool{} // 포인터를 정리할 색인 키
+	for i := fork + 1; i <= localH; i++ {
+		b, err := getBlockByIndex(i)
+		if err != nil {
+			return nil, fmt.Errorf("load block #%d: %w", i, err)
+		}
+		for ei, rec := range b.Entries {
+			orphans = append(orphans, rec)
+			for _, key := range indexKeysForEntry(rec) {
+				touched[key] = true
+			}
+			tx.Delete(retentionMarkKey(i, ei))
+		}
+		for _, h := range b.LeafHashes {
+			tx.Delete(seenKey(h))
+		}
+		tx.Delete(blockKey(i))
+		tx.Delete([]byte("hash_" + b.BlockHash))
+		tx.Delete(rootIndexKey(b.MerkleRoot))
+	}
+
+	// 색인 포인터 목록에서 분기점 이후 블록을 가리키는 항목 제거
+	for key := range touched {
+		v, err := tx.Get([]byte(key), nil)
+		if err != nil {
+			continue
+		}
+		kept := []string{}
+		for _, p := range strings.Split(string(v), ",") {
+			if bi, _, ok := parsePtr(p); ok && bi <= fork {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == 0 {
+			tx.Delete([]byte(key))
+		} else {
+			tx.Put([]byte(key), []byte(strings.Join(kept, ",")))
+		}
+		if id, ok := strings.CutPrefix(key, "cid_"); ok {
+			deleteVersionKeys(tx, id, len(kept), len(strings.Split(string(v), ",")))
+		}
+	}
+
+	// 되돌린 블록에 남아 있는 만료 표시 (본문 없이 표시만 남은 경우 포함)
+	iter := db.NewIterator(util.BytesPrefix([]byte(retentionMarkPrefix)), nil)
+	for iter.Next() {
+		if bi, _, ok := parsePtr(strings.TrimPrefix(string(iter.Key()), retentionMarkPrefix)); ok && bi > fork {
+			tx.Delete(append([]byte(nil), iter.Key()...))
+		}
+	}
+	iter.Release()
+
+	// 되돌린 블록의 검증자 변경 내역
+	deleteValidatorChanges(tx, fork)
+	deleteEvidence(tx, fork)
+
+	// 분기 이후 높이의 체크포인트 폐기
+	if v, ok := getMeta(checkpointKey); ok {
+		var cp Checkpoint
+		if json.Unmarshal([]byte(v), &cp) != nil || cp.Height > fork {
+			tx.Delete([]byte(checkpointKey))
+		}
+	}
+
+	forkBlk, err := getBlockByIndex(fork)
+	if err != nil {
+		return nil, fmt.Errorf("load fork block #%d: %w", fork, err)
+	}
+	tx.Delete([]byte("commit_peaks")) // 다음 stageBlock 에서 제네시스부터 재구성 (commitment.go)
+	tx.Put([]byte("root_latest"), []byte(forkBlk.MerkleRoot))
+	tx.Put([]byte("height_latest"), []byte(fmt.Sprint(fork)))
+	log.Printf("[REORG] Rolling back blocks #%d..#%d (%d records, %d index keys, fork hash=%s)",
+		fork+1, localH, len(orphans), len(touched), forkBlk.BlockHash[:12])
+	return orphans, nil
+}
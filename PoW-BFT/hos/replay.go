@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Replay Mode (마이그레이션 검증용 장부 재생)
+// ------------------------------------------------------------
+// - 해싱/합의 코드 업그레이드 전, 운영 장부를 후보 빌드에 그대로 재생하여 호환성 확인
+// - REPLAY_SOURCE 형식
+//   · "peer:<addr>"  : 원격 노드의 /blocks 를 페이지 단위로 받아 재생 (검증자 공개키는 /peers, /getPublicKey)
+//   · "file:<path>"  : 스냅샷 파일(블록 배열 JSON, /blocks 응답 JSON 또는 /admin/export 아카이브) 재생
+//                      검증자 공개키는 REPLAY_KEYS 파일(주소 => 공개키 PEM 맵)로 지정
+// - 빈 LevelDB(REPLAY_DB_PATH)에 제네시스부터 순서대로
+//   블록 검증(validateLowerBlock) => 합의 증거 검증(verifyConsensusEvidence) => 저장(commitBlock)을 최대 속도로 수행
+// - 최초 비호환 지점(블록 번호, 사유)을 보고하고 종료 (비호환 시 종료코드 1)
+// - 주: 검증자 집합은 재생 시점의 집합으로 고정 (이력 중 노드 변경은 반영하지 않음)
+////////////////////////////////////////////////////////////////////////////////
+
+const ReplayPageSize = 200
+
+var replaying bool // 재생 모드 여부 (운영 블록 이력 파일 기록 생략)
+
+// 재생 결과 보고서
+type ReplayReport struct {
+	Source       string         `json:"source"`
+	Replayed     int            `json:"replayed"`       // 검증/저장에 성공한 블록 수
+	Height       int            `json:"height"`         // 재생된 마지막 블록 번호
+	Elapsed      string         `json:"elapsed"`        // 총 소요 시간
+	BlocksPerSec float64        `json:"blocks_per_sec"` // 처리 속도
+	Validators   int            `json:"validators"`     // 합의 증거 검증에 사용한 검증자 수 (0 이면 생략)
+	Compatible   bool           `json:"compatible"`
+	Failure      *ReplayFailure `json:"failure,omitempty"` // 최초 비호환 지점
+}
+
+type ReplayFailure struct {
+	Index  int    `json:"index"`
+	Hash   string `json:"hash"`
+	Reason string `json:"reason"`
+}
+
+// 재생 모드 진입점 (main 에서 REPLAY_MODE=true 일 때 호출)
+func runReplay(source, dbPath string) {
+	if source == "" {
+		log.Fatal("[REPLAY] REPLAY_SOURCE required (peer:<addr> | file:<path>)")
+	}
+	// 항상 빈 DB에서 시작 (이전 재생 결과 제거)
+	if err := os.RemoveAll(dbPath); err != nil {
+		log.Fatalf("[REPLAY] cannot clear %s: %v", dbPath, err)
+	}
+	replaying = true
+	initDB(dbPath)
+	defer closeDB()
+
+	report := ReplayReport{Source: source, Height: -1, Compatible: true}
+	start := time.Now()
+
+	var (
+		keys map[string]string
+		next func() ([]LowerBlock, error) // 다음 블록 묶음 (빈 슬라이스 = 끝)
+		err  error
+	)
+	switch {
+	case strings.HasPrefix(source, "peer:"):
+		addr := strings.TrimPrefix(source, "peer:")
+		keys, err = fetchReplayKeys(addr)
+		next = peerBlockSource(addr)
+	case strings.HasPrefix(source, "file:"):
+		if keys, err = loadReplayKeys(os.Getenv("REPLAY_KEYS")); err == nil {
+			next, err = fileBlockSource(strings.TrimPrefix(source, "file:"))
+		}
+	default:
+		log.Fatalf("[REPLAY] unknown REPLAY_SOURCE: %s", source)
+	}
+	if err != nil {
+		log.Fatalf("[REPLAY] source init failed: %v", err)
+	}
+	report.Validators = installReplayValidators(keys)
+
+	var prev LowerBlock
+replay:
+	for {
+		batch, err := next()
+		if err != nil {
+			report.Compatible = false
+			report.Failure = &ReplayFailure{Index: report.Height + 1, Reason: "source read: " + err.Error()}
+			break
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, blk := range batch {
+			if err := replayBlock(blk, prev, report.Height, report.Validators > 0); err != nil {
+				report.Compatible = false
+				report.Failure = &ReplayFailure{Index: blk.Index, Hash: blk.BlockHash, Reason: err.Error()}
+				break replay
+			}
+			prev = blk
+			report.Height = blk.Index
+			report.Replayed++
+		}
+		log.Printf("[REPLAY] ... %d blocks replayed (height=%d)", report.Replayed, report.Height)
+	}
+
+	elapsed := time.Since(start)
+	report.Elapsed = elapsed.String()
+	if elapsed > 0 {
+		report.BlocksPerSec = float64(report.Replayed) / elapsed.Seconds()
+	}
+
+	out, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(out))
+	if !report.Compatible {
+		log.Printf("[REPLAY][INCOMPATIBLE] Block #%d: %s", report.Failure.Index, report.Failure.Reason)
+		closeDB()
+		os.Exit(1)
+	}
+	log.Printf("[REPLAY][OK] %d blocks replayed without incompatibility", report.Replayed)
+}
+
+// 단일 블록 재생 : 신규 빌드의 검증 파이프라인 통과 후 저장
+func replayBlock(blk, prev LowerBlock, height int, checkEvidence bool) error {
+	if blk.Index != height+1 {
+		return fmt.Errorf("unexpected index: want=%d got=%d", height+1, blk.Index)
+	}
+	if blk.Index == 0 {
+		// 제네시스 : 이전 블록이 없으므로 해시/루트만 재계산
+		if blk.BlockHash != blk.computeHash() {
+			return fmt.Errorf("genesis block_hash mismatch")
+		}
+	} else {
+		if err := validateLowerBlock(blk, prev); err != nil {
+			return err
+		}
+		if checkEvidence {
+			if err := verifyConsensusEvidence(blk); err != nil {
+				return fmt.Errorf("consensus evidence: %w", err)
+			}
+		}
+	}
+	if err := commitBlock(blk); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	// 저장 후 재조회 결과가 원본과 같은지 확인 (직렬화 호환성)
+	stored, err := getBlockByIndex(blk.Index)
+	if err != nil {
+		return fmt.Errorf("reload: %w", err)
+	}
+	if stored.BlockHash != blk.BlockHash || stored.computeHash() != blk.BlockHash {
+		return fmt.Errorf("stored block differs after round-trip")
+	}
+	return nil
+}
+
+// 검증자 공개키 등록 : 첫 검증자를 자기 자신으로 두어 정족수 계산(n = 피어 + 1)을 원본 네트워크와 맞춤
+func installReplayValidators(keys map[string]string) int {
+	if len(keys) == 0 {
+		log.Printf("[REPLAY][WARN] No validator keys; consensus evidence will not be checked")
+		return 0
+	}
+	addrs := make([]string, 0, len(keys))
+	for a := range keys {
+		addrs = append(addrs, a)
+	}
+	sort.Strings(addrs)
+
+	self = addrs[0]
+	putMeta("meta_hos_pubkey", keys[self])
+	for _, a := range addrs[1:] {
+		addPeerInternal(a, keys[a])
+	}
+	log.Printf("[REPLAY] Validator set loaded (%d nodes)", len(addrs))
+	return len(addrs)
+}
+
+// 원격 노드의 검증자 공개키 수집 (/peers + 자신의 /getPublicKey)
+func fetchReplayKeys(addr string) (map[string]string, error) {
+	keys := map[string]string{}
+	resp, err := nodeClient.Get(nodeURL(addr, "/peers"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return nil, fmt.Errorf("invalid /peers: %w", err)
+	}
+
+	pr, err := nodeClient.Get(nodeURL(addr, "/getPublicKey"))
+	if err != nil {
+		return nil, err
+	}
+	defer pr.Body.Close()
+	if pr.StatusCode == http.StatusOK {
+		pem, _ := io.ReadAll(pr.Body)
+		keys[addr] = string(pem)
+	}
+	return keys, nil
+}
+
+// 스냅샷용 검증자 공개키 파일 로드 (없으면 합의 증거 검증 생략)
+func loadReplayKeys(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys := map[string]string{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("invalid REPLAY_KEYS: %w", err)
+	}
+	return keys, nil
+}
+
+// 원격 노드 /blocks 페이지 순회
+func peerBlockSource(addr string) func() ([]LowerBlock, error) {
+	offset := 0
+	return func() ([]LowerBlock, error) {
+		url := nodeURL(addr, fmt.Sprintf("/blocks?offset=%d&limit=%d", offset, ReplayPageSize))
+		resp, err := nodeClient.Get(url)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("/blocks status=%d", resp.StatusCode)
+		}
+		var page blocksPage
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			return nil, err
+		}
+		offset += len(page.Items)
+		return page.Items, nil
+	}
+}
+
+// 스냅샷 파일 (블록 배열, /blocks 응답 또는 NDJSON 아카이브 형식) 을 한 번에 반환
+func fileBlockSource(path string) (func() ([]LowerBlock, error), error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var blocks []LowerBlock
+	trimmed := bytes.TrimSpace(data)
+	first, _, _ := bytes.Cut(trimmed, []byte{'\n'})
+	var head struct {
+		Archive string `json:"archive"`
+	}
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &blocks)
+	} else if json.Unmarshal(first, &head) == nil && head.Archive != "" {
+		_, blocks, err = readChainArchive(bytes.NewReader(trimmed)) // chainarchive.go
+	} else {
+		var page blocksPage
+		err = json.Unmarshal(data, &page)
+		blocks = page.Items
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Index < blocks[j].Index })
+
+	done := false
+	return func() ([]LowerBlock, error) {
+		if done {
+			return nil, nil
+		}
+		done = true
+		return blocks, nil
+	}, nil
+}
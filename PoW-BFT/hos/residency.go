@@ -0,0 +1,585 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Data Residency (리전 상주 레코드와 리전 서브 장부)
+// ------------------------------------------------------------
+// - ClinicRecord.Residency 가 지정된 레코드는 해당 리전 밖의 노드로 복제되면 안 됨
+//   · 업로드는 같은 리전 노드에서만 접수 (다른 리전 노드는 레코드를 경유시키지 않고 거절)
+//   · 메인 체인 블록에는 포함되지 않고, 리전별 서브 장부("<hos_id>@<region>")에 기록
+// - 서브 장부 합의 : 같은 리전 노드(리전 멤버)만 참여
+//   · 리전 리더(정렬된 멤버 중 첫 노드)가 블록을 제안하고 멤버 서명을 2f+1개 수집 후 확정 전파
+//   · 확정된 서브 장부 블록은 리전 리더가 Gov 에 별도 앵커로 제출 (hos_id = "<hos_id>@<region>")
+// - 검증 단계 차단 (validateLowerBlock)
+//   · 메인 체인 블록에 상주 레코드가 있으면 거부
+//   · 서브 장부 블록은 수신 노드의 리전과 일치하고, 모든 레코드의 상주 리전이 같아야 수용
+////////////////////////////////////////////////////////////////////////////////
+
+const residencyPendingPrefix = "rpending_"
+
+var (
+	regionPending   []ClinicRecord // 서브 장부 메모리풀 (이 노드 리전의 상주 레코드)
+	regionPendingMu sync.Mutex
+	subLedgerMu     sync.Mutex  // 서브 장부 검증/저장 직렬화
+	subInProgress   atomic.Bool // 리전 리더의 서브 장부 합의 진행 여부
+)
+
+// 서브 장부 식별자 : "<hos_id>@<region>"
+func subLedgerID(r string) string {
+	return selfID() + "@" + r
+}
+
+// 블록의 hos_id 에서 서브 장부 리전 추출 (메인 체인이면 빈 문자열)
+func subLedgerRegion(hosID string) string {
+	if i := strings.LastIndex(hosID, "@"); i >= 0 {
+		return hosID[i+1:]
+	}
+	return ""
+}
+
+func subBlockKey(r string, index int) []byte {
+	return []byte(fmt.Sprintf("sub_%s_block_%012d", r, index))
+}
+
+func subHeightKey(r string) string {
+	return "sub_" + r + "_height"
+}
+
+// 리전 멤버 : 자신 + 같은 리전으로 확인된 피어 (정렬)
+func regionMembers() []string {
+	members := []string{self}
+	for _, p := range peersSnapshot() {
+		if isSameRegion(p) {
+			members = append(members, p)
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+// 리전 리더 : 정렬된 리전 멤버 중 첫 노드
+func regionLeader() string {
+	return regionMembers()[0]
+}
+
+func regionQuorum(n int) int {
+	f := (n - 1) / 3
+	return 2*f + 1
+}
+
+// 업로드 레코드 분리 : 상주 제한 없는 레코드 / 이 노드 리전의 상주 레코드
+// 다른 리전에 상주해야 하는 레코드가 하나라도 있으면 오류
+func splitByResidency(entries []ClinicRecord) (open, resident []ClinicRecord, err error) {
+	for _, rec := range entries {
+		switch rec.Residency {
+		case "":
+			open = append(open, rec)
+		case region:
+			resident = append(resident, rec)
+		default:
+			return nil, nil, fmt.Errorf("record %s must reside in region %s (this node: %s)", rec.ClinicID, rec.Residency, region)
+		}
+	}
+	return open, resident, nil
+}
+
+// 블록 상주 규칙 검증
+// - 메인 체인 : 상주 레코드 포함 불가
+// - 서브 장부 : 수신 노드 리전과 일치, 모든 레코드가 해당 리전 상주
+func checkBlockResidency(b LowerBlock) error {
+	sub := subLedgerRegion(b.HosID)
+	if sub != "" && sub != region {
+		return fmt.Errorf("residency violation: sub-ledger of region %s offered to region %s", sub, region)
+	}
+	for _, rec := range b.Entries {
+		if rec.Residency != sub {
+			return fmt.Errorf("residency violation: record %s (residency=%q) in ledger %s", rec.ClinicID, rec.Residency, b.HosID)
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 서브 장부 메모리풀 (LevelDB 선기록)
+////////////////////////////////////////////////////////////////////////////////
+
+func appendRegionPending(entries []ClinicRecord) error {
+	regionPendingMu.Lock()
+	defer regionPendingMu.Unlock()
+	if entries, _ = filterReplays(entries); len(entries) == 0 {
+		return nil
+	}
+
+	seq := 0
+	if s, ok := getMeta("seq_rpending"); ok {
+		seq, _ = strconv.Atoi(s)
+	}
+	batch := new(leveldb.Batch)
+	for _, rec := range entries {
+		data, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		seq++
+		batch.Put([]byte(fmt.Sprintf("%s%020d", residencyPendingPrefix, seq)), data)
+	}
+	markSeen(batch, recordHashes(entries), -1)
+	batch.Put([]byte("seq_rpending"), []byte(strconv.Itoa(seq)))
+	if err := countDBError(db.Write(batch, nil)); err != nil {
+		log.Printf("[RESIDENCY][PENDING][ERROR] write-ahead failed: %v", err)
+		return err
+	}
+	regionPending = append(regionPending, entries...)
+	log.Printf("[RESIDENCY][PENDING] Append region-resident entries (%d items, region=%s)", len(entries), region)
+	return nil
+}
+
+// 재시작 시 서브 장부 메모리풀 복원
+func loadRegionPending() {
+	regionPendingMu.Lock()
+	defer regionPendingMu.Unlock()
+	iter := db.NewIterator(util.BytesPrefix([]byte(residencyPendingPrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var rec ClinicRecord
+		if err := json.Unmarshal(iter.Value(), &rec); err != nil {
+			continue
+		}
+		regionPending = append(regionPending, rec)
+	}
+	if len(regionPending) > 0 {
+		log.Printf("[RESIDENCY] Restored %d region-resident pending entries", len(regionPending))
+	}
+}
+
+// 우선순위/블록 한도에 따라 다음 서브 블록 레코드를 꺼냄 (나머지는 메모리풀에 유지, priority.go)
+func popRegionPending() []ClinicRecord {
+	regionPendingMu.Lock()
+	defer regionPendingMu.Unlock()
+	entries, deferred := selectForBlock(regionPending)
+	regionPending = deferred
+	return entries
+}
+
+// 합의 실패 시 메모리풀 앞쪽으로 되돌림
+func requeueRegionPending(entries []ClinicRecord) {
+	regionPendingMu.Lock()
+	defer regionPendingMu.Unlock()
+	regionPending = append(entries, regionPending...)
+}
+
+func getRegionPendingCnt() int {
+	regionPendingMu.Lock()
+	defer regionPendingMu.Unlock()
+	return len(regionPending)
+}
+
+// 확정된 레코드를 서브 장부 메모리풀(LevelDB)에서 제거
+func clearRegionPending(leafHashes []string) {
+	finalized := make(map[string]bool, len(leafHashes))
+	for _, h := range leafHashes {
+		finalized[h] = true
+	}
+
+	regionPendingMu.Lock()
+	defer regionPendingMu.Unlock()
+	kept := regionPending[:0]
+	for _, rec := range regionPending {
+		if !finalized[hashClinicRecord(rec)] {
+			kept = append(kept, rec)
+		}
+	}
+	regionPending = kept
+
+	batch := new(leveldb.Batch)
+	iter := db.NewIterator(util.BytesPrefix([]byte(residencyPendingPrefix)), nil)
+	for iter.Next() {
+		var rec ClinicRecord
+		if err := json.Unmarshal(iter.Value(), &rec); err == nil && finalized[hashClinicRecord(rec)] {
+			batch.Delete(append([]byte{}, iter.Key()...))
+		}
+	}
+	iter.Release()
+	if err := countDBError(db.Write(batch, nil)); err != nil {
+		log.Printf("[RESIDENCY][PENDING][ERROR] clear finalized entries failed: %v", err)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 서브 장부 저장/조회
+////////////////////////////////////////////////////////////////////////////////
+
+func getSubHeight() int {
+	s, ok := getMeta(subHeightKey(region))
+	if !ok {
+		return 0
+	}
+	h, _ := strconv.Atoi(s)
+	return h
+}
+
+func getSubBlock(index int) (LowerBlock, error) {
+	var b LowerBlock
+	data, err := db.Get(subBlockKey(region, index), nil)
+	if err != nil {
+		return b, countDBError(err)
+	}
+	err = json.Unmarshal(data, &b)
+	return b, err
+}
+
+// 서브 장부 제네시스 (결정적 생성이므로 리전 멤버 모두 동일한 해시)
+func ensureSubGenesis() {
+	if _, err := getSubBlock(0); err == nil {
+		return
+	}
+	if err := commitSubBlock(createGenesisBlock(subLedgerID(region))); err != nil {
+		log.Printf("[RESIDENCY][ERROR] sub-ledger genesis failed: %v", err)
+		return
+	}
+	log.Printf("[RESIDENCY] Sub-ledger %s initialized", subLedgerID(region))
+}
+
+func commitSubBlock(b LowerBlock) error {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	batch.Put(subBlockKey(region, b.Index), data)
+	batch.Put([]byte(subHeightKey(region)), []byte(strconv.Itoa(b.Index)))
+	markSeen(batch, b.LeafHashes, b.Index)
+	return countDBError(db.Write(batch, nil))
+}
+
+func listSubBlocks() []LowerBlock {
+	out := []LowerBlock{}
+	for i := 0; i <= getSubHeight(); i++ {
+		b, err := getSubBlock(i)
+		if err != nil {
+			break
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+func createSubBlock(entries []ClinicRecord) LowerBlock {
+	prev, _ := getSubBlock(getSubHeight())
+	b := LowerBlock{
+		Index:      prev.Index + 1,
+		HosID:      subLedgerID(region),
+		PrevHash:   prev.BlockHash,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339Nano),
+		Entries:    orderByPriority(entries),
+		Proposer:   self,
+		Signatures: []ConsensusSig{},
+	}
+	b.LeafHashes = entryLeafHashes(b.Entries)
+	b.MerkleRoot = merkle.Root(b.LeafHashes)
+	b.BlockHash = b.computeHash()
+	return b
+}
+
+// 리전 멤버 서명 2f+1 확인
+func verifyRegionEvidence(b LowerBlock) error {
+	members := regionMembers()
+	required := regionQuorum(len(members))
+	hash, _ := hex.DecodeString(b.BlockHash)
+
+	keys := map[string]string{}
+	if pub, ok := getMeta("meta_hos_pubkey"); ok {
+		keys[self] = pub
+	}
+	pkMu.RLock()
+	for _, m := range members {
+		if m != self {
+			keys[m] = peerPubKeys[m]
+		}
+	}
+	pkMu.RUnlock()
+
+	if signed := countValidSignatures(b, hash, keys); signed < required {
+		return fmt.Errorf("region signatures insufficient: %d/%d", signed, required)
+	}
+	return nil
+}
+
+// 서브 장부 블록 검증 후 저장 (이미 저장된 인덱스는 무시)
+func onSubBlockReceived(b LowerBlock) error {
+	subLedgerMu.Lock()
+	defer subLedgerMu.Unlock()
+
+	if b.Index <= getSubHeight() {
+		return nil
+	}
+	prev, err := getSubBlock(b.Index - 1)
+	if err != nil {
+		return fmt.Errorf("sub-ledger gap before #%d", b.Index)
+	}
+	if err := validateLowerBlock(b, prev); err != nil {
+		return err
+	}
+	if err := commitSubBlock(b); err != nil {
+		return err
+	}
+	clearRegionPending(b.LeafHashes)
+	log.Printf("[RESIDENCY] Sub-ledger %s block #%d committed (%d entries)", b.HosID, b.Index, len(b.Entries))
+	return nil
+}
+
+// 같은 리전 피어로부터 서브 장부 뒤쪽 블록 동기화
+func syncSubLedger(peer string) {
+	if !isSameRegion(peer) {
+		return
+	}
+	resp, err := nodeClient.Get(nodeURL(peer, "/residency/blocks?region="+region))
+	if err != nil {
+		log.Printf("[RESIDENCY] Failed to sync sub-ledger from %s: %v", peer, err)
+		return
+	}
+	defer resp.Body.Close()
+	var page struct {
+		Items []LowerBlock `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return
+	}
+	for _, b := range page.Items {
+		if b.Index == 0 {
+			continue
+		}
+		if err := verifyRegionEvidence(b); err != nil {
+			log.Printf("[RESIDENCY] Reject synced block #%d from %s: %v", b.Index, peer, err)
+			return
+		}
+		if err := onSubBlockReceived(b); err != nil {
+			log.Printf("[RESIDENCY] Reject synced block #%d from %s: %v", b.Index, peer, err)
+			return
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 서브 장부 합의 (리전 리더)
+////////////////////////////////////////////////////////////////////////////////
+
+func startResidencyWatcher() {
+	ticker := time.NewTicker(time.Duration(ConsWatcherTime) * time.Second)
+	var firstSeen time.Time
+
+	for range ticker.C {
+		if regionLeader() != self || subInProgress.Load() {
+			continue
+		}
+		cnt := getRegionPendingCnt()
+		if cnt == 0 {
+			firstSeen = time.Time{}
+			continue
+		}
+		if firstSeen.IsZero() {
+			firstSeen = time.Now()
+		}
+		if cnt < ConsensusBatchSize && time.Since(firstSeen) < ConsensusTimeout*time.Second {
+			continue
+		}
+		if records := popRegionPending(); len(records) > 0 {
+			proposeSubBlock(records)
+		}
+		firstSeen = time.Time{}
+	}
+}
+
+// 리전 멤버에게만 블록을 보내 서명 수집 => 정족수 충족 시 확정 전파 및 별도 앵커링
+func proposeSubBlock(records []ClinicRecord) {
+	subInProgress.Store(true)
+	defer subInProgress.Store(false)
+
+	block := createSubBlock(records)
+	members := regionMembers()
+	required := regionQuorum(len(members))
+
+	myPriv, _ := nodePrivKey()
+	keys := validatorKeys()
+	sigs := []ConsensusSig{{Addr: self, KeyFP: pubKeyFingerprint(keys[self]), Sig: makeAnchorSignature(myPriv, block.BlockHash, "")}}
+	body, _ := json.Marshal(block)
+	for _, m := range members {
+		if m == self {
+			continue
+		}
+		recordTraffic(m, int64(len(body)))
+		resp, err := nodeClient.Post(nodeURL(m, "/residency/prepare"), "application/json", bytes.NewReader(body))
+		if err != nil {
+			continue
+		}
+		var vote struct {
+			Sig string `json:"sig"`
+		}
+		if resp.StatusCode == http.StatusOK {
+			_ = json.NewDecoder(resp.Body).Decode(&vote)
+		}
+		resp.Body.Close()
+		if vote.Sig != "" {
+			sigs = append(sigs, ConsensusSig{Addr: m, KeyFP: pubKeyFingerprint(keys[m]), Sig: vote.Sig})
+		}
+	}
+	block.Signatures = sigs
+
+	if err := verifyRegionEvidence(block); err != nil {
+		log.Printf("[RESIDENCY] Sub-ledger block #%d not finalized (quorum=%d): %v", block.Index, required, err)
+		requeueRegionPending(records)
+		return
+	}
+	if err := onSubBlockReceived(block); err != nil {
+		log.Printf("[RESIDENCY][ERROR] commit sub-ledger block #%d: %v", block.Index, err)
+		requeueRegionPending(records)
+		return
+	}
+
+	body, _ = json.Marshal(block)
+	for _, m := range members {
+		if m == self {
+			continue
+		}
+		recordTraffic(m, int64(len(body)))
+		go postPeer(m, "/residency/commit", body)
+	}
+	go submitAnchor(block)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 핸들러
+////////////////////////////////////////////////////////////////////////////////
+
+// POST /residency/prepare : 리전 리더의 서브 장부 블록 제안 검증 후 서명 반환
+func handleResidencyPrepare(w http.ResponseWriter, r *http.Request) {
+	var b LowerBlock
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid block")
+		return
+	}
+	if b.Proposer != regionLeader() {
+		writeError(w, http.StatusForbidden, "proposer is not the region leader")
+		return
+	}
+	if b.Index > getSubHeight()+1 {
+		syncSubLedger(b.Proposer)
+	}
+
+	subLedgerMu.Lock()
+	prev, err := getSubBlock(b.Index - 1)
+	if err == nil {
+		err = validateLowerBlock(b, prev)
+	}
+	subLedgerMu.Unlock()
+	if err != nil {
+		log.Printf("[RESIDENCY] Reject proposal #%d from %s: %v", b.Index, b.Proposer, err)
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	myPriv, _ := nodePrivKey()
+	writeJSON(w, http.StatusOK, map[string]string{
+		"addr": self,
+		"sig":  makeAnchorSignature(myPriv, b.BlockHash, ""),
+	})
+}
+
+// POST /residency/commit : 리전 정족수 서명이 포함된 확정 블록 수신
+func handleResidencyCommit(w http.ResponseWriter, r *http.Request) {
+	var b LowerBlock
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid block")
+		return
+	}
+	if err := verifyRegionEvidence(b); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if b.Index > getSubHeight()+1 {
+		syncSubLedger(b.Proposer)
+	}
+	if err := onSubBlockReceived(b); err != nil {
+		log.Printf("[RESIDENCY] Reject committed block #%d: %v", b.Index, err)
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// GET /residency/blocks?region= : 이 노드 리전의 서브 장부 조회 (다른 리전은 보관하지 않음)
+func handleResidencyBlocks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if q := r.URL.Query().Get("region"); q != "" && q != region {
+		writeError(w, http.StatusNotFound, "sub-ledger not held in this region")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"ledger":  subLedgerID(region),
+		"region":  region,
+		"leader":  regionLeader(),
+		"members": regionMembers(),
+		"height":  getSubHeight(),
+		"pending": getRegionPendingCnt(),
+		"items":   listSubBlocks(),
+	})
+}
+
+// 상주 레코드 업로드 처리 : 리전 리더에게 전달 (리더 자신이면 메모리풀에 적재)
+func submitResidentRecords(entries []ClinicRecord) error {
+	leader := regionLeader()
+	if leader == self {
+		return appendRegionPending(entries)
+	}
+	body, _ := json.Marshal(entries)
+	recordTraffic(leader, int64(len(body)))
+	resp, err := nodeClient.Post(nodeURL(leader, "/residency/pending"), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("region leader %s returned status %d", leader, resp.StatusCode)
+	}
+	return nil
+}
+
+// POST /residency/pending : 같은 리전 노드가 접수한 상주 레코드 수신 (리전 리더)
+func handleResidencyPending(w http.ResponseWriter, r *http.Request) {
+	var entries []ClinicRecord
+	if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid Clinic record")
+		return
+	}
+	if open, _, err := splitByResidency(entries); err != nil || len(open) > 0 {
+		writeError(w, http.StatusForbidden, "residency violation")
+		return
+	}
+	if err := appendRegionPending(entries); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to persist pending entries")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(entries)})
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Retention (계약별 레코드 보존 기한 / 아카이브)
+// ------------------------------------------------------------
+// - Gov 장부에 기록된 이 기관의 계약(ContractData.retention)에서 보존 규칙 조회
+//   · max_age_days : 레코드 생성 시각(timestamp, 없으면 블록 시각) 기준 보존 일수
+//   · action       : archive(보관 전환) | restrict(접근 제한)
+// - 주기 작업(RETENTION_WATCHER_TIME, 기본 3600초)이 만료 레코드를 표시
+//   · ret_<bi>:<ei> => action (장부 자체는 변경하지 않음)
+//   · 만료 표시된 레코드는 기본 /search 결과에서 제외 (?include_expired=true 로 포함)
+// - 새로 만료된 레코드 목록은 아카이브 매니페스트로 저장하고
+//   매니페스트 루트(레코드 leaf 들의 머클 루트)를 "<hos_id>@archive" 로 Gov 체인에 앵커링 (부트노드만)
+// - GET  /retention/manifests : 아카이브 매니페스트 목록
+// - POST /admin/retention     : 보존 규칙 즉시 평가 (비동기 작업, /jobs/{id} 로 확인)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RetentionArchive  = "archive"
+	RetentionRestrict = "restrict"
+
+	retentionMarkPrefix = "ret_"
+	manifestPrefix      = "archive_manifest_"
+	archiveLedgerSuffix = "@archive"
+)
+
+var RetentionWatcherTime = 3600 // 초
+
+// 만료 처리된 레코드
+type ArchivedEntry struct {
+	BlockIndex int    `json:"block_index"`
+	EntryIndex int    `json:"entry_index"`
+	ClinicID   string `json:"clinic_id"`
+	Leaf       string `json:"leaf"`
+	Timestamp  string `json:"timestamp"`
+	Action     string `json:"action"`
+}
+
+// 아카이브 매니페스트 (Root 가 Gov 체인에 앵커링됨)
+type ArchiveManifest struct {
+	Seq       int             `json:"seq"`
+	HosID     string          `json:"hos_id"`
+	Rule      RetentionRule   `json:"rule"`
+	Cutoff    string          `json:"cutoff"`
+	Entries   []ArchivedEntry `json:"entries"`
+	Root      string          `json:"root"`
+	CreatedAt string          `json:"created_at"`
+}
+
+type RetentionResult struct {
+	Rule     *RetentionRule `json:"rule,omitempty"`
+	Scanned  int            `json:"scanned"`
+	Expired  int            `json:"expired"`  // 이번 실행에서 새로 만료 처리된 레코드 수
+	Manifest *int           `json:"manifest"` // 생성된 매니페스트 번호
+	Note     string         `json:"note,omitempty"`
+}
+
+func retentionMarkKey(bi, ei int) []byte {
+	return []byte(fmt.Sprintf("%s%d:%d", retentionMarkPrefix, bi, ei))
+}
+
+// 레코드의 만료 표시 (없으면 "")
+func retentionMark(bi, ei int) string {
+	return retentionMarkFrom(db, bi, ei)
+}
+func retentionMarkFrom(rd dbReader, bi, ei int) string {
+	v, _ := rd.Get(retentionMarkKey(bi, ei), nil)
+	return string(v)
+}
+
+// Gov 장부에 기록된 이 기관 계약의 보존 규칙 조회 (규칙이 없으면 nil)
+func fetchRetentionRule() (*RetentionRule, error) {
+	resp, err := nodeClient.Get(nodeURL(getGovBoot(), "/contracts/search"))
+	if err != nil {
+		return nil, fmt.Errorf("contract lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("contract lookup failed: status %d", resp.StatusCode)
+	}
+	var matches []struct {
+		HosID    string       `json:"hos_id"`
+		Contract ContractData `json:"contract"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&matches); err != nil {
+		return nil, fmt.Errorf("invalid contract response: %w", err)
+	}
+	for _, m := range matches {
+		if m.HosID == selfID() {
+			return m.Contract.Retention, nil
+		}
+	}
+	return nil, nil
+}
+
+// 레코드 생성 시각 (레코드 timestamp 우선, 없거나 해석 불가 시 블록 시각)
+func recordTime(rec ClinicRecord, blk LowerBlock) (time.Time, bool) {
+	for _, ts := range []string{rec.Timestamp, blk.Timestamp} {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// 보존 규칙 평가 작업
+func retentionJob(ctx context.Context, report func(done, total int)) (any, error) {
+	rule, err := fetchRetentionRule()
+	if err != nil {
+		return nil, err
+	}
+	res := RetentionResult{Rule: rule}
+	if rule == nil || rule.MaxAgeDays <= 0 {
+		res.Note = "no retention rule in contract"
+		return res, nil
+	}
+	h, ok := getLatestHeight()
+	if !ok {
+		return res, nil
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -rule.MaxAgeDays)
+
+	// 1) 만료 레코드 수집 (제네시스 제외, 이미 표시된 레코드 제외)
+	expired := []ArchivedEntry{}
+	for i := 1; i <= h; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		b, err := getBlockByIndex(i)
+		if err != nil {
+			return nil, fmt.Errorf("load block_%d: %w", i, err)
+		}
+		for ei, rec := range b.Entries {
+			res.Scanned++
+			if retentionMark(i, ei) != "" {
+				continue
+			}
+			t, ok := recordTime(rec, b)
+			if !ok || !t.Before(cutoff) {
+				continue
+			}
+			leaf := hashClinicRecord(rec)
+			if ei < len(b.LeafHashes) {
+				leaf = b.LeafHashes[ei]
+			}
+			expired = append(expired, ArchivedEntry{
+				BlockIndex: i,
+				EntryIndex: ei,
+				ClinicID:   rec.ClinicID,
+				Leaf:       leaf,
+				Timestamp:  t.UTC().Format(time.RFC3339),
+				Action:     rule.Action,
+			})
+		}
+		report(i, h)
+	}
+	res.Expired = len(expired)
+	if len(expired) == 0 {
+		return res, nil
+	}
+
+	// 2) 만료 표시 + 매니페스트 저장 (한 배치로 기록)
+	seq := 0
+	if s, ok := getMeta("seq_archive_manifest"); ok {
+		seq, _ = strconv.Atoi(s)
+	}
+	seq++
+	leaves := make([]string, len(expired))
+	for i, e := range expired {
+		leaves[i] = e.Leaf
+	}
+	m := ArchiveManifest{
+		Seq:       seq,
+		HosID:     selfID() + archiveLedgerSuffix,
+		Rule:      *rule,
+		Cutoff:    cutoff.Format(time.RFC3339),
+		Entries:   expired,
+		Root:      merkle.Root(leaves),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	batch := new(leveldb.Batch)
+	for _, e := range expired {
+		batch.Put(retentionMarkKey(e.BlockIndex, e.EntryIndex), []byte(e.Action))
+	}
+	batch.Put([]byte(fmt.Sprintf("%s%06d", manifestPrefix, seq)), data)
+	batch.Put([]byte("seq_archive_manifest"), []byte(strconv.Itoa(seq)))
+	if err := countDBError(db.Write(batch, nil)); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	res.Manifest = &seq
+	log.Printf("[RETENTION] %d entries %s (cutoff=%s, manifest=#%d root=%s)", len(expired), rule.Action, m.Cutoff, seq, m.Root[:8])
+
+	// 3) 매니페스트 루트 앵커링 (부트노드만)
+	if isBoot.Load() {
+		if err := enqueueAnchorRoot(m.HosID, m.Root, seq, nil); err != nil {
+			log.Printf("[RETENTION][ERROR] failed to queue manifest anchor: %v", err)
+		} else {
+			kickAnchorQueue()
+		}
+	}
+	return res, nil
+}
+
+// 보존 규칙 주기 평가 (main 에서 실행)
+func startRetentionWatcher() {
+	ticker := time.NewTicker(time.Duration(RetentionWatcherTime) * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := startJob("retention", retentionJob); err != nil {
+			log.Printf("[RETENTION] skipped: %v", err)
+		}
+	}
+}
+
+// GET /retention/manifests
+func handleRetentionManifests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	out := []ArchiveManifest{}
+	iter := db.NewIterator(util.BytesPrefix([]byte(manifestPrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var m ArchiveManifest
+		if err := json.Unmarshal(iter.Value(), &m); err == nil {
+			out = append(out, m)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
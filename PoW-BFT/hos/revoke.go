@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Revocation (레코드 철회 / 툼스톤)
+// ------------------------------------------------------------
+// - 확정된 레코드는 장부에서 지울 수 없으므로 이후 블록에 철회 레코드(툼스톤)를 기록
+//   · 툼스톤 = ClinicRecord{clinic_id, timestamp, revocation{targets, reason}}
+//   · targets 는 철회 대상 레코드의 leaf 해시 (블록 위치가 아닌 내용 기준)
+//   · 일반 레코드와 같이 메모리풀 => PBFT 합의로 확정되고 Gov 앵커링 대상이 됨
+// - 툼스톤은 검색 색인(cid_/pc_/info_/ft_)에 넣지 않고 rev_<leaf> => "bi:ei" 로만 색인
+//   · 분기 교체 시 일반 색인과 같이 되돌려짐 (reorg.go rollbackTo)
+// - /search, /proof 응답에 revoked{reason, revoked_at, block_index, entry_index} 표시
+//   · 레코드와 포함 증명은 그대로 제공 (철회 사실 자체도 장부로 증명 가능)
+// - POST /revoke : {clinic_id, reason, block_index?, entry_index?}
+//   · 위치를 지정하지 않으면 clinic_id 의 확정 레코드 전체 철회
+//   · 이미 철회됐거나 메모리풀에 철회 접수된 레코드는 제외, 대상이 없으면 404 / 모두 제외되면 409
+////////////////////////////////////////////////////////////////////////////////
+
+const revocationPrefix = "rev_"
+
+// 레코드의 철회 상태 (툼스톤 위치)
+type RevocationStatus struct {
+	Reason     string `json:"reason,omitempty"`
+	RevokedAt  string `json:"revoked_at"`
+	BlockIndex int    `json:"block_index"`
+	EntryIndex int    `json:"entry_index"`
+}
+
+type RevokeRequest struct {
+	ClinicID   string `json:"clinic_id"`
+	Reason     string `json:"reason"`
+	BlockIndex *int   `json:"block_index,omitempty"`
+	EntryIndex *int   `json:"entry_index,omitempty"`
+}
+
+func revocationKeys(entry ClinicRecord) []string {
+	keys := make([]string, 0, len(entry.Revocation.Targets))
+	for _, leaf := range entry.Revocation.Targets {
+		keys = append(keys, revocationPrefix+leaf)
+	}
+	return keys
+}
+
+// leaf 레코드의 철회 상태 (철회되지 않았으면 nil)
+func revocationFrom(rd dbReader, leaf string) *RevocationStatus {
+	ptrs := indexPointers(rd, revocationPrefix+leaf)
+	if len(ptrs) == 0 {
+		return nil
+	}
+	p := ptrs[0] // 최초 툼스톤 기준
+	st := &RevocationStatus{BlockIndex: p.BlockIndex, EntryIndex: p.EntryIndex}
+	if blk, err := getBlockByIndexForPointer(rd, p.BlockIndex); err == nil {
+		st.RevokedAt = blk.Timestamp
+		if p.EntryIndex < len(blk.Entries) && blk.Entries[p.EntryIndex].Revocation != nil {
+			st.Reason = blk.Entries[p.EntryIndex].Revocation.Reason
+		}
+	}
+	return st
+}
+
+// 메모리풀에서 확정을 기다리는 툼스톤의 철회 대상
+func pendingRevocationTargets() map[string]bool {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	targets := map[string]bool{}
+	for _, rec := range ch.pending {
+		if rec.Revocation == nil {
+			continue
+		}
+		for _, leaf := range rec.Revocation.Targets {
+			targets[leaf] = true
+		}
+	}
+	return targets
+}
+
+// POST /revoke
+func handleRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req RevokeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid revoke request")
+		return
+	}
+	defer r.Body.Close()
+	req.ClinicID = strings.TrimSpace(req.ClinicID)
+	if req.ClinicID == "" {
+		writeError(w, http.StatusBadRequest, "clinic_id required")
+		return
+	}
+	if (req.BlockIndex == nil) != (req.EntryIndex == nil) {
+		writeError(w, http.StatusBadRequest, "block_index and entry_index must be given together")
+		return
+	}
+
+	// 대상 레코드 leaf 수집 (철회되지 않았고 철회 접수 중도 아닌 것만)
+	inflight := pendingRevocationTargets()
+	var targets []string
+	found := 0
+	err := withReadSnapshot(func(rd dbReader) error {
+		for _, p := range indexPointers(rd, "cid_"+req.ClinicID) {
+			if req.BlockIndex != nil && (p.BlockIndex != *req.BlockIndex || p.EntryIndex != *req.EntryIndex) {
+				continue
+			}
+			blk, err := getBlockByIndexForPointer(rd, p.BlockIndex)
+			if err != nil {
+				return err
+			}
+			if p.EntryIndex >= len(blk.LeafHashes) {
+				continue
+			}
+			found++
+			leaf := blk.LeafHashes[p.EntryIndex]
+			if !inflight[leaf] && revocationFrom(rd, leaf) == nil {
+				targets = append(targets, leaf)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if found == 0 {
+		writeError(w, http.StatusNotFound, "no finalized record for clinic_id")
+		return
+	}
+	if len(targets) == 0 {
+		writeError(w, http.StatusConflict, "record already revoked")
+		return
+	}
+
+	tomb := ClinicRecord{
+		ClinicID:   req.ClinicID,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339Nano),
+		Revocation: &RevocationRecord{Targets: targets, Reason: req.Reason},
+	}
+	if _, _, status, err := submitRecords([]ClinicRecord{tomb}); err != nil || status != http.StatusOK {
+		msg := "revocation rejected"
+		if err != nil {
+			msg = err.Error()
+		}
+		writeError(w, status, msg)
+		return
+	}
+	log.Printf("[REVOKE] clinic_id=%s targets=%d reason=%q", req.ClinicID, len(targets), req.Reason)
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"status":    "Revocation Submitted",
+		"clinic_id": req.ClinicID,
+		"targets":   targets,
+	})
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strconv"
@@ -10,6 +12,8 @@ import (
 	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -26,7 +30,7 @@ var db *leveldb.DB
 
 // ---- 내부 메타키 헬퍼 ---------------------------------------------------------
 func putMeta(key, val string) error {
-	return db.Put([]byte(key), []byte(val), nil)
+	return countDBError(db.Put([]byte(key), []byte(val), nil))
 }
 func getMeta(key string) (string, bool) {
 	v, err := db.Get([]byte(key), nil)
@@ -37,8 +41,11 @@ func getMeta(key string) (string, bool) {
 }
 
 func getLatestHeight() (int, bool) {
-	if s, ok := getMeta("height_latest"); ok {
-		h, err := strconv.Atoi(s)
+	return getLatestHeightFrom(db)
+}
+func getLatestHeightFrom(rd dbReader) (int, bool) {
+	if v, err := rd.Get([]byte("height_latest"), nil); err == nil {
+		h, err := strconv.Atoi(string(v))
 		if err == nil {
 			return h, true
 		}
@@ -57,6 +64,35 @@ func initDB(path string) {
 		log.Fatal(err)
 	}
 	log.Println("[DB] LevelDB initialized at", path)
+	migrateBlockKeys()
+}
+
+// 블록 번호 키 : 고정폭("block_%012d")으로 저장하여 사전순 = 번호순 범위 조회 가능
+func blockKey(index int) []byte {
+	return []byte(fmt.Sprintf("block_%012d", index))
+}
+
+// 기존 가변폭 키("block_<Index>")로 저장된 블록을 고정폭 키로 이전
+func migrateBlockKeys() {
+	batch := new(leveldb.Batch)
+	iter := db.NewIterator(util.BytesPrefix([]byte("block_")), nil)
+	for iter.Next() {
+		key := string(iter.Key())
+		idx, err := strconv.Atoi(strings.TrimPrefix(key, "block_"))
+		if err != nil || key == string(blockKey(idx)) {
+			continue
+		}
+		batch.Delete([]byte(key))
+		batch.Put(blockKey(idx), append([]byte(nil), iter.Value()...))
+	}
+	iter.Release()
+	if batch.Len() == 0 {
+		return
+	}
+	if err := db.Write(batch, nil); err != nil {
+		log.Fatalf("[DB] block key migration failed: %v", err)
+	}
+	log.Printf("[DB] Migrated %d block keys to fixed-width encoding", batch.Len()/2)
 }
 
 // LevelDB 닫기
@@ -67,47 +103,149 @@ func closeDB() {
 	}
 }
 
+////////////////////////////////////////////////////////////////////////////////
+// 장부 트랜잭션 (ledgerTxn)
+//  - 블록 반영/분기 교체의 모든 쓰기를 leveldb.Batch 하나에 모아 한 번에 기록
+//  - 트랜잭션 안의 조회는 아직 기록되지 않은 쓰기를 먼저 반영해서 읽음
+//    (분기 교체 시 되돌린 색인/커밋먼트를 새 분기 블록이 이어서 사용)
+////////////////////////////////////////////////////////////////////////////////
+
+// 블록 조회에 필요한 최소 읽기 인터페이스 (*leveldb.DB, *leveldb.Snapshot, *ledgerTxn)
+type kvReader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+}
+
+// 쓰기 대상 (*leveldb.Batch, *ledgerTxn)
+type kvWriter interface {
+	Put(key, value []byte)
+	Delete(key []byte)
+}
+
+type ledgerTxn struct {
+	batch *leveldb.Batch
+	vals  map[string][]byte // 이 트랜잭션에서 쓴 값 (nil = 삭제)
+}
+
+func newLedgerTxn() *ledgerTxn {
+	return &ledgerTxn{batch: new(leveldb.Batch), vals: map[string][]byte{}}
+}
+
+func (t *ledgerTxn) Get(key []byte, _ *opt.ReadOptions) ([]byte, error) {
+	if v, ok := t.vals[string(key)]; ok {
+		if v == nil {
+			return nil, leveldb.ErrNotFound
+		}
+		return v, nil
+	}
+	return db.Get(key, nil)
+}
+
+func (t *ledgerTxn) Put(key, val []byte) {
+	t.batch.Put(key, val)
+	t.vals[string(key)] = append([]byte{}, val...)
+}
+
+func (t *ledgerTxn) Delete(key []byte) {
+	t.batch.Delete(key)
+	t.vals[string(key)] = nil
+}
+
+// prefix 로 시작하는 키 순회 (기록된 값 + 이 트랜잭션의 쓰기, 삭제된 키 제외)
+func (t *ledgerTxn) scanPrefix(prefix string, fn func(key, val []byte)) {
+	iter := db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	for iter.Next() {
+		if _, ok := t.vals[string(iter.Key())]; !ok {
+			fn(iter.Key(), iter.Value())
+		}
+	}
+	iter.Release()
+	for k, v := range t.vals {
+		if v != nil && strings.HasPrefix(k, prefix) {
+			fn([]byte(k), v)
+		}
+	}
+}
+
+func (t *ledgerTxn) commit() error {
+	return countDBError(db.Write(t.batch, nil))
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // 블록 저장/조회
 ////////////////////////////////////////////////////////////////////////////////
 
-// LowerBlock 전체를 JSON으로 저장
-// - Key1: "block_<Index>"     => LowerBlock JSON (번호 기반 접근)
+// 블록 본문 + 검색 인덱스 + 최신 높이를 하나의 Batch로 원자적 반영
+// - 중간에 프로세스가 죽어도 "본문만 있고 인덱스가 없는" 블록이 남지 않음
+func commitBlock(block LowerBlock) error {
+	tx := newLedgerTxn()
+	if err := stageBlock(tx, block); err != nil {
+		return err
+	}
+	if err := tx.commit(); err != nil {
+		return err
+	}
+	invalidateValidatorCache() // 검증자 변경/증거가 반영됐을 수 있음 (validators.go)
+	log.Printf("[DB] Block #%d committed (Hash=%s, %d keys)\n", block.Index, block.BlockHash, tx.batch.Len())
+	afterCommit(block)
+	return nil
+}
+
+// 블록 하나의 쓰기를 트랜잭션에 추가 (기록은 호출자가 tx.commit)
+func stageBlock(tx *ledgerTxn, block LowerBlock) error {
+	if err := saveBlockToBatch(tx, block); err != nil {
+		return err
+	}
+	updateIndicesForBlock(tx, block)
+	markSeen(tx, block.LeafHashes, block.Index) // 확정 레코드 재접수 차단 (dedup.go)
+	tx.Put([]byte("height_latest"), []byte(strconv.Itoa(block.Index)))
+	return appendCommitment(tx, block)
+}
+
+// 블록 기록 이후 처리 (블록 로그, 이벤트, 체크포인트)
+func afterCommit(block LowerBlock) {
+	if replaying {
+		return
+	}
+	appendBlockLog(block)
+	publishBlockFinalized(block)
+	maybeCheckpoint(block.Index) // 주기적 상태 체크포인트 (checkpoint.go)
+}
+
+// LowerBlock 전체를 JSON으로 Batch에 기록
+// - Key1: "block_<Index(12자리)>" => LowerBlock JSON (번호 기반 접근, 범위 조회)
 // - Key2: "hash_<BlockHash>"  => LowerBlock JSON (해시 기반 접근)
+// - Key3: "mroot_<MerkleRoot>" => 블록 번호 (Gov 앵커 대조용 루트 조회)
 // 주: 키 형식은 기존 코드와의 호환을 위해 유지
-func saveBlockToDB(block LowerBlock) error {
+func saveBlockToBatch(batch kvWriter, block LowerBlock) error {
 	data, err := json.Marshal(block)
 	if err != nil {
 		return err
 	}
 
 	// 블록 번호 기반 저장
-	keyByIndex := fmt.Sprintf("block_%d", block.Index)
-	if err := db.Put([]byte(keyByIndex), data, nil); err != nil {
-		return err
-	}
+	batch.Put(blockKey(block.Index), data)
 
 	// 블록 해시 기반 저장
-	keyByHash := fmt.Sprintf("hash_%s", block.BlockHash)
-	if err := db.Put([]byte(keyByHash), data, nil); err != nil {
-		return err
+	batch.Put([]byte(fmt.Sprintf("hash_%s", block.BlockHash)), data)
+
+	// 머클 루트 기반 색인
+	if block.MerkleRoot != "" {
+		batch.Put(rootIndexKey(block.MerkleRoot), []byte(strconv.Itoa(block.Index)))
 	}
 
 	// 최신 루트 캐시(선택)
-	if err := db.Put([]byte("root_latest"), []byte(block.MerkleRoot), nil); err != nil {
-		return err
-	}
-	log.Printf("[DB] Block #%d saved (Hash=%s)\n", block.Index, block.BlockHash)
-	appendBlockLog(block)
+	batch.Put([]byte("root_latest"), []byte(block.MerkleRoot))
 	return nil
 }
 
 // 인덱스로 블록 조회
 func getBlockByIndex(index int) (LowerBlock, error) {
-	key := fmt.Sprintf("block_%d", index)
-	data, err := db.Get([]byte(key), nil)
+	return getBlockByIndexFrom(db, index)
+}
+func getBlockByIndexFrom(rd kvReader, index int) (LowerBlock, error) {
+	data, err := rd.Get(blockKey(index), nil)
 	if err != nil {
-		return LowerBlock{}, err
+		return LowerBlock{}, countDBError(err)
 	}
 	var block LowerBlock
 	if err := json.Unmarshal(data, &block); err != nil {
@@ -130,9 +268,46 @@ func getBlockByHash(hash string) (LowerBlock, error) {
 	return block, nil
 }
 
+func rootIndexKey(root string) []byte {
+	return []byte("mroot_" + root)
+}
+
+// 머클 루트로 블록 조회 (색인 우선, 색인 이전 블록/체크포인트로 받은 블록은 전체 스캔)
+func getBlockByRoot(root string) (LowerBlock, error) {
+	if v, err := db.Get(rootIndexKey(root), nil); err == nil {
+		if idx, err := strconv.Atoi(string(v)); err == nil {
+			if b, err := getBlockByIndex(idx); err == nil && b.MerkleRoot == root {
+				return b, nil
+			}
+		}
+	}
+	var (
+		found LowerBlock
+		ok    bool
+	)
+	err := scanBlocks(0, -1, func(raw []byte) error {
+		var b LowerBlock
+		if err := json.Unmarshal(raw, &b); err == nil && b.MerkleRoot == root {
+			found, ok = b, true
+			return errStopScan
+		}
+		return nil
+	})
+	if err != nil {
+		return LowerBlock{}, err
+	}
+	if !ok {
+		return LowerBlock{}, leveldb.ErrNotFound
+	}
+	return found, nil
+}
+
 // 최신 루트 캐시 조회(없으면 빈 문자열)
 func getLatestRoot() string {
-	if v, err := db.Get([]byte("root_latest"), nil); err == nil {
+	return getLatestRootFrom(db)
+}
+func getLatestRootFrom(rd dbReader) string {
+	if v, err := rd.Get([]byte("root_latest"), nil); err == nil {
 		return string(v)
 	}
 	return ""
@@ -140,48 +315,101 @@ func getLatestRoot() string {
 
 ////////////////////////////////////////////////////////////////////////////////
 // 해시테이블(검색 인덱스) 업데이트
-//  - 블록 단위로 cid/pc/info 색인을 "<blockIndex>:<entryIndex>" 포인터로 저장
+//  - 블록 단위로 cid/pc/info 색인을 "<blockIndex>:<entryIndex>" 포인터 목록으로 저장
+//  - 같은 키가 여러 레코드에 나오면 덮어쓰지 않고 "bi:ei,bi:ei,..." 로 이어 붙임
+//    (기존 단일 포인터 값도 원소 1개짜리 목록으로 그대로 읽힘)
+//  - cid 색인은 목록 순서가 곧 레코드 버전 (cidv_<ClinicID>_v<N> => N 번째 포인터, history.go)
 ////////////////////////////////////////////////////////////////////////////////
 
-func updateIndicesForBlock(block LowerBlock) error {
+func updateIndicesForBlock(tx *ledgerTxn, block LowerBlock) {
 	// 포인터 문자열: "blockIndex:entryIndex"
-	ptr := func(bi, ei int) []byte { return []byte(fmt.Sprintf("%d:%d", bi, ei)) }
+	ptr := func(bi, ei int) string { return fmt.Sprintf("%d:%d", bi, ei) }
+
+	// 이 블록에서 추가할 포인터 (키 => 포인터 목록, 블록 내 순서 유지)
+	added := map[string][]string{}
+	keys := []string{}
+	add := func(key, p string) {
+		if _, ok := added[key]; !ok {
+			keys = append(keys, key)
+		}
+		added[key] = append(added[key], p)
+	}
 
 	for ei, entry := range block.Entries {
-		// 1) ClinicID 색인: "cid_<ClinicID>" -> "bi:ei"
-		if entry.ClinicID != "" {
-			keyByCID := fmt.Sprintf("cid_%s", entry.ClinicID)
-			if err := db.Put([]byte(keyByCID), ptr(block.Index, ei), nil); err != nil {
-				return err
-			}
+		for _, key := range indexKeysForEntry(entry) {
+			add(key, ptr(block.Index, ei))
 		}
+	}
+	putValidatorChanges(tx, block) // 검증자 변경 내역 (validators.go)
+	putEvidence(tx, block)         // 부정 행위 증거 (evidence.go)
 
-		// 2) PrescCode 색인: "pc_<PrescCode>" -> "bi:ei"
-		if entry.PrescCode != "" {
-			keyByPC := fmt.Sprintf("pc_%s", entry.PrescCode)
-			if err := db.Put([]byte(keyByPC), ptr(block.Index, ei), nil); err != nil {
-				return err
+	// 기존 포인터 목록 뒤에 이어 붙임 (재색인 시 중복 포인터는 생략)
+	for _, key := range keys {
+		list := []string{}
+		if v, err := tx.Get([]byte(key), nil); err == nil && len(v) > 0 {
+			list = strings.Split(string(v), ",")
+		}
+		seen := make(map[string]bool, len(list))
+		for _, p := range list {
+			seen[p] = true
+		}
+		for _, p := range added[key] {
+			if !seen[p] {
+				seen[p] = true
+				list = append(list, p)
 			}
 		}
+		tx.Put([]byte(key), []byte(strings.Join(list, ",")))
+		if id, ok := strings.CutPrefix(key, "cid_"); ok {
+			putVersionKeys(tx, id, list, added[key]) // history.go
+		}
+	}
+}
 
-		// 3) Info 키워드 색인(간단 버전)
-		//    - 점 표기(dotted key)나 부분일치는 API 레이어에서 확장 가능
-		//    - 여기서는 cCode 같은 문자열을 소문자로 normalize해서 저장
-		for k, v := range entry.Info {
-			strVal := strings.TrimSpace(fmt.Sprintf("%v", v))
-			if strVal == "" {
-				continue
-			}
-			key := fmt.Sprintf("info_%s_%s", k, strings.ToLower(strVal))
-			if err := db.Put([]byte(key), ptr(block.Index, ei), nil); err != nil {
-				return err
-			}
+// 레코드 하나가 등록되는 색인 키 목록 (포크 정리 시 포인터 제거에도 사용, reorg.go)
+func indexKeysForEntry(entry ClinicRecord) []string {
+	// 툼스톤은 검색 대상이 아니므로 철회 색인만 (revoke.go)
+	if entry.Revocation != nil {
+		return revocationKeys(entry)
+	}
+	// 검증자 변경 레코드는 색인하지 않음 (valset_ 에 별도 저장, validators.go)
+	if entry.Validator != nil {
+		return nil
+	}
+	// 증거 레코드도 색인하지 않음 (evid_ 에 별도 저장, evidence.go)
+	if entry.Evidence != nil {
+		return nil
+	}
+	keys := []string{}
+
+	// 1) ClinicID 색인: "cid_<ClinicID>" -> "bi:ei,..."
+	if entry.ClinicID != "" {
+		keys = append(keys, fmt.Sprintf("cid_%s", entry.ClinicID))
+	}
+
+	// 환자 색인: "pid_<PatientID>" -> "bi:ei,..." (/patient/<id>/records 전용, patient.go)
+	if entry.PatientID != "" {
+		keys = append(keys, patientPrefix+entry.PatientID)
+	}
+
+	// 2) PrescCode 색인: "pc_<PrescCode>" -> "bi:ei,..."
+	if entry.PrescCode != "" {
+		keys = append(keys, fmt.Sprintf("pc_%s", entry.PrescCode))
+	}
+
+	// 3) Info 키워드 색인(간단 버전)
+	//    - 점 표기(dotted key)나 부분일치는 API 레이어에서 확장 가능
+	//    - 여기서는 cCode 같은 문자열을 소문자로 normalize해서 저장
+	for k, v := range entry.Info {
+		strVal := strings.TrimSpace(fmt.Sprintf("%v", v))
+		if strVal == "" {
+			continue
 		}
+		keys = append(keys, fmt.Sprintf("info_%s_%s", k, strings.ToLower(strVal)))
 	}
 
-	log.Printf("[DB] Indices updated for Block #%d (%d entries)\n",
-		block.Index, len(block.Entries))
-	return nil
+	// 4) Info 전문(토큰) 색인: "ft_<token>" -> "bi:ei,..." (fulltext.go)
+	return append(keys, fulltextKeys(entry)...)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -199,29 +427,40 @@ func parsePtr(s string) (int, int, bool) {
 	return bi, ei, err1 == nil && err2 == nil
 }
 
-// 키워드로 블록 조회(단순 버전)
-//   - keyword가 ClinicID, PrescCode, 또는 Info에 매칭되면
-//     해당 포인터("bi:ei")를 통해 블록을 찾아 반환
-//   - 여러 매칭이 가능할 수 있으나, 여기서는 최초 매칭 1개만 반환(간단화)
-func getBlockByClinic(keyword string) (LowerBlock, error) {
-	// ClinicID 색인 조회
-	if v, err := db.Get([]byte("cid_"+keyword), nil); err == nil {
-		if bi, _, ok := parsePtr(string(v)); ok {
-			return getBlockByIndex(bi)
+// 레코드 위치 (블록 번호, 엔트리 번호)
+type RecordPtr struct {
+	BlockIndex int
+	EntryIndex int
+}
+
+// parsePtrList : "bi:ei,bi:ei,..." => 포인터 목록 (형식이 깨진 항목은 생략)
+func parsePtrList(s string) []RecordPtr {
+	out := []RecordPtr{}
+	for _, p := range strings.Split(s, ",") {
+		if bi, ei, ok := parsePtr(p); ok {
+			out = append(out, RecordPtr{bi, ei})
 		}
 	}
+	return out
+}
 
-	// PrescCode 색인 조회
-	if v, err := db.Get([]byte("pc_"+keyword), nil); err == nil {
-		if bi, _, ok := parsePtr(string(v)); ok {
-			return getBlockByIndex(bi)
-		}
+// 색인 키의 포인터 목록 조회 (없으면 빈 목록)
+func indexPointers(rd dbReader, key string) []RecordPtr {
+	v, err := rd.Get([]byte(key), nil)
+	if err != nil {
+		return []RecordPtr{}
 	}
+	return parsePtrList(string(v))
+}
 
-	// Info(title 등) 색인 조회 (소문자 normalize)
-	if v, err := db.Get([]byte("info_cCode_"+strings.ToLower(keyword)), nil); err == nil {
-		if bi, _, ok := parsePtr(string(v)); ok {
-			return getBlockByIndex(bi)
+// 키워드로 블록 조회(단순 버전)
+//   - keyword가 ClinicID, PrescCode, 또는 Info에 매칭되면
+//     해당 포인터 목록의 첫 포인터를 통해 블록을 찾아 반환
+//   - 모든 매칭이 필요하면 searchClinic 사용
+func getBlockByClinic(keyword string) (LowerBlock, error) {
+	for _, key := range []string{"cid_" + keyword, "pc_" + keyword, "info_cCode_" + strings.ToLower(keyword)} {
+		if ptrs := indexPointers(db, key); len(ptrs) > 0 {
+			return getBlockByIndex(ptrs[0].BlockIndex)
 		}
 	}
 
@@ -234,56 +473,162 @@ func getBlockByClinic(keyword string) (LowerBlock, error) {
 
 // 전체 블록 조회
 func listAllBlocks() ([]LowerBlock, error) {
-	h, ok := getLatestHeight()
-	if !ok {
-		// 제네시스만 있을 수도 있으니 0만 확인
-		b0, err := getBlockByIndex(0)
-		if err != nil {
-			return nil, fmt.Errorf("no chain: %w", err)
-		}
-		return []LowerBlock{b0}, nil
-	}
-	out := make([]LowerBlock, 0, h+1)
-	for i := 0; i <= h; i++ {
-		b, err := getBlockByIndex(i)
-		if err != nil {
-			return nil, fmt.Errorf("load block_%d: %w", i, err)
+	out := []LowerBlock{}
+	err := scanBlocks(0, -1, func(raw []byte) error {
+		var b LowerBlock
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return err
 		}
 		out = append(out, b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no chain")
 	}
 	return out, nil
 }
 
-// offset에서 최대 limit개 반환, total(=height+1)도 함께 반환
-func listBlocksPaginated(offset, limit int) ([]LowerBlock, int, error) {
-	if offset < 0 || limit <= 0 {
-		return nil, 0, fmt.Errorf("invalid offset/limit")
+// 블록 번호 [from, to] 구간을 LevelDB Iterator로 순서대로 순회 (to < 0 이면 끝까지)
+// - fn 에는 저장된 블록 JSON 원문이 전달됨 (Iterator 내부 버퍼이므로 보관 시 복사 필요)
+// - fn 이 errStopScan 을 반환하면 오류 없이 순회 중단
+var errStopScan = errors.New("stop scan")
+
+func scanBlocks(from, to int, fn func(raw []byte) error) error {
+	return scanBlocksFrom(db, from, to, fn)
+}
+func scanBlocksFrom(rd dbReader, from, to int, fn func(raw []byte) error) error {
+	r := &util.Range{Start: blockKey(from), Limit: []byte("block`")} // '`' = '_' 다음 문자
+	if to >= 0 {
+		r.Limit = blockKey(to + 1)
 	}
-	h, ok := getLatestHeight()
+	iter := rd.NewIterator(r, nil)
+	defer iter.Release()
+	for iter.Next() {
+		if err := fn(iter.Value()); err != nil {
+			if errors.Is(err, errStopScan) {
+				return nil
+			}
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// 전체 블록 수(total = height+1) 조회
+func blockTotal() (int, error) {
+	return blockTotalFrom(db)
+}
+func blockTotalFrom(rd dbReader) (int, error) {
+	h, ok := getLatestHeightFrom(rd)
 	if !ok {
 		// 제네시스만 있는지 확인
-		if _, err := getBlockByIndex(0); err != nil {
-			return nil, 0, fmt.Errorf("no chain: %w", err)
+		if _, err := getBlockByIndexFrom(rd, 0); err != nil {
+			return 0, fmt.Errorf("no chain: %w", err)
 		}
 		h = 0
 	}
-	total := h + 1
-	if offset >= total {
-		return []LowerBlock{}, total, nil
+	return h + 1, nil
+}
+
+// 페이지네이션 조회 : offset에서 최대 limit개 반환, total(=height+1)도 함께 반환
+// - total 과 블록 목록은 같은 스냅샷에서 읽음 (snapshot.go)
+func listBlocksPaginated(offset, limit int) ([]LowerBlock, int, error) {
+	if offset < 0 || limit <= 0 {
+		return nil, 0, fmt.Errorf("invalid offset/limit")
 	}
-	end := offset + limit - 1
-	if end > h {
-		end = h
+	out := []LowerBlock{}
+	total := 0
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		if total, err = blockTotalFrom(rd); err != nil || offset >= total {
+			return err
+		}
+		return scanBlocksFrom(rd, offset, min(offset+limit, total)-1, func(raw []byte) error {
+			var b LowerBlock
+			if err := json.Unmarshal(raw, &b); err != nil {
+				return err
+			}
+			out = append(out, b)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, total, err
 	}
-	out := make([]LowerBlock, 0, end-offset+1)
-	for i := offset; i <= end; i++ {
-		b, err := getBlockByIndex(i)
+	return out, total, nil
+}
+
+const MaxRecentBlocks = 100 // /blocks/recent 한 번에 반환하는 최대 블록 수
+
+// 최신 블록부터 최대 count개를 역순으로 조회
+// - height 메타데이터에서 바로 시작하므로 offset 0부터 순회할 필요 없음
+func listRecentBlocks(count int) ([]LowerBlock, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid count")
+	}
+	var out []LowerBlock
+	err := withReadSnapshot(func(rd dbReader) error {
+		total, err := blockTotalFrom(rd)
 		if err != nil {
-			return nil, total, fmt.Errorf("load block_%d: %w", i, err)
+			return err
 		}
-		out = append(out, b)
+		out = make([]LowerBlock, 0, min(count, total))
+		for i := total - 1; i >= 0 && len(out) < count; i-- {
+			b, err := getBlockByIndexFrom(rd, i)
+			if err != nil {
+				return err
+			}
+			out = append(out, b)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return out, total, nil
+	return out, nil
+}
+
+// /blocks 응답 스트리밍 : 저장된 블록 JSON을 디코딩 없이 그대로 이어 붙여 전송
+// - total 과 블록 범위는 호출자가 넘긴 같은 스냅샷(rd)에서 읽음
+// - 블록 JSON 합이 BlocksPageBytes 를 넘으면 그 앞에서 중단 (최소 1블록, blocktransfer.go)
+func streamBlocksPage(w io.Writer, rd dbReader, offset, limit int) error {
+	if offset < 0 || limit <= 0 {
+		return fmt.Errorf("invalid offset/limit")
+	}
+	total, err := blockTotalFrom(rd)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, `{"total":%d,"offset":%d,"limit":%d,"items":[`, total, offset, limit); err != nil {
+		return err
+	}
+	if offset >= total {
+		_, err = w.Write([]byte("]}\n"))
+		return err
+	}
+	first, sent := true, 0
+	err = scanBlocksFrom(rd, offset, min(offset+limit, total)-1, func(raw []byte) error {
+		if !first {
+			if sent+len(raw) > BlocksPageBytes {
+				return errStopScan
+			}
+			if _, err := w.Write([]byte{','}); err != nil {
+				return err
+			}
+		}
+		first = false
+		sent += len(raw)
+		_, err := w.Write(raw)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte("]}\n"))
+	return err
 }
 
 // 현재 노드의 Hos 식별자 반환 (메타데이터에서 읽기)
@@ -330,32 +675,76 @@ func appendBlockLog(block LowerBlock) {
 	}
 }
 
-// 로컬 체인을 완전히 초기화하고 제네시스 블록만 재생성
-func resetLocalDB() error {
-	chainMu.Lock()
-	defer chainMu.Unlock()
+////////////////////////////////////////////////////////////////////////////////
+// 메모리풀(pending) 영속화
+//  - appendPending 시점에 "pending_<seq>" 키로 선기록(write-ahead), seen-set 도 같은 배치로 기록
+//  - 블록 확정(onBlockReceived) 시 해당 블록에 포함된 레코드만 삭제
+//  - 재시작 시 남아있는 레코드를 메모리풀로 복원
+////////////////////////////////////////////////////////////////////////////////
 
-	log.Printf("[CHAIN] Local chain RESET in progress...")
+const pendingPrefix = "pending_"
 
-	// LevelDB 전체 삭제
-	iter := db.NewIterator(nil, nil)
-	for iter.Next() {
-		key := iter.Key()
-		if err := db.Delete(key, nil); err != nil {
-			iter.Release()
-			return fmt.Errorf("failed to delete key %s: %v", string(key), err)
+// 메모리풀에 추가된 레코드를 순번 키로 LevelDB에 선기록
+func savePendingToDB(entries []ClinicRecord) error {
+	seq := 0
+	if s, ok := getMeta("seq_pending"); ok {
+		seq, _ = strconv.Atoi(s)
+	}
+
+	batch := new(leveldb.Batch)
+	for _, rec := range entries {
+		data, err := json.Marshal(rec)
+		if err != nil {
+			return err
 		}
+		seq++
+		batch.Put([]byte(fmt.Sprintf("%s%020d", pendingPrefix, seq)), data)
 	}
-	iter.Release()
-	if err := iter.Error(); err != nil {
-		return fmt.Errorf("iterator error during db clear: %v", err)
+	markSeen(batch, recordHashes(entries), -1)
+	batch.Put([]byte("seq_pending"), []byte(strconv.Itoa(seq)))
+	return countDBError(db.Write(batch, nil))
+}
+
+// LevelDB에 남아있는 메모리풀 레코드를 순번대로 조회
+func loadPendingFromDB() ([]ClinicRecord, error) {
+	out := []ClinicRecord{}
+	iter := db.NewIterator(util.BytesPrefix([]byte(pendingPrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var rec ClinicRecord
+		if err := json.Unmarshal(iter.Value(), &rec); err != nil {
+			log.Printf("[DB][PENDING] skip broken entry %s: %v", string(iter.Key()), err)
+			continue
+		}
+		out = append(out, rec)
 	}
+	return out, iter.Error()
+}
 
-	// 로컬 height 초기화
-	if err := setLatestHeight(-1); err != nil {
-		return fmt.Errorf("failed to reset height: %v", err)
+// 확정된 블록의 leaf hash와 일치하는 메모리풀 레코드를 LevelDB에서 삭제
+func clearPendingFromDB(leafHashes []string) (int, error) {
+	if len(leafHashes) == 0 {
+		return 0, nil
+	}
+	finalized := make(map[string]bool, len(leafHashes))
+	for _, h := range leafHashes {
+		finalized[h] = true
 	}
 
-	log.Printf("[CHAIN] Local chain RESET complete ")
-	return nil
+	batch := new(leveldb.Batch)
+	iter := db.NewIterator(util.BytesPrefix([]byte(pendingPrefix)), nil)
+	for iter.Next() {
+		var rec ClinicRecord
+		if err := json.Unmarshal(iter.Value(), &rec); err != nil {
+			continue
+		}
+		if finalized[hashClinicRecord(rec)] {
+			batch.Delete(append([]byte{}, iter.Key()...))
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+	return batch.Len(), countDBError(db.Write(batch, nil))
 }
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Clock Sync (시계 오차 측정 및 차단)
+// ------------------------------------------------------------
+// - 블록 타임스탬프는 제안 노드의 로컬 시계 => 시계가 틀어진 노드는 블록 시각 순서를 깨뜨림
+// - 시작 시 NTP 오프셋 측정 (SNTP, NTP_SERVERS 쉼표 구분, 기본 NTPServerDefault, "off" 면 생략)
+//   · 응답한 서버 오프셋의 중앙값 사용, 모두 실패하면 측정 없이 경고만 (폐쇄망 등)
+// - 피어 시계 : /status 응답의 time 과 요청 왕복 중간 시각의 차이 (probeStatus 마다 갱신)
+//   · 피어 과반(2개 이상)이 CLOCK_SKEW_MAX 넘게 어긋나면 이 노드 시계가 틀어진 것으로 판단
+//   · 일부 피어만 어긋나면 해당 피어 시계 문제로 보고 경고만
+// - CLOCK_SKEW_MAX (기본 ClockSkewDefault) 를 넘을 때
+//   · CLOCK_SKEW_MODE=warn (기본) : 경고 로그 + chain_clock_offset_seconds 메트릭
+//   · CLOCK_SKEW_MODE=refuse : 시작 시 NTP 오프셋 초과면 종료, 실행 중 피어 판정 초과면 /readyz 503
+//     (준비되지 않은 노드는 블록을 제안하지 않음 : health.go)
+// - 제안 블록 타임스탬프 검사 (/bft/start, 모드와 무관)
+//   · 직전 블록보다 이르면 거부, 로컬 시각보다 CLOCK_SKEW_MAX 이상 앞서면 거부
+//   · 제안 노드는 max(현재 시각, 직전 블록 시각) 을 타임스탬프로 사용
+// - GET /clock : NTP 오프셋, 피어별 오프셋/왕복 시간, 판정 결과
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	NTPServerDefault = "pool.ntp.org"
+	NTPTimeout       = 2 * time.Second
+	ClockSkewDefault = 5 * time.Second
+	ClockPeerMaxAge  = 5 * time.Minute // 이보다 오래된 피어 측정값은 판정에서 제외
+	ClockSkewWarn    = "warn"
+	ClockSkewRefuse  = "refuse"
+
+	ntpEpochOffset = 2208988800 // 1900-01-01 => 1970-01-01 (초)
+)
+
+var (
+	clockSkewMax  = ClockSkewDefault
+	clockSkewMode = ClockSkewWarn
+
+	clockMu      sync.Mutex
+	ntpOffset    time.Duration
+	ntpMeasured  bool
+	ntpServer    string
+	ntpError     string
+	ntpCheckedAt time.Time
+	peerClocks   = make(map[string]peerClock) // 피어 주소 => 마지막 측정
+	clockWarned  = make(map[string]bool)      // 경고 중인 피어 (상태가 바뀔 때만 로그)
+	selfWarned   bool                         // 이 노드 시계 경고 중
+)
+
+type peerClock struct {
+	offset time.Duration
+	rtt    time.Duration
+	at     time.Time
+}
+
+// 피어별 측정값 (GET /clock)
+type PeerClock struct {
+	Addr          string  `json:"addr"`
+	OffsetSeconds float64 `json:"offset_seconds"` // 피어 시계 - 이 노드 시계
+	RTTSeconds    float64 `json:"rtt_seconds"`
+	CheckedAt     string  `json:"checked_at"`
+	Skewed        bool    `json:"skewed"`
+}
+
+type ClockReport struct {
+	Mode           string      `json:"mode"`
+	MaxSkewSeconds float64     `json:"max_skew_seconds"`
+	NTPServer      string      `json:"ntp_server,omitempty"`
+	NTPOffset      *float64    `json:"ntp_offset_seconds,omitempty"` // 측정 실패/생략 시 없음
+	NTPError       string      `json:"ntp_error,omitempty"`
+	NTPCheckedAt   string      `json:"ntp_checked_at,omitempty"`
+	Peers          []PeerClock `json:"peers"`
+	Skewed         bool        `json:"skewed"`
+	Reason         string      `json:"reason,omitempty"`
+}
+
+// 설정 해석 + 시작 시 NTP 측정 (main 에서 호출, refuse 모드에서 오프셋 초과면 오류)
+func initClockSync(servers, maxSkew, mode string) error {
+	if maxSkew != "" {
+		d, err := time.ParseDuration(maxSkew)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("CLOCK_SKEW_MAX must be a positive duration (e.g. 5s): %q", maxSkew)
+		}
+		clockSkewMax = d
+	}
+	if mode != ClockSkewWarn && mode != ClockSkewRefuse {
+		return fmt.Errorf("CLOCK_SKEW_MODE must be %s or %s", ClockSkewWarn, ClockSkewRefuse)
+	}
+	clockSkewMode = mode
+	if strings.TrimSpace(servers) == "off" {
+		return nil
+	}
+	measureNTP(servers)
+
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	switch {
+	case !ntpMeasured:
+		log.Printf("[CLOCK][WARN] NTP offset not measured (%s), relying on peer clocks", ntpError)
+	case absDuration(ntpOffset) > clockSkewMax && clockSkewMode == ClockSkewRefuse:
+		return fmt.Errorf("local clock is %s off NTP (%s), max %s", ntpOffset, ntpServer, clockSkewMax)
+	case absDuration(ntpOffset) > clockSkewMax:
+		log.Printf("[CLOCK][WARN] local clock is %s off NTP (%s), max %s", ntpOffset, ntpServer, clockSkewMax)
+	default:
+		log.Printf("[CLOCK] NTP offset %s (%s)", ntpOffset, ntpServer)
+	}
+	return nil
+}
+
+// 설정된 서버들에 질의해 오프셋 중앙값 기록
+func measureNTP(servers string) {
+	var offsets []time.Duration
+	var used, errs []string
+	for _, s := range strings.Split(servers, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		off, err := queryNTP(s)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", s, err))
+			continue
+		}
+		offsets = append(offsets, off)
+		used = append(used, s)
+	}
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	ntpCheckedAt = time.Now()
+	if len(offsets) == 0 {
+		ntpMeasured, ntpError = false, strings.Join(errs, "; ")
+		if ntpError == "" {
+			ntpError = "no NTP servers"
+		}
+		return
+	}
+	slices.Sort(offsets)
+	ntpOffset, ntpMeasured, ntpServer, ntpError = offsets[len(offsets)/2], true, strings.Join(used, ","), ""
+}
+
+// SNTP 질의 1회 (RFC 4330), 반환 : 서버 시계 - 로컬 시계
+func queryNTP(server string) (time.Duration, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "123")
+	}
+	conn, err := net.DialTimeout("udp", server, NTPTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(NTPTimeout))
+
+	req := make([]byte, 48)
+	req[0] = 0x23 // LI=0, VN=4, Mode=3 (client)
+	t1 := time.Now()
+	putNTPTime(req[40:], t1) // transmit timestamp => 서버가 originate 로 돌려줌
+	if _, err := conn.Write(req); err != nil {
+		return 0, err
+	}
+	resp := make([]byte, 48)
+	n, err := conn.Read(resp)
+	t4 := time.Now()
+	if err != nil {
+		return 0, err
+	}
+	if n < 48 {
+		return 0, fmt.Errorf("short NTP response (%d bytes)", n)
+	}
+	if mode := resp[0] & 0x7; mode != 4 {
+		return 0, fmt.Errorf("unexpected NTP mode %d", mode)
+	}
+	if resp[1] == 0 {
+		return 0, errors.New("NTP kiss-o'-death")
+	}
+	if string(resp[24:32]) != string(req[40:48]) {
+		return 0, errors.New("NTP originate timestamp mismatch")
+	}
+	t2, t3 := ntpTime(resp[32:40]), ntpTime(resp[40:48])
+	return (t2.Sub(t1) + t3.Sub(t4)) / 2, nil
+}
+
+func ntpTime(b []byte) time.Time {
+	sec := binary.BigEndian.Uint32(b[0:4])
+	frac := binary.BigEndian.Uint32(b[4:8])
+	return time.Unix(int64(sec)-ntpEpochOffset, int64((uint64(frac)*1e9)>>32))
+}
+
+func putNTPTime(b []byte, t time.Time) {
+	binary.BigEndian.PutUint32(b[0:4], uint32(t.Unix()+ntpEpochOffset))
+	binary.BigEndian.PutUint32(b[4:8], uint32((uint64(t.Nanosecond())<<32)/1e9))
+}
+
+// probeStatus 결과로 피어 시계 기록 (peerTime : 피어 /status 의 time, sent/recv : 요청 송신/응답 수신 시각)
+func observePeerClock(addr, peerTime string, sent, recv time.Time) {
+	t, err := time.Parse(time.RFC3339Nano, peerTime)
+	if err != nil {
+		return // time 을 보내지 않는 이전 버전 노드
+	}
+	rtt := recv.Sub(sent)
+	offset := t.Sub(sent.Add(rtt / 2))
+	clockMu.Lock()
+	peerClocks[addr] = peerClock{offset: offset, rtt: rtt, at: recv}
+	if off := absDuration(offset) > clockSkewMax; off != clockWarned[addr] {
+		clockWarned[addr] = off
+		if off {
+			log.Printf("[CLOCK][WARN] peer %s clock differs by %s (max %s)", addr, offset.Round(time.Millisecond), clockSkewMax)
+		} else {
+			log.Printf("[CLOCK] peer %s clock back within %s", addr, clockSkewMax)
+		}
+	}
+	clockMu.Unlock()
+
+	// 이 노드 판정이 바뀌었으면 로그
+	skewed, reason := clockSkewed()
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	if skewed != selfWarned {
+		selfWarned = skewed
+		if skewed {
+			log.Printf("[CLOCK][WARN] local clock skewed: %s (mode=%s)", reason, clockSkewMode)
+		} else {
+			log.Printf("[CLOCK] local clock agrees with peers again")
+		}
+	}
+}
+
+// 피어 제거 시 측정값 삭제
+func forgetPeerClock(addr string) {
+	clockMu.Lock()
+	delete(peerClocks, addr)
+	delete(clockWarned, addr)
+	clockMu.Unlock()
+}
+
+// 이 노드 시계가 틀어졌는지 (NTP 초과 또는 최근 측정한 피어 과반이 초과)
+func clockSkewed() (bool, string) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	if ntpMeasured && absDuration(ntpOffset) > clockSkewMax {
+		return true, fmt.Sprintf("%s off NTP (max %s)", ntpOffset.Round(time.Millisecond), clockSkewMax)
+	}
+	var offsets []time.Duration
+	off := 0
+	for _, pc := range peerClocks {
+		if time.Since(pc.at) > ClockPeerMaxAge {
+			continue
+		}
+		offsets = append(offsets, pc.offset)
+		if absDuration(pc.offset) > clockSkewMax {
+			off++
+		}
+	}
+	if len(offsets) >= 2 && off*2 > len(offsets) {
+		slices.Sort(offsets)
+		return true, fmt.Sprintf("%d of %d peers differ by more than %s (median %s)", off, len(offsets), clockSkewMax,
+			offsets[len(offsets)/2].Round(time.Millisecond))
+	}
+	return false, ""
+}
+
+// 준비 상태 점검 항목 (refuse 모드만, health.go)
+func clockReadinessFailure() string {
+	if clockSkewMode != ClockSkewRefuse {
+		return ""
+	}
+	if skewed, reason := clockSkewed(); skewed {
+		return reason
+	}
+	return ""
+}
+
+// 메트릭용 오프셋(초) : NTP 측정값, 없으면 피어 오프셋 중앙값의 부호 반대 (피어 기준 이 노드가 앞선 정도)
+func clockOffsetSeconds() float64 {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	if ntpMeasured {
+		return -ntpOffset.Seconds()
+	}
+	var offsets []time.Duration
+	for _, pc := range peerClocks {
+		if time.Since(pc.at) <= ClockPeerMaxAge {
+			offsets = append(offsets, pc.offset)
+		}
+	}
+	if len(offsets) == 0 {
+		return 0
+	}
+	slices.Sort(offsets)
+	return -offsets[len(offsets)/2].Seconds()
+}
+
+// 제안 블록 타임스탬프 : 직전 블록 시각보다 이르지 않게 (시계가 약간 늦은 제안자도 순서 유지)
+func proposalTime(prev LowerBlock) time.Time {
+	now := time.Now().UTC()
+	if pt, err := time.Parse(time.RFC3339Nano, prev.Timestamp); err == nil && pt.After(now) {
+		return pt
+	}
+	return now
+}
+
+// 제안 블록 타임스탬프 검사 (/bft/start)
+//   - 직전 블록 타임스탬프가 파싱되지 않으면(고정 제네시스 등) 순서 검사 생략
+func checkProposalTime(b LowerBlock) error {
+	ts, err := time.Parse(time.RFC3339Nano, b.Timestamp)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", b.Timestamp)
+	}
+	if ahead := time.Until(ts); ahead > clockSkewMax {
+		return fmt.Errorf("timestamp %s is %s ahead of local clock (max %s)", b.Timestamp, ahead.Round(time.Millisecond), clockSkewMax)
+	}
+	if b.Index == 0 {
+		return nil
+	}
+	prev, err := getBlockByIndex(b.Index - 1)
+	if err != nil {
+		return nil // 뒤처진 노드 : 동기화 후 판단
+	}
+	if pt, err := time.Parse(time.RFC3339Nano, prev.Timestamp); err == nil && ts.Before(pt) {
+		return fmt.Errorf("timestamp %s before previous block %s", b.Timestamp, prev.Timestamp)
+	}
+	return nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// GET /clock
+func handleClock(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	rep := ClockReport{Mode: clockSkewMode, MaxSkewSeconds: clockSkewMax.Seconds(), Peers: []PeerClock{}}
+	rep.Skewed, rep.Reason = clockSkewed()
+
+	clockMu.Lock()
+	if ntpMeasured {
+		off := ntpOffset.Seconds()
+		rep.NTPServer, rep.NTPOffset = ntpServer, &off
+	}
+	rep.NTPError = ntpError
+	if !ntpCheckedAt.IsZero() {
+		rep.NTPCheckedAt = ntpCheckedAt.UTC().Format(time.RFC3339)
+	}
+	for addr, pc := range peerClocks {
+		rep.Peers = append(rep.Peers, PeerClock{
+			Addr:          addr,
+			OffsetSeconds: pc.offset.Seconds(),
+			RTTSeconds:    pc.rtt.Seconds(),
+			CheckedAt:     pc.at.UTC().Format(time.RFC3339),
+			Skewed:        absDuration(pc.offset) > clockSkewMax,
+		})
+	}
+	clockMu.Unlock()
+	sort.Slice(rep.Peers, func(i, j int) bool { return rep.Peers[i].Addr < rep.Peers[j].Addr })
+	writeJSON(w, http.StatusOK, rep)
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Node mTLS (노드 간 상호 TLS + 인증서 고정)
+// ------------------------------------------------------------
+// - TLS_CERT_FILE / TLS_KEY_FILE 이 지정된 경우에만 활성화 (미지정 시 기존 평문 HTTP 유지)
+// - 인증서는 CA 체인 대신 SHA-256 지문(pin)으로 신뢰 : 자체 서명 인증서 사용 가능
+// - 지문 배포 : 부트노드가 /register 응답과 /addPeer 전파 시 공개키와 함께 전달
+// - 클라이언트(노드 -> 노드) : 접속 주소에 고정된 지문과 서버 인증서가 다르면 연결 거부
+//   · 지문이 없는 주소는 최초 접속 시 고정(TOFU), TLS_STRICT_PINS=true 면 거부
+// - 서버 : 노드 간 엔드포인트는 고정된(또는 TLS_TRUSTED_PINS 에 등록된) 클라이언트 인증서만 허용
+//   사용자용 API(/upload, /search 등)는 클라이언트 인증서를 요구하지 않음
+////////////////////////////////////////////////////////////////////////////////
+
+var (
+	tlsEnabled  bool
+	tlsStrict   bool
+	tlsCert     tls.Certificate
+	selfCertPin string // 내 인증서 지문
+
+	nodeScheme     = "http"
+	nodeClient     = http.DefaultClient // 노드 간 통신용 클라이언트 (initPeerClient 에서 교체)
+	nodeBulkClient = http.DefaultClient // 대용량 다운로드용 (제한시간만 다름)
+
+	certPins     = make(map[string]string) // 노드 주소 => 인증서 지문
+	trustedPins  = make(map[string]bool)   // 운영자가 지정한 신뢰 지문 (TLS_TRUSTED_PINS)
+	certPinsMu   sync.RWMutex
+	tlsDialLimit = 10 * time.Second
+)
+
+// 인증서 DER의 SHA-256 지문 (hex)
+func certFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// 환경변수로 mTLS 초기화
+func initNodeTLS() {
+	certFile := os.Getenv("TLS_CERT_FILE")
+	keyFile := os.Getenv("TLS_KEY_FILE")
+	if certFile == "" || keyFile == "" {
+		log.Println("[TLS] TLS_CERT_FILE/TLS_KEY_FILE not set; node traffic stays plain HTTP")
+		return
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		log.Fatalf("[TLS] failed to load key pair: %v", err)
+	}
+	tlsCert = cert
+	selfCertPin = certFingerprint(cert.Certificate[0])
+	tlsStrict = getEnvDefault("TLS_STRICT_PINS", "false") == "true"
+	for _, p := range strings.Split(os.Getenv("TLS_TRUSTED_PINS"), ",") {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			trustedPins[p] = true
+		}
+	}
+	// 부트노드 지문을 미리 알고 있다면 고정 (최초 /register 보호)
+	if bp := strings.ToLower(os.Getenv("TLS_BOOT_PIN")); bp != "" && boot != "" {
+		pinPeerCert(boot, bp)
+	}
+	pinPeerCert(self, selfCertPin)
+
+	nodeScheme = "https" // nodeClient 는 initPeerClient 에서 지문 고정 다이얼러로 생성
+	tlsEnabled = true
+	log.Printf("[TLS] mTLS enabled (pin=%s..., strict=%v)", selfCertPin[:16], tlsStrict)
+}
+
+// 노드 주소에 대한 요청 URL
+func nodeURL(addr, path string) string {
+	return nodeScheme + "://" + dialAddr(addr) + path // 후보 주소 중 도달성이 가장 좋은 주소 (advertise.go)
+}
+
+// 서버 시작 (mTLS 활성 시 클라이언트 인증서 요청)
+func serveNode(addr string, handler http.Handler) error {
+	if !tlsEnabled {
+		return http.ListenAndServe(addr, handler)
+	}
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: handler,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{tlsCert},
+			ClientAuth:   tls.RequestClientCert, // 검증은 지문 기반으로 핸들러에서 수행
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+	return srv.ListenAndServeTLS("", "")
+}
+
+func pinPeerCert(addr, pin string) {
+	if addr == "" || pin == "" {
+		return
+	}
+	certPinsMu.Lock()
+	certPins[addr] = strings.ToLower(pin)
+	certPinsMu.Unlock()
+}
+
+func peerCertPin(addr string) string {
+	certPinsMu.RLock()
+	defer certPinsMu.RUnlock()
+	return certPins[addr]
+}
+
+func certPinsSnapshot() map[string]string {
+	certPinsMu.RLock()
+	defer certPinsMu.RUnlock()
+	out := make(map[string]string, len(certPins))
+	for k, v := range certPins {
+		out[k] = v
+	}
+	return out
+}
+
+// 고정된 지문 또는 신뢰 지문인지 확인
+func isKnownPin(pin string) bool {
+	certPinsMu.RLock()
+	defer certPinsMu.RUnlock()
+	if trustedPins[pin] {
+		return true
+	}
+	for _, p := range certPins {
+		if p == pin {
+			return true
+		}
+	}
+	return false
+}
+
+// 접속 주소별 지문을 검증하는 TLS 다이얼러
+func dialPinnedTLS(ctx context.Context, network, addr string) (net.Conn, error) {
+	cfg := &tls.Config{
+		Certificates:       []tls.Certificate{tlsCert},
+		InsecureSkipVerify: true, // CA 검증 대신 아래 지문 검증 사용
+		MinVersion:         tls.VersionTLS12,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return fmt.Errorf("no server certificate from %s", addr)
+			}
+			got := certFingerprint(cs.PeerCertificates[0].Raw)
+			want := peerCertPin(addr)
+			switch {
+			case want == got:
+				return nil
+			case want != "":
+				return fmt.Errorf("certificate pin mismatch for %s", addr)
+			case trustedPins[got]:
+				pinPeerCert(addr, got)
+				return nil
+			case tlsStrict:
+				return fmt.Errorf("no certificate pin for %s", addr)
+			}
+			log.Printf("[TLS][TOFU] Pinning first-seen certificate for %s (%s...)", addr, got[:16])
+			pinPeerCert(addr, got)
+			return nil
+		},
+	}
+	d := &tls.Dialer{NetDialer: &net.Dialer{Timeout: tlsDialLimit}, Config: cfg}
+	return d.DialContext(ctx, network, addr)
+}
+
+// 요청에 제시된 클라이언트 인증서 지문 (없으면 "")
+func clientCertPin(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+	return certFingerprint(r.TLS.PeerCertificates[0].Raw)
+}
+
+// 노드 간 엔드포인트 보호 : 고정된 인증서를 제시한 노드만 허용
+func requireNodeCert(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !tlsEnabled {
+			h(w, r)
+			return
+		}
+		pin := clientCertPin(r)
+		if pin == "" || !isKnownPin(pin) {
+			log.Printf("[TLS][DENY] %s from %s (pin=%q)", r.URL.Path, r.RemoteAddr, pin)
+			writeError(w, http.StatusForbidden, "node certificate not pinned")
+			return
+		}
+		h(w, r)
+	}
+}
+
+// /register 요청의 인증서 지문 확인 (신규 노드는 아직 고정 전이므로 strict 모드에서만 신뢰 지문 요구)
+func registrationPin(r *http.Request) (string, error) {
+	if !tlsEnabled {
+		return "", nil
+	}
+	pin := clientCertPin(r)
+	if pin == "" {
+		return "", fmt.Errorf("client certificate required")
+	}
+	if tlsStrict && !isKnownPin(pin) {
+		return "", fmt.Errorf("client certificate not trusted")
+	}
+	return pin, nil
+}
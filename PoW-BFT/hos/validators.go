@@ -0,0 +1,414 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Validator Set (장부에 기록된 검증자 집합)
+// ------------------------------------------------------------
+// - 합의 정족수/제안자 순환/서명 검증 대상을 부트노드에 등록된 피어 목록이 아니라
+//   블록에 확정된 검증자 변경 레코드로 계산
+//   · 변경 레코드 = ClinicRecord{clinic_id: "validator:<addr>", timestamp, validator{op, addr, pub_key, effective_height}}
+//   · op = join(공개키 포함) | leave, effective_height 높이의 블록부터 적용
+//     (확정 블록 다음 높이보다 작으면 다음 높이로 보정)
+//   · 일반 레코드와 같이 메모리풀 => PBFT 합의로 확정, 검색 색인에는 넣지 않음
+// - 블록 반영 시 "valset_<block>:<entry>" 에 변경 내역 저장 (정리된 블록과 무관하게 유지)
+//   · 분기 교체 시 분기점 이후 변경 내역 삭제 (reorg.go rollbackTo)
+// - 높이 h 의 검증자 집합 = effective_height <= h 인 변경을 확정 순서대로 적용한 결과
+//   · 변경 레코드가 하나도 없는 체인(기존 체인/초기 구동)은 공개키가 등록된 노드 전체 (기존 방식)
+//   · 서명 검증 공개키도 집합에 기록된 join 공개키 (피어가 알려준 현재 공개키가 아님)
+//   · 높이별 집합/처벌 목록은 캐시, 블록 반영(commitBlock)/분기 교체(rollbackTo) 시 비움
+// - 변경 레코드 생성
+//   · 부트노드 : 기동/선출 시 집합에 없는 자신과 피어의 join, 신규 노드 등록(/register) 시 join
+//     (키를 교체한 노드는 새 공개키로 다시 join)
+//   · POST /validators {op: "leave", addr} : 검증자 제외 (운영자)
+// - 제안 블록 검증 (handleBftStart) : join 공개키는 수신 노드가 아는 해당 노드 공개키와 같아야 하고,
+//   leave 대상은 현재 검증자여야 함 (집합이 비게 되는 변경은 거부)
+// - GET /validators[?height=<int>] : 해당 높이(기본 다음 블록)의 검증자 집합과 변경 이력
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	validatorChangePrefix = "valset_"
+	validatorRecordPrefix = "validator:"
+
+	ValidatorJoin  = "join"
+	ValidatorLeave = "leave"
+)
+
+// 검증자 변경 레코드
+type ValidatorChange struct {
+	Op              string `json:"op"` // join | leave
+	Addr            string `json:"addr"`
+	PubKey          string `json:"pub_key,omitempty"` // join 일 때 노드 공개키
+	EffectiveHeight int    `json:"effective_height"`  // 이 높이의 블록부터 적용
+}
+
+// 장부에 확정된 변경 (블록 위치 포함, effective_height 는 보정된 값)
+type ValidatorChangeEntry struct {
+	ValidatorChange
+	Block int `json:"block"`
+	Entry int `json:"entry"`
+}
+
+// 검증자 (집합 조회 응답)
+type Validator struct {
+	Addr   string `json:"addr"`
+	PubKey string `json:"pub_key"`
+	KeyFP  string `json:"pubkey_fingerprint"`
+	Since  int    `json:"since"` // 적용 높이
+}
+
+func validatorChangeKey(bi, ei int) []byte {
+	return []byte(fmt.Sprintf("%s%010d:%04d", validatorChangePrefix, bi, ei))
+}
+
+// 블록의 검증자 변경 내역 저장 (updateIndicesForBlock 에서 호출)
+func putValidatorChanges(batch kvWriter, block LowerBlock) {
+	for ei, rec := range block.Entries {
+		if rec.Validator == nil {
+			continue
+		}
+		e := ValidatorChangeEntry{ValidatorChange: *rec.Validator, Block: block.Index, Entry: ei}
+		e.EffectiveHeight = max(e.EffectiveHeight, block.Index+1)
+		data, _ := json.Marshal(e)
+		batch.Put(validatorChangeKey(block.Index, ei), data)
+	}
+}
+
+// 분기점 이후 블록의 변경 내역 삭제 (rollbackTo 에서 호출)
+func deleteValidatorChanges(batch kvWriter, fork int) {
+	iter := db.NewIterator(util.BytesPrefix([]byte(validatorChangePrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		if bi, _, ok := parsePtr(strings.TrimPrefix(string(iter.Key()), validatorChangePrefix)); ok && bi > fork {
+			batch.Delete(append([]byte(nil), iter.Key()...))
+		}
+	}
+}
+
+// 확정된 변경 내역 (확정 순서)
+func validatorChanges() []ValidatorChangeEntry {
+	out := []ValidatorChangeEntry{}
+	iter := db.NewIterator(util.BytesPrefix([]byte(validatorChangePrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var e ValidatorChangeEntry
+		if json.Unmarshal(iter.Value(), &e) == nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// 높이 h 블록의 검증자 집합 (주소 => 검증자), 변경 내역이 없으면 ok = false
+func committedValidators(h int) (map[string]Validator, bool) {
+	changes := validatorChanges()
+	if len(changes) == 0 {
+		return nil, false
+	}
+	set := map[string]Validator{}
+	for _, c := range changes {
+		if c.EffectiveHeight > h {
+			continue
+		}
+		switch c.Op {
+		case ValidatorJoin:
+			set[c.Addr] = Validator{Addr: c.Addr, PubKey: c.PubKey, KeyFP: pubKeyFingerprint(c.PubKey), Since: c.EffectiveHeight}
+		case ValidatorLeave:
+			delete(set, c.Addr)
+		}
+	}
+	return set, true
+}
+
+// 높이별 장부 검증자 집합 캐시 (투표마다 valset_/evid_ 를 다시 훑지 않도록)
+type validatorSnapshot struct {
+	set       map[string]Validator // 장부 집합 (기록 전이거나 비어 있으면 nil)
+	penalized map[string]bool
+}
+
+const validatorCacheSize = 64
+
+var (
+	validatorCache   = map[int]validatorSnapshot{}
+	validatorCacheMu sync.Mutex
+)
+
+func validatorSnapshotAt(h int) validatorSnapshot {
+	validatorCacheMu.Lock()
+	defer validatorCacheMu.Unlock()
+	if s, ok := validatorCache[h]; ok {
+		return s
+	}
+	s := validatorSnapshot{penalized: penalizedAt(h)}
+	if set, ok := committedValidators(h); ok && len(set) > 0 {
+		s.set = set
+	}
+	if len(validatorCache) >= validatorCacheSize {
+		clear(validatorCache)
+	}
+	validatorCache[h] = s
+	return s
+}
+
+// 장부의 검증자 변경/증거가 바뀌면 캐시 비움 (commitBlock, rollbackTo 의 db.Write 직후 호출)
+func invalidateValidatorCache() {
+	validatorCacheMu.Lock()
+	defer validatorCacheMu.Unlock()
+	clear(validatorCache)
+}
+
+// 높이 h 블록의 검증자 주소 (정렬)
+// 장부에 기록된 집합이 없으면(또는 아직 적용 전이면) 공개키가 등록된 노드 전체
+func validatorsAt(h int) []string {
+	s := validatorSnapshotAt(h)
+	nodes := []string{}
+	if s.set != nil {
+		for addr := range s.set {
+			nodes = append(nodes, addr)
+		}
+	} else {
+		for addr := range validatorKeys() {
+			nodes = append(nodes, addr)
+		}
+		if !slices.Contains(nodes, self) {
+			nodes = append(nodes, self)
+		}
+	}
+	// 증거가 기록된 노드는 처벌 구간 동안 제외 (집합이 비게 되면 제외하지 않음, evidence.go)
+	if len(s.penalized) > 0 {
+		kept := []string{}
+		for _, addr := range nodes {
+			if !s.penalized[addr] {
+				kept = append(kept, addr)
+			}
+		}
+		if len(kept) > 0 {
+			nodes = kept
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// 높이 h 블록 검증자의 공개키 (서명 검증용, 키 교체 전 서명은 countValidSignatures 가 지문으로 처리)
+func validatorKeysAt(h int) map[string]string {
+	out := make(map[string]string)
+	for _, addr := range validatorsAt(h) {
+		if pub := validatorKeyAt(addr, h); pub != "" {
+			out[addr] = pub
+		}
+	}
+	return out
+}
+
+// 높이 h 에서 addr 의 공개키 : 장부 집합의 join 공개키 (처벌 여부와 무관)
+// 장부에 기록된 집합이 없으면 공개키가 등록된 노드의 현재 공개키
+func validatorKeyAt(addr string, h int) string {
+	if s := validatorSnapshotAt(h); s.set != nil {
+		return s.set[addr].PubKey
+	}
+	return validatorKeys()[addr]
+}
+
+// 지문으로 교체 전 공개키 조회 : 장부에 기록된 join 공개키, 없으면 로컬 교체 이력 (keystore.go)
+func historicalPubKey(addr, fp string) string {
+	changes := validatorChanges()
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		if c.Op == ValidatorJoin && c.Addr == addr && pubKeyFingerprint(c.PubKey) == fp {
+			return c.PubKey
+		}
+	}
+	return retiredPubKey(addr, fp)
+}
+
+func isValidatorAt(addr string, h int) bool {
+	return slices.Contains(validatorsAt(h), addr)
+}
+
+// 높이 h 블록의 합의 정족수 (2f+1)
+func quorumAt(h int) int {
+	n := len(validatorsAt(h))
+	f := (n - 1) / 3
+	return 2*f + 1
+}
+
+// 메모리풀에서 확정을 기다리는 변경 레코드
+func pendingValidatorRecords() []ClinicRecord {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	out := []ClinicRecord{}
+	for _, rec := range ch.pending {
+		if rec.Validator != nil {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
+
+// 메모리풀에서 확정을 기다리는 변경 (주소 => op)
+func pendingValidatorChanges() map[string]string {
+	out := map[string]string{}
+	for _, rec := range pendingValidatorRecords() {
+		out[rec.Validator.Addr] = rec.Validator.Op
+	}
+	return out
+}
+
+// 검증자 변경 레코드 접수 (메모리풀 => 다른 노드로 중계)
+func submitValidatorChange(c ValidatorChange) error {
+	rec := ClinicRecord{
+		ClinicID:  validatorRecordPrefix + c.Addr,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Validator: &c,
+	}
+	if _, _, status, err := submitRecords([]ClinicRecord{rec}); err != nil || status != http.StatusOK {
+		if err == nil {
+			err = fmt.Errorf("status=%d", status)
+		}
+		return err
+	}
+	log.Printf("[VALIDATOR] %s %s submitted (effective>=%d)", c.Op, c.Addr, c.EffectiveHeight)
+	return nil
+}
+
+// 공개키가 등록된 노드 중 장부 집합에 없는 노드의 join 접수 (부트노드)
+// 확정 대기 중인 변경은 다시 중계 (피어 등록 전에 접수되어 다음 제안자에게 전달되지 않은 경우 대비)
+func reconcileValidatorSet() {
+	if !isBoot.Load() {
+		return
+	}
+	relayPending(pendingValidatorRecords())
+	height, _ := getLatestHeight()
+	set, _ := committedValidators(height + 1)
+	inflight := pendingValidatorChanges()
+	for addr, pub := range validatorKeys() {
+		if inflight[addr] != "" {
+			continue
+		}
+		// 집합에 있고 기록된 공개키가 현재 키와 같으면 생략 (키를 교체했으면 새 키로 다시 join)
+		if v, ok := set[addr]; ok && strings.TrimSpace(v.PubKey) == strings.TrimSpace(pub) {
+			continue
+		}
+		if err := submitValidatorChange(ValidatorChange{Op: ValidatorJoin, Addr: addr, PubKey: pub, EffectiveHeight: height + 1}); err != nil {
+			log.Printf("[VALIDATOR][ERROR] join %s: %v", addr, err)
+		}
+	}
+}
+
+// 제안 블록의 검증자 변경 확인 (handleBftStart 에서 호출)
+func checkValidatorChanges(b LowerBlock) error {
+	set, _ := committedValidators(b.Index)
+	if set == nil {
+		set = map[string]Validator{}
+	}
+	keys := validatorKeys()
+	for _, rec := range b.Entries {
+		c := rec.Validator
+		if c == nil {
+			continue
+		}
+		switch c.Op {
+		case ValidatorJoin:
+			if known, ok := keys[c.Addr]; !ok || strings.TrimSpace(known) != strings.TrimSpace(c.PubKey) {
+				return fmt.Errorf("validator join %s: unknown node or key mismatch", c.Addr)
+			}
+			set[c.Addr] = Validator{Addr: c.Addr}
+		case ValidatorLeave:
+			if _, ok := set[c.Addr]; !ok {
+				return fmt.Errorf("validator leave %s: not a validator", c.Addr)
+			}
+			delete(set, c.Addr)
+			if len(set) == 0 {
+				return fmt.Errorf("validator leave %s: set would be empty", c.Addr)
+			}
+		default:
+			return fmt.Errorf("validator change %s: unknown op %q", c.Addr, c.Op)
+		}
+	}
+	return nil
+}
+
+// GET  /validators[?height=<int>] : 검증자 집합과 변경 이력
+// POST /validators {op: "leave", addr} : 검증자 제외 접수
+func handleValidators(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		height, _ := getLatestHeight()
+		h := height + 1
+		if v := r.URL.Query().Get("height"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				writeError(w, http.StatusBadRequest, "invalid height")
+				return
+			}
+			h = n
+		}
+		set, committed := committedValidators(h)
+		committed = committed && len(set) > 0 // 적용된 변경 전이면 합의와 같이 등록 노드 기준 (validatorSnapshotAt)
+		out := []Validator{}
+		if committed {
+			for _, v := range set {
+				out = append(out, v)
+			}
+		} else {
+			for addr, pub := range validatorKeys() {
+				out = append(out, Validator{Addr: addr, PubKey: pub, KeyFP: pubKeyFingerprint(pub)})
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Addr < out[j].Addr })
+		writeJSON(w, http.StatusOK, map[string]any{
+			"height":     h,
+			"committed":  committed, // false 면 장부 기록 전 (공개키 등록 노드 기준)
+			"quorum":     quorumAt(h),
+			"validators": out,
+			"penalized":  penalizedList(h), // 증거 기록으로 정족수/제안자 순환에서 제외 중 (evidence.go)
+			"changes":    validatorChanges(),
+			"pending":    pendingValidatorChanges(),
+		})
+
+	case http.MethodPost:
+		var req ValidatorChange
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Addr == "" {
+			writeError(w, http.StatusBadRequest, "addr required")
+			return
+		}
+		defer r.Body.Close()
+		if req.Op != ValidatorLeave {
+			writeError(w, http.StatusBadRequest, "only leave can be requested (join is recorded on registration)")
+			return
+		}
+		height, _ := getLatestHeight()
+		if !isValidatorAt(req.Addr, height+1) {
+			writeError(w, http.StatusNotFound, "not a validator")
+			return
+		}
+		if pendingValidatorChanges()[req.Addr] != "" {
+			writeError(w, http.StatusConflict, "change already pending")
+			return
+		}
+		req.PubKey = ""
+		req.EffectiveHeight = max(req.EffectiveHeight, height+1)
+		if err := submitValidatorChange(req); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]any{"status": "pending", "change": req})
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"merkle"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// API Versioning (/v1 경로 + 기존 경로 호환)
+// ------------------------------------------------------------
+// - 모든 엔드포인트는 /v1/<기존 경로> 로도 호출 가능 (예: /v1/search, /v1/blocks)
+// - 기존 경로(버전 없음)는 당분간 그대로 동작하되 폐기 예정 헤더를 붙임
+//   · Deprecation: true
+//   · Sunset: API_LEGACY_SUNSET (RFC3339, 기본 LegacySunsetDefault) 의 HTTP-date
+//   · Link: </v1/<path>>; rel="successor-version"
+//   (정적 파일과 내장 대시보드 /dashboard 는 제외)
+// - GET /v1/meta : 이 노드가 지원하는 API 버전/기능 목록 (SDK, 피어가 기능 확인용)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	APIVersion          = "v1"
+	LegacySunsetDefault = "2027-06-30T00:00:00Z"
+)
+
+var legacySunset = LegacySunsetDefault
+
+// 이 노드 빌드가 제공하는 기능 목록
+var nodeFeatures = []string{
+	"search", "inclusion", "bft", "residency", "retention",
+	"anchor_queue", "jobs", "events", "commitment", "onboarding", "replay", "dedup", "chain_info", "fulltext", "loadshed", "fast_sync", "snapshot", "pruning", "key_rotation", "signed_registration", "grpc", "manual_finalize", "resync", "revocation", "history", "patient_records", "phi_encryption", "selective_disclosure", "gov_registration", "proposer_rotation", "validator_set", "misbehavior_evidence", "openapi", "health_probes", "proof_version", "anchor_catchup", "pending_limits", "record_priority", "block_transfer", "compression", "binary_wire", "addr_discovery", "bft_message_auth", "chain_id_header", "hot_backup", "observer_mode", "light_client", "chain_archive", "webhooks", "dashboard", "clock_sync",
+}
+
+// /v1 접두어 처리 + 기존 경로 폐기 예정 헤더
+func withAPIVersion(mux *http.ServeMux) http.Handler {
+	sunset := ""
+	if t, err := time.Parse(time.RFC3339, legacySunset); err == nil {
+		sunset = t.UTC().Format(http.TimeFormat)
+	}
+	v1 := http.StripPrefix("/"+APIVersion, mux)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		if p == "/"+APIVersion+"/meta" {
+			handleAPIMeta(w, r)
+			return
+		}
+		if strings.HasPrefix(p, "/"+APIVersion+"/") {
+			v1.ServeHTTP(w, r)
+			return
+		}
+		if _, pattern := mux.Handler(r); pattern != "/" && pattern != "/dashboard" {
+			w.Header().Set("Deprecation", "true")
+			if sunset != "" {
+				w.Header().Set("Sunset", sunset)
+			}
+			w.Header().Set("Link", "</"+APIVersion+p+`>; rel="successor-version"`)
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// GET /v1/meta
+func handleAPIMeta(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"api_versions":   []string{APIVersion},
+		"current":        APIVersion,
+		"legacy_sunset":  legacySunset,
+		"node_role":      metricsNodeRole,
+		"role":           nodeRole, // validator | observer
+		"chain_id":       selfID(),
+		"node":           self,
+		"features":       nodeFeatures,
+		"tls":            tlsEnabled,
+		"region":         region,
+		"proof_version":  merkle.ProofVersion,             // 이 노드가 만드는 증명 형식
+		"proof_versions": merkle.SupportedProofVersions(), // 검증 가능한 증명 형식
+	})
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"hos/hospb"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 노드 간 바이너리 전송 형식 (Protobuf Wire Format)
+// ------------------------------------------------------------
+// - 대상 : 합의 메시지 /bft/start, /bft/prepare, /bft/commit, /bft/viewchange 와 동기화 /blocks
+//   · 공개 API(대시보드/클라이언트)는 계속 JSON
+//   · 레코드 본문은 leaf 해시와 같은 JSON 직렬화를 bytes 로 실어 무손실 (블록 해시/머클루트 재현 보장)
+// - 협상 (피어별)
+//   · 모든 응답에 X-Wire-Formats: protobuf 를 실어 수신 가능을 알림 (withWireFormat)
+//   · peerTransport 가 응답 헤더로 피어 지원 여부 기록 => broadcast 는 지원 피어에게만 protobuf 전송
+//   · /blocks 는 Accept: application/x-protobuf 요청에만 protobuf 응답
+//   => 이전 버전 노드와는 그대로 JSON 으로 통신
+// - WIRE_FORMAT=json 이면 protobuf 송수신 광고를 끔 (수신 시 Content-Type 이 protobuf 면 디코딩은 항상 지원)
+// - Gov 체인(/receiveBlock)은 표준 라이브러리만 사용하므로 JSON 유지
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	WireContentType = "application/x-protobuf"
+	WireFormatJSON  = "json"
+	WireFormatProto = "protobuf"
+	WireMaxBody     = 64 << 20 // protobuf 요청 본문 최대 크기 (바이트)
+)
+
+var WireFormat = WireFormatProto // 노드 간 선호 형식 (WIRE_FORMAT)
+
+var peerWireProto sync.Map // 피어 host => protobuf 수신 지원 여부
+
+// protobuf 로도 주고받는 노드 간 메시지
+type wireCodec interface {
+	marshalWire() ([]byte, error)
+	unmarshalWire(data []byte) error
+}
+
+// 수신 가능 형식 광고
+func withWireFormat(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if WireFormat == WireFormatProto {
+			w.Header().Set("X-Wire-Formats", WireFormatProto)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// 응답의 X-Wire-Formats 로 피어의 protobuf 지원 여부 기록 (peerTransport 에서 호출)
+func notePeerWire(host string, resp *http.Response) {
+	peerWireProto.Store(host, strings.Contains(resp.Header.Get("X-Wire-Formats"), WireFormatProto))
+}
+
+// 피어에게 protobuf 로 보낼지 여부
+func peerWantsProto(addr string) bool {
+	if WireFormat != WireFormatProto {
+		return false
+	}
+	u, err := url.Parse(nodeURL(addr, "/"))
+	if err != nil {
+		return false
+	}
+	v, _ := peerWireProto.Load(u.Host)
+	return v == true
+}
+
+func isWireRequest(r *http.Request) bool {
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return ct == WireContentType
+}
+
+// 요청이 protobuf 응답을 받을 수 있는지 (Accept)
+func acceptsWire(r *http.Request) bool {
+	return WireFormat == WireFormatProto && strings.Contains(r.Header.Get("Accept"), WireContentType)
+}
+
+// Content-Type 에 따라 protobuf 또는 JSON 본문 디코딩
+func decodeWire(r *http.Request, msg wireCodec) error {
+	if !isWireRequest(r) {
+		return json.NewDecoder(r.Body).Decode(msg)
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, WireMaxBody+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > WireMaxBody {
+		return fmt.Errorf("body too large")
+	}
+	return msg.unmarshalWire(data)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 합의 메시지
+////////////////////////////////////////////////////////////////////////////////
+
+// 블록 제안 (Pre-Prepare)
+type bftProposal struct {
+	View   int        `json:"view"`
+	Round  int        `json:"round"`
+	Leader string     `json:"leader"`
+	Block  LowerBlock `json:"block"`
+	Sig    string     `json:"sig"`  // 제안자의 블록 해시 서명
+	Auth   string     `json:"auth"` // 제안자의 voteDigest("start", view, round, hash) 서명 (bft.go)
+}
+
+// Prepare / Commit 투표
+type bftVote struct {
+	View   int               `json:"view"`
+	Round  int               `json:"round"`
+	Addr   string            `json:"addr"`
+	Sig    string            `json:"sig"`
+	Hash   string            `json:"hash"`
+	Header *LowerBlockHeader `json:"header,omitempty"` // 서명한 블록 헤더 (이중 서명 증거용)
+	Auth   string            `json:"auth"`             // voteDigest(단계, view, round, hash) 서명 (bft.go)
+}
+
+// view-change 투표
+type bftViewChange struct {
+	View  int    `json:"view"`
+	Round int    `json:"round"`
+	Addr  string `json:"addr"`
+	Sig   string `json:"sig"`
+}
+
+func (m *bftProposal) marshalWire() ([]byte, error) {
+	block, err := blockToWire(m.Block)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&hospb.BftProposal{View: int64(m.View), Round: int64(m.Round), Leader: m.Leader, Block: block, Sig: m.Sig, Auth: m.Auth})
+}
+
+func (m *bftProposal) unmarshalWire(data []byte) error {
+	var pb hospb.BftProposal
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	block, err := blockFromWire(pb.GetBlock())
+	if err != nil {
+		return err
+	}
+	*m = bftProposal{View: int(pb.View), Round: int(pb.Round), Leader: pb.Leader, Block: block, Sig: pb.Sig, Auth: pb.Auth}
+	return nil
+}
+
+func (m *bftVote) marshalWire() ([]byte, error) {
+	pb := &hospb.BftVote{View: int64(m.View), Round: int64(m.Round), Addr: m.Addr, Sig: m.Sig, Hash: m.Hash, Auth: m.Auth}
+	if h := m.Header; h != nil {
+		pb.Header = &hospb.WireHeader{
+			Index:      int64(h.Index),
+			HosId:      h.HosID,
+			PrevHash:   h.PrevHash,
+			Timestamp:  h.Timestamp,
+			MerkleRoot: h.MerkleRoot,
+			Proposer:   h.Proposer,
+			BlockHash:  h.BlockHash,
+			Elapsed:    h.Elapsed,
+			EntryCount: int64(h.EntryCount),
+		}
+	}
+	return proto.Marshal(pb)
+}
+
+func (m *bftVote) unmarshalWire(data []byte) error {
+	var pb hospb.BftVote
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	*m = bftVote{View: int(pb.View), Round: int(pb.Round), Addr: pb.Addr, Sig: pb.Sig, Hash: pb.Hash, Auth: pb.Auth}
+	if h := pb.Header; h != nil {
+		m.Header = &LowerBlockHeader{
+			Index:      int(h.Index),
+			HosID:      h.HosId,
+			PrevHash:   h.PrevHash,
+			Timestamp:  h.Timestamp,
+			MerkleRoot: h.MerkleRoot,
+			Proposer:   h.Proposer,
+			BlockHash:  h.BlockHash,
+			Elapsed:    h.Elapsed,
+			EntryCount: int(h.EntryCount),
+		}
+	}
+	return nil
+}
+
+func (m *bftViewChange) marshalWire() ([]byte, error) {
+	return proto.Marshal(&hospb.BftViewChange{View: int64(m.View), Round: int64(m.Round), Addr: m.Addr, Sig: m.Sig})
+}
+
+func (m *bftViewChange) unmarshalWire(data []byte) error {
+	var pb hospb.BftViewChange
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	*m = bftViewChange{View: int(pb.View), Round: int(pb.Round), Addr: pb.Addr, Sig: pb.Sig}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// 블록 변환 (레코드는 JSON bytes)
+////////////////////////////////////////////////////////////////////////////////
+
+func blockToWire(b LowerBlock) (*hospb.WireBlock, error) {
+	out := &hospb.WireBlock{
+		Index:      int64(b.Index),
+		HosId:      b.HosID,
+		PrevHash:   b.PrevHash,
+		Timestamp:  b.Timestamp,
+		MerkleRoot: b.MerkleRoot,
+		Proposer:   b.Proposer,
+		BlockHash:  b.BlockHash,
+		Elapsed:    b.Elapsed,
+		LeafHashes: b.LeafHashes,
+		Pruned:     b.Pruned,
+	}
+	for _, e := range b.Entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		out.Entries = append(out.Entries, data)
+	}
+	for _, s := range b.Signatures {
+		out.Signatures = append(out.Signatures, &hospb.ConsensusSig{Addr: s.Addr, PubkeyFingerprint: s.KeyFP, Sig: s.Sig})
+	}
+	return out, nil
+}
+
+func blockFromWire(pb *hospb.WireBlock) (LowerBlock, error) {
+	if pb == nil {
+		return LowerBlock{}, fmt.Errorf("missing block")
+	}
+	b := LowerBlock{
+		Index:      int(pb.Index),
+		HosID:      pb.HosId,
+		PrevHash:   pb.PrevHash,
+		Timestamp:  pb.Timestamp,
+		Entries:    make([]ClinicRecord, len(pb.Entries)),
+		MerkleRoot: pb.MerkleRoot,
+		Proposer:   pb.Proposer,
+		BlockHash:  pb.BlockHash,
+		Elapsed:    pb.Elapsed,
+		LeafHashes: pb.LeafHashes,
+		Pruned:     pb.Pruned,
+	}
+	for i, data := range pb.Entries {
+		if err := json.Unmarshal(data, &b.Entries[i]); err != nil {
+			return LowerBlock{}, fmt.Errorf("entry #%d: %w", i, err)
+		}
+	}
+	for _, s := range pb.Signatures {
+		b.Signatures = append(b.Signatures, ConsensusSig{Addr: s.Addr, KeyFP: s.PubkeyFingerprint, Sig: s.Sig})
+	}
+	return b, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// /blocks 페이지
+////////////////////////////////////////////////////////////////////////////////
+
+// 스냅샷의 offset 부터 limit 개 블록을 protobuf 페이지로 기록 (크기 제한은 streamBlocksPage 와 같음)
+func writeWireBlocksPage(w http.ResponseWriter, rd dbReader, offset, limit, total int) error {
+	page := &hospb.WireBlocksPage{Total: int64(total), Offset: int64(offset), Limit: int64(limit)}
+	if offset < total {
+		sent := 0
+		err := scanBlocksFrom(rd, offset, min(offset+limit, total)-1, func(raw []byte) error {
+			if len(page.Items) > 0 && sent+len(raw) > BlocksPageBytes {
+				return errStopScan
+			}
+			sent += len(raw)
+			var b LowerBlock
+			if err := json.Unmarshal(raw, &b); err != nil {
+				return err
+			}
+			wb, err := blockToWire(b)
+			if err != nil {
+				return err
+			}
+			page.Items = append(page.Items, wb)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	data, err := proto.Marshal(page)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", WireContentType)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	return err
+}
+
+// protobuf /blocks 응답 디코딩
+func decodeWireBlocksPage(r io.Reader) (blocksPage, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return blocksPage{}, err
+	}
+	var pb hospb.WireBlocksPage
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return blocksPage{}, err
+	}
+	page := blocksPage{Total: int(pb.Total), Offset: int(pb.Offset), Limit: int(pb.Limit), Items: make([]LowerBlock, 0, len(pb.Items))}
+	for _, wb := range pb.Items {
+		b, err := blockFromWire(wb)
+		if err != nil {
+			return blocksPage{}, err
+		}
+		page.Items = append(page.Items, b)
+	}
+	return page, nil
+}
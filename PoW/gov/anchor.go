@@ -2,6 +2,8 @@ package main
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/asn1"
@@ -14,8 +16,41 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+
+	"merkle"
 )
 
+// 개인키, 공개키 자동 생성 (최초 실행 시)
+// - 부트노드의 난이도 제어 메시지 서명에 사용
+func ensureKeyPair() {
+	if _, ok := getMeta("meta_gov_privkey"); ok {
+		return
+	}
+
+	priv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privBytes, _ := x509.MarshalECPrivateKey(priv)
+	pubBytes, _ := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+
+	privPem := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privBytes}))
+	pubPem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}))
+
+	putMeta("meta_gov_privkey", privPem)
+	putMeta("meta_gov_pubkey", pubPem)
+	log.Println("[ANCHOR][INIT] Generated ECDSA key pair for Gov node")
+}
+
+// 공개키 조회 API (신규 노드가 부트노드 공개키를 고정할 때 사용)
+// GET /getPublicKey
+func getPublicKey(w http.ResponseWriter, r *http.Request) {
+	pubPem, ok := getMeta("meta_gov_pubkey")
+	if !ok {
+		writeError(w, http.StatusNotFound, "public key not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Write([]byte(pubPem))
+}
+
 // Gov에서 Hos가 제출한 앵커를 수신하고 검증한 후 pending 추가함수 호출(부트노드만 수행)
 func addAnchor(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -26,7 +61,7 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 		Sig     string `json:"sig"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON", 400)
+		writeError(w, 400, "invalid JSON")
 		return
 	}
 	defer r.Body.Close()
@@ -34,7 +69,7 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 	// Hos의 공개키 가져오기
 	resp, err := http.Get("http://" + req.HosBoot + "/getPublicKey")
 	if err != nil {
-		http.Error(w, "failed to fetch public key", 500)
+		writeError(w, 500, "failed to fetch public key")
 		return
 	}
 	defer resp.Body.Close()
@@ -65,14 +100,14 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 	var sigStruct ecdsaSignature
 	_, err = asn1.Unmarshal(sigBytes, &sigStruct)
 	if err != nil {
-		http.Error(w, "invalid signature format", 403)
+		writeError(w, 403, "invalid signature format")
 		return
 	}
 
 	valid := ecdsa.Verify(pubKey, hash[:], sigStruct.R, sigStruct.S)
 
 	if !valid {
-		http.Error(w, "invalid signature", 403)
+		writeError(w, 403, "invalid signature")
 		log.Printf("[ANCHOR][INVALID] rejected from %s", req.HosID)
 		return
 	}
@@ -104,6 +139,7 @@ func addAnchor(w http.ResponseWriter, r *http.Request) {
 
 	// 앵커 저장
 	log.Printf("[ANCHOR] Verified & adding anchor from Hos Chain ... %s : %s)", req.HosID, anchorMap[req.HosID].Root)
+	publishEvent(EventAnchorAccepted, map[string]any{"hos_id": req.HosID, "hos_boot": req.HosBoot, "root": req.Root, "ts": req.Ts})
 
 	// 새로 수신한 Hos 부트노드의 주소가, 기존 Hos체인의 부트노드 주소와 다른 경우
 	if req.HosBoot != getHosBootAddr(req.HosID) {
@@ -121,10 +157,18 @@ type SearchResponse struct {
 	LatestRoot string       `json:"latest_root"`
 	Leaf       string       `json:"leaf"`
 	Proof      [][2]string  `json:"proof"`
+
+	BlockIndex    int    `json:"block_index"`       // 레코드가 속한 Hos 블록 번호
+	Confirmations int    `json:"confirmations"`     // Hos 체인 기준 확인 수
+	Final         bool   `json:"final"`             // Gov 의 FinalityDepth 기준 최종성
+	Warning       string `json:"warning,omitempty"` // 최종성 미달 시 경고
+
+	Inclusion Inclusion `json:"inclusion"` // 블록/엔트리 위치, 확인 수, 앵커 상태
 }
 
 // Hos 검색 프로세스 (핸들러에서 호출)
-func handleHosSearch(hosID, keyword string) ([]byte, int, error) {
+//   - requireFinal : 최종성 깊이 미달 블록의 증명이 포함되면 409 반환
+func handleHosSearch(hosID, keyword string, requireFinal bool) ([]byte, int, error) {
 
 	// 1) Hos 부트 주소 조회
 	hosAddr := getHosBootAddr(hosID)
@@ -145,7 +189,21 @@ func handleHosSearch(hosID, keyword string) ([]byte, int, error) {
 		return nil, http.StatusInternalServerError, err
 	}
 
-	// 4) JSON 반환
+	// 4) 최종성 판정 (Gov 의 FinalityDepth 기준)
+	for i := range verified {
+		it := &verified[i]
+		it.Final = it.Confirmations >= FinalityDepth
+		it.Inclusion.Final = it.Final
+		it.Warning = ""
+		if !it.Final {
+			if requireFinal {
+				return nil, http.StatusConflict, fmt.Errorf("%s", shallowWarning(it.Confirmations, FinalityDepth))
+			}
+			it.Warning = shallowWarning(it.Confirmations, FinalityDepth)
+		}
+	}
+
+	// 5) JSON 반환
 	out, _ := json.Marshal(verified)
 	return out, http.StatusOK, nil
 }
@@ -201,7 +259,9 @@ func verifyHosResults(hosID string, items []SearchResponse) ([]SearchResponse, e
 		}
 
 		// 키워드가 포함된 블록의 Merkle 증명을 통한 유효성 검증
-		if verifyMerkleProof(it.Leaf, it.Proof, it.BlockRoot) {
+		if merkle.Verify(it.Leaf, it.Proof, it.BlockRoot) {
+			// 앵커 상태는 Hos 응답 대신 Gov 장부 기준으로 판정
+			it.Inclusion.AnchorStatus, it.Inclusion.UpperBlockIndex = anchorStatusOf(hosID, it.BlockRoot)
 			verified = append(verified, it)
 			logInfo("[QUERY][SUCCESS] Verified Record Appended")
 		}
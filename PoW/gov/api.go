@@ -24,52 +24,52 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 	// GET /block/index?id=<int>
 	mux.HandleFunc("/block/index", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		q := r.URL.Query().Get("id")
 		if q == "" {
-			http.Error(w, "id parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id parameter required")
 			return
 		}
 		idx, err := strconv.Atoi(q)
 		if err != nil {
-			http.Error(w, "id must be integer", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id must be integer")
 			return
 		}
 		blk, err := getBlockByIndex(idx)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
-		writeJSON(w, http.StatusOK, blk)
+		writeJSON(w, http.StatusOK, newBlockView(blk))
 	})
 
 	// 블록 조회: 해시
 	// GET /block/hash?value=<hash>
 	mux.HandleFunc("/block/hash", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		hash := r.URL.Query().Get("value")
 		if hash == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
 			return
 		}
 		blk, err := getBlockByHash(hash)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
-		writeJSON(w, http.StatusOK, blk)
+		writeJSON(w, http.StatusOK, newBlockView(blk))
 	})
 
 	// 전체 장부 조회 (페이지네이션)
 	// GET /blocks?offset=<int>&limit=<int>
 	mux.HandleFunc("/blocks", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
@@ -79,15 +79,16 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 		}
 		blocks, total, err := listBlocksPaginated(offset, limit)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("list blocks error: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("list blocks error: %v", err))
 			return
 		}
 		writeJSON(w, http.StatusOK, map[string]any{
-			"total":      total,
-			"offset":     offset,
-			"limit":      limit,
-			"items":      blocks,
-			"difficulty": GlobalDifficulty,
+			"total":          total,
+			"offset":         offset,
+			"limit":          limit,
+			"items":          newBlockViews(blocks),
+			"difficulty":     GlobalDifficulty,
+			"finality_depth": FinalityDepth,
 		})
 	})
 
@@ -106,15 +107,16 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 		chainMu.Unlock()
 
 		writeJSON(w, http.StatusOK, map[string]any{
-			"addr":       self,
-			"height":     h,
-			"is_boot":    isBoot.Load(),
-			"bootAddr":   boot,
-			"started_at": startedAt.Format(time.RFC3339),
-			"peers":      peersSnapshot(),
-			"difficulty": GlobalDifficulty,
-			"hos_boot":   hosBootMap,
-			"last_hash":  lastHash,
+			"addr":           self,
+			"height":         h,
+			"is_boot":        isBoot.Load(),
+			"bootAddr":       boot,
+			"started_at":     startedAt.Format(time.RFC3339),
+			"peers":          peersSnapshot(),
+			"difficulty":     GlobalDifficulty,
+			"hos_boot":       hosBootMap,
+			"last_hash":      lastHash,
+			"finality_depth": FinalityDepth,
 		})
 	})
 
@@ -126,10 +128,11 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 	})
 
 	// Hos 체인에게 검색 요청을 중계하는 API
-	// GET /query?hos_id=<id>&keyword=<keyword>
+	// GET /query?hos_id=<id>&keyword=<keyword>&require_final=<bool>
+	//  - require_final=true : 최종성 깊이(FinalityDepth) 미달 블록의 증명은 409로 거부
 	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 
@@ -137,15 +140,15 @@ func RegisterAPI(mux *http.ServeMux, chain *UpperChain) {
 		kw := r.URL.Query().Get("keyword")
 
 		if hosID == "" || kw == "" {
-			http.Error(w, "hos_id and keyword required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "hos_id and keyword required")
 			return
 		}
 		logInfo("[QUERY] Target Hos Chain: %s, Keyword: %s", hosID, kw)
 
 		// 쿼리 검색 수행 후 반환
-		resultBytes, status, err := handleHosSearch(hosID, kw)
+		resultBytes, status, err := handleHosSearch(hosID, kw, r.URL.Query().Get("require_final") == "true")
 		if err != nil {
-			http.Error(w, err.Error(), status)
+			writeError(w, status, err.Error())
 			return
 		}
 
@@ -27,6 +27,12 @@ type UpperBlock struct {
 	Difficulty int            `json:"difficulty"`  // 난이도
 	BlockHash  string         `json:"block_hash"`  // 블록 전체 해시
 	Elapsed    float32        `json:"elapsed"`     // 채굴 소요 시간
+	// nonce 공간 소진 시 증가하는 추가 nonce (0 이면 생략, pow.go)
+	ExtraNonce int `json:"extra_nonce,omitempty"`
+	// 부트노드 서명 난이도 제어 메시지 (긴급 조정 시에만 포함)
+	Control *DifficultyControl `json:"control,omitempty"`
+	// 체인의 난이도 조정 규칙 (제네시스에만, 기본 규칙이면 생략 : difficulty.go)
+	DifficultyParams *DifficultyParams `json:"difficulty_params,omitempty"`
 }
 
 // 제네시스 블록 생성
@@ -46,6 +52,9 @@ func mineGenesisBlock(govID string) UpperBlock {
 		Timestamp:  timestamp,
 		Difficulty: GlobalDifficulty,
 	}
+	// 기본값이 아닌 난이도 규칙은 제네시스 해시에 묶어서 기록 (difficulty.go)
+	params := genesisDifficultyParams()
+	header.ParamsHash = params.hash()
 
 	// === 제네시스 Nonce 탐색 ===
 	nonce := 0
@@ -74,9 +83,10 @@ func mineGenesisBlock(govID string) UpperBlock {
 		Difficulty: GlobalDifficulty,
 		BlockHash:  hash,
 		Elapsed:    elapsed,
+		// 난이도 규칙 (기본 규칙이면 nil)
+		DifficultyParams: params,
 	}
-	// 난이도 조정 수행
-	adjustDifficulty(0, elapsed)
+	// 난이도 조정은 장부 반영 후 refreshDifficulty()에서 수행
 	return genesis
 }
 
@@ -5,10 +5,14 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
 )
 
+// Hos 부트노드의 /chgGovBoot 호출 시 첨부할 자격 증명 (Hos API_KEYS 에 peer 역할로 등록된 키, 미설정 시 미첨부)
+var hosAPIKey = os.Getenv("HOS_API_KEY")
+
 // ============================================
 // 부트노드 기본 소스
 // ============================================
@@ -25,20 +29,20 @@ type registerResp struct {
 // 신규노드가 네트워크 진입 시 부트노드가 다른 노드들의 주소를 제공하는 함수
 func registerPeer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req registerReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Addr == "" {
-		http.Error(w, "invalid body", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid body")
 		return
 	}
 
 	// 체인 정체성 확인: 제네시스 gov_id와 일치해야 가입 허용
 	blk0, err := getBlockByIndex(0)
 	if err != nil || blk0.GovID != req.GovID {
-		http.Error(w, "Gov_id mismatch", http.StatusForbidden)
+		writeError(w, http.StatusForbidden, "Gov_id mismatch")
 		return
 	}
 
@@ -72,7 +76,7 @@ func registerPeer(w http.ResponseWriter, r *http.Request) {
 		log.Printf("[P2P][REGISTER] notifying %d peers about %s", len(others), newPeer)
 		b, _ := json.Marshal(newPeer)
 		for _, op := range others {
-			resp, err := http.Post("http://"+op+"/addPeer", "application/json", strings.NewReader(string(b)))
+			resp, err := peerClient.Post("http://"+op+"/addPeer", "application/json", strings.NewReader(string(b)))
 			if err != nil {
 				log.Printf("[P2P][REGISTER] notify failed to %s: %v", op, err)
 				continue
@@ -106,7 +110,7 @@ type nodeStatus struct {
 // 해당 노드의 현재 상태(nodeStatus)를 가져옴
 func probeStatus(addr string) (nodeStatus, bool) {
 	var s nodeStatus
-	resp, err := http.Get("http://" + addr + "/status")
+	resp, err := peerClient.Get("http://" + addr + "/status")
 	if err != nil {
 		return s, false
 	}
@@ -205,7 +209,7 @@ func broadcastNewBoot(newBoot string) {
 	for _, p := range peersSnapshot() {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"addr": newBoot})
-			_, err := http.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
+			_, err := peerClient.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -217,7 +221,7 @@ func broadcastNewBoot(newBoot string) {
 // POST : /bootNotify
 func bootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -226,12 +230,12 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.Addr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.Addr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.Addr)
 		return
 	}
@@ -256,9 +260,19 @@ func broadcastNewBootToHos(newBoot string) {
 		go func(id, dst string) {
 			log.Printf("[BOOT][ToHos] New Gov Boot Node's Addr is now sending to : %s", dst)
 			body, _ := json.Marshal(map[string]string{"gov_boot": newBoot})
-			_, err := http.Post("http://"+dst+"/chgGovBoot", "application/json", strings.NewReader(string(body)))
+			req, _ := http.NewRequest(http.MethodPost, "http://"+dst+"/chgGovBoot", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			if hosAPIKey != "" {
+				req.Header.Set("X-API-Key", hosAPIKey)
+			}
+			resp, err := http.DefaultClient.Do(req)
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				log.Printf("[BOOT] notify rejected by %s: %s", dst, resp.Status)
 			}
 		}(hosID, hosBoot)
 	}
@@ -275,7 +289,7 @@ func broadcastNewHosBoot(hosID, hosBoot string) {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"hos_id": hosID, "hos_boot": hosBoot})
 			logInfo("[BOOT] notify new hosBoot to %s", dst)
-			_, err := http.Post("http://"+dst+"/hosBootNotify", "application/json", strings.NewReader(string(body)))
+			_, err := peerClient.Post("http://"+dst+"/hosBootNotify", "application/json", strings.NewReader(string(body)))
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -288,7 +302,7 @@ func broadcastNewHosBoot(hosID, hosBoot string) {
 // POST : /hosBootNotify
 func hosBootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -298,12 +312,12 @@ func hosBootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 주소 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.HosBoot == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.HosBoot); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.HosBoot)
 		return
 	}
@@ -316,8 +330,12 @@ func hosBootNotify(w http.ResponseWriter, r *http.Request) {
 
 func setBootAddr(addr string) {
 	bootAddrMu.Lock()
+	prev := boot
 	boot = addr
 	bootAddrMu.Unlock()
+	if prev != addr {
+		publishEvent(EventBootElected, map[string]any{"boot": addr, "prev": prev, "is_self": addr == self})
+	}
 }
 func getBootAddr() string {
 	bootAddrMu.RLock()
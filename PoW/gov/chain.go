@@ -56,6 +56,14 @@ func newUpperChain(govID string) (*UpperChain, error) {
 
 	// 제네시스 블록 존재 여부 확인
 	genesis, err := getBlockByIndex(0)
+	// 난이도 조정 파라미터 결정 (제네시스에 기록된 규칙 우선, difficulty.go)
+	var recorded *UpperBlock
+	if err == nil {
+		recorded = &genesis
+	}
+	if derr := initDifficultyParams(DifficultyConfig, recorded); derr != nil {
+		return nil, fmt.Errorf("difficulty params: %w", derr)
+	}
 	// 제네시스 블록이 없는 경우
 	if err != nil {
 		log.Printf("[INIT] No genesis. Mining genesis...")
@@ -72,6 +80,7 @@ func newUpperChain(govID string) (*UpperChain, error) {
 			return nil, fmt.Errorf("set genesis height: %w", err)
 		}
 		ch.lastBlockTime = time.Now()
+		refreshDifficulty()
 
 		// 부트노드는 여기서 meta_gov_id 저장
 		putMeta("meta_gov_id", govID)
@@ -83,6 +92,8 @@ func newUpperChain(govID string) (*UpperChain, error) {
 	if err := putMeta("meta_gov_id", genesis.GovID); err != nil {
 		return nil, err
 	}
+	// 재시작 시 장부 기준으로 난이도 복원
+	refreshDifficulty()
 
 	return ch, nil
 }
@@ -104,6 +115,7 @@ func onBlockReceived(ub UpperBlock) error {
 	if !validHash(ub.BlockHash, ub.Difficulty) {
 		return fmt.Errorf("invalid PoW hash")
 	}
+	ub.Elapsed = blockInterval(ub, prev)
 
 	// 체인에 추가
 	if err := saveBlockToDB(ub); err != nil {
@@ -115,6 +127,10 @@ func onBlockReceived(ub UpperBlock) error {
 	if err := setLatestHeight(ub.Index); err != nil {
 		return fmt.Errorf("set height: %w", err)
 	}
+	// 장부에 반영된 블록 기준으로 다음 블록 난이도 계산 (제어 메시지 seq 먼저 기록)
+	recordControlSeq(ub.Control)
+	refreshDifficulty()
+	publishFinalizedAt(ub.Index)
 
 	logInfo("[CHAIN][UPPER] Accepted UpperBlock #%d (%s)", ub.Index, ub.BlockHash[:12])
 	return nil
@@ -148,6 +164,13 @@ func pendingIsEmpty() bool {
 	return len(ch.pending) == 0
 }
 
+// 메모리풀의 엔트리 개수 확인
+func getPendingCnt() int {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	return len(ch.pending)
+}
+
 func logInfo(format string, args ...interface{}) {
 	fmt.Printf("[INFO] "+format+"\n", args...)
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"log"
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 체인 식별 헤더 (X-Chain-ID)
+// ------------------------------------------------------------
+// - 노드 간 요청 헤더 X-Chain-ID : 보내는 노드의 제네시스 블록 해시 (peerClient 가 모든 요청에 기록, auth.go)
+//   · gov_id 는 구성값이라 같은 이름으로 새로 만든 체인과 구분되지 않음 => 제네시스 해시로 체인 식별
+// - 같은 체인 노드끼리만 주고받는 엔드포인트(/receiveBlock, /gossip/block, /mine/start)는 requireSameChain 으로
+//   헤더가 없거나 제네시스 해시가 다른 요청을 본문 처리 전에 거절 (같은 구성의 다른 체인 트래픽 혼입 방지)
+//   · Hos 등 다른 체인과 주고받는 엔드포인트는 확인하지 않음
+////////////////////////////////////////////////////////////////////////////////
+
+const ChainIDHeader = "X-Chain-ID"
+
+// X-Chain-ID 값 : 로컬 제네시스 블록 해시 (제네시스가 없으면 "")
+func localChainID() string {
+	genesis, err := getBlockByIndex(0)
+	if err != nil {
+		return ""
+	}
+	return genesis.BlockHash
+}
+
+// 노드 간 요청에 이 노드의 체인 식별값 기록 (peerAuthTransport 에서 호출)
+func stampChainID(req *http.Request) *http.Request {
+	if req.Header.Get(ChainIDHeader) != "" {
+		return req
+	}
+	id := localChainID()
+	if id == "" {
+		return req
+	}
+	out := req.Clone(req.Context())
+	out.Header.Set(ChainIDHeader, id)
+	return out
+}
+
+// 같은 체인 노드 전용 엔드포인트 보호 : X-Chain-ID 가 없거나 로컬 제네시스 해시와 다른 요청 거절
+func requireSameChain(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, local := r.Header.Get(ChainIDHeader), localChainID()
+		if local == "" {
+			writeError(w, http.StatusServiceUnavailable, "no genesis")
+			return
+		}
+		if id != local {
+			log.Printf("[P2P][DENY] %s from %s: chain_id=%q (local=%q)", r.URL.Path, r.RemoteAddr, id, local)
+			writeErrorDetail(w, http.StatusForbidden, "chain_mismatch", "request is missing "+ChainIDHeader+" or is from a different chain",
+				map[string]string{"chain_id": id, "expected": local})
+			return
+		}
+		h(w, r)
+	}
+}
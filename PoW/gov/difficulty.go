@@ -0,0 +1,451 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 난이도 관리
+// ------------------------------------------------------------
+// - 난이도는 피어가 보내주는 값을 신뢰하지 않고, 검증된 로컬 장부(블록 이력)로부터 계산
+//   => 블록 h 다음 블록의 난이도 = f(블록 h의 난이도, 블록 h-DiffWindow ~ h 의 타임스탬프 간격)
+//   · 채굴 노드가 보고하는 소요시간(Elapsed)은 블록 해시에 묶여 있지 않으므로 사용하지 않음
+// - 조정 파라미터(DifficultyParams)는 체인별 값 (제네시스 생성 시 설정값으로 정하고 meta_difficulty_params 에 기록)
+//   · diff_standard_time(초) / diff_window(블록) / diff_min / diff_max / diff_raise_ratio / diff_lower_ratio (config.go)
+//   · 기본값이 아니면 제네시스 블록에 difficulty_params 로 싣고 해시(params_hash)를 PoW 헤더에 묶음
+//     => 규칙이 다른 노드는 제네시스 해시부터 달라짐 (기본 규칙이면 생략되어 기존 제네시스 해시 그대로)
+//   · 재시작 시 설정값이 아니라 제네시스(없으면 meta)에 기록된 값을 사용 (값이 바뀌면 기존 블록 난이도 검증이 달라지므로)
+//   · 기록이 없는 기존 DB 는 이전 빌드 규칙 (diff_standard_time 설정값, 3블록, 1~7, 0.85/1.25)
+// - 블록 타임스탬프 규칙
+//   · median-time-past : 직전 MedianTimeWindow 개 블록 타임스탬프의 중앙값보다 이르면 거부
+//   · 로컬 시각보다 MaxBlockFutureDrift 이상 앞서면 거부
+//   · 채굴 노드는 max(현재 시각, median-time-past) 를 타임스탬프로 사용
+// - Elapsed 는 직전 블록과의 타임스탬프 간격으로 장부 반영 시 계산 (blockInterval)
+// - 긴급 조정은 부트노드가 서명한 제어 메시지(DifficultyControl)로만 가능하며,
+//   해당 메시지는 블록에 포함되어 장부에 기록됨 (기록된 블록 다음부터 적용)
+//   · seq 는 장부에 마지막으로 기록된 제어 메시지보다 커야 함 (이전 메시지 재전송 차단)
+// - 제어 메시지 서명 검증용 공개키는 설정(control_authority_key_file)으로만 지정
+//   (미설정 시 제어 메시지를 발행/수락하지 않음)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	MedianTimeWindow    = 11 // median-time-past 계산에 쓰는 직전 블록 수
+	MaxBlockFutureDrift = 2 * time.Minute
+	MaxDifficultyLimit  = 64 // 난이도 상한의 최대값 (SHA-256 hex 자릿수)
+	controlSeqMeta      = "meta_control_seq"
+	diffParamsMetaKey   = "meta_difficulty_params"
+)
+
+// 체인별 난이도 조정 파라미터
+type DifficultyParams struct {
+	TargetBlockTime int     `json:"target_block_time"` // 목표 블록 간격(초)
+	Window          int     `json:"window"`            // 평균 간격을 재는 블록 간격 수
+	MinDifficulty   int     `json:"min_difficulty"`
+	MaxDifficulty   int     `json:"max_difficulty"`
+	RaiseRatio      float64 `json:"raise_ratio"` // 평균 간격 / 목표 간격이 이보다 작으면 난이도 +1
+	LowerRatio      float64 `json:"lower_ratio"` // 이보다 크면 난이도 -1
+}
+
+// 이전 빌드의 고정 규칙 (기록이 없는 기존 체인, 목표 간격은 DiffStandardTime 기본값)
+var defaultDifficultyParams = DifficultyParams{TargetBlockTime: 20, Window: 3, MinDifficulty: 1, MaxDifficulty: 7, RaiseRatio: 0.85, LowerRatio: 1.25}
+
+var (
+	DifficultyConfig = defaultDifficultyParams // 새 체인의 난이도 규칙 (loadConfig)
+	diffParams       = defaultDifficultyParams // 이 체인에 기록된 규칙 (initDifficultyParams)
+)
+
+func (p DifficultyParams) validate() error {
+	switch {
+	case p.TargetBlockTime < 1:
+		return fmt.Errorf("target_block_time must be >= 1: %d", p.TargetBlockTime)
+	case p.Window < 1:
+		return fmt.Errorf("window must be >= 1: %d", p.Window)
+	case p.MinDifficulty < 1 || p.MaxDifficulty > MaxDifficultyLimit || p.MinDifficulty > p.MaxDifficulty:
+		return fmt.Errorf("difficulty range must be within 1..%d: %d..%d", MaxDifficultyLimit, p.MinDifficulty, p.MaxDifficulty)
+	case p.RaiseRatio <= 0 || p.RaiseRatio >= p.LowerRatio:
+		return fmt.Errorf("ratios must satisfy 0 < raise_ratio < lower_ratio: %g, %g", p.RaiseRatio, p.LowerRatio)
+	}
+	return nil
+}
+
+// 규칙 해시 (제네시스 PoW 헤더의 params_hash, nil 이면 "")
+func (p *DifficultyParams) hash() string {
+	if p == nil {
+		return ""
+	}
+	return sha256Hex(jsonCanonical(*p))
+}
+
+// 제네시스에 기록할 규칙 (기본 규칙이면 nil => 기존 제네시스와 같은 해시)
+func genesisDifficultyParams() *DifficultyParams {
+	if diffParams == defaultDifficultyParams {
+		return nil
+	}
+	p := diffParams
+	return &p
+}
+
+// 체인의 난이도 규칙 결정 (제네시스 채굴 전에 호출)
+//   - 제네시스에 기록된 규칙이 있으면 그대로 사용
+//   - 없으면 meta 에 기록된 값, 그것도 없으면 기존 DB(제네시스 존재)는 이전 빌드 규칙, 새 체인은 configured
+func initDifficultyParams(configured DifficultyParams, genesis *UpperBlock) error {
+	raw, recorded := getMeta(diffParamsMetaKey)
+	var p DifficultyParams
+	switch {
+	case genesis != nil && genesis.DifficultyParams != nil:
+		p = *genesis.DifficultyParams
+		if recorded && raw != "" {
+			var m DifficultyParams
+			if json.Unmarshal([]byte(raw), &m) != nil || m != p {
+				recorded = false // 제네시스 기준으로 meta 다시 기록
+			}
+		}
+	case recorded && raw != "":
+		if err := json.Unmarshal([]byte(raw), &p); err != nil {
+			return fmt.Errorf("recorded %s: %w", diffParamsMetaKey, err)
+		}
+	case genesis != nil:
+		// 이전 빌드는 목표 간격만 설정값을 사용
+		p = defaultDifficultyParams
+		p.TargetBlockTime = configured.TargetBlockTime
+	default:
+		p = configured
+	}
+	if err := p.validate(); err != nil {
+		return err
+	}
+	if configured != p {
+		log.Printf("[DIFF][WARN] difficulty settings ignored: chain uses %+v", p)
+	}
+	diffParams = p
+	if !recorded {
+		data, _ := json.Marshal(p)
+		if err := putMeta(diffParamsMetaKey, string(data)); err != nil {
+			return err
+		}
+	}
+	log.Printf("[DIFF] difficulty params: target=%ds window=%d range=%d..%d ratios=%g/%g",
+		p.TargetBlockTime, p.Window, p.MinDifficulty, p.MaxDifficulty, p.RaiseRatio, p.LowerRatio)
+	return nil
+}
+
+// 제어 메시지 서명 검증용 공개키 PEM (CONTROL_AUTHORITY_KEY_FILE, loadConfig 에서 설정)
+var controlAuthorityPem string
+
+// 부트노드 서명 난이도 제어 메시지
+type DifficultyControl struct {
+	Difficulty int    `json:"difficulty"` // 강제 적용할 난이도
+	Seq        int    `json:"seq"`        // 장부의 마지막 제어 메시지 seq + 1
+	Issuer     string `json:"issuer"`     // 발행한 부트노드 주소
+	Ts         string `json:"ts"`         // 발행 시각
+	Sig        string `json:"sig"`        // 부트노드 ECDSA 서명 (hex, DER)
+}
+
+var (
+	pendingControl *DifficultyControl // 다음 블록에 포함될 제어 메시지
+	controlMu      sync.Mutex
+)
+
+// 서명 대상 다이제스트 (서명 필드 제외)
+func (c DifficultyControl) digest() []byte {
+	body := struct {
+		Difficulty int    `json:"difficulty"`
+		Seq        int    `json:"seq"`
+		Issuer     string `json:"issuer"`
+		Ts         string `json:"ts"`
+	}{c.Difficulty, c.Seq, c.Issuer, c.Ts}
+	sum := sha256.Sum256(jsonCanonical(body))
+	return sum[:]
+}
+
+// 블록 헤더(PoWHeader.ControlHash)에 봉인되는 제어 메시지 해시
+func controlHash(c *DifficultyControl) string {
+	if c == nil {
+		return ""
+	}
+	return sha256Hex(jsonCanonical(c))
+}
+
+// 장부에 마지막으로 기록된 제어 메시지의 seq (없으면 0)
+func lastControlSeq() int {
+	v, ok := getMeta(controlSeqMeta)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.Atoi(v)
+	return n
+}
+
+// 블록에 기록된 제어 메시지의 seq 반영 (onBlockReceived)
+func recordControlSeq(c *DifficultyControl) {
+	if c == nil || c.Seq <= lastControlSeq() {
+		return
+	}
+	if err := putMeta(controlSeqMeta, strconv.Itoa(c.Seq)); err != nil {
+		log.Printf("[DIFF][CONTROL] failed to record control seq %d: %v", c.Seq, err)
+	}
+}
+
+// 제어 메시지 검증용 공개키 파싱
+func parseControlAuthority(pubPem string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pubPem))
+	if block == nil {
+		return nil, fmt.Errorf("invalid control authority key")
+	}
+	pubIfc, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid control authority key: %w", err)
+	}
+	pub, ok := pubIfc.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("control authority is not an ecdsa key")
+	}
+	return pub, nil
+}
+
+// 설정된 공개키로 제어 메시지 검증 (seq 는 장부의 마지막 제어 메시지보다 커야 함)
+func verifyControl(c *DifficultyControl) error {
+	if c.Difficulty < diffParams.MinDifficulty || c.Difficulty > diffParams.MaxDifficulty {
+		return fmt.Errorf("difficulty out of range: %d", c.Difficulty)
+	}
+	if last := lastControlSeq(); c.Seq <= last {
+		return fmt.Errorf("stale control seq %d (last recorded %d)", c.Seq, last)
+	}
+	if controlAuthorityPem == "" {
+		return fmt.Errorf("control authority not configured")
+	}
+	pub, err := parseControlAuthority(controlAuthorityPem)
+	if err != nil {
+		return err
+	}
+	sig, err := hex.DecodeString(c.Sig)
+	if err != nil {
+		return fmt.Errorf("invalid control signature encoding")
+	}
+	if !ecdsa.VerifyASN1(pub, c.digest(), sig) {
+		return fmt.Errorf("control signature verification failed")
+	}
+	return nil
+}
+
+// 설정 파일에서 제어 메시지 검증용 공개키 로드 (경로가 비어 있으면 제어 메시지 비활성)
+func loadControlAuthority(path string) error {
+	controlAuthorityPem = ""
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if _, err := parseControlAuthority(string(data)); err != nil {
+		return err
+	}
+	controlAuthorityPem = string(data)
+	return nil
+}
+
+// 메모리풀에 대기 중인 제어 메시지 존재 여부
+func hasPendingControl() bool {
+	controlMu.Lock()
+	defer controlMu.Unlock()
+	return pendingControl != nil
+}
+
+// 대기 중인 제어 메시지를 꺼내고 비움
+func takePendingControl() *DifficultyControl {
+	controlMu.Lock()
+	defer controlMu.Unlock()
+	c := pendingControl
+	pendingControl = nil
+	return c
+}
+
+// 난이도 계산 규칙 (체인에 기록된 diffParams, window 블록 평균 간격 기준)
+func nextDifficulty(base int, avg float64) int {
+	p := diffParams
+	ratio := avg / float64(p.TargetBlockTime)
+
+	next := base
+	// 너무 일찍 끝났다면 난이도 올림
+	if ratio < p.RaiseRatio {
+		next++
+	} else if ratio > p.LowerRatio { // 너무 오래 걸렸다면 난이도 낮춤
+		next--
+	}
+	return min(max(next, p.MinDifficulty), p.MaxDifficulty)
+}
+
+// 블록 height 다음에 채굴될 블록이 가져야 하는 난이도를 장부로부터 계산
+func expectedDifficulty(height int) (int, error) {
+	blk, err := getBlockByIndex(height)
+	if err != nil {
+		return 0, fmt.Errorf("load block #%d: %w", height, err)
+	}
+	// 제어 메시지가 기록된 블록이라면 다음 블록부터 해당 난이도 강제 적용
+	if blk.Control != nil {
+		return blk.Control.Difficulty, nil
+	}
+
+	// 구간이 제네시스(고정 타임스탬프)에 걸치면 직전 난이도 유지
+	window := diffParams.Window
+	if height <= window {
+		return blk.Difficulty, nil
+	}
+	first, err := getBlockByIndex(height - window)
+	if err != nil {
+		return 0, fmt.Errorf("load block #%d: %w", height-window, err)
+	}
+	t0, err := time.Parse(time.RFC3339, first.Timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("block #%d timestamp: %w", first.Index, err)
+	}
+	t1, err := time.Parse(time.RFC3339, blk.Timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("block #%d timestamp: %w", blk.Index, err)
+	}
+	return nextDifficulty(blk.Difficulty, t1.Sub(t0).Seconds()/float64(window)), nil
+}
+
+// 블록 타임스탬프 규칙 검증 (median-time-past, 미래 시각 허용 범위)
+func checkTimestampRule(newBlk, prevBlk UpperBlock) error {
+	ts, err := time.Parse(time.RFC3339, newBlk.Timestamp)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", newBlk.Timestamp)
+	}
+	mtp, err := medianTimePast(prevBlk)
+	if err != nil {
+		return err
+	}
+	if ts.Before(mtp) {
+		return fmt.Errorf("timestamp %s before median time past %s", newBlk.Timestamp, mtp.Format(time.RFC3339))
+	}
+	if ts.After(time.Now().Add(MaxBlockFutureDrift)) {
+		return fmt.Errorf("timestamp %s too far in the future", newBlk.Timestamp)
+	}
+	return nil
+}
+
+// prev 까지 직전 MedianTimeWindow 개 블록 타임스탬프의 중앙값
+func medianTimePast(prev UpperBlock) (time.Time, error) {
+	times := make([]time.Time, 0, MedianTimeWindow)
+	for i := prev.Index; i >= 0 && i > prev.Index-MedianTimeWindow; i-- {
+		b := prev
+		if i != prev.Index {
+			var err error
+			if b, err = getBlockByIndex(i); err != nil {
+				return time.Time{}, fmt.Errorf("load block #%d for median time: %w", i, err)
+			}
+		}
+		t, err := time.Parse(time.RFC3339, b.Timestamp)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("block #%d timestamp: %w", i, err)
+		}
+		times = append(times, t)
+	}
+	sort.Slice(times, func(a, b int) bool { return times[a].Before(times[b]) })
+	return times[len(times)/2], nil
+}
+
+// 직전 블록과의 타임스탬프 간격(초), 블록 Elapsed 로 기록
+//   - 제네시스 타임스탬프는 고정값이므로 #1 은 0
+func blockInterval(newBlk, prevBlk UpperBlock) float32 {
+	if prevBlk.Index == 0 {
+		return 0
+	}
+	t1, err1 := time.Parse(time.RFC3339, newBlk.Timestamp)
+	t0, err0 := time.Parse(time.RFC3339, prevBlk.Timestamp)
+	if err1 != nil || err0 != nil || t1.Before(t0) {
+		return 0
+	}
+	return float32(t1.Sub(t0).Seconds())
+}
+
+// 로컬 장부의 최신 블록 기준으로 GlobalDifficulty 재계산
+func refreshDifficulty() {
+	h, ok := getLatestHeight()
+	if !ok {
+		return
+	}
+	d, err := expectedDifficulty(h)
+	if err != nil {
+		log.Printf("[DIFF] refresh failed: %v", err)
+		return
+	}
+	if d != GlobalDifficulty {
+		log.Printf("[DIFF] Difficulty derived from chain (height=%d): %d => %d", h, GlobalDifficulty, d)
+	}
+	GlobalDifficulty = d
+}
+
+// 부트노드 전용 : 긴급 난이도 조정 제어 메시지 발행
+// POST /control/difficulty {"difficulty": <int>}
+func handleDifficultyControl(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !isBoot.Load() {
+		writeError(w, http.StatusForbidden, "only boot node can issue control messages")
+		return
+	}
+	var req struct {
+		Difficulty int `json:"difficulty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	defer r.Body.Close()
+
+	privPem, _ := getMeta("meta_gov_privkey")
+	block, _ := pem.Decode([]byte(privPem))
+	if block == nil {
+		writeError(w, http.StatusInternalServerError, "private key not found")
+		return
+	}
+	priv, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "invalid private key")
+		return
+	}
+
+	c := &DifficultyControl{
+		Difficulty: req.Difficulty,
+		Seq:        lastControlSeq() + 1,
+		Issuer:     self,
+		Ts:         time.Unix(time.Now().Unix(), 0).Format(time.RFC3339),
+	}
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, c.digest())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to sign control message")
+		return
+	}
+	c.Sig = hex.EncodeToString(sig)
+
+	// 설정된 공개키로 검증되지 않는 메시지(예: 재선출된 부트노드)는 발행하지 않음
+	if err := verifyControl(c); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	controlMu.Lock()
+	pendingControl = c
+	controlMu.Unlock()
+	log.Printf("[DIFF][CONTROL] Difficulty override issued => %d (recorded in next block)", c.Difficulty)
+	writeJSON(w, http.StatusOK, c)
+}
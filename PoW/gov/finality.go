@@ -0,0 +1,49 @@
+package main
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+// 블록 확정성 (PoW 확인 수 / 최종성 깊이)
+// ------------------------------------------------------------
+// - Gov 블록 응답에 confirmations(최신 높이 - 블록 높이), final(confirmations >= FinalityDepth) 포함
+// - /query 결과에는 Hos 노드가 계산한 확인 수/최종성을 그대로 전달
+//   · /query?require_final=true : 최종성 깊이 미달 블록의 증명이 포함되면 거부(409)
+////////////////////////////////////////////////////////////////////////////////
+
+const DefaultFinalityDepth = 6
+
+var FinalityDepth = DefaultFinalityDepth // 설정 finality_depth (FINALITY_DEPTH) 로 변경 가능
+
+// 블록 높이 기준 확인 수 (최신 높이 - 블록 높이)
+func confirmations(height int) int {
+	tip, _ := getLatestHeight()
+	if tip < height {
+		return 0
+	}
+	return tip - height
+}
+
+// 확인 수를 포함한 블록 응답
+type BlockView struct {
+	UpperBlock
+	Confirmations int  `json:"confirmations"`
+	Final         bool `json:"final"`
+}
+
+func newBlockView(b UpperBlock) BlockView {
+	c := confirmations(b.Index)
+	return BlockView{UpperBlock: b, Confirmations: c, Final: c >= FinalityDepth}
+}
+
+func newBlockViews(blocks []UpperBlock) []BlockView {
+	out := make([]BlockView, 0, len(blocks))
+	for _, b := range blocks {
+		out = append(out, newBlockView(b))
+	}
+	return out
+}
+
+// 최종성 미달 경고 문구
+func shallowWarning(c, depth int) string {
+	return fmt.Sprintf("block has %d confirmations, below finality depth %d; the proof may be invalidated by a reorg", c, depth)
+}
@@ -5,3 +5,11 @@ go 1.25
 require github.com/syndtr/goleveldb v1.0.0
 
 require github.com/golang/snappy v1.0.0 // indirect
+
+require wsconn v0.0.0
+
+replace wsconn => ../../wsconn
+
+require merkle v0.0.0
+
+replace merkle => ../../merkle
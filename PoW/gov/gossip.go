@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Gossip 기반 블록 전파
+// ------------------------------------------------------------
+// - 전체 노드에 블록 본문을 보내는 대신(N²), 블록 해시만 무작위 일부 피어(GossipFanout)에 알림
+// - 알림을 받은 노드는 처음 보는 해시일 때만 알린 노드의 /block/hash 로 본문을 pull
+// - 검증/반영이 끝나면 다시 무작위 일부 피어에게 재전파 (해시 기준 중복 제거)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	GossipFanout  = 3   // 한 번에 알릴 피어 수
+	GossipSeenTTL = 600 // 중복 제거용 해시 보관 시간(초)
+)
+
+// 블록 알림 메시지 (본문 제외)
+type blockAnnounce struct {
+	Index int    `json:"index"`
+	Hash  string `json:"hash"`
+	From  string `json:"from"` // 본문을 pull 할 노드 주소
+}
+
+var (
+	seenBlocks = make(map[string]time.Time) // 블록 해시 => 최초 수신 시각
+	seenMu     sync.Mutex
+)
+
+// 처음 보는 해시라면 기록하고 true 반환
+func markSeen(hash string) bool {
+	seenMu.Lock()
+	defer seenMu.Unlock()
+	now := time.Now()
+	for h, t := range seenBlocks {
+		if now.Sub(t) > GossipSeenTTL*time.Second {
+			delete(seenBlocks, h)
+		}
+	}
+	if _, ok := seenBlocks[hash]; ok {
+		return false
+	}
+	seenBlocks[hash] = now
+	return true
+}
+
+// 무작위 피어 일부에게 블록 해시 알림 (exclude : 알림을 보내준 노드)
+func gossipAnnounce(ann blockAnnounce, exclude string) {
+	candidates := []string{}
+	for _, p := range peersSnapshot() {
+		if p != exclude && p != self {
+			candidates = append(candidates, p)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if len(candidates) > GossipFanout {
+		candidates = candidates[:GossipFanout]
+	}
+
+	body, _ := json.Marshal(ann)
+	for _, addr := range candidates {
+		go func(addr string) {
+			resp, err := peerClient.Post("http://"+addr+"/gossip/block", "application/json", bytes.NewReader(body))
+			if err != nil {
+				log.Printf("[GOSSIP] announce to %s failed: %v", addr, err)
+				return
+			}
+			resp.Body.Close()
+		}(addr)
+	}
+	log.Printf("[GOSSIP] Announced Block #%d (%s) to %d peers", ann.Index, ann.Hash[:12], len(candidates))
+}
+
+// 블록 알림 수신
+// POST /gossip/block
+func handleGossipBlock(w http.ResponseWriter, r *http.Request) {
+	var ann blockAnnounce
+	if err := json.NewDecoder(r.Body).Decode(&ann); err != nil || ann.Hash == "" || ann.From == "" {
+		writeError(w, http.StatusBadRequest, "invalid announce")
+		return
+	}
+	defer r.Body.Close()
+	w.WriteHeader(http.StatusOK)
+
+	if !markSeen(ann.Hash) {
+		return
+	}
+	// 이미 해당 높이의 블록이 있으면 pull 하지 않음
+	if _, err := getBlockByIndex(ann.Index); err == nil {
+		return
+	}
+	go pullAndApplyBlock(ann)
+}
+
+// 알린 노드로부터 블록 본문을 받아 검증 후 반영하고 재전파
+func pullAndApplyBlock(ann blockAnnounce) {
+	blk, err := fetchBlockByHash(ann.From, ann.Hash)
+	if err != nil {
+		log.Printf("[GOSSIP] pull Block #%d from %s failed: %v", ann.Index, ann.From, err)
+		return
+	}
+
+	// 새 블록이 들어왔으므로 진행 중인 채굴 중단
+	miningStop.Store(true)
+
+	chainMu.Lock()
+	prev, err := getBlockByIndex(blk.Index - 1)
+	if err != nil {
+		chainMu.Unlock()
+		log.Printf("[GOSSIP] Missing prev block #%d => sync from %s", blk.Index-1, ann.From)
+		go syncChain(ann.From)
+		return
+	}
+	if err := validateUpperBlock(blk, prev); err != nil {
+		chainMu.Unlock()
+		log.Printf("[GOSSIP] Invalid Block #%d from %s: %v", blk.Index, ann.From, err)
+		return
+	}
+	if err := onBlockReceived(blk); err != nil {
+		chainMu.Unlock()
+		log.Printf("[GOSSIP] Apply Block #%d failed: %v", blk.Index, err)
+		return
+	}
+	chainMu.Unlock()
+	isMining.Store(false)
+
+	log.Printf("[GOSSIP] Block accepted: index=%d hash=%s (from %s)", blk.Index, blk.BlockHash[:12], ann.From)
+	gossipAnnounce(blockAnnounce{Index: blk.Index, Hash: blk.BlockHash, From: self}, ann.From)
+}
+
+// GET /block/hash?value=<hash>
+func fetchBlockByHash(addr, hash string) (UpperBlock, error) {
+	resp, err := peerClient.Get("http://" + addr + "/block/hash?value=" + url.QueryEscape(hash))
+	if err != nil {
+		return UpperBlock{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return UpperBlock{}, fmt.Errorf("status=%d", resp.StatusCode)
+	}
+	var blk UpperBlock
+	if err := json.NewDecoder(resp.Body).Decode(&blk); err != nil {
+		return UpperBlock{}, err
+	}
+	if blk.BlockHash != hash {
+		return UpperBlock{}, fmt.Errorf("hash mismatch")
+	}
+	return blk, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Inclusion (조회 응답의 레코드 포함 정보)
+// ------------------------------------------------------------
+// - /query 응답의 각 항목에 inclusion 객체로 포함 (Hos /search 와 동일 구조)
+//   · block_index / entry_index / block_timestamp / confirmations : Hos 가 제공
+//   · final : Gov 의 FinalityDepth 기준으로 다시 판정
+//   · anchor_status / upper_block_index : Gov 장부 기준으로 다시 판정
+//     (anchored : 상위 블록에 포함 / pending : 수락되었으나 미포함 / unknown : 기록 없음)
+// - GET /anchor/status?hos_id=&root= : Hos 가 자기 블록의 앵커 상태를 조회
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	AnchorStatusAnchored = "anchored"
+	AnchorStatusPending  = "pending"
+	AnchorStatusUnknown  = "unknown"
+)
+
+type Inclusion struct {
+	BlockIndex      int    `json:"block_index"`
+	EntryIndex      int    `json:"entry_index"`
+	BlockTimestamp  string `json:"block_timestamp"`
+	Confirmations   int    `json:"confirmations"`
+	Final           bool   `json:"final"`
+	AnchorStatus    string `json:"anchor_status"`
+	UpperBlockIndex *int   `json:"upper_block_index,omitempty"` // anchored 인 경우 Gov 블록 번호
+}
+
+type AnchorStatusResponse struct {
+	HosID           string `json:"hos_id"`
+	Root            string `json:"root"`
+	AnchorStatus    string `json:"anchor_status"`
+	UpperBlockIndex *int   `json:"upper_block_index,omitempty"`
+}
+
+// 앵커 루트 색인 키 (anchor_ 접두어는 최신 앵커 복원에 쓰이므로 별도 접두어 사용)
+func anchorRootKey(root string) []byte {
+	return []byte("aroot_" + root)
+}
+
+// hosID 의 root 가 기록된 상위 블록 조회 (색인 우선, 색인 이전 블록은 전체 스캔)
+// - 색인과 블록 본문은 같은 스냅샷에서 읽음
+func findAnchoredBlock(hosID, root string) (UpperBlock, bool) {
+	var (
+		found UpperBlock
+		ok    bool
+	)
+	_ = withReadSnapshot(func(rd dbReader) error {
+		found, ok = findAnchoredBlockFrom(rd, hosID, root)
+		return nil
+	})
+	return found, ok
+}
+func findAnchoredBlockFrom(rd dbReader, hosID, root string) (UpperBlock, bool) {
+	if v, err := rd.Get(anchorRootKey(root), nil); err == nil {
+		if bi, ei, ok := parsePtr(string(v)); ok {
+			if b, err := getBlockByIndexFrom(rd, bi); err == nil && ei < len(b.Records) &&
+				b.Records[ei].HosID == hosID && b.Records[ei].LowerRoot == root {
+				return b, true
+			}
+		}
+	}
+	blocks, err := listAllBlocksFrom(rd)
+	if err != nil {
+		return UpperBlock{}, false
+	}
+	for _, b := range blocks {
+		for _, rec := range b.Records {
+			if rec.HosID == hosID && rec.LowerRoot == root {
+				return b, true
+			}
+		}
+	}
+	return UpperBlock{}, false
+}
+
+// 수락되었으나 아직 블록에 포함되지 않은 앵커인지 확인
+func isPendingAnchor(hosID, root string) bool {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	for _, rec := range ch.pending {
+		if rec.HosID == hosID && rec.LowerRoot == root {
+			return true
+		}
+	}
+	return false
+}
+
+// hosID 의 root 앵커 상태 (anchored 면 상위 블록 번호 포함)
+func anchorStatusOf(hosID, root string) (string, *int) {
+	if b, ok := findAnchoredBlock(hosID, root); ok {
+		idx := b.Index
+		return AnchorStatusAnchored, &idx
+	}
+	if isPendingAnchor(hosID, root) {
+		return AnchorStatusPending, nil
+	}
+	return AnchorStatusUnknown, nil
+}
+
+// GET /anchor/status
+func handleAnchorStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	st := AnchorStatusResponse{
+		HosID: r.URL.Query().Get("hos_id"),
+		Root:  r.URL.Query().Get("root"),
+	}
+	if st.HosID == "" || st.Root == "" {
+		writeError(w, http.StatusBadRequest, "hos_id and root required")
+		return
+	}
+	st.AnchorStatus, st.UpperBlockIndex = anchorStatusOf(st.HosID, st.Root)
+	writeJSON(w, http.StatusOK, st)
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Prometheus 메트릭 (GET /metrics, text exposition format 0.0.4)
+// ------------------------------------------------------------
+// - 게이지(조회 시점 계산) : 체인 높이, 메모리풀 수, 피어 수, 동기화 지연(피어 최대 높이 - 내 높이), 마지막 채굴 해시율
+// - 카운터/히스토그램(이벤트 누적) : 채굴 소요시간, LevelDB 오류, Hos 앵커 수신 결과(수락/거부)
+// - 모든 시계열에 node_role(gov), chain_id(Gov 식별자) 라벨을 붙여 cp/ott/hos/gov 노드를 한 대시보드에서 구분
+////////////////////////////////////////////////////////////////////////////////
+
+const metricsNodeRole = "gov"
+
+// 소요시간 히스토그램 구간 상한(초)
+var metricDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type metricHistogram struct {
+	counts []uint64 // 구간별 건수 (출력 시 누적)
+	sum    float64
+	count  uint64
+}
+
+var (
+	metricsMu      sync.Mutex
+	metricCounters = make(map[string]map[string]float64) // 이름 => 라벨 => 값
+	metricHists    = make(map[string]*metricHistogram)   // 이름 => 히스토그램
+
+	peerHeights   = make(map[string]int) // 피어 주소 => 마지막으로 확인한 높이
+	peerHeightsMu sync.Mutex
+)
+
+var metricHelp = map[string][2]string{
+	"chain_height":                   {"gauge", "Latest block height of this node."},
+	"chain_pending_entries":          {"gauge", "Entries waiting in the mempool."},
+	"chain_peers":                    {"gauge", "Number of known peers."},
+	"chain_sync_lag_blocks":          {"gauge", "Highest peer height seen minus local height."},
+	"chain_mining_duration_seconds":  {"histogram", "Time spent finding a valid PoW nonce."},
+	"chain_mining_hashes_total":      {"counter", "PoW hashes computed by all mining workers."},
+	"chain_mining_hashrate":          {"gauge", "Aggregate hashes per second across mining workers during the last mining run."},
+	"chain_leveldb_errors_total":     {"counter", "LevelDB operation errors (excluding not-found)."},
+	"chain_anchor_submissions_total": {"counter", "Anchor submissions received from Hos chains by result."},
+}
+
+// 카운터 증가 (labels 는 `key="value",...` 형식, 없으면 "")
+func incCounter(name, labels string) {
+	addCounter(name, labels, 1)
+}
+
+// 카운터에 delta 더하기
+func addCounter(name, labels string, delta float64) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	m, ok := metricCounters[name]
+	if !ok {
+		m = make(map[string]float64)
+		metricCounters[name] = m
+	}
+	m[labels] += delta
+}
+
+func observeDuration(name string, seconds float64) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	h, ok := metricHists[name]
+	if !ok {
+		h = &metricHistogram{counts: make([]uint64, len(metricDurationBuckets))}
+		metricHists[name] = h
+	}
+	for i, ub := range metricDurationBuckets {
+		if seconds <= ub {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// LevelDB 오류 집계 후 그대로 반환 (ErrNotFound 는 정상 흐름이므로 제외)
+func countDBError(err error) error {
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		incCounter("chain_leveldb_errors_total", "")
+	}
+	return err
+}
+
+// 앵커 수신 결과 기록 (200 => accepted, 그 외 => rejected)
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func countAnchorResults(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h(rec, r)
+		result := "accepted"
+		if rec.status != http.StatusOK {
+			result = "rejected"
+		}
+		incCounter("chain_anchor_submissions_total", `result="`+result+`"`)
+	}
+}
+
+// 피어 높이 기록 (동기화 지연 계산용)
+func observePeerHeight(addr string, h int) {
+	peerHeightsMu.Lock()
+	peerHeights[addr] = h
+	peerHeightsMu.Unlock()
+}
+
+// 현재 피어 중 최대 높이 - 내 높이 (음수면 0)
+func syncLag(local int) int {
+	peers := peersSnapshot()
+	peerHeightsMu.Lock()
+	defer peerHeightsMu.Unlock()
+	best := local
+	for _, p := range peers {
+		if h, ok := peerHeights[p]; ok && h > best {
+			best = h
+		}
+	}
+	return best - local
+}
+
+// GET /metrics
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	height, _ := getLatestHeight()
+	gauges := map[string]float64{
+		"chain_height":          float64(height),
+		"chain_pending_entries": float64(getPendingCnt()),
+		"chain_peers":           float64(len(peersSnapshot())),
+		"chain_sync_lag_blocks": float64(syncLag(height)),
+		"chain_mining_hashrate": miningHashRate(),
+	}
+
+	var sb strings.Builder
+	role := fmt.Sprintf(`node_role=%q,chain_id=%q`, metricsNodeRole, selfID())
+	withRole := func(labels string) string {
+		if labels == "" {
+			return "{" + role + "}"
+		}
+		return "{" + role + "," + labels + "}"
+	}
+
+	metricsMu.Lock()
+	names := make([]string, 0, len(metricHelp))
+	for n := range metricHelp {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		meta := metricHelp[n]
+		fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s %s\n", n, meta[1], n, meta[0])
+		switch meta[0] {
+		case "gauge":
+			fmt.Fprintf(&sb, "%s%s %g\n", n, withRole(""), gauges[n])
+		case "counter":
+			series := metricCounters[n]
+			if len(series) == 0 {
+				fmt.Fprintf(&sb, "%s%s 0\n", n, withRole(""))
+				continue
+			}
+			labels := make([]string, 0, len(series))
+			for l := range series {
+				labels = append(labels, l)
+			}
+			sort.Strings(labels)
+			for _, l := range labels {
+				fmt.Fprintf(&sb, "%s%s %g\n", n, withRole(l), series[l])
+			}
+		case "histogram":
+			h := metricHists[n]
+			if h == nil {
+				h = &metricHistogram{counts: make([]uint64, len(metricDurationBuckets))}
+			}
+			var cum uint64
+			for i, ub := range metricDurationBuckets {
+				cum += h.counts[i]
+				fmt.Fprintf(&sb, "%s_bucket%s %d\n", n, withRole(`le="`+strconv.FormatFloat(ub, 'g', -1, 64)+`"`), cum)
+			}
+			fmt.Fprintf(&sb, "%s_bucket%s %d\n", n, withRole(`le="+Inf"`), h.count)
+			fmt.Fprintf(&sb, "%s_sum%s %g\n", n, withRole(""), h.sum)
+			fmt.Fprintf(&sb, "%s_count%s %d\n", n, withRole(""), h.count)
+		}
+	}
+	metricsMu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(sb.String()))
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
-	"crypto/sha256"
+	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"log"
-	"math/rand"
+	"math"
 	"net/http"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,9 +22,38 @@ import (
 // - 모든 노드가 동시에 채굴 수행
 // - 난이도 조건을 가장 먼저 만족한 노드가 블록 브로드캐스트
 // - 다른 노드는 즉시 채굴 중단 후 검증(verifyBlock) → 체인에 추가
-// - 동일한 GlobalDifficulty 사용
+// - 동일한 GlobalDifficulty 사용 (장부로부터 계산, difficulty.go 참고)
+// - nonce 는 32비트 공간(NonceSpace)을 crypto/rand 로 고른 시작점부터 탐색, 끝에 닿으면 0 으로 wraparound
+//   · 시작점으로 돌아오면(한 바퀴 소진) extra_nonce 를 올려 헤더를 바꾼 뒤 계속 탐색
+//   · MiningTimestampRefresh 마다 타임스탬프를 현재 시각(median-time-past 이상)으로 갱신
+// - nonce 탐색은 MiningWorkers 개 워커가 nonce 공간을 나눠 병렬 수행 (MINING_WORKERS, 기본 GOMAXPROCS)
+//   · 워커 i 는 시작 nonce + i 부터 워커 수 간격으로 탐색, 하나가 찾거나 miningStop 이면 모두 중단
+//   · 탐색한 해시 수/초당 해시 수는 /metrics 로 노출 (chain_mining_hashes_total, chain_mining_hashrate)
+//   · 제네시스는 모든 노드가 같은 nonce 를 얻어야 하므로 단일 탐색 유지 (block.go)
+//   · 워커는 미리 인코딩한 헤더에 nonce 만 채워 해시 (powhash.go)
 ////////////////////////////////////////////////////////////////////////////////
 
+var (
+	MiningWorkers = runtime.GOMAXPROCS(0) // nonce 탐색 워커 수
+	hashRateBits  atomic.Uint64           // 마지막 채굴의 초당 해시 수 (float64 비트)
+)
+
+const (
+	hashCountBatch         = 1024             // 워커가 중단 플래그 확인/해시 수 집계를 하는 간격
+	NonceSpace             = 1 << 32          // 헤더 하나에서 탐색하는 nonce 범위 [0, NonceSpace)
+	MiningTimestampRefresh = 15 * time.Second // 채굴 중 타임스탬프 갱신 주기
+)
+
+// nonce 탐색 종료 사유
+type nonceSearch int
+
+const (
+	nonceFound     nonceSearch = iota
+	nonceStopped               // miningStop (다른 노드 블록 수신)
+	nonceExhausted             // nonce 공간 한 바퀴 소진 => extra_nonce 증가
+	nonceRefresh               // 타임스탬프 갱신 시점
+)
+
 // 채굴 시 해시 계산 대상 최소 정보
 type PoWHeader struct {
 	Index      int    `json:"index"`
@@ -27,7 +61,11 @@ type PoWHeader struct {
 	MerkleRoot string `json:"merkle_root"`
 	Timestamp  string `json:"timestamp"`
 	Difficulty int    `json:"difficulty"`
+	ExtraNonce int    `json:"extra_nonce,omitempty"` // nonce 공간을 다 돌면 증가 (0 이면 생략되어 기존 블록 해시와 동일)
+	ParamsHash string `json:"params_hash,omitempty"` // 제네시스의 난이도 규칙 해시 (기본 규칙이면 생략되어 기존 제네시스 해시와 동일)
 	Nonce      int    `json:"nonce"`
+	// 부트노드 제어 메시지 해시 (없으면 생략되어 기존 블록 해시와 동일)
+	ControlHash string `json:"control_hash,omitempty"`
 }
 
 // 채굴 성공 결과
@@ -36,6 +74,7 @@ type MineResult struct {
 	Nonce     int
 	Header    PoWHeader
 	Elapsed   float32
+	Control   *DifficultyControl
 }
 
 // 채굴되지 않은 pending 을 감시해서 채굴 시작 신호 보내는 watcher
@@ -45,28 +84,29 @@ func startMiningWatcher() {
 
 	for range t.C {
 
-		// 이미 채굴 중이거나 메모리풀이 비었으면 아무것도 안함
-		if isMining.Load() || pendingIsEmpty() {
+		// 이미 채굴 중이거나 메모리풀(제어 메시지 포함)이 비었으면 아무것도 안함
+		if isMining.Load() || (pendingIsEmpty() && !hasPendingControl()) {
 			continue
 		}
 
 		// 메모리풀에 레코드가 있고 채굴 중이 아니면 채굴 시작 signal
 		records := getPending()
+		control := takePendingControl()
 		log.Printf("[WATCHER] Pending detected => Starting mining (%d anchors)", len(records))
-		sendMiningSignal(records)
+		sendMiningSignal(records, control)
 	}
 }
 
 // 모든 노드에 채굴 요청 전파
-func sendMiningSignal(anchors []AnchorRecord) {
-	req, _ := json.Marshal(map[string]any{"anchors": anchors})
+func sendMiningSignal(anchors []AnchorRecord, control *DifficultyControl) {
+	req, _ := json.Marshal(map[string]any{"anchors": anchors, "control": control})
 	log.Printf("[POW][NETWORK] Starting Network Mining Order")
 
 	// peerSnapshot은 자기자신을 포함하지 않으므로 추가
 	nodes := append(peersSnapshot(), self)
 	for _, node := range nodes {
 		go func(addr string) {
-			http.Post("http://"+addr+"/mine/start", "application/json", strings.NewReader(string(req)))
+			peerClient.Post("http://"+addr+"/mine/start", "application/json", strings.NewReader(string(req)))
 			log.Printf("[POW][NETWORK] Broadcasted Mining signal to %s", addr)
 		}(node)
 	}
@@ -77,45 +117,56 @@ func sendMiningSignal(anchors []AnchorRecord) {
 // GET /mine/start
 func handleMineStart(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		Anchors []AnchorRecord `json:"anchors"`
+		Anchors []AnchorRecord     `json:"anchors"`
+		Control *DifficultyControl `json:"control"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid JSON")
 		return
 	}
 	defer r.Body.Close()
 
 	anchors := req.Anchors
-	if len(anchors) == 0 {
+	if len(anchors) == 0 && req.Control == nil {
 		log.Printf("[PoW][NODE] No anchors to mine. Skip.")
+		writeError(w, http.StatusBadRequest, "no anchors to mine")
 		return
 	}
+	// 제어 메시지는 부트노드 서명이 유효한 경우에만 블록에 포함
+	if req.Control != nil {
+		if err := verifyControl(req.Control); err != nil {
+			log.Printf("[PoW][NODE] Invalid control message rejected: %v", err)
+			writeError(w, http.StatusForbidden, err.Error())
+			return
+		}
+	}
 	// CAS: mining 시작 시점 보호
 	if !isMining.CompareAndSwap(false, true) {
 		log.Printf("[PoW][NODE] Mining already in progress => cancel new mining")
+		writeError(w, http.StatusConflict, "mining already in progress")
 		return
 	}
 
 	log.Printf("[PoW][NODE] Received mining start signal with anchors: %d", len(anchors))
-	go func(anchors []AnchorRecord) {
+	go func(anchors []AnchorRecord, control *DifficultyControl) {
 		// entries를 활용해 실제 채굴 시작
-		result := mineBlock(GlobalDifficulty, anchors)
+		result := mineBlock(GlobalDifficulty, anchors, control)
 		if result.BlockHash == "" {
 			log.Printf("[POW][NODE] Mining aborted")
 			return
 		}
 		log.Printf("[PoW][NODE] ✅ Success New Block Mining #%d hash=%s elapsed=%ds", result.Header.Index, result.BlockHash[:12], result.Elapsed)
-		adjustDifficulty(result.Header.Index, result.Elapsed) // 채굴 난이도 조정
+		// 난이도 조정은 블록이 장부에 반영될 때(onBlockReceived) 장부 기준으로 수행
 		broadcastBlock(result, anchors)
 
-	}(anchors)
+	}(anchors, req.Control)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{"status": "mining started"})
 }
 
 // PoW 채굴 수행
 // 항상 현재 로컬 체인 상태 기반으로 시작
-func mineBlock(difficulty int, anchors []AnchorRecord) MineResult {
+func mineBlock(difficulty int, anchors []AnchorRecord, control *DifficultyControl) MineResult {
 
 	miningStop.Store(false)
 	mineStart := time.Now()
@@ -134,6 +185,14 @@ func mineBlock(difficulty int, anchors []AnchorRecord) MineResult {
 		return MineResult{}
 	}
 
+	// 로컬 시계가 늦은 경우에도 median-time-past 규칙 충족 (miningTimestamp)
+	mtp, err := medianTimePast(prev)
+	if err != nil {
+		log.Printf("[PoW] Failed to compute median time past: %v", err)
+		isMining.Store(false)
+		return MineResult{}
+	}
+
 	// 새로운 블록 헤더 구성
 	index := prev.Index + 1
 	prevHash := prev.BlockHash
@@ -142,102 +201,191 @@ func mineBlock(difficulty int, anchors []AnchorRecord) MineResult {
 	mergedRoot := computeUpperMerkleRoot(anchors)
 
 	header := PoWHeader{
-		Index:      index,
-		PrevHash:   prevHash,
-		MerkleRoot: mergedRoot,
-		Timestamp:  time.Unix(time.Now().Unix(), 0).Format(time.RFC3339),
-		Difficulty: difficulty,
+		Index:       index,
+		PrevHash:    prevHash,
+		MerkleRoot:  mergedRoot,
+		Timestamp:   miningTimestamp(mtp),
+		Difficulty:  difficulty,
+		ControlHash: controlHash(control),
 	}
 
 	log.Printf("[PoW] Starting mining (index=%d prev=%s...)", index, prevHash[:8])
 
-	// Nonce 탐색
-	rand.Seed(time.Now().UnixNano())
-	nonce := rand.Intn(4294967296)
-
-	var hash string
-
-	for !miningStop.Load() {
-		header.Nonce = nonce
-		hash = computeHashForPoW(header)
-		// 채굴 성공 시
-		if validHash(hash, difficulty) {
-			mineEnd := time.Now()
-			elapsed := mineEnd.Sub(mineStart)
-			//isMining.Store(false) // nonce 찾기는 끝났지만, 아직 저장되지 않았으므로 플래그 변경하지 않음
-			return MineResult{BlockHash: hash, Nonce: nonce, Header: header, Elapsed: float32(elapsed.Seconds())}
+	// Nonce 탐색 (워커별로 나눈 nonce 공간을 병렬 탐색, 소진/갱신 시점마다 헤더를 바꿔 재시작)
+	var (
+		found PoWHeader
+		hash  string
+	)
+	for {
+		var res nonceSearch
+		found, hash, res = searchNonce(header, difficulty, randomNonce(), MiningTimestampRefresh)
+		if res == nonceFound {
+			break
+		}
+		if res == nonceStopped {
+			log.Printf("[PoW] Stop PoW by Winner Node")
+			return MineResult{} // 다른 노드가 성공 시 중단
 		}
-		nonce++
+		if res == nonceExhausted {
+			header.ExtraNonce++
+			log.Printf("[PoW] nonce space exhausted (index=%d) => extra_nonce=%d", index, header.ExtraNonce)
+		}
+		header.Timestamp = miningTimestamp(mtp)
+	}
+	// 채굴 성공 시
+	elapsed := time.Since(mineStart)
+	observeDuration("chain_mining_duration_seconds", elapsed.Seconds())
+	//isMining.Store(false) // nonce 찾기는 끝났지만, 아직 저장되지 않았으므로 플래그 변경하지 않음
+	return MineResult{BlockHash: hash, Nonce: found.Nonce, Header: found, Elapsed: float32(elapsed.Seconds()), Control: control}
+}
+
+// 병렬 nonce 탐색 : start 부터 nonce 공간을 한 바퀴 돌며 유효한 해시를 찾은 헤더 반환
+//   - 워커 i 는 start+i, start+i+workers, ... (NonceSpace 에 닿으면 0 부터 이어서) 를 맡음
+//   - refresh 가 지나면 nonceRefresh, 모든 워커가 맡은 구간을 다 돌면 nonceExhausted
+func searchNonce(header PoWHeader, difficulty, start int, refresh time.Duration) (found PoWHeader, hash string, res nonceSearch) {
+	workers := max(1, MiningWorkers)
+	var (
+		done    atomic.Bool
+		expired atomic.Bool
+		once    sync.Once
+		wg      sync.WaitGroup
+		total   atomic.Int64
+	)
+	timer := time.AfterFunc(refresh, func() { expired.Store(true) })
+	defer timer.Stop()
+	began := time.Now()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(h PoWHeader, offset int) {
+			defer wg.Done()
+			search := powHasher.Searcher(h) // 워커별 미리 인코딩된 헤더
+			n, flushed := 0, 0              // 탐색한 해시 수, 그중 집계에 반영한 수
+			for ; offset < NonceSpace; offset += workers {
+				if n-flushed >= hashCountBatch {
+					addCounter("chain_mining_hashes_total", "", float64(n-flushed))
+					flushed = n
+					if done.Load() || miningStop.Load() || expired.Load() {
+						break
+					}
+				}
+				nonce := (start + offset) % NonceSpace
+				sum := search(nonce)
+				n++
+				if sumMeetsDifficulty(sum, difficulty) {
+					h.Nonce = nonce
+					once.Do(func() {
+						found, hash = h, hex.EncodeToString(sum[:])
+						done.Store(true)
+					})
+					break
+				}
+			}
+			addCounter("chain_mining_hashes_total", "", float64(n-flushed))
+			total.Add(int64(n))
+		}(header, i)
+	}
+	wg.Wait()
+
+	if secs := time.Since(began).Seconds(); secs > 0 {
+		hashRateBits.Store(math.Float64bits(float64(total.Load()) / secs))
+	}
+	switch {
+	case done.Load():
+		return found, hash, nonceFound
+	case miningStop.Load():
+		return found, hash, nonceStopped
+	case expired.Load():
+		return found, hash, nonceRefresh
+	}
+	return found, hash, nonceExhausted
+}
+
+// 탐색 시작 nonce (crypto/rand, 노드마다 다른 구간부터 탐색)
+func randomNonce() int {
+	var b [4]byte
+	rand.Read(b[:]) // Go 1.24 부터 실패하지 않음
+	return int(binary.BigEndian.Uint32(b[:]))
+}
+
+// 채굴 블록 타임스탬프 : 현재 시각, 로컬 시계가 늦으면 median-time-past
+func miningTimestamp(mtp time.Time) string {
+	ts := time.Now()
+	if mtp.After(ts) {
+		ts = mtp
 	}
-	log.Printf("[PoW] Stop PoW by Winner Node")
-	return MineResult{} // 다른 노드가 성공 시 중단
+	return time.Unix(ts.Unix(), 0).Format(time.RFC3339)
+}
+
+// 마지막 채굴의 초당 해시 수
+func miningHashRate() float64 {
+	return math.Float64frombits(hashRateBits.Load())
 }
 
 // 채굴 성공 시 네트워크로 블록 전파
+// - 승자 노드는 자신의 장부에 먼저 반영한 뒤, 블록 해시만 가십으로 알림 (gossip.go)
+// - 본문은 알림을 받은 노드가 /block/hash 로 pull
 func broadcastBlock(res MineResult, anchors []AnchorRecord) {
-	body, _ := json.Marshal(map[string]any{
-		"header":     res.Header,
-		"hash":       res.BlockHash,
-		"entries":    anchors,
-		"difficulty": GlobalDifficulty,
-		"elapsed":    res.Elapsed,
-		"winner":     self,
-	})
-	// peerSnapshot은 자기자신을 포함하지 않으므로 추가
-	nodes := append(peersSnapshot(), self)
-	for _, node := range nodes {
-		go func(addr string) {
-			http.Post("http://"+addr+"/receiveBlock", "application/json", strings.NewReader(string(body)))
-		}(node)
+	// 그 사이 다른 블록이 먼저 반영되었다면 전파하지 않음
+	if _, err := getBlockByIndex(res.Header.Index); err == nil {
+		log.Printf("[PoW][NODE] Block #%d already exists -> drop mined block", res.Header.Index)
+		isMining.Store(false)
+		return
 	}
-	log.Printf("[PoW][P2P][BROADCAST] Winner sent NewBlock to peers: index=%d hash=%s", res.Header.Index, res.BlockHash)
+	markSeen(res.BlockHash)
+	addBlockToChain(newUpperBlock(res.Header, res.BlockHash, anchors, res.Control))
+	isMining.Store(false)
+
+	gossipAnnounce(blockAnnounce{Index: res.Header.Index, Hash: res.BlockHash, From: self}, "")
+	log.Printf("[PoW][P2P][GOSSIP] Winner announced NewBlock: index=%d hash=%s", res.Header.Index, res.BlockHash)
 }
 
 // PoW 수행 중 승자노드로부터 신규 블록 수신하면 검증한 후 체인에 추가함
-// POST : /receive 요청을 통해 트리거
+// POST : /receive 요청을 통해 트리거 (가십 전파 이전 노드와의 호환용으로 유지)
 func receiveBlock(w http.ResponseWriter, r *http.Request) {
 	var msg struct {
-		Header     PoWHeader      `json:"header"`
-		Hash       string         `json:"hash"`
-		Anchors    []AnchorRecord `json:"entries"`
-		Difficulty int            `json:"difficulty"`
-		Elapsed    float32        `json:"elapsed"`
-		Winner     string         `json:"winner"`
+		Header  PoWHeader          `json:"header"`
+		Hash    string             `json:"hash"`
+		Anchors []AnchorRecord     `json:"entries"`
+		Control *DifficultyControl `json:"control"`
+		Winner  string             `json:"winner"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
-		http.Error(w, err.Error(), 400)
+		writeError(w, 400, err.Error())
 		return
 	}
 	// 이미 해당 인덱스의 블록이 존재하면 무시
 	if _, err := getBlockByIndex(msg.Header.Index); err == nil {
 		log.Printf("[PoW][NODE] Block #%d already exists -> ignore duplicate receiveBlock", msg.Header.Index)
-		return
+		return // 중복 전파는 성공으로 응답
 	}
 	// 들어온 블록이 중복된 블록이 아니라면, pow 즉시 중단
 	// 검증 없이 중단하면, 4번블록 채굴 중 3번블록 들어왔을 때 4번블록 채굴이 멈춤
 	miningStop.Store(true)
 	log.Printf("[PoW][NODE] The Winner Node is : %s", msg.Winner)
-	// PoW 유효성 검증 (기존 난이도로 검증)
-	if !validHash(msg.Hash, msg.Header.Difficulty) {
-		log.Printf("[PoW][BLOCK] Invalid hash rejected: index=%d", msg.Header.Index)
-		w.WriteHeader(http.StatusBadRequest)
+	// 가십 수신과 동일한 검증 (연결, 머클 루트, 제어 메시지 해시를 포함한 PoW 해시, 난이도, 제어 메시지 서명, 타임스탬프)
+	blk := newUpperBlock(msg.Header, msg.Hash, msg.Anchors, msg.Control)
+	prev, err := getBlockByIndex(blk.Index - 1)
+	if err != nil {
+		log.Printf("[PoW][BLOCK] Missing prev block #%d: %v", blk.Index-1, err)
+		writeError(w, http.StatusConflict, fmt.Sprintf("missing prev block #%d", blk.Index-1))
+		return
+	}
+	if err := validateUpperBlock(blk, prev); err != nil {
+		log.Printf("[PoW][BLOCK] Invalid block rejected: index=%d err=%v", blk.Index, err)
+		writeErrorDetail(w, http.StatusUnprocessableEntity, "invalid_block", err.Error(), map[string]int{"index": blk.Index})
 		return
 	}
 	// 체인에 추가
-	addBlockToChain(msg.Header, msg.Hash, msg.Elapsed, msg.Anchors)
+	addBlockToChain(blk)
 	log.Printf("[PoW][CHAIN] Block accepted: index=%d hash=%s", msg.Header.Index, msg.Hash)
 	w.WriteHeader(http.StatusOK)
 
-	// 채굴된 블록의 난이도와 전달받은 난이도가 같지 않다면 전달받은 난이도를 전역변수에 반영
-	if msg.Difficulty != msg.Header.Difficulty {
-		GlobalDifficulty = msg.Difficulty
-	}
 	isMining.Store(false) // 장부 추가가 끝난 후 isMining 종료처리 => 다음 블록 채굴 가능한 상태가 됨
 }
 
-// 검증된 블록을 로컬 체인에 추가
-func addBlockToChain(header PoWHeader, hash string, elapsed float32, anchors []AnchorRecord) {
-	block := UpperBlock{
+// 헤더와 본문으로 로컬 체인 블록 구성 (Elapsed 는 장부 반영 시 타임스탬프 간격으로 계산)
+func newUpperBlock(header PoWHeader, hash string, anchors []AnchorRecord, control *DifficultyControl) UpperBlock {
+	return UpperBlock{
 		Index:      header.Index,
 		GovID:      selfID(),
 		PrevHash:   header.PrevHash,
@@ -245,69 +393,24 @@ func addBlockToChain(header PoWHeader, hash string, elapsed float32, anchors []A
 		Records:    anchors,
 		MerkleRoot: header.MerkleRoot,
 		Nonce:      header.Nonce,
+		ExtraNonce: header.ExtraNonce,
 		Difficulty: header.Difficulty,
 		BlockHash:  hash,
-		Elapsed:    elapsed,
+		Control:    control,
 	}
-	onBlockReceived(block)
 }
 
-// 세 블록 마다 채굴 소요시간에 따른 채굴 난이도 조정
-func adjustDifficulty(idx int, elapsed float32) {
-
-	log.Printf("[DIFF] Adjust Difficulty Start! Index = %d", idx)
-	// 3 블록의 소요시간 담을 배열 (0으로 초기화)
-	e := [3]float32{}
-	// 최신블록 채굴소요시간
-	e[2] = elapsed
-	if idx > 2 {
-		// 직전 블록 조회
-		b1, err1 := getBlockByIndex(idx - 1)
-		if err1 != nil {
-			log.Printf("[DIFF] Previous Block fetch error ")
-		} else { // 직전블록 채굴소요시간
-			e[1] = b1.Elapsed
-		}
-		// 전전 블록 조회
-		b2, err2 := getBlockByIndex(idx - 2)
-		if err2 != nil {
-			log.Printf("[DIFF] Pre-Previous Block fetch error")
-		} else { // 전전블록 채굴소요시간
-			e[0] = b2.Elapsed
-		}
-	}
-	avg := (float64)(e[0]+e[1]+e[2]) / 3.0
-	ratio := avg / float64(DiffStandardTime)
-
-	log.Printf("[DIFF] 3-block average elapsed = %.2f sec , ratio : %.2f (b0=%.2f b1=%.2f b2=%.2f)",
-		avg, ratio, e[0], e[1], e[2])
-
-	// 너무 일찍 끝났다면 난이도 올림
-	if ratio < 0.85 {
-		GlobalDifficulty++
-		log.Printf("[DIFF] Increased difficulty => %d", GlobalDifficulty)
-		if GlobalDifficulty == 8 {
-			GlobalDifficulty--
-			log.Printf("[DIFF] Decreased difficulty 8 => %d", GlobalDifficulty)
-		}
-
-	} else if ratio > 1.25 { // 너무 오래 걸렸다면 난이도 낮춤
-		GlobalDifficulty--
-		if GlobalDifficulty < 1 {
-			GlobalDifficulty = 1
-		}
-		log.Printf("[DIFF] Decreased difficulty => %d", GlobalDifficulty)
-	} else {
-		log.Printf("[DIFF] No difficulty change (within normal range)")
-	}
-
+// 검증된 블록을 로컬 체인에 추가
+func addBlockToChain(block UpperBlock) {
+	// 수신 블록(gossip)과 같은 chainMu 안에서 반영 (조회 스냅샷이 블록 경계에서 찍히도록)
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	onBlockReceived(block)
 }
 
-// 헤더 직렬화 후 SHA-256 해시 계산
+// 헤더 직렬화 후 SHA-256 해시 계산 (powhash.go)
 func computeHashForPoW(header PoWHeader) string {
-	data, _ := json.Marshal(header)
-	sum := sha256.Sum256(data)
-	return hex.EncodeToString(sum[:])
+	return powHasher.HeaderHash(header)
 }
 
 // 주어진 난이도 조건 검사
@@ -26,7 +26,7 @@ var db *leveldb.DB
 
 // ---- 내부 메타키 헬퍼 ---------------------------------------------------------
 func putMeta(key, val string) error {
-	return db.Put([]byte(key), []byte(val), nil)
+	return countDBError(db.Put([]byte(key), []byte(val), nil))
 }
 func getMeta(key string) (string, bool) {
 	v, err := db.Get([]byte(key), nil)
@@ -37,8 +37,11 @@ func getMeta(key string) (string, bool) {
 }
 
 func getLatestHeight() (int, bool) {
-	if s, ok := getMeta("height_latest"); ok {
-		h, err := strconv.Atoi(s)
+	return getLatestHeightFrom(db)
+}
+func getLatestHeightFrom(rd dbReader) (int, bool) {
+	if v, err := rd.Get([]byte("height_latest"), nil); err == nil {
+		h, err := strconv.Atoi(string(v))
 		if err == nil {
 			return h, true
 		}
@@ -83,13 +86,13 @@ func saveBlockToDB(block UpperBlock) error {
 
 	// 블록 번호 기반 저장
 	keyByIndex := fmt.Sprintf("block_%d", block.Index)
-	if err := db.Put([]byte(keyByIndex), data, nil); err != nil {
+	if err := countDBError(db.Put([]byte(keyByIndex), data, nil)); err != nil {
 		return err
 	}
 
 	// 블록 해시 기반 저장
 	keyByHash := fmt.Sprintf("hash_%s", block.BlockHash)
-	if err := db.Put([]byte(keyByHash), data, nil); err != nil {
+	if err := countDBError(db.Put([]byte(keyByHash), data, nil)); err != nil {
 		return err
 	}
 
@@ -105,10 +108,13 @@ func saveBlockToDB(block UpperBlock) error {
 
 // 인덱스로 블록 조회
 func getBlockByIndex(index int) (UpperBlock, error) {
+	return getBlockByIndexFrom(db, index)
+}
+func getBlockByIndexFrom(rd dbReader, index int) (UpperBlock, error) {
 	key := fmt.Sprintf("block_%d", index)
-	data, err := db.Get([]byte(key), nil)
+	data, err := rd.Get([]byte(key), nil)
 	if err != nil {
-		return UpperBlock{}, err
+		return UpperBlock{}, countDBError(err)
 	}
 	var block UpperBlock
 	if err := json.Unmarshal(data, &block); err != nil {
@@ -133,7 +139,10 @@ func getBlockByHash(hash string) (UpperBlock, error) {
 
 // 최신 루트 캐시 조회(없으면 빈 문자열)
 func getLatestRoot() string {
-	if v, err := db.Get([]byte("root_latest"), nil); err == nil {
+	return getLatestRootFrom(db)
+}
+func getLatestRootFrom(rd dbReader) string {
+	if v, err := rd.Get([]byte("root_latest"), nil); err == nil {
 		return string(v)
 	}
 	return ""
@@ -151,6 +160,9 @@ func updateIndicesForBlock(block UpperBlock) error {
 			if err := db.Put([]byte(keyByHos), ptr(block.Index, ei), nil); err != nil {
 				return err
 			}
+			if err := db.Put(anchorRootKey(rec.LowerRoot), ptr(block.Index, ei), nil); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -180,10 +192,19 @@ func parsePtr(s string) (int, int, bool) {
 
 // 전체 블록 조회
 func listAllBlocks() ([]UpperBlock, error) {
-	h, ok := getLatestHeight()
+	var out []UpperBlock
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		out, err = listAllBlocksFrom(rd)
+		return err
+	})
+	return out, err
+}
+func listAllBlocksFrom(rd dbReader) ([]UpperBlock, error) {
+	h, ok := getLatestHeightFrom(rd)
 	if !ok {
 		// 제네시스만 있을 수도 있으니 0만 확인
-		b0, err := getBlockByIndex(0)
+		b0, err := getBlockByIndexFrom(rd, 0)
 		if err != nil {
 			return nil, fmt.Errorf("no chain: %w", err)
 		}
@@ -191,7 +212,7 @@ func listAllBlocks() ([]UpperBlock, error) {
 	}
 	out := make([]UpperBlock, 0, h+1)
 	for i := 0; i <= h; i++ {
-		b, err := getBlockByIndex(i)
+		b, err := getBlockByIndexFrom(rd, i)
 		if err != nil {
 			return nil, fmt.Errorf("load block_%d: %w", i, err)
 		}
@@ -201,14 +222,25 @@ func listAllBlocks() ([]UpperBlock, error) {
 }
 
 // 페이지네이션 조회 : ffset에서 최대 limit개 반환, total(=height+1)도 함께 반환
+// - total 과 블록 목록은 같은 스냅샷에서 읽음 (snapshot.go)
 func listBlocksPaginated(offset, limit int) ([]UpperBlock, int, error) {
 	if offset < 0 || limit <= 0 {
 		return nil, 0, fmt.Errorf("invalid offset/limit")
 	}
-	h, ok := getLatestHeight()
+	var out []UpperBlock
+	total := 0
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		out, total, err = listBlocksPaginatedFrom(rd, offset, limit)
+		return err
+	})
+	return out, total, err
+}
+func listBlocksPaginatedFrom(rd dbReader, offset, limit int) ([]UpperBlock, int, error) {
+	h, ok := getLatestHeightFrom(rd)
 	if !ok {
 		// 제네시스만 있는지 확인
-		if _, err := getBlockByIndex(0); err != nil {
+		if _, err := getBlockByIndexFrom(rd, 0); err != nil {
 			return nil, 0, fmt.Errorf("no chain: %w", err)
 		}
 		h = 0
@@ -223,7 +255,7 @@ func listBlocksPaginated(offset, limit int) ([]UpperBlock, int, error) {
 	}
 	out := make([]UpperBlock, 0, end-offset+1)
 	for i := offset; i <= end; i++ {
-		b, err := getBlockByIndex(i)
+		b, err := getBlockByIndexFrom(rd, i)
 		if err != nil {
 			return nil, total, fmt.Errorf("load block_%d: %w", i, err)
 		}
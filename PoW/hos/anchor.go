@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -16,7 +15,8 @@ import (
 	"math/big"
 	"net/http"
 	"strings"
-	"time"
+
+	"merkle"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -52,7 +52,7 @@ func ensureKeyPair() {
 func getPublicKey(w http.ResponseWriter, r *http.Request) {
 	pubPem, ok := getMeta("meta_hos_pubkey")
 	if !ok {
-		http.Error(w, "public key not found", http.StatusNotFound)
+		writeError(w, http.StatusNotFound, "public key not found")
 		return
 	}
 	w.Header().Set("Content-Type", "application/x-pem-file")
@@ -80,36 +80,13 @@ func makeAnchorSignature(privPem string, root string, ts string) string {
 }
 
 // Gov로 MerkleRoot 제출 (부트노드에서만 실행됨)
+//   - 큐에 먼저 기록한 뒤 재시도 루틴이 순서대로 전송 (anchor_queue.go)
 func submitAnchor(block LowerBlock) {
-	ensureKeyPair() // 키 없으면 생성
-	privPem, _ := getMeta("meta_hos_privkey")
-
-	ts := time.Unix(time.Now().Unix(), 0).Format(time.RFC3339)
-	sig := makeAnchorSignature(privPem, block.MerkleRoot, ts)
-
-	req := map[string]any{
-		"hos_id":   selfID(),
-		"hos_boot": self, // ex: "hos-boot:5000"
-		"root":     block.MerkleRoot,
-		"ts":       ts,
-		"sig":      sig,
-	}
-
-	body, _ := json.Marshal(req)
-	govURL := "http://" + govBoot + "/addAnchor"
-	log.Printf("[ANCHOR] Anchor Sent to Gov BOOT : %s", govBoot)
-	resp, err := http.Post(govURL, "application/json", bytes.NewReader(body))
-	if err != nil {
-		log.Printf("[ANCHOR][ERROR] failed to submit anchor: %v", err)
+	if err := enqueueAnchor(block); err != nil {
+		log.Printf("[ANCHOR][ERROR] failed to queue anchor (root=%s): %v", block.MerkleRoot[:8], err)
 		return
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusOK {
-		log.Printf("[ANCHOR][OK] Anchor submitted to Gov (root=%s)", block.MerkleRoot[:8])
-	} else {
-		log.Printf("[ANCHOR][WARN] Gov rejected anchor (status=%d)", resp.StatusCode)
-	}
+	kickAnchorQueue()
 }
 
 // 검색 응답 구조체
@@ -119,12 +96,30 @@ type SearchResponse struct {
 	LatestRoot string       `json:"latest_root"`
 	Leaf       string       `json:"leaf"`
 	Proof      [][2]string  `json:"proof"`
+
+	BlockIndex    int    `json:"block_index"`       // 레코드가 속한 블록 번호
+	Confirmations int    `json:"confirmations"`     // 최신 높이 - 블록 번호
+	Final         bool   `json:"final"`             // confirmations >= FinalityDepth
+	Warning       string `json:"warning,omitempty"` // 최종성 미달 시 경고
+
+	Inclusion Inclusion `json:"inclusion"` // 블록/엔트리 위치, 확인 수, 앵커 상태
 }
 
 // 쿼리 수행 함수
+//   - 색인/블록/최신 루트·높이는 하나의 스냅샷에서 읽음 (snapshot.go)
 func searchClinic(keyword string) ([]SearchResponse, error) {
+	var results []SearchResponse
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		results, err = searchClinicFrom(rd, keyword)
+		return err
+	})
+	return results, err
+}
+
+func searchClinicFrom(rd dbReader, keyword string) ([]SearchResponse, error) {
 	// 키워드를 가진 블록 찾기
-	blk, err := getBlockByClinicForQuery(keyword)
+	blk, err := getBlockByClinicForQuery(rd, keyword)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +133,7 @@ func searchClinic(keyword string) ([]SearchResponse, error) {
 	// 결과 구조 생성
 	results := make([]SearchResponse, 0, len(matches))
 	for _, m := range matches {
-		results = append(results, buildSearchResponse(m.Record, blk, m.EntryIndex))
+		results = append(results, buildSearchResponse(rd, m.Record, blk, m.EntryIndex))
 	}
 
 	return results, nil
@@ -168,21 +163,36 @@ func findMatchesInBlock(blk *LowerBlock, keyword string) []Match {
 	return matches
 }
 
-func buildSearchResponse(rec ClinicRecord, blk *LowerBlock, entryIndex int) SearchResponse {
+func buildSearchResponse(rd dbReader, rec ClinicRecord, blk *LowerBlock, entryIndex int) SearchResponse {
 
 	// 1) 찾은 블록에서 해당 엔트리에 대한 leaf hash 꺼냄
 	leaf := blk.LeafHashes[entryIndex]
 
 	// 2) 검색된 레코드가 속한 블록을 기준으로 Merkle Proof 생성
-	proof := merkleProof(blk.LeafHashes, entryIndex)
+	proof := merkle.Proof(blk.LeafHashes, entryIndex)
+
+	// 3) 블록 확인 수 (최종성 깊이 미달 시 경고)
+	conf := confirmationsFrom(rd, blk.Index)
+	final := conf >= FinalityDepth
+	warning := ""
+	if !final {
+		warning = shallowWarning(conf, FinalityDepth)
+	}
 
-	// 3) 최종 결과 패키징
+	// 4) 최종 결과 패키징
 	return SearchResponse{
 		Record:     rec,
-		BlockRoot:  blk.MerkleRoot,  // 레코드가 존재하는 블록 루트 (블록 유효성 검증)
-		LatestRoot: getLatestRoot(), // 현재 노드의 최신 블록 루트 (체인 유효성 검증)
+		BlockRoot:  blk.MerkleRoot,        // 레코드가 존재하는 블록 루트 (블록 유효성 검증)
+		LatestRoot: getLatestRootFrom(rd), // 현재 노드의 최신 블록 루트 (체인 유효성 검증)
 		Leaf:       leaf,
 		Proof:      proof,
+
+		BlockIndex:    blk.Index,
+		Confirmations: conf,
+		Final:         final,
+		Warning:       warning,
+
+		Inclusion: buildInclusion(rd, blk, entryIndex),
 	}
 }
 
@@ -190,22 +200,22 @@ func buildSearchResponse(rec ClinicRecord, blk *LowerBlock, entryIndex int) Sear
 //   - keyword가 Info(cCode 등)에 매칭되면
 //     해당 포인터("bi:ei")를 통해 블록을 찾아 반환
 //   - 여러 매칭이 가능할 수 있으나, 여기서는 최초 매칭 1개만 반환
-func getBlockByClinicForQuery(keyword string) (*LowerBlock, error) {
+func getBlockByClinicForQuery(rd dbReader, keyword string) (*LowerBlock, error) {
 
 	// Info(cCode 등) 색인 조회 (소문자 normalize)
-	if v, err := db.Get([]byte("info_cCode_"+strings.ToLower(keyword)), nil); err == nil {
+	if v, err := rd.Get([]byte("info_cCode_"+strings.ToLower(keyword)), nil); err == nil {
 		if bi, _, ok := parsePtr(string(v)); ok {
-			return getBlockByIndexForPointer(bi)
+			return getBlockByIndexForPointer(rd, bi)
 		}
 	}
 
 	return nil, fmt.Errorf("no block found for keyword: %s", keyword)
 }
 
-func getBlockByIndexForPointer(index int) (*LowerBlock, error) {
+func getBlockByIndexForPointer(rd dbReader, index int) (*LowerBlock, error) {
 	key := fmt.Sprintf("block_%d", index)
 
-	data, err := db.Get([]byte(key), nil)
+	data, err := rd.Get([]byte(key), nil)
 	if err != nil {
 		return nil, fmt.Errorf("block_%d not found: %w", index, err)
 	}
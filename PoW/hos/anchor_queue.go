@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Anchor Queue (앵커 제출 재시도 큐)
+// ------------------------------------------------------------
+// - submitAnchor 는 앵커를 LevelDB 큐에 먼저 기록한 뒤 재시도 루틴을 깨움
+//   (Gov 부트노드가 내려가 있어도 앵커가 유실되지 않음, 재시작 후에도 이어서 전송)
+// - 큐는 순서대로 전송 : 앞선 앵커가 실패하면 뒤 앵커도 대기
+//   (Gov 는 Hos 별 최신 앵커를 기준으로 검증하므로 제출 순서를 보존해야 함)
+// - 네트워크 오류 / 5xx : 지수 백오프(AnchorRetryBase * 2^n, 최대 AnchorRetryMax) 후 재시도
+// - 4xx : Gov 가 거절한 앵커로 보고 큐에서 제거
+// - 전송 시점마다 현재 Gov 부트노드 주소로 재서명 후 전송
+//   (Gov 부트노드 재선출 시 대기 중인 앵커를 즉시 새 부트노드로 재전송)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	anchorQueuePrefix = "anchorq_"
+	AnchorRetryBase   = 2   // 초
+	AnchorRetryMax    = 300 // 초
+	AnchorQueueIdle   = 60  // 초, 큐가 비었을 때 점검 주기
+)
+
+type QueuedAnchor struct {
+	Seq        int    `json:"seq"`
+	HosID      string `json:"hos_id"`
+	Root       string `json:"root"`
+	BlockIndex int    `json:"block_index"`
+	Attempts   int    `json:"attempts"`
+	NextRetry  int64  `json:"next_retry"` // unix 초 (0 이면 즉시)
+	LastError  string `json:"last_error,omitempty"`
+}
+
+var (
+	anchorQueueMu   sync.Mutex
+	anchorQueueKick = make(chan struct{}, 1)
+
+	errAnchorRejected = errors.New("anchor rejected by gov")
+)
+
+func anchorQueueKey(seq int) []byte {
+	return []byte(fmt.Sprintf("%s%020d", anchorQueuePrefix, seq))
+}
+
+// 재시도 루틴 깨우기 (이미 깨어 있으면 무시)
+func kickAnchorQueue() {
+	select {
+	case anchorQueueKick <- struct{}{}:
+	default:
+	}
+}
+
+// 앵커를 큐에 기록
+func enqueueAnchor(block LowerBlock) error {
+	anchorQueueMu.Lock()
+	defer anchorQueueMu.Unlock()
+
+	seq := 0
+	if s, ok := getMeta("seq_anchorq"); ok {
+		seq, _ = strconv.Atoi(s)
+	}
+	seq++
+	item := QueuedAnchor{Seq: seq, HosID: selfID(), Root: block.MerkleRoot, BlockIndex: block.Index}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if err := countDBError(db.Put(anchorQueueKey(seq), data, nil)); err != nil {
+		return err
+	}
+	return putMeta("seq_anchorq", strconv.Itoa(seq))
+}
+
+// 큐의 가장 오래된 앵커
+func peekAnchorQueue() (QueuedAnchor, bool) {
+	anchorQueueMu.Lock()
+	defer anchorQueueMu.Unlock()
+	iter := db.NewIterator(util.BytesPrefix([]byte(anchorQueuePrefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var item QueuedAnchor
+		if err := json.Unmarshal(iter.Value(), &item); err == nil {
+			return item, true
+		}
+	}
+	return QueuedAnchor{}, false
+}
+
+func saveQueuedAnchor(item QueuedAnchor) {
+	anchorQueueMu.Lock()
+	defer anchorQueueMu.Unlock()
+	if data, err := json.Marshal(item); err == nil {
+		_ = countDBError(db.Put(anchorQueueKey(item.Seq), data, nil))
+	}
+}
+
+func removeQueuedAnchor(item QueuedAnchor) {
+	anchorQueueMu.Lock()
+	defer anchorQueueMu.Unlock()
+	_ = countDBError(db.Delete(anchorQueueKey(item.Seq), nil))
+}
+
+// Gov 부트노드 변경 시 : 대기 중인 앵커를 백오프 없이 새 부트노드로 재전송
+func retargetAnchorQueue() {
+	if item, ok := peekAnchorQueue(); ok && item.NextRetry != 0 {
+		item.NextRetry = 0
+		saveQueuedAnchor(item)
+		log.Printf("[ANCHOR][RETRY] Gov boot changed (%s), retrying queued anchors now", getGovBoot())
+	}
+	kickAnchorQueue()
+}
+
+// 재시도 지연 (AnchorRetryBase * 2^(attempts-1), 최대 AnchorRetryMax)
+func anchorBackoff(attempts int) time.Duration {
+	d := AnchorRetryBase
+	for i := 1; i < attempts && d < AnchorRetryMax; i++ {
+		d *= 2
+	}
+	return time.Duration(min(d, AnchorRetryMax)) * time.Second
+}
+
+// 앵커 재시도 루틴 (main 에서 실행)
+func startAnchorQueueWatcher() {
+	for {
+		wait := flushAnchorQueue()
+		select {
+		case <-anchorQueueKick:
+		case <-time.After(wait):
+		}
+	}
+}
+
+// 큐 앞에서부터 전송, 다음 점검까지의 대기 시간 반환
+func flushAnchorQueue() time.Duration {
+	for {
+		item, ok := peekAnchorQueue()
+		if !ok {
+			return AnchorQueueIdle * time.Second
+		}
+		if wait := time.Until(time.Unix(item.NextRetry, 0)); item.NextRetry != 0 && wait > 0 {
+			return wait
+		}
+
+		err := sendAnchor(item)
+		if err == nil || errors.Is(err, errAnchorRejected) {
+			removeQueuedAnchor(item)
+			continue
+		}
+
+		item.Attempts++
+		backoff := anchorBackoff(item.Attempts)
+		item.NextRetry = time.Now().Add(backoff).Unix()
+		item.LastError = err.Error()
+		saveQueuedAnchor(item)
+		log.Printf("[ANCHOR][RETRY] root=%s attempt=%d failed: %v (next in %s)", item.Root[:8], item.Attempts, err, backoff)
+		return backoff
+	}
+}
+
+// 현재 Gov 부트노드로 앵커 1건 전송
+func sendAnchor(item QueuedAnchor) error {
+	ensureKeyPair() // 키 없으면 생성
+	privPem, _ := getMeta("meta_hos_privkey")
+
+	ts := time.Unix(time.Now().Unix(), 0).Format(time.RFC3339)
+	sig := makeAnchorSignature(privPem, item.Root, ts)
+
+	req := map[string]any{
+		"hos_id":   item.HosID,
+		"hos_boot": self, // ex: "hos-boot:5000"
+		"root":     item.Root,
+		"ts":       ts,
+		"sig":      sig,
+	}
+
+	body, _ := json.Marshal(req)
+	gov := getGovBoot()
+	log.Printf("[ANCHOR] Anchor Sent to Gov BOOT : %s", gov)
+	resp, err := http.Post("http://"+gov+"/addAnchor", "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("[ANCHOR][ERROR] failed to submit anchor: %v", err)
+		incCounter("chain_anchor_submissions_total", `result="error"`)
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		log.Printf("[ANCHOR][OK] Anchor submitted to Gov (root=%s)", item.Root[:8])
+		incCounter("chain_anchor_submissions_total", `result="ok"`)
+		publishEvent(EventAnchorAccepted, map[string]any{"hos_id": item.HosID, "root": item.Root, "block_index": item.BlockIndex, "ts": ts})
+		return nil
+	case resp.StatusCode >= 500:
+		incCounter("chain_anchor_submissions_total", `result="error"`)
+		return fmt.Errorf("gov status %d", resp.StatusCode)
+	default:
+		log.Printf("[ANCHOR][WARN] Gov rejected anchor (status=%d)", resp.StatusCode)
+		incCounter("chain_anchor_submissions_total", `result="rejected"`)
+		return errAnchorRejected
+	}
+}
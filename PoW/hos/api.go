@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,7 +25,7 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /block/root
 	mux.HandleFunc("/block/root", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		root := getLatestRoot() // storage의 getLatestRoot 사용
@@ -35,67 +36,76 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /block/index?id=<int>
 	mux.HandleFunc("/block/index", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		q := r.URL.Query().Get("id")
 		if q == "" {
-			http.Error(w, "id parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id parameter required")
 			return
 		}
 		idx, err := strconv.Atoi(q)
 		if err != nil {
-			http.Error(w, "id must be integer", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "id must be integer")
 			return
 		}
 		blk, err := getBlockByIndex(idx)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
-		writeJSON(w, http.StatusOK, blk)
+		writeJSON(w, http.StatusOK, newBlockView(blk))
 	})
 
 	// 블록 조회: 해시
 	// GET /block/hash?value=<hash>
 	mux.HandleFunc("/block/hash", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		hash := r.URL.Query().Get("value")
 		if hash == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
 			return
 		}
 		blk, err := getBlockByHash(hash)
 		if err != nil {
-			http.Error(w, "block not found", http.StatusNotFound)
+			writeError(w, http.StatusNotFound, "block not found")
 			return
 		}
-		writeJSON(w, http.StatusOK, blk)
+		writeJSON(w, http.StatusOK, newBlockView(blk))
 	})
 
 	// 키워드로 레코드 검색(정확 일치: cid/pc/info_cCode)
-	// GET /search?value=<keyword>
+	// GET /search?value=<keyword>&require_final=<bool>
+	//  - require_final=true : 최종성 깊이(FinalityDepth) 미달 블록의 증명은 409로 거부
 	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 
 		kw := r.URL.Query().Get("value")
 		if kw == "" {
-			http.Error(w, "value parameter required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "value parameter required")
 			return
 		}
 		logInfo("search query keyword: %s", kw)
 		// 검색 수행
 		results, err := searchClinic(kw)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			writeError(w, http.StatusNotFound, err.Error())
 			return
 		}
+		if r.URL.Query().Get("require_final") == "true" {
+			for _, res := range results {
+				if !res.Final {
+					writeError(w, http.StatusConflict, shallowWarning(res.Confirmations, FinalityDepth))
+					return
+				}
+			}
+		}
 		logInfo("query response's length: %s", len(results))
 		// 결과 반환
 		writeJSON(w, http.StatusOK, results)
@@ -105,7 +115,7 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 	// GET /blocks?offset=<int>&limit=<int>
 	mux.HandleFunc("/blocks", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 			return
 		}
 		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
@@ -115,15 +125,16 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 		}
 		blocks, total, err := listBlocksPaginated(offset, limit)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("list blocks error: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("list blocks error: %v", err))
 			return
 		}
 		writeJSON(w, http.StatusOK, map[string]any{
-			"total":      total,
-			"offset":     offset,
-			"limit":      limit,
-			"items":      blocks,
-			"difficulty": GlobalDifficulty,
+			"total":          total,
+			"offset":         offset,
+			"limit":          limit,
+			"items":          newBlockViews(blocks),
+			"difficulty":     GlobalDifficulty,
+			"finality_depth": FinalityDepth,
 		})
 	})
 
@@ -142,15 +153,16 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 		chainMu.Unlock()
 
 		writeJSON(w, http.StatusOK, map[string]any{
-			"addr":       self,
-			"height":     h,
-			"is_boot":    isBoot.Load(),
-			"bootAddr":   boot,
-			"started_at": startedAt.Format(time.RFC3339),
-			"peers":      peersSnapshot(),
-			"difficulty": GlobalDifficulty,
-			"Gov_boot":   getGovBoot(),
-			"last_hash":  lastHash,
+			"addr":           self,
+			"height":         h,
+			"is_boot":        isBoot.Load(),
+			"bootAddr":       boot,
+			"started_at":     startedAt.Format(time.RFC3339),
+			"peers":          peersSnapshot(),
+			"difficulty":     GlobalDifficulty,
+			"Gov_boot":       getGovBoot(),
+			"last_hash":      lastHash,
+			"finality_depth": FinalityDepth,
 		})
 	})
 
@@ -163,20 +175,55 @@ func RegisterAPI(mux *http.ServeMux, chain *LowerChain) {
 
 	// 채굴 요청을 받아 메모리풀에 저장시킴
 	// POST /mine
-	mux.HandleFunc("/mine", func(w http.ResponseWriter, r *http.Request) {
+	// (인증 사용 시 submitter 역할 필요)
+	mux.HandleFunc("/mine", requireRole(RoleSubmitter, func(w http.ResponseWriter, r *http.Request) {
 		var rec []ClinicRecord
 		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
-			http.Error(w, "invalid Clinic record", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "invalid Clinic record")
 			return
 		}
 		defer r.Body.Close()
 
-		appendPending(rec) // 데이터 저장
+		// 접수 노드 서명 접수증 발급 (발급 실패 시 접수하지 않음)
+		receipts, err := issueReceipts(rec)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to issue receipt: %v", err))
+			return
+		}
+
+		appendPending(rec)          // 데이터 저장
+		txIDs := markTxPending(rec) // 레코드별 tx ID 발급 및 pending 상태 기록
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{
-			"status": "Mining Request Submitted",
-			"count":  len(rec),
+			"status":   "Mining Request Submitted",
+			"count":    len(rec),
+			"tx_ids":   txIDs,
+			"receipts": receipts,
 		})
+	}))
+
+	// 접수증 재조회
+	// GET /receipts/<recordHash>
+	mux.HandleFunc("/receipts/", handleGetReceipt)
+
+	// 제출된 레코드의 처리 상태 조회 (pending / mined / orphaned)
+	// GET /tx/<id>
+	mux.HandleFunc("/tx/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/tx/")
+		if id == "" {
+			writeError(w, http.StatusBadRequest, "tx id required")
+			return
+		}
+		st, err := getTxStatus(id)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "tx not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, st)
 	})
 }
@@ -25,20 +25,20 @@ type registerResp struct {
 // 신규노드가 네트워크 진입 시 부트노드에게 다른 노드들의 주소를 제공받기 위한 함수
 func registerPeer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req registerReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Addr == "" {
-		http.Error(w, "invalid body", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid body")
 		return
 	}
 
 	// 체인 정체성 확인: 제네시스 hos_id와 일치해야 가입 허용
 	blk0, err := getBlockByIndex(0)
 	if err != nil || blk0.HosID != req.HosID {
-		http.Error(w, "hos_id mismatch", http.StatusForbidden)
+		writeError(w, http.StatusForbidden, "hos_id mismatch")
 		return
 	}
 
@@ -72,7 +72,7 @@ func registerPeer(w http.ResponseWriter, r *http.Request) {
 		log.Printf("[P2P][REGISTER] notifying %d peers about %s", len(others), newPeer)
 		b, _ := json.Marshal(newPeer)
 		for _, op := range others {
-			resp, err := http.Post("http://"+op+"/addPeer", "application/json", strings.NewReader(string(b)))
+			resp, err := peerClient.Post("http://"+op+"/addPeer", "application/json", strings.NewReader(string(b)))
 			if err != nil {
 				log.Printf("[P2P][REGISTER] notify failed to %s: %v", op, err)
 				continue
@@ -106,7 +106,7 @@ type nodeStatus struct {
 // 해당 노드의 현재 상태(nodeStatus)를 가져옴
 func probeStatus(addr string) (nodeStatus, bool) {
 	var s nodeStatus
-	resp, err := http.Get("http://" + addr + "/status")
+	resp, err := peerClient.Get("http://" + addr + "/status")
 	if err != nil {
 		return s, false
 	}
@@ -202,7 +202,7 @@ func broadcastNewBoot(newBoot string) {
 	for _, p := range peersSnapshot() {
 		go func(dst string) {
 			body, _ := json.Marshal(map[string]string{"addr": newBoot})
-			_, err := http.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
+			_, err := peerClient.Post("http://"+dst+"/bootNotify", "application/json", strings.NewReader(string(body)))
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -213,7 +213,7 @@ func broadcastNewBoot(newBoot string) {
 // 부트노드 변경 수신(모든 노드 수행)
 func bootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -222,12 +222,12 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.Addr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.Addr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.Addr)
 		return
 	}
@@ -249,7 +249,7 @@ func bootNotify(w http.ResponseWriter, r *http.Request) {
 // POST /chgGovBoot
 func chgGovBoot(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -258,12 +258,12 @@ func chgGovBoot(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.GovAddr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.GovAddr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new Gov Boot addr (%s) but not reachable", in.GovAddr)
 		return
 	}
@@ -281,7 +281,7 @@ func broadcastNewGovBoot(govBoot string) {
 		go func(dst string) {
 			log.Printf("[BOOT][Gov] HosBOOT is now sending New GovBootNode's Addr to : %s", dst)
 			body, _ := json.Marshal(map[string]string{"addr": govBoot})
-			_, err := http.Post("http://"+dst+"/govBootNotify", "application/json", strings.NewReader(string(body)))
+			_, err := peerClient.Post("http://"+dst+"/govBootNotify", "application/json", strings.NewReader(string(body)))
 			if err != nil {
 				log.Printf("[BOOT] notify failed to %s: %v", dst, err)
 			}
@@ -293,7 +293,7 @@ func broadcastNewGovBoot(govBoot string) {
 // POST : /govBootNotify
 func govBootNotify(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", 405)
+		writeError(w, 405, "method not allowed")
 		return
 	}
 	// 응답 파싱할 구조체
@@ -302,12 +302,12 @@ func govBootNotify(w http.ResponseWriter, r *http.Request) {
 	}
 	// 요청 본문이 유효한 JSON이 아니거나 addr 필드가 비어 있다면 잘못된 요청으로 간주
 	if json.NewDecoder(r.Body).Decode(&in) != nil || in.govAddr == "" {
-		http.Error(w, "bad body", 400)
+		writeError(w, 400, "bad body")
 		return
 	}
 	// 전달받은 gov 부트노드 주소가 실제로 살아있는지 검증
 	if _, ok := probeStatus(in.govAddr); !ok {
-		http.Error(w, "boot not reachable", 502)
+		writeError(w, 502, "boot not reachable")
 		log.Printf("[BOOT] received new boot addr (%s) but not reachable", in.govAddr)
 		return
 	}
@@ -321,8 +321,12 @@ func govBootNotify(w http.ResponseWriter, r *http.Request) {
 
 func setBootAddr(addr string) {
 	bootAddrMu.Lock()
+	prev := boot
 	boot = addr
 	bootAddrMu.Unlock()
+	if prev != addr {
+		publishEvent(EventBootElected, map[string]any{"boot": addr, "prev": prev, "is_self": addr == self})
+	}
 }
 func getBootAddr() string {
 	bootAddrMu.RLock()
@@ -331,8 +335,12 @@ func getBootAddr() string {
 }
 func setGovBoot(addr string) {
 	govBootMu.Lock()
+	prev := govBoot
 	govBoot = addr
 	govBootMu.Unlock()
+	if prev != addr {
+		retargetAnchorQueue()
+	}
 }
 func getGovBoot() string {
 	govBootMu.RLock()
@@ -19,6 +19,7 @@ type LowerChain struct {
 	difficulty    int            // 체인 난이도 (모든 노드 동일)
 	pending       []ClinicRecord // 아직 블록에 포함되지 않은 Hos 루트 (HosID => Root)
 	pendingMu     sync.Mutex
+	inflight      []string  // 메모리풀에서 꺼내 채굴 중인 레코드의 tx ID (orphaned 판정용)
 	lastBlockTime time.Time // 마지막 블록 생성 시각
 }
 
@@ -51,6 +52,14 @@ func newLowerChain(hosID string) (*LowerChain, error) {
 
 	// 제네시스 블록 존재 여부 확인
 	genesis, err := getBlockByIndex(0)
+	// 난이도 조정 파라미터 결정 (제네시스에 기록된 규칙 우선, difficulty.go)
+	var recorded *LowerBlock
+	if err == nil {
+		recorded = &genesis
+	}
+	if derr := initDifficultyParams(DifficultyConfig, recorded); derr != nil {
+		return nil, fmt.Errorf("difficulty params: %w", derr)
+	}
 	// 제네시스 블록이 없는 경우
 	if err != nil {
 
@@ -68,6 +77,7 @@ func newLowerChain(hosID string) (*LowerChain, error) {
 			return nil, fmt.Errorf("set genesis height: %w", err)
 		}
 		ch.lastBlockTime = time.Now()
+		refreshDifficulty()
 
 		// 부트노드는 여기서 meta_hos_id 저장
 		putMeta("meta_hos_id", hosID)
@@ -79,6 +89,8 @@ func newLowerChain(hosID string) (*LowerChain, error) {
 	if err := putMeta("meta_hos_id", genesis.HosID); err != nil {
 		return nil, err
 	}
+	// 재시작 시 장부 기준으로 난이도 복원
+	refreshDifficulty()
 
 	return ch, nil
 }
@@ -100,6 +112,7 @@ func onBlockReceived(lb LowerBlock) error {
 	if !validHash(lb.BlockHash, lb.Difficulty) {
 		return fmt.Errorf("invalid PoW hash")
 	}
+	lb.Elapsed = blockInterval(lb, prev)
 
 	// 체인에 추가
 	if err := saveBlockToDB(lb); err != nil {
@@ -111,13 +124,19 @@ func onBlockReceived(lb LowerBlock) error {
 	if err := setLatestHeight(lb.Index); err != nil {
 		return fmt.Errorf("set height: %w", err)
 	}
+	// 채굴에 사용됐지만 이번 블록에 포함되지 않은 레코드는 orphaned 처리
+	settleInflight(lb)
 	// 마지막 블록 생성 시각 업데이트
 	ch.lastBlockTime = time.Now()
+	// 장부에 반영된 블록 기준으로 다음 블록 난이도 계산 (제어 메시지 seq 먼저 기록)
+	recordControlSeq(lb.Control)
+	refreshDifficulty()
 	// 부트노드일 경우, 서명하여 Gov 체인으로 제출
 	if self == boot {
 		submitAnchor(lb)
 		logInfo("[BOOT] New Block's Anchor was sent By BootNode")
 	}
+	publishFinalizedAt(lb.Index)
 	logInfo("[CHAIN] Accepted New Block #%d (%s)", lb.Index, lb.BlockHash[:12])
 	return nil
 }
@@ -130,6 +149,32 @@ func appendPending(entries []ClinicRecord) {
 	log.Printf("[CHAIN][PENDING] Append pending entries (%d items)", len(entries))
 }
 
+// 채굴 중이던 레코드 중 확정 블록에 포함되지 않은 레코드를 orphaned 처리
+func settleInflight(lb LowerBlock) {
+	ch.pendingMu.Lock()
+	inflight := ch.inflight
+	ch.inflight = nil
+	ch.pendingMu.Unlock()
+	if len(inflight) == 0 {
+		return
+	}
+
+	included := make(map[string]bool, len(lb.Entries))
+	for _, rec := range lb.Entries {
+		included[hashClinicRecord(rec)] = true
+	}
+	orphaned := []string{}
+	for _, id := range inflight {
+		if !included[id] {
+			orphaned = append(orphaned, id)
+		}
+	}
+	if len(orphaned) > 0 {
+		markTxOrphaned(orphaned)
+		log.Printf("[CHAIN][TX] %d records orphaned by Block #%d", len(orphaned), lb.Index)
+	}
+}
+
 // 체인의 메모리풀인 pending에 컨텐츠 내용 비우고 가져오기
 func getPending() []ClinicRecord {
 	ch.pendingMu.Lock()
@@ -139,6 +184,10 @@ func getPending() []ClinicRecord {
 	copy(entries, ch.pending)
 	// 원본 비우기
 	ch.pending = []ClinicRecord{}
+	// 채굴에 사용될 레코드의 tx ID 기록
+	for _, rec := range entries {
+		ch.inflight = append(ch.inflight, hashClinicRecord(rec))
+	}
 	log.Printf("[CHAIN][PENDING] Pop pending entries (%d items)", len(entries))
 	return entries
 }
@@ -150,6 +199,13 @@ func pendingIsEmpty() bool {
 	return len(ch.pending) == 0
 }
 
+// 메모리풀의 엔트리 개수 확인
+func getPendingCnt() int {
+	ch.pendingMu.Lock()
+	defer ch.pendingMu.Unlock()
+	return len(ch.pending)
+}
+
 // 간단 로그 출력 함수
 func logInfo(format string, args ...interface{}) {
 	fmt.Printf("[INFO] "+format+"\n", args...)
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Config (노드 설정 파일)
+// ------------------------------------------------------------
+// - 적용 우선순위 : 기본값 < 설정 파일 < 환경변수 (기존 환경변수 배포 방식 유지)
+// - 설정 파일 : CONFIG_FILE 지정 시 해당 파일, 미지정 시 작업 디렉터리의
+//   config.json / config.yaml / config.yml 순으로 탐색 (없으면 기본값 + 환경변수)
+// - YAML 은 단순 "key: value" 와 "- item" 목록만 지원
+// - 검증 실패 시 노드를 시작하지 않음
+// - GET /config : 적용된 설정(읽기 전용) 조회
+////////////////////////////////////////////////////////////////////////////////
+
+type Config struct {
+	NodeAddr           string   `json:"node_addr"`            // 이 노드의 외부접속 주소 (NODE_ADDR)
+	BootstrapAddr      string   `json:"bootstrap_addr"`       // Hos 체인 부트노드 주소 (BOOTSTRAP_ADDR)
+	GovBootstrapAddr   string   `json:"gov_bootstrap_addr"`   // Gov 체인 부트노드 주소 (GOV_BOOTSTRAP_ADDR)
+	Port               int      `json:"port"`                 // 수신 포트 (PORT)
+	DBPath             string   `json:"db_path"`              // LevelDB 경로 (Hos_DB_PATH)
+	HosID              string   `json:"hos_id"`               // Hos 체인 식별자 (Hos_ID)
+	Peers              []string `json:"peers"`                // 시작 시 추가할 고정 피어 (PEERS, 쉼표 구분)
+	Difficulty         int      `json:"difficulty"`           // 초기 난이도, 모든 노드 동일해야 함 (DIFFICULTY)
+	DiffStandardTime   int      `json:"diff_standard_time"`   // 난이도 조정 기준 시간(초) (DIFF_STANDARD_TIME)
+	DiffWindow         int      `json:"diff_window"`          // 평균 간격을 재는 블록 수 (DIFF_WINDOW)
+	DiffMin            int      `json:"diff_min"`             // 난이도 하한 (DIFF_MIN)
+	DiffMax            int      `json:"diff_max"`             // 난이도 상한 (DIFF_MAX)
+	DiffRaiseRatio     float64  `json:"diff_raise_ratio"`     // 평균 간격 / 기준 시간이 이보다 작으면 +1 (DIFF_RAISE_RATIO)
+	DiffLowerRatio     float64  `json:"diff_lower_ratio"`     // 이보다 크면 -1 (DIFF_LOWER_RATIO)
+	MiningWatcherTime  int      `json:"mining_watcher_time"`  // 메모리풀 검사 주기(초) (MINING_WATCHER_TIME)
+	MiningWorkers      int      `json:"mining_workers"`       // nonce 탐색 워커 수, 기본 GOMAXPROCS (MINING_WORKERS)
+	NetworkWatcherTime int      `json:"network_watcher_time"` // 노드 관리 주기(초) (NETWORK_WATCHER_TIME)
+	ChainWatcherTime   int      `json:"chain_watcher_time"`   // 체인 관리 주기(초) (CHAIN_WATCHER_TIME)
+	FinalityDepth      int      `json:"finality_depth"`       // 블록 최종성 깊이 (FINALITY_DEPTH)
+	LegacySunset       string   `json:"legacy_sunset"`        // 버전 없는 기존 API 경로 폐기 시각, RFC3339 (API_LEGACY_SUNSET)
+	// 난이도 제어 메시지 검증용 공개키 PEM 파일, 비어 있으면 제어 메시지 비활성 (CONTROL_AUTHORITY_KEY_FILE)
+	ControlAuthorityKeyFile string `json:"control_authority_key_file"`
+}
+
+var (
+	cfg       Config
+	cfgSource = "defaults" // 설정 파일 경로 (없으면 defaults)
+)
+
+func defaultConfig() Config {
+	return Config{
+		NodeAddr:           "hos-node-00:5000",
+		BootstrapAddr:      "hos-boot:5000",
+		GovBootstrapAddr:   "gov-boot:5000",
+		Port:               5000,
+		DBPath:             "blockchain_db",
+		HosID:              "Hos-A",
+		Peers:              []string{},
+		Difficulty:         GlobalDifficulty,
+		DiffStandardTime:   DiffStandardTime,
+		DiffWindow:         defaultDifficultyParams.Window,
+		DiffMin:            defaultDifficultyParams.MinDifficulty,
+		DiffMax:            defaultDifficultyParams.MaxDifficulty,
+		DiffRaiseRatio:     defaultDifficultyParams.RaiseRatio,
+		DiffLowerRatio:     defaultDifficultyParams.LowerRatio,
+		MiningWatcherTime:  MiningWatcherTime,
+		MiningWorkers:      MiningWorkers,
+		NetworkWatcherTime: NetworkWatcherTime,
+		ChainWatcherTime:   ChainWatcherTime,
+		FinalityDepth:      DefaultFinalityDepth,
+		LegacySunset:       LegacySunsetDefault,
+	}
+}
+
+// 설정 로드 => 검증 => 전역 설정값 반영
+func loadConfig() error {
+	c := defaultConfig()
+
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		for _, p := range []string{"config.json", "config.yaml", "config.yml"} {
+			if _, err := os.Stat(p); err == nil {
+				path = p
+				break
+			}
+		}
+	}
+	if path != "" {
+		if err := readConfigFile(path, &c); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		cfgSource = path
+	}
+
+	if err := applyEnvOverrides(&c); err != nil {
+		return err
+	}
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if err := loadControlAuthority(c.ControlAuthorityKeyFile); err != nil {
+		return fmt.Errorf("invalid config: control_authority_key_file: %w", err)
+	}
+
+	cfg = c
+	self = c.NodeAddr
+	boot = c.BootstrapAddr
+	govBoot = c.GovBootstrapAddr
+	GlobalDifficulty = c.Difficulty
+	DiffStandardTime = c.DiffStandardTime
+	DifficultyConfig = c.difficultyParams() // 새 체인에만 적용 (기존 체인은 기록된 규칙, difficulty.go)
+	MiningWatcherTime = c.MiningWatcherTime
+	MiningWorkers = c.MiningWorkers
+	NetworkWatcherTime = c.NetworkWatcherTime
+	ChainWatcherTime = c.ChainWatcherTime
+	FinalityDepth = c.FinalityDepth
+	return nil
+}
+
+func readConfigFile(path string, c *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		m, err := parseSimpleYAML(data)
+		if err != nil {
+			return err
+		}
+		if data, err = json.Marshal(m); err != nil {
+			return err
+		}
+	case ".json":
+	default:
+		return fmt.Errorf("unsupported config format (use .json, .yaml or .yml)")
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields() // 오타난 키를 조용히 무시하지 않음
+	return dec.Decode(c)
+}
+
+// "key: value" 와 목록("key:" 다음 줄의 "- item")만 해석하는 최소 YAML 파서
+func parseSimpleYAML(data []byte) (map[string]any, error) {
+	out := map[string]any{}
+	listKey := ""
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") {
+			if listKey == "" {
+				return nil, fmt.Errorf("line %d: list item without key", n)
+			}
+			out[listKey] = append(out[listKey].([]any), yamlScalar(strings.TrimSpace(trimmed[2:])))
+			continue
+		}
+		k, v, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", n)
+		}
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		listKey = ""
+		if v == "" {
+			listKey = k
+			out[k] = []any{}
+			continue
+		}
+		out[k] = yamlScalar(v)
+	}
+	return out, sc.Err()
+}
+
+func yamlScalar(v string) any {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+	if n, err := strconv.Atoi(v); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f
+	}
+	return v
+}
+
+// 설정값으로 만든 새 체인의 난이도 규칙
+func (c Config) difficultyParams() DifficultyParams {
+	return DifficultyParams{
+		TargetBlockTime: c.DiffStandardTime,
+		Window:          c.DiffWindow,
+		MinDifficulty:   c.DiffMin,
+		MaxDifficulty:   c.DiffMax,
+		RaiseRatio:      c.DiffRaiseRatio,
+		LowerRatio:      c.DiffLowerRatio,
+	}
+}
+
+// 기존 환경변수가 지정되어 있으면 설정 파일 값보다 우선
+func applyEnvOverrides(c *Config) error {
+	strs := map[string]*string{
+		"NODE_ADDR":                  &c.NodeAddr,
+		"BOOTSTRAP_ADDR":             &c.BootstrapAddr,
+		"GOV_BOOTSTRAP_ADDR":         &c.GovBootstrapAddr,
+		"Hos_DB_PATH":                &c.DBPath,
+		"Hos_ID":                     &c.HosID,
+		"API_LEGACY_SUNSET":          &c.LegacySunset,
+		"CONTROL_AUTHORITY_KEY_FILE": &c.ControlAuthorityKeyFile,
+	}
+	for k, p := range strs {
+		*p = getEnvDefault(k, *p)
+	}
+	ints := map[string]*int{
+		"PORT":                 &c.Port,
+		"DIFFICULTY":           &c.Difficulty,
+		"DIFF_STANDARD_TIME":   &c.DiffStandardTime,
+		"DIFF_WINDOW":          &c.DiffWindow,
+		"DIFF_MIN":             &c.DiffMin,
+		"DIFF_MAX":             &c.DiffMax,
+		"MINING_WATCHER_TIME":  &c.MiningWatcherTime,
+		"MINING_WORKERS":       &c.MiningWorkers,
+		"NETWORK_WATCHER_TIME": &c.NetworkWatcherTime,
+		"CHAIN_WATCHER_TIME":   &c.ChainWatcherTime,
+		"FINALITY_DEPTH":       &c.FinalityDepth,
+	}
+	for k, p := range ints {
+		v := os.Getenv(k)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("env %s: invalid integer %q", k, v)
+		}
+		*p = n
+	}
+	floats := map[string]*float64{
+		"DIFF_RAISE_RATIO": &c.DiffRaiseRatio,
+		"DIFF_LOWER_RATIO": &c.DiffLowerRatio,
+	}
+	for k, p := range floats {
+		v := os.Getenv(k)
+		if v == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("env %s: invalid number %q", k, v)
+		}
+		*p = f
+	}
+	if v := os.Getenv("PEERS"); v != "" {
+		c.Peers = strings.Split(v, ",")
+	}
+	return nil
+}
+
+func (c Config) validate() error {
+	var errs []string
+	if c.Port < 1 || c.Port > 65535 {
+		errs = append(errs, fmt.Sprintf("port out of range: %d", c.Port))
+	}
+	for name, addr := range map[string]string{"node_addr": c.NodeAddr, "bootstrap_addr": c.BootstrapAddr, "gov_bootstrap_addr": c.GovBootstrapAddr} {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			errs = append(errs, fmt.Sprintf("%s must be host:port: %q", name, addr))
+		}
+	}
+	for _, p := range c.Peers {
+		if _, _, err := net.SplitHostPort(strings.TrimSpace(p)); err != nil {
+			errs = append(errs, fmt.Sprintf("peer must be host:port: %q", p))
+		}
+	}
+	if c.HosID == "" {
+		errs = append(errs, "hos_id is empty")
+	}
+	if c.DBPath == "" {
+		errs = append(errs, "db_path is empty")
+	}
+	if err := c.difficultyParams().validate(); err != nil {
+		errs = append(errs, err.Error())
+	} else if c.Difficulty < c.DiffMin || c.Difficulty > c.DiffMax {
+		errs = append(errs, fmt.Sprintf("difficulty must be %d..%d: %d", c.DiffMin, c.DiffMax, c.Difficulty))
+	}
+	for name, v := range map[string]int{
+		"diff_standard_time":   c.DiffStandardTime,
+		"mining_watcher_time":  c.MiningWatcherTime,
+		"mining_workers":       c.MiningWorkers,
+		"network_watcher_time": c.NetworkWatcherTime,
+		"chain_watcher_time":   c.ChainWatcherTime,
+	} {
+		if v <= 0 {
+			errs = append(errs, fmt.Sprintf("%s must be positive: %d", name, v))
+		}
+	}
+	if _, err := time.Parse(time.RFC3339, c.LegacySunset); err != nil {
+		errs = append(errs, fmt.Sprintf("legacy_sunset must be RFC3339: %q", c.LegacySunset))
+	}
+	if c.FinalityDepth < 0 {
+		errs = append(errs, fmt.Sprintf("finality_depth must not be negative: %d", c.FinalityDepth))
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("invalid config: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// GET /config
+func handleConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"source": cfgSource,
+		"config": cfg,
+	})
+}
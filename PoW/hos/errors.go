@@ -0,0 +1,74 @@
+package main
+
+import (
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// API 오류 응답 (공통 JSON 오류 봉투)
+// ------------------------------------------------------------
+// - 모든 4xx/5xx 응답 본문 : {"code": "...", "message": "...", "details": ...}
+//   · code    : 기계가 분기할 오류 코드 (상태 코드별 기본값 errorCodeFor, 핸들러가 세부 코드 지정 가능)
+//   · message : 사람이 읽는 설명
+//   · details : 거부 목록 등 부가 정보 (없으면 생략)
+// - writeError : 기본 코드 사용 / writeErrorDetail : 세부 코드 + details 지정
+////////////////////////////////////////////////////////////////////////////////
+
+// 오류 응답 본문
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+// 상태 코드별 기본 오류 코드
+func errorCodeFor(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusMethodNotAllowed:
+		return "method_not_allowed"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusGone:
+		return "gone"
+	case http.StatusRequestEntityTooLarge:
+		return "payload_too_large"
+	case http.StatusUnprocessableEntity:
+		return "unprocessable"
+	case http.StatusUpgradeRequired:
+		return "upgrade_required"
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	case http.StatusNotImplemented:
+		return "not_implemented"
+	case http.StatusBadGateway:
+		return "bad_gateway"
+	case http.StatusServiceUnavailable:
+		return "unavailable"
+	case http.StatusGatewayTimeout:
+		return "gateway_timeout"
+	}
+	if status >= 500 {
+		return "internal"
+	}
+	return "error"
+}
+
+// 오류 응답 (상태 코드의 기본 오류 코드 사용)
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeErrorDetail(w, status, errorCodeFor(status), message, nil)
+}
+
+// 오류 응답 (세부 오류 코드 + 부가 정보)
+func writeErrorDetail(w http.ResponseWriter, status int, code, message string, details any) {
+	w.Header().Del("Content-Length")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	writeJSON(w, status, ErrorBody{Code: code, Message: message, Details: details})
+}
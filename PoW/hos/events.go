@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"wsconn"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Event Stream (대시보드 실시간 이벤트)
+// ------------------------------------------------------------
+// - GET /ws/events : WebSocket 으로 이벤트 push (Upgrade 헤더가 없으면 SSE 로 응답)
+// - GET /events    : Server-Sent Events (text/event-stream)
+// - ?types=block_finalized,peer_joined 처럼 이벤트 유형 필터 가능 (미지정 시 전체)
+// - 이벤트 유형
+//   · block_finalized : 블록 최종 확정 (PoW 는 FinalityDepth 만큼 확인된 시점)
+//   · anchor_accepted : Gov 체인이 앵커를 수락
+//   · boot_elected    : 부트노드 변경
+//   · peer_joined / peer_left : 피어 추가/제거
+// - 느린 구독자는 버퍼(EventBufferSize)가 차면 이벤트를 건너뜀 (노드 처리를 막지 않음)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	EventBufferSize    = 64
+	EventHeartbeatTime = 15 // 초
+)
+
+const (
+	EventBlockFinalized = "block_finalized"
+	EventAnchorAccepted = "anchor_accepted"
+	EventBootElected    = "boot_elected"
+	EventPeerJoined     = "peer_joined"
+	EventPeerLeft       = "peer_left"
+)
+
+type NodeEvent struct {
+	Type     string `json:"type"`
+	NodeRole string `json:"node_role"`
+	ChainID  string `json:"chain_id"`
+	Node     string `json:"node"`
+	Ts       string `json:"ts"`
+	Data     any    `json:"data"`
+}
+
+var (
+	eventSubs   = make(map[chan NodeEvent]struct{})
+	eventSubsMu sync.Mutex
+)
+
+// 이벤트 발행 (구독자가 없으면 아무 일도 하지 않음)
+func publishEvent(typ string, data any) {
+	eventSubsMu.Lock()
+	defer eventSubsMu.Unlock()
+	if len(eventSubs) == 0 {
+		return
+	}
+	ev := NodeEvent{
+		Type:     typ,
+		NodeRole: metricsNodeRole,
+		ChainID:  selfID(),
+		Node:     self,
+		Ts:       time.Now().UTC().Format(time.RFC3339Nano),
+		Data:     data,
+	}
+	for sub := range eventSubs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+}
+
+func subscribeEvents() chan NodeEvent {
+	sub := make(chan NodeEvent, EventBufferSize)
+	eventSubsMu.Lock()
+	eventSubs[sub] = struct{}{}
+	eventSubsMu.Unlock()
+	return sub
+}
+
+func unsubscribeEvents(sub chan NodeEvent) {
+	eventSubsMu.Lock()
+	delete(eventSubs, sub)
+	eventSubsMu.Unlock()
+}
+
+// ?types= 필터 (nil 이면 전체 허용)
+func eventFilter(r *http.Request) map[string]bool {
+	q := r.URL.Query().Get("types")
+	if q == "" {
+		return nil
+	}
+	f := map[string]bool{}
+	for _, t := range strings.Split(q, ",") {
+		f[strings.TrimSpace(t)] = true
+	}
+	return f
+}
+
+// 블록 높이 h 반영 시, FinalityDepth 만큼 확인된 블록을 확정 이벤트로 발행
+func publishFinalizedAt(h int) {
+	idx := h - FinalityDepth
+	if idx < 0 {
+		return
+	}
+	b, err := getBlockByIndex(idx)
+	if err != nil {
+		return
+	}
+	publishEvent(EventBlockFinalized, map[string]any{
+		"index":         b.Index,
+		"block_hash":    b.BlockHash,
+		"prev_hash":     b.PrevHash,
+		"merkle_root":   b.MerkleRoot,
+		"timestamp":     b.Timestamp,
+		"entry_count":   len(b.Entries),
+		"confirmations": h - idx,
+	})
+}
+
+// GET /events (SSE)
+func handleSSEEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	rc := http.NewResponseController(w)
+	filter := eventFilter(r)
+	sub := subscribeEvents()
+	defer unsubscribeEvents(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	hb := time.NewTicker(EventHeartbeatTime * time.Second)
+	defer hb.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-hb.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case ev := <-sub:
+			if filter != nil && !filter[ev.Type] {
+				continue
+			}
+			data, _ := json.Marshal(ev)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, data)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// GET /ws/events (WebSocket, 서버 => 클라이언트 단방향 텍스트 프레임, 프레임 처리는 wsconn 패키지)
+func handleWSEvents(w http.ResponseWriter, r *http.Request) {
+	if !wsconn.IsUpgrade(r) {
+		handleSSEEvents(w, r)
+		return
+	}
+	conn, err := wsconn.Upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	filter := eventFilter(r)
+	sub := subscribeEvents()
+	defer unsubscribeEvents(sub)
+
+	// 클라이언트 메시지 수신 : ping 응답/조각 재조립은 wsconn 이 처리, close/프로토콜 위반/오류 시 종료
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	hb := time.NewTicker(EventHeartbeatTime * time.Second)
+	defer hb.Stop()
+	for {
+		var err error
+		select {
+		case <-done:
+			return
+		case <-hb.C:
+			err = conn.Ping()
+		case ev := <-sub:
+			if filter != nil && !filter[ev.Type] {
+				continue
+			}
+			data, _ := json.Marshal(ev)
+			err = conn.WriteText(data)
+		}
+		if err != nil {
+			log.Printf("[EVENTS] websocket closed: %v", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+// 블록 확정성 (PoW 확인 수 / 최종성 깊이)
+// ------------------------------------------------------------
+// - PoW 체인은 포크 선택으로 최근 블록이 교체될 수 있으므로, 블록 위에 쌓인 블록 수(확인 수)로 신뢰도를 표시
+//   · confirmations = 최신 높이 - 블록 높이
+//   · final         = confirmations >= FinalityDepth
+// - 블록/검색 응답에 confirmations, final 필드 포함
+// - /search?require_final=true : 최종성 깊이에 못 미친 블록의 증명은 거부(409), 미지정 시 warning 만 첨부
+////////////////////////////////////////////////////////////////////////////////
+
+const DefaultFinalityDepth = 6
+
+var FinalityDepth = DefaultFinalityDepth // 설정 finality_depth (FINALITY_DEPTH) 로 변경 가능
+
+// 블록 높이 기준 확인 수 (최신 높이 - 블록 높이)
+func confirmations(height int) int {
+	return confirmationsFrom(db, height)
+}
+func confirmationsFrom(rd dbReader, height int) int {
+	tip, _ := getLatestHeightFrom(rd)
+	if tip < height {
+		return 0
+	}
+	return tip - height
+}
+
+// 확인 수를 포함한 블록 응답
+type BlockView struct {
+	LowerBlock
+	Confirmations int  `json:"confirmations"`
+	Final         bool `json:"final"`
+}
+
+func newBlockView(b LowerBlock) BlockView {
+	c := confirmations(b.Index)
+	return BlockView{LowerBlock: b, Confirmations: c, Final: c >= FinalityDepth}
+}
+
+func newBlockViews(blocks []LowerBlock) []BlockView {
+	out := make([]BlockView, 0, len(blocks))
+	for _, b := range blocks {
+		out = append(out, newBlockView(b))
+	}
+	return out
+}
+
+// 최종성 미달 경고 문구
+func shallowWarning(c, depth int) string {
+	return fmt.Sprintf("block has %d confirmations, below finality depth %d; the proof may be invalidated by a reorg", c, depth)
+}
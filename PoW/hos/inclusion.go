@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Inclusion (검색 응답의 레코드 포함 정보)
+// ------------------------------------------------------------
+// - /search 응답의 각 항목에 inclusion 객체로 포함 (Gov /query 응답도 동일 구조)
+//   · block_index / entry_index / block_timestamp : 레코드가 기록된 위치
+//   · confirmations / final : 최신 높이 기준 확인 수와 FinalityDepth 충족 여부
+//   · anchor_status : anchored(상위 블록에 포함, upper_block_index 제공) | pending | unknown
+// - 앵커 상태는 Gov 부트노드의 GET /anchor/status 로 조회
+//   (anchored 는 바뀌지 않으므로 결과를 캐시)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	AnchorStatusAnchored = "anchored"
+	AnchorStatusPending  = "pending"
+	AnchorStatusUnknown  = "unknown"
+)
+
+type Inclusion struct {
+	BlockIndex      int    `json:"block_index"`
+	EntryIndex      int    `json:"entry_index"`
+	BlockTimestamp  string `json:"block_timestamp"`
+	Confirmations   int    `json:"confirmations"`
+	Final           bool   `json:"final"`
+	AnchorStatus    string `json:"anchor_status"`
+	UpperBlockIndex *int   `json:"upper_block_index,omitempty"` // anchored 인 경우 Gov 블록 번호
+}
+
+// Gov GET /anchor/status 응답
+type AnchorStatusResponse struct {
+	HosID           string `json:"hos_id"`
+	Root            string `json:"root"`
+	AnchorStatus    string `json:"anchor_status"`
+	UpperBlockIndex *int   `json:"upper_block_index,omitempty"`
+}
+
+// 블록 내 entryIndex 번째 레코드의 포함 정보 생성
+// - 확인 수는 조회 스냅샷(rd) 기준 높이로 계산
+func buildInclusion(rd dbReader, blk *LowerBlock, entryIndex int) Inclusion {
+	conf := confirmationsFrom(rd, blk.Index)
+	status, upper := lookupAnchorStatus(blk.HosID, blk.MerkleRoot)
+	return Inclusion{
+		BlockIndex:      blk.Index,
+		EntryIndex:      entryIndex,
+		BlockTimestamp:  blk.Timestamp,
+		Confirmations:   conf,
+		Final:           conf >= FinalityDepth,
+		AnchorStatus:    status,
+		UpperBlockIndex: upper,
+	}
+}
+
+// 앵커 상태 캐시 키 (값 = Gov 블록 번호)
+func anchoredCacheKey(root string) string {
+	return "meta_anchored_" + root
+}
+
+// hosID 의 root 가 Gov 장부에 앵커링되었는지 조회 (Gov 에 닿지 않으면 unknown)
+func lookupAnchorStatus(hosID, root string) (string, *int) {
+	if v, ok := getMeta(anchoredCacheKey(root)); ok {
+		if idx, err := strconv.Atoi(v); err == nil {
+			return AnchorStatusAnchored, &idx
+		}
+	}
+	if govBoot == "" {
+		return AnchorStatusUnknown, nil
+	}
+	q := url.Values{"hos_id": {hosID}, "root": {root}}
+	resp, err := http.Get("http://" + govBoot + "/anchor/status?" + q.Encode())
+	if err != nil {
+		log.Printf("[INCLUSION][WARN] anchor status lookup failed: %v", err)
+		return AnchorStatusUnknown, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return AnchorStatusUnknown, nil
+	}
+	var st AnchorStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil || st.AnchorStatus == "" {
+		return AnchorStatusUnknown, nil
+	}
+	if st.AnchorStatus == AnchorStatusAnchored && st.UpperBlockIndex != nil {
+		_ = putMeta(anchoredCacheKey(root), strconv.Itoa(*st.UpperBlockIndex))
+	}
+	return st.AnchorStatus, st.UpperBlockIndex
+}
@@ -7,19 +7,22 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 )
 
 func main() {
-	// 1) 설정값 (환경변수 혹은 기본값 사용)
-	dbPath := getEnvDefault("Hos_DB_PATH", "blockchain_db")
-	hosID := getEnvDefault("Hos_ID", "Hos-A")
-	addr := getEnvDefault("PORT", "5000")
-	addr = ":" + addr
+	// 1) 설정값 (기본값 < 설정 파일 < 환경변수)
+	if err := loadConfig(); err != nil {
+		log.Fatal("[START] ", err)
+	}
+	log.Printf("[START] Config loaded (source=%s)", cfgSource)
+	dbPath := cfg.DBPath
+	hosID := cfg.HosID
+	addr := ":" + strconv.Itoa(cfg.Port)
 
-	boot = getEnvDefault("BOOTSTRAP_ADDR", "hos-boot:5000")        // Hos체인 부트노드 주소
-	self = getEnvDefault("NODE_ADDR", "hos-node-00:5000")          // 이 노드의 외부접속 주소
-	govBoot = getEnvDefault("GOV_BOOTSTRAP_ADDR", "gov-boot:5000") // GOV체인 부트노드 주소
+	// API 인증 설정 (API_KEYS / JWT_SECRET / PEER_TOKEN)
+	initAuth()
 
 	// 2) DB 초기화
 	initDB(dbPath)
@@ -41,40 +44,65 @@ func main() {
 	//     - /addPeer : 기존 노드들이 신규 노드를 추가
 	//	   - /mine/start : 노드 간 채굴 요청 전파
 	//     - /receiveBlock : 다른 노드가 보낸 확정 블록 수신
+	//     - /gossip/block : 다른 노드가 알린 신규 블록 해시 수신 (본문은 /block/hash 로 pull)
 	//	   - /register : 부트노드 연결 및 네트워크 연결
 	//	   - /bootNotify : 부트노드 변경 수신
 	//	   - /getPublicKey : 공개키 반환
+	//	   - /control/difficulty : 부트노드 서명 난이도 제어 메시지 발행 (부트노드 전용)
 	//	   - /chgGovBoot : 신규 선출된 Gov 부트노드 주소를 Hos 부트노드가 수신
 	//	   - /govBootNotify : Hos 부트노드로부터 전파된 Gov 부트노드 주소 수신
-	mux.HandleFunc("/addPeer", addPeer)
-	mux.HandleFunc("/mine/start", handleMineStart)
-	mux.HandleFunc("/receiveBlock", receiveBlock)
-	mux.HandleFunc("/register", registerPeer)
-	mux.HandleFunc("/bootNotify", bootNotify)
+	//	   - /admin/usage : API 키별 사용량 조회 (operator 전용)
+	//	   - /metrics : Prometheus 메트릭 (체인 높이, 채굴, 동기화 지연 등)
+	//	   - /config : 적용된 노드 설정 조회 (읽기 전용)
+	//	   - /ws/events : 블록 확정/앵커 수락/부트노드 선출/피어 변동 이벤트 WebSocket 스트림 (Upgrade 없으면 SSE)
+	//	   - /events : 동일 이벤트의 SSE 스트림
+	//	   (인증 사용 시 노드 간 엔드포인트는 peer, 관리 엔드포인트는 operator 역할 필요)
+	//	   (같은 체인 노드 간 엔드포인트는 X-Chain-ID 가 없거나 다른 요청 거절 : chainid.go)
+	//	   (모든 경로는 /v1/<경로> 로도 호출 가능, 버전 없는 경로는 폐기 예정 헤더 포함 / GET /v1/meta : 지원 기능 조회)
+	mux.HandleFunc("/addPeer", requireRole(RolePeer, addPeer))
+	mux.HandleFunc("/mine/start", requireSameChain(requireRole(RolePeer, handleMineStart)))
+	mux.HandleFunc("/receiveBlock", requireSameChain(requireRole(RolePeer, receiveBlock)))
+	mux.HandleFunc("/gossip/block", requireSameChain(requireRole(RolePeer, handleGossipBlock)))
+	mux.HandleFunc("/register", requireRole(RolePeer, registerPeer))
+	mux.HandleFunc("/bootNotify", requireRole(RolePeer, bootNotify))
 	mux.HandleFunc("/getPublicKey", getPublicKey)
-	mux.HandleFunc("/chgGovBoot", chgGovBoot)
-	mux.HandleFunc("/govBootNotify", govBootNotify)
+	mux.HandleFunc("/control/difficulty", requireRole(RoleOperator, handleDifficultyControl))
+	mux.HandleFunc("/chgGovBoot", requireRole(RolePeer, chgGovBoot))
+	mux.HandleFunc("/govBootNotify", requireRole(RolePeer, govBootNotify))
+	mux.HandleFunc("/admin/usage", requireRole(RoleOperator, handleAdminUsage))
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/config", handleConfig)
+	mux.HandleFunc("/ws/events", handleWSEvents)
+	mux.HandleFunc("/events", handleSSEEvents)
 
 	mux.Handle("/", http.FileServer(http.Dir("./static")))
 
 	// 5) 앵커 서명을 위한 key pair 생성
 	ensureKeyPair()
 
-	// 6) 서버 시작 (REST 요청 수신 가능한 상태로 돌입)
+	// 6) 서버 시작 (REST 요청 수신 가능한 상태로 돌입, 모든 요청은 사용량 집계)
+	go startUsageFlusher()
 	go func() {
 		log.Println("[START] NODE Running on", addr)
-		if err := http.ListenAndServe(addr, mux); err != nil {
+		if err := http.ListenAndServe(addr, withUsageMetering(withAPIVersion(mux))); err != nil {
 			log.Fatal(err)
 		}
 	}()
 
+	// 설정 파일에 지정된 고정 피어 등록
+	for _, p := range cfg.Peers {
+		if p = strings.TrimSpace(p); p != self {
+			addPeerInternal(p)
+		}
+	}
+
 	// 7) 자동 부트스트랩
 	//  부트노드가 아니라면 부트노드에 자신의 주소를 등록 -> 부트노드로부터 노드 주소 목록 받아 등록 -> 체인 동기화
 	if boot != "" && self != "" && boot != self {
 		payload := map[string]string{"addr": self, "hos_id": hosID}
 		b, _ := json.Marshal(payload)
 
-		resp, err := http.Post("http://"+boot+"/register", "application/json", strings.NewReader(string(b)))
+		resp, err := peerClient.Post("http://"+boot+"/register", "application/json", strings.NewReader(string(b)))
 		if err != nil {
 			log.Printf("[BOOT] register failed: %v", err)
 			return
@@ -123,6 +151,11 @@ func main() {
 		startMiningWatcher()
 	}()
 
+	go func() {
+		log.Printf("[WATCHER] starting anchor retry queue (backoff %d..%ds)", AnchorRetryBase, AnchorRetryMax)
+		startAnchorQueueWatcher()
+	}()
+
 	//go func() {
 	//	log.Printf("[WATCHER] starting unified chain watcher (%ds interval)", ChainWatcherTime)
 	//	startChainWatcher()
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"merkle"
 )
 
 // -----------------------------------------------------------------------------
@@ -49,21 +51,48 @@ func validateLowerBlock(newBlk, prevBlk LowerBlock) error {
 	for i, r := range newBlk.Entries {
 		leaf[i] = hashClinicRecord(r)
 	}
-	expectedRoot := merkleRootHex(leaf)
+	expectedRoot := merkle.Root(leaf)
 	if expectedRoot != newBlk.MerkleRoot {
 		return fmt.Errorf("merkle_root mismatch")
 	}
-	// 5) BlockHash 재계산
-	blockHash := newBlk.BlockHash
+	// 5) BlockHash 재계산 (제어 메시지 해시 포함)
+	blockHash := computeHashForPoW(PoWHeader{
+		Index:       newBlk.Index,
+		PrevHash:    newBlk.PrevHash,
+		MerkleRoot:  newBlk.MerkleRoot,
+		Timestamp:   newBlk.Timestamp,
+		Difficulty:  newBlk.Difficulty,
+		ExtraNonce:  newBlk.ExtraNonce,
+		ParamsHash:  newBlk.DifficultyParams.hash(),
+		Nonce:       newBlk.Nonce,
+		ControlHash: controlHash(newBlk.Control),
+	})
 	if blockHash != newBlk.BlockHash {
 		return fmt.Errorf("block_hash mismatch")
 	}
 
-	// 6) PoW 난이도 검증
+	// 6) PoW 난이도 검증 (난이도 값 자체도 장부 이력으로부터 계산한 값과 일치해야 함)
+	want, err := expectedDifficulty(prevBlk.Index)
+	if err != nil {
+		return err
+	}
+	if newBlk.Difficulty != want {
+		return fmt.Errorf("unexpected difficulty: want=%d got=%d", want, newBlk.Difficulty)
+	}
 	if !validHash(blockHash, newBlk.Difficulty) {
 		return fmt.Errorf("pow difficulty not satisfied (hash=%s diff=%d)",
 			blockHash, newBlk.Difficulty)
 	}
+	// 7) 제어 메시지 검증
+	if newBlk.Control != nil {
+		if err := verifyControl(newBlk.Control); err != nil {
+			return fmt.Errorf("invalid control: %w", err)
+		}
+	}
+	// 8) 타임스탬프 규칙 (median-time-past, 미래 시각 허용 범위)
+	if err := checkTimestampRule(newBlk, prevBlk); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -73,11 +102,10 @@ func validateLowerBlock(newBlk, prevBlk LowerBlock) error {
 // - 원격 total > 로컬 total : 로컬 height+1 부터 순서대로 검증/append
 // -----------------------------------------------------------------------------
 type blocksPage struct {
-	Total      int          `json:"total"`
-	Offset     int          `json:"offset"`
-	Limit      int          `json:"limit"`
-	Items      []LowerBlock `json:"items"`
-	Difficulty int          `json:"difficulty"`
+	Total  int          `json:"total"`
+	Offset int          `json:"offset"`
+	Limit  int          `json:"limit"`
+	Items  []LowerBlock `json:"items"`
 }
 
 // 입력받은 주소의 노드에게 장부 정보를 제공받는 함수
@@ -85,7 +113,7 @@ func syncChain(peer string) {
 	url := "http://" + peer + "/blocks"
 
 	// 원격에서 전체 블록 수신
-	resp, err := http.Get(url)
+	resp, err := peerClient.Get(url)
 	if err != nil {
 		log.Printf("[P2P] Failed to sync from %s: %v\n", peer, err)
 		return
@@ -100,6 +128,7 @@ func syncChain(peer string) {
 
 	remoteTotal := page.Total
 	appended := 0
+	observePeerHeight(peer, remoteTotal-1)
 
 	// 로컬 상태
 	chainMu.Lock()
@@ -111,12 +140,6 @@ func syncChain(peer string) {
 		log.Printf("[P2P] No local blocks. Full sync from %s\n", peer)
 	}
 
-	// 난이도 변화 감지
-	if page.Difficulty > 0 && page.Difficulty != GlobalDifficulty {
-		log.Printf("[P2P] Difficulty update from peer=%s -> %d", peer, page.Difficulty)
-		GlobalDifficulty = page.Difficulty
-	}
-
 	// 원격이 최신보다 같거나 더 짧으면 필요 없음
 	if localH >= 0 && remoteTotal <= localH+1 {
 		log.Printf("[P2P] Up-to-date (local=%d, remote=%d)\n", localH+1, remoteTotal)
@@ -141,6 +164,7 @@ func syncChain(peer string) {
 				log.Printf("[P2P] Remote block invalid at #%d: %v\n", nb.Index, err)
 				return
 			}
+			nb.Elapsed = blockInterval(nb, prev) // 피어가 기록한 값 대신 타임스탬프 간격
 		} else {
 			log.Printf("[P2P] Fetching genesis from %s", peer)
 		}
@@ -162,11 +186,15 @@ func syncChain(peer string) {
 			return
 		}
 
+		recordControlSeq(nb.Control)
 		localH = nb.Index
 		appended++
 		chainMu.Unlock()
+		publishFinalizedAt(nb.Index)
 	}
 
+	// 난이도는 피어가 알려주는 값이 아닌, 동기화된 장부로부터 계산
+	refreshDifficulty()
 	log.Printf("[P2P] Chain synced from %s (+%d blocks, new height=%d)\n",
 		peer, appended, localH)
 }
@@ -175,7 +203,7 @@ func syncChain(peer string) {
 func addPeer(w http.ResponseWriter, r *http.Request) {
 	var addr string
 	if err := json.NewDecoder(r.Body).Decode(&addr); err != nil {
-		http.Error(w, "invalid peer format", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid peer format")
 		return
 	}
 	if addPeerInternal(addr) {
@@ -199,6 +227,7 @@ func addPeerInternal(addr string) bool {
 		return false
 	}
 	log.Printf("[P2P][ADD] peer added: %s | total=%d", addr, len(peers))
+	publishEvent(EventPeerJoined, map[string]any{"peer": addr, "total": len(peers)})
 
 	// 생존 상태 초기화
 	aliveMu.Lock()
@@ -248,6 +277,7 @@ func removePeer(addr string) {
 	aliveMu.Unlock()
 
 	log.Printf("[WATCHER] Dead Pear removed: %s", addr)
+	publishEvent(EventPeerLeft, map[string]any{"peer": addr, "total": len(peers)})
 }
 
 // 특정 노드 주소와 상태를 입력받아 기록
@@ -273,9 +303,10 @@ func startNetworkWatcher() {
 
 		for _, addr := range peersSnapshot() {
 			// 노드 별 상태 조사
-			_, ok := probeStatus(addr)
+			st, ok := probeStatus(addr)
 			if ok {
 				markAlive(addr, true)
+				observePeerHeight(addr, st.Height)
 				continue
 			}
 
@@ -316,6 +347,7 @@ func startChainWatcher() {
 			if !ok {
 				continue
 			}
+			observePeerHeight(p, st.Height)
 			// 높이가 최대인 노드를 탐색하여 주소, 높이, 해시 저장
 			if st.Height > bestHeight {
 				bestHeight = st.Height
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PoW 해시 규칙 (PoWHasher)
+// ------------------------------------------------------------
+// - 규칙 : SHA-256(헤더 JSON), 기존 블록 해시와 동일 (computeHashForPoW)
+// - 채굴 시 헤더 인코딩은 워커마다 한 번만 만들고 nonce 자리만 바꿔 씀 (nonce 시도마다 JSON 직렬화/할당 없음)
+//   · `..."nonce":` 까지와 nonce 뒤(`,"control_hash":...}` 또는 `}`)를 미리 만들어 두고 사이에 숫자만 채움
+////////////////////////////////////////////////////////////////////////////////
+
+type PoWHasher interface {
+	// 헤더 해시 (hex, 블록 검증용)
+	HeaderHash(h PoWHeader) string
+	// nonce 만 바꿔 가며 해시하는 탐색 함수 (워커마다 하나, 동시 사용 불가)
+	Searcher(h PoWHeader) func(nonce int) [32]byte
+}
+
+var powHasher PoWHasher = jsonPoWHasher{}
+
+// 헤더가 난이도(앞자리 0 의 hex 자릿수)를 만족하는지 (hex 변환 없이 바이트로 확인)
+func sumMeetsDifficulty(sum [32]byte, difficulty int) bool {
+	for i := 0; i < difficulty; i++ {
+		if i/2 >= len(sum) {
+			return false
+		}
+		b := sum[i/2]
+		if i%2 == 0 {
+			b >>= 4
+		}
+		if b&0x0F != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sha256-json : json.Marshal(PoWHeader) 와 같은 바이트
+type jsonPoWHasher struct{}
+
+func (jsonPoWHasher) HeaderHash(h PoWHeader) string {
+	data, _ := json.Marshal(h)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (jsonPoWHasher) Searcher(h PoWHeader) func(nonce int) [32]byte {
+	h.Nonce = 0
+	data, _ := json.Marshal(h)
+	cut := bytes.LastIndex(data, []byte(`"nonce":0`)) + len(`"nonce":`)
+	tail := append([]byte{}, data[cut+1:]...) // nonce 뒤 필드와 닫는 괄호
+	buf := make([]byte, cut, cut+24+len(tail))
+	copy(buf, data[:cut])
+	return func(nonce int) [32]byte {
+		b := strconv.AppendInt(buf, int64(nonce), 10)
+		b = append(b, tail...)
+		return sha256.Sum256(b)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Receipt (제출 접수증)
+// ------------------------------------------------------------
+// /mine 으로 레코드를 제출받은 노드가 발급하는 서명된 접수증
+// - 블록 포함이 지연되더라도, 클라이언트는 접수증으로 "시각 T에 제출했음"을 증명 가능
+// - 서명 검증은 발급 노드의 공개키(/getPublicKey)로 수행
+// - "receipt_<recordHash>" 키에 최초 접수증만 보관 (재제출 시 기존 접수증 반환)
+////////////////////////////////////////////////////////////////////////////////
+
+type Receipt struct {
+	RecordHash string `json:"record_hash"` // hashClinicRecord 결과 (tx ID와 동일)
+	ReceivedAt string `json:"received_at"` // 노드가 레코드를 접수한 시각 (RFC3339)
+	NodeID     string `json:"node_id"`     // 접수한 노드 (hos_id@addr)
+	Sig        string `json:"sig"`         // 노드 ECDSA 서명 (hex, DER)
+}
+
+// 서명 대상 다이제스트 (서명 필드 제외)
+func (rc Receipt) digest() []byte {
+	body := struct {
+		RecordHash string `json:"record_hash"`
+		ReceivedAt string `json:"received_at"`
+		NodeID     string `json:"node_id"`
+	}{rc.RecordHash, rc.ReceivedAt, rc.NodeID}
+	sum := sha256.Sum256(jsonCanonical(body))
+	return sum[:]
+}
+
+// 노드 개인키 로드
+func loadNodePrivKey() (*ecdsa.PrivateKey, error) {
+	privPem, ok := getMeta("meta_hos_privkey")
+	if !ok {
+		return nil, fmt.Errorf("private key not found")
+	}
+	block, _ := pem.Decode([]byte(privPem))
+	if block == nil {
+		return nil, fmt.Errorf("invalid private key pem")
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+// 레코드별 접수증 발급 (이미 발급된 레코드는 기존 접수증 반환)
+func issueReceipts(entries []ClinicRecord) ([]Receipt, error) {
+	priv, err := loadNodePrivKey()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Unix(time.Now().Unix(), 0).Format(time.RFC3339)
+	nodeID := selfID() + "@" + self
+
+	out := make([]Receipt, 0, len(entries))
+	for _, rec := range entries {
+		h := hashClinicRecord(rec)
+		if prev, err := getReceipt(h); err == nil {
+			out = append(out, prev)
+			continue
+		}
+
+		rc := Receipt{RecordHash: h, ReceivedAt: now, NodeID: nodeID}
+		sig, err := ecdsa.SignASN1(rand.Reader, priv, rc.digest())
+		if err != nil {
+			return nil, fmt.Errorf("sign receipt: %w", err)
+		}
+		rc.Sig = hex.EncodeToString(sig)
+
+		data, _ := json.Marshal(rc)
+		if err := db.Put([]byte("receipt_"+h), data, nil); err != nil {
+			return nil, fmt.Errorf("save receipt: %w", err)
+		}
+		out = append(out, rc)
+	}
+	return out, nil
+}
+
+func getReceipt(recordHash string) (Receipt, error) {
+	data, err := db.Get([]byte("receipt_"+recordHash), nil)
+	if err != nil {
+		return Receipt{}, err
+	}
+	var rc Receipt
+	if err := json.Unmarshal(data, &rc); err != nil {
+		return Receipt{}, err
+	}
+	return rc, nil
+}
+
+// 접수증 재조회
+// GET /receipts/<recordHash>
+func handleGetReceipt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	h := strings.TrimPrefix(r.URL.Path, "/receipts/")
+	if h == "" {
+		writeError(w, http.StatusBadRequest, "record hash required")
+		return
+	}
+	rc, err := getReceipt(h)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "receipt not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, rc)
+}
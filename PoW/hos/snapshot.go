@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Read Snapshot (조회 경로의 스냅샷 격리)
+// ------------------------------------------------------------
+// - 여러 키를 읽는 조회(검색+증명, 블록 페이지네이션)는 LevelDB 스냅샷 하나에서 수행
+//   · 조회 도중 새 블록이 커밋되어도 색인/블록 본문/최신 높이·루트가 서로 어긋나지 않음
+// - 스냅샷은 chainMu 를 잠깐 잡은 상태에서 생성
+//   · 블록 반영(블록 본문·색인·높이 기록)은 chainMu 안에서만 일어나므로 항상 블록 경계에서 찍힘
+//   · 스냅샷 생성 후에는 락 없이 읽으므로 조회가 블록 반영을 막지 않음
+// - 주의 : chainMu 를 이미 잡은 코드에서 withReadSnapshot 호출 금지 (교착)
+////////////////////////////////////////////////////////////////////////////////
+
+// *leveldb.DB 와 *leveldb.Snapshot 공통 읽기 인터페이스
+type dbReader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+}
+
+// 블록 경계에서 찍은 스냅샷으로 fn 실행 (fn 반환 후 스냅샷 해제)
+func withReadSnapshot(fn func(rd dbReader) error) error {
+	chainMu.Lock()
+	snap, err := db.GetSnapshot()
+	chainMu.Unlock()
+	if err != nil {
+		return countDBError(err)
+	}
+	defer snap.Release()
+	return fn(snap)
+}
@@ -26,7 +26,7 @@ var db *leveldb.DB
 
 // ---- 내부 메타키 헬퍼 ---------------------------------------------------------
 func putMeta(key, val string) error {
-	return db.Put([]byte(key), []byte(val), nil)
+	return countDBError(db.Put([]byte(key), []byte(val), nil))
 }
 func getMeta(key string) (string, bool) {
 	v, err := db.Get([]byte(key), nil)
@@ -37,8 +37,11 @@ func getMeta(key string) (string, bool) {
 }
 
 func getLatestHeight() (int, bool) {
-	if s, ok := getMeta("height_latest"); ok {
-		h, err := strconv.Atoi(s)
+	return getLatestHeightFrom(db)
+}
+func getLatestHeightFrom(rd dbReader) (int, bool) {
+	if v, err := rd.Get([]byte("height_latest"), nil); err == nil {
+		h, err := strconv.Atoi(string(v))
 		if err == nil {
 			return h, true
 		}
@@ -83,13 +86,13 @@ func saveBlockToDB(block LowerBlock) error {
 
 	// 블록 번호 기반 저장
 	keyByIndex := fmt.Sprintf("block_%d", block.Index)
-	if err := db.Put([]byte(keyByIndex), data, nil); err != nil {
+	if err := countDBError(db.Put([]byte(keyByIndex), data, nil)); err != nil {
 		return err
 	}
 
 	// 블록 해시 기반 저장
 	keyByHash := fmt.Sprintf("hash_%s", block.BlockHash)
-	if err := db.Put([]byte(keyByHash), data, nil); err != nil {
+	if err := countDBError(db.Put([]byte(keyByHash), data, nil)); err != nil {
 		return err
 	}
 
@@ -104,10 +107,13 @@ func saveBlockToDB(block LowerBlock) error {
 
 // 인덱스로 블록 조회
 func getBlockByIndex(index int) (LowerBlock, error) {
+	return getBlockByIndexFrom(db, index)
+}
+func getBlockByIndexFrom(rd dbReader, index int) (LowerBlock, error) {
 	key := fmt.Sprintf("block_%d", index)
-	data, err := db.Get([]byte(key), nil)
+	data, err := rd.Get([]byte(key), nil)
 	if err != nil {
-		return LowerBlock{}, err
+		return LowerBlock{}, countDBError(err)
 	}
 	var block LowerBlock
 	if err := json.Unmarshal(data, &block); err != nil {
@@ -132,7 +138,10 @@ func getBlockByHash(hash string) (LowerBlock, error) {
 
 // 최신 루트 캐시 조회(없으면 빈 문자열)
 func getLatestRoot() string {
-	if v, err := db.Get([]byte("root_latest"), nil); err == nil {
+	return getLatestRootFrom(db)
+}
+func getLatestRootFrom(rd dbReader) string {
+	if v, err := rd.Get([]byte("root_latest"), nil); err == nil {
 		return string(v)
 	}
 	return ""
@@ -179,6 +188,11 @@ func updateIndicesForBlock(block LowerBlock) error {
 		}
 	}
 
+	// 4) 트랜잭션 상태 색인 : "tx_<leafHash>" -> mined
+	if err := markTxMined(block); err != nil {
+		return err
+	}
+
 	log.Printf("[DB] Indices updated for Block #%d (%d entries)\n",
 		block.Index, len(block.Entries))
 	return nil
@@ -234,10 +248,19 @@ func getBlockByClinic(keyword string) (LowerBlock, error) {
 
 // 전체 블록 조회
 func listAllBlocks() ([]LowerBlock, error) {
-	h, ok := getLatestHeight()
+	var out []LowerBlock
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		out, err = listAllBlocksFrom(rd)
+		return err
+	})
+	return out, err
+}
+func listAllBlocksFrom(rd dbReader) ([]LowerBlock, error) {
+	h, ok := getLatestHeightFrom(rd)
 	if !ok {
 		// 제네시스만 있을 수도 있으니 0만 확인
-		b0, err := getBlockByIndex(0)
+		b0, err := getBlockByIndexFrom(rd, 0)
 		if err != nil {
 			return nil, fmt.Errorf("no chain: %w", err)
 		}
@@ -245,7 +268,7 @@ func listAllBlocks() ([]LowerBlock, error) {
 	}
 	out := make([]LowerBlock, 0, h+1)
 	for i := 0; i <= h; i++ {
-		b, err := getBlockByIndex(i)
+		b, err := getBlockByIndexFrom(rd, i)
 		if err != nil {
 			return nil, fmt.Errorf("load block_%d: %w", i, err)
 		}
@@ -255,14 +278,25 @@ func listAllBlocks() ([]LowerBlock, error) {
 }
 
 // offset에서 최대 limit개 반환, total(=height+1)도 함께 반환
+// - total 과 블록 목록은 같은 스냅샷에서 읽음 (snapshot.go)
 func listBlocksPaginated(offset, limit int) ([]LowerBlock, int, error) {
 	if offset < 0 || limit <= 0 {
 		return nil, 0, fmt.Errorf("invalid offset/limit")
 	}
-	h, ok := getLatestHeight()
+	var out []LowerBlock
+	total := 0
+	err := withReadSnapshot(func(rd dbReader) error {
+		var err error
+		out, total, err = listBlocksPaginatedFrom(rd, offset, limit)
+		return err
+	})
+	return out, total, err
+}
+func listBlocksPaginatedFrom(rd dbReader, offset, limit int) ([]LowerBlock, int, error) {
+	h, ok := getLatestHeightFrom(rd)
 	if !ok {
 		// 제네시스만 있는지 확인
-		if _, err := getBlockByIndex(0); err != nil {
+		if _, err := getBlockByIndexFrom(rd, 0); err != nil {
 			return nil, 0, fmt.Errorf("no chain: %w", err)
 		}
 		h = 0
@@ -277,7 +311,7 @@ func listBlocksPaginated(offset, limit int) ([]LowerBlock, int, error) {
 	}
 	out := make([]LowerBlock, 0, end-offset+1)
 	for i := offset; i <= end; i++ {
-		b, err := getBlockByIndex(i)
+		b, err := getBlockByIndexFrom(rd, i)
 		if err != nil {
 			return nil, total, fmt.Errorf("load block_%d: %w", i, err)
 		}
@@ -340,3 +374,92 @@ func resetLocalDB() error {
 	log.Printf("[CHAIN] Local chain RESET complete ")
 	return nil
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// 트랜잭션 상태 색인
+//  - tx ID = 제출된 ClinicRecord의 해시(hashClinicRecord, 블록의 leaf hash와 동일)
+//  - "tx_<id>" => TxStatus JSON
+//  - 상태: pending(메모리풀 대기) -> mined(블록 확정) / orphaned(채굴에 사용됐으나 블록에 미포함)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	TxPending  = "pending"
+	TxMined    = "mined"
+	TxOrphaned = "orphaned"
+)
+
+type TxStatus struct {
+	TxID       string `json:"tx_id"`
+	Status     string `json:"status"`
+	BlockIndex int    `json:"block_index"` // mined 상태일 때만 유효 (그 외 -1)
+	EntryIndex int    `json:"entry_index"` // mined 상태일 때만 유효 (그 외 -1)
+	BlockHash  string `json:"block_hash,omitempty"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+func saveTxStatus(st TxStatus) error {
+	st.UpdatedAt = time.Unix(time.Now().Unix(), 0).Format(time.RFC3339)
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return db.Put([]byte("tx_"+st.TxID), data, nil)
+}
+
+func getTxStatus(txID string) (TxStatus, error) {
+	data, err := db.Get([]byte("tx_"+txID), nil)
+	if err != nil {
+		return TxStatus{}, err
+	}
+	var st TxStatus
+	if err := json.Unmarshal(data, &st); err != nil {
+		return TxStatus{}, err
+	}
+	return st, nil
+}
+
+// 메모리풀에 접수된 레코드들을 pending 상태로 기록하고 tx ID 목록 반환
+// (이미 mined 된 레코드는 상태를 되돌리지 않음)
+func markTxPending(entries []ClinicRecord) []string {
+	ids := make([]string, 0, len(entries))
+	for _, rec := range entries {
+		id := hashClinicRecord(rec)
+		ids = append(ids, id)
+		if st, err := getTxStatus(id); err == nil && st.Status == TxMined {
+			continue
+		}
+		if err := saveTxStatus(TxStatus{TxID: id, Status: TxPending, BlockIndex: -1, EntryIndex: -1}); err != nil {
+			log.Printf("[DB][TX] failed to save pending status for %s: %v", id, err)
+		}
+	}
+	return ids
+}
+
+// 블록에 포함된 레코드들을 mined 상태로 기록
+func markTxMined(block LowerBlock) error {
+	for ei, rec := range block.Entries {
+		st := TxStatus{
+			TxID:       hashClinicRecord(rec),
+			Status:     TxMined,
+			BlockIndex: block.Index,
+			EntryIndex: ei,
+			BlockHash:  block.BlockHash,
+		}
+		if err := saveTxStatus(st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 채굴에 사용됐지만 확정 블록에 포함되지 않은 레코드들을 orphaned 상태로 기록
+func markTxOrphaned(txIDs []string) {
+	for _, id := range txIDs {
+		if st, err := getTxStatus(id); err == nil && st.Status == TxMined {
+			continue
+		}
+		if err := saveTxStatus(TxStatus{TxID: id, Status: TxOrphaned, BlockIndex: -1, EntryIndex: -1}); err != nil {
+			log.Printf("[DB][TX] failed to save orphaned status for %s: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// API 사용량 집계 (API 키별 부하 분석)
+// ------------------------------------------------------------
+// - 모든 요청을 호출자(API 키 ID / JWT sub, 미인증은 "anonymous")와 엔드포인트(라우팅 패턴) 단위로 집계
+//   · 요청 수, 오류 수(4xx/5xx), 응답 바이트, 지연시간 히스토그램
+// - 메모리에 누적한 증분을 UsageFlushInterval 마다 LevelDB에 일 단위로 합산 저장
+//   · Key: "usage_<YYYY-MM-DD>_<keyID>" => {endpoint: UsageStat}
+// - GET /admin/usage?key=&day= 로 조회 (operator 전용, day 기본값 = 오늘(UTC))
+////////////////////////////////////////////////////////////////////////////////
+
+const UsageFlushInterval = 30 // 초
+
+// 지연시간 히스토그램 구간 상한(ms), 마지막 칸은 초과분
+var usageLatencyBuckets = []int64{5, 10, 25, 50, 100, 250, 500, 1000, 2500}
+
+type UsageStat struct {
+	Requests  int64   `json:"requests"`
+	Errors    int64   `json:"errors"`
+	Bytes     int64   `json:"bytes"`
+	TotalMs   int64   `json:"total_ms"`
+	LatencyMs []int64 `json:"latency_ms"` // usageLatencyBuckets 구간별 건수 (+ 초과 구간)
+}
+
+func (s *UsageStat) merge(o *UsageStat) {
+	s.Requests += o.Requests
+	s.Errors += o.Errors
+	s.Bytes += o.Bytes
+	s.TotalMs += o.TotalMs
+	if len(s.LatencyMs) < len(o.LatencyMs) {
+		s.LatencyMs = append(s.LatencyMs, make([]int64, len(o.LatencyMs)-len(s.LatencyMs))...)
+	}
+	for i, v := range o.LatencyMs {
+		s.LatencyMs[i] += v
+	}
+}
+
+// day => keyID => endpoint => 아직 저장되지 않은 증분
+type usageDelta map[string]map[string]map[string]*UsageStat
+
+var (
+	usagePending = usageDelta{}
+	usageMu      sync.Mutex
+)
+
+// 응답 상태/바이트 기록용 ResponseWriter
+type meteredWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (m *meteredWriter) WriteHeader(code int) {
+	m.status = code
+	m.ResponseWriter.WriteHeader(code)
+}
+
+func (m *meteredWriter) Write(b []byte) (int, error) {
+	n, err := m.ResponseWriter.Write(b)
+	m.bytes += int64(n)
+	return n, err
+}
+
+// 이벤트 스트림(SSE Flush, WebSocket Hijack)이 원본 ResponseWriter 에 접근할 수 있도록 노출
+func (m *meteredWriter) Unwrap() http.ResponseWriter {
+	return m.ResponseWriter
+}
+
+// 전체 라우터를 감싸 요청별 사용량 기록
+func withUsageMetering(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		mw := &meteredWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(mw, r)
+
+		keyID := "anonymous"
+		if cred := requestCredential(r); cred != "" {
+			if p, err := authenticate(cred); err == nil {
+				keyID = p.ID
+			}
+		}
+		endpoint := r.Pattern // ServeMux가 매칭한 패턴 (/tx/<id> 등은 "/tx/" 로 묶임)
+		if endpoint == "" {
+			endpoint = r.URL.Path
+		}
+		recordUsage(keyID, endpoint, mw.status, mw.bytes, time.Since(start))
+	})
+}
+
+func recordUsage(keyID, endpoint string, status int, bytes int64, elapsed time.Duration) {
+	day := time.Now().UTC().Format("2006-01-02")
+	ms := elapsed.Milliseconds()
+
+	usageMu.Lock()
+	defer usageMu.Unlock()
+	byKey, ok := usagePending[day]
+	if !ok {
+		byKey = map[string]map[string]*UsageStat{}
+		usagePending[day] = byKey
+	}
+	byEp, ok := byKey[keyID]
+	if !ok {
+		byEp = map[string]*UsageStat{}
+		byKey[keyID] = byEp
+	}
+	st, ok := byEp[endpoint]
+	if !ok {
+		st = &UsageStat{LatencyMs: make([]int64, len(usageLatencyBuckets)+1)}
+		byEp[endpoint] = st
+	}
+	st.Requests++
+	if status >= 400 {
+		st.Errors++
+	}
+	st.Bytes += bytes
+	st.TotalMs += ms
+	bucket := len(usageLatencyBuckets)
+	for i, ub := range usageLatencyBuckets {
+		if ms <= ub {
+			bucket = i
+			break
+		}
+	}
+	st.LatencyMs[bucket]++
+}
+
+func usageKey(day, keyID string) string {
+	return "usage_" + day + "_" + keyID
+}
+
+func loadUsage(day, keyID string) map[string]*UsageStat {
+	out := map[string]*UsageStat{}
+	if v, ok := getMeta(usageKey(day, keyID)); ok {
+		_ = json.Unmarshal([]byte(v), &out)
+	}
+	return out
+}
+
+// 메모리 증분을 LevelDB 일 단위 집계에 합산
+func flushUsage() {
+	usageMu.Lock()
+	pending := usagePending
+	usagePending = usageDelta{}
+	usageMu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	batch := new(leveldb.Batch)
+	for day, byKey := range pending {
+		for keyID, byEp := range byKey {
+			stored := loadUsage(day, keyID)
+			for ep, d := range byEp {
+				if s, ok := stored[ep]; ok {
+					s.merge(d)
+				} else {
+					stored[ep] = d
+				}
+			}
+			data, _ := json.Marshal(stored)
+			batch.Put([]byte(usageKey(day, keyID)), data)
+		}
+	}
+	if err := db.Write(batch, nil); err != nil {
+		log.Printf("[USAGE][ERROR] flush failed: %v", err)
+	}
+}
+
+// 주기적 저장 루틴
+func startUsageFlusher() {
+	ticker := time.NewTicker(UsageFlushInterval * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		flushUsage()
+	}
+}
+
+// API 사용량 조회 (operator 전용)
+// GET /admin/usage?key=<keyID>&day=<YYYY-MM-DD>
+// - key 생략 시 해당 일자의 전체 키 반환
+func handleAdminUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	day := r.URL.Query().Get("day")
+	if day == "" {
+		day = time.Now().UTC().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", day); err != nil {
+		writeError(w, http.StatusBadRequest, "day must be YYYY-MM-DD")
+		return
+	}
+	key := r.URL.Query().Get("key")
+
+	// 최신 값을 보여주기 위해 대기 중인 증분을 먼저 저장
+	flushUsage()
+
+	keys := []string{key}
+	if key == "" {
+		keys = keys[:0]
+		prefix := "usage_" + day + "_"
+		iter := db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+		for iter.Next() {
+			keys = append(keys, strings.TrimPrefix(string(iter.Key()), prefix))
+		}
+		iter.Release()
+		sort.Strings(keys)
+	}
+
+	out := make(map[string]map[string]*UsageStat, len(keys))
+	for _, k := range keys {
+		out[k] = loadUsage(day, k)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"day":            day,
+		"latency_bucket": usageLatencyBuckets,
+		"usage":          out,
+	})
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// API Versioning (/v1 경로 + 기존 경로 호환)
+// ------------------------------------------------------------
+// - 모든 엔드포인트는 /v1/<기존 경로> 로도 호출 가능 (예: /v1/search, /v1/blocks)
+// - 기존 경로(버전 없음)는 당분간 그대로 동작하되 폐기 예정 헤더를 붙임
+//   · Deprecation: true
+//   · Sunset: 설정 legacy_sunset (API_LEGACY_SUNSET, RFC3339) 의 HTTP-date
+//   · Link: </v1/<path>>; rel="successor-version"
+//   (정적 대시보드 파일은 제외)
+// - GET /v1/meta : 이 노드가 지원하는 API 버전/기능 목록 (SDK, 피어가 기능 확인용)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	APIVersion          = "v1"
+	LegacySunsetDefault = "2027-06-30T00:00:00Z"
+)
+
+// 이 노드 빌드가 제공하는 기능 목록
+var nodeFeatures = []string{
+	"search", "inclusion", "pow", "finality", "gossip", "receipts",
+	"auth", "usage", "anchor_queue", "events", "config", "chain_id_header",
+}
+
+// /v1 접두어 처리 + 기존 경로 폐기 예정 헤더
+func withAPIVersion(mux *http.ServeMux) http.Handler {
+	sunset := ""
+	if t, err := time.Parse(time.RFC3339, cfg.LegacySunset); err == nil {
+		sunset = t.UTC().Format(http.TimeFormat)
+	}
+	v1 := http.StripPrefix("/"+APIVersion, mux)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		if p == "/"+APIVersion+"/meta" {
+			handleAPIMeta(w, r)
+			return
+		}
+		if strings.HasPrefix(p, "/"+APIVersion+"/") {
+			v1.ServeHTTP(w, r)
+			return
+		}
+		if _, pattern := mux.Handler(r); pattern != "/" {
+			w.Header().Set("Deprecation", "true")
+			if sunset != "" {
+				w.Header().Set("Sunset", sunset)
+			}
+			w.Header().Set("Link", "</"+APIVersion+p+`>; rel="successor-version"`)
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// GET /v1/meta
+func handleAPIMeta(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"api_versions":  []string{APIVersion},
+		"current":       APIVersion,
+		"legacy_sunset": cfg.LegacySunset,
+		"node_role":     metricsNodeRole,
+		"chain_id":      selfID(),
+		"node":          self,
+		"features":      nodeFeatures,
+	})
+}
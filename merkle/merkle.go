@@ -0,0 +1,86 @@
+// Package merkle 은 Hos/Gov 체인과 클라이언트가 함께 쓰는 이진 Merkle 트리 (루트, 포함 증명, 검증).
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Merkle 트리 (모든 체인 계층 공용)
+// ------------------------------------------------------------
+// - leaf   : hex 문자열 (레코드/블록 해시 등, 호출 측이 계산)
+// - 부모   : sha256(왼쪽 raw 바이트 || 오른쪽 raw 바이트) => hex
+// - 패딩   : 레벨 원소 수가 홀수면 마지막 원소를 복제 (자기 자신과 결합)
+// - 빈 트리 : sha256("") / leaf 1개 : leaf 그대로
+// - 증명   : [][2]string{방향, 형제 해시}, "L" 이면 형제가 왼쪽, "R" 이면 오른쪽 (leaf 에서 루트 방향 순서)
+// - 교차 체인 적합성 벡터(고정 루트/증명)는 merkle_test.go
+////////////////////////////////////////////////////////////////////////////////
+
+// 두 해시의 부모 해시 (hex 를 raw 바이트로 바꿔 이어 붙인 뒤 SHA-256)
+func PairHash(left, right string) string {
+	lb, _ := hex.DecodeString(left)
+	rb, _ := hex.DecodeString(right)
+	sum := sha256.Sum256(append(lb, rb...))
+	return hex.EncodeToString(sum[:])
+}
+
+// Merkle 루트
+func Root(leaves []string) string {
+	if len(leaves) == 0 {
+		sum := sha256.Sum256(nil)
+		return hex.EncodeToString(sum[:])
+	}
+	level := append([]string(nil), leaves...)
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+	return level[0]
+}
+
+// index 번째 leaf 의 포함 증명 (범위 밖이면 nil)
+func Proof(leaves []string, index int) [][2]string {
+	if index < 0 || index >= len(leaves) {
+		return nil
+	}
+	level := append([]string(nil), leaves...)
+	proof := make([][2]string, 0)
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		if index%2 == 0 {
+			proof = append(proof, [2]string{"R", level[index+1]})
+		} else {
+			proof = append(proof, [2]string{"L", level[index-1]})
+		}
+		level = nextLevel(level)
+		index /= 2
+	}
+	return proof
+}
+
+// 포함 증명 검증
+func Verify(leaf string, proof [][2]string, root string) bool {
+	cur := leaf
+	for _, p := range proof {
+		if p[0] == "L" {
+			cur = PairHash(p[1], cur)
+		} else {
+			cur = PairHash(cur, p[1])
+		}
+	}
+	return cur == root
+}
+
+// 한 레벨 위로 (홀수면 마지막 원소 복제)
+func nextLevel(level []string) []string {
+	if len(level)%2 == 1 {
+		level = append(level, level[len(level)-1])
+	}
+	next := make([]string, 0, len(level)/2)
+	for i := 0; i < len(level); i += 2 {
+		next = append(next, PairHash(level[i], level[i+1]))
+	}
+	return next
+}
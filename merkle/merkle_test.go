@@ -0,0 +1,68 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// 교차 체인 적합성 벡터
+// ------------------------------------------------------------
+// - leaf i = sha256("leaf-<i>") hex, leaf 개수별 기대 루트 (빈 트리, 1개, 짝수, 홀수 패딩)
+// - 규칙을 바꾸면 이 벡터도 함께 바꿔야 하며, 이는 증명 형식 변경이다 (모든 계층 동시 업그레이드 필요)
+////////////////////////////////////////////////////////////////////////////////
+
+var vectorRoots = map[int]string{
+	0: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	1: "d2dbf006f96dd05044a8f63d8f118f23925ba4cc5750f8b6c8e287fd506c8188",
+	2: "8b0f563106070048a1057926820c7118dec20b8a73715544f4528487c16dc0d7",
+	3: "39313694557e76d28b720ad7f4481cb144c24c8341f8a68fc4a8363fcd1a04bb",
+	5: "3ad4abec5d43ae09f5275cf7ce77d8615e1e87164b255aa7661e237b1982a5bf",
+	7: "7455b3f1f5709720dcbe8ba0a4e4c4853d798ad08b119ebce8b212477c422ecd",
+}
+
+// 5개 트리에서 마지막 leaf(홀수 패딩 경로)의 증명
+var vectorProof = [][2]string{
+	{"R", "697f943b9ec5f90eddda8ae7473f5eb688187e3467f312fefa8677dde255042c"},
+	{"R", "b0e59ca828e696f7989603fea45e4ab2bf0667151f71599738ecd55b6e99aa9b"},
+	{"L", "476c4a255bbaa3fa397182c77cb1bc85be71aa10349349f67e5c2bdd0453bfa0"},
+}
+
+func vectorLeaves(n int) []string {
+	leaves := make([]string, n)
+	for i := range leaves {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("leaf-%d", i)))
+		leaves[i] = hex.EncodeToString(sum[:])
+	}
+	return leaves
+}
+
+// 루트 + 모든 leaf 의 증명 생성/검증
+func TestVectorRoots(t *testing.T) {
+	for n, want := range vectorRoots {
+		leaves := vectorLeaves(n)
+		if got := Root(leaves); got != want {
+			t.Errorf("merkle root of %d leaves: got %s want %s", n, got, want)
+			continue
+		}
+		for i, leaf := range leaves {
+			if !Verify(leaf, Proof(leaves, i), want) {
+				t.Errorf("merkle proof of leaf %d/%d does not verify", i, n)
+			}
+		}
+	}
+}
+
+// 홀수 패딩 경로의 증명 형식
+func TestVectorProof(t *testing.T) {
+	leaves := vectorLeaves(5)
+	if got := Proof(leaves, 4); !reflect.DeepEqual(got, vectorProof) {
+		t.Fatalf("merkle proof of leaf 4/5: got %v want %v", got, vectorProof)
+	}
+	if Verify(leaves[0], vectorProof, vectorRoots[5]) {
+		t.Fatal("merkle proof verified for the wrong leaf")
+	}
+}
@@ -0,0 +1,55 @@
+package merkle
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+// 증명 형식 버전 (proof_version)
+// ------------------------------------------------------------
+// - 증명을 만드는 쪽(Hos 검색/증명 응답, 앵커 제출, Gov 앵커 증명)이 proof_version 을 함께 보냄
+// - 검증하는 쪽은 VerifyVersion 으로 버전별 규칙을 선택, 현재 버전과 직전 버전까지 지원
+//   · 0 : proof_version 이 없던 이전 응답 (규칙은 1 과 같음)
+//   · 1 : 현재 규칙 (merkle.go)
+// - 규칙을 바꿀 때는 ProofVersion 을 올리고 VerifyVersion 에 새 규칙을 추가,
+//   MinProofVersion 은 직전 버전으로 올려 한 단계 이전 노드와의 교차 검증을 유지
+// - 각 노드는 /version 의 proof_versions 로 지원 범위를 알림 (요청 측은 ?proof_version= 으로 원하는 버전 지정)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ProofVersion    = 1 // 이 빌드가 만드는 증명 형식
+	MinProofVersion = 0 // 검증 가능한 가장 오래된 형식
+)
+
+// 검증 가능한 증명 형식 버전 목록
+func SupportedProofVersions() []int {
+	out := make([]int, 0, ProofVersion-MinProofVersion+1)
+	for v := MinProofVersion; v <= ProofVersion; v++ {
+		out = append(out, v)
+	}
+	return out
+}
+
+// 검증 가능한 버전인지
+func ProofVersionSupported(v int) bool {
+	return v >= MinProofVersion && v <= ProofVersion
+}
+
+// 버전별 규칙으로 포함 증명 검증 (지원하지 않는 버전은 오류)
+func VerifyVersion(version int, leaf string, proof [][2]string, root string) (bool, error) {
+	switch version {
+	case 0, 1:
+		return Verify(leaf, proof, root), nil
+	}
+	return false, fmt.Errorf("unsupported proof_version %d (supported %d..%d)", version, MinProofVersion, ProofVersion)
+}
+
+// 요청 측이 읽을 수 있는 최고 버전(?proof_version=)에 맞춰 응답할 버전 결정
+//   - 요청 버전이 현재보다 높으면 현재 버전 (요청 측은 직전 버전까지 읽을 수 있음)
+//   - 지원 범위 안이면 현재 버전 (0 과 1 은 같은 규칙이라 proof_version 을 모르는 이전 노드도 그대로 검증 가능,
+//     규칙이 달라지는 버전부터는 여기서 이전 형식으로 만들어야 함)
+//   - 지원 범위보다 낮으면 오류
+func NegotiateProofVersion(requested int) (int, error) {
+	if requested < MinProofVersion {
+		return 0, fmt.Errorf("unsupported proof_version %d (supported %d..%d)", requested, MinProofVersion, ProofVersion)
+	}
+	return ProofVersion, nil
+}
@@ -0,0 +1,3 @@
+module wsconn
+
+go 1.25
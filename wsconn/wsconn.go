@@ -0,0 +1,294 @@
+// Package wsconn 은 노드 이벤트 스트림(/ws/events)용 서버 측 WebSocket(RFC 6455) 연결.
+package wsconn
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// WebSocket 서버 연결 (Hos/Gov 노드 공용)
+// ------------------------------------------------------------
+// - Upgrade : 핸드셰이크 검증(GET, Upgrade/Connection 헤더, Sec-WebSocket-Key, 버전 13) 후 Hijack
+// - 송신 : WriteText / Ping (FIN=1, 서버 프레임은 마스킹 없음, 동시 호출 가능)
+// - 수신 : ReadMessage
+//   · 클라이언트 프레임은 반드시 마스킹되어야 함 => 마스킹 없는 프레임은 close(1002) 후 종료
+//   · 조각난 메시지(FIN=0 + continuation)를 하나의 메시지로 재조립, 조각 사이의 제어 프레임 처리
+//   · ping => pong 자동 응답, close => close 응답 후 io.EOF
+//   · RSV 비트, 잘못된 조각 순서, 125 바이트 초과/조각난 제어 프레임은 프로토콜 오류(1002)
+//   · 메시지 크기 MaxMessageSize 초과 시 close(1009), 텍스트가 UTF-8 이 아니면 close(1007)
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	MaxMessageSize = 1 << 20 // 재조립한 메시지 최대 크기 (바이트)
+
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+// 프레임 opcode
+const (
+	OpContinuation byte = 0x0
+	OpText         byte = 0x1
+	OpBinary       byte = 0x2
+	OpClose        byte = 0x8
+	OpPing         byte = 0x9
+	OpPong         byte = 0xA
+)
+
+// close 상태 코드
+const (
+	CloseNormal          = 1000
+	CloseProtocolError   = 1002
+	CloseInvalidPayload  = 1007
+	CloseMessageTooLarge = 1009
+)
+
+var ErrNotWebSocket = errors.New("not a websocket upgrade request")
+
+// 프로토콜 위반 (상대에게 보낸 close 상태 코드 포함)
+type ProtocolError struct {
+	Code   int
+	Reason string
+}
+
+func (e *ProtocolError) Error() string {
+	return fmt.Sprintf("websocket protocol error %d: %s", e.Code, e.Reason)
+}
+
+type Conn struct {
+	conn    net.Conn
+	brw     *bufio.ReadWriter
+	writeMu sync.Mutex
+	closed  bool // close 프레임 송신 여부 (writeMu 보호)
+}
+
+// 요청 헤더가 WebSocket 업그레이드인지 (아니면 호출 측이 SSE 등으로 응답)
+func IsUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// 핸드셰이크 검증 후 연결 인계 (실패 시 HTTP 오류 응답을 이미 쓴 상태로 오류 반환)
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if !IsUpgrade(r) {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" || !headerHasToken(r.Header, "Connection", "upgrade") {
+		http.Error(w, "bad websocket handshake", http.StatusBadRequest)
+		return nil, fmt.Errorf("bad websocket handshake")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("unsupported websocket version %q", r.Header.Get("Sec-WebSocket-Version"))
+	}
+	conn, brw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	fmt.Fprintf(brw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+		base64.StdEncoding.EncodeToString(sum[:]))
+	if err := brw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Conn{conn: conn, brw: brw}, nil
+}
+
+// "Connection: keep-alive, Upgrade" 처럼 쉼표로 구분된 헤더 값에 token 포함 여부
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 텍스트 메시지 송신
+func (c *Conn) WriteText(data []byte) error {
+	return c.writeFrame(OpText, data)
+}
+
+// ping 송신 (연결 생존 확인)
+func (c *Conn) Ping() error {
+	return c.writeFrame(OpPing, nil)
+}
+
+// close 프레임(CloseNormal) 송신 후 연결 종료
+func (c *Conn) Close() error {
+	_ = c.writeClose(CloseNormal, "")
+	return c.conn.Close()
+}
+
+// 다음 데이터 메시지 수신 (opcode 는 OpText 또는 OpBinary, 제어 프레임은 내부 처리)
+func (c *Conn) ReadMessage() (byte, []byte, error) {
+	var (
+		op  byte
+		msg []byte
+	)
+	for {
+		fin, fop, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, c.fail(err)
+		}
+		switch fop {
+		case OpPing:
+			if err := c.writeFrame(OpPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case OpPong:
+			continue
+		case OpClose:
+			_ = c.writeClose(CloseNormal, "")
+			return 0, nil, io.EOF
+		case OpContinuation:
+			if msg == nil {
+				return 0, nil, c.fail(&ProtocolError{CloseProtocolError, "continuation without a started message"})
+			}
+		case OpText, OpBinary:
+			if msg != nil {
+				return 0, nil, c.fail(&ProtocolError{CloseProtocolError, "new message before previous fragments finished"})
+			}
+			op, msg = fop, []byte{}
+		default:
+			return 0, nil, c.fail(&ProtocolError{CloseProtocolError, fmt.Sprintf("unknown opcode 0x%x", fop)})
+		}
+
+		if len(msg)+len(payload) > MaxMessageSize {
+			return 0, nil, c.fail(&ProtocolError{CloseMessageTooLarge, "message too large"})
+		}
+		msg = append(msg, payload...)
+		if !fin {
+			continue
+		}
+		if op == OpText && !utf8.Valid(msg) {
+			return 0, nil, c.fail(&ProtocolError{CloseInvalidPayload, "text message is not valid UTF-8"})
+		}
+		return op, msg, nil
+	}
+}
+
+// 프로토콜 위반이면 해당 상태 코드로 close 송신 후 연결 종료
+func (c *Conn) fail(err error) error {
+	var pe *ProtocolError
+	if errors.As(err, &pe) {
+		_ = c.writeClose(pe.Code, pe.Reason)
+		c.conn.Close()
+	}
+	return err
+}
+
+// 프레임 하나 수신 (마스킹 해제 포함)
+func (c *Conn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var hdr [2]byte
+	if _, err = io.ReadFull(c.brw, hdr[:]); err != nil {
+		return
+	}
+	fin = hdr[0]&0x80 != 0
+	op = hdr[0] & 0x0F
+	if hdr[0]&0x70 != 0 {
+		err = &ProtocolError{CloseProtocolError, "reserved bits set"}
+		return
+	}
+	if hdr[1]&0x80 == 0 {
+		err = &ProtocolError{CloseProtocolError, "client frame is not masked"}
+		return
+	}
+	n := uint64(hdr[1] & 0x7F)
+	if op >= OpClose && (!fin || n > 125) {
+		err = &ProtocolError{CloseProtocolError, "invalid control frame"}
+		return
+	}
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.brw, ext[:]); err != nil {
+			return
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.brw, ext[:]); err != nil {
+			return
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if n > MaxMessageSize {
+		err = &ProtocolError{CloseMessageTooLarge, "frame too large"}
+		return
+	}
+	var mask [4]byte
+	if _, err = io.ReadFull(c.brw, mask[:]); err != nil {
+		return
+	}
+	payload = make([]byte, n)
+	if _, err = io.ReadFull(c.brw, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return
+}
+
+// close 프레임 송신 (한 번만)
+func (c *Conn) writeClose(code int, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	payload = append(payload, reason...)
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.writeFrameLocked(OpClose, payload)
+}
+
+func (c *Conn) writeFrame(op byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	return c.writeFrameLocked(op, payload)
+}
+
+// 서버 프레임 (FIN=1, 마스킹 없음, writeMu 보유 상태에서 호출)
+func (c *Conn) writeFrameLocked(op byte, payload []byte) error {
+	hdr := []byte{0x80 | op}
+	switch n := len(payload); {
+	case n < 126:
+		hdr = append(hdr, byte(n))
+	case n <= 0xFFFF:
+		hdr = append(hdr, 126, 0, 0)
+		binary.BigEndian.PutUint16(hdr[2:], uint16(n))
+	default:
+		hdr = append(hdr, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(hdr[2:], uint64(n))
+	}
+	if _, err := c.brw.Write(hdr); err != nil {
+		return err
+	}
+	if _, err := c.brw.Write(payload); err != nil {
+		return err
+	}
+	return c.brw.Flush()
+}